@@ -17,6 +17,7 @@ package cli
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 	"regexp"
 	"strings"
 
@@ -93,10 +94,17 @@ func CreateMergeArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(NoCommitFlag, "", "Perform the merge and stop just before creating a merge commit. Note this will not prevent a fast-forward merge; use the --no-ff arg together with the --no-commit arg to prevent both fast-forwards and merge commits.")
 	ap.SupportsFlag(NoEditFlag, "", "Use an auto-generated commit message when creating a merge commit. The default for interactive CLI sessions is to open an editor.")
 	ap.SupportsString(AuthorParam, "", "author", "Specify an explicit author using the standard A U Thor {{.LessThan}}author@example.com{{.GreaterThan}} format.")
+	supportsMergeStrategies(ap)
 
 	return ap
 }
 
+// supportsMergeStrategies adds the merge strategy arguments shared by merge and pull to |ap|.
+func supportsMergeStrategies(ap *argparser.ArgParser) {
+	ap.SupportsString(StrategyParam, "s", "strategy", "Use the given merge strategy. Valid values are: recursive (default), which three-way merges every table, or ours, which keeps the current branch's version of every table and ignores all changes from the other branch.")
+	ap.SupportsString(StrategyOptionParam, "X", "option", "Pass the given option to the recursive merge strategy. Valid values are: ours or theirs, which resolve conflicting rows with the current branch's or the other branch's version of the row. Tables listed in the {{.EmphasisLeft}}dolt_merge_strategies{{.EmphasisRight}} table are merged with their listed strategy instead.")
+}
+
 func CreateRebaseArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithMaxArgs("rebase", 1)
 	ap.TooManyArgsErrorFunc = func(receivedArgs []string) error {
@@ -139,6 +147,7 @@ func CreateCloneArgParser() *argparser.ArgParser {
 	ap.SupportsString(dbfactory.AWSCredsProfile, "", "profile", "AWS profile to use.")
 	ap.SupportsString(dbfactory.OSSCredsFileParam, "", "file", "OSS credentials file.")
 	ap.SupportsString(dbfactory.OSSCredsProfile, "", "profile", "OSS profile to use.")
+	ap.SupportsString(dbfactory.EncryptionKeyFileParam, "", "file", "File holding the key the remote is encrypted with on the client.")
 	ap.SupportsString(UserFlag, "u", "user", "User name to use when authenticating with the remote. Gets password from the environment variable {{.EmphasisLeft}}DOLT_REMOTE_PASSWORD{{.EmphasisRight}}.")
 	ap.SupportsFlag(SingleBranchFlag, "", "Clone only the history leading to the tip of a single branch, either specified by --branch or the remote's HEAD (default).")
 	return ap
@@ -213,6 +222,7 @@ func CreatePullArgParser() *argparser.ArgParser {
 	ap.SupportsString(UserFlag, "", "user", "User name to use when authenticating with the remote. Gets password from the environment variable {{.EmphasisLeft}}DOLT_REMOTE_PASSWORD{{.EmphasisRight}}.")
 	ap.SupportsFlag(PruneFlag, "p", "After fetching, remove any remote-tracking references that don't exist on the remote.")
 	ap.SupportsFlag(SilentFlag, "", "Suppress progress information.")
+	supportsMergeStrategies(ap)
 	return ap
 }
 
@@ -389,6 +399,19 @@ func AddOSSParams(remoteUrl string, apr *argparser.ArgParseResults, params map[s
 	return nil
 }
 
+// AddEncryptionParams adds the absolute path of the key file of a remote which is encrypted on the client, if one was
+// given, to |params|.
+func AddEncryptionParams(apr *argparser.ArgParseResults, params map[string]string) error {
+	if val, ok := apr.GetValue(dbfactory.EncryptionKeyFileParam); ok {
+		path, err := filepath.Abs(val)
+		if err != nil {
+			return err
+		}
+		params[dbfactory.EncryptionKeyFileParam] = path
+	}
+	return nil
+}
+
 func VerifyNoAwsParams(apr *argparser.ArgParseResults) error {
 	if awsParams := apr.GetValues(awsParams...); len(awsParams) > 0 {
 		awsParamKeys := make([]string, 0, len(awsParams))
@@ -0,0 +1,750 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/gocraft/dbr/v2"
+	"github.com/google/uuid"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+const (
+	checkFlag = "check"
+)
+
+var applyDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Apply a patch to the working set",
+	LongDesc: `Applies a patch written by {{.EmphasisLeft}}dolt diff -r patch{{.EmphasisRight}} to the tables in the working set. If no patch file is given, the patch is read from standard input.
+
+Each change in the patch is checked before it is applied. A schema change applies if the table's schema matches the schema before the change. A row change applies if each column it changes still has the value it had before the change. Changes which are already present are skipped. Any other difference is a conflict. If there are conflicts, they are listed and nothing is applied.
+
+The patch is applied to a temporary branch created from HEAD, and its changes are only merged into the working set once the whole patch has applied, so a patch which is truncated or conflicts leaves the working set unchanged. The tables changed by the patch must not have uncommitted changes.
+
+If {{.EmphasisLeft}}--check{{.EmphasisRight}} is given, the patch is checked for conflicts but the working set is not changed.`,
+	Synopsis: []string{
+		"[--check] [{{.LessThan}}patchfile{{.GreaterThan}}]",
+	},
+}
+
+type ApplyCmd struct{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd ApplyCmd) Name() string {
+	return "apply"
+}
+
+// Description returns a description of the command
+func (cmd ApplyCmd) Description() string {
+	return "Apply a patch to the working set."
+}
+
+func (cmd ApplyCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(applyDocs, ap)
+}
+
+func (cmd ApplyCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 1)
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"patchfile", "The patch to apply. Defaults to standard input."})
+	ap.SupportsFlag(checkFlag, "", "Check that the patch applies without conflicts, but don't change the working set.")
+	return ap
+}
+
+func (cmd ApplyCmd) RequiresRepo() bool {
+	return false
+}
+
+// Exec executes the command
+func (cmd ApplyCmd) Exec(ctx context.Context, commandStr string, args []string, _ *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	apr, usage, terminate, status := ParseArgsOrPrintHelp(ap, commandStr, args, applyDocs)
+	if terminate {
+		return status
+	}
+	var rd io.Reader = cli.InStream
+	if apr.NArg() == 1 {
+		path := apr.Arg(0)
+		f, err := os.Open(path)
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: could not open patch '%s'", path).AddCause(err).Build(), usage)
+		}
+		defer f.Close()
+		rd = f
+	}
+
+	queryist, sqlCtx, closeFunc, err := cliCtx.QueryEngine(ctx)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	if closeFunc != nil {
+		defer closeFunc()
+	}
+
+	pa := &patchApplier{queryist: queryist, sqlCtx: sqlCtx}
+	if apr.Contains(checkFlag) {
+		pa.dryRun = true
+		if err = pa.apply(rd); err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: failed to check patch").AddCause(err).Build(), usage)
+		}
+		if len(pa.conflicts) > 0 {
+			printPatchConflicts(pa.conflicts)
+			cli.PrintErrf("error: patch does not apply, %s\n", pluralize("conflict", "conflicts", uint64(len(pa.conflicts))))
+			return 1
+		}
+		cli.Println("Patch applies cleanly.")
+		return 0
+	}
+
+	if err = pa.applyOnTempBranch(rd); err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: failed to apply patch").AddCause(err).Build(), usage)
+	}
+	if len(pa.conflicts) > 0 {
+		printPatchConflicts(pa.conflicts)
+		cli.PrintErrf("error: patch does not apply, %s\n", pluralize("conflict", "conflicts", uint64(len(pa.conflicts))))
+		return 1
+	}
+
+	cli.Printf("Applied patch: %s, %s, %s, %s\n",
+		pluralize("schema change", "schema changes", pa.schemaChanges),
+		pluralize("row inserted", "rows inserted", pa.inserted),
+		pluralize("row updated", "rows updated", pa.updated),
+		pluralize("row deleted", "rows deleted", pa.deleted))
+	return 0
+}
+
+func printPatchConflicts(conflicts []string) {
+	for _, conflict := range conflicts {
+		cli.PrintErrln("conflict: " + conflict)
+	}
+}
+
+// patchApplier applies the records of a patch to the working set.
+type patchApplier struct {
+	queryist cli.Queryist
+	sqlCtx   *sql.Context
+	// dryRun is set to check the patch for conflicts without changing the working set
+	dryRun bool
+
+	// table is the table the current records apply to
+	table diff.PatchTable
+	// rowTable is the table in the working set which holds the current table's rows. When checking a patch, this is
+	// the table before its schema change, and it is empty for added tables.
+	rowTable string
+	// skipTable is set if the table's schema conflicts, in which case its rows are not applied
+	skipTable bool
+	// cols are the columns of the table's row records
+	cols []diff.PatchColumn
+	// tableCols are the columns of the table in the working set
+	tableCols map[string]*sql.Column
+
+	conflicts                                 []string
+	schemaChanges, inserted, updated, deleted uint64
+}
+
+// applyOnTempBranch applies the patch to a temporary branch created from HEAD, and then merges the changes into the
+// working set. Schema changes commit implicitly and a patch is only known to be complete once it has been read to the
+// end, so the working set is only changed once the whole patch has applied without conflicts. The tables the patch
+// changes must not have uncommitted changes.
+func (pa *patchApplier) applyOnTempBranch(rd io.Reader) (err error) {
+	rows, err := GetRowsForSql(pa.queryist, pa.sqlCtx, "SELECT database(), active_branch()")
+	if err != nil {
+		return err
+	}
+	if len(rows) != 1 || rows[0][0] == nil || rows[0][1] == nil {
+		return errors.New("a branch must be checked out to apply a patch")
+	}
+	db := rows[0][0].(string)
+	dbName, _, _ := strings.Cut(db, "/")
+
+	tempBranch := "dolt_apply_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	if _, err = InterpolateAndRunQuery(pa.queryist, pa.sqlCtx, "CALL DOLT_BRANCH(?)", tempBranch); err != nil {
+		return err
+	}
+	defer func() {
+		_, useErr := GetRowsForSql(pa.queryist, pa.sqlCtx, "USE "+sqlfmt.QuoteIdentifier(db))
+		_, deleteErr := InterpolateAndRunQuery(pa.queryist, pa.sqlCtx, "CALL DOLT_BRANCH('-D', ?)", tempBranch)
+		if err == nil {
+			err = errors.Join(useErr, deleteErr)
+		}
+	}()
+
+	if _, err = GetRowsForSql(pa.queryist, pa.sqlCtx, "USE "+sqlfmt.QuoteIdentifier(dbName+"/"+tempBranch)); err != nil {
+		return err
+	}
+	if err = pa.apply(rd); err != nil || len(pa.conflicts) > 0 {
+		return err
+	}
+	changed, err := pa.statusTables(false)
+	if err != nil || len(changed) == 0 {
+		return err
+	}
+	if _, err = GetRowsForSql(pa.queryist, pa.sqlCtx, "CALL DOLT_COMMIT('-Am', 'dolt apply')"); err != nil {
+		return err
+	}
+
+	if _, err = GetRowsForSql(pa.queryist, pa.sqlCtx, "USE "+sqlfmt.QuoteIdentifier(db)); err != nil {
+		return err
+	}
+	dirty, err := pa.statusTables(false)
+	if err != nil {
+		return err
+	}
+	for table := range dirty {
+		if changed[table] {
+			return fmt.Errorf("table %s has uncommitted changes, commit them before applying the patch", table)
+		}
+	}
+	rows, err = InterpolateAndRunQuery(pa.queryist, pa.sqlCtx, "CALL DOLT_MERGE(?, '--squash')", tempBranch)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 1 && len(rows[0]) > 2 {
+		if conflicts, err := getInt64ColAsInt64(rows[0][2]); err == nil && conflicts > 0 {
+			return errors.New("the patch conflicts with changes committed while it was applied")
+		}
+	}
+
+	// the merge stages the changes, which are left unstaged like any other change to the working set
+	staged, err := pa.statusTables(true)
+	if err != nil {
+		return err
+	}
+	var reset []interface{}
+	for table := range staged {
+		if changed[table] {
+			reset = append(reset, table)
+		}
+	}
+	if len(reset) > 0 {
+		query := "CALL DOLT_RESET(" + strings.TrimSuffix(strings.Repeat("?, ", len(reset)), ", ") + ")"
+		if _, err = InterpolateAndRunQuery(pa.queryist, pa.sqlCtx, query, reset...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// statusTables returns the names of the tables with changes in the working set, or only of those with staged changes
+// if |staged| is set. Both names of a renamed table are returned.
+func (pa *patchApplier) statusTables(staged bool) (map[string]bool, error) {
+	rows, err := GetRowsForSql(pa.queryist, pa.sqlCtx, "SELECT table_name, staged FROM dolt_status")
+	if err != nil {
+		return nil, err
+	}
+	tables := make(map[string]bool)
+	for _, row := range rows {
+		isStaged, err := GetTinyIntColAsBool(row[1])
+		if err != nil {
+			return nil, err
+		}
+		if staged && !isStaged {
+			continue
+		}
+		from, to, renamed := strings.Cut(row[0].(string), " -> ")
+		tables[from] = true
+		if renamed {
+			tables[to] = true
+		}
+	}
+	return tables, nil
+}
+
+func (pa *patchApplier) apply(rd io.Reader) error {
+	pr, err := diff.NewPatchReader(rd)
+	if err != nil {
+		return err
+	}
+	for {
+		rec, err := pr.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		switch rec.Type {
+		case diff.PatchTableRecord:
+			pa.table, pa.skipTable, pa.cols, pa.tableCols = *rec.Table, false, nil, nil
+			pa.rowTable = pa.targetTable()
+		case diff.PatchSchemaRecord:
+			err = pa.applySchema(*rec.Schema)
+		case diff.PatchColumnsRecord:
+			err = pa.loadColumns(rec.Columns)
+		case diff.PatchInsertRecord, diff.PatchDeleteRecord, diff.PatchUpdateRecord:
+			if pa.skipTable {
+				continue
+			}
+			if pa.cols == nil {
+				return fmt.Errorf("invalid patch on line %d: %s record without columns", rec.Line, rec.Type)
+			}
+			err = pa.applyRow(*rec.Row)
+		case diff.PatchViewRecord, diff.PatchTriggerRecord, diff.PatchEventRecord:
+			err = pa.applyFragment(rec.Type, *rec.Fragment)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (pa *patchApplier) conflict(format string, args ...interface{}) {
+	pa.conflicts = append(pa.conflicts, fmt.Sprintf(format, args...))
+}
+
+// targetTable returns the name of the table the current rows are applied to.
+func (pa *patchApplier) targetTable() string {
+	if pa.table.To != "" {
+		return pa.table.To
+	}
+	return pa.table.From
+}
+
+// applySchema applies a schema change to the current table if its schema in the working set matches the schema before
+// the change.
+func (pa *patchApplier) applySchema(sch diff.PatchSchema) error {
+	before, after := pa.table.From, pa.table.To
+	fromStmt, fromExists, err := pa.createStmt(before)
+	if err != nil {
+		return err
+	}
+	toStmt, toExists, err := pa.createStmt(after)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case after != "" && toExists && sameDefinition(toStmt, sch.To):
+		// already applied
+		return nil
+	case after == "" && !fromExists:
+		// already dropped
+		return nil
+	case before == "" && !toExists, before != "" && fromExists && sameDefinition(fromStmt, sch.From):
+		if pa.dryRun {
+			pa.rowTable = before
+			return nil
+		}
+		for _, stmt := range sch.Statements {
+			if _, err = GetRowsForSql(pa.queryist, pa.sqlCtx, stmt); err != nil {
+				return fmt.Errorf("error applying schema change to table '%s': %w", pa.targetTable(), err)
+			}
+		}
+		pa.schemaChanges++
+		return nil
+	case before != "" && !fromExists:
+		pa.conflict("table %s does not exist", before)
+	default:
+		pa.conflict("schema of table %s has changed", pa.targetTable())
+	}
+	pa.skipTable = true
+	return nil
+}
+
+// createStmt returns the CREATE TABLE statement for |table| in the working set, and whether the table exists.
+func (pa *patchApplier) createStmt(table string) (string, bool, error) {
+	if table == "" {
+		return "", false, nil
+	}
+	rows, err := InterpolateAndRunQuery(pa.queryist, pa.sqlCtx, "SHOW CREATE TABLE ?", dbr.I(table))
+	if isTableNotFoundError(err) {
+		return "", false, nil
+	} else if err != nil {
+		return "", false, err
+	}
+	if len(rows) != 1 {
+		return "", false, fmt.Errorf("expected 1 row for table '%s', got %d", table, len(rows))
+	}
+	return rows[0][1].(string), true, nil
+}
+
+// loadColumns sets the columns of the current table's rows, and loads the table's schema in the working set.
+func (pa *patchApplier) loadColumns(cols []diff.PatchColumn) error {
+	pa.cols = cols
+	if pa.skipTable || pa.table.To == "" || pa.rowTable == "" {
+		return nil
+	}
+
+	table := pa.rowTable
+	stmt, ok, err := pa.createStmt(table)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		pa.conflict("table %s does not exist", table)
+		pa.skipTable = true
+		return nil
+	}
+	sch, err := schemaFromCreateTableStmt(stmt)
+	if err != nil {
+		return err
+	}
+	pkSch, err := sqlutil.FromDoltSchema("", table, sch)
+	if err != nil {
+		return err
+	}
+	pa.tableCols = make(map[string]*sql.Column)
+	for _, col := range pkSch.Schema {
+		pa.tableCols[strings.ToLower(col.Name)] = col
+	}
+	return nil
+}
+
+// applyRow applies an inserted, deleted or updated row to the current table.
+func (pa *patchApplier) applyRow(row diff.PatchRow) error {
+	if len(row.Old) > 0 && len(row.Old) != len(pa.cols) || len(row.New) > 0 && len(row.New) != len(pa.cols) {
+		return fmt.Errorf("row of table '%s' has %d columns, expected %d", pa.targetTable(), max(len(row.Old), len(row.New)), len(pa.cols))
+	}
+	if pa.table.To == "" || pa.tableCols == nil {
+		// rows of dropped tables are removed with the table, and rows of added tables can't conflict
+		return nil
+	}
+
+	keyed := false
+	for _, col := range pa.cols {
+		keyed = keyed || col.PrimaryKey
+	}
+	if !keyed {
+		return pa.applyKeylessRow(row)
+	}
+
+	current, found, err := pa.currentRow(row)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case row.Old == nil:
+		if !found {
+			return pa.insertRow(row.New)
+		}
+		if !pa.matches(current, row.New, func(col diff.PatchColumn) bool { return col.To }) {
+			pa.conflict("table %s: row %s was inserted, but a different row with the same key exists", pa.targetTable(), fmtPatchKey(row.Key))
+		}
+		return nil
+
+	case row.New == nil:
+		if !found {
+			// already deleted
+			return nil
+		}
+		if !pa.matches(current, row.Old, func(col diff.PatchColumn) bool { return col.From }) {
+			pa.conflict("table %s: row %s was deleted, but it has been modified", pa.targetTable(), fmtPatchKey(row.Key))
+			return nil
+		}
+		return pa.deleteRow(row.Key)
+
+	default:
+		if !found {
+			pa.conflict("table %s: row %s was updated, but it does not exist", pa.targetTable(), fmtPatchKey(row.Key))
+			return nil
+		}
+		return pa.updateRow(row, current)
+	}
+}
+
+// currentRow returns the values of the current table's columns in the working set for the row with the key of |row|.
+func (pa *patchApplier) currentRow(row diff.PatchRow) (map[string]*string, bool, error) {
+	var cols []*sql.Column
+	for _, col := range pa.cols {
+		if tableCol, ok := pa.tableCols[strings.ToLower(col.Name)]; ok {
+			cols = append(cols, tableCol)
+		}
+	}
+
+	query := "SELECT "
+	var params []interface{}
+	for i, col := range cols {
+		if i > 0 {
+			query += ", "
+		}
+		query += "?"
+		params = append(params, dbr.I(col.Name))
+	}
+	query += " FROM ? WHERE "
+	params = append(params, dbr.I(pa.rowTable))
+	where, whereParams := pa.keyCondition(row.Key)
+	query += where
+	params = append(params, whereParams...)
+
+	rows, err := InterpolateAndRunQuery(pa.queryist, pa.sqlCtx, query, params...)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(rows) == 0 {
+		return nil, false, nil
+	}
+
+	current := make(map[string]*string, len(cols))
+	for i, col := range cols {
+		if rows[0][i] == nil {
+			current[strings.ToLower(col.Name)] = nil
+			continue
+		}
+		v, err := patchValue(col.Type, rows[0][i])
+		if err != nil {
+			return nil, false, err
+		}
+		current[strings.ToLower(col.Name)] = &v
+	}
+	return current, true, nil
+}
+
+// matches returns whether the |current| values of the row match |values|, for the columns selected by |include| which
+// exist in the working set.
+func (pa *patchApplier) matches(current map[string]*string, values []*string, include func(col diff.PatchColumn) bool) bool {
+	for i, col := range pa.cols {
+		cur, ok := current[strings.ToLower(col.Name)]
+		if !ok || !include(col) {
+			continue
+		}
+		if !equalPatchValues(cur, values[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (pa *patchApplier) insertRow(values []*string) error {
+	if pa.dryRun {
+		return nil
+	}
+	var names, exprs []string
+	params := []interface{}{dbr.I(pa.rowTable)}
+	var valueParams []interface{}
+	for i, col := range pa.cols {
+		if !col.To || pa.tableCols[strings.ToLower(col.Name)] == nil {
+			continue
+		}
+		names = append(names, "?")
+		params = append(params, dbr.I(col.Name))
+		expr, param := patchValueExpr(col, values[i])
+		exprs = append(exprs, expr)
+		valueParams = append(valueParams, param)
+	}
+	query := fmt.Sprintf("INSERT INTO ? (%s) VALUES (%s)", strings.Join(names, ", "), strings.Join(exprs, ", "))
+	params = append(params, valueParams...)
+	if _, err := InterpolateAndRunQuery(pa.queryist, pa.sqlCtx, query, params...); err != nil {
+		return err
+	}
+	pa.inserted++
+	return nil
+}
+
+func (pa *patchApplier) deleteRow(key []*string) error {
+	if pa.dryRun {
+		return nil
+	}
+	where, params := pa.keyCondition(key)
+	params = append([]interface{}{dbr.I(pa.rowTable)}, params...)
+	if _, err := InterpolateAndRunQuery(pa.queryist, pa.sqlCtx, "DELETE FROM ? WHERE "+where, params...); err != nil {
+		return err
+	}
+	pa.deleted++
+	return nil
+}
+
+// updateRow applies the changes to each column of |row| which still has its old value in the |current| row.
+func (pa *patchApplier) updateRow(row diff.PatchRow, current map[string]*string) error {
+	var sets []string
+	var params []interface{}
+	for i, col := range pa.cols {
+		cur, ok := current[strings.ToLower(col.Name)]
+		if !ok || !col.To {
+			continue
+		}
+		if col.From && equalPatchValues(row.Old[i], row.New[i]) {
+			// not changed by the patch
+			continue
+		}
+		if equalPatchValues(cur, row.New[i]) {
+			// already applied
+			continue
+		}
+		if col.From && !equalPatchValues(cur, row.Old[i]) {
+			pa.conflict("table %s: row %s: column %s was changed from %s to %s, but it is %s",
+				pa.targetTable(), fmtPatchKey(row.Key), col.Name, fmtPatchValue(row.Old[i]), fmtPatchValue(row.New[i]), fmtPatchValue(cur))
+			return nil
+		}
+		expr, param := patchValueExpr(col, row.New[i])
+		sets = append(sets, "? = "+expr)
+		params = append(params, dbr.I(col.Name), param)
+	}
+	if len(sets) == 0 || pa.dryRun {
+		return nil
+	}
+
+	where, whereParams := pa.keyCondition(row.Key)
+	params = append([]interface{}{dbr.I(pa.rowTable)}, params...)
+	params = append(params, whereParams...)
+	query := fmt.Sprintf("UPDATE ? SET %s WHERE %s", strings.Join(sets, ", "), where)
+	if _, err := InterpolateAndRunQuery(pa.queryist, pa.sqlCtx, query, params...); err != nil {
+		return err
+	}
+	pa.updated++
+	return nil
+}
+
+// applyKeylessRow applies an inserted or deleted row of a keyless table. Deleting a row conflicts if there is no
+// matching row in the working set.
+func (pa *patchApplier) applyKeylessRow(row diff.PatchRow) error {
+	if row.Old == nil {
+		return pa.insertRow(row.New)
+	}
+
+	var conds []string
+	var params []interface{}
+	for i, col := range pa.cols {
+		if !col.From || pa.tableCols[strings.ToLower(col.Name)] == nil {
+			continue
+		}
+		expr, param := patchValueExpr(col, row.Old[i])
+		conds = append(conds, "? <=> "+expr)
+		params = append(params, dbr.I(col.Name), param)
+	}
+	where := strings.Join(conds, " AND ")
+	params = append([]interface{}{dbr.I(pa.rowTable)}, params...)
+
+	rows, err := InterpolateAndRunQuery(pa.queryist, pa.sqlCtx, "SELECT 1 FROM ? WHERE "+where+" LIMIT 1", params...)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		pa.conflict("table %s: row %s was deleted, but it does not exist", pa.targetTable(), fmtPatchKey(row.Key))
+		return nil
+	}
+	if pa.dryRun {
+		return nil
+	}
+	if _, err = InterpolateAndRunQuery(pa.queryist, pa.sqlCtx, "DELETE FROM ? WHERE "+where+" LIMIT 1", params...); err != nil {
+		return err
+	}
+	pa.deleted++
+	return nil
+}
+
+// keyCondition returns a WHERE condition matching the primary key |key| of the current table, and its parameters.
+func (pa *patchApplier) keyCondition(key []*string) (string, []interface{}) {
+	var conds []string
+	var params []interface{}
+	i := 0
+	for _, col := range pa.cols {
+		if !col.PrimaryKey {
+			continue
+		}
+		expr, param := patchValueExpr(col, key[i])
+		conds = append(conds, "? <=> "+expr)
+		params = append(params, dbr.I(col.Name), param)
+		i++
+	}
+	return strings.Join(conds, " AND "), params
+}
+
+// applyFragment applies a change to a view, trigger or event if its definition in the working set matches the
+// definition before the change.
+func (pa *patchApplier) applyFragment(recordType string, fragment diff.PatchFragment) error {
+	rows, err := InterpolateAndRunQuery(pa.queryist, pa.sqlCtx, "SELECT fragment FROM dolt_schemas WHERE type = ? AND name = ?", recordType, fragment.Name)
+	if err != nil && !isTableNotFoundError(err) {
+		return err
+	}
+	var current string
+	if len(rows) > 0 {
+		current = rows[0][0].(string)
+	}
+
+	switch {
+	case sameDefinition(current, fragment.To):
+		return nil
+	case !sameDefinition(current, fragment.From):
+		pa.conflict("%s %s has changed", recordType, fragment.Name)
+		return nil
+	}
+
+	if pa.dryRun {
+		return nil
+	}
+	if current != "" {
+		if _, err = InterpolateAndRunQuery(pa.queryist, pa.sqlCtx, fmt.Sprintf("DROP %s ?", strings.ToUpper(recordType)), dbr.I(fragment.Name)); err != nil {
+			return err
+		}
+	}
+	if fragment.To != "" {
+		if _, err = GetRowsForSql(pa.queryist, pa.sqlCtx, fragment.To); err != nil {
+			return fmt.Errorf("error creating %s %s: %w", recordType, fragment.Name, err)
+		}
+	}
+	pa.schemaChanges++
+	return nil
+}
+
+// patchValueExpr returns a SQL expression for the value |v| of the column |col| and its parameter. NULL values have a
+// nil parameter.
+func patchValueExpr(col diff.PatchColumn, v *string) (string, interface{}) {
+	if v == nil {
+		return "?", nil
+	}
+	switch col.Encoding {
+	case diff.PatchEncodingHex:
+		return "UNHEX(?)", *v
+	case diff.PatchEncodingJSON:
+		return "CAST(? AS JSON)", *v
+	default:
+		return "?", *v
+	}
+}
+
+// sameDefinition returns whether two CREATE statements are the same, ignoring trailing semicolons and whitespace.
+func sameDefinition(a, b string) bool {
+	trim := func(s string) string {
+		return strings.TrimRight(strings.TrimSpace(s), ";")
+	}
+	return trim(a) == trim(b)
+}
+
+func equalPatchValues(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func fmtPatchValue(v *string) string {
+	if v == nil {
+		return "NULL"
+	}
+	return fmt.Sprintf("'%s'", *v)
+}
+
+func fmtPatchKey(key []*string) string {
+	values := make([]string, len(key))
+	for i, v := range key {
+		values[i] = fmtPatchValue(v)
+	}
+	return "(" + strings.Join(values, ", ") + ")"
+}
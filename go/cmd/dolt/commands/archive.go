@@ -27,6 +27,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/commitwalk"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/store/datas"
@@ -45,7 +46,13 @@ func (cmd ArchiveCmd) Name() string {
 var docs = cli.CommandDocumentationContent{
 	ShortDesc: "Create archive files for greater compression, then verify all chunks.",
 	LongDesc: `Run this command on a dolt database only after running 'dolt gc'. This command will convert all 'oldgen' 
-table files into archives. Currently, for safety, table files are left in place.`,
+table files into archives. Currently, for safety, table files are left in place.
+
+Chunks are compressed with a dictionary trained for the table they belong to. With {{.EmphasisLeft}}--group-chunks{{.EmphasisRight}}, 
+chunks are grouped with the other versions of themselves across the commit history instead.
+
+A running sql-server can archive its databases in the background after each automatic GC. See 
+{{.EmphasisLeft}}behavior.auto_gc_behavior.archive_level{{.EmphasisRight}}.`,
 
 	Synopsis: []string{
 		`[--group-chunks]`,
@@ -69,7 +76,7 @@ func (cmd ArchiveCmd) Docs() *cli.CommandDocumentation {
 
 func (cmd ArchiveCmd) ArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 0)
-	ap.SupportsFlag(groupChunksFlag, "", "Attempt to group chunks. This will produce smaller archives, but can take much longer to build.")
+	ap.SupportsFlag(groupChunksFlag, "", "Group chunks with their other versions in the commit history, rather than by table. This can produce smaller archives, but can take much longer to build.")
 	ap.SupportsFlag(revertFlag, "", "Return to unpurged table files, or rebuilt table files from archives")
 	/* TODO: Implement these flags
 	ap.SupportsFlag("purge", "", "remove table files after archiving")
@@ -128,16 +135,18 @@ func (cmd ArchiveCmd) Exec(ctx context.Context, commandStr string, args []string
 			return nil
 		})
 
-		groupings := nbs.NewChunkRelations()
-		if apr.Contains(groupChunksFlag) {
-			err = historicalFuzzyMatching(ctx, hs, &groupings, dEnv.DoltDB(ctx))
-			if err != nil {
-				cli.PrintErrln(err)
-				return 1
+		// By default, a dictionary is trained for each table. Grouping chunks by their history instead relates the
+		// versions of each chunk more closely, but takes much longer.
+		relate := func(ctx context.Context, include func(hash.Hash) (bool, error)) (nbs.ChunkRelations, error) {
+			if apr.Contains(groupChunksFlag) {
+				groupings := nbs.NewChunkRelations()
+				err := historicalFuzzyMatching(ctx, hs, &groupings, dEnv.DoltDB(ctx))
+				return groupings, err
 			}
+			return actions.TableChunkRelations(ctx, dEnv.DoltDB(ctx), include)
 		}
 
-		err = nbs.BuildArchive(ctx, cs, &groupings, progress)
+		err = nbs.BuildArchive(ctx, cs, relate, progress)
 		if err != nil {
 			cli.PrintErrln(err)
 			return 1
@@ -0,0 +1,208 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/nbs"
+)
+
+const (
+	createBundleId   = "create"
+	unbundleId       = "unbundle"
+	bundleSinceParam = "since"
+)
+
+var bundleDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Move commits between repositories with a file",
+	LongDesc: `Bundles package the commits of branches and tags, along with their history, into a single file. A bundle can be carried to a repository which can't reach a remote, and then fetched from or cloned like a remote with a url of the form {{.EmphasisLeft}}file:///path/to/file.bundle{{.EmphasisRight}}.
+
+{{.EmphasisLeft}}create{{.EmphasisRight}}
+Writes the branches and tags {{.LessThan}}ref{{.GreaterThan}} to the bundle {{.LessThan}}file{{.GreaterThan}}. With {{.EmphasisLeft}}--since{{.EmphasisRight}}, the history of {{.LessThan}}commit{{.GreaterThan}} is left out of the bundle, which can then only be read by a repository which has {{.LessThan}}commit{{.GreaterThan}}.
+
+{{.EmphasisLeft}}unbundle{{.EmphasisRight}}
+Reads the commits of the bundle {{.LessThan}}file{{.GreaterThan}} into the repository and prints the refs of the bundle along with their commits. No branches or tags are changed; fetch from the bundle to update them.`,
+
+	Synopsis: []string{
+		"create [--since {{.LessThan}}commit{{.GreaterThan}}] {{.LessThan}}file{{.GreaterThan}} {{.LessThan}}ref{{.GreaterThan}}...",
+		"unbundle {{.LessThan}}file{{.GreaterThan}}",
+	},
+}
+
+type BundleCmd struct{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd BundleCmd) Name() string {
+	return "bundle"
+}
+
+// Description returns a description of the command
+func (cmd BundleCmd) Description() string {
+	return "Move commits between repositories with a file."
+}
+
+func (cmd BundleCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(bundleDocs, ap)
+}
+
+func (cmd BundleCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithVariableArgs(cmd.Name())
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"file", "The bundle file."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"ref", "A branch or tag to write to the bundle."})
+	ap.SupportsString(bundleSinceParam, "", "commit", "Leave the history of {{.LessThan}}commit{{.GreaterThan}} out of the bundle.")
+	return ap
+}
+
+// EventType returns the type of the event to log
+func (cmd BundleCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_REMOTE
+}
+
+// Exec executes the command
+func (cmd BundleCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, bundleDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if !cli.CheckEnvIsValid(dEnv) {
+		return 2
+	}
+
+	var verr errhand.VerboseError
+	switch {
+	case apr.NArg() > 0 && apr.Arg(0) == createBundleId:
+		verr = createBundle(ctx, dEnv, apr)
+	case apr.NArg() > 0 && apr.Arg(0) == unbundleId:
+		verr = unbundle(ctx, dEnv, apr)
+	default:
+		verr = errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	return HandleVErrAndExitCode(verr, usage)
+}
+
+func createBundle(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
+	if apr.NArg() < 3 {
+		return errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	ddb := dEnv.DoltDB(ctx)
+	path := apr.Arg(1)
+	refs := make([]ref.DoltRef, 0, apr.NArg()-2)
+	for _, refStr := range apr.Args[2:] {
+		r, verr := resolveBundleRef(ctx, ddb, refStr)
+		if verr != nil {
+			return verr
+		}
+		refs = append(refs, r)
+	}
+
+	var since *doltdb.Commit
+	if sinceStr, ok := apr.GetValue(bundleSinceParam); ok {
+		var verr errhand.VerboseError
+		since, verr = MaybeGetCommitWithVErr(dEnv, sinceStr)
+		if verr != nil {
+			return verr
+		}
+		if since == nil {
+			return errhand.BuildDError("error: '%s' is not a commit, branch or tag", sinceStr).Build()
+		}
+	}
+
+	tmpDir, err := dEnv.TempTableFilesDir()
+	if err != nil {
+		return errhand.BuildDError("error: ").AddCause(err).Build()
+	}
+	err = actions.CreateBundle(ctx, tmpDir, ddb, refs, since, path, buildProgStarter(defaultLanguage), stopProgFuncs)
+	if err != nil {
+		return errhand.BuildDError("error: failed to create bundle '%s'", path).AddCause(err).Build()
+	}
+	return nil
+}
+
+// resolveBundleRef returns the branch or tag named |refStr|. Full ref paths, such as refs/tags/v1, may be used to
+// name a tag which has the name of a branch.
+func resolveBundleRef(ctx context.Context, ddb *doltdb.DoltDB, refStr string) (ref.DoltRef, errhand.VerboseError) {
+	if ref.IsRef(refStr) {
+		r, err := ref.Parse(refStr)
+		if err != nil {
+			return nil, errhand.BuildDError("error: '%s' is not a valid ref", refStr).AddCause(err).Build()
+		}
+		has, err := ddb.HasRef(ctx, r)
+		if err != nil {
+			return nil, errhand.BuildDError("error: failed to read refs").AddCause(err).Build()
+		}
+		if !has {
+			return nil, errhand.BuildDError("error: ref '%s' not found", refStr).Build()
+		}
+		return r, nil
+	}
+
+	name, ok, err := ddb.HasBranch(ctx, refStr)
+	if err != nil {
+		return nil, errhand.BuildDError("error: failed to read branches").AddCause(err).Build()
+	}
+	if ok {
+		return ref.NewBranchRef(name), nil
+	}
+
+	name, ok, err = ddb.HasTag(ctx, refStr)
+	if err != nil {
+		return nil, errhand.BuildDError("error: failed to read tags").AddCause(err).Build()
+	}
+	if ok {
+		return ref.NewTagRef(name), nil
+	}
+	return nil, errhand.BuildDError("error: '%s' is not a branch or tag", refStr).Build()
+}
+
+func unbundle(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
+	if apr.NArg() != 2 {
+		return errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	path := apr.Arg(1)
+	tmpDir, err := dEnv.TempTableFilesDir()
+	if err != nil {
+		return errhand.BuildDError("error: ").AddCause(err).Build()
+	}
+	refs, err := actions.Unbundle(ctx, tmpDir, dEnv.DoltDB(ctx), path, buildProgStarter(downloadLanguage), stopProgFuncs)
+	switch {
+	case err == nil:
+	case errors.Is(err, nbs.ErrNotABundle):
+		return errhand.BuildDError("error: '%s' is not a bundle", path).Build()
+	case errors.Is(err, actions.ErrMissingBundlePrerequisite):
+		return errhand.BuildDError("error: %s", err.Error()).
+			AddDetails("fetch the history the bundle was created since before unbundling it").Build()
+	default:
+		return errhand.BuildDError("error: failed to unbundle '%s'", path).AddCause(err).Build()
+	}
+
+	for _, r := range refs {
+		cli.Printf("%s %s\n", r.Hash.String(), r.Ref.String())
+	}
+	return nil
+}
@@ -16,6 +16,7 @@ package ci
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
@@ -30,7 +31,7 @@ import (
 
 var initDocs = cli.CommandDocumentationContent{
 	ShortDesc: "Creates database tables used to store continuous integration configuration",
-	LongDesc:  "Creates database tables used to store continuous integration configuration and creates a Dolt commit",
+	LongDesc:  "Creates database tables used to store continuous integration configuration and creates a Dolt commit. If the database was initialized by an older version of Dolt, creates only the tables it is missing.",
 	Synopsis:  []string{""},
 }
 
@@ -97,6 +98,10 @@ func (cmd InitCmd) Exec(ctx context.Context, commandStr string, args []string, d
 	}
 
 	hasTables, err := dolt_ci.HasDoltCITables(sqlCtx)
+	if errors.Is(err, dolt_ci.ErrIncompleteDoltCITables) {
+		err = dolt_ci.UpgradeDoltCITables(sqlCtx, db, queryist.Query, name, email)
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
 	if err != nil {
 		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
 	}
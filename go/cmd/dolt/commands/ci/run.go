@@ -0,0 +1,187 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ci
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fatih/color"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/dolt_ci"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+const refFlag = "ref"
+
+var runDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Run a Dolt continuous integration workflow by name",
+	LongDesc: `Run a Dolt continuous integration workflow by name.
+
+Each step of each job in the workflow is run against the current branch, or against the branch, tag or commit given with {{.EmphasisLeft}}--ref{{.EmphasisRight}}. Saved query steps execute the named query from the {{.EmphasisLeft}}dolt_query_catalog{{.EmphasisRight}} table at that ref and check the result against the expected row and column counts stored for the step. The other step types are:
+
+{{.EmphasisLeft}}sql_assertion{{.EmphasisRight}}: runs a query and checks its result set, in any order, against the step's expected rows.
+
+{{.EmphasisLeft}}verify_constraints{{.EmphasisRight}}: checks every row of the listed tables, or of all tables, for constraint violations.
+
+{{.EmphasisLeft}}schema_unchanged{{.EmphasisRight}}: checks that a table's schema is the same as at the step's {{.EmphasisLeft}}compare_to{{.EmphasisRight}} revision, {{.EmphasisLeft}}HEAD~1{{.EmphasisRight}} by default.
+
+{{.EmphasisLeft}}diff_budget{{.EmphasisRight}}: checks the number of rows added, deleted and modified since the step's {{.EmphasisLeft}}compare_to{{.EmphasisRight}} revision, in one table or all tables, against the step's maximums.
+
+A report of every step is printed. The command exits with a non-zero status if any step fails.`,
+	Synopsis: []string{
+		"[--ref {{.LessThan}}ref{{.GreaterThan}}] {{.LessThan}}workflow name{{.GreaterThan}}",
+	},
+}
+
+type RunCmd struct{}
+
+// Name implements cli.Command.
+func (cmd RunCmd) Name() string {
+	return "run"
+}
+
+// Description implements cli.Command.
+func (cmd RunCmd) Description() string {
+	return runDocs.ShortDesc
+}
+
+// RequiresRepo implements cli.Command.
+func (cmd RunCmd) RequiresRepo() bool {
+	return true
+}
+
+// Docs implements cli.Command.
+func (cmd RunCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(runDocs, ap)
+}
+
+// Hidden should return true if this command should be hidden from the help text
+func (cmd RunCmd) Hidden() bool {
+	return false
+}
+
+// ArgParser implements cli.Command.
+func (cmd RunCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 1)
+	ap.SupportsString(refFlag, "", "ref", "The branch, tag or commit to run the workflow against. Defaults to the current branch.")
+	return ap
+}
+
+// Exec implements cli.Command.
+func (cmd RunCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, runDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+	if !cli.CheckEnvIsValid(dEnv) {
+		return 1
+	}
+
+	var verr errhand.VerboseError
+	verr = validateRunArgs(apr)
+	if verr != nil {
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	workflowName := apr.Arg(0)
+	ref := apr.GetValueOrDefault(refFlag, "")
+
+	queryist, sqlCtx, closeFunc, err := cliCtx.QueryEngine(ctx)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	if closeFunc != nil {
+		defer closeFunc()
+	}
+
+	user, email, err := env.GetNameAndEmail(dEnv.Config)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	hasTables, err := dolt_ci.HasDoltCITables(sqlCtx)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	if !hasTables {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(fmt.Errorf("dolt ci has not been initialized, please initialize with: dolt ci init")), usage)
+	}
+
+	wm := dolt_ci.NewWorkflowManager(user, email, queryist.Query)
+
+	db, err := newDatabase(sqlCtx, sqlCtx.GetCurrentDatabase(), dEnv, false)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	result, err := wm.RunWorkflow(sqlCtx, db, workflowName, ref)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	printWorkflowRunResult(result)
+
+	if !result.Passed() {
+		return 1
+	}
+	return 0
+}
+
+func printWorkflowRunResult(result *dolt_ci.WorkflowRunResult) {
+	if result.Ref != "" {
+		cli.Println(color.CyanString(fmt.Sprintf("Running workflow '%s' against %s", result.WorkflowName, result.Ref)))
+	} else {
+		cli.Println(color.CyanString(fmt.Sprintf("Running workflow '%s'", result.WorkflowName)))
+	}
+
+	failed := 0
+	jobName := ""
+	for _, step := range result.Steps {
+		if step.JobName != jobName || jobName == "" {
+			jobName = step.JobName
+			cli.Println(fmt.Sprintf("Job: %s", jobName))
+		}
+
+		if step.Passed() {
+			cli.Println(fmt.Sprintf("  %s %s", color.GreenString("PASS"), step.StepName))
+			continue
+		}
+
+		failed++
+		cli.Println(fmt.Sprintf("  %s %s", color.RedString("FAIL"), step.StepName))
+		for _, failure := range step.Failures {
+			cli.Println(fmt.Sprintf("    - %s", failure))
+		}
+	}
+
+	if failed == 0 {
+		cli.Println(color.GreenString(fmt.Sprintf("Workflow '%s' passed: %d of %d steps passed", result.WorkflowName, len(result.Steps), len(result.Steps))))
+	} else {
+		cli.Println(color.RedString(fmt.Sprintf("Workflow '%s' failed: %d of %d steps failed", result.WorkflowName, failed, len(result.Steps))))
+	}
+}
+
+func validateRunArgs(apr *argparser.ArgParseResults) errhand.VerboseError {
+	if apr.NArg() != 1 {
+		return errhand.BuildDError("expected 1 argument").SetPrintUsage().Build()
+	}
+	return nil
+}
@@ -68,6 +68,12 @@ Valid configuration variables:
 	- remotes.default_port - sets default port for authenticating with doltremoteapi.
 
 	- push.autoSetupRemote - if set to "true" assume --set-upstream on default push when no upstream tracking exists for the current branch.
+
+	- storage.cold_tier.url - the url of a blobstore (file, localbs, gs, oci or oss) which 'dolt gc --full' moves the repository's oldest history to. Reads of that history are served from it through a local cache. Only read from the repository local config. Once history has been moved, the repository can't be read without it.
+
+	- storage.cold_tier.after - how old commits must be before 'dolt gc --full' moves them, and the history behind them, to the cold tier, e.g. "720h". Defaults to 30 days.
+
+	- storage.cold_tier.cache_size - the size of the local cache of the history read from the cold tier, e.g. "10GB". Defaults to 10GB.
 `,
 
 	Synopsis: []string{
@@ -57,9 +57,12 @@ const (
 
 	SchemaAndDataDiff = SchemaOnlyDiff | DataOnlyDiff
 
-	TabularDiffOutput diffOutput = 1
-	SQLDiffOutput     diffOutput = 2
-	JsonDiffOutput    diffOutput = 3
+	TabularDiffOutput  diffOutput = 1
+	SQLDiffOutput      diffOutput = 2
+	JsonDiffOutput     diffOutput = 3
+	PatchDiffOutput    diffOutput = 4
+	MarkdownDiffOutput diffOutput = 5
+	HTMLDiffOutput     diffOutput = 6
 
 	DataFlag     = "data"
 	SchemaFlag   = "schema"
@@ -98,6 +101,10 @@ The diffs displayed can be limited to show the first N by providing the paramete
 
 To filter which data rows are displayed, use {{.EmphasisLeft}}--where <SQL expression>{{.EmphasisRight}}. Table column names in the filter expression must be prefixed with {{.EmphasisLeft}}from_{{.EmphasisRight}} or {{.EmphasisLeft}}to_{{.EmphasisRight}}, e.g. {{.EmphasisLeft}}to_COLUMN_NAME > 100{{.EmphasisRight}} or {{.EmphasisLeft}}from_COLUMN_NAME + to_COLUMN_NAME = 0{{.EmphasisRight}}.
 
+The {{.EmphasisLeft}}patch{{.EmphasisRight}} output format writes a line-oriented patch holding the schema changes and the key, old values and new values of each changed row. The patch can be applied to another database with {{.EmphasisLeft}}dolt apply{{.EmphasisRight}}. The last line of the patch holds a checksum of the lines before it, so that a truncated or corrupted patch is not applied.
+
+The {{.EmphasisLeft}}markdown{{.EmphasisRight}} and {{.EmphasisLeft}}html{{.EmphasisRight}} output formats write diffs for pasting into pull requests and chat. Each modified row is written once, with the old and new values of each changed cell, and unchanged columns are left out as with {{.EmphasisLeft}}--skinny{{.EmphasisRight}}.
+
 The {{.EmphasisLeft}}--diff-mode{{.EmphasisRight}} argument controls how modified rows are presented when the format output is set to {{.EmphasisLeft}}tabular{{.EmphasisRight}}. When set to {{.EmphasisLeft}}row{{.EmphasisRight}}, modified rows are presented as old and new rows. When set to {{.EmphasisLeft}}line{{.EmphasisRight}}, modified rows are presented as a single row, and changes are presented using "+" and "-" within the column. When set to {{.EmphasisLeft}}in-place{{.EmphasisRight}}, modified rows are presented as a single row, and changes are presented side-by-side with a color distinction (requires a color-enabled terminal). When set to {{.EmphasisLeft}}context{{.EmphasisRight}}, rows that contain at least one column that spans multiple lines uses {{.EmphasisLeft}}line{{.EmphasisRight}}, while all other rows use {{.EmphasisLeft}}row{{.EmphasisRight}}. The default value is {{.EmphasisLeft}}context{{.EmphasisRight}}.
 `,
 	Synopsis: []string{
@@ -169,7 +176,7 @@ func (cmd DiffCmd) ArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(SchemaFlag, "s", "Show only the schema changes, do not show the data changes (Both shown by default).")
 	ap.SupportsFlag(StatFlag, "", "Show stats of data changes")
 	ap.SupportsFlag(SummaryFlag, "", "Show summary of data and schema changes")
-	ap.SupportsString(FormatFlag, "r", "result output format", "How to format diff output. Valid values are tabular, sql, json. Defaults to tabular.")
+	ap.SupportsString(FormatFlag, "r", "result output format", "How to format diff output. Valid values are tabular, sql, json, patch, markdown, html. Defaults to tabular.")
 	ap.SupportsString(whereParam, "", "column", "filters columns based on values in the diff.  See {{.EmphasisLeft}}dolt diff --help{{.EmphasisRight}} for details.")
 	ap.SupportsInt(limitParam, "", "record_count", "limits to the first N diffs.")
 	ap.SupportsFlag(cli.StagedFlag, "", "Show only the staged data changes.")
@@ -232,7 +239,7 @@ func (cmd DiffCmd) validateArgs(apr *argparser.ArgParseResults) errhand.VerboseE
 
 	f, _ := apr.GetValue(FormatFlag)
 	switch strings.ToLower(f) {
-	case "tabular", "sql", "json", "":
+	case "tabular", "sql", "json", "patch", "markdown", "html", "":
 	default:
 		return errhand.BuildDError("invalid output format: %s", f).Build()
 	}
@@ -276,6 +283,14 @@ func parseDiffDisplaySettings(apr *argparser.ArgParseResults) *diffDisplaySettin
 		displaySettings.diffOutput = SQLDiffOutput
 	case "json":
 		displaySettings.diffOutput = JsonDiffOutput
+	case "patch":
+		displaySettings.diffOutput = PatchDiffOutput
+	case "markdown":
+		displaySettings.diffOutput = MarkdownDiffOutput
+		displaySettings.skinny = true
+	case "html":
+		displaySettings.diffOutput = HTMLDiffOutput
+		displaySettings.skinny = true
 	}
 
 	displaySettings.limit, _ = apr.GetInt(limitParam)
@@ -1183,7 +1198,7 @@ func diffUserTable(
 		}
 	}
 
-	if tableSummary.IsDrop() && dArgs.diffOutput == SQLDiffOutput {
+	if tableSummary.IsDrop() && (dArgs.diffOutput == SQLDiffOutput || dArgs.diffOutput == PatchDiffOutput) {
 		return nil // don't output DELETE FROM statements after DROP TABLE
 	}
 
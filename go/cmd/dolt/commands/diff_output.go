@@ -16,13 +16,17 @@ package commands
 
 import (
 	"context"
+	"encoding/hex"
 	ejson "encoding/json"
 	"errors"
 	"fmt"
+	"html"
 	"io"
+	"strings"
 
 	textdiff "github.com/andreyvit/diff"
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
 	"github.com/dustin/go-humanize"
 	"github.com/fatih/color"
 
@@ -68,6 +72,12 @@ func newDiffWriter(diffOutput diffOutput) (diffWriter, error) {
 		return sqlDiffWriter{}, nil
 	case JsonDiffOutput:
 		return newJsonDiffWriter(iohelp.NopWrCloser(cli.CliOut))
+	case PatchDiffOutput:
+		return newPatchDiffWriter(cli.CliOut)
+	case MarkdownDiffOutput:
+		return newMarkdownDiffWriter(cli.CliOut)
+	case HTMLDiffOutput:
+		return newHTMLDiffWriter(cli.CliOut)
 	default:
 		panic(fmt.Sprintf("unexpected diff output: %v", diffOutput))
 	}
@@ -180,6 +190,20 @@ func (t tabularDiffWriter) WriteTableDiffStats(diffStats []diffStatistics, oldCo
 }
 
 func (t tabularDiffWriter) printStat(acc diff.DiffStatProgress, oldColLen, newColLen int) {
+	for _, line := range DiffStatLines(acc, oldColLen, newColLen) {
+		cli.Println(line)
+	}
+	cli.Println()
+}
+
+func (t tabularDiffWriter) printKeylessStat(acc diff.DiffStatProgress) {
+	for _, line := range keylessDiffStatLines(acc) {
+		cli.Println(line)
+	}
+}
+
+// DiffStatLines returns the lines of the summary of |acc| written by --stat.
+func DiffStatLines(acc diff.DiffStatProgress, oldColLen, newColLen int) []string {
 	numCellInserts, numCellDeletes := dtablefunctions.GetCellsAddedAndDeleted(acc, newColLen)
 	rowsUnmodified := uint64(acc.OldRowSize - acc.Changes - acc.Removes)
 	unmodified := pluralize("Row Unmodified", "Rows Unmodified", rowsUnmodified)
@@ -203,22 +227,24 @@ func (t tabularDiffWriter) printStat(acc diff.DiffStatProgress, oldColLen, newCo
 		return float64(100*num) / (float64(dom))
 	}
 
-	cli.Printf("%s (%.2f%%)\n", unmodified, safePercent(rowsUnmodified, acc.OldRowSize))
-	cli.Printf("%s (%.2f%%)\n", insertions, safePercent(acc.Adds, acc.OldRowSize))
-	cli.Printf("%s (%.2f%%)\n", deletions, safePercent(acc.Removes, acc.OldRowSize))
-	cli.Printf("%s (%.2f%%)\n", changes, safePercent(acc.Changes, acc.OldRowSize))
-	cli.Printf("%s (%.2f%%)\n", cellInsertions, safePercent(numCellInserts, acc.OldCellSize))
-	cli.Printf("%s (%.2f%%)\n", cellDeletions, safePercent(numCellDeletes, acc.OldCellSize))
-	cli.Printf("%s (%.2f%%)\n", cellChanges, percentCellsChanged)
-	cli.Printf("(%s vs %s)\n\n", oldValues, newValues)
+	return []string{
+		fmt.Sprintf("%s (%.2f%%)", unmodified, safePercent(rowsUnmodified, acc.OldRowSize)),
+		fmt.Sprintf("%s (%.2f%%)", insertions, safePercent(acc.Adds, acc.OldRowSize)),
+		fmt.Sprintf("%s (%.2f%%)", deletions, safePercent(acc.Removes, acc.OldRowSize)),
+		fmt.Sprintf("%s (%.2f%%)", changes, safePercent(acc.Changes, acc.OldRowSize)),
+		fmt.Sprintf("%s (%.2f%%)", cellInsertions, safePercent(numCellInserts, acc.OldCellSize)),
+		fmt.Sprintf("%s (%.2f%%)", cellDeletions, safePercent(numCellDeletes, acc.OldCellSize)),
+		fmt.Sprintf("%s (%.2f%%)", cellChanges, percentCellsChanged),
+		fmt.Sprintf("(%s vs %s)", oldValues, newValues),
+	}
 }
 
-func (t tabularDiffWriter) printKeylessStat(acc diff.DiffStatProgress) {
-	insertions := pluralize("Row Added", "Rows Added", acc.Adds)
-	deletions := pluralize("Row Deleted", "Rows Deleted", acc.Removes)
-
-	cli.Printf("%s\n", insertions)
-	cli.Printf("%s\n", deletions)
+// keylessDiffStatLines returns the lines of the summary of |acc| written by --stat for keyless tables.
+func keylessDiffStatLines(acc diff.DiffStatProgress) []string {
+	return []string{
+		pluralize("Row Added", "Rows Added", acc.Adds),
+		pluralize("Row Deleted", "Rows Deleted", acc.Removes),
+	}
 }
 
 func (t tabularDiffWriter) RowWriter(fromTableInfo, toTableInfo *diff.TableInfo, tds diff.TableDeltaSummary, unionSch sql.Schema) (diff.SqlRowDiffWriter, error) {
@@ -650,3 +676,528 @@ func (j *jsonDiffWriter) Close(ctx context.Context) error {
 	// Writer has already been closed here during row iteration, no need to close it here
 	return nil
 }
+
+type patchDiffWriter struct {
+	pw *diff.PatchWriter
+}
+
+var _ diffWriter = (*patchDiffWriter)(nil)
+
+func newPatchDiffWriter(wr io.Writer) (*patchDiffWriter, error) {
+	pw, err := diff.NewPatchWriter(wr)
+	if err != nil {
+		return nil, err
+	}
+	return &patchDiffWriter{pw: pw}, nil
+}
+
+func (p *patchDiffWriter) BeginTable(fromTableName, toTableName string, isAdd, isDrop bool) error {
+	table := diff.PatchTable{From: fromTableName, To: toTableName, DiffType: "modified"}
+	switch {
+	case isAdd:
+		table.From, table.DiffType = "", "added"
+	case isDrop:
+		table.To, table.DiffType = "", "dropped"
+	case fromTableName != toTableName:
+		table.DiffType = "renamed"
+	}
+	return p.pw.WriteTable(table)
+}
+
+func (p *patchDiffWriter) WriteTableSchemaDiff(fromTableInfo, toTableInfo *diff.TableInfo, tds diff.TableDeltaSummary) error {
+	var sch diff.PatchSchema
+	if fromTableInfo != nil {
+		sch.From = fromTableInfo.CreateStmt
+	}
+	if toTableInfo != nil {
+		sch.To = toTableInfo.CreateStmt
+	}
+	if sch.From == sch.To {
+		return nil
+	}
+
+	stmts := tds.AlterStmts
+	if tds.IsAdd() {
+		stmts = []string{toTableInfo.CreateStmt}
+	} else if tds.IsDrop() {
+		stmts = []string{sqlfmt.DropTableStmt(fromTableInfo.Name)}
+	}
+	for _, stmt := range stmts {
+		if len(stmt) > 0 {
+			sch.Statements = append(sch.Statements, stmt)
+		}
+	}
+	return p.pw.WriteSchema(sch)
+}
+
+func (p *patchDiffWriter) WriteEventDiff(ctx context.Context, eventName, oldDefn, newDefn string) error {
+	return p.pw.WriteFragment(diff.PatchEventRecord, diff.PatchFragment{Name: eventName, From: oldDefn, To: newDefn})
+}
+
+func (p *patchDiffWriter) WriteTriggerDiff(ctx context.Context, triggerName, oldDefn, newDefn string) error {
+	return p.pw.WriteFragment(diff.PatchTriggerRecord, diff.PatchFragment{Name: triggerName, From: oldDefn, To: newDefn})
+}
+
+func (p *patchDiffWriter) WriteViewDiff(ctx context.Context, viewName, oldDefn, newDefn string) error {
+	return p.pw.WriteFragment(diff.PatchViewRecord, diff.PatchFragment{Name: viewName, From: oldDefn, To: newDefn})
+}
+
+func (p *patchDiffWriter) WriteTableDiffStats(diffStats []diffStatistics, oldColLen, newColLen int, areTablesKeyless bool) error {
+	return errors.New("diff stats are not supported for patch output")
+}
+
+func (p *patchDiffWriter) RowWriter(fromTableInfo, toTableInfo *diff.TableInfo, tds diff.TableDeltaSummary, unionSch sql.Schema) (diff.SqlRowDiffWriter, error) {
+	cols := make([]diff.PatchColumn, len(unionSch))
+	for i, col := range unionSch {
+		cols[i] = diff.PatchColumn{
+			Name:       col.Name,
+			PrimaryKey: col.PrimaryKey,
+			Encoding:   patchColumnEncoding(col.Type),
+			From:       hasPatchColumn(fromTableInfo, col.Name),
+			To:         hasPatchColumn(toTableInfo, col.Name),
+		}
+	}
+	if err := p.pw.WriteColumns(cols); err != nil {
+		return nil, err
+	}
+	return &patchRowWriter{pw: p.pw, sch: unionSch}, nil
+}
+
+func (p *patchDiffWriter) Close(ctx context.Context) error {
+	return p.pw.Close()
+}
+
+// patchRowWriter writes the rows of a table diff as patch records.
+type patchRowWriter struct {
+	pw  *diff.PatchWriter
+	sch sql.Schema
+	// old is the old value of a modified row, written together with the row's new value
+	old []*string
+}
+
+var _ diff.SqlRowDiffWriter = (*patchRowWriter)(nil)
+
+func (p *patchRowWriter) WriteRow(ctx context.Context, row sql.Row, diffType diff.ChangeType, colDiffTypes []diff.ChangeType) error {
+	values, err := patchValues(p.sch, row)
+	if err != nil {
+		return err
+	}
+
+	switch diffType {
+	case diff.Added:
+		return p.pw.WriteRow(diff.PatchRow{Key: p.key(values), New: values})
+	case diff.Removed:
+		return p.pw.WriteRow(diff.PatchRow{Key: p.key(values), Old: values})
+	case diff.ModifiedOld:
+		p.old = values
+		return nil
+	case diff.ModifiedNew:
+		old := p.old
+		p.old = nil
+		if old == nil {
+			return errors.New("modified row is missing its old value")
+		}
+		return p.pw.WriteRow(diff.PatchRow{Key: p.key(values), Old: old, New: values})
+	default:
+		return fmt.Errorf("unexpected row diff type: %v", diffType)
+	}
+}
+
+func (p *patchRowWriter) WriteCombinedRow(ctx context.Context, oldRow, newRow sql.Row, mode diff.Mode) error {
+	if err := p.WriteRow(ctx, oldRow, diff.ModifiedOld, nil); err != nil {
+		return err
+	}
+	return p.WriteRow(ctx, newRow, diff.ModifiedNew, nil)
+}
+
+func (p *patchRowWriter) Close(ctx context.Context) error {
+	return nil
+}
+
+// key returns the primary key values of |values|, or all of the values for keyless tables.
+func (p *patchRowWriter) key(values []*string) []*string {
+	var key []*string
+	for i, col := range p.sch {
+		if col.PrimaryKey {
+			key = append(key, values[i])
+		}
+	}
+	if key == nil {
+		return values
+	}
+	return key
+}
+
+// hasPatchColumn returns whether the table |tableInfo| has a column named |name|.
+func hasPatchColumn(tableInfo *diff.TableInfo, name string) bool {
+	if tableInfo == nil {
+		return false
+	}
+	_, ok := tableInfo.Sch.GetAllCols().GetByName(name)
+	return ok
+}
+
+// patchColumnEncoding returns the encoding of the values of a column of type |typ| in a patch.
+func patchColumnEncoding(typ sql.Type) string {
+	if _, ok := typ.(sql.SpatialColumnType); ok {
+		return diff.PatchEncodingHex
+	}
+	switch {
+	case types.IsJSON(typ):
+		return diff.PatchEncodingJSON
+	case types.IsBinaryType(typ):
+		return diff.PatchEncodingHex
+	default:
+		return ""
+	}
+}
+
+// patchValues returns the values of |row| formatted for a patch.
+func patchValues(sch sql.Schema, row sql.Row) ([]*string, error) {
+	values := make([]*string, len(sch))
+	for i, col := range sch {
+		if i >= len(row) || row[i] == nil {
+			continue
+		}
+		v, err := patchValue(col.Type, row[i])
+		if err != nil {
+			return nil, err
+		}
+		values[i] = &v
+	}
+	return values, nil
+}
+
+// patchValue formats the non-NULL value |v| of a column of type |typ| for a patch.
+func patchValue(typ sql.Type, v interface{}) (string, error) {
+	str, err := sqlutil.SqlColToStr(typ, v)
+	if err != nil {
+		return "", err
+	}
+	if patchColumnEncoding(typ) == diff.PatchEncodingHex {
+		if _, ok := typ.(sql.SpatialColumnType); ok && strings.HasPrefix(str, "0x") {
+			// spatial values are already hex encoded
+			return strings.ToLower(str[2:]), nil
+		}
+		return hex.EncodeToString([]byte(str)), nil
+	}
+	return str, nil
+}
+
+// schemaDiffSch is the schema of the table of column changes written by the markdown and html diff writers.
+var schemaDiffSch = sql.Schema{
+	{Name: "column", Type: types.Text},
+	{Name: "definition", Type: types.Text},
+}
+
+// writeColumnDiffs writes the changes to the columns of a table to |wr| and closes it. It returns whether any columns
+// changed.
+func writeColumnDiffs(ctx context.Context, wr diff.SqlRowDiffWriter, fromTableInfo, toTableInfo *diff.TableInfo) (bool, error) {
+	var fromSch, toSch schema.Schema = schema.EmptySchema, schema.EmptySchema
+	if fromTableInfo != nil && fromTableInfo.Sch != nil {
+		fromSch = fromTableInfo.Sch
+	}
+	if toTableInfo != nil && toTableInfo.Sch != nil {
+		toSch = toTableInfo.Sch
+	}
+
+	definition := func(col *schema.Column, sch schema.Schema) string {
+		def := sqlfmt.GenerateCreateTableColumnDefinition(*col, sql.CollationID(sch.GetCollation()))
+		return strings.TrimPrefix(def, sqlfmt.QuoteIdentifier(col.Name)+" ")
+	}
+
+	changed := false
+	for _, cd := range diff.DiffSchColumnsByName(fromSch, toSch) {
+		var err error
+		switch cd.DiffType {
+		case diff.SchDiffAdded:
+			err = wr.WriteRow(ctx, sql.Row{cd.New.Name, definition(cd.New, toSch)}, diff.Added, []diff.ChangeType{diff.Added, diff.Added})
+		case diff.SchDiffRemoved:
+			err = wr.WriteRow(ctx, sql.Row{cd.Old.Name, definition(cd.Old, fromSch)}, diff.Removed, []diff.ChangeType{diff.Removed, diff.Removed})
+		case diff.SchDiffModified:
+			err = wr.WriteRow(ctx, sql.Row{cd.Old.Name, definition(cd.Old, fromSch)}, diff.ModifiedOld, []diff.ChangeType{diff.ModifiedOld, diff.ModifiedOld})
+			if err == nil {
+				err = wr.WriteRow(ctx, sql.Row{cd.New.Name, definition(cd.New, toSch)}, diff.ModifiedNew, []diff.ChangeType{diff.ModifiedNew, diff.ModifiedNew})
+			}
+		default:
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		changed = true
+	}
+
+	return changed, wr.Close(ctx)
+}
+
+// sumDiffStats returns the totals of |diffStats|.
+func sumDiffStats(diffStats []diffStatistics) diff.DiffStatProgress {
+	acc := diff.DiffStatProgress{}
+	for _, diffStat := range diffStats {
+		acc.Adds += diffStat.RowsAdded
+		acc.Removes += diffStat.RowsDeleted
+		acc.Changes += diffStat.RowsModified
+		acc.CellChanges += diffStat.CellsModified
+		acc.NewRowSize += diffStat.NewRowCount
+		acc.OldRowSize += diffStat.OldRowCount
+		acc.NewCellSize += diffStat.NewCellCount
+		acc.OldCellSize += diffStat.OldCellCount
+	}
+	return acc
+}
+
+// markdownDiffWriter writes diffs as GitHub flavored Markdown, for pasting into pull requests and chat.
+type markdownDiffWriter struct {
+	wr io.Writer
+}
+
+var _ diffWriter = (*markdownDiffWriter)(nil)
+
+func newMarkdownDiffWriter(wr io.Writer) (*markdownDiffWriter, error) {
+	return &markdownDiffWriter{wr: wr}, nil
+}
+
+func (m *markdownDiffWriter) write(format string, args ...interface{}) error {
+	return iohelp.WriteAll(m.wr, []byte(fmt.Sprintf(format, args...)))
+}
+
+// writeCodeDiff writes the line diff of two definitions as a diff code block.
+func (m *markdownDiffWriter) writeCodeDiff(oldDefn, newDefn string) error {
+	return m.write("```diff\n%s\n```\n\n", textdiff.LineDiff(oldDefn, newDefn))
+}
+
+func (m *markdownDiffWriter) BeginTable(fromTableName, toTableName string, isAdd, isDrop bool) error {
+	switch {
+	case isDrop:
+		return m.write("## %s\n\n_deleted table_\n\n", tabular.MarkdownEscape(fromTableName))
+	case isAdd:
+		return m.write("## %s\n\n_added table_\n\n", tabular.MarkdownEscape(toTableName))
+	case fromTableName != toTableName:
+		return m.write("## %s\n\n_renamed from %s_\n\n", tabular.MarkdownEscape(toTableName), tabular.MarkdownEscape(fromTableName))
+	default:
+		return m.write("## %s\n\n", tabular.MarkdownEscape(toTableName))
+	}
+}
+
+func (m *markdownDiffWriter) WriteTableSchemaDiff(fromTableInfo, toTableInfo *diff.TableInfo, tds diff.TableDeltaSummary) error {
+	var fromCreateStmt, toCreateStmt string
+	if fromTableInfo != nil {
+		fromCreateStmt = fromTableInfo.CreateStmt
+	}
+	if toTableInfo != nil {
+		toCreateStmt = toTableInfo.CreateStmt
+	}
+	if fromCreateStmt == toCreateStmt {
+		return nil
+	}
+
+	if err := m.write("**Schema changes**\n\n"); err != nil {
+		return err
+	}
+	colsChanged, err := writeColumnDiffs(context.Background(), tabular.NewMarkdownDiffTableWriter(schemaDiffSch, iohelp.NopWrCloser(m.wr)), fromTableInfo, toTableInfo)
+	if err != nil {
+		return err
+	}
+	if !colsChanged {
+		return m.writeCodeDiff(fromCreateStmt, toCreateStmt)
+	}
+
+	// the column changes don't include changes to indexes, constraints and table options
+	if err = m.write("<details>\n<summary>CREATE TABLE</summary>\n\n"); err != nil {
+		return err
+	}
+	if err = m.writeCodeDiff(fromCreateStmt, toCreateStmt); err != nil {
+		return err
+	}
+	return m.write("</details>\n\n")
+}
+
+func (m *markdownDiffWriter) WriteEventDiff(ctx context.Context, eventName, oldDefn, newDefn string) error {
+	return m.writeFragmentDiff("event", eventName, oldDefn, newDefn)
+}
+
+func (m *markdownDiffWriter) WriteTriggerDiff(ctx context.Context, triggerName, oldDefn, newDefn string) error {
+	return m.writeFragmentDiff("trigger", triggerName, oldDefn, newDefn)
+}
+
+func (m *markdownDiffWriter) WriteViewDiff(ctx context.Context, viewName, oldDefn, newDefn string) error {
+	return m.writeFragmentDiff("view", viewName, oldDefn, newDefn)
+}
+
+func (m *markdownDiffWriter) writeFragmentDiff(fragmentType, name, oldDefn, newDefn string) error {
+	if err := m.write("## %s %s\n\n", fragmentType, tabular.MarkdownEscape(name)); err != nil {
+		return err
+	}
+	return m.writeCodeDiff(oldDefn, newDefn)
+}
+
+func (m *markdownDiffWriter) WriteTableDiffStats(diffStats []diffStatistics, oldColLen, newColLen int, areTablesKeyless bool) error {
+	acc := sumDiffStats(diffStats)
+	if (acc.Adds+acc.Removes+acc.Changes) == 0 && (acc.OldCellSize-acc.NewCellSize) == 0 {
+		return m.write("No data changes.\n\n")
+	}
+
+	lines := keylessDiffStatLines(acc)
+	if !areTablesKeyless {
+		lines = DiffStatLines(acc, oldColLen, newColLen)
+	}
+	for _, line := range lines {
+		if err := m.write("- %s\n", tabular.MarkdownEscape(line)); err != nil {
+			return err
+		}
+	}
+	return m.write("\n")
+}
+
+func (m *markdownDiffWriter) RowWriter(fromTableInfo, toTableInfo *diff.TableInfo, tds diff.TableDeltaSummary, unionSch sql.Schema) (diff.SqlRowDiffWriter, error) {
+	return tabular.NewMarkdownDiffTableWriter(unionSch, iohelp.NopWrCloser(m.wr)), nil
+}
+
+func (m *markdownDiffWriter) Close(ctx context.Context) error {
+	return nil
+}
+
+// htmlDiffStyle is written at the beginning of html diffs to highlight changes when they are viewed in a browser.
+const htmlDiffStyle = `<style>
+table.dolt-diff { border-collapse: collapse; font-family: monospace; }
+table.dolt-diff th, table.dolt-diff td { border: 1px solid #d0d7de; padding: 2px 6px; }
+tr.added { background-color: #e6ffec; }
+tr.removed { background-color: #ffebe9; }
+ins { background-color: #abf2bc; text-decoration: none; }
+del { background-color: #ffc0c0; }
+</style>
+`
+
+// htmlDiffWriter writes diffs as HTML.
+type htmlDiffWriter struct {
+	wr            io.Writer
+	beganDocument bool
+}
+
+var _ diffWriter = (*htmlDiffWriter)(nil)
+
+func newHTMLDiffWriter(wr io.Writer) (*htmlDiffWriter, error) {
+	return &htmlDiffWriter{wr: wr}, nil
+}
+
+func (h *htmlDiffWriter) write(format string, args ...interface{}) error {
+	if !h.beganDocument {
+		h.beganDocument = true
+		if err := iohelp.WriteAll(h.wr, []byte(htmlDiffStyle)); err != nil {
+			return err
+		}
+	}
+	return iohelp.WriteAll(h.wr, []byte(fmt.Sprintf(format, args...)))
+}
+
+// writeCodeDiff writes the line diff of two definitions as preformatted text, with added and removed lines marked.
+func (h *htmlDiffWriter) writeCodeDiff(oldDefn, newDefn string) error {
+	var sb strings.Builder
+	for i, line := range strings.Split(textdiff.LineDiff(oldDefn, newDefn), "\n") {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		switch {
+		case strings.HasPrefix(line, "+"):
+			sb.WriteString("<ins>" + html.EscapeString(line) + "</ins>")
+		case strings.HasPrefix(line, "-"):
+			sb.WriteString("<del>" + html.EscapeString(line) + "</del>")
+		default:
+			sb.WriteString(html.EscapeString(line))
+		}
+	}
+	return h.write("<pre>%s</pre>\n", sb.String())
+}
+
+func (h *htmlDiffWriter) BeginTable(fromTableName, toTableName string, isAdd, isDrop bool) error {
+	switch {
+	case isDrop:
+		return h.write("<h2>%s</h2>\n<p><em>deleted table</em></p>\n", tabular.HTMLEscape(fromTableName))
+	case isAdd:
+		return h.write("<h2>%s</h2>\n<p><em>added table</em></p>\n", tabular.HTMLEscape(toTableName))
+	case fromTableName != toTableName:
+		return h.write("<h2>%s</h2>\n<p><em>renamed from %s</em></p>\n", tabular.HTMLEscape(toTableName), tabular.HTMLEscape(fromTableName))
+	default:
+		return h.write("<h2>%s</h2>\n", tabular.HTMLEscape(toTableName))
+	}
+}
+
+func (h *htmlDiffWriter) WriteTableSchemaDiff(fromTableInfo, toTableInfo *diff.TableInfo, tds diff.TableDeltaSummary) error {
+	var fromCreateStmt, toCreateStmt string
+	if fromTableInfo != nil {
+		fromCreateStmt = fromTableInfo.CreateStmt
+	}
+	if toTableInfo != nil {
+		toCreateStmt = toTableInfo.CreateStmt
+	}
+	if fromCreateStmt == toCreateStmt {
+		return nil
+	}
+
+	if err := h.write("<h3>Schema changes</h3>\n"); err != nil {
+		return err
+	}
+	colsChanged, err := writeColumnDiffs(context.Background(), tabular.NewHTMLDiffTableWriter(schemaDiffSch, iohelp.NopWrCloser(h.wr)), fromTableInfo, toTableInfo)
+	if err != nil {
+		return err
+	}
+	if !colsChanged {
+		return h.writeCodeDiff(fromCreateStmt, toCreateStmt)
+	}
+
+	// the column changes don't include changes to indexes, constraints and table options
+	if err = h.write("<details>\n<summary>CREATE TABLE</summary>\n"); err != nil {
+		return err
+	}
+	if err = h.writeCodeDiff(fromCreateStmt, toCreateStmt); err != nil {
+		return err
+	}
+	return h.write("</details>\n")
+}
+
+func (h *htmlDiffWriter) WriteEventDiff(ctx context.Context, eventName, oldDefn, newDefn string) error {
+	return h.writeFragmentDiff("event", eventName, oldDefn, newDefn)
+}
+
+func (h *htmlDiffWriter) WriteTriggerDiff(ctx context.Context, triggerName, oldDefn, newDefn string) error {
+	return h.writeFragmentDiff("trigger", triggerName, oldDefn, newDefn)
+}
+
+func (h *htmlDiffWriter) WriteViewDiff(ctx context.Context, viewName, oldDefn, newDefn string) error {
+	return h.writeFragmentDiff("view", viewName, oldDefn, newDefn)
+}
+
+func (h *htmlDiffWriter) writeFragmentDiff(fragmentType, name, oldDefn, newDefn string) error {
+	if err := h.write("<h2>%s %s</h2>\n", fragmentType, tabular.HTMLEscape(name)); err != nil {
+		return err
+	}
+	return h.writeCodeDiff(oldDefn, newDefn)
+}
+
+func (h *htmlDiffWriter) WriteTableDiffStats(diffStats []diffStatistics, oldColLen, newColLen int, areTablesKeyless bool) error {
+	acc := sumDiffStats(diffStats)
+	if (acc.Adds+acc.Removes+acc.Changes) == 0 && (acc.OldCellSize-acc.NewCellSize) == 0 {
+		return h.write("<p>No data changes.</p>\n")
+	}
+
+	lines := keylessDiffStatLines(acc)
+	if !areTablesKeyless {
+		lines = DiffStatLines(acc, oldColLen, newColLen)
+	}
+	var sb strings.Builder
+	sb.WriteString("<ul>\n")
+	for _, line := range lines {
+		sb.WriteString("<li>" + tabular.HTMLEscape(line) + "</li>\n")
+	}
+	sb.WriteString("</ul>\n")
+	return h.write("%s", sb.String())
+}
+
+func (h *htmlDiffWriter) RowWriter(fromTableInfo, toTableInfo *diff.TableInfo, tds diff.TableDeltaSummary, unionSch sql.Schema) (diff.SqlRowDiffWriter, error) {
+	return tabular.NewHTMLDiffTableWriter(unionSch, iohelp.NopWrCloser(h.wr)), nil
+}
+
+func (h *htmlDiffWriter) Close(ctx context.Context) error {
+	return nil
+}
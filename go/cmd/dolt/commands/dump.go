@@ -38,6 +38,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/table"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/untyped/sqlexport"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/untyped/xlsx"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
@@ -52,11 +53,18 @@ const (
 	noAutocommitFlag = "no-autocommit"
 	schemaOnlyFlag   = "schema-only"
 	noCreateDbFlag   = "no-create-db"
+	dumpAsOfFlag     = "as-of"
+	dumpTablesFlag   = "tables"
+	excludeTblsFlag  = "exclude-tables"
+	dumpWhereFlag    = "where"
+	allBranchesFlag  = "all-branches"
 
 	sqlFileExt     = "sql"
 	csvFileExt     = "csv"
 	jsonFileExt    = "json"
+	jsonlFileExt   = "jsonl"
 	parquetFileExt = "parquet"
+	xlsxFileExt    = "xlsx"
 	emptyFileExt   = ""
 	emptyStr       = ""
 )
@@ -66,12 +74,33 @@ var dumpDocs = cli.CommandDocumentationContent{
 	LongDesc: `{{.EmphasisLeft}}dolt dump{{.EmphasisRight}} dumps all tables in the working set. 
 If a dump file already exists then the operation will fail, unless the {{.EmphasisLeft}}--force | -f{{.EmphasisRight}} flag 
 is provided. The force flag forces the existing dump file to be overwritten. The {{.EmphasisLeft}}-r{{.EmphasisRight}} flag 
-is used to support different file formats of the dump. In the case of non .sql files each table is written to a separate
-csv,json or parquet file. 
+is used to support different file formats of the dump. In the case of csv, json, jsonl and parquet dumps each table is
+written to a separate file. An xlsx dump is written to a single workbook, with each table written to the sheet named
+after it. 
+
+The working set is dumped, unless {{.EmphasisLeft}}--as-of{{.EmphasisRight}} gives the commit, branch or tag to dump. 
+The tables dumped can be limited to those matching {{.EmphasisLeft}}--tables{{.EmphasisRight}} and not matching 
+{{.EmphasisLeft}}--exclude-tables{{.EmphasisRight}}, each a comma separated list of table names or patterns, in which 
+{{.EmphasisLeft}}*{{.EmphasisRight}} matches any characters and {{.EmphasisLeft}}?{{.EmphasisRight}} matches a single 
+character. Views and triggers which depend on tables which aren't dumped are skipped. The rows dumped of a table can be 
+limited with {{.EmphasisLeft}}--where {{.LessThan}}table{{.GreaterThan}}:{{.LessThan}}expr{{.GreaterThan}}{{.EmphasisRight}}, 
+which dumps only the rows of the table matching the SQL expression given. Filters of several tables are given with a 
+{{.EmphasisLeft}}--where{{.EmphasisRight}} for each, as in {{.EmphasisLeft}}--where "people:age > 21" --where "pets:kind = 'cat'"{{.EmphasisRight}}.
+
+With {{.EmphasisLeft}}--all-branches{{.EmphasisRight}}, the head commit of every branch is dumped to a separate sql file, 
+{{.LessThan}}directory{{.GreaterThan}}/{{.LessThan}}branch{{.GreaterThan}}.sql, from a single snapshot of the branches. 
+The file of the current branch rebuilds the merge base of every other branch with it, creating the other branches at 
+their merge bases, and then rebuilds the current branch's head commit, so it must be imported first. The file of every 
+other branch checks out its branch with {{.EmphasisLeft}}CALL dolt_checkout({{.LessThan}}branch{{.GreaterThan}}){{.EmphasisRight}} 
+and rebuilds its head commit. A commit is rebuilt by replacing the branch's tables, views and procedures with those of 
+the commit, and committing them with {{.EmphasisLeft}}CALL dolt_commit(){{.EmphasisRight}} using the message, author and 
+date of the commit, so that importing the files rebuilds the tip of each branch on top of the history it shares with 
+the current branch. 
 `,
 
 	Synopsis: []string{
-		"[-f] [-r {{.LessThan}}result-format{{.GreaterThan}}] [-fn {{.LessThan}}file_name{{.GreaterThan}}]  [-d {{.LessThan}}directory{{.GreaterThan}}] [--batch] [--no-batch] [--no-autocommit] [--no-create-db] ",
+		"[-f] [-r {{.LessThan}}result-format{{.GreaterThan}}] [-fn {{.LessThan}}file_name{{.GreaterThan}}]  [-d {{.LessThan}}directory{{.GreaterThan}}] [--batch] [--no-batch] [--no-autocommit] [--no-create-db] [--as-of {{.LessThan}}ref{{.GreaterThan}}] [--tables {{.LessThan}}patterns{{.GreaterThan}}] [--exclude-tables {{.LessThan}}patterns{{.GreaterThan}}] [--where {{.LessThan}}table{{.GreaterThan}}:{{.LessThan}}expr{{.GreaterThan}}]",
+		"--all-branches [-f] [-d {{.LessThan}}directory{{.GreaterThan}}] [--tables {{.LessThan}}patterns{{.GreaterThan}}] [--exclude-tables {{.LessThan}}patterns{{.GreaterThan}}] [--where {{.LessThan}}table{{.GreaterThan}}:{{.LessThan}}expr{{.GreaterThan}}]",
 	},
 }
 
@@ -95,8 +124,8 @@ func (cmd DumpCmd) Docs() *cli.CommandDocumentation {
 
 func (cmd DumpCmd) ArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 0)
-	ap.SupportsString(FormatFlag, "r", "result_file_type", "Define the type of the output file. Defaults to sql. Valid values are sql, csv, json and parquet.")
-	ap.SupportsString(filenameFlag, "fn", "file_name", "Define file name for dump file. Defaults to `doltdump.sql`.")
+	ap.SupportsString(FormatFlag, "r", "result_file_type", "Define the type of the output file. Defaults to sql. Valid values are sql, csv, json, jsonl, parquet and xlsx.")
+	ap.SupportsString(filenameFlag, "fn", "file_name", "Define file name for dump file. Defaults to `doltdump.sql`, or `doltdump.xlsx` for xlsx dumps.")
 	ap.SupportsString(directoryFlag, "d", "directory_name", "Define directory name to dump the files in. Defaults to `doltdump/`.")
 	ap.SupportsFlag(forceParam, "f", "If data already exists in the destination, the force flag will allow the target to be overwritten.")
 	ap.SupportsFlag(batchFlag, "", "Return batch insert statements wherever possible, enabled by default.")
@@ -104,6 +133,11 @@ func (cmd DumpCmd) ArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(noAutocommitFlag, "na", "Turn off autocommit for each dumped table. Useful for speeding up loading of output SQL file.")
 	ap.SupportsFlag(schemaOnlyFlag, "", "Dump a table's schema, without including any data, to the output SQL file.")
 	ap.SupportsFlag(noCreateDbFlag, "", "Do not write `CREATE DATABASE` statements in SQL files.")
+	ap.SupportsString(dumpAsOfFlag, "", "ref", "Dump the tables as of the commit, branch or tag given, rather than the working set.")
+	ap.SupportsStringList(dumpTablesFlag, "", "patterns", "Only dump the tables matching the comma separated list of table names or patterns given.")
+	ap.SupportsStringList(excludeTblsFlag, "", "patterns", "Don't dump the tables matching the comma separated list of table names or patterns given.")
+	ap.SupportsRepeatedString(dumpWhereFlag, "", "table:expr", "Only dump the rows of the table matching the SQL expression given. Give the option once for each table filtered.")
+	ap.SupportsFlag(allBranchesFlag, "", "Dump the head commit of every branch to a separate SQL file, which rebuilds the branch when it's imported.")
 	return ap
 }
 
@@ -118,25 +152,39 @@ func (cmd DumpCmd) Exec(ctx context.Context, commandStr string, args []string, d
 	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, dumpDocs, ap))
 	apr := cli.ParseArgsOrDie(ap, args, help)
 
-	root, verr := GetWorkingWithVErr(dEnv)
+	force := apr.Contains(forceParam)
+	schemaOnly := apr.Contains(schemaOnlyFlag)
+	resFormat, _ := apr.GetValue(FormatFlag)
+	resFormat = strings.TrimPrefix(resFormat, ".")
+
+	if apr.Contains(allBranchesFlag) {
+		dirName, vErr := validateDumpArgs(apr)
+		if vErr != nil {
+			return HandleVErrAndExitCode(vErr, usage)
+		}
+		vErr = dumpAllBranches(ctx, dEnv, apr, force, schemaOnly, dirName)
+		if vErr != nil {
+			return HandleVErrAndExitCode(vErr, usage)
+		}
+		cli.PrintErrln(color.CyanString("Successfully exported data."))
+		return 0
+	}
+
+	src, verr := getDumpSource(ctx, dEnv, apr)
 	if verr != nil {
 		return HandleVErrAndExitCode(verr, usage)
 	}
+	root := src.root
 
-	tblNames, err := doltdb.GetNonSystemTableNames(ctx, root)
-	if err != nil {
-		return HandleVErrAndExitCode(errhand.BuildDError("error: failed to get tables").AddCause(err).Build(), usage)
+	tblNames, verr := getDumpTableNames(ctx, src, apr)
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
 	}
 	if len(tblNames) == 0 {
 		cli.Println("No tables to export.")
 		return 0
 	}
 
-	force := apr.Contains(forceParam)
-	schemaOnly := apr.Contains(schemaOnlyFlag)
-	resFormat, _ := apr.GetValue(FormatFlag)
-	resFormat = strings.TrimPrefix(resFormat, ".")
-
 	outputFileOrDirName, vErr := validateDumpArgs(apr)
 	if vErr != nil {
 		return HandleVErrAndExitCode(vErr, usage)
@@ -181,20 +229,17 @@ func (cmd DumpCmd) Exec(ctx context.Context, commandStr string, args []string, d
 			return HandleVErrAndExitCode(err, usage)
 		}
 
-		for _, tbl := range tblNames {
-			tblOpts := newTableArgs(tbl, dumpOpts.dest, !apr.Contains(noBatchFlag), apr.Contains(noAutocommitFlag), schemaOnly)
-			err = dumpTable(ctx, dEnv, tblOpts, fPath)
-			if err != nil {
-				return HandleVErrAndExitCode(err, usage)
-			}
-		}
-
-		err = dumpSchemaElements(ctx, dEnv, fPath)
+		err = dumpSqlTables(ctx, dEnv, apr, src, dumpOpts, tblNames, fPath)
 		if err != nil {
 			return HandleVErrAndExitCode(err, usage)
 		}
-	case csvFileExt, jsonFileExt, parquetFileExt:
-		err = dumpNonSqlTables(ctx, root, dEnv, force, tblNames, resFormat, outputFileOrDirName, false)
+	case csvFileExt, jsonFileExt, jsonlFileExt, parquetFileExt:
+		err := dumpNonSqlTables(ctx, src, dEnv, force, tblNames, resFormat, outputFileOrDirName, false)
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+		}
+	case xlsxFileExt:
+		err := dumpXlsxTables(ctx, src, dEnv, force, tblNames, outputFileOrDirName)
 		if err != nil {
 			return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
 		}
@@ -207,8 +252,24 @@ func (cmd DumpCmd) Exec(ctx context.Context, commandStr string, args []string, d
 	return 0
 }
 
-// dumpSchemaElements writes the non-table schema elements (views, triggers, procedures) to the file path given
-func dumpSchemaElements(ctx context.Context, dEnv *env.DoltEnv, path string) errhand.VerboseError {
+// dumpSqlTables writes the tables |tblNames| of |src|, followed by its views, triggers and procedures, to the sql file
+// at |fPath|.
+func dumpSqlTables(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults, src *dumpSource, dumpOpts *dumpOptions, tblNames []string, fPath string) errhand.VerboseError {
+	schemaOnly := apr.Contains(schemaOnlyFlag)
+	for _, tbl := range tblNames {
+		tblOpts := newTableArgs(src, tbl, dumpOpts.dest, !apr.Contains(noBatchFlag), apr.Contains(noAutocommitFlag), schemaOnly)
+		err := dumpTable(ctx, dEnv, tblOpts, fPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	return dumpSchemaElements(ctx, dEnv, src, tblNames, fPath)
+}
+
+// dumpSchemaElements writes the non-table schema elements (views, triggers, procedures) of |src| to the file path
+// given. Views and triggers which depend on tables of |src| which aren't in |tblNames| are skipped.
+func dumpSchemaElements(ctx context.Context, dEnv *env.DoltEnv, src *dumpSource, tblNames []string, path string) errhand.VerboseError {
 	writer, err := dEnv.FS.OpenForWriteAppend(path, os.ModePerm)
 	if err != nil {
 		return errhand.VerboseErrorFromError(err)
@@ -225,22 +286,22 @@ func dumpSchemaElements(ctx context.Context, dEnv *env.DoltEnv, path string) err
 	}
 	sqlCtx.SetCurrentDatabase(dbName)
 
-	root, err := dEnv.WorkingRoot(ctx)
+	skipped, err := getSkippedTables(ctx, src, tblNames)
 	if err != nil {
 		return errhand.VerboseErrorFromError(err)
 	}
 
-	err = dumpViews(sqlCtx, engine, root, writer)
+	err = dumpViews(sqlCtx, engine, src, skipped, writer)
 	if err != nil {
 		return errhand.VerboseErrorFromError(err)
 	}
 
-	err = dumpTriggers(sqlCtx, engine, root, writer)
+	err = dumpTriggers(sqlCtx, engine, src, skipped, writer)
 	if err != nil {
 		return errhand.VerboseErrorFromError(err)
 	}
 
-	err = dumpProcedures(sqlCtx, engine, root, writer)
+	err = dumpProcedures(sqlCtx, engine, src, writer)
 	if err != nil {
 		return errhand.VerboseErrorFromError(err)
 	}
@@ -253,8 +314,8 @@ func dumpSchemaElements(ctx context.Context, dEnv *env.DoltEnv, path string) err
 	return nil
 }
 
-func dumpProcedures(sqlCtx *sql.Context, engine *engine.SqlEngine, root doltdb.RootValue, writer io.WriteCloser) (rerr error) {
-	_, _, ok, err := doltdb.GetTableInsensitive(sqlCtx, root, doltdb.TableName{Name: doltdb.ProceduresTableName})
+func dumpProcedures(sqlCtx *sql.Context, engine *engine.SqlEngine, src *dumpSource, writer io.WriteCloser) (rerr error) {
+	_, _, ok, err := doltdb.GetTableInsensitive(sqlCtx, src.root, doltdb.TableName{Name: doltdb.ProceduresTableName})
 	if err != nil {
 		return err
 	}
@@ -263,7 +324,7 @@ func dumpProcedures(sqlCtx *sql.Context, engine *engine.SqlEngine, root doltdb.R
 		return nil
 	}
 
-	sch, iter, _, err := engine.Query(sqlCtx, "select * from "+doltdb.ProceduresTableName)
+	sch, iter, _, err := engine.Query(sqlCtx, "select * from "+doltdb.ProceduresTableName+src.asOfClause())
 	if err != nil {
 		return err
 	}
@@ -324,8 +385,8 @@ func dumpProcedures(sqlCtx *sql.Context, engine *engine.SqlEngine, root doltdb.R
 	return nil
 }
 
-func dumpTriggers(sqlCtx *sql.Context, engine *engine.SqlEngine, root doltdb.RootValue, writer io.WriteCloser) (rerr error) {
-	_, _, ok, err := doltdb.GetTableInsensitive(sqlCtx, root, doltdb.TableName{Name: doltdb.SchemasTableName})
+func dumpTriggers(sqlCtx *sql.Context, engine *engine.SqlEngine, src *dumpSource, skipped map[string]bool, writer io.WriteCloser) (rerr error) {
+	_, _, ok, err := doltdb.GetTableInsensitive(sqlCtx, src.root, doltdb.TableName{Name: doltdb.SchemasTableName})
 	if err != nil {
 		return err
 	}
@@ -334,7 +395,7 @@ func dumpTriggers(sqlCtx *sql.Context, engine *engine.SqlEngine, root doltdb.Roo
 		return nil
 	}
 
-	sch, iter, _, err := engine.Query(sqlCtx, "select * from "+doltdb.SchemasTableName)
+	sch, iter, _, err := engine.Query(sqlCtx, "select * from "+doltdb.SchemasTableName+src.asOfClause())
 	if err != nil {
 		return err
 	}
@@ -362,6 +423,9 @@ func dumpTriggers(sqlCtx *sql.Context, engine *engine.SqlEngine, root doltdb.Roo
 		if row[typeColIdx] != "trigger" {
 			continue
 		}
+		if dependsOnSkippedTables(row[fragColIdx].(string), skipped) {
+			continue
+		}
 
 		sqlMode := ""
 		if sqlModeIdx >= 0 {
@@ -390,8 +454,8 @@ func dumpTriggers(sqlCtx *sql.Context, engine *engine.SqlEngine, root doltdb.Roo
 	return nil
 }
 
-func dumpViews(ctx *sql.Context, engine *engine.SqlEngine, root doltdb.RootValue, writer io.WriteCloser) (rerr error) {
-	_, _, ok, err := doltdb.GetTableInsensitive(ctx, root, doltdb.TableName{Name: doltdb.SchemasTableName})
+func dumpViews(ctx *sql.Context, engine *engine.SqlEngine, src *dumpSource, skipped map[string]bool, writer io.WriteCloser) (rerr error) {
+	_, _, ok, err := doltdb.GetTableInsensitive(ctx, src.root, doltdb.TableName{Name: doltdb.SchemasTableName})
 	if err != nil {
 		return err
 	}
@@ -400,7 +464,7 @@ func dumpViews(ctx *sql.Context, engine *engine.SqlEngine, root doltdb.RootValue
 		return nil
 	}
 
-	sch, iter, _, err := engine.Query(ctx, "select * from "+doltdb.SchemasTableName)
+	sch, iter, _, err := engine.Query(ctx, "select * from "+doltdb.SchemasTableName+src.asOfClause())
 	if err != nil {
 		return err
 	}
@@ -429,6 +493,9 @@ func dumpViews(ctx *sql.Context, engine *engine.SqlEngine, root doltdb.RootValue
 		if row[typeColIdx] != "view" {
 			continue
 		}
+		if dependsOnSkippedTables(row[fragColIdx].(string), skipped) {
+			continue
+		}
 
 		sqlMode := ""
 		if sqlModeIdx >= 0 {
@@ -527,6 +594,8 @@ type tableOptions struct {
 	dest          mvdata.DataLocation
 	batched       bool
 	autocommitOff bool
+	root          doltdb.RootValue
+	readOpts      mvdata.TableReadOptions
 }
 
 func (m tableOptions) IsBatched() bool {
@@ -561,7 +630,7 @@ func (m dumpOptions) DumpDestName() string {
 
 // dumpTable dumps table in file given specific table and file location info
 func dumpTable(ctx context.Context, dEnv *env.DoltEnv, tblOpts *tableOptions, filePath string) errhand.VerboseError {
-	rd, err := mvdata.NewSqlEngineReader(ctx, dEnv, tblOpts.tableName)
+	rd, err := mvdata.NewSqlEngineReaderWithOptions(ctx, dEnv, tblOpts.tableName, tblOpts.readOpts)
 	if err != nil {
 		return errhand.BuildDError("Error creating reader for %s.", tblOpts.SrcName()).AddCause(err).Build()
 	}
@@ -602,12 +671,7 @@ func getTableWriter(ctx context.Context, dEnv *env.DoltEnv, tblOpts *tableOption
 		return nil, errhand.BuildDError("Error opening writer for %s.", tblOpts.DestName()).AddCause(err).Build()
 	}
 
-	root, err := dEnv.WorkingRoot(ctx)
-	if err != nil {
-		return nil, errhand.BuildDError("Could not create table writer for %s", tblOpts.tableName).AddCause(err).Build()
-	}
-
-	wr, err := tblOpts.dest.NewCreatingWriter(ctx, tblOpts, root, outSch, opts, writer)
+	wr, err := tblOpts.dest.NewCreatingWriter(ctx, tblOpts, tblOpts.root, outSch, opts, writer)
 	if err != nil {
 		return nil, errhand.BuildDError("Could not create table writer for %s", tblOpts.tableName).AddCause(err).Build()
 	}
@@ -691,13 +755,33 @@ func validateDumpArgs(apr *argparser.ArgParseResults) (string, errhand.VerboseEr
 	if fnOk && dnOk {
 		return emptyStr, errhand.BuildDError("cannot pass both directory and file names").SetPrintUsage().Build()
 	}
+	if apr.Contains(allBranchesFlag) {
+		if rf != emptyFileExt && rf != sqlFileExt {
+			return emptyStr, errhand.BuildDError("%s is only supported for %s exports", allBranchesFlag, sqlFileExt).SetPrintUsage().Build()
+		}
+		if fnOk {
+			return emptyStr, errhand.BuildDError("%s is not supported with %s, which writes a file per branch", filenameFlag, allBranchesFlag).SetPrintUsage().Build()
+		}
+		if apr.Contains(dumpAsOfFlag) {
+			return emptyStr, errhand.BuildDError("%s and %s are mutually exclusive", dumpAsOfFlag, allBranchesFlag).SetPrintUsage().Build()
+		}
+		return dn, nil
+	}
 	switch rf {
 	case emptyFileExt, sqlFileExt:
 		if dnOk {
 			return emptyStr, errhand.BuildDError("%s is not supported for %s exports", directoryFlag, sqlFileExt).SetPrintUsage().Build()
 		}
 		return fn, nil
-	case csvFileExt, jsonFileExt, parquetFileExt:
+	case xlsxFileExt:
+		if dnOk {
+			return emptyStr, errhand.BuildDError("%s is not supported for %s exports", directoryFlag, xlsxFileExt).SetPrintUsage().Build()
+		}
+		if snOk {
+			return emptyStr, errhand.BuildDError("%s dump is not supported for %s exports", schemaOnlyFlag, xlsxFileExt).SetPrintUsage().Build()
+		}
+		return fn, nil
+	case csvFileExt, jsonFileExt, jsonlFileExt, parquetFileExt:
 		if fnOk {
 			return emptyStr, errhand.BuildDError("%s is not supported for %s exports", filenameFlag, rf).SetPrintUsage().Build()
 		}
@@ -723,7 +807,7 @@ func getDumpOptions(fileName string, rf string, schemaOnly bool) *dumpOptions {
 
 // newTableArgs returns tableOptions of table name and src table location and dest file location
 // corresponding to the input parameters
-func newTableArgs(tblName string, destination mvdata.DataLocation, batched, autocommitOff, schemaOnly bool) *tableOptions {
+func newTableArgs(src *dumpSource, tblName string, destination mvdata.DataLocation, batched, autocommitOff, schemaOnly bool) *tableOptions {
 	if schemaOnly {
 		batched = false
 	}
@@ -733,12 +817,14 @@ func newTableArgs(tblName string, destination mvdata.DataLocation, batched, auto
 		dest:          destination,
 		batched:       batched,
 		autocommitOff: autocommitOff,
+		root:          src.root,
+		readOpts:      src.readOptions(tblName),
 	}
 }
 
 // dumpNonSqlTables returns nil if all tables is dumped successfully, and it returns err if there is one.
-// It handles only csv and json file types(rf).
-func dumpNonSqlTables(ctx context.Context, root doltdb.RootValue, dEnv *env.DoltEnv, force bool, tblNames []string, rf string, dirName string, batched bool) errhand.VerboseError {
+// It handles only csv, json, jsonl and parquet file types(rf).
+func dumpNonSqlTables(ctx context.Context, src *dumpSource, dEnv *env.DoltEnv, force bool, tblNames []string, rf string, dirName string, batched bool) errhand.VerboseError {
 	var fName string
 	if dirName == emptyStr {
 		dirName = "doltdump/"
@@ -752,12 +838,12 @@ func dumpNonSqlTables(ctx context.Context, root doltdb.RootValue, dEnv *env.Dolt
 		fName = fmt.Sprintf("%s%s.%s", dirName, tbl, rf)
 		dumpOpts := getDumpOptions(fName, rf, false)
 
-		fPath, err := checkAndCreateOpenDestFile(ctx, root, dEnv, force, dumpOpts, fName)
+		fPath, err := checkAndCreateOpenDestFile(ctx, src.root, dEnv, force, dumpOpts, fName)
 		if err != nil {
 			return err
 		}
 
-		tblOpts := newTableArgs(tbl, dumpOpts.dest, batched, false, false)
+		tblOpts := newTableArgs(src, tbl, dumpOpts.dest, batched, false, false)
 
 		err = dumpTable(ctx, dEnv, tblOpts, fPath)
 		if err != nil {
@@ -767,6 +853,58 @@ func dumpNonSqlTables(ctx context.Context, root doltdb.RootValue, dEnv *env.Dolt
 	return nil
 }
 
+// dumpXlsxTables returns nil if all tables are dumped successfully to the sheets of a single xlsx workbook, each sheet
+// named after its table as far as Excel allows, and it returns err if there is one.
+func dumpXlsxTables(ctx context.Context, src *dumpSource, dEnv *env.DoltEnv, force bool, tblNames []string, fileName string) errhand.VerboseError {
+	if fileName == emptyStr {
+		fileName = "doltdump.xlsx"
+	} else if !strings.HasSuffix(fileName, ".xlsx") {
+		fileName = fmt.Sprintf("%s.xlsx", fileName)
+	}
+
+	dumpOpts := getDumpOptions(fileName, xlsxFileExt, false)
+	fPath, verr := checkAndCreateOpenDestFile(ctx, src.root, dEnv, force, dumpOpts, fileName)
+	if verr != nil {
+		return verr
+	}
+
+	writer, err := dEnv.FS.OpenForWrite(fPath, os.ModePerm)
+	if err != nil {
+		return errhand.BuildDError("Error opening writer for %s.", fileName).AddCause(err).Build()
+	}
+
+	// the workbook is only written once every table has been added to it
+	wbw := xlsx.NewXLSXWorkbookWriter(writer)
+	for _, tbl := range tblNames {
+		rd, err := mvdata.NewSqlEngineReaderWithOptions(ctx, dEnv, tbl, src.readOptions(tbl))
+		if err != nil {
+			writer.Close()
+			return errhand.BuildDError("Error creating reader for %s.", tbl).AddCause(err).Build()
+		}
+
+		wr, err := wbw.NewSheetWriter(tbl, rd.GetSchema())
+		if err != nil {
+			rd.Close(ctx)
+			writer.Close()
+			return errhand.BuildDError("Could not create table writer for %s", tbl).AddCause(err).Build()
+		}
+		if wr.SheetName() != tbl {
+			cli.PrintErrf("Dumping table %s to sheet %s\n", tbl, wr.SheetName())
+		}
+
+		err = mvdata.NewDataMoverPipeline(ctx, rd, wr).Execute()
+		if err != nil {
+			writer.Close()
+			return errhand.BuildDError("Error with dumping %s.", tbl).AddCause(err).Build()
+		}
+	}
+
+	if err = wbw.Close(); err != nil {
+		return errhand.BuildDError("Error writing %s.", fileName).AddCause(err).Build()
+	}
+	return nil
+}
+
 // addBulkLoadingParadigms adds statements that are used to expedite dump file ingestion.
 // cc. https://dev.mysql.com/doc/refman/8.0/en/optimizing-innodb-bulk-data-loading.html
 // This includes turning off FOREIGN_KEY_CHECKS and UNIQUE_CHECKS off at the beginning of the file.
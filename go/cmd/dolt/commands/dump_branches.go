@@ -0,0 +1,451 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands/engine"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/set"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+const defaultBranchDumpDir = "doltdump"
+
+// dumpSnapshot is a commit dumped by --all-branches, along with the tables, views and procedures of it which are dumped.
+type dumpSnapshot struct {
+	commit   *doltdb.Commit
+	src      *dumpSource
+	tblNames []string
+	views    []string
+	procs    []string
+}
+
+// branchDump is the head commit of a branch dumped by --all-branches.
+type branchDump struct {
+	name string
+	head *dumpSnapshot
+	// base is the commit the branch is created from, its merge base with the current branch. It's nil for the current
+	// branch.
+	base *dumpSnapshot
+}
+
+// dumpAllBranches dumps the head commit of every branch to its own sql file under |dirName|. The file of the current
+// branch rebuilds the merge base of every other branch with it, creates the other branches at their merge bases, and
+// then rebuilds its own head commit. The file of every other branch checks out the branch and rebuilds its head commit.
+// A commit is rebuilt by replacing the tables, views and procedures of the branch with those of the commit, and
+// committing them with the message, author and date of the commit.
+func dumpAllBranches(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults, force, schemaOnly bool, dirName string) errhand.VerboseError {
+	if dirName == emptyStr {
+		dirName = defaultBranchDumpDir
+	}
+
+	wheres, verr := parseDumpWheres(apr)
+	if verr != nil {
+		return verr
+	}
+
+	current, branches, bases, verr := getBranchDumps(ctx, dEnv, apr, wheres)
+	if verr != nil {
+		return verr
+	}
+
+	// every table, view and procedure of any commit is dropped before a commit is rebuilt, so that a commit rebuilt
+	// after another doesn't keep the other's
+	allTables, allViews, allProcs := set.NewStrSet(nil), set.NewStrSet(nil), set.NewStrSet(nil)
+	var dumped []string
+	for _, snap := range append(bases, current.head) {
+		allTables.Add(snap.tblNames...)
+		allViews.Add(snap.views...)
+		allProcs.Add(snap.procs...)
+		dumped = append(dumped, snap.tblNames...)
+	}
+	for _, b := range branches {
+		allTables.Add(b.head.tblNames...)
+		allViews.Add(b.head.views...)
+		allProcs.Add(b.head.procs...)
+		dumped = append(dumped, b.head.tblNames...)
+	}
+	if verr = validateDumpWheres(wheres, dumped); verr != nil {
+		return verr
+	}
+	d := &branchDumper{
+		ctx:        ctx,
+		dEnv:       dEnv,
+		apr:        apr,
+		force:      force,
+		schemaOnly: schemaOnly,
+		dirName:    dirName,
+		allTables:  allTables,
+		allViews:   allViews,
+		allProcs:   allProcs,
+	}
+	if !apr.Contains(noCreateDbFlag) {
+		d.dbName, verr = getActiveDatabaseName(ctx, dEnv)
+		if verr != nil {
+			return verr
+		}
+	}
+
+	// the current branch is checked out with -B, since a new database may not have it
+	fPath, verr := d.createFile(current, fmt.Sprintf("CALL dolt_checkout('-B', %s);", sqlfmt.QuoteString(current.name)))
+	if verr != nil {
+		return verr
+	}
+	for _, base := range bases {
+		if verr = d.appendSnapshot(fPath, base); verr != nil {
+			return verr
+		}
+		if verr = appendStatements(dEnv, fPath, createBranchStatements(branches, base)); verr != nil {
+			return verr
+		}
+	}
+	if verr = d.appendSnapshot(fPath, current.head); verr != nil {
+		return verr
+	}
+	if verr = appendStatements(dEnv, fPath, createBranchStatements(branches, current.head)); verr != nil {
+		return verr
+	}
+	cli.PrintErrf("Dumped branch %s to %s\n", current.name, d.fileName(current))
+
+	for _, b := range branches {
+		fPath, verr := d.createFile(b, fmt.Sprintf("CALL dolt_checkout(%s);", sqlfmt.QuoteString(b.name)))
+		if verr != nil {
+			return verr
+		}
+		// a branch whose head is its merge base was created at its head
+		if b.head.commit != b.base.commit {
+			if verr = d.appendSnapshot(fPath, b.head); verr != nil {
+				return verr
+			}
+		}
+		cli.PrintErrf("Dumped branch %s to %s\n", b.name, d.fileName(b))
+	}
+
+	return nil
+}
+
+// branchDumper writes the files of the branches dumped by --all-branches.
+type branchDumper struct {
+	ctx        context.Context
+	dEnv       *env.DoltEnv
+	apr        *argparser.ArgParseResults
+	force      bool
+	schemaOnly bool
+	dirName    string
+	dbName     string
+
+	allTables, allViews, allProcs *set.StrSet
+}
+
+// fileName returns the name of the file of |b|.
+func (d *branchDumper) fileName(b *branchDump) string {
+	return filepath.Join(d.dirName, filepath.FromSlash(b.name)+".sql")
+}
+
+// createFile creates the file of |b|, which creates the database if it's named, checks out the branch with
+// |checkoutStmt| and disables checks which slow bulk loading.
+func (d *branchDumper) createFile(b *branchDump, checkoutStmt string) (string, errhand.VerboseError) {
+	fileName := d.fileName(b)
+	fPath, verr := checkAndCreateOpenDestFile(d.ctx, b.head.src.root, d.dEnv, d.force, getDumpOptions(fileName, sqlFileExt, d.schemaOnly), fileName)
+	if verr != nil {
+		return "", verr
+	}
+
+	if d.dbName != "" {
+		if verr = addCreateDatabaseHeader(d.dEnv, fPath, d.dbName); verr != nil {
+			return "", verr
+		}
+	}
+	if verr = appendStatements(d.dEnv, fPath, []string{checkoutStmt}); verr != nil {
+		return "", verr
+	}
+	if verr = addBulkLoadingParadigms(d.dEnv, fPath); verr != nil {
+		return "", verr
+	}
+	return fPath, nil
+}
+
+// appendSnapshot appends the statements rebuilding |snap| on the checked out branch to the file at |fPath|.
+func (d *branchDumper) appendSnapshot(fPath string, snap *dumpSnapshot) errhand.VerboseError {
+	if verr := appendStatements(d.dEnv, fPath, dropStatements(snap, d.allTables, d.allViews, d.allProcs)); verr != nil {
+		return verr
+	}
+
+	dumpOpts := getDumpOptions(fPath, sqlFileExt, d.schemaOnly)
+	if verr := dumpSqlTables(d.ctx, d.dEnv, d.apr, snap.src, dumpOpts, snap.tblNames, fPath); verr != nil {
+		return verr
+	}
+
+	stmt, err := commitStatement(d.ctx, snap.commit)
+	if err != nil {
+		return errhand.BuildDError("error: failed to read commit %s", snap.src.asOf).AddCause(err).Build()
+	}
+	return appendStatements(d.dEnv, fPath, []string{stmt})
+}
+
+// createBranchStatements returns the statements creating the branches of |branches| whose merge base is |base| at the
+// head of the checked out branch.
+func createBranchStatements(branches []*branchDump, base *dumpSnapshot) []string {
+	var stmts []string
+	for _, b := range branches {
+		if b.base == base {
+			stmts = append(stmts, fmt.Sprintf("CALL dolt_branch('-f', %s);", sqlfmt.QuoteString(b.name)))
+		}
+	}
+	return stmts
+}
+
+// getBranchDumps returns the head commit of the current branch and of every other branch of |dEnv|, read from a single
+// snapshot of the branches, along with the merge bases of the other branches with the current branch, other than the
+// head of the current branch, ordered by their height.
+func getBranchDumps(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults, wheres map[string]string) (*branchDump, []*branchDump, []*dumpSnapshot, errhand.VerboseError) {
+	ddb := dEnv.DoltDB(ctx)
+	refs, err := ddb.GetBranchesWithHashes(ctx)
+	if err != nil {
+		return nil, nil, nil, errhand.BuildDError("error: failed to read branches").AddCause(err).Build()
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i].Ref.GetPath() < refs[j].Ref.GetPath()
+	})
+	headRef, err := dEnv.RepoStateReader().CWBHeadRef()
+	if err != nil {
+		return nil, nil, nil, errhand.BuildDError("error: --%s requires a branch to be checked out", allBranchesFlag).AddCause(err).Build()
+	}
+
+	eng, engDbName, err := engine.NewSqlEngineForEnv(ctx, dEnv)
+	if err != nil {
+		return nil, nil, nil, errhand.VerboseErrorFromError(err)
+	}
+	defer eng.Close()
+
+	sqlCtx, err := eng.NewLocalContext(ctx)
+	if err != nil {
+		return nil, nil, nil, errhand.VerboseErrorFromError(err)
+	}
+	sqlCtx.SetCurrentDatabase(engDbName)
+
+	snapshots := make(map[hash.Hash]*dumpSnapshot)
+	readSnapshot := func(cm *doltdb.Commit) (*dumpSnapshot, errhand.VerboseError) {
+		h, err := cm.HashOf()
+		if err != nil {
+			return nil, errhand.VerboseErrorFromError(err)
+		}
+		if snap, ok := snapshots[h]; ok {
+			return snap, nil
+		}
+		snap, verr := getDumpSnapshot(ctx, sqlCtx, eng, apr, cm, h, wheres)
+		if verr != nil {
+			return nil, verr
+		}
+		snapshots[h] = snap
+		return snap, nil
+	}
+
+	var current *branchDump
+	var branches []*branchDump
+	for _, r := range refs {
+		name := r.Ref.GetPath()
+		optCmt, err := ddb.ReadCommit(ctx, r.Hash)
+		if err != nil {
+			return nil, nil, nil, errhand.BuildDError("error: failed to read the head commit of branch '%s'", name).AddCause(err).Build()
+		}
+		cm, ok := optCmt.ToCommit()
+		if !ok {
+			return nil, nil, nil, errhand.BuildDError("error: the head commit of branch '%s' is a ghost commit", name).Build()
+		}
+		head, verr := readSnapshot(cm)
+		if verr != nil {
+			return nil, nil, nil, verr
+		}
+
+		b := &branchDump{name: name, head: head}
+		if name == headRef.GetPath() {
+			current = b
+		} else {
+			branches = append(branches, b)
+		}
+	}
+	if current == nil {
+		return nil, nil, nil, errhand.BuildDError("error: the current branch '%s' was not found", headRef.GetPath()).Build()
+	}
+
+	var bases []*dumpSnapshot
+	for _, b := range branches {
+		optCmt, err := doltdb.GetCommitAncestor(ctx, b.head.commit, current.head.commit)
+		if err != nil {
+			return nil, nil, nil, errhand.BuildDError("error: failed to find the merge base of branches '%s' and '%s'", b.name, current.name).AddCause(err).Build()
+		}
+		cm, ok := optCmt.ToCommit()
+		if !ok {
+			return nil, nil, nil, errhand.BuildDError("error: the merge base of branches '%s' and '%s' is a ghost commit", b.name, current.name).Build()
+		}
+		var verr errhand.VerboseError
+		if b.base, verr = readSnapshot(cm); verr != nil {
+			return nil, nil, nil, verr
+		}
+		if b.base != current.head && !containsSnapshot(bases, b.base) {
+			bases = append(bases, b.base)
+		}
+	}
+
+	heights := make(map[*dumpSnapshot]uint64, len(bases))
+	for _, base := range bases {
+		if heights[base], err = base.commit.Height(); err != nil {
+			return nil, nil, nil, errhand.VerboseErrorFromError(err)
+		}
+	}
+	sort.SliceStable(bases, func(i, j int) bool {
+		return heights[bases[i]] < heights[bases[j]]
+	})
+	return current, branches, bases, nil
+}
+
+// containsSnapshot returns whether |snaps| contains |snap|.
+func containsSnapshot(snaps []*dumpSnapshot, snap *dumpSnapshot) bool {
+	for _, s := range snaps {
+		if s == snap {
+			return true
+		}
+	}
+	return false
+}
+
+// getDumpSnapshot returns the snapshot of |cm|, whose hash is |h|, with the tables, views and procedures of it which
+// are dumped.
+func getDumpSnapshot(ctx context.Context, sqlCtx *sql.Context, eng *engine.SqlEngine, apr *argparser.ArgParseResults, cm *doltdb.Commit, h hash.Hash, wheres map[string]string) (*dumpSnapshot, errhand.VerboseError) {
+	root, err := cm.GetRootValue(ctx)
+	if err != nil {
+		return nil, errhand.BuildDError("error: failed to read commit %s", h.String()).AddCause(err).Build()
+	}
+
+	src := &dumpSource{root: root, asOf: h.String(), wheres: wheres}
+	allNames, err := doltdb.GetNonSystemTableNames(ctx, root)
+	if err != nil {
+		return nil, errhand.BuildDError("error: failed to get tables").AddCause(err).Build()
+	}
+	tblNames, verr := filterDumpTableNames(allNames, apr)
+	if verr != nil {
+		return nil, verr
+	}
+
+	views, err := querySchemaElementNames(sqlCtx, eng, src, doltdb.SchemasTableName, "type = 'view'")
+	if err != nil {
+		return nil, errhand.VerboseErrorFromError(err)
+	}
+	procs, err := querySchemaElementNames(sqlCtx, eng, src, doltdb.ProceduresTableName, "")
+	if err != nil {
+		return nil, errhand.VerboseErrorFromError(err)
+	}
+
+	return &dumpSnapshot{commit: cm, src: src, tblNames: tblNames, views: views, procs: procs}, nil
+}
+
+// querySchemaElementNames returns the names in the system table |sysTable| of |src| which satisfy |filter|.
+func querySchemaElementNames(sqlCtx *sql.Context, eng *engine.SqlEngine, src *dumpSource, sysTable, filter string) ([]string, error) {
+	_, _, ok, err := doltdb.GetTableInsensitive(sqlCtx, src.root, doltdb.TableName{Name: sysTable})
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	query := "select name from " + sysTable + src.asOfClause()
+	if filter != "" {
+		query += " where " + filter
+	}
+	_, iter, _, err := eng.Query(sqlCtx, query)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := sql.RowIterToRows(sqlCtx, iter)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(rows))
+	for _, row := range rows {
+		names = append(names, row[0].(string))
+	}
+	return names, nil
+}
+
+// dropStatements returns the statements dropping the views and procedures of every dumped commit, and the tables of
+// every dumped commit which aren't dumped for |snap|. The tables dumped for |snap| are dropped as they're dumped.
+func dropStatements(snap *dumpSnapshot, allTables, allViews, allProcs *set.StrSet) []string {
+	dumped := set.NewStrSet(snap.tblNames)
+
+	var stmts []string
+	for _, view := range allViews.AsSortedSlice() {
+		stmts = append(stmts, fmt.Sprintf("DROP VIEW IF EXISTS %s;", sqlfmt.QuoteIdentifier(view)))
+	}
+	for _, proc := range allProcs.AsSortedSlice() {
+		stmts = append(stmts, fmt.Sprintf("DROP PROCEDURE IF EXISTS %s;", sqlfmt.QuoteIdentifier(proc)))
+	}
+	for _, tbl := range allTables.AsSortedSlice() {
+		if !dumped.Contains(tbl) {
+			stmts = append(stmts, sqlfmt.DropTableIfExistsStmt(tbl))
+		}
+	}
+	return stmts
+}
+
+// commitStatement returns the statement committing a dumped branch with the message, author and date of |cm|.
+func commitStatement(ctx context.Context, cm *doltdb.Commit) (string, error) {
+	meta, err := cm.GetCommitMeta(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	author := fmt.Sprintf("%s <%s>", meta.Name, meta.Email)
+	date := meta.Time().UTC().Format(time.RFC3339)
+	return fmt.Sprintf("CALL dolt_commit('-A', '--allow-empty', '-m', %s, '--author', %s, '--date', %s);",
+		sqlfmt.QuoteString(meta.Description), sqlfmt.QuoteString(author), sqlfmt.QuoteString(date)), nil
+}
+
+// appendStatements appends |stmts|, one per line, to the file at |fPath|.
+func appendStatements(dEnv *env.DoltEnv, fPath string, stmts []string) errhand.VerboseError {
+	if len(stmts) == 0 {
+		return nil
+	}
+
+	writer, err := dEnv.FS.OpenForWriteAppend(fPath, os.ModePerm)
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+
+	_, err = writer.Write([]byte(strings.Join(stmts, "\n") + "\n"))
+	if err != nil {
+		writer.Close()
+		return errhand.VerboseErrorFromError(err)
+	}
+
+	if err = writer.Close(); err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+	return nil
+}
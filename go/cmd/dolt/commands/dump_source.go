@@ -0,0 +1,247 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/mvdata"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+// dumpSource is the root value dumped, along with the filters of the rows of its tables.
+type dumpSource struct {
+	root doltdb.RootValue
+	// asOf is the commit, branch or tag |root| is read at. When empty, |root| is the working set.
+	asOf string
+	// wheres are the SQL expressions the dumped rows of a table must satisfy, keyed by lowercase table name.
+	wheres map[string]string
+}
+
+// readOptions returns the options for reading the dumped rows of |tblName|.
+func (src *dumpSource) readOptions(tblName string) mvdata.TableReadOptions {
+	return mvdata.TableReadOptions{AsOf: src.asOf, Where: src.wheres[strings.ToLower(tblName)]}
+}
+
+// asOfClause returns the AS OF clause of queries of |src|'s system tables.
+func (src *dumpSource) asOfClause() string {
+	if src.asOf == "" {
+		return ""
+	}
+	return fmt.Sprintf(" AS OF '%s'", strings.ReplaceAll(src.asOf, "'", "''"))
+}
+
+// getDumpSource returns the source of a dump, which is the commit, branch or tag given with --as-of, or the working
+// set.
+func getDumpSource(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) (*dumpSource, errhand.VerboseError) {
+	wheres, verr := parseDumpWheres(apr)
+	if verr != nil {
+		return nil, verr
+	}
+
+	asOf, ok := apr.GetValue(dumpAsOfFlag)
+	if !ok {
+		root, verr := GetWorkingWithVErr(dEnv)
+		if verr != nil {
+			return nil, verr
+		}
+		return &dumpSource{root: root, wheres: wheres}, nil
+	}
+
+	cm, verr := MaybeGetCommitWithVErr(dEnv, asOf)
+	if verr != nil {
+		return nil, verr
+	}
+	if cm == nil {
+		return nil, errhand.BuildDError("error: '%s' is not a commit, branch or tag", asOf).Build()
+	}
+	root, err := cm.GetRootValue(ctx)
+	if err != nil {
+		return nil, errhand.BuildDError("error: failed to read '%s'", asOf).AddCause(err).Build()
+	}
+	return &dumpSource{root: root, asOf: asOf, wheres: wheres}, nil
+}
+
+// parseDumpWheres parses the --where filters, which are <table>:<expr> pairs given once for each table, into a map of
+// lowercase table name to expression.
+func parseDumpWheres(apr *argparser.ArgParseResults) (map[string]string, errhand.VerboseError) {
+	wheres := make(map[string]string)
+	filters, ok := apr.GetRepeatedValue(dumpWhereFlag)
+	if !ok {
+		return wheres, nil
+	}
+
+	for _, filter := range filters {
+		tbl, expr, found := strings.Cut(filter, ":")
+		tbl, expr = strings.ToLower(strings.TrimSpace(tbl)), strings.TrimSpace(expr)
+		if !found || tbl == "" || expr == "" {
+			return nil, errhand.BuildDError("error: invalid --%s filter '%s', expected <table>:<expr>", dumpWhereFlag, filter).Build()
+		}
+		if _, ok := wheres[tbl]; ok {
+			return nil, errhand.BuildDError("error: more than one --%s filter is given for table '%s'", dumpWhereFlag, tbl).Build()
+		}
+		wheres[tbl] = expr
+	}
+	return wheres, nil
+}
+
+// getDumpTableNames returns the names of the tables of |src| selected by --tables and --exclude-tables.
+func getDumpTableNames(ctx context.Context, src *dumpSource, apr *argparser.ArgParseResults) ([]string, errhand.VerboseError) {
+	allNames, err := doltdb.GetNonSystemTableNames(ctx, src.root)
+	if err != nil {
+		return nil, errhand.BuildDError("error: failed to get tables").AddCause(err).Build()
+	}
+
+	tblNames, verr := filterDumpTableNames(allNames, apr)
+	if verr != nil {
+		return nil, verr
+	}
+	if verr = validateDumpWheres(src.wheres, tblNames); verr != nil {
+		return nil, verr
+	}
+	return tblNames, nil
+}
+
+// filterDumpTableNames returns the names in |tblNames| which match a pattern of --tables, if given, and don't match
+// any pattern of --exclude-tables. Patterns are matched case-insensitively.
+func filterDumpTableNames(tblNames []string, apr *argparser.ArgParseResults) ([]string, errhand.VerboseError) {
+	includes, verr := getDumpTablePatterns(apr, dumpTablesFlag)
+	if verr != nil {
+		return nil, verr
+	}
+	excludes, verr := getDumpTablePatterns(apr, excludeTblsFlag)
+	if verr != nil {
+		return nil, verr
+	}
+
+	var filtered []string
+	for _, name := range tblNames {
+		if (len(includes) == 0 || matchesAnyPattern(name, includes)) && !matchesAnyPattern(name, excludes) {
+			filtered = append(filtered, name)
+		}
+	}
+	return filtered, nil
+}
+
+func getDumpTablePatterns(apr *argparser.ArgParseResults, flag string) ([]string, errhand.VerboseError) {
+	vals, ok := apr.GetValueList(flag)
+	if !ok {
+		return nil, nil
+	}
+
+	var patterns []string
+	for _, pattern := range vals {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if pattern == "" {
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, errhand.BuildDError("error: invalid table pattern '%s' given with --%s", pattern, flag).Build()
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+func matchesAnyPattern(tblName string, patterns []string) bool {
+	tblName = strings.ToLower(tblName)
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, tblName); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// validateDumpWheres returns an error if a --where filter is given for a table which isn't dumped.
+func validateDumpWheres(wheres map[string]string, tblNames []string) errhand.VerboseError {
+	dumped := make(map[string]bool, len(tblNames))
+	for _, name := range tblNames {
+		dumped[strings.ToLower(name)] = true
+	}
+
+	var missing []string
+	for tbl := range wheres {
+		if !dumped[tbl] {
+			missing = append(missing, tbl)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return errhand.BuildDError("error: --%s is given for tables which aren't dumped: %s", dumpWhereFlag, strings.Join(missing, ", ")).Build()
+	}
+	return nil
+}
+
+// getSkippedTables returns the lowercase names of the tables of |src| which aren't in |tblNames|.
+func getSkippedTables(ctx context.Context, src *dumpSource, tblNames []string) (map[string]bool, error) {
+	allNames, err := doltdb.GetNonSystemTableNames(ctx, src.root)
+	if err != nil {
+		return nil, err
+	}
+
+	skipped := make(map[string]bool)
+	for _, name := range allNames {
+		skipped[strings.ToLower(name)] = true
+	}
+	for _, name := range tblNames {
+		delete(skipped, strings.ToLower(name))
+	}
+	return skipped, nil
+}
+
+// dependsOnSkippedTables returns whether the view or trigger defined by |fragment| depends on a table in |skipped|. A
+// trigger depends on the table it's defined on, and a view on the tables it selects from. Fragments which can't be
+// parsed are assumed not to depend on skipped tables.
+func dependsOnSkippedTables(fragment string, skipped map[string]bool) bool {
+	if len(skipped) == 0 {
+		return false
+	}
+
+	stmt, err := sqlparser.Parse(fragment)
+	if err != nil {
+		return false
+	}
+	ddl, ok := stmt.(*sqlparser.DDL)
+	if !ok {
+		return false
+	}
+
+	if ddl.TriggerSpec != nil {
+		return skipped[strings.ToLower(ddl.Table.Name.String())]
+	}
+	if ddl.ViewSpec == nil || ddl.ViewSpec.ViewExpr == nil {
+		return false
+	}
+
+	depends := false
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		if tn, ok := node.(sqlparser.TableName); ok && skipped[strings.ToLower(tn.Name.String())] {
+			depends = true
+			return false, nil
+		}
+		return true, nil
+	}, ddl.ViewSpec.ViewExpr)
+	return depends
+}
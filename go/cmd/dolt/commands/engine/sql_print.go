@@ -45,6 +45,7 @@ const (
 	FormatNull // used for profiling
 	FormatVertical
 	FormatParquet
+	FormatJsonl
 )
 
 type PrintSummaryBehavior byte
@@ -107,6 +108,12 @@ func prettyPrintResultsWithSummary(ctx *sql.Context, resultFormat PrintResultFor
 			if err != nil {
 				return
 			}
+		case FormatJsonl:
+			var err error
+			wr, err = json.NewJSONLSqlWriter(iohelp.NopWrCloser(writerStream), sqlSch)
+			if err != nil {
+				return
+			}
 		case FormatTabular:
 			wr = tabular.NewFixedWidthTableWriter(sqlSch, iohelp.NopWrCloser(writerStream), 100)
 		case FormatNull:
@@ -36,6 +36,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
 	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/dolt_ci"
 	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
 	dsqle "github.com/dolthub/dolt/go/libraries/doltcore/sqle"
@@ -83,6 +84,7 @@ type SqlEngineConfig struct {
 	SystemVariables            SystemVariables
 	ClusterController          *cluster.Controller
 	AutoGCController           *dsqle.AutoGCController
+	WorkflowRunController      *dolt_ci.WorkflowRunController
 	BinlogReplicaController    binlogreplication.BinlogReplicaController
 	EventSchedulerStatus       eventscheduler.SchedulerStatus
 }
@@ -216,6 +218,15 @@ func NewSqlEngine(
 		dprocedures.UseSessionAwareSafepointController = true
 	}
 
+	if config.WorkflowRunController != nil {
+		err = config.WorkflowRunController.RunBackgroundThread(bThreads, sqlEngine.NewDefaultContext, sqlEngine.Query)
+		if err != nil {
+			return nil, err
+		}
+		config.WorkflowRunController.ApplyCommitHooks(ctx, mrEnv, dbs...)
+		pro.InitDatabaseHooks = append(pro.InitDatabaseHooks, config.WorkflowRunController.InitDatabaseHook())
+	}
+
 	engine.Analyzer.ExecBuilder = rowexec.NewOverrideBuilder(kvexec.Builder{})
 	sessFactory := doltSessionFactory(pro, statsPro, mrEnv.Config(), bcController, gcSafepointController, config.Autocommit)
 	sqlEngine.provider = pro
@@ -41,7 +41,9 @@ copied during a prior garbage collection.
 
 If the {{.EmphasisLeft}}--shallow{{.EmphasisRight}} flag is supplied, a faster but less thorough garbage collection will be performed.
 
-If the {{.EmphasisLeft}}--full{{.EmphasisRight}} flag is supplied, a more thorough garbage collection, fully collecting the old gen and new gen, will be performed.`,
+If the {{.EmphasisLeft}}--full{{.EmphasisRight}} flag is supplied, a more thorough garbage collection, fully collecting the old gen and new gen, will be performed. When the repository has a cold tier, a full GC also collects the cold tier and moves the history behind commits older than {{.EmphasisLeft}}storage.cold_tier.after{{.EmphasisRight}} to it.
+
+When the database is encrypted at rest with the keys in {{.EmphasisLeft}}DOLT_ENCRYPTION_KEY{{.EmphasisRight}} or {{.EmphasisLeft}}DOLT_ENCRYPTION_KEY_FILE{{.EmphasisRight}}, {{.EmphasisLeft}}--full{{.EmphasisRight}} rewrites all of its data with the first key, including the table files in its cold tier. To rotate keys, put the new key before the old key, run {{.EmphasisLeft}}dolt gc --full{{.EmphasisRight}}, and then remove the old key.`,
 	Synopsis: []string{
 		"[--shallow|--full]",
 	},
@@ -49,10 +49,18 @@ var mergeDocs = cli.CommandDocumentationContent{
 The second syntax ({{.LessThan}}dolt merge --abort{{.GreaterThan}}) can only be run after the merge has resulted in conflicts. dolt merge {{.EmphasisLeft}}--abort{{.EmphasisRight}} will abort the merge process and try to reconstruct the pre-merge state. However, if there were uncommitted changes when the merge started (and especially if those changes were further modified after the merge was started), dolt merge {{.EmphasisLeft}}--abort{{.EmphasisRight}} will in some cases be unable to reconstruct the original (pre-merge) changes. Therefore: 
 
 {{.LessThan}}Warning{{.GreaterThan}}: Running dolt merge with non-trivial uncommitted changes is discouraged: while possible, it may leave you in a state that is hard to back out of in the case of a conflict.
+
+By default, conflicting changes to the same row are recorded as conflicts to be resolved before committing. {{.EmphasisLeft}}-X ours{{.EmphasisRight}} and {{.EmphasisLeft}}-X theirs{{.EmphasisRight}} instead resolve them with the current branch's or the merged branch's version of the row. {{.EmphasisLeft}}-s ours{{.EmphasisRight}} records the merge without taking any changes from the merged branch.
+
+The strategy for individual tables can be set by adding rows to the {{.EmphasisLeft}}dolt_merge_strategies{{.EmphasisRight}} system table, which has a {{.EmphasisLeft}}table_name{{.EmphasisRight}} column and a {{.EmphasisLeft}}strategy{{.EmphasisRight}} column. The strategy is one of {{.EmphasisLeft}}merge{{.EmphasisRight}}, {{.EmphasisLeft}}merge-ours{{.EmphasisRight}}, {{.EmphasisLeft}}merge-theirs{{.EmphasisRight}}, {{.EmphasisLeft}}ours{{.EmphasisRight}}, which keeps the current branch's version of the table, or {{.EmphasisLeft}}theirs{{.EmphasisRight}}, which takes the merged branch's version of the table. Listed tables use their strategy instead of the one chosen with {{.EmphasisLeft}}-X{{.EmphasisRight}}.
+
+Concurrent changes to a column can also be resolved by a SQL expression stored in the {{.EmphasisLeft}}dolt_conflict_resolvers{{.EmphasisRight}} system table, which has {{.EmphasisLeft}}table_name{{.EmphasisRight}}, {{.EmphasisLeft}}column_name{{.EmphasisRight}} and {{.EmphasisLeft}}resolver{{.EmphasisRight}} columns. The resolver refers to the column's values as {{.EmphasisLeft}}base{{.EmphasisRight}}, {{.EmphasisLeft}}ours{{.EmphasisRight}} and {{.EmphasisLeft}}theirs{{.EmphasisRight}}, for example {{.EmphasisLeft}}greatest(ours, theirs){{.EmphasisRight}} for a timestamp or {{.EmphasisLeft}}ours + theirs - base{{.EmphasisRight}} for a counter. {{.EmphasisLeft}}json_array_union(ours, theirs){{.EmphasisRight}} combines the elements of two JSON arrays. {{.EmphasisLeft}}base{{.EmphasisRight}} is NULL when both branches inserted the row.
+
+Concurrent changes to JSON objects are merged key by key. Concurrent changes to the same JSON array are conflicts unless {{.EmphasisLeft}}@@dolt_merge_json_arrays{{.EmphasisRight}} is set. Then arrays whose elements are all objects with a unique identity field are merged element by element, and other arrays are merged as sequences. The identity fields to try are listed in {{.EmphasisLeft}}@@dolt_json_array_identity_fields{{.EmphasisRight}}, separated by commas, and default to {{.EmphasisLeft}}id{{.EmphasisRight}}.
 `,
 
 	Synopsis: []string{
-		"[--squash] {{.LessThan}}branch{{.GreaterThan}}",
+		"[--squash] [-s {{.LessThan}}strategy{{.GreaterThan}}] [-X {{.LessThan}}option{{.GreaterThan}}] {{.LessThan}}branch{{.GreaterThan}}",
 		"--no-ff [-m message] {{.LessThan}}branch{{.GreaterThan}}",
 		"--abort",
 	},
@@ -308,6 +316,16 @@ func constructInterpolatedDoltMergeQuery(apr *argparser.ArgParseResults, cliCtx
 		}
 		params = append(params, date)
 	}
+	if strategy, ok := apr.GetValue(cli.StrategyParam); ok {
+		writeToBuffer("--strategy", false)
+		writeToBuffer("?", true)
+		params = append(params, strategy)
+	}
+	if option, ok := apr.GetValue(cli.StrategyOptionParam); ok {
+		writeToBuffer("--strategy-option", false)
+		writeToBuffer("?", true)
+		params = append(params, option)
+	}
 	if apr.Contains(cli.MessageArg) {
 		writeToBuffer("-m", false)
 		writeToBuffer("?", true)
@@ -380,7 +398,8 @@ func printMergeStats(fastForward bool,
 			cli.Println(err.Error())
 			return 1
 		}
-		if upToDate {
+		// a merge with the ours strategy records a merge commit without changing any data
+		if upToDate && !strings.EqualFold(apr.GetValueOrDefault(cli.StrategyParam, ""), merge.StrategyOurs) {
 			cli.Println(doltdb.ErrUpToDate.Error())
 			return 0
 		}
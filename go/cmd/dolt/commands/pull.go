@@ -257,6 +257,16 @@ func constructInterpolatedDoltPullQuery(apr *argparser.ArgParseResults) (string,
 	if apr.Contains(cli.PruneFlag) {
 		args = append(args, "'--prune'")
 	}
+	if strategy, ok := apr.GetValue(cli.StrategyParam); ok {
+		args = append(args, "'--strategy'")
+		args = append(args, "?")
+		params = append(params, strategy)
+	}
+	if option, ok := apr.GetValue(cli.StrategyOptionParam); ok {
+		args = append(args, "'--strategy-option'")
+		args = append(args, "?")
+		params = append(params, option)
+	}
 	if user, hasUser := apr.GetValue(cli.UserFlag); hasUser {
 		args = append(args, "'--user'")
 		args = append(args, "?")
@@ -57,12 +57,14 @@ GCP remote urls should be of the form gs://gcs-bucket/database and will use the
 
 The local filesystem can be used as a remote by providing a repository url in the format file://absolute path. See https://en.wikipedia.org/wiki/File_URI_scheme
 
+Remotes can be encrypted on the client, so that the server or bucket holding them never sees their data, by giving the parameter {{.EmphasisLeft}}encryption-key-file{{.EmphasisRight}}. The file holds a base64 encoded 32 byte key, which every clone of the remote must be given as well. Chunks are encrypted before they're pushed, and are stored under keyed hashes of their addresses. The mapping from addresses to keyed hashes is stored in the remote, encrypted. Servers can't garbage collect encrypted remotes, since they can't read which chunks are live, and refuse to.
+
 {{.EmphasisLeft}}remove{{.EmphasisRight}}, {{.EmphasisLeft}}rm{{.EmphasisRight}}
 Remove the remote named {{.LessThan}}name{{.GreaterThan}}. All remote-tracking branches and configuration settings for the remote are removed.`,
 
 	Synopsis: []string{
 		"[-v | --verbose]",
-		"add [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] {{.LessThan}}name{{.GreaterThan}} {{.LessThan}}url{{.GreaterThan}}",
+		"add [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] [--encryption-key-file {{.LessThan}}file{{.GreaterThan}}] {{.LessThan}}name{{.GreaterThan}} {{.LessThan}}url{{.GreaterThan}}",
 		"remove {{.LessThan}}name{{.GreaterThan}}",
 	},
 }
@@ -101,6 +103,8 @@ func (cmd RemoteCmd) ArgParser() *argparser.ArgParser {
 
 	ap.SupportsString(dbfactory.OSSCredsFileParam, "", "file", "OSS credentials file")
 	ap.SupportsString(dbfactory.OSSCredsProfile, "", "profile", "OSS profile to use")
+
+	ap.SupportsString(dbfactory.EncryptionKeyFileParam, "", "file", "File holding the key the remote is encrypted with on the client")
 	return ap
 }
 
@@ -218,6 +222,9 @@ func parseRemoteArgs(apr *argparser.ArgParseResults, scheme, remoteUrl string) (
 	default:
 		err = cli.VerifyNoAwsParams(apr)
 	}
+	if err == nil {
+		err = cli.AddEncryptionParams(apr, params)
+	}
 	if err != nil {
 		return nil, errhand.VerboseErrorFromError(err)
 	}
@@ -22,10 +22,13 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/dolthub/go-mysql-server/sql"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
 	"github.com/fatih/color"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands/engine"
 	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
 	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
@@ -55,6 +58,9 @@ const (
 	floatThresholdParam = "float-threshold"
 	keepTypesParam      = "keep-types"
 	delimParam          = "delim"
+	sampleParam         = "sample"
+	inferReportFlag     = "infer-report"
+	inferPrecisionFlag  = "infer-precision"
 )
 
 var MappingFileHelp = "A mapping file is json in the format:" + `
@@ -84,10 +90,16 @@ In create, update, and replace scenarios the file's extension is used to infer t
 If the parameter {{.EmphasisLeft}}--dry-run{{.EmphasisRight}} is supplied a sql statement will be generated showing what would be executed if this were run without the --dry-run flag
 
 {{.EmphasisLeft}}--float-threshold{{.EmphasisRight}} is the threshold at which a string representing a floating point number should be interpreted as a float versus an int.  If FloatThreshold is 0.0 then any number with a decimal point will be interpreted as a float (such as 0.0, 1.0, etc).  If FloatThreshold is 1.0 then any number with a decimal point will be converted to an int (0.5 will be the int 0, 1.99 will be the int 1, etc.  If the FloatThreshold is 0.001 then numbers with a fractional component greater than or equal to 0.001 will be treated as a float (1.0 would be an int, 1.0009 would be an int, 1.001 would be a float, 1.1 would be a float, etc)
+
+By default the schema is inferred from rows sampled across the whole file, skipping through larger files exponentially. {{.EmphasisLeft}}--sample{{.EmphasisRight}} infers the schema from each of the first {{.LessThan}}n{{.GreaterThan}} rows of the file instead.
+
+If {{.EmphasisLeft}}--infer-precision{{.EmphasisRight}} is given, columns of numbers with a decimal point are given a DECIMAL type whose precision and scale fit the sampled numbers, rather than a FLOAT, and columns of datetimes are given the precision of the fractional seconds of the sampled datetimes.
+
+If {{.EmphasisLeft}}--infer-report{{.EmphasisRight}} is given, a report is printed which shows, for each column, the types of the sampled values, the number of sampled values, how many of them were null or empty, the length of the longest value, and the type which was chosen along with the reason it was chosen. It can be combined with {{.EmphasisLeft}}--dry-run{{.EmphasisRight}} to check the inferred schema before creating the table.
 `,
 
 	Synopsis: []string{
-		`[--create|--replace] [--force] [--dry-run] [--lower|--upper] [--keep-types] [--file-type <type>] [--float-threshold] [--map {{.LessThan}}mapping-file{{.GreaterThan}}] [--delim {{.LessThan}}delimiter{{.GreaterThan}}]--pks {{.LessThan}}field{{.GreaterThan}},... {{.LessThan}}table{{.GreaterThan}} {{.LessThan}}file{{.GreaterThan}}`,
+		`[--create|--replace] [--force] [--dry-run] [--lower|--upper] [--keep-types] [--file-type <type>] [--float-threshold] [--sample {{.LessThan}}n{{.GreaterThan}}] [--infer-precision] [--infer-report] [--map {{.LessThan}}mapping-file{{.GreaterThan}}] [--delim {{.LessThan}}delimiter{{.GreaterThan}}]--pks {{.LessThan}}field{{.GreaterThan}},... {{.LessThan}}table{{.GreaterThan}} {{.LessThan}}file{{.GreaterThan}}`,
 	},
 }
 
@@ -110,6 +122,9 @@ type importOptions struct {
 	keepTypes      bool
 	colMapper      rowconv.NameMapper
 	floatThreshold float64
+	sampleSize     int
+	inferPrecision bool
+	inferReport    bool
 }
 
 func (im *importOptions) ColNameMapper() rowconv.NameMapper {
@@ -118,6 +133,12 @@ func (im *importOptions) ColNameMapper() rowconv.NameMapper {
 func (im *importOptions) FloatThreshold() float64 {
 	return im.floatThreshold
 }
+func (im *importOptions) SampleSize() int {
+	return im.sampleSize
+}
+func (im *importOptions) InferPrecision() bool {
+	return im.inferPrecision
+}
 
 type ImportCmd struct{}
 
@@ -155,6 +176,9 @@ func (cmd ImportCmd) ArgParser() *argparser.ArgParser {
 	ap.SupportsString(mappingParam, "m", "mapping-file", "A file that can map a column name in {{.LessThan}}file{{.GreaterThan}} to a new value.")
 	ap.SupportsString(floatThresholdParam, "", "float", "Minimum value at which the fractional component of a value must exceed in order to be considered a float.")
 	ap.SupportsString(delimParam, "", "delimiter", "Specify a delimiter for a csv style file with a non-comma delimiter.")
+	ap.SupportsInt(sampleParam, "", "n", "Infer the schema from each of the first {{.LessThan}}n{{.GreaterThan}} rows of the file, rather than from rows sampled across the whole file.")
+	ap.SupportsFlag(inferPrecisionFlag, "", "Infer DECIMAL types, and the precision of DATETIME types, which fit the sampled values.")
+	ap.SupportsFlag(inferReportFlag, "", "Print a report of the types considered for each column and the reason each type was chosen.")
 	return ap
 }
 
@@ -267,6 +291,11 @@ func getSchemaImportArgs(ctx context.Context, apr *argparser.ArgParseResults, dE
 		return nil, errhand.BuildDError("error: '%s' is not a valid float in the range 0.0 (all floats) to 1.0 (no floats)", floatThresholdStr).SetPrintUsage().Build()
 	}
 
+	sampleSize, ok := apr.GetInt(sampleParam)
+	if ok && sampleSize <= 0 {
+		return nil, errhand.BuildDError("error: --sample must be a positive number of rows").SetPrintUsage().Build()
+	}
+
 	return &importOptions{
 		op:             op,
 		fileName:       fileName,
@@ -278,6 +307,9 @@ func getSchemaImportArgs(ctx context.Context, apr *argparser.ArgParseResults, dE
 		keepTypes:      apr.Contains(keepTypesParam),
 		colMapper:      colMapper,
 		floatThreshold: floatThreshold,
+		sampleSize:     sampleSize,
+		inferPrecision: apr.Contains(inferPrecisionFlag),
+		inferReport:    apr.Contains(inferReportFlag),
 	}, nil
 }
 
@@ -396,15 +428,46 @@ func inferSchemaFromFile(ctx context.Context, nbf *types.NomsBinFormat, impOpts
 
 	defer rd.Close(ctx)
 
-	infCols, err := actions.InferColumnTypesFromTableReader(ctx, rd, impOpts)
+	infCols, report, err := actions.InferColumnTypesWithReport(ctx, rd, impOpts)
 
 	if err != nil {
 		return nil, errhand.BuildDError("error: failed to infer schema").AddCause(err).Build()
 	}
 
+	if impOpts.inferReport {
+		if err = printInferenceReport(ctx, report); err != nil {
+			return nil, errhand.BuildDError("error: failed to print inference report").AddCause(err).Build()
+		}
+	}
+
 	return CombineColCollections(ctx, root, infCols, impOpts)
 }
 
+// printInferenceReport prints how the type of each column was inferred.
+func printInferenceReport(ctx context.Context, report []actions.ColumnInference) error {
+	var reportSchema = sql.Schema{
+		{Name: "column", Type: gmstypes.Text},
+		{Name: "candidate_types", Type: gmstypes.Text},
+		{Name: "sampled", Type: gmstypes.Int64},
+		{Name: "nulls", Type: gmstypes.Int64},
+		{Name: "max_length", Type: gmstypes.Int64},
+		{Name: "type", Type: gmstypes.Text},
+		{Name: "reason", Type: gmstypes.Text},
+	}
+
+	rows := make([]sql.Row, len(report))
+	for i, col := range report {
+		candidates := make([]string, len(col.Candidates))
+		for j, ti := range col.Candidates {
+			candidates[j] = ti.ToSqlType().String()
+		}
+		rows[i] = sql.NewRow(col.Name, strings.Join(candidates, ", "), int64(col.Sampled), int64(col.Nulls),
+			int64(col.MaxLength), col.Type.ToSqlType().String(), col.Reason)
+	}
+
+	return engine.PrettyPrintResults(sql.NewContext(ctx), engine.FormatTabular, reportSchema, sql.RowsToRowIter(rows...), false)
+}
+
 func CombineColCollections(ctx context.Context, root doltdb.RootValue, inferredCols *schema.ColCollection, impOpts *importOptions) (schema.Schema, errhand.VerboseError) {
 	existingCols := impOpts.existingSch.GetAllCols()
 
@@ -538,3 +538,7 @@ type stubAutoGCBehavior struct {
 func (stubAutoGCBehavior) Enable() bool {
 	return false
 }
+
+func (stubAutoGCBehavior) ArchiveLevel() int {
+	return servercfg.DefaultAutoGCArchiveLevel
+}
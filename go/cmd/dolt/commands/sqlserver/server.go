@@ -48,6 +48,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/dolt_ci"
 	"github.com/dolthub/dolt/go/libraries/doltcore/remotesrv"
 	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
@@ -271,13 +272,23 @@ func ConfigureServices(
 		InitF: func(context.Context) error {
 			if cfg.ServerConfig.AutoGCBehavior() != nil &&
 				cfg.ServerConfig.AutoGCBehavior().Enable() {
-				config.AutoGCController = sqle.NewAutoGCController(lgr)
+				archiveOldGen := cfg.ServerConfig.AutoGCBehavior().ArchiveLevel() == servercfg.AutoGCArchiveLevelOldGen
+				config.AutoGCController = sqle.NewAutoGCController(archiveOldGen, lgr)
 			}
 			return nil
 		},
 	}
 	controller.Register(InitAutoGCController)
 
+	InitWorkflowRunController := &svcs.AnonService{
+		InitF: func(context.Context) error {
+			// Workflows run as the ephemeral local super user, which is created below.
+			config.WorkflowRunController = dolt_ci.NewWorkflowRunController(lgr, sql.Client{User: LocalConnectionUser, Address: "localhost"})
+			return nil
+		},
+	}
+	controller.Register(InitWorkflowRunController)
+
 	// mySQLServer is going to be populated down below once further services
 	// are initialized. However, we want to block Controller shutdown on all
 	// connections being fully drained from the Server. Stopping the
@@ -612,7 +623,7 @@ func ConfigureServices(
 			}
 			var err error
 			args.FS = sqlEngine.FileSystem()
-			args.DBCache, err = sqle.RemoteSrvDBCache(sqle.GetInterceptorSqlContext, sqle.DoNotCreateUnknownDatabases)
+			args.DBCache, err = sqle.RemoteSrvDBCache(sqle.GetInterceptorSqlContext, sqle.DoNotCreateUnknownDatabases, sqle.RunCommitHooks)
 			if err != nil {
 				lgr.Errorf("error creating SQL engine context for remotesapi server: %v", err)
 				return err
@@ -101,6 +101,8 @@ SUPPORTED CONFIG FILE FIELDS:
 
 {{.EmphasisLeft}}behavior.auto_gc_behavior.enabled{{.EmphasisRight}}: If true, garbage collection will run automatically in the background. 
 
+{{.EmphasisLeft}}behavior.auto_gc_behavior.archive_level{{.EmphasisRight}}: If 1, the old generation of each database is converted to archives, with a compression dictionary trained for each table, after each automatic garbage collection. Defaults to 0, which leaves table files as they are.
+
 {{.EmphasisLeft}}listener.host{{.EmphasisRight}}: The host address that the server will run on.  This may be {{.EmphasisLeft}}localhost{{.EmphasisRight}} or an IPv4 or IPv6 address
 
 {{.EmphasisLeft}}listener.port{{.EmphasisRight}}: The port that the server should listen on
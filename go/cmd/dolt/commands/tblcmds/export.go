@@ -16,8 +16,10 @@ package tblcmds
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/fatih/color"
 
@@ -29,8 +31,10 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/mvdata"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/parquet"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
@@ -41,17 +45,34 @@ var exportDocs = cli.CommandDocumentationContent{
 	LongDesc: `{{.EmphasisLeft}}dolt table export{{.EmphasisRight}} will export the contents of {{.LessThan}}table{{.GreaterThan}} to {{.LessThan}}|file{{.GreaterThan}}
 
 See the help for {{.EmphasisLeft}}dolt table import{{.EmphasisRight}} as the options are the same.
+
+The rows exported can be limited to those matching the SQL expression given by {{.EmphasisLeft}}--where{{.EmphasisRight}}, and the columns exported to the comma separated list of columns given by {{.EmphasisLeft}}--columns{{.EmphasisRight}}, in the order given. The table is exported from the working set, unless {{.EmphasisLeft}}--as-of{{.EmphasisRight}} gives the commit, branch or tag to export it at.
+
+When exporting to parquet, {{.EmphasisLeft}}--partition-by{{.EmphasisRight}} writes the table to a Hive-style layout of files under the directory {{.LessThan}}file{{.GreaterThan}}, in place of a single file. The rows with each value of the partition column are written, without the partition column, to {{.EmphasisLeft}}{{.LessThan}}column{{.GreaterThan}}={{.LessThan}}value{{.GreaterThan}}/part-0.parquet{{.EmphasisRight}}, and rows whose partition column is NULL are written to the {{.EmphasisLeft}}__HIVE_DEFAULT_PARTITION__{{.EmphasisRight}} partition. The export fails if two values of the partition column differ only in case, since their directories would collide on case-insensitive file systems. The directory must be empty or not exist, unless {{.EmphasisLeft}}--force{{.EmphasisRight}} is given. The compression of parquet files is given by {{.EmphasisLeft}}--compression{{.EmphasisRight}}, which is one of ` + "`" + `uncompressed` + "`" + `, ` + "`" + `snappy` + "`" + ` (the default), ` + "`" + `gzip` + "`" + `, ` + "`" + `lz4` + "`" + `, or ` + "`" + `zstd` + "`" + `, and the size in bytes of their row groups by {{.EmphasisLeft}}--row-group-size{{.EmphasisRight}}.
 `,
 	Synopsis: []string{
-		"[-f] [-pk {{.LessThan}}field{{.GreaterThan}}] [-schema {{.LessThan}}file{{.GreaterThan}}] [-map {{.LessThan}}file{{.GreaterThan}}] [-continue] [-file-type {{.LessThan}}type{{.GreaterThan}}] {{.LessThan}}table{{.GreaterThan}} {{.LessThan}}file{{.GreaterThan}}",
+		"[-f] [-pk {{.LessThan}}field{{.GreaterThan}}] [-schema {{.LessThan}}file{{.GreaterThan}}] [-map {{.LessThan}}file{{.GreaterThan}}] [-continue] [-file-type {{.LessThan}}type{{.GreaterThan}}] [--where {{.LessThan}}expr{{.GreaterThan}}] [--columns {{.LessThan}}columns{{.GreaterThan}}] [--as-of {{.LessThan}}commit{{.GreaterThan}}] {{.LessThan}}table{{.GreaterThan}} {{.LessThan}}file{{.GreaterThan}}",
+		"[-f] [--where {{.LessThan}}expr{{.GreaterThan}}] [--columns {{.LessThan}}columns{{.GreaterThan}}] [--as-of {{.LessThan}}commit{{.GreaterThan}}] [--compression {{.LessThan}}codec{{.GreaterThan}}] [--row-group-size {{.LessThan}}bytes{{.GreaterThan}}] --partition-by {{.LessThan}}column{{.GreaterThan}} {{.LessThan}}table{{.GreaterThan}} {{.LessThan}}dir{{.GreaterThan}}",
 	},
 }
 
+const (
+	whereParam        = "where"
+	columnsParam      = "columns"
+	asOfParam         = "as-of"
+	partitionByParam  = "partition-by"
+	compressionParam  = "compression"
+	rowGroupSizeParam = "row-group-size"
+)
+
 type exportOptions struct {
-	tableName  string
-	force      bool
-	dest       mvdata.DataLocation
-	srcOptions interface{}
+	tableName   string
+	force       bool
+	dest        mvdata.DataLocation
+	srcOptions  interface{}
+	readOpts    mvdata.TableReadOptions
+	partitionBy string
+	parquetOpts parquet.WriterOptions
 }
 
 func (m exportOptions) checkOverwrite(ctx context.Context, root doltdb.RootValue, fs filesys.ReadableFS) (bool, error) {
@@ -87,6 +108,10 @@ func (m exportOptions) DestName() string {
 	return m.dest.String()
 }
 
+func (m exportOptions) ParquetWriterOptions() parquet.WriterOptions {
+	return m.parquetOpts
+}
+
 // getExportDestination returns an export destination corresponding to the input parameters
 func getExportDestination(apr *argparser.ArgParseResults) mvdata.DataLocation {
 	path := ""
@@ -110,7 +135,8 @@ func getExportDestination(apr *argparser.ArgParseResults) mvdata.DataLocation {
 		if val.Format == mvdata.InvalidDataFormat {
 			val = mvdata.StreamDataLocation{Format: mvdata.CsvFile, Reader: os.Stdin, Writer: iohelp.NopWrCloser(cli.CliOut)}
 			destLoc = val
-		} else if val.Format != mvdata.CsvFile && val.Format != mvdata.PsvFile {
+		} else if val.Format != mvdata.CsvFile && val.Format != mvdata.PsvFile && val.Format != mvdata.JsonlFile &&
+			val.Format != mvdata.ArrowStreamFile {
 			cli.PrintErrln(color.RedString("Cannot export this format to stdout"))
 			return nil
 		}
@@ -138,17 +164,110 @@ func parseExportArgs(ap *argparser.ArgParser, commandStr string, args []string)
 		return nil, errhand.BuildDError("invalid table name").Build()
 	}
 
-	fileLoc := getExportDestination(apr)
+	partitionBy, _ := apr.GetValue(partitionByParam)
+	var fileLoc mvdata.DataLocation
+	if partitionBy != "" {
+		fileLoc = getPartitionedExportDestination(apr)
+	} else {
+		fileLoc = getExportDestination(apr)
+	}
 
 	if fileLoc == nil {
 		return nil, errhand.BuildDError("could not validate table export args").Build()
 	}
 
-	return &exportOptions{
-		tableName: tableName,
-		force:     apr.Contains(forceParam),
-		dest:      fileLoc,
-	}, nil
+	exOpts := &exportOptions{
+		tableName:   tableName,
+		force:       apr.Contains(forceParam),
+		dest:        fileLoc,
+		partitionBy: partitionBy,
+	}
+
+	exOpts.readOpts.Where, _ = apr.GetValue(whereParam)
+	exOpts.readOpts.AsOf, _ = apr.GetValue(asOfParam)
+	if cols, ok := apr.GetValue(columnsParam); ok {
+		for _, col := range strings.Split(cols, ",") {
+			col = strings.TrimSpace(col)
+			if col == "" {
+				return nil, errhand.BuildDError("invalid --%s list '%s'", columnsParam, cols).Build()
+			}
+			exOpts.readOpts.Columns = append(exOpts.readOpts.Columns, col)
+		}
+	}
+
+	if verr := parseParquetExportArgs(apr, exOpts); verr != nil {
+		return nil, verr
+	}
+
+	return exOpts, nil
+}
+
+// getPartitionedExportDestination returns the directory a partitioned export is written to, which must be given
+// as a path, and must be written as parquet.
+func getPartitionedExportDestination(apr *argparser.ArgParseResults) mvdata.DataLocation {
+	if apr.NArg() < 2 {
+		cli.PrintErrln(color.RedString("--%s requires a directory to export to", partitionByParam))
+		return nil
+	}
+	if fType, ok := apr.GetValue(fileTypeParam); ok && mvdata.DFFromString(fType) != mvdata.ParquetFile {
+		cli.PrintErrln(color.RedString("--%s is only supported when exporting to parquet", partitionByParam))
+		return nil
+	}
+	return mvdata.FileDataLocation{Path: apr.Arg(1), Format: mvdata.ParquetFile}
+}
+
+// parseParquetExportArgs sets the options of the parquet files written by the export, which may only be given when
+// exporting to parquet.
+func parseParquetExportArgs(apr *argparser.ArgParseResults, exOpts *exportOptions) errhand.VerboseError {
+	isParquet := false
+	if f, ok := exOpts.dest.(mvdata.FileDataLocation); ok && f.Format == mvdata.ParquetFile {
+		isParquet = true
+	}
+
+	if compression, ok := apr.GetValue(compressionParam); ok {
+		if !isParquet {
+			return errhand.BuildDError("--%s is only supported when exporting to parquet", compressionParam).Build()
+		}
+		valid := false
+		for _, c := range parquet.Compressions {
+			if strings.EqualFold(compression, c) {
+				valid = true
+			}
+		}
+		if !valid {
+			return errhand.BuildDError("invalid compression '%s', expected one of: %s", compression, strings.Join(parquet.Compressions, ", ")).Build()
+		}
+		exOpts.parquetOpts.Compression = compression
+	}
+
+	if size, ok := apr.GetInt(rowGroupSizeParam); ok {
+		if !isParquet {
+			return errhand.BuildDError("--%s is only supported when exporting to parquet", rowGroupSizeParam).Build()
+		}
+		if size <= 0 {
+			return errhand.BuildDError("--%s must be a positive number of bytes", rowGroupSizeParam).Build()
+		}
+		exOpts.parquetOpts.RowGroupSize = int64(size)
+	}
+
+	if exOpts.partitionBy != "" {
+		// the partition column is needed to partition the rows, and rows are ordered by it so that each
+		// partition is written to a single file.
+		if len(exOpts.readOpts.Columns) > 0 {
+			found := false
+			for _, col := range exOpts.readOpts.Columns {
+				if strings.EqualFold(col, exOpts.partitionBy) {
+					found = true
+				}
+			}
+			if !found {
+				exOpts.readOpts.Columns = append(exOpts.readOpts.Columns, exOpts.partitionBy)
+			}
+		}
+		exOpts.readOpts.OrderBy = []string{exOpts.partitionBy}
+	}
+
+	return nil
 }
 
 type ExportCmd struct{}
@@ -174,6 +293,12 @@ func (cmd ExportCmd) ArgParser() *argparser.ArgParser {
 	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"file", "The file being output to."})
 	ap.SupportsFlag(forceParam, "f", "If data already exists in the destination, the force flag will allow the target to be overwritten.")
 	ap.SupportsString(fileTypeParam, "", "file_type", "Explicitly define the type of the file if it can't be inferred from the file extension.")
+	ap.SupportsString(whereParam, "", "expr", "Only export the rows matching the SQL expression given.")
+	ap.SupportsString(columnsParam, "", "columns", "Comma separated list of the columns to export, in order. Defaults to every column.")
+	ap.SupportsString(asOfParam, "", "commit", "Export the table as of the commit, branch or tag given, rather than the working set.")
+	ap.SupportsString(partitionByParam, "", "column", "Export to a Hive-style layout of parquet files under the directory given, partitioned by the column given.")
+	ap.SupportsString(compressionParam, "", "codec", "The compression of the parquet files written: uncompressed, snappy, gzip, lz4, or zstd. Defaults to snappy.")
+	ap.SupportsInt(rowGroupSizeParam, "", "bytes", "The size in bytes of the row groups of the parquet files written. Defaults to 128MB.")
 	return ap
 }
 
@@ -197,12 +322,19 @@ func (cmd ExportCmd) Exec(ctx context.Context, commandStr string, args []string,
 		return commands.HandleVErrAndExitCode(verr, usage)
 	}
 
-	rd, err := mvdata.NewSqlEngineReader(ctx, dEnv, exOpts.tableName)
+	rd, err := mvdata.NewSqlEngineReaderWithOptions(ctx, dEnv, exOpts.tableName, exOpts.readOpts)
 	if err != nil {
 		return commands.HandleVErrAndExitCode(errhand.BuildDError("Error creating reader for %s.", exOpts.SrcName()).AddCause(err).Build(), usage)
 	}
 
-	wr, verr := getTableWriter(ctx, root, dEnv, rd.GetSchema(), exOpts)
+	var wr table.SqlRowWriter
+	var partWr *parquet.PartitionedParquetWriter
+	if exOpts.partitionBy != "" {
+		partWr, verr = getPartitionedTableWriter(dEnv, rd.GetSchema(), exOpts)
+		wr = partWr
+	} else {
+		wr, verr = getTableWriter(ctx, root, dEnv, rd.GetSchema(), exOpts)
+	}
 	if verr != nil {
 		return commands.HandleVErrAndExitCode(verr, usage)
 	}
@@ -214,10 +346,51 @@ func (cmd ExportCmd) Exec(ctx context.Context, commandStr string, args []string,
 		return commands.HandleVErrAndExitCode(errhand.BuildDError("Error opening writer for %s.", exOpts.DestName()).AddCause(err).Build(), usage)
 	}
 
+	if partWr != nil {
+		cli.PrintErrln(fmt.Sprintf("Wrote %d files to %s.", len(partWr.Files()), exOpts.DestName()))
+	}
 	cli.PrintErrln(color.CyanString("Successfully exported data."))
 	return 0
 }
 
+// getPartitionedTableWriter returns a writer of the rows of |rdSchema| to parquet files under the directory of the
+// export, partitioned by the export's partition column.
+func getPartitionedTableWriter(dEnv *env.DoltEnv, rdSchema schema.Schema, exOpts *exportOptions) (*parquet.PartitionedParquetWriter, errhand.VerboseError) {
+	dir, err := dEnv.FS.Abs(exOpts.DestName())
+	if err != nil {
+		return nil, errhand.VerboseErrorFromError(err)
+	}
+
+	exists, isDir := dEnv.FS.Exists(dir)
+	if exists && !isDir {
+		return nil, errhand.BuildDError("%s is not a directory.", exOpts.DestName()).Build()
+	}
+	if exists && !exOpts.force {
+		empty := true
+		err = dEnv.FS.Iter(dir, false, func(string, int64, bool) bool {
+			empty = false
+			return true
+		})
+		if err != nil {
+			return nil, errhand.VerboseErrorFromError(err)
+		}
+		if !empty {
+			return nil, errhand.BuildDError("%s already exists. Use -f to overwrite.", exOpts.DestName()).Build()
+		}
+	}
+
+	sqlSch, err := sqlutil.FromDoltSchema("", exOpts.tableName, rdSchema)
+	if err != nil {
+		return nil, errhand.VerboseErrorFromError(err)
+	}
+
+	wr, err := parquet.NewPartitionedParquetWriter(sqlSch.Schema, dir, exOpts.partitionBy, exOpts.parquetOpts)
+	if err != nil {
+		return nil, errhand.BuildDError("Error opening writer for %s.", exOpts.DestName()).AddCause(err).Build()
+	}
+	return wr, nil
+}
+
 func getTableWriter(ctx context.Context, root doltdb.RootValue, dEnv *env.DoltEnv, rdSchema schema.Schema, exOpts *exportOptions) (table.SqlRowWriter, errhand.VerboseError) {
 	ow, err := exOpts.checkOverwrite(ctx, root, dEnv.FS)
 	if err != nil {
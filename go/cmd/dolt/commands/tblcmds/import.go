@@ -23,6 +23,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -46,6 +47,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/noms"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/libraries/utils/funcitr"
@@ -58,12 +60,14 @@ const (
 	updateParam       = "update-table"
 	replaceParam      = "replace-table"
 	appendParam       = "append-table"
+	syncParam         = "sync"
 	tableParam        = "table"
 	fileParam         = "file"
 	schemaParam       = "schema"
 	mappingFileParam  = "map"
 	forceParam        = "force"
 	contOnErrParam    = "continue"
+	badRowsParam      = "bad-rows"
 	primaryKeyParam   = "pk"
 	fileTypeParam     = "file-type"
 	delimParam        = "delim"
@@ -71,6 +75,15 @@ const (
 	ignoreSkippedRows = "ignore-skipped-rows" // alias for quiet
 	disableFkChecks   = "disable-fk-checks"
 	allTextParam      = "all-text"
+	fromMySQLParam    = "from-mysql"
+	sourceTableParam  = "table"
+	queryParam        = "query"
+	dirParam          = "dir"
+	parallelParam     = "parallel"
+	sheetParam        = "sheet"
+
+	// allSheets is the value of --sheet which imports every sheet of a workbook
+	allSheets = "all"
 )
 
 var jsonInputFileHelp = "The expected JSON input file format is:" + `
@@ -85,6 +98,13 @@ var jsonInputFileHelp = "The expected JSON input file format is:" + `
 	}
 
 where column_name is the name of a column of the table being imported and value is the data for that column in the table.
+
+JSON Lines files (with a .jsonl or .ndjson extension) hold one JSON row object per line instead, and are read a row at a time:
+
+	{"column_name":"value", ...}
+	{"column_name":"value", ...}
+
+Arrow files (with a .arrow or .feather extension) and Arrow streams (with a .arrows extension) are read using the Arrow IPC file and streaming formats respectively. Fields are matched to the columns of the table by name.
 `
 
 var importDocs = cli.CommandDocumentationContent{
@@ -99,23 +119,38 @@ If {{.EmphasisLeft}}--append-table | -a{{.EmphasisRight}} is given the operation
 
 If {{.EmphasisLeft}}--replace-table | -r{{.EmphasisRight}} is given the operation will replace {{.LessThan}}table{{.GreaterThan}} with the contents of the file. The table's existing schema will be used, and field names will be used to match file fields with table fields unless a mapping file is specified.
 
+If {{.EmphasisLeft}}--sync{{.EmphasisRight}} is given the operation will make {{.LessThan}}table{{.GreaterThan}} match the contents of the file. Rows of the file are matched to rows of the table by primary key, and the table's rows are diffed against the file's rows so that only rows which were added, modified, or removed are written. Rows of the table which aren't in the file are deleted. The file must contain every column of the table, and a summary of the changes like that of {{.EmphasisLeft}}dolt diff --stat{{.EmphasisRight}} is printed when the import completes.
+
 If the schema for the existing table does not match the schema for the new file, the import will be aborted by default. To overwrite both the table and the schema, use {{.EmphasisLeft}}-c -f{{.EmphasisRight}}.
 
 A mapping file can be used to map fields between the file being imported and the table being written to. This can be used when creating a new table, or updating or replacing an existing table.
 
 During import, if there is an error importing any row, the import will be aborted by default. Use the {{.EmphasisLeft}}--continue{{.EmphasisRight}} flag to continue importing when an error is encountered. You can add the {{.EmphasisLeft}}--quiet{{.EmphasisRight}} flag to prevent the import utility from printing all the skipped rows. 
 
+With {{.EmphasisLeft}}--continue{{.EmphasisRight}}, the {{.EmphasisLeft}}--bad-rows{{.EmphasisRight}} option writes each skipped row to a csv, psv, or jsonl file, chosen by the file's extension. Each row of the file holds the line or record number the row was read from (dolt_line), the error which caused it to be skipped (dolt_error), and the row's values in the columns of the imported file, so that the skipped rows can be fixed and imported again. {{.EmphasisLeft}}--bad-rows{{.EmphasisRight}} isn't supported with {{.EmphasisLeft}}--sync{{.EmphasisRight}}, which applies only the rows which differ from the table, in primary key order rather than in the order they were read.
+
+Rows can also be imported directly from a MySQL server, or any other server which speaks the MySQL protocol, such as a {{.EmphasisLeft}}dolt sql-server{{.EmphasisRight}}. The {{.EmphasisLeft}}--from-mysql{{.EmphasisRight}} parameter gives the data source name of the server, such as {{.EmphasisLeft}}user:password@tcp(127.0.0.1:3306)/database{{.EmphasisRight}}, and rows are read from either the table given by {{.EmphasisLeft}}--table{{.EmphasisRight}} or the results of the query given by {{.EmphasisLeft}}--query{{.EmphasisRight}}, in place of a file. Rows are streamed from the server as they are imported. When creating a table from a source table, the column types, column defaults, primary key and secondary indexes of the new table are read from the server's information_schema, so that details such as the precision of decimals and the values of enums are kept. PostgreSQL servers are not supported. When creating a table from a query, column types are read from the query's result set, string columns become {{.EmphasisLeft}}varchar(1023){{.EmphasisRight}} columns, and the primary key must be given with {{.EmphasisLeft}}--pk{{.EmphasisRight}} or with a schema file.
+
+The {{.EmphasisLeft}}--dir{{.EmphasisRight}} parameter imports every csv, psv, json, jsonl, parquet, arrow, and xlsx file in a directory, in place of a table and a file, into the table named after each file, so that {{.EmphasisLeft}}people.csv{{.EmphasisRight}} is imported into {{.EmphasisLeft}}people{{.EmphasisRight}}. Files of other types are skipped. The operation given is applied to every table, and the directory written by {{.EmphasisLeft}}dolt dump -r csv{{.EmphasisRight}} can be imported back in this way. The tables are imported all or nothing, in a single transaction which creates one commit of the imported tables: if any table fails to import, the working set is left as it was. Foreign key checks are deferred until every table has been imported, so that tables can be imported in any order, and the import fails if the imported rows violate a foreign key, unless {{.EmphasisLeft}}--disable-fk-checks{{.EmphasisRight}} is given. Tables are written one at a time, and {{.EmphasisLeft}}--parallel{{.EmphasisRight}} gives the number of files read at once, ahead of the table being written.
+
+A sheet of an xlsx file is imported into the table named after the sheet, unless the sheet is given by {{.EmphasisLeft}}--sheet{{.EmphasisRight}}. With {{.EmphasisLeft}}--sheet all{{.EmphasisRight}}, every sheet of the file given in place of a table and a file is imported into the table named after the sheet, so that a workbook written by {{.EmphasisLeft}}dolt dump -r xlsx{{.EmphasisRight}} can be imported back. As with {{.EmphasisLeft}}--dir{{.EmphasisRight}}, the sheets are imported all or nothing, with one commit.
+
 ` + schcmds.MappingFileHelp +
 		`
 ` + jsonInputFileHelp +
 		`
-In create, update, and replace scenarios the file's extension is used to infer the type of the file.  If a file does not have the expected extension then the {{.EmphasisLeft}}--file-type{{.EmphasisRight}} parameter should be used to explicitly define the format of the file in one of the supported formats (csv, psv, json, xlsx).  For files separated by a delimiter other than a ',' (type csv) or a '|' (type psv), the --delim parameter can be used to specify a delimiter`,
+In create, update, and replace scenarios the file's extension is used to infer the type of the file.  If a file does not have the expected extension then the {{.EmphasisLeft}}--file-type{{.EmphasisRight}} parameter should be used to explicitly define the format of the file in one of the supported formats (csv, psv, json, jsonl, xlsx, parquet, arrow, arrows).  For files separated by a delimiter other than a ',' (type csv) or a '|' (type psv), the --delim parameter can be used to specify a delimiter`,
 
 	Synopsis: []string{
-		"-c [-f] [--pk {{.LessThan}}field{{.GreaterThan}}] [--all-text] [--schema {{.LessThan}}file{{.GreaterThan}}] [--map {{.LessThan}}file{{.GreaterThan}}] [--continue]  [--quiet] [--disable-fk-checks] [--file-type {{.LessThan}}type{{.GreaterThan}}] {{.LessThan}}table{{.GreaterThan}} {{.LessThan}}file{{.GreaterThan}}",
-		"-u [--map {{.LessThan}}file{{.GreaterThan}}] [--continue] [--quiet] [--file-type {{.LessThan}}type{{.GreaterThan}}] {{.LessThan}}table{{.GreaterThan}} {{.LessThan}}file{{.GreaterThan}}",
-		"-a [--map {{.LessThan}}file{{.GreaterThan}}] [--continue] [--quiet] [--file-type {{.LessThan}}type{{.GreaterThan}}] {{.LessThan}}table{{.GreaterThan}} {{.LessThan}}file{{.GreaterThan}}",
+		"-c [-f] [--pk {{.LessThan}}field{{.GreaterThan}}] [--all-text] [--schema {{.LessThan}}file{{.GreaterThan}}] [--map {{.LessThan}}file{{.GreaterThan}}] [--continue] [--bad-rows {{.LessThan}}file{{.GreaterThan}}]  [--quiet] [--disable-fk-checks] [--file-type {{.LessThan}}type{{.GreaterThan}}] {{.LessThan}}table{{.GreaterThan}} {{.LessThan}}file{{.GreaterThan}}",
+		"-u [--map {{.LessThan}}file{{.GreaterThan}}] [--continue] [--bad-rows {{.LessThan}}file{{.GreaterThan}}] [--quiet] [--file-type {{.LessThan}}type{{.GreaterThan}}] {{.LessThan}}table{{.GreaterThan}} {{.LessThan}}file{{.GreaterThan}}",
+		"-a [--map {{.LessThan}}file{{.GreaterThan}}] [--continue] [--bad-rows {{.LessThan}}file{{.GreaterThan}}] [--quiet] [--file-type {{.LessThan}}type{{.GreaterThan}}] {{.LessThan}}table{{.GreaterThan}} {{.LessThan}}file{{.GreaterThan}}",
 		"-r [--map {{.LessThan}}file{{.GreaterThan}}] [--file-type {{.LessThan}}type{{.GreaterThan}}] {{.LessThan}}table{{.GreaterThan}} {{.LessThan}}file{{.GreaterThan}}",
+		"--sync [--map {{.LessThan}}file{{.GreaterThan}}] [--continue] [--quiet] [--disable-fk-checks] [--file-type {{.LessThan}}type{{.GreaterThan}}] {{.LessThan}}table{{.GreaterThan}} {{.LessThan}}file{{.GreaterThan}}",
+		"-c|-u|-a|-r|--sync [options] --from-mysql {{.LessThan}}dsn{{.GreaterThan}} --table {{.LessThan}}source_table{{.GreaterThan}} {{.LessThan}}table{{.GreaterThan}}",
+		"-c|-u|-a|-r|--sync [options] --from-mysql {{.LessThan}}dsn{{.GreaterThan}} --query {{.LessThan}}query{{.GreaterThan}} {{.LessThan}}table{{.GreaterThan}}",
+		"-c|-u|-a|-r|--sync [options] [--parallel {{.LessThan}}n{{.GreaterThan}}] --dir {{.LessThan}}directory{{.GreaterThan}}",
+		"-c|-u|-a|-r|--sync [options] [--parallel {{.LessThan}}n{{.GreaterThan}}] --sheet all {{.LessThan}}file{{.GreaterThan}}",
 	},
 }
 
@@ -132,6 +167,7 @@ type importOptions struct {
 	src             mvdata.DataLocation
 	srcOptions      interface{}
 	quiet           bool
+	badRowsFile     string
 	disableFkChecks bool
 	allText         bool
 }
@@ -163,6 +199,32 @@ func (m importOptions) FloatThreshold() float64 {
 	return 0.0
 }
 
+func (m importOptions) SampleSize() int {
+	return 0
+}
+
+func (m importOptions) InferPrecision() bool {
+	return false
+}
+
+// sourceLine returns the line of the source holding the |row|th row read from it. Delimited files and spreadsheets
+// start with a header row, and the rows of other formats are numbered from 1.
+func (m importOptions) sourceLine(row int) int {
+	var format mvdata.DataFormat
+	switch src := m.src.(type) {
+	case mvdata.FileDataLocation:
+		format = src.Format
+	case mvdata.StreamDataLocation:
+		format = src.Format
+	}
+	switch format {
+	case mvdata.CsvFile, mvdata.PsvFile, mvdata.XlsxFile:
+		return row + 1
+	default:
+		return row
+	}
+}
+
 func (m importOptions) checkOverwrite(ctx context.Context, root doltdb.RootValue, fs filesys.ReadableFS) (bool, error) {
 	if !m.force && m.operation == mvdata.CreateOp {
 		return root.HasTable(ctx, doltdb.TableName{Name: m.destTableName})
@@ -175,19 +237,27 @@ func (m importOptions) srcIsJson() bool {
 	return isJson
 }
 
+func (m importOptions) srcIsMySQL() bool {
+	_, isMySQL := m.src.(mvdata.MySQLDataLocation)
+	return isMySQL
+}
+
 func (m importOptions) srcIsStream() bool {
 	_, isStream := m.src.(mvdata.StreamDataLocation)
 	return isStream
 }
 
 func getImportMoveOptions(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.DoltEnv) (*importOptions, errhand.VerboseError) {
-	tableName := apr.Arg(0)
-
 	path := ""
 	if apr.NArg() > 1 {
 		path = apr.Arg(1)
 	}
 
+	return getTableImportMoveOptions(ctx, apr, dEnv, apr.Arg(0), path)
+}
+
+// getTableImportMoveOptions returns the options for importing |path| into |tableName|.
+func getTableImportMoveOptions(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.DoltEnv, tableName, path string) (*importOptions, errhand.VerboseError) {
 	fType, _ := apr.GetValue(fileTypeParam)
 	srcLoc := mvdata.NewDataLocation(path, fType)
 	delim, hasDelim := apr.GetValue(delimParam)
@@ -196,6 +266,7 @@ func getImportMoveOptions(ctx context.Context, apr *argparser.ArgParseResults, d
 	force := apr.Contains(forceParam)
 	contOnErr := apr.Contains(contOnErrParam)
 	quiet := apr.Contains(quiet)
+	badRowsFile, _ := apr.GetValue(badRowsParam)
 	disableFks := apr.Contains(disableFkChecks)
 	allText := apr.Contains(allTextParam)
 
@@ -209,6 +280,14 @@ func getImportMoveOptions(ctx context.Context, apr *argparser.ArgParseResults, d
 		return nil, errhand.VerboseErrorFromError(err)
 	}
 
+	if dsn, ok := apr.GetValue(fromMySQLParam); ok {
+		srcLoc = mvdata.MySQLDataLocation{
+			DSN:   dsn,
+			Table: apr.GetValueOrDefault(sourceTableParam, ""),
+			Query: apr.GetValueOrDefault(queryParam, ""),
+		}
+	}
+
 	var srcOpts interface{}
 	switch val := srcLoc.(type) {
 	case mvdata.FileDataLocation:
@@ -222,12 +301,18 @@ func getImportMoveOptions(ctx context.Context, apr *argparser.ArgParseResults, d
 		}
 
 		if val.Format == mvdata.XlsxFile {
-			// table name must match sheet name currently
-			srcOpts = mvdata.XlsxOptions{SheetName: tableName}
-		} else if val.Format == mvdata.JsonFile {
+			// the sheet imported is the one named after the table, unless it's given by --sheet
+			sheetName := tableName
+			if sheet, ok := apr.GetValue(sheetParam); ok && sheet != allSheets {
+				sheetName = sheet
+			}
+			srcOpts = mvdata.XlsxOptions{SheetName: sheetName}
+		} else if val.Format == mvdata.JsonFile || val.Format == mvdata.JsonlFile {
 			srcOpts = mvdata.JSONOptions{TableName: tableName, SchFile: schemaFile}
 		} else if val.Format == mvdata.ParquetFile {
 			srcOpts = mvdata.ParquetOptions{TableName: tableName, SchFile: schemaFile}
+		} else if val.Format == mvdata.ArrowFile || val.Format == mvdata.ArrowStreamFile {
+			srcOpts = mvdata.ArrowOptions{TableName: tableName, SchFile: schemaFile}
 		}
 
 	case mvdata.StreamDataLocation:
@@ -238,6 +323,10 @@ func getImportMoveOptions(ctx context.Context, apr *argparser.ArgParseResults, d
 
 		if hasDelim {
 			srcOpts = mvdata.CsvOptions{Delim: delim}
+		} else if val.Format == mvdata.JsonlFile {
+			srcOpts = mvdata.JSONOptions{TableName: tableName, SchFile: schemaFile}
+		} else if val.Format == mvdata.ArrowStreamFile {
+			srcOpts = mvdata.ArrowOptions{TableName: tableName, SchFile: schemaFile}
 		}
 	}
 
@@ -249,6 +338,8 @@ func getImportMoveOptions(ctx context.Context, apr *argparser.ArgParseResults, d
 		moveOp = mvdata.ReplaceOp
 	case apr.Contains(appendParam):
 		moveOp = mvdata.AppendOp
+	case apr.Contains(syncParam):
+		moveOp = mvdata.SyncOp
 	default:
 		moveOp = mvdata.UpdateOp
 	}
@@ -278,6 +369,7 @@ func getImportMoveOptions(ctx context.Context, apr *argparser.ArgParseResults, d
 		src:             srcLoc,
 		srcOptions:      srcOpts,
 		quiet:           quiet,
+		badRowsFile:     badRowsFile,
 		disableFkChecks: disableFks,
 		allText:         allText,
 	}, nil
@@ -293,31 +385,47 @@ func validateImportArgs(apr *argparser.ArgParseResults) errhand.VerboseError {
 		return errhand.BuildDError("parameters %s and %s are mutually exclusive", schemaParam, primaryKeyParam).Build()
 	}
 
-	if !apr.ContainsAny(createParam, updateParam, replaceParam, appendParam) {
-		return errhand.BuildDError("Must specify exactly one of -c, -u, -a, or -r.").SetPrintUsage().Build()
+	if verr := validateImportOp(apr); verr != nil {
+		return verr
 	}
 
-	if len(apr.ContainsMany(createParam, updateParam, replaceParam, appendParam)) > 1 {
-		return errhand.BuildDError("Must specify exactly one of -c, -u, -a, or -r.").SetPrintUsage().Build()
+	if apr.Contains(parallelParam) {
+		return errhand.BuildDError("fatal: --%s is only supported with --%s or --%s %s", parallelParam, dirParam, sheetParam, allSheets).Build()
 	}
 
 	if apr.Contains(schemaParam) && !apr.Contains(createParam) {
 		return errhand.BuildDError("fatal: " + schemaParam + " is not supported for update or replace operations").Build()
 	}
 
-	if apr.Contains(allTextParam) && !apr.Contains(createParam) {
-		return errhand.BuildDError("fatal: --%s is only supported for create operations", allTextParam).Build()
-	}
-
 	if apr.ContainsAll(allTextParam, schemaParam) {
 		return errhand.BuildDError("parameters %s and %s are mutually exclusive", allTextParam, schemaParam).Build()
 	}
 
+	if badRowsFile, ok := apr.GetValue(badRowsParam); ok {
+		if !apr.Contains(contOnErrParam) {
+			return errhand.BuildDError("fatal: --%s requires --%s", badRowsParam, contOnErrParam).Build()
+		}
+		if apr.Contains(syncParam) {
+			// synced rows are written in primary key order, so the line numbers of skipped rows aren't known
+			return errhand.BuildDError("fatal: --%s is not supported with --%s", badRowsParam, syncParam).Build()
+		}
+		badRowsLoc := mvdata.NewDataLocation(badRowsFile, "").(mvdata.FileDataLocation)
+		if badRowsLoc.Format != mvdata.CsvFile && badRowsLoc.Format != mvdata.PsvFile && badRowsLoc.Format != mvdata.JsonlFile {
+			return errhand.BuildDError("fatal: the --%s file must have a .csv, .psv, or .jsonl extension", badRowsParam).Build()
+		}
+	}
+
 	tableName := apr.Arg(0)
 	if err := schcmds.ValidateTableNameForCreate(tableName); err != nil {
 		return err
 	}
 
+	if apr.Contains(fromMySQLParam) {
+		return validateMySQLImportArgs(apr)
+	} else if apr.ContainsAny(sourceTableParam, queryParam) {
+		return errhand.BuildDError("fatal: --%s and --%s are only supported with --%s", sourceTableParam, queryParam, fromMySQLParam).Build()
+	}
+
 	path := ""
 	if apr.NArg() > 1 {
 		path = apr.Arg(1)
@@ -338,6 +446,12 @@ func validateImportArgs(apr *argparser.ArgParseResults) errhand.VerboseError {
 		}
 	}
 
+	if apr.Contains(sheetParam) {
+		if srcFileLoc, isFileType := srcLoc.(mvdata.FileDataLocation); !isFileType || srcFileLoc.Format != mvdata.XlsxFile {
+			return errhand.BuildDError("fatal: --%s is only supported when importing an xlsx file", sheetParam).Build()
+		}
+	}
+
 	if srcFileLoc, isFileType := srcLoc.(mvdata.FileDataLocation); isFileType {
 		if srcFileLoc.Format == mvdata.SqlFile {
 			return errhand.BuildDError("For SQL import, please pipe SQL input files to `dolt sql`").Build()
@@ -346,14 +460,51 @@ func validateImportArgs(apr *argparser.ArgParseResults) errhand.VerboseError {
 		_, hasSchema := apr.GetValue(schemaParam)
 		if srcFileLoc.Format == mvdata.JsonFile && apr.Contains(createParam) && !hasSchema {
 			return errhand.BuildDError("Please specify schema file for .json tables.").Build()
+		} else if srcFileLoc.Format == mvdata.JsonlFile && apr.Contains(createParam) && !hasSchema {
+			return errhand.BuildDError("Please specify schema file for .jsonl tables.").Build()
 		} else if srcFileLoc.Format == mvdata.ParquetFile && apr.Contains(createParam) && !hasSchema {
 			return errhand.BuildDError("Please specify schema file for .parquet tables.").Build()
+		} else if (srcFileLoc.Format == mvdata.ArrowFile || srcFileLoc.Format == mvdata.ArrowStreamFile) && apr.Contains(createParam) && !hasSchema {
+			return errhand.BuildDError("Please specify schema file for .arrow tables.").Build()
+		}
+	} else if srcStreamLoc, isStream := srcLoc.(mvdata.StreamDataLocation); isStream {
+		_, hasSchema := apr.GetValue(schemaParam)
+		if srcStreamLoc.Format == mvdata.JsonlFile && apr.Contains(createParam) && !hasSchema {
+			return errhand.BuildDError("Please specify schema file for .jsonl tables.").Build()
+		} else if srcStreamLoc.Format == mvdata.ArrowStreamFile && apr.Contains(createParam) && !hasSchema {
+			return errhand.BuildDError("Please specify schema file for .arrow tables.").Build()
 		}
 	}
 
 	return nil
 }
 
+// validateImportOp validates that exactly one import operation was given.
+func validateImportOp(apr *argparser.ArgParseResults) errhand.VerboseError {
+	if len(apr.ContainsMany(createParam, updateParam, replaceParam, appendParam, syncParam)) != 1 {
+		return errhand.BuildDError("Must specify exactly one of -c, -u, -a, -r, or --sync.").SetPrintUsage().Build()
+	}
+
+	if apr.Contains(allTextParam) && !apr.Contains(createParam) {
+		return errhand.BuildDError("fatal: --%s is only supported for create operations", allTextParam).Build()
+	}
+	return nil
+}
+
+// validateMySQLImportArgs validates the arguments of an import from a MySQL server.
+func validateMySQLImportArgs(apr *argparser.ArgParseResults) errhand.VerboseError {
+	if apr.NArg() > 1 {
+		return errhand.BuildDError("fatal: a file can't be given with --%s", fromMySQLParam).SetPrintUsage().Build()
+	}
+	if len(apr.ContainsMany(sourceTableParam, queryParam)) != 1 {
+		return errhand.BuildDError("fatal: --%s requires exactly one of --%s or --%s", fromMySQLParam, sourceTableParam, queryParam).Build()
+	}
+	if apr.ContainsAny(fileTypeParam, delimParam) {
+		return errhand.BuildDError("fatal: --%s and --%s can't be used with --%s", fileTypeParam, delimParam, fromMySQLParam).Build()
+	}
+	return nil
+}
+
 type ImportCmd struct{}
 
 // Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
@@ -379,10 +530,12 @@ func (cmd ImportCmd) ArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(updateParam, "u", "Update an existing table with the imported data.")
 	ap.SupportsFlag(appendParam, "a", "Require that the operation will not modify any rows in the table.")
 	ap.SupportsFlag(replaceParam, "r", "Replace existing table with imported data while preserving the original schema.")
+	ap.SupportsFlag(syncParam, "", "Make an existing table match the imported data by inserting, updating, and deleting only the rows which differ.")
 	ap.SupportsFlag(forceParam, "f", "If a create operation is being executed, data already exists in the destination, the force flag will allow the target to be overwritten.")
 	ap.SupportsFlag(contOnErrParam, "", "Continue importing when row import errors are encountered.")
 	ap.SupportsFlag(quiet, "", "Suppress any warning messages about invalid rows when using the --continue flag.")
 	ap.SupportsAlias(ignoreSkippedRows, quiet)
+	ap.SupportsString(badRowsParam, "", "file", "Write the rows skipped when using the --continue flag, with their line numbers and errors, to a csv, psv, or jsonl file.")
 	ap.SupportsFlag(disableFkChecks, "", "Disables foreign key checks.")
 	ap.SupportsString(schemaParam, "s", "schema_file", "The schema for the output data.")
 	ap.SupportsString(mappingFileParam, "m", "mapping_file", "A file that lays out how fields should be mapped from input data to output data.")
@@ -390,6 +543,12 @@ func (cmd ImportCmd) ArgParser() *argparser.ArgParser {
 	ap.SupportsString(fileTypeParam, "", "file_type", "Explicitly define the type of the file if it can't be inferred from the file extension.")
 	ap.SupportsString(delimParam, "", "delimiter", "Specify a delimiter for a csv style file with a non-comma delimiter.")
 	ap.SupportsFlag(allTextParam, "", "Treats all fields as text. Can only be used when creating a table.")
+	ap.SupportsString(fromMySQLParam, "", "dsn", "Import rows from the MySQL server with the given data source name, such as user:password@tcp(127.0.0.1:3306)/database, instead of a file.")
+	ap.SupportsString(sourceTableParam, "", "source_table", "The table of the MySQL server given by --from-mysql to import rows from.")
+	ap.SupportsString(queryParam, "", "query", "The query of the MySQL server given by --from-mysql to import the results of.")
+	ap.SupportsString(dirParam, "", "directory", "Import each supported file in the directory into the table named after the file, instead of a single file.")
+	ap.SupportsInt(parallelParam, "", "n", "The number of files read at once with --dir, or of sheets with --sheet all. Only reading is done in parallel: the tables are written one at a time. Defaults to 1.")
+	ap.SupportsString(sheetParam, "", "sheet", "The sheet of an xlsx file to import, which defaults to the sheet named after the table. With 'all', each sheet is imported into the table named after the sheet.")
 	return ap
 }
 
@@ -412,6 +571,12 @@ func (cmd ImportCmd) Exec(ctx context.Context, commandStr string, args []string,
 		return commands.HandleVErrAndExitCode(verr, usage)
 	}
 
+	if apr.Contains(dirParam) {
+		return commands.HandleVErrAndExitCode(importDir(ctx, dEnv, apr), usage)
+	} else if sheet, _ := apr.GetValue(sheetParam); sheet == allSheets {
+		return commands.HandleVErrAndExitCode(importSheets(ctx, dEnv, apr), usage)
+	}
+
 	verr = validateImportArgs(apr)
 	if verr != nil {
 		return commands.HandleVErrAndExitCode(verr, usage)
@@ -428,44 +593,102 @@ func (cmd ImportCmd) Exec(ctx context.Context, commandStr string, args []string,
 		return commands.HandleVErrAndExitCode(verr, usage)
 	}
 
+	wr, skipped, verr := importTable(ctx, dEnv, root, mvOpts, importStatsCB)
+	if verr != nil {
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	cli.PrintErrln()
+
+	if skipped > 0 {
+		cli.PrintErrln(color.YellowString("Lines skipped: %d", skipped))
+		if mvOpts.badRowsFile != "" {
+			cli.PrintErrln(color.YellowString("Skipped rows written to %s", mvOpts.badRowsFile))
+		}
+	}
+	if mvOpts.operation == mvdata.SyncOp {
+		printSyncStat(wr)
+	}
+	cli.Println(color.CyanString("Import completed successfully."))
+
+	return 0
+}
+
+// importTable imports the rows of the source of |mvOpts| into its destination table, and returns the writer the rows
+// were written with along with the number of rows which were skipped. |statsCB| is called with the progress of the
+// import.
+func importTable(ctx context.Context, dEnv *env.DoltEnv, root doltdb.RootValue, mvOpts *importOptions, statsCB noms.StatsCB) (*mvdata.SqlEngineTableWriter, int64, errhand.VerboseError) {
 	rd, nDMErr := newImportDataReader(ctx, root, dEnv, mvOpts)
 	if nDMErr != nil {
-		verr = newDataMoverErrToVerr(mvOpts, nDMErr)
-		return commands.HandleVErrAndExitCode(verr, usage)
+		return nil, 0, newDataMoverErrToVerr(mvOpts, nDMErr)
 	}
 
-	wr, nDMErr := newImportSqlEngineMover(ctx, dEnv, rd.GetSchema(), mvOpts)
+	return importRows(ctx, dEnv, nil, rd, mvOpts, statsCB)
+}
+
+// importRows imports the rows of |rd| into the destination table of |mvOpts|, as importTable does. The rows are
+// written in the transaction of |imp|, if it isn't nil, and otherwise committed to the working set.
+func importRows(ctx context.Context, dEnv *env.DoltEnv, imp *mvdata.SqlEngineImport, rd table.SqlRowReader, mvOpts *importOptions, statsCB noms.StatsCB) (*mvdata.SqlEngineTableWriter, int64, errhand.VerboseError) {
+	wr, nDMErr := newImportSqlEngineMover(ctx, dEnv, imp, rd.GetSchema(), mvOpts, statsCB)
 	if nDMErr != nil {
-		verr = newDataMoverErrToVerr(mvOpts, nDMErr)
-		return commands.HandleVErrAndExitCode(verr, usage)
+		return nil, 0, newDataMoverErrToVerr(mvOpts, nDMErr)
+	}
+
+	var badRowWr *mvdata.BadRowWriter
+	if mvOpts.badRowsFile != "" {
+		var err error
+		badRowWr, err = newBadRowWriter(dEnv, rd.GetSchema(), mvOpts)
+		if err != nil {
+			return nil, 0, errhand.BuildDError("Error opening bad rows file %s.", mvOpts.badRowsFile).AddCause(err).Build()
+		}
 	}
 
-	skipped, err := move(ctx, rd, wr, mvOpts)
+	skipped, err := move(ctx, rd, wr, badRowWr, mvOpts)
+	if badRowWr != nil {
+		if cerr := badRowWr.Close(ctx); cerr != nil && err == nil {
+			err = fmt.Errorf("error writing bad rows file %s: %w", mvOpts.badRowsFile, cerr)
+		}
+	}
 	if err != nil {
 		bdr := errhand.BuildDError("\nAn error occurred while moving data")
 		bdr.AddCause(err)
 		bdr.AddDetails("Errors during import can be ignored using '--continue'")
-		return commands.HandleVErrAndExitCode(bdr.Build(), usage)
+		return nil, skipped, bdr.Build()
 	}
 
-	cli.PrintErrln()
-
-	if skipped > 0 {
-		cli.PrintErrln(color.YellowString("Lines skipped: %d", skipped))
-	}
-	cli.Println(color.CyanString("Import completed successfully."))
-
-	return 0
+	return wr, skipped, nil
 }
 
 var displayStrLen int
 
 func importStatsCB(stats types.AppliedEditStats) {
+	displayStrLen = cli.DeleteAndPrint(displayStrLen, formatImportStats(stats))
+}
+
+// formatImportStats returns the progress of an import for display.
+func formatImportStats(stats types.AppliedEditStats) string {
 	noEffect := stats.NonExistentDeletes + stats.SameVal
 	total := noEffect + stats.Modifications + stats.Additions
 	p := message.NewPrinter(message.MatchLanguage("en")) // adds commas
 	displayStr := p.Sprintf("Rows Processed: %d, Additions: %d, Modifications: %d, Had No Effect: %d", total, stats.Additions, stats.Modifications, noEffect)
-	displayStrLen = cli.DeleteAndPrint(displayStrLen, displayStr)
+	if stats.Deletions > 0 {
+		displayStr += p.Sprintf(", Deletions: %d", stats.Deletions)
+	}
+	return displayStr
+}
+
+// printSyncStat prints a summary of the changes made by a sync import, in the format of dolt diff --stat.
+func printSyncStat(wr *mvdata.SqlEngineTableWriter) {
+	acc := wr.SyncStat()
+	if acc.Adds+acc.Removes+acc.Changes == 0 {
+		cli.Println("No data changes.")
+		return
+	}
+
+	numCols := len(wr.TableSchema().Schema)
+	for _, line := range commands.DiffStatLines(acc, numCols, numCols) {
+		cli.Println(line)
+	}
 }
 
 func newImportDataReader(ctx context.Context, root doltdb.RootValue, dEnv *env.DoltEnv, impOpts *importOptions) (table.SqlRowReader, *mvdata.DataMoverCreationError) {
@@ -488,7 +711,7 @@ func newImportDataReader(ctx context.Context, root doltdb.RootValue, dEnv *env.D
 	return rd, nil
 }
 
-func newImportSqlEngineMover(ctx context.Context, dEnv *env.DoltEnv, rdSchema schema.Schema, imOpts *importOptions) (*mvdata.SqlEngineTableWriter, *mvdata.DataMoverCreationError) {
+func newImportSqlEngineMover(ctx context.Context, dEnv *env.DoltEnv, imp *mvdata.SqlEngineImport, rdSchema schema.Schema, imOpts *importOptions, statsCB noms.StatsCB) (*mvdata.SqlEngineTableWriter, *mvdata.DataMoverCreationError) {
 	moveOps := &mvdata.MoverOptions{Force: imOpts.force, TableToWriteTo: imOpts.destTableName, ContinueOnErr: imOpts.contOnErr, Operation: imOpts.operation, DisableFks: imOpts.disableFkChecks}
 
 	// Returns the schema of the table to be created or the existing schema
@@ -537,7 +760,12 @@ func newImportSqlEngineMover(ctx context.Context, dEnv *env.DoltEnv, rdSchema sc
 		}
 	}
 
-	mv, err := mvdata.NewSqlEngineTableWriter(ctx, dEnv, tableSchema, rowOperationSchema, moveOps, importStatsCB)
+	var mv *mvdata.SqlEngineTableWriter
+	if imp != nil {
+		mv, err = imp.NewTableWriter(ctx, tableSchema, rowOperationSchema, moveOps, statsCB)
+	} else {
+		mv, err = mvdata.NewSqlEngineTableWriter(ctx, dEnv, tableSchema, rowOperationSchema, moveOps, statsCB)
+	}
 	if err != nil {
 		return nil, &mvdata.DataMoverCreationError{ErrType: mvdata.CreateWriterErr, Cause: err}
 	}
@@ -545,9 +773,25 @@ func newImportSqlEngineMover(ctx context.Context, dEnv *env.DoltEnv, rdSchema sc
 	return mv, nil
 }
 
+// newBadRowWriter opens the file that the rows skipped by the import are written to.
+func newBadRowWriter(dEnv *env.DoltEnv, rdSchema schema.Schema, imOpts *importOptions) (*mvdata.BadRowWriter, error) {
+	loc := mvdata.NewDataLocation(imOpts.badRowsFile, "").(mvdata.FileDataLocation)
+	f, err := dEnv.FS.OpenForWrite(loc.Path, os.ModePerm)
+	if err != nil {
+		return nil, err
+	}
+
+	badRowWr, err := mvdata.NewBadRowWriter(f, loc.Format, rdSchema, imOpts.nameMapper)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return badRowWr, nil
+}
+
 type badRowFn func(row sql.Row, rowSchema sql.PrimaryKeySchema, tableName string, lineNumber int, err error) (quit bool)
 
-func move(ctx context.Context, rd table.SqlRowReader, wr *mvdata.SqlEngineTableWriter, options *importOptions) (int64, error) {
+func move(ctx context.Context, rd table.SqlRowReader, wr *mvdata.SqlEngineTableWriter, badRowWr *mvdata.BadRowWriter, options *importOptions) (int64, error) {
 	g, ctx := errgroup.WithContext(ctx)
 
 	// Set up the necessary data points for the import job
@@ -556,7 +800,10 @@ func move(ctx context.Context, rd table.SqlRowReader, wr *mvdata.SqlEngineTableW
 	var printBadRowsStarted bool
 	var badCount int64
 
+	// the reader and writer number the rows they read from 1, which are mapped to lines of the source here
 	badRowCB := func(row sql.Row, rowSchema sql.PrimaryKeySchema, tableName string, lineNumber int, err error) (quit bool) {
+		lineNumber = options.sourceLine(lineNumber)
+
 		// record the first error encountered unless asked to ignore it
 		if row != nil && rowErr == nil && !options.contOnErr {
 			var sqlRowWithColumns []string
@@ -587,6 +834,13 @@ func move(ctx context.Context, rd table.SqlRowReader, wr *mvdata.SqlEngineTableW
 			return true
 		}
 
+		if badRowWr != nil {
+			if werr := badRowWr.WriteBadRow(ctx, row, rowSchema.Schema, lineNumber, err); werr != nil {
+				rowErr = fmt.Errorf("error writing bad rows file %s: %w", options.badRowsFile, werr)
+				return true
+			}
+		}
+
 		// Don't log the skipped rows when asked to suppress warning output
 		if options.quiet {
 			return false
@@ -602,16 +856,38 @@ func move(ctx context.Context, rd table.SqlRowReader, wr *mvdata.SqlEngineTableW
 		return false
 	}
 
+	// The writer numbers rows in the order it receives them, which skips the rows rejected by the reader. The numbers
+	// of those rows are recorded so that the writer's row numbers can be mapped back to the rows of the source.
+	var skippedLinesMu sync.Mutex
+	var skippedLines []int
+	readerBadRowCB := func(row sql.Row, rowSchema sql.PrimaryKeySchema, tableName string, lineNumber int, err error) (quit bool) {
+		skippedLinesMu.Lock()
+		skippedLines = append(skippedLines, lineNumber)
+		skippedLinesMu.Unlock()
+		return badRowCB(row, rowSchema, tableName, lineNumber, err)
+	}
+	writerBadRowCB := func(row sql.Row, rowSchema sql.PrimaryKeySchema, tableName string, lineNumber int, err error) (quit bool) {
+		skippedLinesMu.Lock()
+		for _, skipped := range skippedLines {
+			if skipped > lineNumber {
+				break
+			}
+			lineNumber++
+		}
+		skippedLinesMu.Unlock()
+		return badRowCB(row, rowSchema, tableName, lineNumber, err)
+	}
+
 	// Start the group that reads rows from the reader
 	g.Go(func() error {
 		defer close(parsedRowChan)
 
-		return moveRows(ctx, wr, rd, options, parsedRowChan, badRowCB)
+		return moveRows(ctx, wr, rd, options, parsedRowChan, readerBadRowCB)
 	})
 
 	// Start the group that writes rows
 	g.Go(func() error {
-		err := wr.WriteRows(ctx, parsedRowChan, badRowCB)
+		err := wr.WriteRows(ctx, parsedRowChan, writerBadRowCB)
 		if err != nil {
 			return err
 		}
@@ -654,7 +930,7 @@ func moveRows(
 		return err
 	}
 
-	line := 1
+	line := 0
 
 	for {
 		sqlRow, err := rd.ReadSqlRow(ctx)
@@ -733,6 +1009,15 @@ func getImportSchema(ctx context.Context, dEnv *env.DoltEnv, impOpts *importOpti
 			return nil, &mvdata.DataMoverCreationError{ErrType: mvdata.SchemaErr, Cause: err}
 		}
 
+		if impOpts.srcIsMySQL() {
+			// the source defines the types of its columns, so they aren't inferred
+			outSch, err := mvdata.SourceSchema(ctx, root, rd.GetSchema(), impOpts.destTableName, impOpts.primaryKeys)
+			if err != nil {
+				return nil, &mvdata.DataMoverCreationError{ErrType: mvdata.SchemaErr, Cause: err}
+			}
+			return outSch, nil
+		}
+
 		outSch, err := mvdata.InferSchema(ctx, root, rd, impOpts.destTableName, impOpts.primaryKeys, impOpts)
 		if err != nil {
 			return nil, &mvdata.DataMoverCreationError{ErrType: mvdata.SchemaErr, Cause: err}
@@ -840,7 +1125,7 @@ func NameAndTypeTransform(row sql.Row, rowOperationSchema sql.PrimaryKeySchema,
 
 		// Bit types need additional verification due to the differing values they can take on. "4", "0x04", b'100' should
 		// be interpreted in the correct manner.
-		if _, ok := col.Type.(gmstypes.BitType); ok {
+		if _, ok := col.Type.(gmstypes.BitType); ok && row[i] != nil {
 			colAsString, ok := row[i].(string)
 			if !ok {
 				return nil, fmt.Errorf("error: column value should be of type string")
@@ -0,0 +1,426 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tblcmds
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/fatih/color"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands/schcmds"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/mvdata"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// dirImportFormats are the formats of the files which are imported from a directory.
+var dirImportFormats = map[mvdata.DataFormat]bool{
+	mvdata.CsvFile:         true,
+	mvdata.PsvFile:         true,
+	mvdata.JsonFile:        true,
+	mvdata.JsonlFile:       true,
+	mvdata.ParquetFile:     true,
+	mvdata.ArrowFile:       true,
+	mvdata.ArrowStreamFile: true,
+	mvdata.XlsxFile:        true,
+}
+
+// validateDirImportArgs validates the arguments of an import of a directory of files.
+func validateDirImportArgs(apr *argparser.ArgParseResults) errhand.VerboseError {
+	if apr.NArg() > 0 {
+		return errhand.BuildDError("fatal: a table and file can't be given with --%s", dirParam).SetPrintUsage().Build()
+	}
+
+	if verr := validateImportOp(apr); verr != nil {
+		return verr
+	}
+
+	for _, param := range []string{schemaParam, mappingFileParam, badRowsParam, fileTypeParam, delimParam, fromMySQLParam, sourceTableParam, queryParam, sheetParam} {
+		if apr.Contains(param) {
+			return errhand.BuildDError("fatal: --%s can't be used with --%s", param, dirParam).Build()
+		}
+	}
+
+	if parallel, ok := apr.GetInt(parallelParam); ok && parallel <= 0 {
+		return errhand.BuildDError("fatal: --%s must be a positive number of tables", parallelParam).Build()
+	}
+	return nil
+}
+
+// getDirImportMoveOptions returns the options for importing each supported file of |dir| into the table named after
+// the file, in the order of the files' names.
+func getDirImportMoveOptions(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.DoltEnv, dir string) ([]*importOptions, errhand.VerboseError) {
+	if exists, isDir := dEnv.FS.Exists(dir); !exists || !isDir {
+		return nil, errhand.BuildDError("error: directory '%s' not found.", dir).Build()
+	}
+
+	var paths []string
+	err := dEnv.FS.Iter(dir, false, func(path string, size int64, isDir bool) (stop bool) {
+		if !isDir {
+			// paths are given relative to |dir| as it was given
+			paths = append(paths, filepath.Join(dir, filepath.Base(path)))
+		}
+		return false
+	})
+	if err != nil {
+		return nil, errhand.BuildDError("error: failed to read directory '%s'.", dir).AddCause(err).Build()
+	}
+	sort.Strings(paths)
+
+	var allOpts []*importOptions
+	tablePaths := make(map[string]string)
+	for _, path := range paths {
+		loc, ok := mvdata.NewDataLocation(path, "").(mvdata.FileDataLocation)
+		if !ok || !dirImportFormats[loc.Format] {
+			cli.PrintErrln(color.YellowString("Skipping %s, which isn't a supported file type.", path))
+			continue
+		}
+
+		tableName := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		if verr := schcmds.ValidateTableNameForCreate(tableName); verr != nil {
+			return nil, verr
+		}
+		if other, ok := tablePaths[tableName]; ok {
+			return nil, errhand.BuildDError("error: both %s and %s would be imported into table %s.", other, path, tableName).Build()
+		}
+		tablePaths[tableName] = path
+
+		if apr.Contains(createParam) && loc.Format != mvdata.CsvFile && loc.Format != mvdata.PsvFile && loc.Format != mvdata.XlsxFile {
+			return nil, errhand.BuildDError("error: table %s can't be created from %s, which needs a schema file.", tableName, path).
+				AddDetails("Create the table first and import the file with -u, -r, or --sync.").Build()
+		}
+
+		opts, verr := getTableImportMoveOptions(ctx, apr, dEnv, tableName, path)
+		if verr != nil {
+			return nil, verr
+		}
+		// foreign keys are checked once every table has been imported
+		opts.disableFkChecks = true
+		allOpts = append(allOpts, opts)
+	}
+
+	if len(allOpts) == 0 {
+		return nil, errhand.BuildDError("error: no files to import were found in '%s'.", dir).Build()
+	}
+	return allOpts, nil
+}
+
+// importDir imports each supported file of the directory given by --dir into the table named after the file. Tables
+// are imported all or nothing, as by importTables.
+func importDir(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
+	if verr := validateDirImportArgs(apr); verr != nil {
+		return verr
+	}
+
+	dir, _ := apr.GetValue(dirParam)
+	allOpts, verr := getDirImportMoveOptions(ctx, apr, dEnv, dir)
+	if verr != nil {
+		return verr
+	}
+
+	return importTables(ctx, dEnv, apr, allOpts, fmt.Sprintf("Import %s", dir))
+}
+
+// importTables imports the source of each of |allOpts| into its table, all or nothing. Every table is written through
+// one session, in a single transaction, which is committed along with a Dolt commit of the tables with |message| once
+// every table has been imported. If any table fails to import, or the imported rows violate a foreign key, the
+// transaction is rolled back and the working set is left as it was. Tables are written one at a time, while the
+// sources of up to --parallel tables are read at once.
+func importTables(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults, allOpts []*importOptions, message string) errhand.VerboseError {
+	imp, err := mvdata.NewSqlEngineImport(ctx, dEnv)
+	if err != nil {
+		return errhand.BuildDError("error: failed to start the import.").AddCause(err).Build()
+	}
+	defer imp.Close()
+
+	origRoot, err := imp.WorkingRoot()
+	if err != nil {
+		return errhand.BuildDError("Unable to get the working root value for this data repository.").AddCause(err).Build()
+	}
+
+	readCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	readers := openReaders(readCtx, dEnv, origRoot, allOpts, apr.GetIntOrDefault(parallelParam, 1))
+
+	tables := make([]string, len(allOpts))
+	for i, opts := range allOpts {
+		var opened openedReader
+		select {
+		case opened = <-readers[i]:
+		case <-ctx.Done():
+			return rollbackImport(imp, errhand.VerboseErrorFromError(ctx.Err()))
+		}
+		if opened.verr != nil {
+			return rollbackImport(imp, errhand.BuildDError("error: failed to import %s into table %s.", opts.SrcName(), opts.destTableName).AddCause(opened.verr).Build())
+		}
+
+		var stats types.AppliedEditStats
+		_, skipped, verr := importRows(ctx, dEnv, imp, opened.rd, opts, func(s types.AppliedEditStats) {
+			stats = s
+		})
+		_ = opened.rd.Close(ctx)
+		if verr != nil {
+			return rollbackImport(imp, errhand.BuildDError("error: failed to import %s into table %s.", opts.SrcName(), opts.destTableName).AddCause(verr).Build())
+		}
+
+		cli.Printf("%s: %s\n", opts.destTableName, formatImportStats(stats))
+		if skipped > 0 {
+			cli.PrintErrln(color.YellowString("%s: Lines skipped: %d", opts.destTableName, skipped))
+		}
+		tables[i] = opts.destTableName
+	}
+
+	if !apr.Contains(disableFkChecks) {
+		root, err := imp.WorkingRoot()
+		if err != nil {
+			return rollbackImport(imp, errhand.VerboseErrorFromError(err))
+		}
+
+		// foreign keys created with foreign key checks disabled, as they are in a dump's schema, are only resolved by
+		// the first write that checks them, so they're resolved here to be verified as well
+		resolved, err := resolveForeignKeys(ctx, root)
+		if err != nil {
+			return rollbackImport(imp, errhand.BuildDError("error: failed to check foreign keys.").AddCause(err).Build())
+		}
+		if resolved != root {
+			if err = imp.SetWorkingRoot(resolved); err != nil {
+				return rollbackImport(imp, errhand.BuildDError("error: failed to check foreign keys.").AddCause(err).Build())
+			}
+		}
+
+		violated, err := merge.GetForeignKeyViolatedTables(ctx, resolved, origRoot, doltdb.NewTableNameSet(nil))
+		if err != nil {
+			return rollbackImport(imp, errhand.BuildDError("error: failed to check foreign keys.").AddCause(err).Build())
+		} else if violated.Size() > 0 {
+			return rollbackImport(imp, errhand.BuildDError("error: the imported rows violate foreign keys of the tables %s.", violated.JoinStrings(", ")).
+				AddDetails("Use --disable-fk-checks to import the rows anyway.").Build())
+		}
+	}
+
+	if err = imp.Commit(tables, message); err != nil {
+		return rollbackImport(imp, errhand.BuildDError("error: failed to commit the import.").AddCause(err).Build())
+	}
+
+	cli.Println(color.CyanString("Import completed successfully."))
+	return nil
+}
+
+// rollbackImport rolls back the transaction of |imp| after the import of several tables failed with |verr|.
+func rollbackImport(imp *mvdata.SqlEngineImport, verr errhand.VerboseError) errhand.VerboseError {
+	if err := imp.Rollback(); err != nil {
+		return errhand.BuildDError("error: failed to roll back the import.").AddCause(err).
+			AddDetails("The import failed with: %s", verr.Error()).Build()
+	}
+	return verr
+}
+
+// readAheadRows is the number of rows of a table which are read ahead of the rows being written.
+const readAheadRows = 64 * 1024
+
+// openedReader is the reader of the source of a table, or the error opening it.
+type openedReader struct {
+	rd   table.SqlRowReader
+	verr errhand.VerboseError
+}
+
+// openReaders opens the reader of the source of each of |allOpts|, in order, and sends it on the channel of the same
+// index. Each reader reads ahead of the rows being written, and at most |parallel| readers are read at once, so that
+// the sources of later tables are read while the rows of earlier ones are written. No more readers are opened after
+// one fails to open.
+func openReaders(ctx context.Context, dEnv *env.DoltEnv, root doltdb.RootValue, allOpts []*importOptions, parallel int) []chan openedReader {
+	readers := make([]chan openedReader, len(allOpts))
+	for i := range readers {
+		readers[i] = make(chan openedReader, 1)
+	}
+
+	sem := make(chan struct{}, parallel)
+	go func() {
+		for i, opts := range allOpts {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+
+			rd, dmce := newImportDataReader(ctx, root, dEnv, opts)
+			if dmce != nil {
+				<-sem
+				readers[i] <- openedReader{verr: newDataMoverErrToVerr(opts, dmce)}
+				return
+			}
+			readers[i] <- openedReader{rd: newReadAheadReader(ctx, rd, func() { <-sem })}
+		}
+	}()
+	return readers
+}
+
+// readAheadReader is a table.SqlRowReader which reads the rows of another reader in the background.
+type readAheadReader struct {
+	table.SqlRowReader
+	rows   chan readRow
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// readRow is a row read by a readAheadReader, or the error reading it.
+type readRow struct {
+	row sql.Row
+	err error
+}
+
+// newReadAheadReader starts reading the rows of |rd| in the background, and calls |done| once they've all been read.
+func newReadAheadReader(ctx context.Context, rd table.SqlRowReader, done func()) *readAheadReader {
+	ctx, cancel := context.WithCancel(ctx)
+	rar := &readAheadReader{
+		SqlRowReader: rd,
+		rows:         make(chan readRow, readAheadRows),
+		cancel:       cancel,
+		done:         make(chan struct{}),
+	}
+
+	go func() {
+		defer close(rar.done)
+		defer done()
+		for {
+			row, err := rd.ReadSqlRow(ctx)
+			select {
+			case rar.rows <- readRow{row: row, err: err}:
+			case <-ctx.Done():
+				return
+			}
+			// bad rows are reported, and reading continues past them
+			if err != nil && !table.IsBadRow(err) {
+				return
+			}
+		}
+	}()
+	return rar
+}
+
+// ReadSqlRow returns the next row read ahead.
+func (rar *readAheadReader) ReadSqlRow(ctx context.Context) (sql.Row, error) {
+	select {
+	case r := <-rar.rows:
+		return r.row, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Close stops reading ahead and closes the underlying reader.
+func (rar *readAheadReader) Close(ctx context.Context) error {
+	rar.cancel()
+	<-rar.done
+	return rar.SqlRowReader.Close(ctx)
+}
+
+// resolveForeignKeys returns |root| with each of its unresolved foreign keys resolved against the tables' schemas.
+func resolveForeignKeys(ctx context.Context, root doltdb.RootValue) (doltdb.RootValue, error) {
+	fkc, err := root.GetForeignKeyCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := false
+	for _, fk := range fkc.AllKeys() {
+		if fk.IsResolved() {
+			continue
+		}
+
+		sch, ok, err := getSchemaInsensitive(ctx, root, fk.TableName)
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			continue
+		}
+		refSch, ok, err := getSchemaInsensitive(ctx, root, fk.ReferencedTableName)
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			continue
+		}
+
+		fk.TableColumns, fk.TableIndex, ok, err = resolveForeignKeyColumns(sch, fk.UnresolvedFKDetails.TableColumns)
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			continue
+		}
+		fk.ReferencedTableColumns, fk.ReferencedTableIndex, ok, err = resolveForeignKeyColumns(refSch, fk.UnresolvedFKDetails.ReferencedTableColumns)
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			continue
+		}
+
+		fkc.RemoveKeyByName(fk.Name)
+		if err = fkc.AddKeys(fk); err != nil {
+			return nil, err
+		}
+		resolved = true
+	}
+
+	if !resolved {
+		return root, nil
+	}
+	return root.PutForeignKeyCollection(ctx, fkc)
+}
+
+// getSchemaInsensitive returns the schema of the table named |tableName|, matched case-insensitively.
+func getSchemaInsensitive(ctx context.Context, root doltdb.RootValue, tableName doltdb.TableName) (schema.Schema, bool, error) {
+	tbl, _, ok, err := doltdb.GetTableInsensitive(ctx, root, tableName)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	return sch, true, nil
+}
+
+// resolveForeignKeyColumns returns the tags of the columns of |sch| named |colNames| and the index used for them by a
+// foreign key, which is empty for the primary key.
+func resolveForeignKeyColumns(sch schema.Schema, colNames []string) ([]uint64, string, bool, error) {
+	tags := make([]uint64, len(colNames))
+	for i, name := range colNames {
+		col, ok := sch.GetAllCols().GetByNameCaseInsensitive(name)
+		if !ok {
+			return nil, "", false, nil
+		}
+		tags[i] = col.Tag
+	}
+
+	idx, ok, err := sqle.FindIndexWithPrefix(sch, colNames)
+	if err != nil {
+		return nil, "", false, err
+	} else if ok {
+		return tags, idx.Name(), true, nil
+	}
+	return tags, "", true, nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tblcmds
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/commands/schcmds"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/mvdata"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/untyped/xlsx"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+// validateSheetsImportArgs validates the arguments of an import of every sheet of an xlsx file.
+func validateSheetsImportArgs(apr *argparser.ArgParseResults) errhand.VerboseError {
+	if apr.NArg() != 1 {
+		return errhand.BuildDError("fatal: only the file is given with --%s %s", sheetParam, allSheets).SetPrintUsage().Build()
+	}
+
+	if verr := validateImportOp(apr); verr != nil {
+		return verr
+	}
+
+	for _, param := range []string{schemaParam, mappingFileParam, badRowsParam, delimParam, fromMySQLParam, sourceTableParam, queryParam} {
+		if apr.Contains(param) {
+			return errhand.BuildDError("fatal: --%s can't be used with --%s %s", param, sheetParam, allSheets).Build()
+		}
+	}
+
+	fType, _ := apr.GetValue(fileTypeParam)
+	if loc, ok := mvdata.NewDataLocation(apr.Arg(0), fType).(mvdata.FileDataLocation); !ok || loc.Format != mvdata.XlsxFile {
+		return errhand.BuildDError("fatal: --%s is only supported when importing an xlsx file", sheetParam).Build()
+	}
+
+	if parallel, ok := apr.GetInt(parallelParam); ok && parallel <= 0 {
+		return errhand.BuildDError("fatal: --%s must be a positive number of tables", parallelParam).Build()
+	}
+	return nil
+}
+
+// getSheetsImportMoveOptions returns the options for importing each sheet of the xlsx file at |path| into the table
+// named after the sheet, in the order of the sheets.
+func getSheetsImportMoveOptions(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.DoltEnv, path string) ([]*importOptions, errhand.VerboseError) {
+	absPath, err := dEnv.FS.Abs(path)
+	if err != nil {
+		return nil, errhand.VerboseErrorFromError(err)
+	}
+	sheets, err := xlsx.SheetNames(absPath)
+	if err != nil {
+		return nil, errhand.BuildDError("error: failed to read the sheets of '%s'.", path).AddCause(err).Build()
+	}
+	if len(sheets) == 0 {
+		return nil, errhand.BuildDError("error: '%s' has no sheets to import.", path).Build()
+	}
+
+	allOpts := make([]*importOptions, 0, len(sheets))
+	for _, sheet := range sheets {
+		if verr := schcmds.ValidateTableNameForCreate(sheet); verr != nil {
+			return nil, verr
+		}
+
+		opts, verr := getTableImportMoveOptions(ctx, apr, dEnv, sheet, path)
+		if verr != nil {
+			return nil, verr
+		}
+		// foreign keys are checked once every table has been imported
+		opts.disableFkChecks = true
+		allOpts = append(allOpts, opts)
+	}
+	return allOpts, nil
+}
+
+// importSheets imports each sheet of the xlsx file given with --sheet all into the table named after the sheet. Tables
+// are imported all or nothing, as by importTables.
+func importSheets(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
+	if verr := validateSheetsImportArgs(apr); verr != nil {
+		return verr
+	}
+
+	allOpts, verr := getSheetsImportMoveOptions(ctx, apr, dEnv, apr.Arg(0))
+	if verr != nil {
+		return verr
+	}
+
+	return importTables(ctx, dEnv, apr, allOpts, fmt.Sprintf("Import the sheets of %s", apr.Arg(0)))
+}
@@ -74,6 +74,7 @@ var commandsWithoutCliCtx = []cli.Command{
 	admin.Commands,
 	commands.CloneCmd{},
 	commands.BackupCmd{},
+	commands.BundleCmd{},
 	commands.LoginCmd{},
 	credcmds.Commands,
 	schcmds.Commands,
@@ -661,11 +662,18 @@ If you're interested in running this command against a remote host, hit us up on
 	if noValidRepository && isValidRepositoryRequired {
 		return func(ctx context.Context) (cli.Queryist, *sql.Context, func(), error) {
 			err := errors.New("The current directory is not a valid dolt repository.")
+			// |rootEnv| is loaded without its database, which is loaded here to explain why it isn't valid
+			rootEnv.DoltDB(ctx)
 			if errors.Is(rootEnv.DBLoadError, nbs.ErrUnsupportedTableFileFormat) {
 				// This is fairly targeted and specific to allow for better error messaging. We should consider
 				// breaking this out into its own function if we add more conditions.
 
 				err = errors.New("The data in this database is in an unsupported format. Please upgrade to the latest version of Dolt.")
+			} else if errors.Is(rootEnv.DBLoadError, nbs.ErrEncryptionKeyNotConfigured) || errors.Is(rootEnv.DBLoadError, nbs.ErrEncryptionKeyUnknown) {
+				err = fmt.Errorf("The data in this database is encrypted: %w. Set %s or %s to its encryption key.",
+					rootEnv.DBLoadError, dconfig.EnvEncryptionKey, dconfig.EnvEncryptionKeyFile)
+			} else if errors.Is(rootEnv.DBLoadError, dbfactory.ErrInvalidColdTierConfig) {
+				err = fmt.Errorf("The storage settings of this database are invalid: %w. Fix them with dolt config --local.", rootEnv.DBLoadError)
 			}
 
 			return nil, nil, nil, err
@@ -39,6 +39,7 @@ var doltSubCommands = []cli.Command{
 	commands.StatusCmd{},
 	commands.AddCmd{},
 	commands.DiffCmd{},
+	commands.ApplyCmd{},
 	commands.ResetCmd{},
 	commands.CleanCmd{},
 	commands.CommitCmd{},
@@ -60,6 +61,7 @@ var doltSubCommands = []cli.Command{
 	commands.ConfigCmd{},
 	commands.RemoteCmd{},
 	commands.BackupCmd{},
+	commands.BundleCmd{},
 	commands.LoginCmd{},
 	credcmds.Commands,
 	commands.LsCmd{},
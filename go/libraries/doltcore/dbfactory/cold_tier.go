@@ -0,0 +1,176 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbfactory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/dustin/go-humanize"
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+
+	"github.com/dolthub/dolt/go/libraries/utils/earl"
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/nbs"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const (
+	// ColdTierURLParam is a creation parameter for local databases which sets the url of the blobstore that holds
+	// their cold history. Supported schemes are file, localbs, gs, oci, oss and s3, where s3 urls have the form
+	// s3://bucket/prefix and use the aws-* creation parameters for their credentials.
+	ColdTierURLParam = "cold-tier-url"
+
+	// ColdTierAfterParam is a creation parameter for local databases which sets how old commits must be before a full
+	// GC moves the history behind them to the cold tier, e.g. "720h".
+	ColdTierAfterParam = "cold-tier-after"
+
+	// ColdTierCacheSizeParam is a creation parameter for local databases which bounds the size of the local cache of
+	// the data read from the cold tier, e.g. "10GB".
+	ColdTierCacheSizeParam = "cold-tier-cache-size"
+
+	defaultColdTierAfter     = 30 * 24 * time.Hour
+	defaultColdTierCacheSize = 10 * humanize.GByte
+
+	// coldTierCacheDir is the directory, within the data directory, which holds the cache of the cold tier.
+	coldTierCacheDir = "coldcache"
+
+	// coldTierS3Scheme is the url scheme of a cold tier held in an S3 bucket.
+	coldTierS3Scheme = "s3"
+)
+
+// ErrInvalidColdTierConfig is returned when a local database can't be opened because of the settings of its cold tier.
+var ErrInvalidColdTierConfig = errors.New("invalid cold tier configuration")
+
+// openColdStore opens the cold tier configured in |params| for the local database with data directory |path|, along
+// with the age at which commits move to it. It returns a nil store if no cold tier is configured.
+func openColdStore(ctx context.Context, nbf *types.NomsBinFormat, path string, params map[string]interface{}) (*nbs.NomsBlockStore, time.Duration, error) {
+	urlStr, ok, err := stringParam(params, ColdTierURLParam)
+	if err != nil || !ok {
+		return nil, 0, err
+	}
+
+	after := defaultColdTierAfter
+	if s, ok, err := stringParam(params, ColdTierAfterParam); err != nil {
+		return nil, 0, err
+	} else if ok {
+		after, err = time.ParseDuration(s)
+		if err != nil || after < 0 {
+			return nil, 0, fmt.Errorf("%w: invalid value for %s: '%s'", ErrInvalidColdTierConfig, ColdTierAfterParam, s)
+		}
+	}
+
+	cacheSize := uint64(defaultColdTierCacheSize)
+	if s, ok, err := stringParam(params, ColdTierCacheSizeParam); err != nil {
+		return nil, 0, err
+	} else if ok {
+		cacheSize, err = humanize.ParseBytes(s)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: invalid value for %s: '%s'", ErrInvalidColdTierConfig, ColdTierCacheSizeParam, s)
+		}
+	}
+
+	bs, err := coldTierBlobstore(ctx, urlStr, params)
+	if err != nil {
+		return nil, 0, err
+	}
+	st, err := nbs.NewColdStore(ctx, nbf.VersionString(), bs, filepath.Join(path, coldTierCacheDir), int64(cacheSize), nbs.NewUnlimitedMemQuotaProvider())
+	if err != nil {
+		return nil, 0, err
+	}
+	return st, after, nil
+}
+
+// coldTierBlobstore returns the blobstore at |urlStr|.
+func coldTierBlobstore(ctx context.Context, urlStr string, params map[string]interface{}) (blobstore.Blobstore, error) {
+	if strings.HasPrefix(strings.ToLower(urlStr), AWSScheme+"://") {
+		// aws urls name a DynamoDB table along with the bucket, which a cold tier doesn't use.
+		return nil, fmt.Errorf("%w: aws urls are not supported for cold tiers, use s3://bucket/prefix instead", ErrInvalidColdTierConfig)
+	}
+
+	urlObj, err := earl.Parse(urlStr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidColdTierConfig, err)
+	}
+
+	switch strings.ToLower(urlObj.Scheme) {
+	case FileScheme, LocalBSScheme:
+		path, err := url.PathUnescape(urlObj.Path)
+		if err != nil {
+			return nil, err
+		}
+		path, err = filepath.Abs(urlObj.Host + filepath.FromSlash(path))
+		if err != nil {
+			return nil, err
+		}
+		if err = os.MkdirAll(path, os.ModePerm); err != nil {
+			return nil, err
+		}
+		return blobstore.NewLocalBlobstore(path), nil
+
+	case GSScheme:
+		gcs, err := storage.NewClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return blobstore.NewGCSBlobstore(gcs, urlObj.Host, urlObj.Path), nil
+
+	case OCIScheme:
+		provider := common.DefaultConfigProvider()
+		client, err := objectstorage.NewObjectStorageClientWithConfigurationProvider(provider)
+		if err != nil {
+			return nil, err
+		}
+		return blobstore.NewOCIBlobstore(ctx, provider, client, urlObj.Host, urlObj.Path)
+
+	case OSSScheme:
+		ossClient, err := getOSSClient(ossConfigFromParams(params))
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize oss err: %s", err)
+		}
+		return blobstore.NewOSSBlobstore(ossClient, urlObj.Hostname(), urlObj.Path)
+
+	case coldTierS3Scheme:
+		cfg, err := awsConfigFromParams(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		return blobstore.NewS3Blobstore(s3.NewFromConfig(cfg), urlObj.Host, urlObj.Path), nil
+
+	default:
+		return nil, fmt.Errorf("%w: unsupported cold tier url scheme: '%s'", ErrInvalidColdTierConfig, urlObj.Scheme)
+	}
+}
+
+func stringParam(params map[string]interface{}, name string) (string, bool, error) {
+	v, ok := params[name]
+	if !ok {
+		return "", false, nil
+	}
+	s, ok := v.(string)
+	if !ok || len(s) == 0 {
+		return "", false, fmt.Errorf("%w: invalid value for %s", ErrInvalidColdTierConfig, name)
+	}
+	return s, true, nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbfactory
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/remotestorage"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// EncryptionKeyFileParam is a creation parameter that can be used to specify a file holding the key of a remote which
+// is encrypted on the client. The file holds a base64 encoded 32 byte key.
+const EncryptionKeyFileParam = "encryption-key-file"
+
+// encryptDB returns a database which encrypts the chunks written to |db| with the key in the file at |keyFile|, and
+// decrypts those read from it.
+func encryptDB(db datas.Database, keyFile interface{}) (datas.Database, types.ValueReadWriter, tree.NodeStore, error) {
+	path, ok := keyFile.(string)
+	if !ok || len(path) == 0 {
+		return nil, nil, nil, fmt.Errorf("invalid value for %s", EncryptionKeyFileParam)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to read the remote encryption key: %w", err)
+	}
+	key, err := remotestorage.ParseEncryptionKey(string(b))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	cs, err := remotestorage.NewEncryptedChunkStore(datas.ChunkStoreFromDatabase(db), key)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	vrw := types.NewValueStore(cs)
+	ns := tree.NewNodeStore(cs)
+	return datas.NewTypesDatabase(vrw, ns), vrw, ns, nil
+}
@@ -55,8 +55,19 @@ const (
 
 	defaultScheme       = HTTPSScheme
 	defaultMemTableSize = 256 * 1024 * 1024
+
+	// UnsealedParam is a creation parameter which opens a database whose table files are written in plaintext, even
+	// when encryption at rest is configured. Remotes are opened this way, as they're read by machines which don't have
+	// the key.
+	UnsealedParam = "__DOLT__unsealed"
 )
 
+// isUnsealed returns whether |params| open a database whose table files are written in plaintext.
+func isUnsealed(params map[string]interface{}) bool {
+	_, ok := params[UnsealedParam]
+	return ok
+}
+
 // DBFactory is an interface for creating concrete datas.Database instances from different backing stores
 type DBFactory interface {
 	// CreateDB returns the database located at the URL given and its associated data access interfaces
@@ -95,7 +106,14 @@ func CreateDB(ctx context.Context, nbf *types.NomsBinFormat, urlStr string, para
 	}
 
 	if fact, ok := DBFactories[strings.ToLower(scheme)]; ok {
-		return fact.CreateDB(ctx, nbf, urlObj, params)
+		db, vrw, ns, err := fact.CreateDB(ctx, nbf, urlObj, params)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if keyFile, ok := params[EncryptionKeyFileParam]; ok {
+			return encryptDB(db, keyFile)
+		}
+		return db, vrw, ns, nil
 	}
 
 	return nil, nil, nil, fmt.Errorf("unknown url scheme: '%s'", urlObj.Scheme)
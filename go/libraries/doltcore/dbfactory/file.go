@@ -132,6 +132,11 @@ func (fact FileFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat,
 	path = urlObj.Host + path
 
 	err = validateDir(path)
+	if errors.Is(err, filesys.ErrIsFile) {
+		if ok, bErr := nbs.IsBundle(path); bErr == nil && ok {
+			return openBundleDB(ctx, nbf, path)
+		}
+	}
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -141,12 +146,17 @@ func (fact FileFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat,
 		_, useJournal = params[ChunkJournalParam]
 	}
 
+	newLocalStore := nbs.NewLocalStore
+	if isUnsealed(params) {
+		newLocalStore = nbs.NewUnsealedLocalStore
+	}
+
 	var newGenSt *nbs.NomsBlockStore
 	q := nbs.NewUnlimitedMemQuotaProvider()
 	if useJournal && chunkJournalFeatureFlag {
 		newGenSt, err = nbs.NewLocalJournalingStore(ctx, nbf.VersionString(), path, q)
 	} else {
-		newGenSt, err = nbs.NewLocalStore(ctx, nbf.VersionString(), path, defaultMemTableSize, q)
+		newGenSt, err = newLocalStore(ctx, nbf.VersionString(), path, defaultMemTableSize, q)
 	}
 
 	if err != nil {
@@ -166,7 +176,7 @@ func (fact FileFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat,
 		}
 	}
 
-	oldGenSt, err := nbs.NewLocalStore(ctx, newGenSt.Version(), oldgenPath, defaultMemTableSize, q)
+	oldGenSt, err := newLocalStore(ctx, newGenSt.Version(), oldgenPath, defaultMemTableSize, q)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -176,7 +186,12 @@ func (fact FileFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat,
 		return nil, nil, nil, err
 	}
 
-	st := nbs.NewGenerationalCS(oldGenSt, newGenSt, ghostGen)
+	coldGenSt, coldAfter, err := openColdStore(ctx, nbf, path, params)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	st := nbs.NewTieredGenerationalCS(oldGenSt, newGenSt, coldGenSt, ghostGen, coldAfter)
 	// metrics?
 
 	vrw := types.NewValueStore(st)
@@ -192,6 +207,24 @@ func (fact FileFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat,
 	return ddb, vrw, ns, nil
 }
 
+// openBundleDB returns a read-only database of the chunks of the bundle file at |path|. Bundles aren't cached, as they
+// may be replaced between reads.
+func openBundleDB(ctx context.Context, nbf *types.NomsBinFormat, path string) (datas.Database, types.ValueReadWriter, tree.NodeStore, error) {
+	b, err := nbs.OpenBundle(path)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	st, err := nbs.NewBundleStore(ctx, nbf.VersionString(), b, nbs.NewUnlimitedMemQuotaProvider())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	vrw := types.NewValueStore(st)
+	ns := tree.NewNodeStore(st)
+	return datas.NewTypesDatabase(vrw, ns), vrw, ns, nil
+}
+
 func validateDir(path string) error {
 	info, err := os.Stat(path)
 
@@ -48,7 +48,11 @@ func (fact GSFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, ur
 
 	bs := blobstore.NewGCSBlobstore(gcs, urlObj.Host, urlObj.Path)
 	q := nbs.NewUnlimitedMemQuotaProvider()
-	gcsStore, err := nbs.NewBSStore(ctx, nbf.VersionString(), bs, defaultMemTableSize, q)
+	newStore := nbs.NewBSStore
+	if isUnsealed(params) {
+		newStore = nbs.NewUnsealedBSStore
+	}
+	gcsStore, err := newStore(ctx, nbf.VersionString(), bs, defaultMemTableSize, q)
 
 	if err != nil {
 		return nil, nil, nil, err
@@ -81,7 +85,11 @@ func (fact LocalBSFactory) CreateDB(ctx context.Context, nbf *types.NomsBinForma
 
 	bs := blobstore.NewLocalBlobstore(absPath)
 	q := nbs.NewUnlimitedMemQuotaProvider()
-	bsStore, err := nbs.NewBSStore(ctx, nbf.VersionString(), bs, defaultMemTableSize, q)
+	newStore := nbs.NewBSStore
+	if isUnsealed(params) {
+		newStore = nbs.NewUnsealedBSStore
+	}
+	bsStore, err := newStore(ctx, nbf.VersionString(), bs, defaultMemTableSize, q)
 
 	if err != nil {
 		return nil, nil, nil, err
@@ -42,7 +42,11 @@ func (fact MemFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, u
 
 	bs := blobstore.NewInMemoryBlobstore(uuid.New().String())
 	q := nbs.NewUnlimitedMemQuotaProvider()
-	cs, err := nbs.NewBSStore(ctx, nbf.VersionString(), bs, defaultMemTableSize, q)
+	newStore := nbs.NewBSStore
+	if isUnsealed(params) {
+		newStore = nbs.NewUnsealedBSStore
+	}
+	cs, err := newStore(ctx, nbf.VersionString(), bs, defaultMemTableSize, q)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -54,7 +54,11 @@ func (fact OCIFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, u
 
 	q := nbs.NewUnlimitedMemQuotaProvider()
 
-	ociStore, err := nbs.NewNoConjoinBSStore(ctx, nbf.VersionString(), bs, defaultMemTableSize, q)
+	newStore := nbs.NewNoConjoinBSStore
+	if isUnsealed(params) {
+		newStore = nbs.NewUnsealedNoConjoinBSStore
+	}
+	ociStore, err := newStore(ctx, nbf.VersionString(), bs, defaultMemTableSize, q)
 	if err != nil {
 		return nil, nil, nil, err
 	}
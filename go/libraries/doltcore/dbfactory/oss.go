@@ -97,6 +97,9 @@ func (fact OSSFactory) newChunkStore(ctx context.Context, nbf *types.NomsBinForm
 	}
 
 	q := nbs.NewUnlimitedMemQuotaProvider()
+	if isUnsealed(params) {
+		return nbs.NewUnsealedBSStore(ctx, nbf.VersionString(), bs, defaultMemTableSize, q)
+	}
 	return nbs.NewBSStore(ctx, nbf.VersionString(), bs, defaultMemTableSize, q)
 }
 
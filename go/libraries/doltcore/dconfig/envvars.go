@@ -45,6 +45,8 @@ const (
 	EnvDbNameReplace                 = "DOLT_DBNAME_REPLACE"
 	EnvDoltRootHost                  = "DOLT_ROOT_HOST"
 	EnvDoltRootPassword              = "DOLT_ROOT_PASSWORD"
+	EnvEncryptionKey                 = "DOLT_ENCRYPTION_KEY"
+	EnvEncryptionKeyFile             = "DOLT_ENCRYPTION_KEY_FILE"
 
 	// If set, must be "kill_connections" or "session_aware"
 	// Will go away after session_aware is made default-and-only.
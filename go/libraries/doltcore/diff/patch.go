@@ -0,0 +1,328 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+)
+
+// A patch is a line-oriented text format describing the changes between two revisions, which can be applied to
+// another database with `dolt apply`. Each line is a record consisting of the record type, a space, and the JSON
+// encoding of the record. The first record is the patch header and the last record is the patch trailer, which holds
+// the number of records before it and the SHA-256 checksum of their lines, so that a truncated or corrupted patch is
+// detected. Blank lines and lines beginning with # are ignored, and are not part of the checksum.
+//
+//	dolt-patch {"version":1}
+//	table {"from":"t","to":"t","diff_type":"modified"}
+//	schema {"from":"CREATE TABLE ...","to":"CREATE TABLE ...","statements":["ALTER TABLE ..."]}
+//	columns [{"name":"pk","primary_key":true,"from":true,"to":true},{"name":"c","from":true,"to":true}]
+//	update {"key":["1"],"old":["1","a"],"new":["1","b"]}
+//	end {"records":5,"checksum":"..."}
+//
+// Row records hold the values of the columns listed in the preceding columns record, formatted as strings. NULL
+// values are null.
+
+// PatchFormatVersion is the version of the patch format written by PatchWriter.
+const PatchFormatVersion = 1
+
+const (
+	PatchHeaderRecord  = "dolt-patch"
+	PatchTableRecord   = "table"
+	PatchSchemaRecord  = "schema"
+	PatchColumnsRecord = "columns"
+	PatchInsertRecord  = "insert"
+	PatchDeleteRecord  = "delete"
+	PatchUpdateRecord  = "update"
+	PatchViewRecord    = "view"
+	PatchTriggerRecord = "trigger"
+	PatchEventRecord   = "event"
+	PatchEndRecord     = "end"
+)
+
+const (
+	// PatchEncodingHex is the encoding of binary column values, which are hex encoded.
+	PatchEncodingHex = "hex"
+	// PatchEncodingJSON is the encoding of JSON column values.
+	PatchEncodingJSON = "json"
+)
+
+// PatchHeader is the first record of a patch.
+type PatchHeader struct {
+	Version int `json:"version"`
+}
+
+// PatchTrailer is the last record of a patch. |Records| is the number of records before it, including the header, and
+// |Checksum| is the hex encoded SHA-256 checksum of their lines.
+type PatchTrailer struct {
+	Records  int    `json:"records"`
+	Checksum string `json:"checksum"`
+}
+
+// PatchTable begins the records for a table. |From| is empty for added tables and |To| is empty for dropped tables.
+type PatchTable struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	DiffType string `json:"diff_type"`
+}
+
+// PatchSchema is a change to the schema of a table. |From| and |To| are the CREATE TABLE statements before and after
+// the change, and |Statements| are the statements which make the change.
+type PatchSchema struct {
+	From       string   `json:"from"`
+	To         string   `json:"to"`
+	Statements []string `json:"statements"`
+}
+
+// PatchColumn describes a column of the rows of a table.
+type PatchColumn struct {
+	Name       string `json:"name"`
+	PrimaryKey bool   `json:"primary_key,omitempty"`
+	Encoding   string `json:"encoding,omitempty"`
+	// From and To are whether the column exists before and after the change.
+	From bool `json:"from"`
+	To   bool `json:"to"`
+}
+
+// PatchRow is an inserted, deleted or updated row. |Old| is nil for inserts and |New| is nil for deletes.
+type PatchRow struct {
+	Key []*string `json:"key"`
+	Old []*string `json:"old,omitempty"`
+	New []*string `json:"new,omitempty"`
+}
+
+// PatchFragment is a change to a view, trigger or event. |From| is empty if it was created, and |To| is empty if it
+// was dropped.
+type PatchFragment struct {
+	Name string `json:"name"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// PatchRecord is a record read from a patch. Only the field for the record's |Type| is set.
+type PatchRecord struct {
+	Type     string
+	Line     int
+	Table    *PatchTable
+	Schema   *PatchSchema
+	Columns  []PatchColumn
+	Row      *PatchRow
+	Fragment *PatchFragment
+}
+
+// PatchWriter writes patch records. Close must be called after the last record to write the patch trailer.
+type PatchWriter struct {
+	wr      io.Writer
+	records int
+	sum     hash.Hash
+}
+
+// NewPatchWriter returns a PatchWriter writing to |wr|, and writes the patch header.
+func NewPatchWriter(wr io.Writer) (*PatchWriter, error) {
+	pw := &PatchWriter{wr: wr, sum: sha256.New()}
+	if err := pw.writeRecord(PatchHeaderRecord, PatchHeader{Version: PatchFormatVersion}); err != nil {
+		return nil, err
+	}
+	return pw, nil
+}
+
+// WriteTable writes a table record.
+func (pw *PatchWriter) WriteTable(table PatchTable) error {
+	return pw.writeRecord(PatchTableRecord, table)
+}
+
+// WriteSchema writes a schema record.
+func (pw *PatchWriter) WriteSchema(sch PatchSchema) error {
+	return pw.writeRecord(PatchSchemaRecord, sch)
+}
+
+// WriteColumns writes a columns record.
+func (pw *PatchWriter) WriteColumns(cols []PatchColumn) error {
+	return pw.writeRecord(PatchColumnsRecord, cols)
+}
+
+// WriteRow writes an insert, delete or update record, depending on which of the row's values are set.
+func (pw *PatchWriter) WriteRow(row PatchRow) error {
+	switch {
+	case row.Old == nil:
+		return pw.writeRecord(PatchInsertRecord, row)
+	case row.New == nil:
+		return pw.writeRecord(PatchDeleteRecord, row)
+	default:
+		return pw.writeRecord(PatchUpdateRecord, row)
+	}
+}
+
+// WriteFragment writes a view, trigger or event record.
+func (pw *PatchWriter) WriteFragment(recordType string, fragment PatchFragment) error {
+	return pw.writeRecord(recordType, fragment)
+}
+
+// Close writes the patch trailer. It does not close the underlying writer.
+func (pw *PatchWriter) Close() error {
+	return pw.writeRecord(PatchEndRecord, PatchTrailer{Records: pw.records, Checksum: hex.EncodeToString(pw.sum.Sum(nil))})
+}
+
+func (pw *PatchWriter) writeRecord(recordType string, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	line := fmt.Sprintf("%s %s\n", recordType, b)
+	if _, err = io.WriteString(pw.wr, line); err != nil {
+		return err
+	}
+	pw.records++
+	pw.sum.Write([]byte(line))
+	return nil
+}
+
+// PatchReader reads patch records. The patch is only known to be complete once Next has returned io.EOF, which it does
+// after reading a trailer matching the records before it.
+type PatchReader struct {
+	rd      *bufio.Reader
+	line    int
+	records int
+	sum     hash.Hash
+	done    bool
+}
+
+// NewPatchReader returns a PatchReader reading from |rd|, after reading and validating the patch header.
+func NewPatchReader(rd io.Reader) (*PatchReader, error) {
+	pr := &PatchReader{rd: bufio.NewReader(rd), sum: sha256.New()}
+	recordType, payload, err := pr.nextLine()
+	if err == io.EOF {
+		return nil, errors.New("patch is empty")
+	} else if err != nil {
+		return nil, err
+	}
+	if recordType != PatchHeaderRecord {
+		return nil, fmt.Errorf("not a dolt patch: expected '%s' on line %d", PatchHeaderRecord, pr.line)
+	}
+	var header PatchHeader
+	if err = json.Unmarshal(payload, &header); err != nil {
+		return nil, pr.lineError(err)
+	}
+	if header.Version != PatchFormatVersion {
+		return nil, fmt.Errorf("unsupported patch version %d", header.Version)
+	}
+	return pr, nil
+}
+
+// Next returns the next record of the patch, or io.EOF when there are no more records. It returns an error if the patch
+// ends without a trailer, or if the trailer doesn't match the records before it.
+func (pr *PatchReader) Next() (PatchRecord, error) {
+	if pr.done {
+		return PatchRecord{}, io.EOF
+	}
+	recordType, payload, err := pr.nextLine()
+	if err == io.EOF {
+		return PatchRecord{}, fmt.Errorf("patch is truncated: missing '%s' record after line %d", PatchEndRecord, pr.line)
+	} else if err != nil {
+		return PatchRecord{}, err
+	}
+	if recordType == PatchEndRecord {
+		return PatchRecord{}, pr.readTrailer(payload)
+	}
+
+	rec := PatchRecord{Type: recordType, Line: pr.line}
+	switch recordType {
+	case PatchTableRecord:
+		rec.Table = &PatchTable{}
+		err = json.Unmarshal(payload, rec.Table)
+	case PatchSchemaRecord:
+		rec.Schema = &PatchSchema{}
+		err = json.Unmarshal(payload, rec.Schema)
+	case PatchColumnsRecord:
+		err = json.Unmarshal(payload, &rec.Columns)
+	case PatchInsertRecord, PatchDeleteRecord, PatchUpdateRecord:
+		rec.Row = &PatchRow{}
+		err = json.Unmarshal(payload, rec.Row)
+		if err == nil && ((recordType != PatchInsertRecord && rec.Row.Old == nil) || (recordType != PatchDeleteRecord && rec.Row.New == nil)) {
+			err = fmt.Errorf("%s record is missing row values", recordType)
+		}
+	case PatchViewRecord, PatchTriggerRecord, PatchEventRecord:
+		rec.Fragment = &PatchFragment{}
+		err = json.Unmarshal(payload, rec.Fragment)
+	default:
+		err = fmt.Errorf("unknown record type '%s'", recordType)
+	}
+	if err != nil {
+		return PatchRecord{}, pr.lineError(err)
+	}
+	return rec, nil
+}
+
+// readTrailer checks the patch trailer against the records read before it, and that nothing but comments follow it.
+// It returns io.EOF if the patch is complete.
+func (pr *PatchReader) readTrailer(payload []byte) error {
+	var trailer PatchTrailer
+	if err := json.Unmarshal(payload, &trailer); err != nil {
+		return pr.lineError(err)
+	}
+	// the trailer itself is counted by nextLine
+	if records := pr.records - 1; trailer.Records != records {
+		return pr.lineError(fmt.Errorf("patch has %d records, expected %d", records, trailer.Records))
+	}
+	if checksum := hex.EncodeToString(pr.sum.Sum(nil)); trailer.Checksum != checksum {
+		return pr.lineError(errors.New("patch checksum does not match, the patch is corrupted"))
+	}
+	if _, _, err := pr.nextLine(); err == nil {
+		return pr.lineError(fmt.Errorf("unexpected record after '%s' record", PatchEndRecord))
+	} else if err != io.EOF {
+		return err
+	}
+	pr.done = true
+	return io.EOF
+}
+
+// nextLine returns the type and payload of the next record, skipping blank lines and comments. Records other than the
+// trailer are added to the patch checksum.
+func (pr *PatchReader) nextLine() (string, []byte, error) {
+	for {
+		line, err := pr.rd.ReadString('\n')
+		if err == io.EOF && len(line) == 0 {
+			return "", nil, io.EOF
+		} else if err != nil && err != io.EOF {
+			return "", nil, err
+		}
+		pr.line++
+
+		line = strings.TrimRight(line, "\r\n")
+		if len(strings.TrimSpace(line)) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		recordType, payload, ok := strings.Cut(line, " ")
+		if !ok {
+			return "", nil, pr.lineError(errors.New("expected a record type followed by a JSON value"))
+		}
+		pr.records++
+		if recordType != PatchEndRecord {
+			pr.sum.Write([]byte(line + "\n"))
+		}
+		return recordType, []byte(payload), nil
+	}
+}
+
+func (pr *PatchReader) lineError(err error) error {
+	return fmt.Errorf("invalid patch on line %d: %w", pr.line, err)
+}
@@ -0,0 +1,184 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatchRoundTrip(t *testing.T) {
+	str := func(s string) *string {
+		return &s
+	}
+
+	var buf bytes.Buffer
+	pw, err := NewPatchWriter(&buf)
+	require.NoError(t, err)
+
+	table := PatchTable{From: "t", To: "t", DiffType: "modified"}
+	sch := PatchSchema{From: "CREATE TABLE t (pk int primary key)", To: "CREATE TABLE t (pk int primary key, c json)", Statements: []string{"ALTER TABLE t ADD c json;"}}
+	cols := []PatchColumn{{Name: "pk", PrimaryKey: true, From: true, To: true}, {Name: "c", Encoding: PatchEncodingJSON, To: true}}
+	rows := []PatchRow{
+		{Key: []*string{str("1")}, New: []*string{str("1"), str(`{"a": "line\nbreak"}`)}},
+		{Key: []*string{str("2")}, Old: []*string{str("2"), nil}},
+		{Key: []*string{str("3")}, Old: []*string{str("3"), nil}, New: []*string{str("3"), str("[]")}},
+	}
+	fragment := PatchFragment{Name: "v", To: "CREATE VIEW v AS SELECT 1"}
+
+	require.NoError(t, pw.WriteTable(table))
+	require.NoError(t, pw.WriteSchema(sch))
+	require.NoError(t, pw.WriteColumns(cols))
+	for _, row := range rows {
+		require.NoError(t, pw.WriteRow(row))
+	}
+	require.NoError(t, pw.WriteFragment(PatchViewRecord, fragment))
+	require.NoError(t, pw.Close())
+
+	pr, err := NewPatchReader(&buf)
+	require.NoError(t, err)
+	var recs []PatchRecord
+	for {
+		rec, err := pr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		recs = append(recs, rec)
+	}
+
+	require.Len(t, recs, 7)
+	assert.Equal(t, &table, recs[0].Table)
+	assert.Equal(t, &sch, recs[1].Schema)
+	assert.Equal(t, cols, recs[2].Columns)
+	for i, typ := range []string{PatchInsertRecord, PatchDeleteRecord, PatchUpdateRecord} {
+		assert.Equal(t, typ, recs[3+i].Type)
+		assert.Equal(t, &rows[i], recs[3+i].Row)
+	}
+	assert.Equal(t, PatchViewRecord, recs[6].Type)
+	assert.Equal(t, &fragment, recs[6].Fragment)
+}
+
+func TestPatchTruncated(t *testing.T) {
+	var buf bytes.Buffer
+	pw, err := NewPatchWriter(&buf)
+	require.NoError(t, err)
+	require.NoError(t, pw.WriteTable(PatchTable{From: "t", To: "t", DiffType: "modified"}))
+	require.NoError(t, pw.WriteColumns([]PatchColumn{{Name: "pk", PrimaryKey: true, From: true, To: true}}))
+	for _, k := range []string{"1", "2", "3"} {
+		k := k
+		require.NoError(t, pw.WriteRow(PatchRow{Key: []*string{&k}, New: []*string{&k}}))
+	}
+	require.NoError(t, pw.Close())
+
+	patch := buf.String()
+	readAll := func(patch string) error {
+		pr, err := NewPatchReader(strings.NewReader(patch))
+		for err == nil {
+			_, err = pr.Next()
+		}
+		if err == io.EOF {
+			return nil
+		}
+		return err
+	}
+	require.NoError(t, readAll(patch))
+
+	// every prefix of the patch which ends on a line boundary is rejected
+	for i := 0; i < len(patch)-1; i++ {
+		if patch[i] == '\n' {
+			assert.Error(t, readAll(patch[:i+1]), "prefix of %d bytes", i+1)
+		}
+	}
+}
+
+func TestPatchReaderErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+		err   string
+	}{
+		{
+			name:  "empty",
+			patch: "",
+			err:   "patch is empty",
+		},
+		{
+			name:  "missing header",
+			patch: "table {}\n",
+			err:   "not a dolt patch",
+		},
+		{
+			name:  "unsupported version",
+			patch: "dolt-patch {\"version\":2}\n",
+			err:   "unsupported patch version 2",
+		},
+		{
+			name:  "unknown record",
+			patch: "dolt-patch {\"version\":1}\n\n# comment\nfoo {}\n",
+			err:   "invalid patch on line 4: unknown record type 'foo'",
+		},
+		{
+			name:  "update without new values",
+			patch: "dolt-patch {\"version\":1}\nupdate {\"key\":[\"1\"],\"old\":[\"1\"]}\n",
+			err:   "invalid patch on line 2: update record is missing row values",
+		},
+		{
+			name:  "missing trailer",
+			patch: "dolt-patch {\"version\":1}\ntable {\"from\":\"t\",\"to\":\"t\"}\n",
+			err:   "patch is truncated: missing 'end' record after line 2",
+		},
+		{
+			name:  "wrong record count",
+			patch: "dolt-patch {\"version\":1}\nend {\"records\":2,\"checksum\":\"\"}\n",
+			err:   "invalid patch on line 2: patch has 1 records, expected 2",
+		},
+		{
+			name:  "wrong checksum",
+			patch: "dolt-patch {\"version\":1}\nend {\"records\":1,\"checksum\":\"00\"}\n",
+			err:   "invalid patch on line 2: patch checksum does not match",
+		},
+		{
+			name:  "record after trailer",
+			patch: "dolt-patch {\"version\":1}\nend {\"records\":1,\"checksum\":\"" + checksum("dolt-patch {\"version\":1}\n") + "\"}\ntable {}\n",
+			err:   "invalid patch on line 3: unexpected record after 'end' record",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pr, err := NewPatchReader(strings.NewReader(test.patch))
+			if err == nil {
+				for err == nil {
+					_, err = pr.Next()
+				}
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.err)
+		})
+	}
+}
+
+func checksum(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
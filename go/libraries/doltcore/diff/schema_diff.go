@@ -16,6 +16,7 @@ package diff
 
 import (
 	"reflect"
+	"strings"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
@@ -65,6 +66,44 @@ func DiffSchColumns(fromSch, toSch schema.Schema) (map[uint64]ColumnDifference,
 	return diffs, unionTags
 }
 
+// DiffSchColumnsByName compares two schemas by looking at columns with the same tag, as DiffSchColumns does, and then
+// pairs the columns which are only in one of the schemas by name, ignoring case. This pairs the columns of schemas
+// whose tags differ, such as those parsed from CREATE TABLE statements. The differences are returned in the order of the
+// tags of DiffSchColumns, with columns paired by name in the place of their old column.
+func DiffSchColumnsByName(fromSch, toSch schema.Schema) []ColumnDifference {
+	colDiffs, unionTags := DiffSchColumns(fromSch, toSch)
+
+	// added columns follow all the columns of fromSch in unionTags, so they're paired before they're reached
+	addedTags := make(map[string]uint64)
+	for _, tag := range unionTags {
+		if cd := colDiffs[tag]; cd.DiffType == SchDiffAdded {
+			addedTags[strings.ToLower(cd.New.Name)] = tag
+		}
+	}
+
+	diffs := make([]ColumnDifference, 0, len(unionTags))
+	paired := make(map[uint64]bool)
+	for _, tag := range unionTags {
+		cd := colDiffs[tag]
+		if cd.DiffType == SchDiffRemoved {
+			if addedTag, ok := addedTags[strings.ToLower(cd.Old.Name)]; ok {
+				cd.New = colDiffs[addedTag].New
+				if cd.Old.EqualsWithoutTag(*cd.New) {
+					cd.DiffType = SchDiffNone
+				} else {
+					cd.DiffType = SchDiffModified
+				}
+				paired[addedTag] = true
+			}
+		} else if cd.DiffType == SchDiffAdded && paired[tag] {
+			continue
+		}
+		diffs = append(diffs, cd)
+	}
+
+	return diffs
+}
+
 // pairColumns loops over both sets of columns pairing columns with the same tag.
 func pairColumns(fromSch, toSch schema.Schema) (map[uint64]columnPair, []uint64) {
 	// collect the tag union of the two schemas, ordering fromSch before toSch
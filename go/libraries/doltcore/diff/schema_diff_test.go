@@ -66,3 +66,43 @@ func TestDiffSchemas(t *testing.T) {
 		t.Error(diffs, "!=", expected)
 	}
 }
+
+func TestDiffSchemasByName(t *testing.T) {
+	oldCols := []schema.Column{
+		schema.NewColumn("unchanged", 0, types.StringKind, true, schema.NotNullConstraint{}),
+		schema.NewColumn("dropped", 1, types.StringKind, true),
+		schema.NewColumn("renamed", 2, types.StringKind, false),
+		schema.NewColumn("type_changed", 3, types.StringKind, false),
+		schema.NewColumn("same_tag", 4, types.StringKind, false),
+		schema.NewColumn("same", 5, types.StringKind, false),
+	}
+
+	newCols := []schema.Column{
+		schema.NewColumn("UNCHANGED", 10, types.StringKind, true, schema.NotNullConstraint{}),
+		schema.NewColumn("renamed_new", 12, types.StringKind, false),
+		schema.NewColumn("type_changed", 13, types.IntKind, false),
+		schema.NewColumn("same_tag_renamed", 4, types.StringKind, false),
+		schema.NewColumn("same", 15, types.StringKind, false),
+	}
+
+	oldSch, err := schema.SchemaFromCols(schema.NewColCollection(oldCols...))
+	require.NoError(t, err)
+	newSch, err := schema.SchemaFromCols(schema.NewColCollection(newCols...))
+	require.NoError(t, err)
+	diffs := DiffSchColumnsByName(oldSch, newSch)
+
+	expected := []ColumnDifference{
+		{SchDiffModified, 0, &oldCols[0], &newCols[0]},
+		{SchDiffRemoved, 1, &oldCols[1], nil},
+		{SchDiffRemoved, 2, &oldCols[2], nil},
+		{SchDiffModified, 3, &oldCols[3], &newCols[2]},
+		// columns with the same tag are paired even if their names differ
+		{SchDiffModified, 4, &oldCols[4], &newCols[3]},
+		{SchDiffNone, 5, &oldCols[5], &newCols[4]},
+		{SchDiffAdded, 12, nil, &newCols[1]},
+	}
+
+	if !reflect.DeepEqual(diffs, expected) {
+		t.Error(diffs, "!=", expected)
+	}
+}
@@ -30,8 +30,10 @@ import (
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/sirupsen/logrus"
 
+	"github.com/dolthub/dolt/go/gen/fb/serial"
 	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/remotestorage"
 	"github.com/dolthub/dolt/go/libraries/utils/earl"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/store/chunks"
@@ -1777,7 +1779,14 @@ func (ddb *DoltDB) GC(ctx context.Context, mode types.GCMode, safepointControlle
 		return fmt.Errorf("this database does not support garbage collection")
 	}
 
-	err := ddb.pruneUnreferencedDatasets(ctx)
+	encrypted, err := ddb.isEncryptedRemote(ctx)
+	if err != nil {
+		return err
+	} else if encrypted {
+		return ErrGCEncryptedRemote
+	}
+
+	err = ddb.pruneUnreferencedDatasets(ctx)
 	if err != nil {
 		return err
 	}
@@ -1818,6 +1827,21 @@ func (ddb *DoltDB) GC(ctx context.Context, mode types.GCMode, safepointControlle
 	return collector.GC(ctx, mode, oldGen, newGen, safepointController)
 }
 
+// isEncryptedRemote returns whether this ddb is the remote of a client which encrypts its chunks, whose root is an
+// encrypted root record.
+func (ddb *DoltDB) isEncryptedRemote(ctx context.Context) (bool, error) {
+	cs := datas.ChunkStoreFromDatabase(ddb.db)
+	root, err := cs.Root(ctx)
+	if err != nil || root.IsEmpty() {
+		return false, err
+	}
+	c, err := cs.Get(ctx, root)
+	if err != nil {
+		return false, err
+	}
+	return remotestorage.IsEncryptedChunk(c.Data()), nil
+}
+
 func (ddb *DoltDB) ShallowGC(ctx context.Context) error {
 	return datas.PruneTableFiles(ctx, ddb.db)
 }
@@ -1870,6 +1894,120 @@ func (ddb *DoltDB) PullChunks(
 	return pullHash(ctx, ddb.db, srcDB.db, targetHashes, tempDir, statsCh, skipHashes)
 }
 
+// PullChunksOnto is like PullChunks, but leaves out the chunks in |present| as though this database had them. References
+// to those chunks aren't required to be in this database when the pulled table files are added.
+func (ddb *DoltDB) PullChunksOnto(
+	ctx context.Context,
+	tempDir string,
+	srcDB *DoltDB,
+	targetHashes []hash.Hash,
+	statsCh chan pull.Stats,
+	present hash.HashSet,
+) error {
+	destCS, ok := datas.ChunkStoreFromDatabase(ddb.db).(tableFileChunkStore)
+	if !ok || !datas.CanUsePuller(srcDB.db) || !datas.CanUsePuller(ddb.db) {
+		return errors.New("Puller not supported")
+	}
+	sink := presentSink{tableFileChunkStore: destCS, present: present}
+
+	srcCS := datas.ChunkStoreFromDatabase(srcDB.db)
+	waf := types.WalkAddrsForNBF(srcDB.Format(), nil)
+	puller, err := pull.NewPuller(ctx, tempDir, defaultChunksPerTF, srcCS, sink, waf, targetHashes, statsCh)
+	if err == pull.ErrDBUpToDate {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return puller.Pull(ctx)
+}
+
+// CommitChunks returns the addresses of the chunks of the commit |cm|: the commit, the chunks of its root value and
+// parent closure, and the addresses of its ancestor commits. The chunks of the ancestors' root values are not walked,
+// so the walk is bounded by the size of the commit's root value and the number of its ancestors, rather than by the
+// size of the history.
+func (ddb *DoltDB) CommitChunks(ctx context.Context, cm hash.Hash) (hash.HashSet, error) {
+	cs := datas.ChunkStoreFromDatabase(ddb.db)
+	waf := types.WalkAddrsForNBF(ddb.Format(), nil)
+	found := hash.NewHashSet(cm)
+	next := hash.NewHashSet(cm)
+	for next.Size() > 0 {
+		batch := next
+		next = hash.NewHashSet()
+		var mu sync.Mutex
+		var walkErr error
+		err := cs.GetMany(ctx, batch, func(ctx context.Context, c *chunks.Chunk) {
+			if c.Hash() != cm && serial.GetFileID(c.Data()) == serial.CommitFileID {
+				// ancestor commits are not walked
+				return
+			}
+			err := waf(*c, func(h hash.Hash, isleaf bool) error {
+				mu.Lock()
+				defer mu.Unlock()
+				if !found.Has(h) {
+					found.Insert(h)
+					if !isleaf {
+						next.Insert(h)
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				mu.Lock()
+				walkErr = err
+				mu.Unlock()
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+		if walkErr != nil {
+			return nil, walkErr
+		}
+	}
+	return found, nil
+}
+
+type tableFileChunkStore interface {
+	chunks.ChunkStore
+	chunks.TableFileStore
+}
+
+// presentSink is the sink of a pull which reports the chunks of |present| as present.
+type presentSink struct {
+	tableFileChunkStore
+	present hash.HashSet
+}
+
+func (s presentSink) HasMany(ctx context.Context, hashes hash.HashSet) (hash.HashSet, error) {
+	absent, err := s.tableFileChunkStore.HasMany(ctx, hashes)
+	if err != nil {
+		return nil, err
+	}
+	for h := range absent {
+		if s.present.Has(h) {
+			absent.Remove(h)
+		}
+	}
+	return absent, nil
+}
+
+func (s presentSink) AddTableFilesToManifest(ctx context.Context, fileIdToNumChunks map[string]int, getAddrs chunks.GetAddrsCurry) error {
+	return s.tableFileChunkStore.AddTableFilesToManifest(ctx, fileIdToNumChunks, func(c chunks.Chunk) chunks.GetAddrsCb {
+		return func(ctx context.Context, addrs hash.HashSet, exists chunks.PendingRefExists) error {
+			refs := hash.NewHashSet()
+			if err := getAddrs(c)(ctx, refs, exists); err != nil {
+				return err
+			}
+			for h := range refs {
+				if !s.present.Has(h) {
+					addrs.Insert(h)
+				}
+			}
+			return nil
+		}
+	})
+}
+
 func pullHash(
 	ctx context.Context,
 	destDB, srcDB datas.Database,
@@ -1902,8 +2040,10 @@ func (ddb *DoltDB) getAddrs(c chunks.Chunk) chunks.GetAddrsCb {
 	}
 }
 
-func (ddb *DoltDB) Clone(ctx context.Context, destDB *DoltDB, eventCh chan<- pull.TableFileEvent) error {
-	return pull.Clone(ctx, datas.ChunkStoreFromDatabase(ddb.db),
+// Clone copies the table files of this DoltDB into |destDB|. If |tempDir| is not empty, an interrupted Clone into
+// |destDB| is resumed from the checkpoint it kept in |tempDir|.
+func (ddb *DoltDB) Clone(ctx context.Context, tempDir string, destDB *DoltDB, eventCh chan<- pull.TableFileEvent) error {
+	return pull.Clone(ctx, tempDir, datas.ChunkStoreFromDatabase(ddb.db),
 		datas.ChunkStoreFromDatabase(destDB.db),
 		ddb.getAddrs,
 		eventCh)
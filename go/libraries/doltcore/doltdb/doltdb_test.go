@@ -16,9 +16,13 @@ package doltdb
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -31,6 +35,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/libraries/utils/test"
+	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/prolly/tree"
@@ -218,6 +223,217 @@ func TestEmptyInMemoryRepoCreation(t *testing.T) {
 	}
 }
 
+func TestWorkflowRunSteps(t *testing.T) {
+	ctx := context.Background()
+	ddb, err := LoadDoltDB(ctx, types.Format_Default, InMemDoltDB, filesys.LocalFS)
+	require.NoError(t, err)
+	defer ddb.Close()
+	err = ddb.WriteEmptyRepo(ctx, "main", "Bill Billerson", "bigbillieb@fake.horse")
+	require.NoError(t, err)
+	refs, err := ddb.GetRefsWithHashes(ctx)
+	require.NoError(t, err)
+
+	steps, err := ddb.GetWorkflowRunSteps(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, steps)
+
+	for i := 0; i < maxWorkflowRunSteps+1; i++ {
+		err = ddb.AddWorkflowRunSteps(ctx, []WorkflowRunStep{{Id: uuid.NewString(), StepOrder: i}})
+		require.NoError(t, err)
+	}
+	steps, err = ddb.GetWorkflowRunSteps(ctx)
+	require.NoError(t, err)
+	require.Len(t, steps, maxWorkflowRunSteps)
+	assert.Equal(t, 1, steps[0].StepOrder)
+	assert.Equal(t, maxWorkflowRunSteps, steps[len(steps)-1].StepOrder)
+
+	// runs are not recorded on any branch
+	after, err := ddb.GetRefsWithHashes(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, refs, after)
+}
+
+func TestCommitChunks(t *testing.T) {
+	ctx := context.Background()
+	ddb, err := LoadDoltDB(ctx, types.Format_Default, InMemDoltDB, filesys.LocalFS)
+	require.NoError(t, err)
+	defer ddb.Close()
+	err = ddb.WriteEmptyRepo(ctx, "main", "Bill Billerson", "bigbillieb@fake.horse")
+	require.NoError(t, err)
+
+	cs, _ := NewCommitSpec("main")
+	optCmt, err := ddb.Resolve(ctx, cs, nil)
+	require.NoError(t, err)
+	commit, ok := optCmt.ToCommit()
+	require.True(t, ok)
+	root, err := commit.GetRootValue(ctx)
+	require.NoError(t, err)
+
+	tSchema := createTestSchema(t)
+	var commitHashes, rootHashes []hash.Hash
+	for _, add := range []bool{true, false} {
+		if add {
+			tbl, err := CreateTestTable(ddb.vrw, ddb.ns, tSchema, createTestRowData(t, ddb.vrw, ddb.ns, tSchema))
+			require.NoError(t, err)
+			root, err = root.PutTable(ctx, TableName{Name: "test"}, tbl)
+			require.NoError(t, err)
+		} else {
+			root, err = root.RemoveTables(ctx, false, false, TableName{Name: "test"})
+			require.NoError(t, err)
+		}
+		var valHash hash.Hash
+		root, valHash, err = ddb.WriteRootValue(ctx, root)
+		require.NoError(t, err)
+		meta, err := datas.NewCommitMeta("Bill Billerson", "bigbillieb@fake.horse", "update test")
+		require.NoError(t, err)
+		commit, err = ddb.Commit(ctx, valHash, ref.NewBranchRef("main"), meta)
+		require.NoError(t, err)
+		h, err := commit.HashOf()
+		require.NoError(t, err)
+		commitHashes = append(commitHashes, h)
+		rootHashes = append(rootHashes, valHash)
+	}
+
+	found, err := ddb.CommitChunks(ctx, commitHashes[1])
+	require.NoError(t, err)
+	assert.True(t, found.Has(commitHashes[1]))
+	assert.True(t, found.Has(rootHashes[1]))
+	// ancestor commits are included, but not the root values of the ancestors
+	assert.True(t, found.Has(commitHashes[0]))
+	assert.False(t, found.Has(rootHashes[0]))
+}
+
+func TestGCEncryptedRemote(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	keyFile := filepath.Join(t.TempDir(), "key")
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(keyFile, []byte(base64.StdEncoding.EncodeToString(key)), 0600))
+
+	client, err := LoadDoltDBWithParams(ctx, types.Format_Default, "file://"+dir, filesys.LocalFS, map[string]interface{}{dbfactory.EncryptionKeyFileParam: keyFile})
+	require.NoError(t, err)
+	require.NoError(t, client.WriteEmptyRepo(ctx, "main", "Bill Billerson", "bigbillieb@fake.horse"))
+
+	// the server of the remote can't walk its chunks. It shares the store of the client, which is cached by path.
+	server, err := LoadDoltDB(ctx, types.Format_Default, "file://"+dir, filesys.LocalFS)
+	require.NoError(t, err)
+	defer server.Close()
+	err = server.GC(ctx, types.GCModeFull, nil)
+	assert.ErrorIs(t, err, ErrGCEncryptedRemote)
+}
+
+func TestGCColdTier(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	coldDir := t.TempDir()
+	params := map[string]interface{}{
+		dbfactory.ColdTierURLParam:   "file://" + coldDir,
+		dbfactory.ColdTierAfterParam: "24h",
+	}
+	ddb, err := LoadDoltDBWithParams(ctx, types.Format_Default, "file://"+dir, filesys.LocalFS, params)
+	require.NoError(t, err)
+	defer ddb.Close()
+	tcs := datas.ChunkStoreFromDatabase(ddb.db).(chunks.ColdTieredCS)
+
+	defer func(now func() time.Time) { datas.CommitterDate = now }(datas.CommitterDate)
+	datas.CommitterDate = func() time.Time { return time.Now().Add(-48 * time.Hour) }
+	require.NoError(t, ddb.WriteEmptyRepo(ctx, "main", "Bill Billerson", "bigbillieb@fake.horse"))
+
+	cs, _ := NewCommitSpec("main")
+	optCmt, err := ddb.Resolve(ctx, cs, nil)
+	require.NoError(t, err)
+	commit, ok := optCmt.ToCommit()
+	require.True(t, ok)
+	require.NoError(t, ddb.NewBranchAtCommit(ctx, ref.NewBranchRef("feature"), commit, nil))
+	root, err := commit.GetRootValue(ctx)
+	require.NoError(t, err)
+
+	tSchema := createTestSchema(t)
+	tbl, err := CreateTestTable(ddb.vrw, ddb.ns, tSchema, createTestRowData(t, ddb.vrw, ddb.ns, tSchema))
+	require.NoError(t, err)
+	commitTable := func(branch string, name string) (hash.Hash, hash.Hash) {
+		newRoot, err := root.PutTable(ctx, TableName{Name: name}, tbl)
+		require.NoError(t, err)
+		_, valHash, err := ddb.WriteRootValue(ctx, newRoot)
+		require.NoError(t, err)
+		meta, err := datas.NewCommitMeta("Bill Billerson", "bigbillieb@fake.horse", "add "+name)
+		require.NoError(t, err)
+		cm, err := ddb.Commit(ctx, valHash, ref.NewBranchRef(branch), meta)
+		require.NoError(t, err)
+		h, err := cm.HashOf()
+		require.NoError(t, err)
+		return h, valHash
+	}
+	oldCommit, oldRoot := commitTable("main", "old")
+	featureCommit, _ := commitTable("feature", "feature")
+	datas.CommitterDate = time.Now
+	newCommit, newRoot := commitTable("main", "new")
+
+	coldFiles := func() []string {
+		entries, err := os.ReadDir(coldDir)
+		require.NoError(t, err)
+		var names []string
+		for _, e := range entries {
+			if _, ok := hash.MaybeParse(strings.TrimSuffix(e.Name(), ".bs")); ok {
+				names = append(names, e.Name())
+			}
+		}
+		return names
+	}
+
+	require.NoError(t, ddb.GC(ctx, types.GCModeFull, nil))
+	// the history behind commits older than a day is cold, and everything newer stays local
+	for _, h := range []hash.Hash{oldCommit, oldRoot, featureCommit} {
+		ok, err := tcs.ColdGen().Has(ctx, h)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		ok, err = tcs.OldGen().Has(ctx, h)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	}
+	for _, h := range []hash.Hash{newCommit, newRoot} {
+		ok, err := tcs.ColdGen().Has(ctx, h)
+		require.NoError(t, err)
+		assert.False(t, ok)
+		ok, err = tcs.OldGen().Has(ctx, h)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	}
+	_, err = ddb.ReadRootValue(ctx, oldRoot)
+	require.NoError(t, err)
+	firstFiles := coldFiles()
+	require.Len(t, firstFiles, 1)
+
+	// once the feature branch is gone, its commit is collected from the cold tier
+	require.NoError(t, ddb.DeleteBranch(ctx, ref.NewBranchRef("feature"), nil))
+	require.NoError(t, ddb.GC(ctx, types.GCModeFull, nil))
+	ok, err = tcs.ColdGen().Has(ctx, featureCommit)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	ok, err = tcs.ColdGen().Has(ctx, oldCommit)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	secondFiles := coldFiles()
+	require.Len(t, secondFiles, 1)
+	assert.NotEqual(t, firstFiles, secondFiles)
+
+	optCmt, err = ddb.Resolve(ctx, cs, nil)
+	require.NoError(t, err)
+	commit, ok = optCmt.ToCommit()
+	require.True(t, ok)
+	parent, err := ddb.ResolveParent(ctx, commit, 0)
+	require.NoError(t, err)
+	parentCommit, ok := parent.ToCommit()
+	require.True(t, ok)
+	parentRoot, err := parentCommit.GetRootValue(ctx)
+	require.NoError(t, err)
+	_, ok, err = parentRoot.GetTable(ctx, TableName{Name: "old"})
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
 func TestLoadNonExistentLocalFSRepo(t *testing.T) {
 	_, err := test.ChangeToTestDir("TestLoadRepo")
 
@@ -51,6 +51,10 @@ var ErrIsBehind = errors.New("cannot reverse from b to a. b is a is behind a alr
 var ErrUnresolvedConflictsOrViolations = errors.New("merge has unresolved conflicts or constraint violations")
 var ErrMergeActive = errors.New("merging is not possible because you have not committed an active merge")
 
+// ErrGCEncryptedRemote is returned when garbage collecting a database which is the remote of a client that encrypts
+// its chunks. The references of those chunks can't be read, so the chunks which are live can't be found.
+var ErrGCEncryptedRemote = errors.New("cannot garbage collect a remote which is encrypted on the client")
+
 var ErrOperationNotSupportedInDetachedHead = errors.New("this operation is not supported while in a detached head state")
 
 type ErrClientOutOfDate struct {
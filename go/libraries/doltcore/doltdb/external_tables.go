@@ -0,0 +1,95 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// ExternalTableDef is the definition of an external table, a read-only table whose rows are read from a file, as
+// stored in the dolt_external_tables system table.
+type ExternalTableDef struct {
+	// Name is the name of the table.
+	Name string
+	// Path is the path of the file the table's rows are read from.
+	Path string
+	// Format is the format of the file. When empty, it's given by the file's extension.
+	Format string
+	// Delimiter is the delimiter of a delimited file. When empty, it's the default delimiter of the format.
+	Delimiter string
+}
+
+// GetExternalTableDefs returns the definitions of the external tables in the dolt_external_tables table of |root|,
+// keyed by their lower-cased names.
+func GetExternalTableDefs(ctx context.Context, root RootValue) (map[string]ExternalTableDef, error) {
+	tbl, ok, err := root.GetTable(ctx, TableName{Name: ExternalTablesTableName})
+	if err != nil {
+		return nil, err
+	}
+	defs := make(map[string]ExternalTableDef)
+	if !ok || tbl.Format() == types.Format_LD_1 {
+		// dolt_external_tables is not supported for the legacy storage format.
+		return defs, nil
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if sch.GetPKCols().Size() != 1 || sch.GetNonPKCols().Size() != 3 {
+		return nil, fmt.Errorf("%s had unexpected schema, this should never happen", ExternalTablesTableName)
+	}
+
+	idx, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m := durable.MapFromIndex(idx)
+	keyDesc, valueDesc := sch.GetMapDescriptors(m.NodeStore())
+
+	iter, err := m.IterAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		k, v, err := iter.Next(ctx)
+		if err == io.EOF {
+			return defs, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		name, ok := keyDesc.GetString(0, k)
+		if !ok {
+			return nil, fmt.Errorf("could not read table name from %s", ExternalTablesTableName)
+		}
+		path, ok := valueDesc.GetString(0, v)
+		if !ok {
+			return nil, fmt.Errorf("could not read path of table '%s' from %s", name, ExternalTablesTableName)
+		}
+		// the format and delimiter are optional
+		format, _ := valueDesc.GetString(1, v)
+		delim, _ := valueDesc.GetString(2, v)
+
+		defs[strings.ToLower(name)] = ExternalTableDef{Name: name, Path: path, Format: format, Delimiter: delim}
+	}
+}
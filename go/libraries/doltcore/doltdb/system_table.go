@@ -191,6 +191,9 @@ var getWriteableSystemTables = func() []string {
 		SchemasTableName,
 		ProceduresTableName,
 		IgnoreTableName,
+		MergeStrategiesTableName,
+		ConflictResolversTableName,
+		ExternalTablesTableName,
 		GetRebaseTableName(),
 
 		// TODO: find way to make these writable by the dolt process
@@ -203,6 +206,10 @@ var getWriteableSystemTables = func() []string {
 		WorkflowStepsTableName,
 		WorkflowSavedQueryStepsTableName,
 		WorkflowSavedQueryStepExpectedRowColumnResultsTableName,
+		WorkflowSqlAssertionStepsTableName,
+		WorkflowVerifyConstraintsStepsTableName,
+		WorkflowSchemaUnchangedStepsTableName,
+		WorkflowDiffBudgetStepsTableName,
 	}
 }
 
@@ -439,6 +446,15 @@ const (
 	// IgnoreTableName is the ignore table name
 	IgnoreTableName = "dolt_ignore"
 
+	// MergeStrategiesTableName is the merge strategies system table name
+	MergeStrategiesTableName = "dolt_merge_strategies"
+
+	// ConflictResolversTableName is the conflict resolvers system table name
+	ConflictResolversTableName = "dolt_conflict_resolvers"
+
+	// ExternalTablesTableName is the external tables system table name
+	ExternalTablesTableName = "dolt_external_tables"
+
 	// RebaseTableName is the rebase system table name.
 	RebaseTableName = "dolt_rebase"
 
@@ -446,6 +462,40 @@ const (
 	StatisticsTableName = "dolt_statistics"
 )
 
+const (
+	// MergeStrategiesTableNameColName is the name of the column storing the name of the table in the merge strategies table
+	MergeStrategiesTableNameColName = "table_name"
+
+	// MergeStrategiesStrategyColName is the name of the column storing the merge strategy in the merge strategies table
+	MergeStrategiesStrategyColName = "strategy"
+)
+
+const (
+	// ConflictResolversTableNameColName is the name of the column storing the name of the table in the conflict resolvers table
+	ConflictResolversTableNameColName = "table_name"
+
+	// ConflictResolversColumnNameColName is the name of the column storing the name of the column in the conflict resolvers table
+	ConflictResolversColumnNameColName = "column_name"
+
+	// ConflictResolversResolverColName is the name of the column storing the resolver expression in the conflict resolvers table
+	ConflictResolversResolverColName = "resolver"
+)
+
+const (
+	// ExternalTablesTableNameColName is the name of the column storing the name of the table in the external tables table
+	ExternalTablesTableNameColName = "table_name"
+
+	// ExternalTablesPathColName is the name of the column storing the path of the file read by an external table
+	ExternalTablesPathColName = "path"
+
+	// ExternalTablesFormatColName is the name of the column storing the format of the file read by an external table
+	ExternalTablesFormatColName = "format"
+
+	// ExternalTablesDelimiterColName is the name of the column storing the delimiter of a delimited file read by an
+	// external table
+	ExternalTablesDelimiterColName = "delimiter"
+)
+
 const (
 	// WorkflowsTableName is the dolt CI workflows system table name
 	WorkflowsTableName = "dolt_ci_workflows"
@@ -581,6 +631,114 @@ const (
 
 	// WorkflowSavedQueryStepExpectedRowColumnResultsUpdatedAtColName is the name of the updated at column on the workflow saved query step expected row column results table
 	WorkflowSavedQueryStepExpectedRowColumnResultsUpdatedAtColName = "updated_at"
+
+	// WorkflowSqlAssertionStepsTableName is the name of the workflow sql assertion steps table
+	WorkflowSqlAssertionStepsTableName = "dolt_ci_workflow_sql_assertion_steps"
+
+	// WorkflowSqlAssertionStepsIdPkColName is the name of the id column on the workflow sql assertion steps table
+	WorkflowSqlAssertionStepsIdPkColName = "id"
+
+	// WorkflowSqlAssertionStepsWorkflowStepIdFkColName is the name of the workflow step id foreign key column on the workflow sql assertion steps table
+	WorkflowSqlAssertionStepsWorkflowStepIdFkColName = "workflow_step_id_fk"
+
+	// WorkflowSqlAssertionStepsQueryColName is the name of the query column on the workflow sql assertion steps table
+	WorkflowSqlAssertionStepsQueryColName = "query"
+
+	// WorkflowSqlAssertionStepsExpectedResultsColName is the name of the expected results column on the workflow sql assertion steps table
+	WorkflowSqlAssertionStepsExpectedResultsColName = "expected_results"
+
+	// WorkflowVerifyConstraintsStepsTableName is the name of the workflow verify constraints steps table
+	WorkflowVerifyConstraintsStepsTableName = "dolt_ci_workflow_verify_constraints_steps"
+
+	// WorkflowVerifyConstraintsStepsIdPkColName is the name of the id column on the workflow verify constraints steps table
+	WorkflowVerifyConstraintsStepsIdPkColName = "id"
+
+	// WorkflowVerifyConstraintsStepsWorkflowStepIdFkColName is the name of the workflow step id foreign key column on the workflow verify constraints steps table
+	WorkflowVerifyConstraintsStepsWorkflowStepIdFkColName = "workflow_step_id_fk"
+
+	// WorkflowVerifyConstraintsStepsTableNamesColName is the name of the table names column on the workflow verify constraints steps table
+	WorkflowVerifyConstraintsStepsTableNamesColName = "table_names"
+
+	// WorkflowSchemaUnchangedStepsTableName is the name of the workflow schema unchanged steps table
+	WorkflowSchemaUnchangedStepsTableName = "dolt_ci_workflow_schema_unchanged_steps"
+
+	// WorkflowSchemaUnchangedStepsIdPkColName is the name of the id column on the workflow schema unchanged steps table
+	WorkflowSchemaUnchangedStepsIdPkColName = "id"
+
+	// WorkflowSchemaUnchangedStepsWorkflowStepIdFkColName is the name of the workflow step id foreign key column on the workflow schema unchanged steps table
+	WorkflowSchemaUnchangedStepsWorkflowStepIdFkColName = "workflow_step_id_fk"
+
+	// WorkflowSchemaUnchangedStepsTableNameColName is the name of the table name column on the workflow schema unchanged steps table
+	WorkflowSchemaUnchangedStepsTableNameColName = "table_name"
+
+	// WorkflowSchemaUnchangedStepsCompareToColName is the name of the compare to column on the workflow schema unchanged steps table
+	WorkflowSchemaUnchangedStepsCompareToColName = "compare_to"
+
+	// WorkflowDiffBudgetStepsTableName is the name of the workflow diff budget steps table
+	WorkflowDiffBudgetStepsTableName = "dolt_ci_workflow_diff_budget_steps"
+
+	// WorkflowDiffBudgetStepsIdPkColName is the name of the id column on the workflow diff budget steps table
+	WorkflowDiffBudgetStepsIdPkColName = "id"
+
+	// WorkflowDiffBudgetStepsWorkflowStepIdFkColName is the name of the workflow step id foreign key column on the workflow diff budget steps table
+	WorkflowDiffBudgetStepsWorkflowStepIdFkColName = "workflow_step_id_fk"
+
+	// WorkflowDiffBudgetStepsTableNameColName is the name of the table name column on the workflow diff budget steps table
+	WorkflowDiffBudgetStepsTableNameColName = "table_name"
+
+	// WorkflowDiffBudgetStepsCompareToColName is the name of the compare to column on the workflow diff budget steps table
+	WorkflowDiffBudgetStepsCompareToColName = "compare_to"
+
+	// WorkflowDiffBudgetStepsMaxRowsAddedColName is the name of the max rows added column on the workflow diff budget steps table
+	WorkflowDiffBudgetStepsMaxRowsAddedColName = "max_rows_added"
+
+	// WorkflowDiffBudgetStepsMaxRowsDeletedColName is the name of the max rows deleted column on the workflow diff budget steps table
+	WorkflowDiffBudgetStepsMaxRowsDeletedColName = "max_rows_deleted"
+
+	// WorkflowDiffBudgetStepsMaxRowsModifiedColName is the name of the max rows modified column on the workflow diff budget steps table
+	WorkflowDiffBudgetStepsMaxRowsModifiedColName = "max_rows_modified"
+
+	// WorkflowRunsTableName is the name of the generated system table recording the workflow runs performed by a sql-server
+	WorkflowRunsTableName = "dolt_ci_workflow_runs"
+
+	// WorkflowRunsIdPkColName is the name of the primary key id column on the workflow runs table
+	WorkflowRunsIdPkColName = "id"
+
+	// WorkflowRunsRunIdColName is the name of the run id column on the workflow runs table
+	WorkflowRunsRunIdColName = "run_id"
+
+	// WorkflowRunsWorkflowNameColName is the name of the workflow name column on the workflow runs table
+	WorkflowRunsWorkflowNameColName = "workflow_name"
+
+	// WorkflowRunsEventTypeColName is the name of the event type column on the workflow runs table
+	WorkflowRunsEventTypeColName = "event_type"
+
+	// WorkflowRunsBranchColName is the name of the branch column on the workflow runs table
+	WorkflowRunsBranchColName = "branch"
+
+	// WorkflowRunsCommitHashColName is the name of the commit hash column on the workflow runs table
+	WorkflowRunsCommitHashColName = "commit_hash"
+
+	// WorkflowRunsJobNameColName is the name of the job name column on the workflow runs table
+	WorkflowRunsJobNameColName = "job_name"
+
+	// WorkflowRunsStepNameColName is the name of the step name column on the workflow runs table
+	WorkflowRunsStepNameColName = "step_name"
+
+	// WorkflowRunsStepOrderColName is the name of the step order column on the workflow runs table
+	WorkflowRunsStepOrderColName = "step_order"
+
+	// WorkflowRunsStatusColName is the name of the status column on the workflow runs table
+	WorkflowRunsStatusColName = "status"
+
+	// WorkflowRunsStartedAtColName is the name of the started at column on the workflow runs table
+	WorkflowRunsStartedAtColName = "started_at"
+
+	// WorkflowRunsEndedAtColName is the name of the ended at column on the workflow runs table
+	WorkflowRunsEndedAtColName = "ended_at"
+
+	// WorkflowRunsFailureDetailsColName is the name of the failure details column on the workflow runs table
+	WorkflowRunsFailureDetailsColName = "failure_details"
 )
 
 const (
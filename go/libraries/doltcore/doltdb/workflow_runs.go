@@ -0,0 +1,81 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// workflowRunsTupleKey is the key of the tuple ref storing the workflow runs performed by a sql-server. Runs are kept
+// outside of every branch, so recording one never changes a working set and runs are never staged or committed.
+const workflowRunsTupleKey = "ci_workflow_runs"
+
+// maxWorkflowRunSteps is the number of workflow run steps kept for a database. When more are recorded, the oldest
+// steps are dropped.
+const maxWorkflowRunSteps = 1000
+
+// WorkflowRunStep is the record of a single step of a dolt ci workflow run, which is a row of the
+// dolt_ci_workflow_runs system table.
+type WorkflowRunStep struct {
+	Id             string    `json:"id"`
+	RunId          string    `json:"run_id"`
+	WorkflowName   string    `json:"workflow_name"`
+	EventType      string    `json:"event_type"`
+	Branch         string    `json:"branch"`
+	CommitHash     string    `json:"commit_hash"`
+	JobName        string    `json:"job_name"`
+	StepName       string    `json:"step_name"`
+	StepOrder      int       `json:"step_order"`
+	Status         string    `json:"status"`
+	StartedAt      time.Time `json:"started_at"`
+	EndedAt        time.Time `json:"ended_at"`
+	FailureDetails string    `json:"failure_details,omitempty"`
+}
+
+// GetWorkflowRunSteps returns the recorded steps of the workflow runs of this database, oldest first.
+func (ddb *DoltDB) GetWorkflowRunSteps(ctx context.Context) ([]WorkflowRunStep, error) {
+	if !ddb.Format().UsesFlatbuffers() {
+		return nil, nil
+	}
+	val, ok, err := ddb.GetTuple(ctx, workflowRunsTupleKey)
+	if err != nil || !ok {
+		return nil, err
+	}
+	var steps []WorkflowRunStep
+	err = json.Unmarshal(val, &steps)
+	if err != nil {
+		return nil, err
+	}
+	return steps, nil
+}
+
+// AddWorkflowRunSteps records the steps of workflow runs of this database. Only the most recent steps are kept.
+func (ddb *DoltDB) AddWorkflowRunSteps(ctx context.Context, steps []WorkflowRunStep) error {
+	existing, err := ddb.GetWorkflowRunSteps(ctx)
+	if err != nil {
+		return err
+	}
+	all := append(existing, steps...)
+	if len(all) > maxWorkflowRunSteps {
+		all = all[len(all)-maxWorkflowRunSteps:]
+	}
+	val, err := json.Marshal(all)
+	if err != nil {
+		return err
+	}
+	return ddb.SetTuple(ctx, workflowRunsTupleKey, val)
+}
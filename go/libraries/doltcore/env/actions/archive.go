@@ -0,0 +1,183 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+)
+
+// tableChunkGroup identifies the chunks which share a dictionary when archived: the leaf, or internal, nodes of the
+// row data or of one secondary index of a table.
+type tableChunkGroup struct {
+	table doltdb.TableName
+	index string
+	leaf  bool
+}
+
+// TableChunkRelations relates the chunks of each table in the history of |ddb|, so that archives are built with a
+// dictionary per table. The chunks of a table's row data, and of each of its secondary indexes, are related to each
+// other, with leaf nodes and internal nodes kept apart. Every commit in the history is visited, but only the chunks for
+// which |include| returns true, those of the table files being archived, are walked and related: the prolly trees of
+// the tables are only descended into where they reach chunks of those files.
+func TableChunkRelations(ctx context.Context, ddb *doltdb.DoltDB, include func(hash.Hash) (bool, error)) (nbs.ChunkRelations, error) {
+	relations := nbs.NewChunkRelations()
+	db := doltdb.HackDatasDatabaseFromDoltDB(ddb)
+
+	datasets, err := db.Datasets(ctx)
+	if err != nil {
+		return relations, err
+	}
+	var queue []hash.Hash
+	err = datasets.IterAll(ctx, func(_ string, h hash.Hash) error {
+		queue = append(queue, h)
+		return nil
+	})
+	if err != nil {
+		return relations, err
+	}
+
+	w := &tableChunkWalker{
+		relations: &relations,
+		anchors:   make(map[tableChunkGroup]hash.Hash),
+		seen:      hash.NewHashSet(),
+		include:   include,
+	}
+	commits := hash.NewHashSet()
+	for len(queue) > 0 {
+		h := queue[0]
+		queue = queue[1:]
+		if commits.Has(h) {
+			continue
+		}
+		commits.Insert(h)
+
+		oCmt, err := ddb.ReadCommit(ctx, h)
+		if err != nil {
+			continue // Only want commits. Skip working sets and others.
+		}
+		cmt, ok := oCmt.ToCommit()
+		if !ok {
+			continue // Ghost commits of shallow clones have nothing to relate.
+		}
+		if err = w.walkCommit(ctx, cmt); err != nil {
+			return relations, err
+		}
+		parents, err := cmt.ParentHashes(ctx)
+		if err != nil {
+			return relations, err
+		}
+		queue = append(queue, parents...)
+	}
+	return relations, nil
+}
+
+type tableChunkWalker struct {
+	relations *nbs.ChunkRelations
+	// anchors holds the first chunk seen in each group, which the rest of the group is related to.
+	anchors map[tableChunkGroup]hash.Hash
+	// seen holds the chunks walked, which are all included.
+	seen    hash.HashSet
+	include func(hash.Hash) (bool, error)
+}
+
+func (w *tableChunkWalker) walkCommit(ctx context.Context, cmt *doltdb.Commit) error {
+	root, err := cmt.GetRootValue(ctx)
+	if err != nil {
+		return err
+	}
+	return root.IterTables(ctx, func(name doltdb.TableName, table *doltdb.Table, sch schema.Schema) (bool, error) {
+		rows, err := table.GetRowData(ctx)
+		if err != nil {
+			return true, err
+		}
+		if err = w.walkIndex(ctx, rows, tableChunkGroup{table: name}); err != nil {
+			return true, err
+		}
+
+		indexes, err := table.GetIndexSet(ctx)
+		if err != nil {
+			return true, err
+		}
+		for _, idx := range sch.Indexes().AllIndexes() {
+			idxRows, err := indexes.GetIndex(ctx, sch, nil, idx.Name())
+			if err != nil {
+				return true, err
+			}
+			if err = w.walkIndex(ctx, idxRows, tableChunkGroup{table: name, index: idx.Name()}); err != nil {
+				return true, err
+			}
+		}
+		return false, nil
+	})
+}
+
+func (w *tableChunkWalker) walkIndex(ctx context.Context, idx durable.Index, group tableChunkGroup) error {
+	// Vector indexes and the old storage format aren't prolly maps, and aren't grouped.
+	m, ok := durable.MaybeProllyMapFromIndex(idx)
+	if !ok {
+		return nil
+	}
+	if wanted, err := w.wanted(m.HashOf()); err != nil || !wanted {
+		return err
+	}
+	return w.walkNode(ctx, m.Node(), m.NodeStore(), group)
+}
+
+// wanted returns true if the chunk |h| is included, and hasn't been walked yet.
+func (w *tableChunkWalker) wanted(h hash.Hash) (bool, error) {
+	if w.seen.Has(h) {
+		return false, nil
+	}
+	return w.include(h)
+}
+
+func (w *tableChunkWalker) walkNode(ctx context.Context, nd tree.Node, ns tree.NodeStore, group tableChunkGroup) error {
+	h := nd.HashOf()
+	w.seen.Insert(h)
+
+	group.leaf = nd.IsLeaf()
+	if anchor, ok := w.anchors[group]; ok {
+		w.relations.Add(anchor, h)
+	} else {
+		w.anchors[group] = h
+	}
+	if nd.IsLeaf() {
+		return nil
+	}
+
+	for i := 0; i < nd.Count(); i++ {
+		addr := hash.New(nd.GetValue(i))
+		if wanted, err := w.wanted(addr); err != nil {
+			return err
+		} else if !wanted {
+			continue
+		}
+		child, err := ns.Read(ctx, addr)
+		if err != nil {
+			return err
+		}
+		if err = w.walkNode(ctx, child, ns, group); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/earl"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/datas/pull"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
+)
+
+var ErrMissingBundlePrerequisite = errors.New("repository lacks the prerequisite commit of the bundle")
+
+// CreateBundle writes the refs |refs| of |srcDB|, along with the history of their commits, to a bundle file at |path|.
+// When |since| is given, the chunks of it are left out of the bundle, which can then only be read by a database which
+// has |since|.
+func CreateBundle(ctx context.Context, tempTableDir string, srcDB *doltdb.DoltDB, refs []ref.DoltRef, since *doltdb.Commit, path string, progStarter ProgStarter, progStopper ProgStopper) error {
+	targets, cmHashes, err := getBundleTargets(ctx, srcDB, refs)
+	if err != nil {
+		return err
+	}
+
+	// the bundle is built as a blobstore backed database, whose manifest and table files are then packed into a file
+	bsDir, err := os.MkdirTemp(tempTableDir, "bundle")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(bsDir)
+
+	bundleDB, err := loadTempBlobstoreDB(ctx, srcDB, bsDir)
+	if err != nil {
+		return err
+	}
+	defer bundleDB.Close()
+
+	var prereqs []hash.Hash
+	newCtx, cancelFunc := context.WithCancel(ctx)
+	wg, statsCh := progStarter(newCtx)
+	if since == nil {
+		err = bundleDB.PullChunks(ctx, tempTableDir, srcDB, targets, statsCh, nil)
+	} else {
+		var sinceHash hash.Hash
+		sinceHash, err = since.HashOf()
+		if err == nil {
+			prereqs = append(prereqs, sinceHash)
+			err = pullBundleOnto(ctx, tempTableDir, srcDB, bundleDB, targets, cmHashes, sinceHash, statsCh)
+		}
+	}
+	progStopper(cancelFunc, wg, statsCh)
+	if err != nil {
+		return err
+	}
+
+	for i, r := range refs {
+		if err = bundleDB.SetHead(ctx, r, targets[i]); err != nil {
+			return err
+		}
+	}
+
+	return writeBundleFile(ctx, blobstore.NewLocalBlobstore(bsDir), prereqs, path)
+}
+
+// pullBundleOnto pulls |targets| from |srcDB| into |bundleDB|, leaving out the chunks of the commit |since| other than
+// the commits |cmHashes|. The chunks left out are those of the commit's root value and the commits of its history,
+// which covers the chunks the targets share with |since| without walking the root values of its history.
+func pullBundleOnto(ctx context.Context, tempTableDir string, srcDB, bundleDB *doltdb.DoltDB, targets, cmHashes []hash.Hash, since hash.Hash, statsCh chan pull.Stats) error {
+	present, err := srcDB.CommitChunks(ctx, since)
+	if err != nil {
+		return err
+	}
+	// the commits of the refs are always bundled, so that the refs can be set in the bundle
+	for _, h := range cmHashes {
+		present.Remove(h)
+	}
+	return bundleDB.PullChunksOnto(ctx, tempTableDir, srcDB, targets, statsCh, present)
+}
+
+// loadTempBlobstoreDB returns a database of the format of |srcDB| backed by a blobstore in the directory |dir|.
+func loadTempBlobstoreDB(ctx context.Context, srcDB *doltdb.DoltDB, dir string) (*doltdb.DoltDB, error) {
+	return doltdb.LoadDoltDB(ctx, srcDB.Format(), dbfactory.LocalBSScheme+"://"+filepath.ToSlash(dir), filesys.LocalFS)
+}
+
+// getBundleTargets returns the addresses of the commits and tags of |refs|, along with the addresses of the commits of
+// them.
+func getBundleTargets(ctx context.Context, srcDB *doltdb.DoltDB, refs []ref.DoltRef) ([]hash.Hash, []hash.Hash, error) {
+	targets := make([]hash.Hash, len(refs))
+	var cmHashes []hash.Hash
+	for i, r := range refs {
+		switch r.GetType() {
+		case ref.BranchRefType:
+			cm, err := srcDB.ResolveCommitRef(ctx, r)
+			if err != nil {
+				return nil, nil, err
+			}
+			h, err := cm.HashOf()
+			if err != nil {
+				return nil, nil, err
+			}
+			targets[i] = h
+			cmHashes = append(cmHashes, h)
+		case ref.TagRefType:
+			tag, err := srcDB.ResolveTag(ctx, r.(ref.TagRef))
+			if err != nil {
+				return nil, nil, err
+			}
+			addr, err := tag.GetAddr()
+			if err != nil {
+				return nil, nil, err
+			}
+			h, err := tag.Commit.HashOf()
+			if err != nil {
+				return nil, nil, err
+			}
+			targets[i] = addr
+			cmHashes = append(cmHashes, h)
+		default:
+			return nil, nil, fmt.Errorf("cannot bundle ref %s, only branches and tags can be bundled", r.String())
+		}
+	}
+	return targets, cmHashes, nil
+}
+
+// writeBundleFile packs the store in |bs| into a bundle at |path|. The bundle is written to a temporary file which
+// replaces |path| once it's complete.
+func writeBundleFile(ctx context.Context, bs blobstore.Blobstore, prereqs []hash.Hash, path string) (err error) {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+
+	if err = nbs.WriteBundle(ctx, bs, prereqs, f); err != nil {
+		return err
+	}
+	// temp files are only readable by their owner
+	if err = f.Chmod(0644); err != nil {
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// Unbundle reads the chunks of the bundle file at |path| into |destDB|, and returns the refs of the bundle. The refs
+// of |destDB| aren't changed.
+func Unbundle(ctx context.Context, tempTableDir string, destDB *doltdb.DoltDB, path string, progStarter ProgStarter, progStopper ProgStopper) ([]doltdb.RefWithHash, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := nbs.OpenBundle(absPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, h := range b.Prerequisites() {
+		has, err := destDB.Has(ctx, h)
+		if err != nil {
+			return nil, err
+		}
+		if !has {
+			return nil, fmt.Errorf("%w: %s", ErrMissingBundlePrerequisite, h.String())
+		}
+	}
+
+	srcDB, err := doltdb.LoadDoltDB(ctx, destDB.Format(), earl.FileUrlFromPath(filepath.ToSlash(absPath), os.PathSeparator), filesys.LocalFS)
+	if err != nil {
+		return nil, err
+	}
+	defer srcDB.Close()
+
+	refs, err := srcDB.GetRefsWithHashes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(refs) == 0 {
+		return nil, nil
+	}
+
+	targets := make([]hash.Hash, len(refs))
+	for i, r := range refs {
+		targets[i] = r.Hash
+	}
+
+	newCtx, cancelFunc := context.WithCancel(ctx)
+	wg, statsCh := progStarter(newCtx)
+	err = destDB.PullChunks(ctx, tempTableDir, srcDB, targets, statsCh, nil)
+	progStopper(cancelFunc, wg, statsCh)
+	if err != nil && !errors.Is(err, pull.ErrDBUpToDate) {
+		return nil, err
+	}
+	return refs, nil
+}
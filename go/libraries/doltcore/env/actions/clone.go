@@ -37,6 +37,7 @@ import (
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/datas/pull"
+	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -57,11 +58,19 @@ var ErrEmailNotFound = errors.New("could not determine email. run dolt config --
 var ErrCloneFailed = errors.New("clone failed")
 
 // EnvForClone creates a new DoltEnv and configures it with repo state from the specified remote. The returned DoltEnv is ready for content to be cloned into it. The directory used for the new DoltEnv is determined by resolving the specified dir against the specified Filesys.
+// If the directory holds an interrupted clone of the same remote, its DoltEnv is returned instead, and cloning into it resumes the clone.
 func EnvForClone(ctx context.Context, nbf *types.NomsBinFormat, r env.Remote, dir string, fs filesys.Filesys, version string, homeProvider env.HomeDirProvider) (*env.DoltEnv, error) {
 	exists, _ := fs.Exists(filepath.Join(dir, dbfactory.DoltDir))
 
 	if exists {
-		return nil, fmt.Errorf("%w: %s", ErrRepositoryExists, dir)
+		dEnv, err := envForInterruptedClone(ctx, r, dir, fs, version, homeProvider)
+		if err != nil {
+			return nil, err
+		}
+		if dEnv == nil {
+			return nil, fmt.Errorf("%w: %s", ErrRepositoryExists, dir)
+		}
+		return dEnv, nil
 	}
 
 	err := fs.MkDirs(dir)
@@ -91,6 +100,51 @@ func EnvForClone(ctx context.Context, nbf *types.NomsBinFormat, r env.Remote, di
 	return dEnv, nil
 }
 
+// envForInterruptedClone returns the DoltEnv in |dir| if it holds a clone of |r| which was interrupted, and can be
+// resumed. Returns nil if |dir| holds anything else.
+func envForInterruptedClone(ctx context.Context, r env.Remote, dir string, fs filesys.Filesys, version string, homeProvider env.HomeDirProvider) (*env.DoltEnv, error) {
+	if env.IsEmptyRemote(r) {
+		return nil, nil
+	}
+
+	newFs, err := fs.WithWorkingDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s; %s", ErrFailedToAccessDir, dir, err.Error())
+	}
+
+	dEnv := env.Load(ctx, homeProvider, newFs, doltdb.LocalDirDoltDB, version)
+	if dEnv.DBLoadError != nil || dEnv.RSLoadErr != nil {
+		return nil, nil
+	}
+
+	tmpDir, err := dEnv.TempTableFilesDir()
+	if err != nil {
+		return nil, nil
+	}
+	if ok, err := pull.HasCheckpoint(tmpDir); err != nil || !ok {
+		return nil, err
+	}
+
+	remotes, err := dEnv.GetRemotes()
+	if err != nil {
+		return nil, nil
+	}
+	if existing, ok := remotes.Get(r.Name); !ok || existing.Url != r.Url {
+		return nil, nil
+	}
+
+	// A fetch into an existing repository is checkpointed too. Only a clone, which sets the root last, is resumed.
+	root, err := dEnv.DoltDB(ctx).NomsRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !root.IsEmpty() {
+		return nil, nil
+	}
+
+	return dEnv, nil
+}
+
 func clonePrint(eventCh <-chan pull.TableFileEvent) {
 	var (
 		chunksC           int64
@@ -128,6 +182,10 @@ func clonePrint(eventCh <-chan pull.TableFileEvent) {
 				chunksDownloaded += int64(tf.NumChunks())
 				delete(currStats, tf.FileID())
 			}
+		case pull.Resumed:
+			for _, tf := range tblFEvt.TableFiles {
+				chunksDownloaded += int64(tf.NumChunks())
+			}
 		case pull.DownloadFailed:
 			// Ignore for now and output errors on the main thread
 			for _, tf := range tblFEvt.TableFiles {
@@ -255,6 +313,11 @@ func getSrcRefs(ctx context.Context, branch string, srcDB *doltdb.DoltDB, dEnv *
 }
 
 func fullClone(ctx context.Context, srcDB *doltdb.DoltDB, dEnv *env.DoltEnv, srcRefHashes []doltdb.RefWithHash, branch, remoteName string, singleBranch bool) (*doltdb.Commit, error) {
+	tmpDir, err := dEnv.TempTableFilesDir()
+	if err != nil {
+		return nil, err
+	}
+
 	eventCh := make(chan pull.TableFileEvent, 128)
 	wg := &sync.WaitGroup{}
 	wg.Add(1)
@@ -263,11 +326,16 @@ func fullClone(ctx context.Context, srcDB *doltdb.DoltDB, dEnv *env.DoltEnv, src
 		clonePrint(eventCh)
 	}()
 
-	err := srcDB.Clone(ctx, dEnv.DoltDB(ctx), eventCh)
+	err = srcDB.Clone(ctx, tmpDir, dEnv.DoltDB(ctx), eventCh)
 
 	close(eventCh)
 	wg.Wait()
 
+	if errors.Is(err, pull.ErrCloneUnsupported) {
+		// remotes whose table files can't be copied as they are, such as encrypted remotes, are cloned by pulling
+		// their chunks instead
+		err = pullClone(ctx, srcDB, dEnv)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -318,6 +386,35 @@ func fullClone(ctx context.Context, srcDB *doltdb.DoltDB, dEnv *env.DoltEnv, src
 	return cm, nil
 }
 
+// pullClone pulls all the chunks of |srcDB| into the database of |dEnv|, and sets its root to that of |srcDB|.
+func pullClone(ctx context.Context, srcDB *doltdb.DoltDB, dEnv *env.DoltEnv) error {
+	destDB := dEnv.DoltDB(ctx)
+	srcRoot, err := srcDB.NomsRoot(ctx)
+	if err != nil {
+		return err
+	}
+	destRoot, err := destDB.NomsRoot(ctx)
+	if err != nil {
+		return err
+	}
+	tmpDir, err := dEnv.TempTableFilesDir()
+	if err != nil {
+		return err
+	}
+
+	err = destDB.PullChunks(ctx, tmpDir, srcDB, []hash.Hash{srcRoot}, nil, nil)
+	if err != nil {
+		return err
+	}
+	ok, err := destDB.CommitRoot(ctx, srcRoot, destRoot)
+	if err != nil {
+		return err
+	} else if !ok {
+		return errors.New("the root of the new database changed while it was being cloned")
+	}
+	return nil
+}
+
 // shallowCloneDataPull is a shallow clone specific helper function to pull only the data required to show the given branch
 // at the depth given.
 func shallowCloneDataPull(ctx context.Context, destData env.DbData, srcDB *doltdb.DoltDB, remoteName, branch string, depth int) (*doltdb.Commit, error) {
@@ -15,6 +15,7 @@
 package dolt_ci
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -57,8 +58,16 @@ var ExpectedDoltCITablesOrdered = WrappedTableNameSlice{
 	{TableName: doltdb.TableName{Name: doltdb.WorkflowStepsTableName}},
 	{TableName: doltdb.TableName{Name: doltdb.WorkflowSavedQueryStepsTableName}},
 	{TableName: doltdb.TableName{Name: doltdb.WorkflowSavedQueryStepExpectedRowColumnResultsTableName}},
+	{TableName: doltdb.TableName{Name: doltdb.WorkflowSqlAssertionStepsTableName}},
+	{TableName: doltdb.TableName{Name: doltdb.WorkflowVerifyConstraintsStepsTableName}},
+	{TableName: doltdb.TableName{Name: doltdb.WorkflowSchemaUnchangedStepsTableName}},
+	{TableName: doltdb.TableName{Name: doltdb.WorkflowDiffBudgetStepsTableName}},
 }
 
+// ErrIncompleteDoltCITables is returned when a database has some, but not all, of the expected dolt ci tables. This
+// is the case for databases initialized by a version of Dolt which did not yet have every table.
+var ErrIncompleteDoltCITables = errors.New("found some but not all of required dolt ci tables, run 'dolt ci init' to create the missing tables")
+
 type queryFunc func(ctx *sql.Context, query string) (sql.Schema, sql.RowIter, *sql.QueryFlags, error)
 
 // HasDoltCITables reports whether a database has all expected dolt_ci tables which store continuous integration config.
@@ -93,7 +102,7 @@ func HasDoltCITables(ctx *sql.Context) (bool, error) {
 		return false, nil
 	}
 	if hasSome && !hasAll {
-		return true, fmt.Errorf("found some but not all of required dolt ci tables")
+		return true, ErrIncompleteDoltCITables
 	}
 	return true, nil
 }
@@ -205,6 +214,10 @@ func CreateDoltCITables(ctx *sql.Context, db sqle.Database, queryFunc queryFunc,
 		createWorkflowStepsTableQuery(),
 		createWorkflowSavedQueryStepsTableQuery(),
 		createWorkflowSavedQueryStepExpectedRowColumnResultsTableQuery(),
+		createWorkflowSqlAssertionStepsTableQuery(),
+		createWorkflowVerifyConstraintsStepsTableQuery(),
+		createWorkflowSchemaUnchangedStepsTableQuery(),
+		createWorkflowDiffBudgetStepsTableQuery(),
 		deleteAllFromWorkflowsTableQuery(), // as last step run delete to create resolve all indexes/fks
 	}
 
@@ -220,6 +233,74 @@ func CreateDoltCITables(ctx *sql.Context, db sqle.Database, queryFunc queryFunc,
 	return commitCIInit(newCtx, queryFunc, ExpectedDoltCITablesOrdered.ActiveTableNames(), commiterName, commiterEmail)
 }
 
+// UpgradeDoltCITables creates the dolt_ci tables missing from a database initialized by an older version of Dolt and
+// creates a new Dolt commit.
+func UpgradeDoltCITables(ctx *sql.Context, db sqle.Database, queryFunc queryFunc, commiterName, commiterEmail string) error {
+	if err := dsess.CheckAccessForDb(ctx, db, branch_control.Permissions_Write); err != nil {
+		return err
+	}
+
+	existing, err := getExistingDoltCITables(ctx)
+	if err != nil {
+		return err
+	}
+
+	found := make(map[string]struct{})
+	for _, tn := range existing {
+		found[tn.Name] = struct{}{}
+	}
+
+	createTableQueries := map[string]string{
+		doltdb.WorkflowsTableName:                                      createWorkflowsTableQuery(),
+		doltdb.WorkflowEventsTableName:                                 createWorkflowEventsTableQuery(),
+		doltdb.WorkflowEventTriggersTableName:                          createWorkflowEventTriggersTableQuery(),
+		doltdb.WorkflowEventTriggerBranchesTableName:                   createWorkflowEventTriggerBranchesTableQuery(),
+		doltdb.WorkflowJobsTableName:                                   createWorkflowJobsTableQuery(),
+		doltdb.WorkflowStepsTableName:                                  createWorkflowStepsTableQuery(),
+		doltdb.WorkflowSavedQueryStepsTableName:                        createWorkflowSavedQueryStepsTableQuery(),
+		doltdb.WorkflowSavedQueryStepExpectedRowColumnResultsTableName: createWorkflowSavedQueryStepExpectedRowColumnResultsTableQuery(),
+		doltdb.WorkflowSqlAssertionStepsTableName:                      createWorkflowSqlAssertionStepsTableQuery(),
+		doltdb.WorkflowVerifyConstraintsStepsTableName:                 createWorkflowVerifyConstraintsStepsTableQuery(),
+		doltdb.WorkflowSchemaUnchangedStepsTableName:                   createWorkflowSchemaUnchangedStepsTableQuery(),
+		doltdb.WorkflowDiffBudgetStepsTableName:                        createWorkflowDiffBudgetStepsTableQuery(),
+	}
+
+	newCtx := doltdb.ContextWithDoltCICreateBypassKey(ctx)
+
+	created := make([]doltdb.TableName, 0)
+	for _, tn := range ExpectedDoltCITablesOrdered.ActiveTableNames() {
+		if _, ok := found[tn.Name]; ok {
+			continue
+		}
+		query, ok := createTableQueries[tn.Name]
+		if !ok {
+			return fmt.Errorf("unable to create dolt ci table: %s", tn.Name)
+		}
+		err = sqlWriteQuery(newCtx, queryFunc, query)
+		if err != nil {
+			return err
+		}
+		// as with init, run delete to resolve all indexes/fks
+		err = sqlWriteQuery(newCtx, queryFunc, fmt.Sprintf("delete from %s;", tn.Name))
+		if err != nil {
+			return err
+		}
+		created = append(created, tn)
+	}
+
+	if len(created) == 0 {
+		return nil
+	}
+
+	for i := len(created) - 1; i >= 0; i-- {
+		err = sqlWriteQuery(newCtx, queryFunc, fmt.Sprintf("CALL DOLT_ADD('%s');", created[i].Name))
+		if err != nil {
+			return err
+		}
+	}
+	return sqlWriteQuery(newCtx, queryFunc, fmt.Sprintf("CALL DOLT_COMMIT('-m' 'Successfully upgraded Dolt CI', '--author', '%s <%s>');", commiterName, commiterEmail))
+}
+
 func createWorkflowsTableQuery() string {
 	return fmt.Sprintf("create table %s (`%s` varchar(2048) collate utf8mb4_0900_ai_ci primary key, `%s` datetime(6) not null, `%s` datetime(6) not null);", doltdb.WorkflowsTableName, doltdb.WorkflowsNameColName, doltdb.WorkflowsCreatedAtColName, doltdb.WorkflowsUpdatedAtColName)
 }
@@ -252,6 +333,22 @@ func createWorkflowSavedQueryStepExpectedRowColumnResultsTableQuery() string {
 	return fmt.Sprintf("create table %s (`%s` varchar(36) primary key,`%s` int not null, `%s` int not null,`%s` bigint not null,`%s` bigint not null,`%s` datetime(6) not null,`%s` datetime(6) not null,`%s` varchar(36) not null, foreign key (`%s`) references %s (`%s`) on delete cascade);", doltdb.WorkflowSavedQueryStepExpectedRowColumnResultsTableName, doltdb.WorkflowSavedQueryStepExpectedRowColumnResultsIdPkColName, doltdb.WorkflowSavedQueryStepExpectedRowColumnResultsExpectedColumnCountComparisonTypeColName, doltdb.WorkflowSavedQueryStepExpectedRowColumnResultsExpectedRowCountComparisonTypeColName, doltdb.WorkflowSavedQueryStepExpectedRowColumnResultsExpectedColumnCountColName, doltdb.WorkflowSavedQueryStepExpectedRowColumnResultsExpectedRowCountColName, doltdb.WorkflowSavedQueryStepExpectedRowColumnResultsCreatedAtColName, doltdb.WorkflowSavedQueryStepExpectedRowColumnResultsUpdatedAtColName, doltdb.WorkflowSavedQueryStepExpectedRowColumnResultsSavedQueryStepIdFkColName, doltdb.WorkflowSavedQueryStepExpectedRowColumnResultsSavedQueryStepIdFkColName, doltdb.WorkflowSavedQueryStepsTableName, doltdb.WorkflowSavedQueryStepsIdPkColName)
 }
 
+func createWorkflowSqlAssertionStepsTableQuery() string {
+	return fmt.Sprintf("create table %s (`%s` varchar(36) primary key, `%s` longtext not null, `%s` longtext not null, `%s` varchar(36) not null, foreign key (`%s`) references %s (`%s`) on delete cascade);", doltdb.WorkflowSqlAssertionStepsTableName, doltdb.WorkflowSqlAssertionStepsIdPkColName, doltdb.WorkflowSqlAssertionStepsQueryColName, doltdb.WorkflowSqlAssertionStepsExpectedResultsColName, doltdb.WorkflowSqlAssertionStepsWorkflowStepIdFkColName, doltdb.WorkflowSqlAssertionStepsWorkflowStepIdFkColName, doltdb.WorkflowStepsTableName, doltdb.WorkflowStepsIdPkColName)
+}
+
+func createWorkflowVerifyConstraintsStepsTableQuery() string {
+	return fmt.Sprintf("create table %s (`%s` varchar(36) primary key, `%s` longtext not null, `%s` varchar(36) not null, foreign key (`%s`) references %s (`%s`) on delete cascade);", doltdb.WorkflowVerifyConstraintsStepsTableName, doltdb.WorkflowVerifyConstraintsStepsIdPkColName, doltdb.WorkflowVerifyConstraintsStepsTableNamesColName, doltdb.WorkflowVerifyConstraintsStepsWorkflowStepIdFkColName, doltdb.WorkflowVerifyConstraintsStepsWorkflowStepIdFkColName, doltdb.WorkflowStepsTableName, doltdb.WorkflowStepsIdPkColName)
+}
+
+func createWorkflowSchemaUnchangedStepsTableQuery() string {
+	return fmt.Sprintf("create table %s (`%s` varchar(36) primary key, `%s` varchar(1024) collate utf8mb4_0900_ai_ci not null, `%s` varchar(1024) collate utf8mb4_0900_ai_ci not null, `%s` varchar(36) not null, foreign key (`%s`) references %s (`%s`) on delete cascade);", doltdb.WorkflowSchemaUnchangedStepsTableName, doltdb.WorkflowSchemaUnchangedStepsIdPkColName, doltdb.WorkflowSchemaUnchangedStepsTableNameColName, doltdb.WorkflowSchemaUnchangedStepsCompareToColName, doltdb.WorkflowSchemaUnchangedStepsWorkflowStepIdFkColName, doltdb.WorkflowSchemaUnchangedStepsWorkflowStepIdFkColName, doltdb.WorkflowStepsTableName, doltdb.WorkflowStepsIdPkColName)
+}
+
+func createWorkflowDiffBudgetStepsTableQuery() string {
+	return fmt.Sprintf("create table %s (`%s` varchar(36) primary key, `%s` varchar(1024) collate utf8mb4_0900_ai_ci not null, `%s` varchar(1024) collate utf8mb4_0900_ai_ci not null, `%s` bigint, `%s` bigint, `%s` bigint, `%s` varchar(36) not null, foreign key (`%s`) references %s (`%s`) on delete cascade);", doltdb.WorkflowDiffBudgetStepsTableName, doltdb.WorkflowDiffBudgetStepsIdPkColName, doltdb.WorkflowDiffBudgetStepsTableNameColName, doltdb.WorkflowDiffBudgetStepsCompareToColName, doltdb.WorkflowDiffBudgetStepsMaxRowsAddedColName, doltdb.WorkflowDiffBudgetStepsMaxRowsDeletedColName, doltdb.WorkflowDiffBudgetStepsMaxRowsModifiedColName, doltdb.WorkflowDiffBudgetStepsWorkflowStepIdFkColName, doltdb.WorkflowDiffBudgetStepsWorkflowStepIdFkColName, doltdb.WorkflowStepsTableName, doltdb.WorkflowStepsIdPkColName)
+}
+
 func deleteAllFromWorkflowsTableQuery() string {
 	return fmt.Sprintf("delete from %s;", doltdb.WorkflowsTableName)
 }
@@ -19,15 +19,84 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
 type Step struct {
-	Name            yaml.Node `yaml:"name"`
-	SavedQueryName  yaml.Node `yaml:"saved_query_name"`
-	ExpectedColumns yaml.Node `yaml:"expected_columns,omitempty"`
-	ExpectedRows    yaml.Node `yaml:"expected_rows,omitempty"`
+	Name              yaml.Node          `yaml:"name"`
+	SavedQueryName    yaml.Node          `yaml:"saved_query_name,omitempty"`
+	ExpectedColumns   yaml.Node          `yaml:"expected_columns,omitempty"`
+	ExpectedRows      yaml.Node          `yaml:"expected_rows,omitempty"`
+	SqlAssertion      *SqlAssertion      `yaml:"sql_assertion,omitempty"`
+	VerifyConstraints *VerifyConstraints `yaml:"verify_constraints,omitempty"`
+	SchemaUnchanged   *SchemaUnchanged   `yaml:"schema_unchanged,omitempty"`
+	DiffBudget        *DiffBudget        `yaml:"diff_budget,omitempty"`
+}
+
+// SqlAssertion runs |Query| and compares its result set to |ExpectedResults|, ignoring row order. Each expected row
+// is a list of column values, which are compared to the query's values formatted as strings. A null value only
+// matches NULL.
+type SqlAssertion struct {
+	Query           yaml.Node     `yaml:"query"`
+	ExpectedResults [][]yaml.Node `yaml:"expected_results"`
+}
+
+// VerifyConstraints checks every row of |Tables|, or of all tables if none are listed, for constraint violations.
+type VerifyConstraints struct {
+	Tables []yaml.Node `yaml:"tables,omitempty"`
+}
+
+// SchemaUnchanged checks that the schema of |Table| is the same as at the |CompareTo| revision, which defaults to the
+// parent of the revision under test.
+type SchemaUnchanged struct {
+	Table     yaml.Node `yaml:"table"`
+	CompareTo yaml.Node `yaml:"compare_to,omitempty"`
+}
+
+// DiffBudget checks the number of rows changed in |Table|, or in all tables if no table is given, since the
+// |CompareTo| revision, which defaults to the parent of the revision under test.
+type DiffBudget struct {
+	Table           yaml.Node `yaml:"table,omitempty"`
+	CompareTo       yaml.Node `yaml:"compare_to,omitempty"`
+	MaxRowsAdded    yaml.Node `yaml:"max_rows_added,omitempty"`
+	MaxRowsDeleted  yaml.Node `yaml:"max_rows_deleted,omitempty"`
+	MaxRowsModified yaml.Node `yaml:"max_rows_modified,omitempty"`
+}
+
+// stepType returns the type of step configured. Exactly one kind of step must be configured.
+func (s Step) stepType() (WorkflowStepType, error) {
+	stepType := WorkflowStepTypeUnspecified
+	count := 0
+	if s.SavedQueryName.Value != "" {
+		stepType = WorkflowStepTypeSavedQuery
+		count++
+	}
+	if s.SqlAssertion != nil {
+		stepType = WorkflowStepTypeSqlAssertion
+		count++
+	}
+	if s.VerifyConstraints != nil {
+		stepType = WorkflowStepTypeVerifyConstraints
+		count++
+	}
+	if s.SchemaUnchanged != nil {
+		stepType = WorkflowStepTypeSchemaUnchanged
+		count++
+	}
+	if s.DiffBudget != nil {
+		stepType = WorkflowStepTypeDiffBudget
+		count++
+	}
+	if count == 0 {
+		return WorkflowStepTypeUnspecified, fmt.Errorf("invalid config: step %s is missing saved_query_name, sql_assertion, verify_constraints, schema_unchanged or diff_budget", s.Name.Value)
+	}
+	if count > 1 {
+		return WorkflowStepTypeUnspecified, fmt.Errorf("invalid config: step %s defines more than one of saved_query_name, sql_assertion, verify_constraints, schema_unchanged and diff_budget", s.Name.Value)
+	}
+	return stepType, nil
 }
 
 type Job struct {
@@ -101,6 +170,9 @@ func ValidateWorkflowConfig(workflow *WorkflowConfig) error {
 			} else {
 				branches[branch.Value] = true
 			}
+			if _, err := matchBranchPattern(branch.Value, ""); err != nil {
+				return fmt.Errorf("invalid config: %w", err)
+			}
 		}
 	}
 
@@ -156,11 +228,65 @@ func ValidateWorkflowConfig(workflow *WorkflowConfig) error {
 			} else {
 				steps[step.Name.Value] = true
 			}
-			if step.SavedQueryName.Value == "" {
-				return fmt.Errorf("invalid config: step %s is missing saved_query_name", step.Name.Value)
+			err := validateStep(step)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateStep(step Step) error {
+	stepType, err := step.stepType()
+	if err != nil {
+		return err
+	}
+
+	if stepType != WorkflowStepTypeSavedQuery && (step.ExpectedColumns.Value != "" || step.ExpectedRows.Value != "") {
+		return fmt.Errorf("invalid config: step %s: expected_columns and expected_rows are only valid with saved_query_name", step.Name.Value)
+	}
+
+	switch stepType {
+	case WorkflowStepTypeSqlAssertion:
+		if step.SqlAssertion.Query.Value == "" {
+			return fmt.Errorf("invalid config: step %s is missing sql_assertion query", step.Name.Value)
+		}
+		for _, row := range step.SqlAssertion.ExpectedResults {
+			for _, val := range row {
+				if val.Kind != yaml.ScalarNode {
+					return fmt.Errorf("invalid config: step %s: sql_assertion expected_results must be a list of rows of scalar values", step.Name.Value)
+				}
+			}
+		}
+	case WorkflowStepTypeSchemaUnchanged:
+		if step.SchemaUnchanged.Table.Value == "" {
+			return fmt.Errorf("invalid config: step %s is missing schema_unchanged table", step.Name.Value)
+		}
+	case WorkflowStepTypeDiffBudget:
+		budget := step.DiffBudget
+		if budget.MaxRowsAdded.Value == "" && budget.MaxRowsDeleted.Value == "" && budget.MaxRowsModified.Value == "" {
+			return fmt.Errorf("invalid config: step %s: diff_budget requires at least one of max_rows_added, max_rows_deleted or max_rows_modified", step.Name.Value)
+		}
+		for _, n := range []yaml.Node{budget.MaxRowsAdded, budget.MaxRowsDeleted, budget.MaxRowsModified} {
+			if n.Value == "" {
+				continue
+			}
+			if _, err := parseDiffBudgetMax(n.Value); err != nil {
+				return fmt.Errorf("invalid config: step %s: %w", step.Name.Value, err)
 			}
 		}
 	}
 
 	return nil
 }
+
+// parseDiffBudgetMax parses a diff budget maximum, which must be a non-negative integer.
+func parseDiffBudgetMax(str string) (int64, error) {
+	i, err := strconv.ParseInt(strings.TrimSpace(str), 10, 64)
+	if err != nil || i < 0 {
+		return 0, fmt.Errorf("diff_budget maximums must be non-negative integers, got: %s", str)
+	}
+	return i, nil
+}
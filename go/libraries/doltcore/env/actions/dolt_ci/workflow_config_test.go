@@ -95,3 +95,92 @@ jobs:
 
 	// todo: check expected stuff
 }
+
+func TestValidateWorkflowStepTypes(t *testing.T) {
+	ymlTemplate := `name: workflow
+on:
+  push:
+    branches:
+      - main
+jobs:
+  - name: job
+    steps:
+      - name: step
+%s
+`
+
+	tests := []struct {
+		name  string
+		step  string
+		valid bool
+	}{
+		{"saved query", `        saved_query_name: sq`, true},
+		{"sql assertion", `        sql_assertion:
+          query: select 1, null
+          expected_results:
+            - [1, null]`, true},
+		{"sql assertion without query", `        sql_assertion:
+          expected_results:
+            - [1]`, false},
+		{"sql assertion with nested result", `        sql_assertion:
+          query: select 1
+          expected_results:
+            - [[1]]`, false},
+		{"verify constraints", `        verify_constraints:
+          tables:
+            - t`, true},
+		{"schema unchanged", `        schema_unchanged:
+          table: t
+          compare_to: main`, true},
+		{"schema unchanged without table", `        schema_unchanged:
+          compare_to: main`, false},
+		{"diff budget", `        diff_budget:
+          max_rows_added: 10`, true},
+		{"diff budget without maximums", `        diff_budget:
+          table: t`, false},
+		{"diff budget with negative maximum", `        diff_budget:
+          max_rows_deleted: -1`, false},
+		{"no step type", `        expected_rows: "1"`, false},
+		{"two step types", `        saved_query_name: sq
+        schema_unchanged:
+          table: t`, false},
+		{"expected rows without saved query", `        expected_rows: "1"
+        schema_unchanged:
+          table: t`, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			wf, err := ParseWorkflowConfig(strings.NewReader(fmt.Sprintf(ymlTemplate, test.step)))
+			require.NoError(t, err)
+			err = ValidateWorkflowConfig(wf)
+			if test.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestValidatePushBranchPatterns(t *testing.T) {
+	ymlTemplate := `name: workflow
+on:
+  push:
+    branches:
+      - "%s"
+jobs:
+  - name: job
+    steps:
+      - name: step
+        saved_query_name: sq
+`
+
+	wf, err := ParseWorkflowConfig(strings.NewReader(fmt.Sprintf(ymlTemplate, "Release-*")))
+	require.NoError(t, err)
+	require.NoError(t, ValidateWorkflowConfig(wf))
+
+	wf, err = ParseWorkflowConfig(strings.NewReader(fmt.Sprintf(ymlTemplate, "release-[")))
+	require.NoError(t, err)
+	require.ErrorContains(t, ValidateWorkflowConfig(wf), "invalid push branch pattern 'release-['")
+}
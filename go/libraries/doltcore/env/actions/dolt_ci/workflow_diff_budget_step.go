@@ -0,0 +1,31 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dolt_ci
+
+type WorkflowDiffBudgetStepId string
+
+// WorkflowDiffBudgetStep is a step which fails if more rows were added, deleted or modified than allowed, as reported
+// by dolt_diff_stat between another revision and the revision under test. An empty TableName counts the changes to
+// every table, and an empty CompareTo compares against the parent of the revision under test. A nil maximum is not
+// checked.
+type WorkflowDiffBudgetStep struct {
+	Id               *WorkflowDiffBudgetStepId `db:"id"`
+	WorkflowStepIdFK *WorkflowStepId           `db:"workflow_step_id_fk"`
+	TableName        string                    `db:"table_name"`
+	CompareTo        string                    `db:"compare_to"`
+	MaxRowsAdded     *int64                    `db:"max_rows_added"`
+	MaxRowsDeleted   *int64                    `db:"max_rows_deleted"`
+	MaxRowsModified  *int64                    `db:"max_rows_modified"`
+}
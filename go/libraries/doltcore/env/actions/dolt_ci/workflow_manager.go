@@ -48,6 +48,9 @@ type WorkflowManager interface {
 	GetWorkflowConfig(ctx *sql.Context, db sqle.Database, workflowName string) (*WorkflowConfig, error)
 	// StoreAndCommit creates or updates a workflow and creates a Dolt commit
 	StoreAndCommit(ctx *sql.Context, db sqle.Database, config *WorkflowConfig) error
+	// RunWorkflow runs the steps of a workflow against a branch, tag or commit and returns the result of each step.
+	// An empty ref runs the workflow against the current branch.
+	RunWorkflow(ctx *sql.Context, db sqle.Database, workflowName, ref string) (*WorkflowRunResult, error)
 }
 
 type doltWorkflowManager struct {
@@ -1264,6 +1267,16 @@ func (d *doltWorkflowManager) updateExistingWorkflow(ctx *sql.Context, config *W
 
 			for _, step := range steps {
 				configStep, ok := configSteps[step.Name]
+				if ok {
+					configStepType, err := configStep.stepType()
+					if err != nil {
+						return err
+					}
+					// steps other than saved query steps are replaced, rather than updated, when they change
+					if configStepType != step.StepType || configStepType != WorkflowStepTypeSavedQuery {
+						ok = false
+					}
+				}
 				if !ok {
 					err = d.deleteWorkflowStep(ctx, *step.Id)
 					if err != nil {
@@ -1356,27 +1369,7 @@ func (d *doltWorkflowManager) updateExistingWorkflow(ctx *sql.Context, config *W
 				}
 
 				stepOrder := orderIdx + 1
-				stepID, err := d.writeWorkflowStepRow(ctx, *job.Id, step.Name.Value, stepOrder, WorkflowStepTypeSavedQuery)
-				if err != nil {
-					return err
-				}
-
-				savedQueryStepID, err := d.writeWorkflowSavedQueryStepRow(ctx, stepID, step.SavedQueryName.Value, WorkflowSavedQueryExpectedResultsTypeRowColumnCount)
-				if err != nil {
-					return err
-				}
-
-				expectedColumnComparisonType, expectedColumnCount, err := d.parseSavedQueryExpectedResultString(step.ExpectedColumns.Value)
-				if err != nil {
-					return err
-				}
-
-				expectedRowComparisonType, expectedRowCount, err := d.parseSavedQueryExpectedResultString(step.ExpectedRows.Value)
-				if err != nil {
-					return err
-				}
-
-				_, err = d.writeWorkflowSavedQueryStepExpectedRowColumnResultRow(ctx, savedQueryStepID, expectedColumnComparisonType, expectedRowComparisonType, expectedColumnCount, expectedRowCount)
+				err = d.writeWorkflowStepFromConfig(ctx, *job.Id, step, stepOrder)
 				if err != nil {
 					return err
 				}
@@ -1396,27 +1389,7 @@ func (d *doltWorkflowManager) updateExistingWorkflow(ctx *sql.Context, config *W
 			return err
 		}
 		for idx, step := range job.Steps {
-			stepID, err := d.writeWorkflowStepRow(ctx, jobID, step.Name.Value, idx+1, WorkflowStepTypeSavedQuery)
-			if err != nil {
-				return err
-			}
-
-			savedQueryStepID, err := d.writeWorkflowSavedQueryStepRow(ctx, stepID, step.SavedQueryName.Value, WorkflowSavedQueryExpectedResultsTypeRowColumnCount)
-			if err != nil {
-				return err
-			}
-
-			expectedColumnComparisonType, expectedColumnCount, err := d.parseSavedQueryExpectedResultString(step.ExpectedColumns.Value)
-			if err != nil {
-				return err
-			}
-
-			expectedRowComparisonType, expectedRowCount, err := d.parseSavedQueryExpectedResultString(step.ExpectedRows.Value)
-			if err != nil {
-				return err
-			}
-
-			_, err = d.writeWorkflowSavedQueryStepExpectedRowColumnResultRow(ctx, savedQueryStepID, expectedColumnComparisonType, expectedRowComparisonType, expectedColumnCount, expectedRowCount)
+			err = d.writeWorkflowStepFromConfig(ctx, jobID, step, idx+1)
 			if err != nil {
 				return err
 			}
@@ -1727,49 +1700,10 @@ func (d *doltWorkflowManager) createWorkflow(ctx *sql.Context, config *WorkflowC
 
 		// handle steps
 		for idx, step := range job.Steps {
-			// insert into step
-			order := idx + 1
-
-			var stepType WorkflowStepType
-			if step.SavedQueryName.Value != "" {
-				stepType = WorkflowStepTypeSavedQuery
-			}
-
-			stepID, err := d.writeWorkflowStepRow(ctx, jobID, step.Name.Value, order, stepType)
+			err = d.writeWorkflowStepFromConfig(ctx, jobID, step, idx+1)
 			if err != nil {
 				return err
 			}
-
-			// insert into saved query steps
-			if stepType == WorkflowStepTypeSavedQuery {
-				resultType := WorkflowSavedQueryExpectedResultsTypeUnspecified
-				if step.ExpectedColumns.Value != "" || step.ExpectedRows.Value != "" {
-					resultType = WorkflowSavedQueryExpectedResultsTypeRowColumnCount
-				}
-
-				savedQueryStepID, err := d.writeWorkflowSavedQueryStepRow(ctx, stepID, step.SavedQueryName.Value, resultType)
-				if err != nil {
-					return err
-				}
-
-				if resultType == WorkflowSavedQueryExpectedResultsTypeRowColumnCount {
-					// insert into expected results
-					expectedColumnComparisonType, expectedColumnCount, err := d.parseSavedQueryExpectedResultString(step.ExpectedColumns.Value)
-					if err != nil {
-						return err
-					}
-
-					expectedRowComparisonType, expectedRowCount, err := d.parseSavedQueryExpectedResultString(step.ExpectedRows.Value)
-					if err != nil {
-						return err
-					}
-
-					_, err = d.writeWorkflowSavedQueryStepExpectedRowColumnResultRow(ctx, savedQueryStepID, expectedColumnComparisonType, expectedRowComparisonType, expectedColumnCount, expectedRowCount)
-					if err != nil {
-						return err
-					}
-				}
-			}
 		}
 	}
 	return nil
@@ -1900,6 +1834,12 @@ func (d *doltWorkflowManager) getWorkflowConfig(ctx *sql.Context, workflowName s
 					}
 				}
 
+				steps = append(steps, step)
+			} else {
+				step, err := d.stepConfig(ctx, stp)
+				if err != nil {
+					return nil, err
+				}
 				steps = append(steps, step)
 			}
 		}
@@ -1981,6 +1921,13 @@ func (d *doltWorkflowManager) StoreAndCommit(ctx *sql.Context, db sqle.Database,
 	return d.commitWorkflow(ctx, ExpectedDoltCITablesOrdered.ActiveTableNames(), config.Name.Value)
 }
 
+func (d *doltWorkflowManager) RunWorkflow(ctx *sql.Context, db sqle.Database, workflowName, ref string) (*WorkflowRunResult, error) {
+	if err := dsess.CheckAccessForDb(ctx, db, branch_control.Permissions_Read); err != nil {
+		return nil, err
+	}
+	return d.runWorkflow(ctx, workflowName, ref)
+}
+
 func newScalarDoubleQuotedYamlNode(value string) yaml.Node {
 	return yaml.Node{
 		Kind:  yaml.ScalarNode,
@@ -0,0 +1,520 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dolt_ci
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+)
+
+const yamlNullTag = "!!null"
+
+// selects
+
+func (d *doltWorkflowManager) selectAllFromSqlAssertionStepsTableByWorkflowStepIdQuery(stepID string) string {
+	return fmt.Sprintf("select * from %s where `%s` = '%s' limit 1;", doltdb.WorkflowSqlAssertionStepsTableName, doltdb.WorkflowSqlAssertionStepsWorkflowStepIdFkColName, stepID)
+}
+
+func (d *doltWorkflowManager) selectAllFromVerifyConstraintsStepsTableByWorkflowStepIdQuery(stepID string) string {
+	return fmt.Sprintf("select * from %s where `%s` = '%s' limit 1;", doltdb.WorkflowVerifyConstraintsStepsTableName, doltdb.WorkflowVerifyConstraintsStepsWorkflowStepIdFkColName, stepID)
+}
+
+func (d *doltWorkflowManager) selectAllFromSchemaUnchangedStepsTableByWorkflowStepIdQuery(stepID string) string {
+	return fmt.Sprintf("select * from %s where `%s` = '%s' limit 1;", doltdb.WorkflowSchemaUnchangedStepsTableName, doltdb.WorkflowSchemaUnchangedStepsWorkflowStepIdFkColName, stepID)
+}
+
+func (d *doltWorkflowManager) selectAllFromDiffBudgetStepsTableByWorkflowStepIdQuery(stepID string) string {
+	return fmt.Sprintf("select * from %s where `%s` = '%s' limit 1;", doltdb.WorkflowDiffBudgetStepsTableName, doltdb.WorkflowDiffBudgetStepsWorkflowStepIdFkColName, stepID)
+}
+
+// inserts
+
+func (d *doltWorkflowManager) insertIntoWorkflowSqlAssertionStepsTableQuery(stepID, query, expectedResults string) (string, string) {
+	sqlAssertionStepID := uuid.NewString()
+	return sqlAssertionStepID, fmt.Sprintf("insert into %s (`%s`, `%s`, `%s`, `%s`) values ('%s', '%s', %s, %s);", doltdb.WorkflowSqlAssertionStepsTableName, doltdb.WorkflowSqlAssertionStepsIdPkColName, doltdb.WorkflowSqlAssertionStepsWorkflowStepIdFkColName, doltdb.WorkflowSqlAssertionStepsQueryColName, doltdb.WorkflowSqlAssertionStepsExpectedResultsColName, sqlAssertionStepID, stepID, quoteSqlString(query), quoteSqlString(expectedResults))
+}
+
+func (d *doltWorkflowManager) insertIntoWorkflowVerifyConstraintsStepsTableQuery(stepID, tableNames string) (string, string) {
+	verifyConstraintsStepID := uuid.NewString()
+	return verifyConstraintsStepID, fmt.Sprintf("insert into %s (`%s`, `%s`, `%s`) values ('%s', '%s', %s);", doltdb.WorkflowVerifyConstraintsStepsTableName, doltdb.WorkflowVerifyConstraintsStepsIdPkColName, doltdb.WorkflowVerifyConstraintsStepsWorkflowStepIdFkColName, doltdb.WorkflowVerifyConstraintsStepsTableNamesColName, verifyConstraintsStepID, stepID, quoteSqlString(tableNames))
+}
+
+func (d *doltWorkflowManager) insertIntoWorkflowSchemaUnchangedStepsTableQuery(stepID, tableName, compareTo string) (string, string) {
+	schemaUnchangedStepID := uuid.NewString()
+	return schemaUnchangedStepID, fmt.Sprintf("insert into %s (`%s`, `%s`, `%s`, `%s`) values ('%s', '%s', %s, %s);", doltdb.WorkflowSchemaUnchangedStepsTableName, doltdb.WorkflowSchemaUnchangedStepsIdPkColName, doltdb.WorkflowSchemaUnchangedStepsWorkflowStepIdFkColName, doltdb.WorkflowSchemaUnchangedStepsTableNameColName, doltdb.WorkflowSchemaUnchangedStepsCompareToColName, schemaUnchangedStepID, stepID, quoteSqlString(tableName), quoteSqlString(compareTo))
+}
+
+func (d *doltWorkflowManager) insertIntoWorkflowDiffBudgetStepsTableQuery(stepID, tableName, compareTo string, maxRowsAdded, maxRowsDeleted, maxRowsModified *int64) (string, string) {
+	diffBudgetStepID := uuid.NewString()
+	return diffBudgetStepID, fmt.Sprintf("insert into %s (`%s`, `%s`, `%s`, `%s`, `%s`, `%s`, `%s`) values ('%s', '%s', %s, %s, %s, %s, %s);", doltdb.WorkflowDiffBudgetStepsTableName, doltdb.WorkflowDiffBudgetStepsIdPkColName, doltdb.WorkflowDiffBudgetStepsWorkflowStepIdFkColName, doltdb.WorkflowDiffBudgetStepsTableNameColName, doltdb.WorkflowDiffBudgetStepsCompareToColName, doltdb.WorkflowDiffBudgetStepsMaxRowsAddedColName, doltdb.WorkflowDiffBudgetStepsMaxRowsDeletedColName, doltdb.WorkflowDiffBudgetStepsMaxRowsModifiedColName, diffBudgetStepID, stepID, quoteSqlString(tableName), quoteSqlString(compareTo), nullableInt64Sql(maxRowsAdded), nullableInt64Sql(maxRowsDeleted), nullableInt64Sql(maxRowsModified))
+}
+
+// quoteSqlString quotes |s| as a SQL string literal, escaping any special characters within it.
+func quoteSqlString(s string) string {
+	buf := &bytes.Buffer{}
+	sqltypes.MakeTrusted(sqltypes.VarChar, []byte(s)).EncodeSQL(buf)
+	return buf.String()
+}
+
+func nullableInt64Sql(i *int64) string {
+	if i == nil {
+		return "NULL"
+	}
+	return strconv.FormatInt(*i, 10)
+}
+
+// rows
+
+func (d *doltWorkflowManager) newWorkflowSqlAssertionStep(cvs columnValues) (*WorkflowSqlAssertionStep, error) {
+	s := &WorkflowSqlAssertionStep{}
+
+	for _, cv := range cvs {
+		if cv == nil {
+			continue
+		}
+		switch cv.ColumnName {
+		case doltdb.WorkflowSqlAssertionStepsIdPkColName:
+			id := WorkflowSqlAssertionStepId(cv.Value)
+			s.Id = &id
+		case doltdb.WorkflowSqlAssertionStepsWorkflowStepIdFkColName:
+			id := WorkflowStepId(cv.Value)
+			s.WorkflowStepIdFK = &id
+		case doltdb.WorkflowSqlAssertionStepsQueryColName:
+			s.Query = cv.Value
+		case doltdb.WorkflowSqlAssertionStepsExpectedResultsColName:
+			s.ExpectedResults = cv.Value
+		default:
+			return nil, errors.New(fmt.Sprintf("unknown sql assertion step column: %s", cv.ColumnName))
+		}
+	}
+
+	return s, nil
+}
+
+func (d *doltWorkflowManager) newWorkflowVerifyConstraintsStep(cvs columnValues) (*WorkflowVerifyConstraintsStep, error) {
+	s := &WorkflowVerifyConstraintsStep{}
+
+	for _, cv := range cvs {
+		if cv == nil {
+			continue
+		}
+		switch cv.ColumnName {
+		case doltdb.WorkflowVerifyConstraintsStepsIdPkColName:
+			id := WorkflowVerifyConstraintsStepId(cv.Value)
+			s.Id = &id
+		case doltdb.WorkflowVerifyConstraintsStepsWorkflowStepIdFkColName:
+			id := WorkflowStepId(cv.Value)
+			s.WorkflowStepIdFK = &id
+		case doltdb.WorkflowVerifyConstraintsStepsTableNamesColName:
+			s.TableNames = cv.Value
+		default:
+			return nil, errors.New(fmt.Sprintf("unknown verify constraints step column: %s", cv.ColumnName))
+		}
+	}
+
+	return s, nil
+}
+
+func (d *doltWorkflowManager) newWorkflowSchemaUnchangedStep(cvs columnValues) (*WorkflowSchemaUnchangedStep, error) {
+	s := &WorkflowSchemaUnchangedStep{}
+
+	for _, cv := range cvs {
+		if cv == nil {
+			continue
+		}
+		switch cv.ColumnName {
+		case doltdb.WorkflowSchemaUnchangedStepsIdPkColName:
+			id := WorkflowSchemaUnchangedStepId(cv.Value)
+			s.Id = &id
+		case doltdb.WorkflowSchemaUnchangedStepsWorkflowStepIdFkColName:
+			id := WorkflowStepId(cv.Value)
+			s.WorkflowStepIdFK = &id
+		case doltdb.WorkflowSchemaUnchangedStepsTableNameColName:
+			s.TableName = cv.Value
+		case doltdb.WorkflowSchemaUnchangedStepsCompareToColName:
+			s.CompareTo = cv.Value
+		default:
+			return nil, errors.New(fmt.Sprintf("unknown schema unchanged step column: %s", cv.ColumnName))
+		}
+	}
+
+	return s, nil
+}
+
+func (d *doltWorkflowManager) newWorkflowDiffBudgetStep(cvs columnValues) (*WorkflowDiffBudgetStep, error) {
+	s := &WorkflowDiffBudgetStep{}
+
+	parseMax := func(str string) (*int64, error) {
+		i, err := strconv.ParseInt(str, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return &i, nil
+	}
+
+	var err error
+	for _, cv := range cvs {
+		if cv == nil {
+			continue
+		}
+		switch cv.ColumnName {
+		case doltdb.WorkflowDiffBudgetStepsIdPkColName:
+			id := WorkflowDiffBudgetStepId(cv.Value)
+			s.Id = &id
+		case doltdb.WorkflowDiffBudgetStepsWorkflowStepIdFkColName:
+			id := WorkflowStepId(cv.Value)
+			s.WorkflowStepIdFK = &id
+		case doltdb.WorkflowDiffBudgetStepsTableNameColName:
+			s.TableName = cv.Value
+		case doltdb.WorkflowDiffBudgetStepsCompareToColName:
+			s.CompareTo = cv.Value
+		case doltdb.WorkflowDiffBudgetStepsMaxRowsAddedColName:
+			s.MaxRowsAdded, err = parseMax(cv.Value)
+		case doltdb.WorkflowDiffBudgetStepsMaxRowsDeletedColName:
+			s.MaxRowsDeleted, err = parseMax(cv.Value)
+		case doltdb.WorkflowDiffBudgetStepsMaxRowsModifiedColName:
+			s.MaxRowsModified, err = parseMax(cv.Value)
+		default:
+			return nil, errors.New(fmt.Sprintf("unknown diff budget step column: %s", cv.ColumnName))
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// gets
+
+// readOneByStepId reads the single row |query| selects for the step given, passing its columns to |cb|. It returns
+// an error if no row was found.
+func (d *doltWorkflowManager) readOneByStepId(ctx *sql.Context, query string, stepID WorkflowStepId, kind string, cb func(cvs columnValues) error) error {
+	found := false
+	err := d.sqlReadQuery(ctx, query, func(_ *sql.Context, cvs columnValues) error {
+		found = true
+		return cb(cvs)
+	})
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("%s step not found for step: %s", kind, stepID)
+	}
+	return nil
+}
+
+func (d *doltWorkflowManager) getWorkflowSqlAssertionStepByStepId(ctx *sql.Context, stepID WorkflowStepId) (s *WorkflowSqlAssertionStep, err error) {
+	err = d.readOneByStepId(ctx, d.selectAllFromSqlAssertionStepsTableByWorkflowStepIdQuery(string(stepID)), stepID, "sql assertion", func(cvs columnValues) error {
+		s, err = d.newWorkflowSqlAssertionStep(cvs)
+		return err
+	})
+	return s, err
+}
+
+func (d *doltWorkflowManager) getWorkflowVerifyConstraintsStepByStepId(ctx *sql.Context, stepID WorkflowStepId) (s *WorkflowVerifyConstraintsStep, err error) {
+	err = d.readOneByStepId(ctx, d.selectAllFromVerifyConstraintsStepsTableByWorkflowStepIdQuery(string(stepID)), stepID, "verify constraints", func(cvs columnValues) error {
+		s, err = d.newWorkflowVerifyConstraintsStep(cvs)
+		return err
+	})
+	return s, err
+}
+
+func (d *doltWorkflowManager) getWorkflowSchemaUnchangedStepByStepId(ctx *sql.Context, stepID WorkflowStepId) (s *WorkflowSchemaUnchangedStep, err error) {
+	err = d.readOneByStepId(ctx, d.selectAllFromSchemaUnchangedStepsTableByWorkflowStepIdQuery(string(stepID)), stepID, "schema unchanged", func(cvs columnValues) error {
+		s, err = d.newWorkflowSchemaUnchangedStep(cvs)
+		return err
+	})
+	return s, err
+}
+
+func (d *doltWorkflowManager) getWorkflowDiffBudgetStepByStepId(ctx *sql.Context, stepID WorkflowStepId) (s *WorkflowDiffBudgetStep, err error) {
+	err = d.readOneByStepId(ctx, d.selectAllFromDiffBudgetStepsTableByWorkflowStepIdQuery(string(stepID)), stepID, "diff budget", func(cvs columnValues) error {
+		s, err = d.newWorkflowDiffBudgetStep(cvs)
+		return err
+	})
+	return s, err
+}
+
+// writes
+
+func (d *doltWorkflowManager) writeWorkflowSqlAssertionStepRow(ctx *sql.Context, stepID WorkflowStepId, query, expectedResults string) (WorkflowSqlAssertionStepId, error) {
+	id, insertQuery := d.insertIntoWorkflowSqlAssertionStepsTableQuery(string(stepID), query, expectedResults)
+	err := d.sqlWriteQuery(ctx, insertQuery)
+	if err != nil {
+		return "", err
+	}
+	return WorkflowSqlAssertionStepId(id), nil
+}
+
+func (d *doltWorkflowManager) writeWorkflowVerifyConstraintsStepRow(ctx *sql.Context, stepID WorkflowStepId, tableNames string) (WorkflowVerifyConstraintsStepId, error) {
+	id, insertQuery := d.insertIntoWorkflowVerifyConstraintsStepsTableQuery(string(stepID), tableNames)
+	err := d.sqlWriteQuery(ctx, insertQuery)
+	if err != nil {
+		return "", err
+	}
+	return WorkflowVerifyConstraintsStepId(id), nil
+}
+
+func (d *doltWorkflowManager) writeWorkflowSchemaUnchangedStepRow(ctx *sql.Context, stepID WorkflowStepId, tableName, compareTo string) (WorkflowSchemaUnchangedStepId, error) {
+	id, insertQuery := d.insertIntoWorkflowSchemaUnchangedStepsTableQuery(string(stepID), tableName, compareTo)
+	err := d.sqlWriteQuery(ctx, insertQuery)
+	if err != nil {
+		return "", err
+	}
+	return WorkflowSchemaUnchangedStepId(id), nil
+}
+
+func (d *doltWorkflowManager) writeWorkflowDiffBudgetStepRow(ctx *sql.Context, stepID WorkflowStepId, tableName, compareTo string, maxRowsAdded, maxRowsDeleted, maxRowsModified *int64) (WorkflowDiffBudgetStepId, error) {
+	id, insertQuery := d.insertIntoWorkflowDiffBudgetStepsTableQuery(string(stepID), tableName, compareTo, maxRowsAdded, maxRowsDeleted, maxRowsModified)
+	err := d.sqlWriteQuery(ctx, insertQuery)
+	if err != nil {
+		return "", err
+	}
+	return WorkflowDiffBudgetStepId(id), nil
+}
+
+// writeWorkflowStepFromConfig writes the step row for |step| and the rows of its step type.
+func (d *doltWorkflowManager) writeWorkflowStepFromConfig(ctx *sql.Context, jobID WorkflowJobId, step Step, stepOrder int) error {
+	stepType, err := step.stepType()
+	if err != nil {
+		return err
+	}
+
+	stepID, err := d.writeWorkflowStepRow(ctx, jobID, step.Name.Value, stepOrder, stepType)
+	if err != nil {
+		return err
+	}
+
+	switch stepType {
+	case WorkflowStepTypeSavedQuery:
+		resultType := WorkflowSavedQueryExpectedResultsTypeUnspecified
+		if step.ExpectedColumns.Value != "" || step.ExpectedRows.Value != "" {
+			resultType = WorkflowSavedQueryExpectedResultsTypeRowColumnCount
+		}
+
+		savedQueryStepID, err := d.writeWorkflowSavedQueryStepRow(ctx, stepID, step.SavedQueryName.Value, resultType)
+		if err != nil {
+			return err
+		}
+
+		if resultType == WorkflowSavedQueryExpectedResultsTypeRowColumnCount {
+			expectedColumnComparisonType, expectedColumnCount, err := d.parseSavedQueryExpectedResultString(step.ExpectedColumns.Value)
+			if err != nil {
+				return err
+			}
+
+			expectedRowComparisonType, expectedRowCount, err := d.parseSavedQueryExpectedResultString(step.ExpectedRows.Value)
+			if err != nil {
+				return err
+			}
+
+			_, err = d.writeWorkflowSavedQueryStepExpectedRowColumnResultRow(ctx, savedQueryStepID, expectedColumnComparisonType, expectedRowComparisonType, expectedColumnCount, expectedRowCount)
+			if err != nil {
+				return err
+			}
+		}
+	case WorkflowStepTypeSqlAssertion:
+		expectedResults, err := expectedResultsToJson(step.SqlAssertion.ExpectedResults)
+		if err != nil {
+			return err
+		}
+		_, err = d.writeWorkflowSqlAssertionStepRow(ctx, stepID, step.SqlAssertion.Query.Value, expectedResults)
+		if err != nil {
+			return err
+		}
+	case WorkflowStepTypeVerifyConstraints:
+		tableNames := make([]string, len(step.VerifyConstraints.Tables))
+		for i, t := range step.VerifyConstraints.Tables {
+			tableNames[i] = t.Value
+		}
+		b, err := json.Marshal(tableNames)
+		if err != nil {
+			return err
+		}
+		_, err = d.writeWorkflowVerifyConstraintsStepRow(ctx, stepID, string(b))
+		if err != nil {
+			return err
+		}
+	case WorkflowStepTypeSchemaUnchanged:
+		_, err = d.writeWorkflowSchemaUnchangedStepRow(ctx, stepID, step.SchemaUnchanged.Table.Value, step.SchemaUnchanged.CompareTo.Value)
+		if err != nil {
+			return err
+		}
+	case WorkflowStepTypeDiffBudget:
+		budget := step.DiffBudget
+		maxes := make([]*int64, 3)
+		for i, n := range []yaml.Node{budget.MaxRowsAdded, budget.MaxRowsDeleted, budget.MaxRowsModified} {
+			if n.Value == "" {
+				continue
+			}
+			m, err := parseDiffBudgetMax(n.Value)
+			if err != nil {
+				return err
+			}
+			maxes[i] = &m
+		}
+		_, err = d.writeWorkflowDiffBudgetStepRow(ctx, stepID, budget.Table.Value, budget.CompareTo.Value, maxes[0], maxes[1], maxes[2])
+		if err != nil {
+			return err
+		}
+	default:
+		return ErrUnknownWorkflowStepType
+	}
+
+	return nil
+}
+
+// stepConfig returns the Step config for a stored step which is not a saved query step.
+func (d *doltWorkflowManager) stepConfig(ctx *sql.Context, stp *WorkflowStep) (Step, error) {
+	step := Step{Name: newScalarDoubleQuotedYamlNode(stp.Name)}
+
+	switch stp.StepType {
+	case WorkflowStepTypeSqlAssertion:
+		s, err := d.getWorkflowSqlAssertionStepByStepId(ctx, *stp.Id)
+		if err != nil {
+			return Step{}, err
+		}
+		expectedResults, err := expectedResultsFromJson(s.ExpectedResults)
+		if err != nil {
+			return Step{}, err
+		}
+		step.SqlAssertion = &SqlAssertion{
+			Query:           newScalarDoubleQuotedYamlNode(s.Query),
+			ExpectedResults: expectedResults,
+		}
+	case WorkflowStepTypeVerifyConstraints:
+		s, err := d.getWorkflowVerifyConstraintsStepByStepId(ctx, *stp.Id)
+		if err != nil {
+			return Step{}, err
+		}
+		tableNames, err := tableNamesFromJson(s.TableNames)
+		if err != nil {
+			return Step{}, err
+		}
+		step.VerifyConstraints = &VerifyConstraints{}
+		for _, t := range tableNames {
+			step.VerifyConstraints.Tables = append(step.VerifyConstraints.Tables, newScalarDoubleQuotedYamlNode(t))
+		}
+	case WorkflowStepTypeSchemaUnchanged:
+		s, err := d.getWorkflowSchemaUnchangedStepByStepId(ctx, *stp.Id)
+		if err != nil {
+			return Step{}, err
+		}
+		step.SchemaUnchanged = &SchemaUnchanged{Table: newScalarDoubleQuotedYamlNode(s.TableName)}
+		if s.CompareTo != "" {
+			step.SchemaUnchanged.CompareTo = newScalarDoubleQuotedYamlNode(s.CompareTo)
+		}
+	case WorkflowStepTypeDiffBudget:
+		s, err := d.getWorkflowDiffBudgetStepByStepId(ctx, *stp.Id)
+		if err != nil {
+			return Step{}, err
+		}
+		step.DiffBudget = &DiffBudget{}
+		if s.TableName != "" {
+			step.DiffBudget.Table = newScalarDoubleQuotedYamlNode(s.TableName)
+		}
+		if s.CompareTo != "" {
+			step.DiffBudget.CompareTo = newScalarDoubleQuotedYamlNode(s.CompareTo)
+		}
+		if s.MaxRowsAdded != nil {
+			step.DiffBudget.MaxRowsAdded = newScalarDoubleQuotedYamlNode(strconv.FormatInt(*s.MaxRowsAdded, 10))
+		}
+		if s.MaxRowsDeleted != nil {
+			step.DiffBudget.MaxRowsDeleted = newScalarDoubleQuotedYamlNode(strconv.FormatInt(*s.MaxRowsDeleted, 10))
+		}
+		if s.MaxRowsModified != nil {
+			step.DiffBudget.MaxRowsModified = newScalarDoubleQuotedYamlNode(strconv.FormatInt(*s.MaxRowsModified, 10))
+		}
+	default:
+		return Step{}, ErrUnknownWorkflowStepType
+	}
+
+	return step, nil
+}
+
+// expectedResultsToJson encodes the expected rows of a sql assertion as a JSON array of rows. Null values are
+// encoded as JSON nulls, all other values as strings.
+func expectedResultsToJson(rows [][]yaml.Node) (string, error) {
+	encoded := make([][]*string, len(rows))
+	for i, row := range rows {
+		encoded[i] = make([]*string, len(row))
+		for j, val := range row {
+			if val.Tag == yamlNullTag {
+				continue
+			}
+			v := val.Value
+			encoded[i][j] = &v
+		}
+	}
+	b, err := json.Marshal(encoded)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func expectedResultsFromJson(str string) ([][]yaml.Node, error) {
+	rows, err := decodeExpectedResults(str)
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([][]yaml.Node, len(rows))
+	for i, row := range rows {
+		nodes[i] = make([]yaml.Node, len(row))
+		for j, val := range row {
+			if val == nil {
+				nodes[i][j] = yaml.Node{Kind: yaml.ScalarNode, Tag: yamlNullTag, Value: "null"}
+			} else {
+				nodes[i][j] = newScalarDoubleQuotedYamlNode(*val)
+			}
+		}
+	}
+	return nodes, nil
+}
+
+func decodeExpectedResults(str string) ([][]*string, error) {
+	rows := make([][]*string, 0)
+	if str == "" {
+		return rows, nil
+	}
+	err := json.Unmarshal([]byte(str), &rows)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sql assertion expected results: %w", err)
+	}
+	return rows, nil
+}
+
+func tableNamesFromJson(str string) ([]string, error) {
+	tableNames := make([]string, 0)
+	if str == "" {
+		return tableNames, nil
+	}
+	err := json.Unmarshal([]byte(str), &tableNames)
+	if err != nil {
+		return nil, fmt.Errorf("invalid verify constraints table names: %w", err)
+	}
+	return tableNames, nil
+}
@@ -0,0 +1,658 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dolt_ci
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+)
+
+var ErrSavedQueryNotFound = errors.New("saved query not found")
+
+// WorkflowStepRunResult is the outcome of running a single workflow step.
+type WorkflowStepRunResult struct {
+	JobName   string
+	StepName  string
+	StepOrder int
+	StepType  WorkflowStepType
+	// Failures holds a description of every assertion that did not hold, or the
+	// error that prevented the step from running. A step with no failures passed.
+	Failures  []string
+	StartedAt time.Time
+	EndedAt   time.Time
+}
+
+// Passed returns whether the step ran and all of its assertions held.
+func (r *WorkflowStepRunResult) Passed() bool {
+	return len(r.Failures) == 0
+}
+
+// WorkflowRunResult is the outcome of running every step of every job in a workflow.
+type WorkflowRunResult struct {
+	WorkflowName string
+	// Ref is the branch, tag or commit the workflow ran against. An empty ref
+	// means the current branch of the session.
+	Ref       string
+	Steps     []*WorkflowStepRunResult
+	StartedAt time.Time
+	EndedAt   time.Time
+}
+
+// Passed returns whether all steps of the workflow passed.
+func (r *WorkflowRunResult) Passed() bool {
+	for _, s := range r.Steps {
+		if !s.Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// runnableStep is a workflow step with all of its stored configuration loaded, ready to be run.
+type runnableStep struct {
+	jobName               string
+	step                  *WorkflowStep
+	savedQueryStep        *WorkflowSavedQueryStep
+	expectedResults       *WorkflowSavedQueryExpectedRowColumnResult
+	sqlAssertionStep      *WorkflowSqlAssertionStep
+	verifyConstraintsStep *WorkflowVerifyConstraintsStep
+	schemaUnchangedStep   *WorkflowSchemaUnchangedStep
+	diffBudgetStep        *WorkflowDiffBudgetStep
+}
+
+func (d *doltWorkflowManager) selectSavedQueryFromQueryCatalogQuery(savedQueryName string) string {
+	return fmt.Sprintf("select `%s` from %s where `%s` = '%s' limit 1;", doltdb.QueryCatalogQueryCol, doltdb.DoltQueryCatalogTableName, doltdb.QueryCatalogIdCol, savedQueryName)
+}
+
+// loadRunnableSteps reads every job and step of the workflow from the dolt_ci tables and returns them ordered by job
+// name and step order.
+func (d *doltWorkflowManager) loadRunnableSteps(ctx *sql.Context, workflowName WorkflowName) ([]*runnableStep, error) {
+	jobs, err := d.listWorkflowJobsByWorkflowName(ctx, workflowName)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].Name < jobs[j].Name
+	})
+
+	runnable := make([]*runnableStep, 0)
+	for _, job := range jobs {
+		steps, err := d.listWorkflowStepsByJobId(ctx, *job.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		sort.Slice(steps, func(i, j int) bool {
+			return steps[i].StepOrder < steps[j].StepOrder
+		})
+
+		for _, step := range steps {
+			rs := &runnableStep{jobName: job.Name, step: step}
+			switch step.StepType {
+			case WorkflowStepTypeSavedQuery:
+				rs.savedQueryStep, err = d.getWorkflowSavedQueryStepByStepId(ctx, *step.Id)
+				if err != nil {
+					return nil, err
+				}
+				if rs.savedQueryStep == nil {
+					return nil, fmt.Errorf("saved query step not found for step: %s", step.Name)
+				}
+				if rs.savedQueryStep.SavedQueryExpectedResultsType == WorkflowSavedQueryExpectedResultsTypeRowColumnCount {
+					rs.expectedResults, err = d.getWorkflowSavedQueryExpectedRowColumnResultBySavedQueryStepId(ctx, *rs.savedQueryStep.Id)
+				}
+			case WorkflowStepTypeSqlAssertion:
+				rs.sqlAssertionStep, err = d.getWorkflowSqlAssertionStepByStepId(ctx, *step.Id)
+			case WorkflowStepTypeVerifyConstraints:
+				rs.verifyConstraintsStep, err = d.getWorkflowVerifyConstraintsStepByStepId(ctx, *step.Id)
+			case WorkflowStepTypeSchemaUnchanged:
+				rs.schemaUnchangedStep, err = d.getWorkflowSchemaUnchangedStepByStepId(ctx, *step.Id)
+			case WorkflowStepTypeDiffBudget:
+				rs.diffBudgetStep, err = d.getWorkflowDiffBudgetStepByStepId(ctx, *step.Id)
+			}
+			if err != nil {
+				return nil, err
+			}
+			runnable = append(runnable, rs)
+		}
+	}
+
+	return runnable, nil
+}
+
+// listWorkflowNamesForPushToBranch returns the names of the workflows that should run when |branch| is pushed to.
+// Workflows whose push event has no branch triggers run for every branch. Branch triggers may be glob patterns, which
+// are matched case-insensitively, as branch triggers are stored. The workflows with an invalid pattern are returned
+// separately, along with the error of their pattern.
+func (d *doltWorkflowManager) listWorkflowNamesForPushToBranch(ctx *sql.Context, branch string) ([]WorkflowName, map[WorkflowName]error, error) {
+	workflows, err := d.listWorkflows(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sort.Slice(workflows, func(i, j int) bool {
+		return *workflows[i].Name < *workflows[j].Name
+	})
+
+	lowered := strings.ToLower(branch)
+	names := make([]WorkflowName, 0)
+	invalid := make(map[WorkflowName]error)
+	for _, workflow := range workflows {
+		events, err := d.listWorkflowEventsByWorkflowNameWhereEventTypeIsPush(ctx, *workflow.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		matched := false
+	events:
+		for _, event := range events {
+			triggers, err := d.listWorkflowEventTriggersByEventIdWhereEventTriggerTypeIsBranches(ctx, *event.Id)
+			if err != nil {
+				return nil, nil, err
+			}
+			if len(triggers) == 0 {
+				matched = true
+				break
+			}
+
+			for _, trigger := range triggers {
+				branches, err := d.listWorkflowEventTriggerBranchesByEventTriggerId(ctx, *trigger.Id)
+				if err != nil {
+					return nil, nil, err
+				}
+				for _, b := range branches {
+					ok, err := matchBranchPattern(b.Branch, lowered)
+					if err != nil {
+						invalid[*workflow.Name] = err
+						matched = false
+						break events
+					} else if ok {
+						matched = true
+					}
+				}
+			}
+		}
+
+		if matched {
+			names = append(names, *workflow.Name)
+		}
+	}
+
+	return names, invalid, nil
+}
+
+// matchBranchPattern returns whether |branch| matches the branch trigger |pattern|, case-insensitively.
+func matchBranchPattern(pattern, branch string) (bool, error) {
+	ok, err := path.Match(strings.ToLower(pattern), strings.ToLower(branch))
+	if err != nil {
+		return false, fmt.Errorf("invalid push branch pattern '%s': %w", pattern, err)
+	}
+	return ok, nil
+}
+
+// useDatabase switches the session to the database given.
+func (d *doltWorkflowManager) useDatabase(ctx *sql.Context, dbName string) error {
+	return d.sqlWriteQuery(ctx, fmt.Sprintf("use %s;", sql.QuoteIdentifier(dbName)))
+}
+
+// runWorkflow loads the workflow named and runs each of its steps against |ref|, or against the current branch if
+// |ref| is empty. Step failures are recorded in the result, not returned as errors.
+func (d *doltWorkflowManager) runWorkflow(ctx *sql.Context, workflowName, ref string) (result *WorkflowRunResult, err error) {
+	workflow, err := d.getWorkflow(ctx, workflowName)
+	if err != nil {
+		return nil, err
+	}
+
+	steps, err := d.loadRunnableSteps(ctx, *workflow.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if ref != "" {
+		dbName := ctx.GetCurrentDatabase()
+		err = d.useDatabase(ctx, fmt.Sprintf("%s/%s", dbName, ref))
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			rerr := d.useDatabase(ctx, dbName)
+			if err == nil {
+				err = rerr
+			}
+		}()
+	}
+
+	result = &WorkflowRunResult{
+		WorkflowName: string(*workflow.Name),
+		Ref:          ref,
+		Steps:        make([]*WorkflowStepRunResult, 0, len(steps)),
+		StartedAt:    time.Now(),
+	}
+
+	for _, rs := range steps {
+		result.Steps = append(result.Steps, d.runStep(ctx, rs))
+	}
+
+	result.EndedAt = time.Now()
+	return result, nil
+}
+
+func (d *doltWorkflowManager) runStep(ctx *sql.Context, rs *runnableStep) *WorkflowStepRunResult {
+	sr := &WorkflowStepRunResult{
+		JobName:   rs.jobName,
+		StepName:  rs.step.Name,
+		StepOrder: rs.step.StepOrder,
+		StepType:  rs.step.StepType,
+		StartedAt: time.Now(),
+	}
+
+	var failures []string
+	var err error
+	switch rs.step.StepType {
+	case WorkflowStepTypeSavedQuery:
+		failures, err = d.runSavedQueryStep(ctx, rs.savedQueryStep, rs.expectedResults)
+	case WorkflowStepTypeSqlAssertion:
+		failures, err = d.runSqlAssertionStep(ctx, rs.sqlAssertionStep)
+	case WorkflowStepTypeVerifyConstraints:
+		failures, err = d.runVerifyConstraintsStep(ctx, rs.verifyConstraintsStep)
+	case WorkflowStepTypeSchemaUnchanged:
+		failures, err = d.runSchemaUnchangedStep(ctx, rs.schemaUnchangedStep)
+	case WorkflowStepTypeDiffBudget:
+		failures, err = d.runDiffBudgetStep(ctx, rs.diffBudgetStep)
+	default:
+		err = ErrUnknownWorkflowStepType
+	}
+
+	if err != nil {
+		failures = append(failures, err.Error())
+	}
+
+	sr.Failures = failures
+	sr.EndedAt = time.Now()
+	return sr
+}
+
+func (d *doltWorkflowManager) getSavedQuery(ctx *sql.Context, savedQueryName string) (string, error) {
+	var query string
+	err := d.sqlReadQuery(ctx, d.selectSavedQueryFromQueryCatalogQuery(savedQueryName), func(_ *sql.Context, cvs columnValues) error {
+		for _, cv := range cvs {
+			if cv != nil && cv.ColumnName == doltdb.QueryCatalogQueryCol {
+				query = cv.Value
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if sql.ErrTableNotFound.Is(err) {
+			return "", fmt.Errorf("%w: %s", ErrSavedQueryNotFound, savedQueryName)
+		}
+		return "", err
+	}
+	if query == "" {
+		return "", fmt.Errorf("%w: %s", ErrSavedQueryNotFound, savedQueryName)
+	}
+	return query, nil
+}
+
+// runSavedQueryStep runs the saved query and checks the result against the expected row and column counts, if any.
+func (d *doltWorkflowManager) runSavedQueryStep(ctx *sql.Context, sqs *WorkflowSavedQueryStep, expected *WorkflowSavedQueryExpectedRowColumnResult) ([]string, error) {
+	query, err := d.getSavedQuery(ctx, sqs.SavedQueryName)
+	if err != nil {
+		return nil, err
+	}
+
+	sch, rowIter, _, err := d.queryFunc(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("saved query '%s' failed: %w", sqs.SavedQueryName, err)
+	}
+	rows, err := sql.RowIterToRows(ctx, rowIter)
+	if err != nil {
+		return nil, fmt.Errorf("saved query '%s' failed: %w", sqs.SavedQueryName, err)
+	}
+
+	if sqs.SavedQueryExpectedResultsType != WorkflowSavedQueryExpectedResultsTypeRowColumnCount || expected == nil {
+		return nil, nil
+	}
+
+	failures := make([]string, 0)
+	if f := d.checkRowColumnCount("column", expected.ExpectedColumnCountComparisonType, expected.ExpectedColumnCount, int64(len(sch))); f != "" {
+		failures = append(failures, f)
+	}
+	if f := d.checkRowColumnCount("row", expected.ExpectedRowCountComparisonType, expected.ExpectedRowCount, int64(len(rows))); f != "" {
+		failures = append(failures, f)
+	}
+	return failures, nil
+}
+
+// maxReportedRows is the number of missing or unexpected rows reported for a failed sql assertion step.
+const maxReportedRows = 10
+
+// defaultCompareTo is the revision schema unchanged and diff budget steps compare against when none is configured.
+const defaultCompareTo = "HEAD~1"
+
+// runSqlAssertionStep runs the step's query and compares its result set to the expected rows, ignoring row order.
+func (d *doltWorkflowManager) runSqlAssertionStep(ctx *sql.Context, s *WorkflowSqlAssertionStep) ([]string, error) {
+	expected, err := decodeExpectedResults(s.ExpectedResults)
+	if err != nil {
+		return nil, err
+	}
+
+	sch, rowIter, _, err := d.queryFunc(ctx, s.Query)
+	if err != nil {
+		return nil, fmt.Errorf("sql assertion query failed: %w", err)
+	}
+	rows, err := sql.RowIterToRows(ctx, rowIter)
+	if err != nil {
+		return nil, fmt.Errorf("sql assertion query failed: %w", err)
+	}
+
+	actual := make([][]*string, len(rows))
+	for i, row := range rows {
+		actual[i] = make([]*string, len(row))
+		for j, val := range row {
+			if val == nil {
+				continue
+			}
+			str, err := toUtf8StringValue(sch[j], val)
+			if err != nil {
+				return nil, err
+			}
+			actual[i][j] = &str
+		}
+	}
+
+	return compareResultSets(expected, actual), nil
+}
+
+// compareResultSets returns a description of every difference between the |expected| and |actual| rows, ignoring
+// row order.
+func compareResultSets(expected, actual [][]*string) []string {
+	failures := make([]string, 0)
+	if len(expected) != len(actual) {
+		failures = append(failures, fmt.Sprintf("expected %d rows, got %d", len(expected), len(actual)))
+	}
+
+	remaining := make(map[string]int)
+	for _, row := range expected {
+		remaining[formatResultRow(row)]++
+	}
+
+	unexpected := make([]string, 0)
+	for _, row := range actual {
+		key := formatResultRow(row)
+		if remaining[key] > 0 {
+			remaining[key]--
+		} else {
+			unexpected = append(unexpected, key)
+		}
+	}
+
+	missing := make([]string, 0)
+	for _, row := range expected {
+		key := formatResultRow(row)
+		if remaining[key] > 0 {
+			remaining[key]--
+			missing = append(missing, key)
+		}
+	}
+
+	failures = append(failures, reportRows("missing row", missing)...)
+	failures = append(failures, reportRows("unexpected row", unexpected)...)
+	return failures
+}
+
+func reportRows(kind string, rows []string) []string {
+	reported := make([]string, 0, maxReportedRows+1)
+	for i, row := range rows {
+		if i == maxReportedRows {
+			reported = append(reported, fmt.Sprintf("... and %d more", len(rows)-maxReportedRows))
+			break
+		}
+		reported = append(reported, fmt.Sprintf("%s: %s", kind, row))
+	}
+	return reported
+}
+
+// formatResultRow formats a row as a JSON array, in which NULL values are nulls and all others are strings.
+func formatResultRow(row []*string) string {
+	b, err := json.Marshal(row)
+	if err != nil {
+		return fmt.Sprintf("%v", row)
+	}
+	return string(b)
+}
+
+// runVerifyConstraintsStep checks every row of the step's tables, or of all tables, for constraint violations,
+// without recording the violations found.
+func (d *doltWorkflowManager) runVerifyConstraintsStep(ctx *sql.Context, s *WorkflowVerifyConstraintsStep) ([]string, error) {
+	tableNames, err := tableNamesFromJson(s.TableNames)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tableNames) == 0 {
+		ok, err := d.verifyConstraints(ctx, "")
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return []string{"constraint violations found"}, nil
+		}
+		return nil, nil
+	}
+
+	failures := make([]string, 0)
+	for _, tableName := range tableNames {
+		ok, err := d.verifyConstraints(ctx, tableName)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			failures = append(failures, fmt.Sprintf("constraint violations found in table %s", tableName))
+		}
+	}
+	return failures, nil
+}
+
+// verifyConstraints returns whether |tableName|, or all tables if it is empty, has no constraint violations.
+func (d *doltWorkflowManager) verifyConstraints(ctx *sql.Context, tableName string) (bool, error) {
+	args := "'--all', '--output-only'"
+	if tableName != "" {
+		args += ", " + quoteSqlString(tableName)
+	}
+
+	_, rowIter, _, err := d.queryFunc(ctx, fmt.Sprintf("call dolt_verify_constraints(%s);", args))
+	if err != nil {
+		return false, fmt.Errorf("dolt_verify_constraints failed: %w", err)
+	}
+	rows, err := sql.RowIterToRows(ctx, rowIter)
+	if err != nil {
+		return false, fmt.Errorf("dolt_verify_constraints failed: %w", err)
+	}
+	if len(rows) != 1 || len(rows[0]) != 1 {
+		return false, fmt.Errorf("unexpected result from dolt_verify_constraints")
+	}
+
+	violations, err := toInt64(rows[0][0])
+	if err != nil {
+		return false, err
+	}
+	return violations == 0, nil
+}
+
+// runSchemaUnchangedStep checks that the schema of the step's table is the same as at the revision it compares to.
+func (d *doltWorkflowManager) runSchemaUnchangedStep(ctx *sql.Context, s *WorkflowSchemaUnchangedStep) ([]string, error) {
+	compareTo := s.CompareTo
+	if compareTo == "" {
+		compareTo = defaultCompareTo
+	}
+
+	query := fmt.Sprintf("select count(*) from dolt_schema_diff(%s, 'WORKING', %s);", quoteSqlString(compareTo), quoteSqlString(s.TableName))
+	_, rowIter, _, err := d.queryFunc(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("dolt_schema_diff failed: %w", err)
+	}
+	rows, err := sql.RowIterToRows(ctx, rowIter)
+	if err != nil {
+		return nil, fmt.Errorf("dolt_schema_diff failed: %w", err)
+	}
+	if len(rows) != 1 || len(rows[0]) != 1 {
+		return nil, fmt.Errorf("unexpected result from dolt_schema_diff")
+	}
+
+	changes, err := toInt64(rows[0][0])
+	if err != nil {
+		return nil, err
+	}
+	if changes > 0 {
+		return []string{fmt.Sprintf("schema of table %s changed since %s", s.TableName, compareTo)}, nil
+	}
+	return nil, nil
+}
+
+// runDiffBudgetStep checks the number of rows added, deleted and modified since the revision the step compares to
+// against the step's maximums.
+func (d *doltWorkflowManager) runDiffBudgetStep(ctx *sql.Context, s *WorkflowDiffBudgetStep) ([]string, error) {
+	compareTo := s.CompareTo
+	if compareTo == "" {
+		compareTo = defaultCompareTo
+	}
+
+	args := fmt.Sprintf("%s, 'WORKING'", quoteSqlString(compareTo))
+	if s.TableName != "" {
+		args += ", " + quoteSqlString(s.TableName)
+	}
+
+	query := fmt.Sprintf("select table_name, rows_added, rows_deleted, rows_modified from dolt_diff_stat(%s);", args)
+	_, rowIter, _, err := d.queryFunc(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("dolt_diff_stat failed: %w", err)
+	}
+	rows, err := sql.RowIterToRows(ctx, rowIter)
+	if err != nil {
+		return nil, fmt.Errorf("dolt_diff_stat failed: %w", err)
+	}
+
+	// added, deleted, modified
+	var totals [3]int64
+	for _, row := range rows {
+		// changes to the workflows themselves do not count against the budget
+		if tableName, ok := row[0].(string); ok && doltdb.IsDoltCITable(tableName) {
+			continue
+		}
+		for i := range totals {
+			n, err := toInt64(row[i+1])
+			if err != nil {
+				return nil, err
+			}
+			totals[i] += n
+		}
+	}
+
+	failures := make([]string, 0)
+	for i, budget := range []struct {
+		kind string
+		max  *int64
+	}{
+		{"added", s.MaxRowsAdded},
+		{"deleted", s.MaxRowsDeleted},
+		{"modified", s.MaxRowsModified},
+	} {
+		if budget.max != nil && totals[i] > *budget.max {
+			failures = append(failures, fmt.Sprintf("%d rows %s since %s, more than the budget of %d", totals[i], budget.kind, compareTo, *budget.max))
+		}
+	}
+	return failures, nil
+}
+
+// toInt64 converts an integer result value to an int64. NULL is converted to 0.
+func toInt64(val interface{}) (int64, error) {
+	switch v := val.(type) {
+	case nil:
+		return 0, nil
+	case int:
+		return int64(v), nil
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case uint8:
+		return int64(v), nil
+	case uint16:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case uint64:
+		return int64(v), nil
+	default:
+		return strconv.ParseInt(fmt.Sprint(v), 10, 64)
+	}
+}
+
+// checkRowColumnCount returns a description of the failure if |actual| does not satisfy the comparison, or an
+// empty string if it does.
+func (d *doltWorkflowManager) checkRowColumnCount(kind string, comparisonType WorkflowSavedQueryExpectedRowColumnComparisonType, expected, actual int64) string {
+	if comparisonType == WorkflowSavedQueryExpectedRowColumnComparisonTypeUnspecified {
+		return ""
+	}
+	ok, err := CompareRowColumnCount(comparisonType, expected, actual)
+	if err != nil {
+		return err.Error()
+	}
+	if ok {
+		return ""
+	}
+	expectedStr, err := d.toSavedQueryExpectedResultString(comparisonType, expected)
+	if err != nil {
+		return err.Error()
+	}
+	return fmt.Sprintf("expected %s count %s, got %d", kind, expectedStr, actual)
+}
+
+// CompareRowColumnCount reports whether |actual| satisfies the comparison against |expected|.
+func CompareRowColumnCount(comparisonType WorkflowSavedQueryExpectedRowColumnComparisonType, expected, actual int64) (bool, error) {
+	switch comparisonType {
+	case WorkflowSavedQueryExpectedRowColumnComparisonTypeEquals:
+		return actual == expected, nil
+	case WorkflowSavedQueryExpectedRowColumnComparisonTypeNotEquals:
+		return actual != expected, nil
+	case WorkflowSavedQueryExpectedRowColumnComparisonTypeLessThan:
+		return actual < expected, nil
+	case WorkflowSavedQueryExpectedRowColumnComparisonTypeGreaterThan:
+		return actual > expected, nil
+	case WorkflowSavedQueryExpectedRowColumnComparisonTypeLessThanOrEqual:
+		return actual <= expected, nil
+	case WorkflowSavedQueryExpectedRowColumnComparisonTypeGreaterThanOrEqual:
+		return actual >= expected, nil
+	case WorkflowSavedQueryExpectedRowColumnComparisonTypeUnspecified:
+		return true, nil
+	default:
+		return false, ErrUnknownWorkflowSavedQueryExpectedRowColumnComparisonType
+	}
+}
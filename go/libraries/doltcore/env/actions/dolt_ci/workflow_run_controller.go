@@ -0,0 +1,304 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dolt_ci
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// Automatic workflow runs are the ability of a running SQL server to
+// run the dolt ci workflows stored in a database when one of its
+// branches is updated. They work as follows:
+//
+// A WorkflowRunController is created for a running SQL engine. Post
+// commit hooks are installed on every database in the engine's
+// DoltDatabaseProvider. When a branch head moves, through a commit, a
+// merge, a reset, a dolt_push to the server or a push to its
+// remotesapi endpoint, the hook queues the new head for a single
+// background thread. That thread reads the workflows stored at the new
+// head, runs the ones whose push event matches the branch, and records
+// every step with doltdb.AddWorkflowRunSteps. Runs are stored outside
+// of every branch and are shown by the dolt_ci_workflow_runs system
+// table.
+//
+// At most maxPendingWorkflowRuns updates are queued. Commit hooks never
+// wait for the background thread: updates arriving when the queue is
+// full, or after the thread has stopped, are dropped with a warning.
+
+const workflowRunEventTypePush = "push"
+
+const (
+	workflowRunStepStatusPassed = "passed"
+	workflowRunStepStatusFailed = "failed"
+)
+
+// maxPendingWorkflowRuns is the number of branch updates which can be
+// waiting for their workflows to run.
+const maxPendingWorkflowRuns = 256
+
+type WorkflowRunController struct {
+	workCh chan workflowRunWork
+	// Closed when the background thread exits.
+	done   chan struct{}
+	lgr    *logrus.Logger
+	client sql.Client
+
+	ctxF      func(context.Context) (*sql.Context, error)
+	queryFunc queryFunc
+}
+
+// NewWorkflowRunController returns a controller which runs workflows
+// in sessions authenticated as |client|. The account must be able to
+// read every database the server serves.
+func NewWorkflowRunController(lgr *logrus.Logger, client sql.Client) *WorkflowRunController {
+	return &WorkflowRunController{
+		workCh: make(chan workflowRunWork, maxPendingWorkflowRuns),
+		done:   make(chan struct{}),
+		lgr:    lgr,
+		client: client,
+	}
+}
+
+// Passed by a commit hook to the background thread, requesting the
+// workflows matching |branch| be run against |commit| of |dbName|.
+// The runs are recorded in |ddb|.
+type workflowRunWork struct {
+	dbName string
+	ddb    *doltdb.DoltDB
+	branch string
+	commit hash.Hash
+}
+
+// During engine initialization, this should be called to ensure the
+// background thread responsible for running workflows is running.
+// |ctxF| creates the sessions used to run workflows and |queryFunc|
+// executes their queries.
+func (c *WorkflowRunController) RunBackgroundThread(threads *sql.BackgroundThreads, ctxF func(context.Context) (*sql.Context, error), queryFunc func(ctx *sql.Context, query string) (sql.Schema, sql.RowIter, *sql.QueryFlags, error)) error {
+	c.ctxF = ctxF
+	c.queryFunc = queryFunc
+	return threads.Add("ci_workflow_run_thread", c.runBgThread)
+}
+
+func (c *WorkflowRunController) runBgThread(ctx context.Context) {
+	defer close(c.done)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case work := <-c.workCh:
+			c.doWork(ctx, work)
+		}
+	}
+}
+
+func (c *WorkflowRunController) doWork(ctx context.Context, work workflowRunWork) {
+	sqlCtx, err := c.ctxF(ctx)
+	if err != nil {
+		c.lgr.Warnf("dolt_ci: Could not create session to run workflows for %s: %v", work.dbName, err)
+		return
+	}
+	sqlCtx.Session.SetClient(c.client)
+	defer sql.SessionEnd(sqlCtx.Session)
+	sql.SessionCommandBegin(sqlCtx.Session)
+	defer sql.SessionCommandEnd(sqlCtx.Session)
+
+	wm := NewWorkflowManager("", "", c.queryFunc)
+
+	// Workflows are read from, and run against, the commit that triggered
+	// them, so later updates to the branch do not affect the run.
+	err = wm.useDatabase(sqlCtx, fmt.Sprintf("%s/%s", work.dbName, work.commit.String()))
+	if err != nil {
+		c.lgr.Warnf("dolt_ci: Could not use commit %s of %s to run workflows: %v", work.commit.String(), work.dbName, err)
+		return
+	}
+
+	names, invalid, err := wm.listWorkflowNamesForPushToBranch(sqlCtx, work.branch)
+	if err != nil {
+		if !sql.ErrTableNotFound.Is(err) {
+			c.lgr.Warnf("dolt_ci: Could not list workflows of %s: %v", work.dbName, err)
+		}
+		return
+	}
+
+	runs := make([][]doltdb.WorkflowRunStep, 0, len(names)+len(invalid))
+	for name, err := range invalid {
+		c.lgr.Warnf("dolt_ci: Could not match workflow %s to branch %s of %s: %v", name, work.branch, work.dbName, err)
+		now := time.Now()
+		runs = append(runs, []doltdb.WorkflowRunStep{newFailedWorkflowRunStep(uuid.NewString(), string(name), work, now, now, err)})
+	}
+	for _, name := range names {
+		runId := uuid.NewString()
+		start := time.Now()
+		c.lgr.Tracef("dolt_ci: Running workflow %s for branch %s of %s", name, work.branch, work.dbName)
+		result, err := wm.runWorkflow(sqlCtx, string(name), "")
+		if err != nil {
+			c.lgr.Warnf("dolt_ci: Could not run workflow %s for branch %s of %s: %v", name, work.branch, work.dbName, err)
+			runs = append(runs, []doltdb.WorkflowRunStep{newFailedWorkflowRunStep(runId, string(name), work, start, time.Now(), err)})
+			continue
+		}
+		runs = append(runs, newWorkflowRunSteps(runId, work, result))
+	}
+	if len(runs) == 0 {
+		return
+	}
+
+	// Runs are not recorded on any branch, so recording one never moves a
+	// branch and never triggers another run.
+	var steps []doltdb.WorkflowRunStep
+	for _, run := range runs {
+		steps = append(steps, run...)
+	}
+	err = work.ddb.AddWorkflowRunSteps(ctx, steps)
+	if err != nil {
+		c.lgr.Warnf("dolt_ci: Could not record workflow runs for branch %s of %s: %v", work.branch, work.dbName, err)
+	}
+}
+
+func newWorkflowRunSteps(runId string, work workflowRunWork, result *WorkflowRunResult) []doltdb.WorkflowRunStep {
+	steps := make([]doltdb.WorkflowRunStep, len(result.Steps))
+	for i, s := range result.Steps {
+		status := workflowRunStepStatusPassed
+		if !s.Passed() {
+			status = workflowRunStepStatusFailed
+		}
+		steps[i] = doltdb.WorkflowRunStep{
+			Id:             uuid.NewString(),
+			RunId:          runId,
+			WorkflowName:   result.WorkflowName,
+			EventType:      workflowRunEventTypePush,
+			Branch:         work.branch,
+			CommitHash:     work.commit.String(),
+			JobName:        s.JobName,
+			StepName:       s.StepName,
+			StepOrder:      s.StepOrder,
+			Status:         status,
+			StartedAt:      s.StartedAt.UTC(),
+			EndedAt:        s.EndedAt.UTC(),
+			FailureDetails: strings.Join(s.Failures, "\n"),
+		}
+	}
+	return steps
+}
+
+// newFailedWorkflowRunStep returns the record of a workflow which
+// could not be run at all, failing with |err|.
+func newFailedWorkflowRunStep(runId, workflowName string, work workflowRunWork, start, end time.Time, err error) doltdb.WorkflowRunStep {
+	return doltdb.WorkflowRunStep{
+		Id:             uuid.NewString(),
+		RunId:          runId,
+		WorkflowName:   workflowName,
+		EventType:      workflowRunEventTypePush,
+		Branch:         work.branch,
+		CommitHash:     work.commit.String(),
+		Status:         workflowRunStepStatusFailed,
+		StartedAt:      start.UTC(),
+		EndedAt:        end.UTC(),
+		FailureDetails: err.Error(),
+	}
+}
+
+// During engine initialization, called on the original set of
+// databases to install the commit hooks which trigger workflow runs.
+func (c *WorkflowRunController) ApplyCommitHooks(ctx context.Context, mrEnv *env.MultiRepoEnv, dbs ...dsess.SqlDatabase) error {
+	for _, db := range dbs {
+		denv := mrEnv.GetEnv(db.Name())
+		if denv == nil {
+			continue
+		}
+		denv.DoltDB(ctx).PrependCommitHooks(ctx, c.newCommitHook(db.Name()))
+	}
+	return nil
+}
+
+func (c *WorkflowRunController) InitDatabaseHook() sqle.InitDatabaseHook {
+	return func(ctx *sql.Context, _ *sqle.DoltDatabaseProvider, name string, env *env.DoltEnv, _ dsess.SqlDatabase) error {
+		env.DoltDB(ctx).PrependCommitHooks(ctx, c.newCommitHook(name))
+		return nil
+	}
+}
+
+func (c *WorkflowRunController) newCommitHook(name string) *workflowRunCommitHook {
+	return &workflowRunCommitHook{c: c, name: name}
+}
+
+// The doltdb.CommitHook which watches for branch updates and requests
+// workflow runs.
+type workflowRunCommitHook struct {
+	c    *WorkflowRunController
+	name string
+}
+
+var _ doltdb.CommitHook = (*workflowRunCommitHook)(nil)
+
+func (h *workflowRunCommitHook) Execute(ctx context.Context, ds datas.Dataset, db *doltdb.DoltDB) (func(context.Context) error, error) {
+	addr, ok := ds.MaybeHeadAddr()
+	if !ok || !ref.IsRef(ds.ID()) {
+		return nil, nil
+	}
+
+	dref, err := ref.Parse(ds.ID())
+	if err != nil {
+		return nil, nil
+	}
+
+	// Only updates to the database's own branches are pushes. Remote
+	// tracking branches move on fetch, and tags are not branches.
+	branch, ok := dref.(ref.BranchRef)
+	if !ok {
+		return nil, nil
+	}
+
+	select {
+	case <-h.c.done:
+		h.c.lgr.Warnf("dolt_ci: Not running workflows for branch %s of %s, workflow runs have stopped", branch.GetPath(), h.name)
+		return nil, nil
+	default:
+	}
+
+	select {
+	case h.c.workCh <- workflowRunWork{dbName: h.name, ddb: db, branch: branch.GetPath(), commit: addr}:
+	default:
+		h.c.lgr.Warnf("dolt_ci: Not running workflows for branch %s of %s, %d branch updates are already waiting for their workflows to run", branch.GetPath(), h.name, maxPendingWorkflowRuns)
+	}
+	return nil, nil
+}
+
+func (h *workflowRunCommitHook) HandleError(ctx context.Context, err error) error {
+	return nil
+}
+
+func (h *workflowRunCommitHook) SetLogger(ctx context.Context, wr io.Writer) error {
+	return nil
+}
+
+func (h *workflowRunCommitHook) ExecuteForWorkingSets() bool {
+	return false
+}
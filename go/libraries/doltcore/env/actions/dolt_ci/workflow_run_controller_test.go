@@ -0,0 +1,116 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dolt_ci
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestWorkflowRunSteps(t *testing.T) {
+	work := workflowRunWork{dbName: "MyDb", branch: "main", commit: hash.Of([]byte("commit"))}
+	now := time.Date(2025, 3, 1, 12, 30, 0, 123456000, time.UTC)
+
+	t.Run("RecordsSteps", func(t *testing.T) {
+		result := &WorkflowRunResult{
+			WorkflowName: "workflow",
+			Steps: []*WorkflowStepRunResult{
+				{JobName: "job", StepName: "passes", StepOrder: 1, StartedAt: now, EndedAt: now},
+				{JobName: "job", StepName: "fails", StepOrder: 2, Failures: []string{"first", "second"}, StartedAt: now, EndedAt: now},
+			},
+		}
+		steps := newWorkflowRunSteps("run", work, result)
+		require.Len(t, steps, 2)
+		assert.NotEmpty(t, steps[0].Id)
+		assert.NotEqual(t, steps[0].Id, steps[1].Id)
+		steps[0].Id = ""
+		assert.Equal(t, doltdb.WorkflowRunStep{
+			RunId:        "run",
+			WorkflowName: "workflow",
+			EventType:    workflowRunEventTypePush,
+			Branch:       "main",
+			CommitHash:   work.commit.String(),
+			JobName:      "job",
+			StepName:     "passes",
+			StepOrder:    1,
+			Status:       workflowRunStepStatusPassed,
+			StartedAt:    now,
+			EndedAt:      now,
+		}, steps[0])
+		assert.Equal(t, workflowRunStepStatusFailed, steps[1].Status)
+		assert.Equal(t, "first\nsecond", steps[1].FailureDetails)
+	})
+	t.Run("RecordsWorkflowsWhichCouldNotRun", func(t *testing.T) {
+		step := newFailedWorkflowRunStep("run", "it's broken", work, now, now, errors.New("can't run"))
+		assert.Equal(t, "it's broken", step.WorkflowName)
+		assert.Equal(t, workflowRunStepStatusFailed, step.Status)
+		assert.Equal(t, "can't run", step.FailureDetails)
+		assert.Empty(t, step.JobName)
+	})
+}
+
+func TestWorkflowRunCommitHook(t *testing.T) {
+	ctx := context.Background()
+	ddb, err := doltdb.LoadDoltDB(ctx, types.Format_Default, doltdb.InMemDoltDB, filesys.LocalFS)
+	require.NoError(t, err)
+	defer ddb.Close()
+	err = ddb.WriteEmptyRepo(ctx, "main", "name", "email@example.com")
+	require.NoError(t, err)
+	ds, err := doltdb.HackDatasDatabaseFromDoltDB(ddb).GetDataset(ctx, ref.NewBranchRef("main").String())
+	require.NoError(t, err)
+
+	t.Run("DropsWorkWhenTheQueueIsFull", func(t *testing.T) {
+		c := NewWorkflowRunController(logrus.New(), sql.Client{})
+		h := c.newCommitHook("MyDb")
+		for i := 0; i < maxPendingWorkflowRuns+10; i++ {
+			_, err := h.Execute(ctx, ds, ddb)
+			require.NoError(t, err)
+		}
+		assert.Len(t, c.workCh, maxPendingWorkflowRuns)
+	})
+	t.Run("DropsWorkAfterTheControllerStops", func(t *testing.T) {
+		c := NewWorkflowRunController(logrus.New(), sql.Client{})
+		bgCtx, cancel := context.WithCancel(ctx)
+		cancel()
+		c.runBgThread(bgCtx)
+
+		h := c.newCommitHook("MyDb")
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_, err := h.Execute(ctx, ds, ddb)
+			assert.NoError(t, err)
+		}()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("commit hook blocked after the controller stopped")
+		}
+		assert.Len(t, c.workCh, 0)
+	})
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dolt_ci
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareRowColumnCount(t *testing.T) {
+	tests := []struct {
+		comparisonType WorkflowSavedQueryExpectedRowColumnComparisonType
+		expected       int64
+		actual         int64
+		ok             bool
+	}{
+		{WorkflowSavedQueryExpectedRowColumnComparisonTypeEquals, 2, 2, true},
+		{WorkflowSavedQueryExpectedRowColumnComparisonTypeEquals, 2, 3, false},
+		{WorkflowSavedQueryExpectedRowColumnComparisonTypeNotEquals, 2, 3, true},
+		{WorkflowSavedQueryExpectedRowColumnComparisonTypeNotEquals, 2, 2, false},
+		{WorkflowSavedQueryExpectedRowColumnComparisonTypeLessThan, 2, 1, true},
+		{WorkflowSavedQueryExpectedRowColumnComparisonTypeLessThan, 2, 2, false},
+		{WorkflowSavedQueryExpectedRowColumnComparisonTypeGreaterThan, 2, 3, true},
+		{WorkflowSavedQueryExpectedRowColumnComparisonTypeGreaterThan, 2, 2, false},
+		{WorkflowSavedQueryExpectedRowColumnComparisonTypeLessThanOrEqual, 2, 2, true},
+		{WorkflowSavedQueryExpectedRowColumnComparisonTypeLessThanOrEqual, 2, 3, false},
+		{WorkflowSavedQueryExpectedRowColumnComparisonTypeGreaterThanOrEqual, 2, 2, true},
+		{WorkflowSavedQueryExpectedRowColumnComparisonTypeGreaterThanOrEqual, 2, 1, false},
+		{WorkflowSavedQueryExpectedRowColumnComparisonTypeUnspecified, 2, 100, true},
+	}
+
+	for _, test := range tests {
+		ok, err := CompareRowColumnCount(test.comparisonType, test.expected, test.actual)
+		require.NoError(t, err)
+		require.Equal(t, test.ok, ok, "comparison %d: expected %d, actual %d", test.comparisonType, test.expected, test.actual)
+	}
+
+	_, err := CompareRowColumnCount(WorkflowSavedQueryExpectedRowColumnComparisonType(100), 1, 1)
+	require.ErrorIs(t, err, ErrUnknownWorkflowSavedQueryExpectedRowColumnComparisonType)
+}
+
+func TestCompareResultSets(t *testing.T) {
+	str := func(s string) *string {
+		return &s
+	}
+
+	expected := [][]*string{
+		{str("1"), str("a")},
+		{str("2"), nil},
+		{str("2"), nil},
+	}
+
+	require.Empty(t, compareResultSets(expected, [][]*string{
+		{str("2"), nil},
+		{str("1"), str("a")},
+		{str("2"), nil},
+	}))
+
+	require.Equal(t, []string{
+		"missing row: [\"2\",null]",
+		"unexpected row: [\"2\",\"\"]",
+	}, compareResultSets(expected, [][]*string{
+		{str("1"), str("a")},
+		{str("2"), nil},
+		{str("2"), str("")},
+	}))
+
+	require.Equal(t, []string{
+		"expected 3 rows, got 1",
+		"missing row: [\"2\",null]",
+		"missing row: [\"2\",null]",
+	}, compareResultSets(expected, [][]*string{
+		{str("1"), str("a")},
+	}))
+
+	actual := make([][]*string, maxReportedRows+5)
+	for i := range actual {
+		actual[i] = []*string{str("3")}
+	}
+	failures := compareResultSets(nil, actual)
+	require.Len(t, failures, maxReportedRows+2)
+	require.Equal(t, "... and 5 more", failures[len(failures)-1])
+}
+
+func TestMatchBranchPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		branch  string
+		ok      bool
+	}{
+		{"main", "main", true},
+		{"main", "Main", true},
+		{"Release-*", "release-1.0", true},
+		{"release-*", "Release-1.0", true},
+		{"release-*", "main", false},
+		{"feature/*", "feature/x", true},
+		{"feature/*", "feature/x/y", false},
+	}
+	for _, test := range tests {
+		ok, err := matchBranchPattern(test.pattern, test.branch)
+		require.NoError(t, err)
+		require.Equal(t, test.ok, ok, "pattern %s, branch %s", test.pattern, test.branch)
+	}
+
+	_, err := matchBranchPattern("release-[", "release-1")
+	require.ErrorIs(t, err, path.ErrBadPattern)
+}
@@ -0,0 +1,28 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dolt_ci
+
+type WorkflowSqlAssertionStepId string
+
+// WorkflowSqlAssertionStep is a step which runs an inline query and compares its result set to the expected rows
+// stored with the step.
+type WorkflowSqlAssertionStep struct {
+	Id               *WorkflowSqlAssertionStepId `db:"id"`
+	WorkflowStepIdFK *WorkflowStepId             `db:"workflow_step_id_fk"`
+	Query            string                      `db:"query"`
+	// ExpectedResults is a JSON array of rows, each of which is a JSON array of column values formatted as strings,
+	// or null.
+	ExpectedResults string `db:"expected_results"`
+}
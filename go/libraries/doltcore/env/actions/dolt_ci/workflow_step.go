@@ -26,6 +26,10 @@ type WorkflowStepType int
 const (
 	WorkflowStepTypeUnspecified WorkflowStepType = iota
 	WorkflowStepTypeSavedQuery
+	WorkflowStepTypeSqlAssertion
+	WorkflowStepTypeVerifyConstraints
+	WorkflowStepTypeSchemaUnchanged
+	WorkflowStepTypeDiffBudget
 )
 
 type WorkflowStepId string
@@ -45,6 +49,14 @@ func ToWorkflowStepType(t int) (WorkflowStepType, error) {
 	switch t {
 	case int(WorkflowStepTypeSavedQuery):
 		return WorkflowStepTypeSavedQuery, nil
+	case int(WorkflowStepTypeSqlAssertion):
+		return WorkflowStepTypeSqlAssertion, nil
+	case int(WorkflowStepTypeVerifyConstraints):
+		return WorkflowStepTypeVerifyConstraints, nil
+	case int(WorkflowStepTypeSchemaUnchanged):
+		return WorkflowStepTypeSchemaUnchanged, nil
+	case int(WorkflowStepTypeDiffBudget):
+		return WorkflowStepTypeDiffBudget, nil
 	default:
 		return WorkflowStepTypeUnspecified, ErrUnknownWorkflowStepType
 	}
@@ -0,0 +1,26 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dolt_ci
+
+type WorkflowVerifyConstraintsStepId string
+
+// WorkflowVerifyConstraintsStep is a step which runs dolt_verify_constraints against every row of the tables named,
+// or of all tables if none are named.
+type WorkflowVerifyConstraintsStep struct {
+	Id               *WorkflowVerifyConstraintsStepId `db:"id"`
+	WorkflowStepIdFK *WorkflowStepId                  `db:"workflow_step_id_fk"`
+	// TableNames is a JSON array of table names.
+	TableNames string `db:"table_names"`
+}
@@ -18,12 +18,17 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"math"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/sqltypes"
 	"github.com/google/uuid"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
@@ -53,16 +58,64 @@ type InferenceArgs interface {
 	// a fractional component greater than or equal to 0.001 will be treated as a float (1.0 would be an int, 1.0009 would
 	// be an int, 1.001 would be a float, 1.1 would be a float, etc)
 	FloatThreshold() float64
+	// SampleSize is the number of rows at the start of the input which types are inferred from. Each of these rows is
+	// examined. If SampleSize is 0 then rows are sampled from the whole input, skipping through it exponentially.
+	SampleSize() int
+	// InferPrecision is true if the precision of DECIMAL and DATETIME types should be chosen to fit the sampled
+	// values. Otherwise, numbers with a decimal point are inferred as floats and datetimes have the maximum precision.
+	InferPrecision() bool
+}
+
+// ColumnInference describes how the type of a column was inferred from the sampled values of the column.
+type ColumnInference struct {
+	// Name is the name of the column in the inferred schema
+	Name string
+	// Candidates are the types of the sampled values, which the inferred type is the common type of
+	Candidates []typeinfo.TypeInfo
+	// Sampled is the number of values which were sampled
+	Sampled int
+	// Nulls is the number of sampled values which were null or empty
+	Nulls int
+	// MaxLength is the length in characters of the longest sampled value
+	MaxLength int
+	// Type is the inferred type of the column
+	Type typeinfo.TypeInfo
+	// Reason explains why Type was chosen
+	Reason string
 }
 
 // InferColumnTypesFromTableReader will infer a data types from a table reader.
 func InferColumnTypesFromTableReader(ctx context.Context, rd table.ReadCloser, args InferenceArgs) (*schema.ColCollection, error) {
+	cols, _, err := InferColumnTypesWithReport(ctx, rd, args)
+	return cols, err
+}
+
+// InferColumnTypesWithReport infers data types from a table reader, and returns a report of how the type of each
+// column was inferred along with the inferred columns.
+func InferColumnTypesWithReport(ctx context.Context, rd table.ReadCloser, args InferenceArgs) (*schema.ColCollection, []ColumnInference, error) {
+	i := newInferrer(rd.GetSchema(), args)
+
+	if sampleSize := args.SampleSize(); sampleSize > 0 {
+		for n := 0; n < sampleSize; n++ {
+			r, err := rd.ReadRow(ctx)
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, nil, err
+			}
+			if err = i.processRow(r); err != nil {
+				return nil, nil, err
+			}
+		}
+		return i.inferColumnTypes()
+	}
+
 	// for large imports, we want to sample a subset of the rows.
 	// skip through the file in an exponential manner
 	const exp = 1.02
 
 	var curr, prev row.Row
-	i := newInferrer(rd.GetSchema(), args)
+	processed := false
 OUTER:
 	for j := 0; true; j++ {
 		var err error
@@ -73,58 +126,96 @@ OUTER:
 			if err == io.EOF {
 				break OUTER
 			} else if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			prev = curr
+			processed = false
 		}
 		if err = i.processRow(curr); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
+		processed = true
 	}
 
 	// always process last row
-	if prev != nil {
+	if prev != nil && !processed {
 		if err := i.processRow(prev); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
 	return i.inferColumnTypes()
 }
 
+// columnStats are statistics of the sampled values of a column.
+type columnStats struct {
+	sampled   int
+	nulls     int
+	maxLength int
+	// intDigits and scale are the most digits sampled before and after the decimal point of a number
+	intDigits int
+	scale     int
+	// exponent is true if a number was sampled in scientific notation, which a DECIMAL can't be chosen for
+	exponent bool
+	// fsp is the most fractional second digits sampled in a datetime
+	fsp int
+}
+
 type inferrer struct {
 	readerSch      schema.Schema
 	inferSets      map[uint64]typeInfoSet
+	stats          map[uint64]*columnStats
 	nullable       *set.Uint64Set
 	mapper         rowconv.NameMapper
 	floatThreshold float64
+	inferPrecision bool
 }
 
 func newInferrer(readerSch schema.Schema, args InferenceArgs) *inferrer {
 	inferSets := make(map[uint64]typeInfoSet, readerSch.GetAllCols().Size())
+	stats := make(map[uint64]*columnStats, readerSch.GetAllCols().Size())
 	_ = readerSch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
 		inferSets[tag] = make(typeInfoSet)
+		stats[tag] = &columnStats{}
 		return false, nil
 	})
 
 	return &inferrer{
 		readerSch:      readerSch,
 		inferSets:      inferSets,
+		stats:          stats,
 		nullable:       set.NewUint64Set(nil),
 		mapper:         args.ColNameMapper(),
 		floatThreshold: args.FloatThreshold(),
+		inferPrecision: args.InferPrecision(),
 	}
 }
 
-// inferColumnTypes returns TableReader's columns with updated TypeInfo and columns names
-func (inf *inferrer) inferColumnTypes() (*schema.ColCollection, error) {
+// inferColumnTypes returns TableReader's columns with updated TypeInfo and columns names, and a report of how each
+// column's type was inferred
+func (inf *inferrer) inferColumnTypes() (*schema.ColCollection, []ColumnInference, error) {
 
 	inferredTypes := make(map[uint64]typeinfo.TypeInfo)
+	reasons := make(map[uint64]string)
+	candidates := make(map[uint64][]typeinfo.TypeInfo)
 	for tag, ts := range inf.inferSets {
-		inferredTypes[tag] = findCommonType(ts)
+		for ti := range ts {
+			if ti != typeinfo.UnknownType {
+				candidates[tag] = append(candidates[tag], ti)
+			}
+		}
+		sort.Slice(candidates[tag], func(i, j int) bool {
+			return candidates[tag][i].ToSqlType().String() < candidates[tag][j].ToSqlType().String()
+		})
+
+		inferredTypes[tag], reasons[tag] = findCommonTypeWithReason(ts)
+		if inf.inferPrecision {
+			inferredTypes[tag], reasons[tag] = preciseType(inferredTypes[tag], reasons[tag], inf.stats[tag])
+		}
 	}
 
 	var cols []schema.Column
+	var report []ColumnInference
 	_ = inf.readerSch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
 		col.Name = inf.mapper.Map(col.Name)
 		col.Kind = inferredTypes[tag].NomsKind()
@@ -135,27 +226,114 @@ func (inf *inferrer) inferColumnTypes() (*schema.ColCollection, error) {
 		col.Constraints = []schema.ColConstraint(nil)
 
 		cols = append(cols, col)
+
+		stats := inf.stats[tag]
+		report = append(report, ColumnInference{
+			Name:       col.Name,
+			Candidates: candidates[tag],
+			Sampled:    stats.sampled,
+			Nulls:      stats.nulls,
+			MaxLength:  stats.maxLength,
+			Type:       col.TypeInfo,
+			Reason:     reasons[tag],
+		})
 		return false, nil
 	})
 
-	return schema.NewColCollection(cols...), nil
+	return schema.NewColCollection(cols...), report, nil
 }
 
 func (inf *inferrer) processRow(r row.Row) error {
 	_, err := r.IterSchema(inf.readerSch, func(tag uint64, val types.Value) (stop bool, err error) {
+		stats := inf.stats[tag]
+		stats.sampled++
 		if val == nil {
+			stats.nulls++
 			inf.nullable.Add(tag)
 			return false, nil
 		}
 		strVal := string(val.(types.String))
+		if len(strVal) == 0 {
+			stats.nulls++
+		}
+		if l := utf8.RuneCountInString(strVal); l > stats.maxLength {
+			stats.maxLength = l
+		}
+
 		typeInfo := leastPermissiveType(strVal, inf.floatThreshold)
 		inf.inferSets[tag][typeInfo] = struct{}{}
+
+		if inf.inferPrecision {
+			stats.addPrecision(strings.TrimSpace(strVal), typeInfo)
+		}
 		return false, nil
 	})
 
 	return err
 }
 
+// addPrecision records the digits of |strVal|, a value which was inferred as |ti|.
+func (stats *columnStats) addPrecision(strVal string, ti typeinfo.TypeInfo) {
+	switch ti {
+	case typeinfo.Int32Type, typeinfo.Int64Type, typeinfo.Float32Type, typeinfo.Float64Type:
+		digits := strings.TrimLeft(strVal, "+-")
+		if strings.ContainsAny(digits, "eE") {
+			stats.exponent = true
+			return
+		}
+		whole, frac, _ := strings.Cut(digits, ".")
+		whole = strings.TrimLeft(whole, "0")
+		stats.intDigits = max(stats.intDigits, len(whole))
+		stats.scale = max(stats.scale, len(frac))
+	case typeinfo.DatetimeType:
+		stats.fsp = max(stats.fsp, fractionalSecondDigits(strVal))
+	}
+}
+
+// fractionalSecondDigits returns the number of digits of the fractional seconds of the datetime |strVal|.
+func fractionalSecondDigits(strVal string) int {
+	colon := strings.LastIndex(strVal, ":")
+	if colon < 0 {
+		return 0
+	}
+	_, frac, ok := strings.Cut(strVal[colon:], ".")
+	if !ok {
+		return 0
+	}
+	n := 0
+	for n < len(frac) && frac[n] >= '0' && frac[n] <= '9' {
+		n++
+	}
+	return min(n, gmstypes.DatetimeMaxPrecision.Precision())
+}
+
+// preciseType returns a DECIMAL type for floats, and a DATETIME type for datetimes, whose precision fits the sampled
+// values described by |stats|. Other types, and floats which can't be held by a DECIMAL, are returned unchanged.
+func preciseType(ti typeinfo.TypeInfo, reason string, stats *columnStats) (typeinfo.TypeInfo, string) {
+	switch ti {
+	case typeinfo.Float32Type, typeinfo.Float64Type:
+		if stats.exponent {
+			return ti, reason + "; a DECIMAL can't be chosen for numbers in scientific notation"
+		}
+		precision := max(stats.intDigits+stats.scale, 1)
+		if precision > gmstypes.DecimalTypeMaxPrecision || stats.scale > gmstypes.DecimalTypeMaxScale {
+			return ti, reason + "; the sampled numbers have more digits than a DECIMAL can hold"
+		}
+		decType, err := typeinfo.FromSqlType(gmstypes.MustCreateColumnDecimalType(uint8(precision), uint8(stats.scale)))
+		if err != nil {
+			return ti, reason
+		}
+		return decType, fmt.Sprintf("sampled numbers have at most %d digits before and %d digits after the decimal point", stats.intDigits, stats.scale)
+	case typeinfo.DatetimeType:
+		dtType, err := typeinfo.FromSqlType(gmstypes.MustCreateDatetimeType(sqltypes.Datetime, stats.fsp))
+		if err != nil {
+			return ti, reason
+		}
+		return dtType, fmt.Sprintf("%s; sampled datetimes have at most %d fractional second digits", reason, stats.fsp)
+	}
+	return ti, reason
+}
+
 func leastPermissiveType(strVal string, floatThreshold float64) typeinfo.TypeInfo {
 	if len(strVal) == 0 {
 		return typeinfo.UnknownType
@@ -319,29 +497,34 @@ func setHasType(ts typeInfoSet, t typeinfo.TypeInfo) bool {
 // findCommonType takes a set of types and finds the least permissive
 // (ie most specific) common type between all types in the set
 func findCommonType(ts typeInfoSet) typeinfo.TypeInfo {
+	ti, _ := findCommonTypeWithReason(ts)
+	return ti
+}
+
+// findCommonTypeWithReason returns the common type of the set of types, as findCommonType does, along with the reason
+// it was chosen
+func findCommonTypeWithReason(ts typeInfoSet) (typeinfo.TypeInfo, string) {
 
 	// empty values were inferred as UnknownType
 	delete(ts, typeinfo.UnknownType)
 
 	if len(ts) == 0 {
 		// use strings if all values were empty
-		return typeinfo.StringDefaultType
+		return typeinfo.StringDefaultType, "no non-empty values were sampled"
 	}
 
 	if len(ts) == 1 {
 		for ti := range ts {
-			return ti
+			return ti, "all sampled values are " + ti.ToSqlType().String()
 		}
 	}
 
 	// len(ts) > 1
 
 	if setHasType(ts, typeinfo.TextType) {
-		return typeinfo.TextType
-	} else if setHasType(ts, typeinfo.StringDefaultType) {
-		return typeinfo.StringDefaultType
+		return typeinfo.TextType, fmt.Sprintf("some sampled values are longer than %d characters", typeinfo.MaxVarcharLength)
 	} else if setHasType(ts, typeinfo.StringDefaultType) {
-		return typeinfo.StringDefaultType
+		return typeinfo.StringDefaultType, "some sampled values are only valid as strings"
 	}
 
 	hasNumeric := false
@@ -364,11 +547,11 @@ func findCommonType(ts typeInfoSet) typeinfo.TypeInfo {
 	}
 
 	if hasNumeric && hasNonNumeric {
-		return typeinfo.StringDefaultType
+		return typeinfo.StringDefaultType, "sampled values mix numbers with other types"
 	}
 
 	if hasNumeric {
-		return findCommonNumericType(ts)
+		return findCommonNumericType(ts), "widened to the numeric type which holds all sampled numbers"
 	}
 
 	// find a common nonNumeric type
@@ -383,11 +566,11 @@ func findCommonType(ts typeInfoSet) typeinfo.TypeInfo {
 		if setHasType(ts, nct) {
 			// types in nonChronoTypes have only string
 			// as a common type with any other type
-			return typeinfo.StringDefaultType
+			return typeinfo.StringDefaultType, "sampled values mix " + nct.ToSqlType().String() + " with other types"
 		}
 	}
 
-	return findCommonChronoType(ts)
+	return findCommonChronoType(ts), "widened to the type which holds all sampled dates and times"
 }
 
 func findCommonNumericType(nums typeInfoSet) typeinfo.TypeInfo {
@@ -17,11 +17,16 @@ package actions
 import (
 	"context"
 	"fmt"
+	"io"
 	"math"
 	"os"
 	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/dolthub/go-mysql-server/sql"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/sqltypes"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -339,11 +344,31 @@ da95f30d-4cde-4e53-a786-e00624ff2cbe,0.0001
 6fb474ca-8bec-4e21-9af2-a1ba22f39f1d,-1.0005
 aee125d4-e055-42e9-af3d-0bc676436ccd,1.0001`
 
+var numbersAndDatetimesWithFractions = `id,price,created,ratio
+1,12.5,2024-01-02 03:04:05.12,1.5e3
+2,-1234.125,2024-01-02 03:04:05,2.5
+3,,2024-01-03 00:00:01.1,0.5`
+
+var stringAfterSampledRows = `id,val
+1,1
+2,2
+3,abc`
+
 var identityMapper = make(rowconv.NameMapper)
 
+func mustTypeInfo(t sql.Type) typeinfo.TypeInfo {
+	ti, err := typeinfo.FromSqlType(t)
+	if err != nil {
+		panic(err)
+	}
+	return ti
+}
+
 type testInferenceArgs struct {
 	ColMapper      rowconv.NameMapper
 	floatThreshold float64
+	sampleSize     int
+	inferPrecision bool
 }
 
 func (tia testInferenceArgs) ColNameMapper() rowconv.NameMapper {
@@ -354,6 +379,14 @@ func (tia testInferenceArgs) FloatThreshold() float64 {
 	return tia.floatThreshold
 }
 
+func (tia testInferenceArgs) SampleSize() int {
+	return tia.sampleSize
+}
+
+func (tia testInferenceArgs) InferPrecision() bool {
+	return tia.inferPrecision
+}
+
 func TestInferSchema(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -444,6 +477,60 @@ func TestInferSchema(t *testing.T) {
 			},
 			nil,
 		},
+		{
+			"decimals and datetimes with inferred precision",
+			numbersAndDatetimesWithFractions,
+			testInferenceArgs{
+				ColMapper:      identityMapper,
+				inferPrecision: true,
+			},
+			map[string]typeinfo.TypeInfo{
+				"id":      typeinfo.Int32Type,
+				"price":   mustTypeInfo(gmstypes.MustCreateColumnDecimalType(7, 3)),
+				"created": mustTypeInfo(gmstypes.MustCreateDatetimeType(sqltypes.Datetime, 2)),
+				"ratio":   typeinfo.Float32Type,
+			},
+			nil,
+		},
+		{
+			"decimals and datetimes without inferred precision",
+			numbersAndDatetimesWithFractions,
+			testInferenceArgs{
+				ColMapper: identityMapper,
+			},
+			map[string]typeinfo.TypeInfo{
+				"id":      typeinfo.Int32Type,
+				"price":   typeinfo.Float32Type,
+				"created": typeinfo.DatetimeType,
+				"ratio":   typeinfo.Float32Type,
+			},
+			nil,
+		},
+		{
+			"sample size smaller than the number of rows",
+			stringAfterSampledRows,
+			testInferenceArgs{
+				ColMapper:  identityMapper,
+				sampleSize: 2,
+			},
+			map[string]typeinfo.TypeInfo{
+				"id":  typeinfo.Int32Type,
+				"val": typeinfo.Int32Type,
+			},
+			nil,
+		},
+		{
+			"sampling includes the last row",
+			stringAfterSampledRows,
+			testInferenceArgs{
+				ColMapper: identityMapper,
+			},
+			map[string]typeinfo.TypeInfo{
+				"id":  typeinfo.Int32Type,
+				"val": typeinfo.StringDefaultType,
+			},
+			nil,
+		},
 	}
 
 	const importFilePath = "/Users/home/datasets/test/import_file.csv"
@@ -492,3 +579,30 @@ func TestInferSchema(t *testing.T) {
 		})
 	}
 }
+
+func TestInferColumnTypesWithReport(t *testing.T) {
+	ctx := context.Background()
+	csvRd, err := csv.NewCSVReader(types.Format_Default, io.NopCloser(strings.NewReader(numbersAndDatetimesWithFractions)), csv.NewCSVInfo())
+	require.NoError(t, err)
+
+	_, report, err := InferColumnTypesWithReport(ctx, csvRd, testInferenceArgs{ColMapper: identityMapper, inferPrecision: true})
+	require.NoError(t, err)
+	require.Len(t, report, 4)
+
+	price := report[1]
+	assert.Equal(t, "price", price.Name)
+	assert.Equal(t, []typeinfo.TypeInfo{typeinfo.Float32Type}, price.Candidates)
+	assert.Equal(t, 3, price.Sampled)
+	assert.Equal(t, 1, price.Nulls)
+	assert.Equal(t, 9, price.MaxLength)
+	assert.Equal(t, "decimal(7,3)", price.Type.ToSqlType().String())
+	assert.Equal(t, "sampled numbers have at most 4 digits before and 3 digits after the decimal point", price.Reason)
+
+	created := report[2]
+	assert.Equal(t, "datetime(2)", created.Type.ToSqlType().String())
+	assert.Equal(t, "all sampled values are datetime(6); sampled datetimes have at most 2 fractional second digits", created.Reason)
+
+	ratio := report[3]
+	assert.Equal(t, typeinfo.Float32Type, ratio.Type)
+	assert.Equal(t, "all sampled values are float; a DECIMAL can't be chosen for numbers in scientific notation", ratio.Reason)
+}
@@ -330,8 +330,8 @@ func FetchTag(ctx context.Context, tempTableDir string, srcDB, destDB *doltdb.Do
 }
 
 // Clone pulls all data from a remote source database to a local destination database.
-func Clone(ctx context.Context, srcDB, destDB *doltdb.DoltDB, eventCh chan<- pull.TableFileEvent) error {
-	return srcDB.Clone(ctx, destDB, eventCh)
+func Clone(ctx context.Context, tempTableDir string, srcDB, destDB *doltdb.DoltDB, eventCh chan<- pull.TableFileEvent) error {
+	return srcDB.Clone(ctx, tempTableDir, destDB, eventCh)
 }
 
 // FetchFollowTags fetches all tags from the source DB whose commits have already
@@ -801,7 +801,10 @@ func canSyncRootsWithClone(ctx context.Context, srcDb, destDb *doltdb.DoltDB, de
 		return false, nil
 	}
 	srcHasJournal, err := srcDb.TableFileStoreHasJournal(ctx)
-	if err != nil {
+	if errors.Is(err, pull.ErrCloneUnsupported) {
+		// the table files of encrypted remotes can't be copied as they are
+		return false, nil
+	} else if err != nil {
 		return false, err
 	}
 	if srcHasJournal {
@@ -891,7 +894,8 @@ func SyncRoots(ctx context.Context, srcDb, destDb *doltdb.DoltDB, tempTableDir s
 			}
 		}()
 
-		err := srcDb.Clone(ctx, destDb, tfCh)
+		// The destination of a sync isn't the repository which owns |tempTableDir|, so it isn't checkpointed.
+		err := srcDb.Clone(ctx, "", destDb, tfCh)
 		close(tfCh)
 		if err == nil {
 			return nil
@@ -39,6 +39,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/datas/pull"
 	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
 )
@@ -200,6 +201,33 @@ func LoadWithoutDB(_ context.Context, hdp HomeDirProvider, fs filesys.Filesys, u
 	}
 }
 
+// storageParams returns the database creation params for the storage settings in the repository's local config, such
+// as its cold tier. Only the local config is used, since these settings can't be shared between repositories.
+func (dEnv *DoltEnv) storageParams() map[string]interface{} {
+	if dEnv.Config == nil {
+		return nil
+	}
+	localCfg, ok := dEnv.Config.GetConfig(LocalConfig)
+	if !ok {
+		return nil
+	}
+
+	params := make(map[string]interface{})
+	for key, param := range map[string]string{
+		config.ColdTierURLKey:       dbfactory.ColdTierURLParam,
+		config.ColdTierAfterKey:     dbfactory.ColdTierAfterParam,
+		config.ColdTierCacheSizeKey: dbfactory.ColdTierCacheSizeParam,
+	} {
+		if val, err := localCfg.GetString(key); err == nil {
+			params[param] = val
+		}
+	}
+	if len(params) == 0 {
+		return nil
+	}
+	return params
+}
+
 // Load loads the DoltEnv for the .dolt directory determined by resolving the specified urlStr with the specified Filesys.
 func Load(ctx context.Context, hdp HomeDirProvider, fs filesys.Filesys, urlStr string, version string) *DoltEnv {
 	dEnv := LoadWithoutDB(ctx, hdp, fs, urlStr, version)
@@ -209,7 +237,7 @@ func Load(ctx context.Context, hdp HomeDirProvider, fs filesys.Filesys, urlStr s
 
 func LoadDoltDB(ctx context.Context, fs filesys.Filesys, urlStr string, dEnv *DoltEnv) {
 	dEnv.loadDBOnce.Do(func() {
-		ddb, dbLoadErr := doltdb.LoadDoltDB(ctx, types.Format_Default, urlStr, fs)
+		ddb, dbLoadErr := doltdb.LoadDoltDBWithParams(ctx, types.Format_Default, urlStr, fs, dEnv.storageParams())
 		dEnv.doltDB = ddb
 		dEnv.DBLoadError = dbLoadErr
 		dEnv.urlStr = urlStr
@@ -231,8 +259,10 @@ func LoadDoltDB(ctx context.Context, fs filesys.Filesys, urlStr string, dEnv *Do
 					if err != nil {
 						return
 					}
+					// checkpoints of interrupted pulls are kept until the pull is resumed
+					checkpointsDir := filepath.Join(tmpTableDir, pull.CheckpointsDir)
 					_ = fs.Iter(tmpTableDir, true, func(path string, size int64, isDir bool) (stop bool) {
-						if !isDir {
+						if !isDir && filepath.Dir(path) != checkpointsDir {
 							lm, exists := fs.LastModified(path)
 
 							if exists && time.Now().Sub(lm) > (time.Hour*24) {
@@ -34,6 +34,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/utils/config"
 	"github.com/dolthub/dolt/go/libraries/utils/earl"
 	filesys2 "github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/nbs"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -104,6 +105,7 @@ func (r *Remote) GetRemoteDB(ctx context.Context, nbf *types.NomsBinFormat, dial
 	}
 
 	params[dbfactory.GRPCDialProviderParam] = dialer
+	params[dbfactory.UnsealedParam] = true
 
 	return doltdb.LoadDoltDBWithParams(ctx, nbf, r.Url, filesys2.LocalFS, params)
 }
@@ -117,6 +119,7 @@ func (r *Remote) Prepare(ctx context.Context, nbf *types.NomsBinFormat, dialer d
 	}
 
 	params[dbfactory.GRPCDialProviderParam] = dialer
+	params[dbfactory.UnsealedParam] = true
 
 	return dbfactory.PrepareDB(ctx, nbf, r.Url, params)
 }
@@ -128,6 +131,7 @@ func (r *Remote) GetRemoteDBWithoutCaching(ctx context.Context, nbf *types.NomsB
 	}
 	params[dbfactory.NoCachingParameter] = "true"
 	params[dbfactory.GRPCDialProviderParam] = dialer
+	params[dbfactory.UnsealedParam] = true
 
 	return doltdb.LoadDoltDBWithParams(ctx, nbf, r.Url, filesys2.LocalFS, params)
 }
@@ -701,7 +705,10 @@ func getAbsFileRemoteUrl(u *url.URL, fs filesys2.Filesys) (string, error) {
 			return "", fmt.Errorf("failed to create directory '%s': %w", urlStr, err)
 		}
 	} else if !isDir {
-		return "", filesys2.ErrIsFile
+		// bundle files are read like remotes
+		if isBundle, err := nbs.IsBundle(urlStr); err != nil || !isBundle || scheme != dbfactory.FileScheme {
+			return "", filesys2.ErrIsFile
+		}
 	}
 
 	urlStr = filepath.ToSlash(urlStr)
@@ -46,6 +46,7 @@ type MergeSpec struct {
 	Email           string
 	Name            string
 	Date            time.Time
+	Strategies      Strategies
 }
 
 type MergeSpecOpt func(*MergeSpec)
@@ -80,6 +81,12 @@ func WithSquash(squash bool) MergeSpecOpt {
 	}
 }
 
+func WithStrategies(strategies Strategies) MergeSpecOpt {
+	return func(ms *MergeSpec) {
+		ms.Strategies = strategies
+	}
+}
+
 // NewMergeSpec returns a MergeSpec with the arguments provided.
 func NewMergeSpec(
 	ctx context.Context,
@@ -0,0 +1,309 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/expranalysis"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	storetypes "github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// ConflictResolvers are the conflict resolvers stored in the dolt_conflict_resolvers table. They map lower-cased
+// table names to a map of lower-cased column names to the resolver expression for that column.
+type ConflictResolvers map[doltdb.TableName]map[string]string
+
+// conflictResolver resolves concurrent modifications to a column by evaluating a SQL expression over the base, ours
+// and theirs values of the column.
+type conflictResolver struct {
+	column   string
+	resolver string
+	expr     sql.Expression
+}
+
+// ConflictResolverFunctions are the functions available to conflict resolver expressions in addition to the built-in
+// functions.
+var ConflictResolverFunctions = []sql.Function{
+	sql.Function2{Name: "json_array_union", Fn: NewJSONArrayUnion},
+}
+
+// GetConflictResolvers returns the conflict resolvers stored in the dolt_conflict_resolvers table of |root|.
+func GetConflictResolvers(ctx context.Context, root doltdb.RootValue) (ConflictResolvers, error) {
+	tbl, ok, err := root.GetTable(ctx, doltdb.TableName{Name: doltdb.ConflictResolversTableName})
+	if err != nil {
+		return nil, err
+	}
+	if !ok || tbl.Format() == storetypes.Format_LD_1 {
+		// dolt_conflict_resolvers is not supported for the legacy storage format.
+		return nil, nil
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if sch.GetPKCols().Size() != 2 || sch.GetNonPKCols().Size() != 1 {
+		return nil, fmt.Errorf("%s had unexpected schema, this should never happen", doltdb.ConflictResolversTableName)
+	}
+
+	idx, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m := durable.MapFromIndex(idx)
+	keyDesc, valueDesc := sch.GetMapDescriptors(m.NodeStore())
+
+	iter, err := m.IterAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make(ConflictResolvers)
+	for {
+		k, v, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		tableName, ok := keyDesc.GetString(0, k)
+		if !ok {
+			return nil, fmt.Errorf("could not read table name from %s", doltdb.ConflictResolversTableName)
+		}
+		columnName, ok := keyDesc.GetString(1, k)
+		if !ok {
+			return nil, fmt.Errorf("could not read column name from %s", doltdb.ConflictResolversTableName)
+		}
+		resolver, ok := valueDesc.GetString(0, v)
+		if !ok {
+			return nil, fmt.Errorf("could not read resolver for column '%s.%s' from %s", tableName, columnName, doltdb.ConflictResolversTableName)
+		}
+
+		tblName := doltdb.TableName{Name: tableName}.ToLower()
+		if resolvers[tblName] == nil {
+			resolvers[tblName] = make(map[string]string)
+		}
+		resolvers[tblName][strings.ToLower(columnName)] = resolver
+	}
+
+	return resolvers, nil
+}
+
+// resolveConflictResolvers returns the conflict resolvers for the columns of |mergedSch|, keyed by the stored index
+// of the column in the merged value tuple. Resolvers for columns not in |mergedSch| are ignored.
+func resolveConflictResolvers(ctx *sql.Context, tm *TableMerger, mergedSch schema.Schema) (map[int]conflictResolver, error) {
+	if len(tm.resolvers) == 0 {
+		return nil, nil
+	}
+
+	resolvers := make(map[int]conflictResolver)
+	i := 0
+	for _, col := range mergedSch.GetNonPKCols().GetColumns() {
+		if col.Virtual {
+			continue
+		}
+		if resolver, ok := tm.resolvers[strings.ToLower(col.Name)]; ok {
+			expr, err := expranalysis.ResolveConflictResolverExpression(ctx, col.TypeInfo.ToSqlType(), resolver, ConflictResolverFunctions...)
+			if err != nil {
+				return nil, fmt.Errorf("invalid conflict resolver '%s' for column '%s' of table '%s': %w", resolver, col.Name, tm.name, err)
+			}
+			resolvers[i] = conflictResolver{column: col.Name, resolver: resolver, expr: expr}
+		}
+		i++
+	}
+	return resolvers, nil
+}
+
+// resolveColumn returns the value of column |i| of the merged schema resolved by |resolver| from the |base|, |left|
+// and |right| values of the column, which have already been converted to the merged schema's type.
+func (m *valueMerger) resolveColumn(ctx *sql.Context, i int, resolver conflictResolver, base, left, right []byte) ([]byte, error) {
+	desc := m.columnDesc(i)
+	row := make(sql.Row, 3)
+	for j, cell := range [][]byte{base, left, right} {
+		if cell == nil {
+			continue
+		}
+		v, err := tree.GetField(ctx, desc, 0, val.NewTuple(m.syncPool, cell), m.ns)
+		if err != nil {
+			return nil, err
+		}
+		row[j] = v
+	}
+
+	resolved, err := resolver.expr.Eval(ctx, row)
+	if err != nil {
+		return nil, fmt.Errorf("error evaluating conflict resolver '%s' for column '%s': %w", resolver.resolver, resolver.column, err)
+	}
+	if resolved == nil {
+		return nil, nil
+	}
+
+	sqlType := m.resultSchema.GetNonPKCols().GetByIndex(i).TypeInfo.ToSqlType()
+	resolved, _, err = sqlType.Convert(resolved)
+	if err != nil {
+		return nil, fmt.Errorf("error converting result of conflict resolver '%s' for column '%s': %w", resolver.resolver, resolver.column, err)
+	}
+
+	tb := val.NewTupleBuilder(desc)
+	if err = tree.PutField(ctx, m.ns, tb, 0, resolved); err != nil {
+		return nil, err
+	}
+	return desc.GetField(0, tb.Build(m.syncPool)), nil
+}
+
+// columnDesc returns a single field tuple descriptor for column |i| of the merged schema.
+func (m *valueMerger) columnDesc(i int) val.TupleDesc {
+	typ := m.resultVD.Types[i]
+	// If a resolver returns NULL for a non-null column, don't panic.
+	// Instead we validate the merged tuple before merging it into the table.
+	typ.Nullable = true
+	var args val.TupleDescriptorArgs
+	if len(m.resultVD.Handlers) > i && m.resultVD.Handlers[i] != nil {
+		args.Handlers = []val.TupleTypeHandler{m.resultVD.Handlers[i]}
+	}
+	return val.NewTupleDescriptorWithArgs(args, typ)
+}
+
+// JSONArrayUnion is the json_array_union(ours, theirs) conflict resolver function. It returns the elements of the
+// array |ours| followed by the elements of the array |theirs| not in |ours|. Values which are not arrays are treated
+// as arrays of one element, and a NULL value as an empty array. Returns NULL if both values are NULL.
+type JSONArrayUnion struct {
+	Left  sql.Expression
+	Right sql.Expression
+}
+
+var _ sql.FunctionExpression = (*JSONArrayUnion)(nil)
+
+// NewJSONArrayUnion creates a new JSONArrayUnion function.
+func NewJSONArrayUnion(left, right sql.Expression) sql.Expression {
+	return &JSONArrayUnion{Left: left, Right: right}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (j *JSONArrayUnion) FunctionName() string {
+	return "json_array_union"
+}
+
+// Description implements sql.FunctionExpression
+func (j *JSONArrayUnion) Description() string {
+	return "returns the union of the elements of two JSON arrays."
+}
+
+// Resolved implements sql.Expression
+func (j *JSONArrayUnion) Resolved() bool {
+	return j.Left.Resolved() && j.Right.Resolved()
+}
+
+// String implements sql.Expression
+func (j *JSONArrayUnion) String() string {
+	return fmt.Sprintf("%s(%s, %s)", j.FunctionName(), j.Left.String(), j.Right.String())
+}
+
+// Type implements sql.Expression
+func (j *JSONArrayUnion) Type() sql.Type {
+	return types.JSON
+}
+
+// IsNullable implements sql.Expression
+func (j *JSONArrayUnion) IsNullable() bool {
+	return true
+}
+
+// Children implements sql.Expression
+func (j *JSONArrayUnion) Children() []sql.Expression {
+	return []sql.Expression{j.Left, j.Right}
+}
+
+// WithChildren implements sql.Expression
+func (j *JSONArrayUnion) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(children), 2)
+	}
+	return NewJSONArrayUnion(children[0], children[1]), nil
+}
+
+// Eval implements sql.Expression
+func (j *JSONArrayUnion) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	left, err := jsonArrayFromRow(ctx, row, j.Left)
+	if err != nil {
+		return nil, err
+	}
+	right, err := jsonArrayFromRow(ctx, row, j.Right)
+	if err != nil {
+		return nil, err
+	}
+	if left == nil && right == nil {
+		return nil, nil
+	} else if left == nil {
+		return types.JSONDocument{Val: right}, nil
+	} else if right == nil {
+		return types.JSONDocument{Val: left}, nil
+	}
+
+	union := append(types.JsonArray{}, left...)
+	for _, r := range right {
+		found := false
+		for _, l := range left {
+			cmp, err := types.CompareJSON(l, r)
+			if err != nil {
+				return nil, err
+			}
+			if cmp == 0 {
+				found = true
+				break
+			}
+		}
+		if !found {
+			union = append(union, r)
+		}
+	}
+	return types.JSONDocument{Val: union}, nil
+}
+
+// jsonArrayFromRow evaluates |expr| as a JSON value, returning its elements if it is an array or the value itself
+// as the only element if it is not. Returns nil if the value is NULL.
+func jsonArrayFromRow(ctx *sql.Context, row sql.Row, expr sql.Expression) (types.JsonArray, error) {
+	v, err := expr.Eval(ctx, row)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	v, _, err = types.JSON.Convert(v)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := v.(sql.JSONWrapper).ToInterface()
+	if err != nil {
+		return nil, err
+	}
+	if arr, ok := doc.(types.JsonArray); ok {
+		return arr, nil
+	}
+	if arr, ok := doc.([]interface{}); ok {
+		return arr, nil
+	}
+	return types.JsonArray{doc}, nil
+}
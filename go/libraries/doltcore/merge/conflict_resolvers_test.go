@@ -0,0 +1,118 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/expranalysis"
+)
+
+func TestConflictResolverExpressions(t *testing.T) {
+	tests := []struct {
+		name     string
+		typ      sql.Type
+		resolver string
+		row      sql.Row
+		expected string
+	}{
+		{
+			name:     "greatest",
+			typ:      types.Int32,
+			resolver: "greatest(ours, theirs)",
+			row:      sql.Row{int32(1), int32(5), int32(3)},
+			expected: "5",
+		},
+		{
+			name:     "sum of deltas",
+			typ:      types.Int64,
+			resolver: "ours + theirs - base",
+			row:      sql.Row{int64(10), int64(15), int64(12)},
+			expected: "17",
+		},
+		{
+			name:     "sum of deltas with no base",
+			typ:      types.Int64,
+			resolver: "ours + theirs - coalesce(base, 0)",
+			row:      sql.Row{nil, int64(15), int64(12)},
+			expected: "27",
+		},
+		{
+			name:     "json array union",
+			typ:      types.JSON,
+			resolver: "json_array_union(ours, theirs)",
+			row:      sql.Row{types.MustJSON(`["a"]`), types.MustJSON(`["a", "b"]`), types.MustJSON(`["c", "a", {"d": 1}]`)},
+			expected: `["a", "b", "c", {"d": 1}]`,
+		},
+		{
+			name:     "json array union with scalar",
+			typ:      types.JSON,
+			resolver: "json_array_union(ours, theirs)",
+			row:      sql.Row{nil, types.MustJSON(`["a"]`), types.MustJSON(`"b"`)},
+			expected: `["a", "b"]`,
+		},
+		{
+			name:     "json array union of nulls",
+			typ:      types.JSON,
+			resolver: "json_array_union(ours, theirs)",
+			row:      sql.Row{types.MustJSON(`["a"]`), nil, nil},
+			expected: "NULL",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := sql.NewEmptyContext()
+			expr, err := expranalysis.ResolveConflictResolverExpression(ctx, test.typ, test.resolver, ConflictResolverFunctions...)
+			require.NoError(t, err)
+
+			res, err := expr.Eval(ctx, test.row)
+			require.NoError(t, err)
+			if res != nil {
+				res, _, err = test.typ.Convert(res)
+				require.NoError(t, err)
+			}
+			if jw, ok := res.(sql.JSONWrapper); ok {
+				res, err = types.StringifyJSON(jw)
+				require.NoError(t, err)
+			}
+			assert.Equal(t, test.expected, fmtResolved(res))
+		})
+	}
+}
+
+func TestInvalidConflictResolverExpression(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	_, err := expranalysis.ResolveConflictResolverExpression(ctx, types.Int32, "greatest(ours,", ConflictResolverFunctions...)
+	assert.Error(t, err)
+	_, err = expranalysis.ResolveConflictResolverExpression(ctx, types.Int32, "greatest(ours, other)", ConflictResolverFunctions...)
+	assert.Error(t, err)
+}
+
+func fmtResolved(v interface{}) string {
+	if v == nil {
+		return "NULL"
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	s, _, _ := types.LongText.Convert(v)
+	return s.(string)
+}
@@ -48,7 +48,9 @@ var ErrMultipleViolationsForRow = errors.New("multiple violations for row not su
 
 var ErrSameTblAddedTwice = goerrors.NewKind("table with same name '%s' added in 2 commits can't be merged")
 
-func MergeCommits(ctx *sql.Context, commit, mergeCommit *doltdb.Commit, opts editor.Options) (*Result, error) {
+// MergeCommits three-way merges |mergeCommit| into |commit|, merging each table with the strategy given by
+// |strategies|.
+func MergeCommits(ctx *sql.Context, commit, mergeCommit *doltdb.Commit, opts editor.Options, strategies Strategies) (*Result, error) {
 	optCmt, err := doltdb.GetCommitAncestor(ctx, commit, mergeCommit)
 	if err != nil {
 		return nil, err
@@ -77,6 +79,7 @@ func MergeCommits(ctx *sql.Context, commit, mergeCommit *doltdb.Commit, opts edi
 	mo := MergeOpts{
 		IsCherryPick:        false,
 		KeepSchemaConflicts: true,
+		Strategies:          strategies,
 	}
 	return MergeRoots(ctx, ourRoot, theirRoot, ancRoot, mergeCommit, ancCommit, opts, mo)
 }
@@ -174,6 +177,13 @@ func MergeRoots(
 		err            error
 	)
 
+	if mergeOpts.Strategies.KeepsOurs() {
+		return &Result{
+			Root:  ourRoot,
+			Stats: make(map[doltdb.TableName]*MergeStats),
+		}, nil
+	}
+
 	nbf = ourRoot.VRW().Format()
 	if !types.IsFormat_DOLT(nbf) {
 		ourRoot, conflictStash, err = stashConflicts(ctx, ourRoot)
@@ -241,6 +251,11 @@ func MergeRoots(
 		return nil, err
 	}
 
+	merger.resolvers, err = GetConflictResolvers(ctx, ourRoot)
+	if err != nil {
+		return nil, err
+	}
+
 	destSchemaNames, err := getDatabaseSchemaNames(ctx, ourRoot)
 	if err != nil {
 		return nil, err
@@ -91,6 +91,11 @@ func mergeProllyTable(
 		sqlCtx = sql.NewContext(ctx)
 	}
 
+	valueMerger.resolvers, err = resolveConflictResolvers(sqlCtx, tm, mergedSch)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	var stats *MergeStats
 	mergeTbl, stats, err = mergeProllyTableData(sqlCtx, tm, mergedSch, mergeTbl, valueMerger, mergeInfo, diffInfo)
 	if err != nil {
@@ -184,6 +189,8 @@ func mergeProllyTableData(ctx *sql.Context, tm *TableMerger, finalSch schema.Sch
 		} else if err != nil {
 			return nil, nil, err
 		}
+		diff = tm.resolveDataConflict(diff)
+
 		cnt, err := uniq.validateDiff(ctx, diff)
 		if err != nil {
 			return nil, nil, err
@@ -326,6 +333,36 @@ func mergeProllyTableData(ctx *sql.Context, tm *TableMerger, finalSch schema.Sch
 	return finalTbl, s, nil
 }
 
+// resolveDataConflict returns |diff| unchanged, unless it is a conflicting change to a row and the table's merge
+// strategy resolves conflicts in favor of one side of the merge. In that case, it returns |diff| as if only that
+// side of the merge had changed the row.
+func (tm *TableMerger) resolveDataConflict(diff tree.ThreeWayDiff) tree.ThreeWayDiff {
+	if diff.Op != tree.DiffOpDivergentModifyConflict && diff.Op != tree.DiffOpDivergentDeleteConflict {
+		return diff
+	}
+
+	switch tm.strategy {
+	case TableMergeStrategyMergeOurs:
+		if diff.Left == nil {
+			diff.Op = tree.DiffOpLeftDelete
+		} else {
+			diff.Op = tree.DiffOpLeftModify
+		}
+	case TableMergeStrategyMergeTheirs:
+		// The row in the destination is the left-side row, so secondary index
+		// edits must be made relative to it rather than to the base row.
+		diff.Base = diff.Left
+		if diff.Right == nil {
+			diff.Op = tree.DiffOpRightDelete
+		} else if diff.Left == nil {
+			diff.Op = tree.DiffOpRightAdd
+		} else {
+			diff.Op = tree.DiffOpRightModify
+		}
+	}
+	return diff
+}
+
 func threeWayDiffer(ctx context.Context, tm *TableMerger, valueMerger *valueMerger, diffInfo tree.ThreeWayDiffInfo) (*tree.ThreeWayDiffer[val.Tuple, val.TupleDesc], error) {
 	lr, err := tm.leftTbl.GetRowData(ctx)
 	if err != nil {
@@ -1624,6 +1661,8 @@ type valueMerger struct {
 	syncPool                               pool.BuffPool
 	keyless                                bool
 	ns                                     tree.NodeStore
+	// resolvers resolve concurrent modifications to the columns of the merged schema, keyed by column index.
+	resolvers map[int]conflictResolver
 }
 
 func newValueMerger(merged, leftSch, rightSch, baseSch schema.Schema, syncPool pool.BuffPool, ns tree.NodeStore) *valueMerger {
@@ -1895,6 +1934,11 @@ func (m *valueMerger) processColumn(ctx *sql.Context, i int, left, right, base v
 			return leftCol, false, nil
 		}
 
+		if resolver, ok := m.resolvers[i]; ok {
+			result, err = m.resolveColumn(ctx, i, resolver, nil, leftCol, rightCol)
+			return result, false, err
+		}
+
 		// conflicting inserts
 		return nil, true, nil
 	}
@@ -1959,6 +2003,11 @@ func (m *valueMerger) processColumn(ctx *sql.Context, i int, left, right, base v
 		if generatedColumn {
 			return leftCol, false, nil
 		}
+		// a conflict resolver registered for the column computes the merged value
+		if resolver, ok := m.resolvers[i]; ok {
+			result, err = m.resolveColumn(ctx, i, resolver, baseCol, leftCol, rightCol)
+			return result, false, err
+		}
 		// concurrent modification
 		// if the result type is JSON, we can attempt to merge the JSON changes.
 		dontMergeJsonVar, err := ctx.Session.GetSessionVariable(ctx, "dolt_dont_merge_json")
@@ -1970,7 +2019,11 @@ func (m *valueMerger) processColumn(ctx *sql.Context, i int, left, right, base v
 			return nil, true, err
 		}
 		if _, ok := sqlType.(types.JsonType); ok && !disallowJsonMerge {
-			return m.mergeJSONAddr(ctx, baseCol, leftCol, rightCol)
+			arrays, err := newJsonArrayMerger(ctx)
+			if err != nil {
+				return nil, true, err
+			}
+			return m.mergeJSONAddr(ctx, baseCol, leftCol, rightCol, arrays)
 		}
 		// otherwise, this is a conflict.
 		return nil, true, nil
@@ -1981,7 +2034,9 @@ func (m *valueMerger) processColumn(ctx *sql.Context, i int, left, right, base v
 	}
 }
 
-func (m *valueMerger) mergeJSONAddr(ctx context.Context, baseAddr []byte, leftAddr []byte, rightAddr []byte) (resultAddr []byte, conflict bool, err error) {
+// mergeJSONAddr merges the JSON documents stored at |baseAddr|, |leftAddr| and |rightAddr|. If |arrays| is non-nil,
+// it is used to merge concurrent changes to the same array.
+func (m *valueMerger) mergeJSONAddr(ctx context.Context, baseAddr []byte, leftAddr []byte, rightAddr []byte, arrays *jsonArrayMerger) (resultAddr []byte, conflict bool, err error) {
 	baseDoc, err := tree.NewJSONDoc(hash.New(baseAddr), m.ns).ToIndexedJSONDocument(ctx)
 	if err != nil {
 		return nil, true, err
@@ -1995,7 +2050,7 @@ func (m *valueMerger) mergeJSONAddr(ctx context.Context, baseAddr []byte, leftAd
 		return nil, true, err
 	}
 
-	mergedDoc, conflict, err := mergeJSON(ctx, m.ns, baseDoc, leftDoc, rightDoc)
+	mergedDoc, conflict, err := mergeJSON(ctx, m.ns, baseDoc, leftDoc, rightDoc, arrays)
 	if err != nil {
 		return nil, true, err
 	}
@@ -2011,9 +2066,19 @@ func (m *valueMerger) mergeJSONAddr(ctx context.Context, baseAddr []byte, leftAd
 	return mergedAddr[:], false, nil
 }
 
-func mergeJSON(ctx context.Context, ns tree.NodeStore, base, left, right sql.JSONWrapper) (resultDoc sql.JSONWrapper, conflict bool, err error) {
+func mergeJSON(ctx context.Context, ns tree.NodeStore, base, left, right sql.JSONWrapper, arrays *jsonArrayMerger) (resultDoc sql.JSONWrapper, conflict bool, err error) {
 	// First, deserialize each value into JSON.
-	// We can only merge if the value at all three commits is a JSON object.
+	// We can only merge if the value at all three commits is a JSON object, or a JSON array if array merging is enabled.
+
+	if arrays != nil {
+		isArrays, err := allJsonArrays(base, left, right)
+		if err != nil {
+			return nil, true, err
+		}
+		if isArrays {
+			return arrays.merge(ctx, ns, base, left, right)
+		}
+	}
 
 	baseIsObject, err := tree.IsJsonObject(base)
 	if err != nil {
@@ -2090,6 +2155,11 @@ func mergeJSON(ctx context.Context, ns tree.NodeStore, base, left, right sql.JSO
 		leftDiffer:  leftDiffer,
 		rightDiffer: rightDiffer,
 		ns:          ns,
+		arrays:      arrays,
+	}
+	if isBaseIndexed && isLeftIndexed && isRightIndexed {
+		threeWayDiffer.base, threeWayDiffer.left, threeWayDiffer.right = indexedBase, indexedLeft, indexedRight
+		threeWayDiffer.indexed = true
 	}
 
 	// Compute the merged object by applying diffs to the left object as needed.
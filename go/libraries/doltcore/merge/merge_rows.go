@@ -16,6 +16,7 @@ package merge
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/dolthub/go-mysql-server/sql"
 
@@ -52,6 +53,9 @@ type MergeOpts struct {
 	// dolt_verify_constraints() stored procedure to allow callers to verify constraints for a
 	// subset of tables.
 	RecordViolationsForTables map[doltdb.TableName]struct{}
+	// Strategies are the strategies used to merge each table. The zero value three-way merges
+	// every table and records any conflicts.
+	Strategies Strategies
 }
 
 type TableMerger struct {
@@ -76,6 +80,14 @@ type TableMerger struct {
 	// exception is for the dolt_verify_constraints() stored procedure, which allows callers to
 	// only record constraint violations for a specified subset of tables.
 	recordViolations bool
+
+	// strategy is the strategy used to merge this table. Conflicting rows are resolved with
+	// one side of the merge for TableMergeStrategyMergeOurs and TableMergeStrategyMergeTheirs.
+	strategy TableMergeStrategy
+
+	// resolvers are the conflict resolver expressions for the columns of this table, keyed by
+	// lower-cased column name.
+	resolvers map[string]string
 }
 
 func (tm TableMerger) tableHashes() (left, right, anc hash.Hash, err error) {
@@ -107,6 +119,9 @@ type RootMerger struct {
 
 	vrw types.ValueReadWriter
 	ns  tree.NodeStore
+
+	// resolvers are the conflict resolvers used to resolve concurrent modifications to a column.
+	resolvers ConflictResolvers
 }
 
 // NewMerger creates a new merger utility object.
@@ -157,6 +172,13 @@ func (rm *RootMerger) MergeTable(
 		return nil, nil, err
 	}
 
+	switch tm.strategy {
+	case TableMergeStrategyOurs:
+		return keepTable(tm.leftTbl, tm.leftTbl)
+	case TableMergeStrategyTheirs:
+		return keepTable(tm.rightTbl, tm.leftTbl)
+	}
+
 	// short-circuit here if we can
 	finished, stats, err := rm.maybeShortCircuit(ctx, tm, mergeOpts)
 	if finished != nil || stats != nil || err != nil {
@@ -187,6 +209,8 @@ func (rm *RootMerger) MergeTable(
 	var tbl *doltdb.Table
 	if types.IsFormat_DOLT(tm.vrw.Format()) {
 		tbl, stats, err = mergeProllyTable(ctx, tm, mergeSch, mergeInfo, diffInfo)
+	} else if tm.strategy == TableMergeStrategyMergeOurs || tm.strategy == TableMergeStrategyMergeTheirs {
+		return nil, nil, fmt.Errorf("merge strategy '%s' is not supported for the %s format", tm.strategy, tm.vrw.Format().VersionString())
 	} else {
 		tbl, stats, err = mergeNomsTable(ctx, tm, mergeSch, rm.vrw, opts)
 	}
@@ -196,6 +220,30 @@ func (rm *RootMerger) MergeTable(
 	return &MergedTable{table: tbl}, stats, nil
 }
 
+// keepTable returns |kept|, one side's version of a table, as the result of merging the table into |left|, our
+// side's version. Either may be nil if the table does not exist on that side.
+func keepTable(kept, left *doltdb.Table) (*MergedTable, *MergeStats, error) {
+	if kept == nil {
+		return &MergedTable{}, &MergeStats{Operation: TableRemoved}, nil
+	}
+	if left == nil {
+		return &MergedTable{table: kept}, &MergeStats{Operation: TableAdded}, nil
+	}
+
+	keptHash, err := kept.HashOf()
+	if err != nil {
+		return nil, nil, err
+	}
+	leftHash, err := left.HashOf()
+	if err != nil {
+		return nil, nil, err
+	}
+	if keptHash == leftHash {
+		return &MergedTable{table: kept}, &MergeStats{Operation: TableUnmodified}, nil
+	}
+	return &MergedTable{table: kept}, &MergeStats{Operation: TableModified}, nil
+}
+
 func (rm *RootMerger) makeTableMerger(ctx context.Context, tblName doltdb.TableName, mergeOpts MergeOpts) (*TableMerger, error) {
 	recordViolations := true
 	if mergeOpts.RecordViolationsForTables != nil {
@@ -211,6 +259,8 @@ func (rm *RootMerger) makeTableMerger(ctx context.Context, tblName doltdb.TableN
 		vrw:              rm.vrw,
 		ns:               rm.ns,
 		recordViolations: recordViolations,
+		strategy:         mergeOpts.Strategies.ForTable(tblName),
+		resolvers:        rm.resolvers[tblName.ToLower()],
 	}
 
 	var err error
@@ -0,0 +1,190 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// TableMergeStrategy determines how the changes made to a table on both sides of a merge are combined.
+type TableMergeStrategy string
+
+const (
+	// TableMergeStrategyMerge three-way merges the table, recording any conflicts. This is the default.
+	TableMergeStrategyMerge TableMergeStrategy = "merge"
+	// TableMergeStrategyMergeOurs three-way merges the table, resolving conflicting rows with our side of the merge.
+	TableMergeStrategyMergeOurs TableMergeStrategy = "merge-ours"
+	// TableMergeStrategyMergeTheirs three-way merges the table, resolving conflicting rows with their side of the merge.
+	TableMergeStrategyMergeTheirs TableMergeStrategy = "merge-theirs"
+	// TableMergeStrategyOurs keeps our side's version of the table, ignoring all of their changes to it.
+	TableMergeStrategyOurs TableMergeStrategy = "ours"
+	// TableMergeStrategyTheirs takes their side's version of the table, discarding all of our changes to it.
+	TableMergeStrategyTheirs TableMergeStrategy = "theirs"
+)
+
+// TableMergeStrategies are the values of the strategy column of the dolt_merge_strategies system table. Their
+// order is part of the table's schema and must not change.
+var TableMergeStrategies = []string{
+	string(TableMergeStrategyMerge),
+	string(TableMergeStrategyMergeOurs),
+	string(TableMergeStrategyMergeTheirs),
+	string(TableMergeStrategyOurs),
+	string(TableMergeStrategyTheirs),
+}
+
+const (
+	// StrategyOurs is the merge strategy which keeps our side of the merge for every table.
+	StrategyOurs = "ours"
+	// StrategyRecursive is the default merge strategy, which three-way merges every table.
+	StrategyRecursive = "recursive"
+
+	// StrategyOptionOurs resolves conflicting rows with our side of the merge.
+	StrategyOptionOurs = "ours"
+	// StrategyOptionTheirs resolves conflicting rows with their side of the merge.
+	StrategyOptionTheirs = "theirs"
+)
+
+// Strategies are the merge strategies used for each table in a merge. The zero value three-way merges every table.
+type Strategies struct {
+	// Default is the strategy for tables with no entry in Tables. The empty string is TableMergeStrategyMerge.
+	Default TableMergeStrategy
+	// Tables maps lower-cased table names to the strategy used to merge them.
+	Tables map[doltdb.TableName]TableMergeStrategy
+}
+
+// ForTable returns the strategy used to merge |tblName|.
+func (s Strategies) ForTable(tblName doltdb.TableName) TableMergeStrategy {
+	if strategy, ok := s.Tables[tblName.ToLower()]; ok {
+		return strategy
+	}
+	if s.Default == "" {
+		return TableMergeStrategyMerge
+	}
+	return s.Default
+}
+
+// KeepsOurs returns whether these strategies keep our side of the merge for every table.
+func (s Strategies) KeepsOurs() bool {
+	return s.Default == TableMergeStrategyOurs && len(s.Tables) == 0
+}
+
+// NewStrategies returns the Strategies for a merge run with the merge strategy |strategy| and the strategy option
+// |option|, either of which may be empty. Unless |strategy| is StrategyOurs, tables listed in the
+// dolt_merge_strategies table of |root| are merged with their listed strategy instead of the one chosen by |option|.
+func NewStrategies(ctx context.Context, root doltdb.RootValue, strategy, option string) (Strategies, error) {
+	switch strings.ToLower(strategy) {
+	case StrategyOurs:
+		if option != "" {
+			return Strategies{}, fmt.Errorf("strategy option '%s' cannot be used with merge strategy '%s'", option, StrategyOurs)
+		}
+		return Strategies{Default: TableMergeStrategyOurs}, nil
+	case "", StrategyRecursive:
+	default:
+		return Strategies{}, fmt.Errorf("unknown merge strategy '%s', expected one of: %s, %s", strategy, StrategyRecursive, StrategyOurs)
+	}
+
+	var s Strategies
+	switch strings.ToLower(option) {
+	case "":
+	case StrategyOptionOurs:
+		s.Default = TableMergeStrategyMergeOurs
+	case StrategyOptionTheirs:
+		s.Default = TableMergeStrategyMergeTheirs
+	default:
+		return Strategies{}, fmt.Errorf("unknown merge strategy option '%s', expected one of: %s, %s", option, StrategyOptionOurs, StrategyOptionTheirs)
+	}
+
+	var err error
+	s.Tables, err = GetTableMergeStrategies(ctx, root)
+	if err != nil {
+		return Strategies{}, err
+	}
+	return s, nil
+}
+
+// GetTableMergeStrategies returns the per-table merge strategies stored in the dolt_merge_strategies table of
+// |root|, keyed by lower-cased table name.
+func GetTableMergeStrategies(ctx context.Context, root doltdb.RootValue) (map[doltdb.TableName]TableMergeStrategy, error) {
+	tbl, ok, err := root.GetTable(ctx, doltdb.TableName{Name: doltdb.MergeStrategiesTableName})
+	if err != nil {
+		return nil, err
+	}
+	if !ok || tbl.Format() == types.Format_LD_1 {
+		// dolt_merge_strategies is not supported for the legacy storage format.
+		return nil, nil
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if sch.GetPKCols().Size() != 1 || sch.GetNonPKCols().Size() != 1 {
+		return nil, fmt.Errorf("%s had unexpected schema, this should never happen", doltdb.MergeStrategiesTableName)
+	}
+	enumType, ok := sch.GetNonPKCols().GetByIndex(0).TypeInfo.ToSqlType().(sql.EnumType)
+	if !ok {
+		return nil, fmt.Errorf("%s had unexpected schema, this should never happen", doltdb.MergeStrategiesTableName)
+	}
+
+	idx, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m := durable.MapFromIndex(idx)
+	keyDesc, valueDesc := sch.GetMapDescriptors(m.NodeStore())
+
+	iter, err := m.IterAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	strategies := make(map[doltdb.TableName]TableMergeStrategy)
+	for {
+		k, v, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		name, ok := keyDesc.GetString(0, k)
+		if !ok {
+			return nil, fmt.Errorf("could not read table name from %s", doltdb.MergeStrategiesTableName)
+		}
+		i, ok := valueDesc.GetEnum(0, v)
+		if !ok {
+			return nil, fmt.Errorf("could not read strategy for table '%s' from %s", name, doltdb.MergeStrategiesTableName)
+		}
+		strategy, ok := enumType.At(int(i))
+		if !ok {
+			return nil, fmt.Errorf("unknown strategy for table '%s' in %s", name, doltdb.MergeStrategiesTableName)
+		} else if strategy == "" {
+			// the empty enum value, written by INSERT IGNORE of an invalid strategy, uses the default strategy
+			continue
+		}
+		strategies[doltdb.TableName{Name: name}.ToLower()] = TableMergeStrategy(strategy)
+	}
+
+	return strategies, nil
+}
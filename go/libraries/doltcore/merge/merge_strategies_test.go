@@ -0,0 +1,71 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+)
+
+func TestStrategiesForTable(t *testing.T) {
+	var zero Strategies
+	assert.Equal(t, TableMergeStrategyMerge, zero.ForTable(doltdb.TableName{Name: "t"}))
+	assert.False(t, zero.KeepsOurs())
+
+	s := Strategies{
+		Default: TableMergeStrategyMergeTheirs,
+		Tables: map[doltdb.TableName]TableMergeStrategy{
+			{Name: "t"}: TableMergeStrategyOurs,
+		},
+	}
+	assert.Equal(t, TableMergeStrategyOurs, s.ForTable(doltdb.TableName{Name: "t"}))
+	assert.Equal(t, TableMergeStrategyOurs, s.ForTable(doltdb.TableName{Name: "T"}))
+	assert.Equal(t, TableMergeStrategyMergeTheirs, s.ForTable(doltdb.TableName{Name: "u"}))
+	assert.False(t, s.KeepsOurs())
+
+	ours := Strategies{Default: TableMergeStrategyOurs}
+	assert.True(t, ours.KeepsOurs())
+	assert.Equal(t, TableMergeStrategyOurs, ours.ForTable(doltdb.TableName{Name: "t"}))
+}
+
+func TestNewStrategiesErrors(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := NewStrategies(ctx, nil, "OURS", "")
+	require.NoError(t, err)
+	assert.True(t, s.KeepsOurs())
+
+	tests := []struct {
+		name     string
+		strategy string
+		option   string
+	}{
+		{name: "unknown strategy", strategy: "octopus"},
+		{name: "unknown option", option: "patience"},
+		{name: "unknown option for recursive", strategy: StrategyRecursive, option: "patience"},
+		{name: "option with ours", strategy: StrategyOurs, option: StrategyOptionTheirs},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewStrategies(ctx, nil, test.strategy, test.option)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,560 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/shopspring/decimal"
+
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+)
+
+// maxJsonArrayMergeEdits limits the number of elements which can be inserted or removed in the changed region of an
+// array on each branch. Concurrent changes to arrays which differ by more edits are reported as conflicts.
+const maxJsonArrayMergeEdits = 1 << 10
+
+// jsonArrayMerger merges concurrent changes to the same JSON array, which are otherwise reported as conflicts.
+// Arrays whose elements are all objects with a unique scalar identity field are merged as keyed sets: each element is
+// merged with the elements that have the same identity on the other branches. Other arrays are merged as sequences,
+// matching the elements of each branch to the base array by their longest common subsequence.
+type jsonArrayMerger struct {
+	identityFields []string
+}
+
+// newJsonArrayMerger returns a jsonArrayMerger configured by the @@dolt_merge_json_arrays and
+// @@dolt_json_array_identity_fields session variables, or nil if array merging is disabled.
+func newJsonArrayMerger(ctx *sql.Context) (*jsonArrayMerger, error) {
+	mergeArraysVar, err := ctx.Session.GetSessionVariable(ctx, "dolt_merge_json_arrays")
+	if err != nil {
+		return nil, err
+	}
+	mergeArrays, err := sql.ConvertToBool(ctx, mergeArraysVar)
+	if err != nil || !mergeArrays {
+		return nil, err
+	}
+
+	identityFieldsVar, err := ctx.Session.GetSessionVariable(ctx, "dolt_json_array_identity_fields")
+	if err != nil {
+		return nil, err
+	}
+	identityFields, ok := identityFieldsVar.(string)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for @@dolt_json_array_identity_fields: %T", identityFieldsVar)
+	}
+
+	m := &jsonArrayMerger{}
+	for _, field := range strings.Split(identityFields, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			m.identityFields = append(m.identityFields, field)
+		}
+	}
+	return m, nil
+}
+
+// merge performs a three-way merge of the arrays |base|, |left| and |right|. Returns true if the changes can't be
+// merged.
+func (m *jsonArrayMerger) merge(ctx context.Context, ns tree.NodeStore, base, left, right sql.JSONWrapper) (sql.JSONWrapper, bool, error) {
+	var elems [3][][]byte
+	for i, doc := range []sql.JSONWrapper{base, left, right} {
+		arr, isArray, err := jsonArrayElements(ctx, doc)
+		if err != nil {
+			return nil, true, err
+		}
+		if !isArray {
+			cmp, err := types.CompareJSON(left, right)
+			if err != nil || cmp != 0 {
+				return nil, true, err
+			}
+			return left, false, nil
+		}
+		elems[i] = arr
+	}
+	return m.mergeElements(ctx, ns, elems[0], elems[1], elems[2])
+}
+
+// mergeElements performs a three-way merge of arrays with the serialized elements |base|, |left| and |right|. The
+// elements at the start and end of the arrays which are the same on every branch are kept as they are, and only the
+// elements between them are merged.
+func (m *jsonArrayMerger) mergeElements(ctx context.Context, ns tree.NodeStore, base, left, right [][]byte) (sql.JSONWrapper, bool, error) {
+	prefix := 0
+	for prefix < len(base) && prefix < len(left) && prefix < len(right) &&
+		bytes.Equal(base[prefix], left[prefix]) && bytes.Equal(base[prefix], right[prefix]) {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(base)-prefix && suffix < len(left)-prefix && suffix < len(right)-prefix &&
+		bytes.Equal(base[len(base)-1-suffix], left[len(left)-1-suffix]) && bytes.Equal(base[len(base)-1-suffix], right[len(right)-1-suffix]) {
+		suffix++
+	}
+	baseMid, leftMid, rightMid := base[prefix:len(base)-suffix], left[prefix:len(left)-suffix], right[prefix:len(right)-suffix]
+
+	unchanged := slices.Concat(left[:prefix], left[len(left)-suffix:])
+	merged, conflict, err := m.mergeChangedElements(ctx, ns, baseMid, leftMid, rightMid, unchanged)
+	if err != nil || conflict {
+		return nil, true, err
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, 2+len(base)*16))
+	buf.WriteByte('[')
+	for _, elems := range [][][]byte{left[:prefix], merged, left[len(left)-suffix:]} {
+		for _, elem := range elems {
+			if buf.Len() > 1 {
+				buf.WriteByte(',')
+			}
+			buf.Write(elem)
+		}
+	}
+	buf.WriteByte(']')
+	return types.NewLazyJSONDocument(buf.Bytes()), false, nil
+}
+
+// mergeChangedElements merges the serialized elements of the region of an array changed on at least one branch.
+// |unchanged| are the elements outside of that region, which are the same on every branch. The changed elements are
+// only merged as keyed sets if their identities are also unique among the unchanged elements.
+func (m *jsonArrayMerger) mergeChangedElements(ctx context.Context, ns tree.NodeStore, base, left, right, unchanged [][]byte) ([][]byte, bool, error) {
+	if len(base) == 0 && len(left) == 0 && len(right) == 0 {
+		return nil, false, nil
+	}
+
+	var vals [4]types.JsonArray
+	for i, elems := range [][][]byte{base, left, right, unchanged} {
+		vals[i] = make(types.JsonArray, len(elems))
+		for j, elem := range elems {
+			val, err := types.NewLazyJSONDocument(elem).ToInterface()
+			if err != nil {
+				return nil, true, err
+			}
+			vals[i][j] = val
+		}
+	}
+
+	field, ok := m.identityField(slices.Concat(vals[3], vals[0]), slices.Concat(vals[3], vals[1]), slices.Concat(vals[3], vals[2]))
+	if !ok {
+		return mergeJsonSequences(base, left, right)
+	}
+	merged, conflict, err := m.mergeKeyed(ctx, ns, field, vals[0], vals[1], vals[2])
+	if err != nil || conflict {
+		return nil, true, err
+	}
+	elems := make([][]byte, len(merged))
+	for i, val := range merged {
+		if elems[i], err = types.MarshallJsonValue(val); err != nil {
+			return nil, true, err
+		}
+	}
+	return elems, false, nil
+}
+
+// jsonArrayElements returns the serialized elements of |doc|, or false if it isn't an array. The elements of an
+// IndexedJsonDocument are read without decoding the document.
+func jsonArrayElements(ctx context.Context, doc sql.JSONWrapper) ([][]byte, bool, error) {
+	if indexed, ok := doc.(tree.IndexedJsonDocument); ok {
+		return indexed.ArrayElementsWithKey(ctx, nil)
+	}
+	val, err := doc.ToInterface()
+	if err != nil {
+		return nil, false, err
+	}
+	arr, ok := val.(types.JsonArray)
+	if !ok {
+		return nil, false, nil
+	}
+	elems := make([][]byte, len(arr))
+	for i, elem := range arr {
+		if elems[i], err = types.MarshallJsonValue(elem); err != nil {
+			return nil, false, err
+		}
+	}
+	return elems, true, nil
+}
+
+// identityField returns the first identity field which every element of |arrays| has, with a scalar value that is
+// unique within its array.
+func (m *jsonArrayMerger) identityField(arrays ...types.JsonArray) (string, bool) {
+	for _, field := range m.identityFields {
+		if hasUniqueIdentity(field, arrays...) {
+			return field, true
+		}
+	}
+	return "", false
+}
+
+func hasUniqueIdentity(field string, arrays ...types.JsonArray) bool {
+	for _, arr := range arrays {
+		seen := make(map[string]struct{}, len(arr))
+		for _, elem := range arr {
+			id, ok := jsonIdentity(field, elem)
+			if !ok {
+				return false
+			}
+			if _, ok = seen[id]; ok {
+				return false
+			}
+			seen[id] = struct{}{}
+		}
+	}
+	return true
+}
+
+// jsonIdentity returns the value of |field| in the object |elem| as a string, if |elem| is an object and the value is
+// a scalar.
+func jsonIdentity(field string, elem interface{}) (string, bool) {
+	obj, ok := elem.(types.JsonObject)
+	if !ok {
+		return "", false
+	}
+	switch id := obj[field].(type) {
+	case string:
+		return "s" + id, true
+	case bool:
+		return fmt.Sprintf("b%t", id), true
+	case float64, float32, int64, int32, int16, int8, uint64, uint32, uint16, uint8, decimal.Decimal:
+		return fmt.Sprintf("n%v", id), true
+	default:
+		return "", false
+	}
+}
+
+// mergeKeyed merges arrays of objects identified by |field|. Elements are added, removed and modified according to
+// the changes made on each branch, and elements modified on both branches are merged recursively. Removing an element
+// on one branch and modifying it on the other is a conflict.
+func (m *jsonArrayMerger) mergeKeyed(ctx context.Context, ns tree.NodeStore, field string, base, left, right types.JsonArray) (types.JsonArray, bool, error) {
+	baseIDs, baseByID := indexJsonArray(field, base)
+	leftIDs, leftByID := indexJsonArray(field, left)
+	rightIDs, rightByID := indexJsonArray(field, right)
+
+	merged := make(map[string]interface{})
+	seen := make(map[string]struct{})
+	for _, ids := range [][]string{baseIDs, leftIDs, rightIDs} {
+		for _, id := range ids {
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+
+			b, inBase := baseByID[id]
+			l, inLeft := leftByID[id]
+			r, inRight := rightByID[id]
+			elem, keep, conflict, err := m.mergeElement(ctx, ns, b, l, r, inBase, inLeft, inRight)
+			if err != nil || conflict {
+				return nil, true, err
+			}
+			if keep {
+				merged[id] = elem
+			}
+		}
+	}
+
+	// Use the order of the left array, unless only the right branch reordered the elements of the base array.
+	primary, secondary := leftIDs, rightIDs
+	if sameJsonArrayOrder(baseIDs, leftIDs, baseByID, leftByID) && !sameJsonArrayOrder(baseIDs, rightIDs, baseByID, rightByID) {
+		primary, secondary = rightIDs, leftIDs
+	}
+
+	result := make(types.JsonArray, 0, len(merged))
+	for _, id := range orderJsonArrayIDs(primary, secondary) {
+		if elem, ok := merged[id]; ok {
+			result = append(result, elem)
+		}
+	}
+	return result, false, nil
+}
+
+// mergeElement merges the values of a keyed array element on each branch. Returns whether the element is kept in the
+// merged array, and whether the changes conflict.
+func (m *jsonArrayMerger) mergeElement(ctx context.Context, ns tree.NodeStore, base, left, right interface{}, inBase, inLeft, inRight bool) (elem interface{}, keep bool, conflict bool, err error) {
+	switch {
+	case !inLeft && !inRight:
+		// removed on both branches, or only in the base array
+		return nil, false, false, nil
+	case !inBase && !inRight:
+		return left, true, false, nil
+	case !inBase && !inLeft:
+		return right, true, false, nil
+	case !inBase:
+		// added on both branches
+		cmp, err := types.CompareJSON(left, right)
+		if err != nil || cmp != 0 {
+			return nil, false, true, err
+		}
+		return left, true, false, nil
+	case !inLeft:
+		cmp, err := types.CompareJSON(base, right)
+		if err != nil || cmp != 0 {
+			return nil, false, true, err
+		}
+		return nil, false, false, nil
+	case !inRight:
+		cmp, err := types.CompareJSON(base, left)
+		if err != nil || cmp != 0 {
+			return nil, false, true, err
+		}
+		return nil, false, false, nil
+	}
+
+	if cmp, err := types.CompareJSON(base, left); err != nil {
+		return nil, false, true, err
+	} else if cmp == 0 {
+		return right, true, false, nil
+	}
+	if cmp, err := types.CompareJSON(base, right); err != nil {
+		return nil, false, true, err
+	} else if cmp == 0 {
+		return left, true, false, nil
+	}
+
+	// modified on both branches
+	merged, conflict, err := mergeJSON(ctx, ns, types.JSONDocument{Val: base}, types.JSONDocument{Val: left}, types.JSONDocument{Val: right}, m)
+	if err != nil || conflict {
+		return nil, false, true, err
+	}
+	elem, err = merged.ToInterface()
+	if err != nil {
+		return nil, false, true, err
+	}
+	return elem, true, false, nil
+}
+
+// indexJsonArray returns the identities of the elements of |arr| in order, and the elements by their identity.
+func indexJsonArray(field string, arr types.JsonArray) ([]string, map[string]interface{}) {
+	ids := make([]string, len(arr))
+	byID := make(map[string]interface{}, len(arr))
+	for i, elem := range arr {
+		ids[i], _ = jsonIdentity(field, elem)
+		byID[ids[i]] = elem
+	}
+	return ids, byID
+}
+
+// sameJsonArrayOrder returns whether the elements common to the arrays |ids| and |otherIDs| are in the same order in
+// both arrays.
+func sameJsonArrayOrder(ids, otherIDs []string, byID, otherByID map[string]interface{}) bool {
+	var common []string
+	for _, id := range ids {
+		if _, ok := otherByID[id]; ok {
+			common = append(common, id)
+		}
+	}
+	i := 0
+	for _, id := range otherIDs {
+		if _, ok := byID[id]; !ok {
+			continue
+		}
+		if common[i] != id {
+			return false
+		}
+		i++
+	}
+	return true
+}
+
+// orderJsonArrayIDs returns the identities in |primary| in order, with the identities only in |secondary| inserted
+// after the nearest identity preceding them in |secondary| which is also in |primary|.
+func orderJsonArrayIDs(primary, secondary []string) []string {
+	inPrimary := make(map[string]struct{}, len(primary))
+	for _, id := range primary {
+		inPrimary[id] = struct{}{}
+	}
+
+	// identities are never empty, so the empty string anchors elements at the start of the array
+	anchor := ""
+	after := make(map[string][]string)
+	for _, id := range secondary {
+		if _, ok := inPrimary[id]; ok {
+			anchor = id
+			continue
+		}
+		after[anchor] = append(after[anchor], id)
+	}
+
+	ordered := make([]string, 0, len(primary)+len(secondary))
+	ordered = append(ordered, after[""]...)
+	for _, id := range primary {
+		ordered = append(ordered, id)
+		ordered = append(ordered, after[id]...)
+	}
+	return ordered
+}
+
+// mergeJsonSequences performs a three-way merge of the serialized elements |base|, |left| and |right| of arrays as
+// sequences. The elements of each branch are matched to the base array by their longest common subsequence, and the
+// regions between elements matched on both branches are merged: a region changed on only one branch takes that
+// branch's elements, and elements inserted at the same position on both branches are all kept, left first. Other
+// concurrent changes to the same region conflict.
+func mergeJsonSequences(base, left, right [][]byte) ([][]byte, bool, error) {
+	leftMatches, ok := matchJsonElements(base, left)
+	if !ok {
+		return nil, true, nil
+	}
+	rightMatches, ok := matchJsonElements(base, right)
+	if !ok {
+		return nil, true, nil
+	}
+
+	var merged [][]byte
+	b, l, r := 0, 0, 0
+	for {
+		// find the next base element which is unchanged on both branches
+		next, nextLeft, nextRight := b, len(left), len(right)
+		for ; next < len(base); next++ {
+			if leftMatches[next] >= 0 && rightMatches[next] >= 0 {
+				nextLeft, nextRight = leftMatches[next], rightMatches[next]
+				break
+			}
+		}
+
+		chunk, conflict := mergeJsonChunk(base[b:next], left[l:nextLeft], right[r:nextRight])
+		if conflict {
+			return nil, true, nil
+		}
+		merged = append(merged, chunk...)
+
+		if next == len(base) {
+			return merged, false, nil
+		}
+		merged = append(merged, left[nextLeft])
+		b, l, r = next+1, nextLeft+1, nextRight+1
+	}
+}
+
+// mergeJsonChunk merges a region of an array which was changed on at least one branch.
+func mergeJsonChunk(base, left, right [][]byte) ([][]byte, bool) {
+	switch {
+	case slices.EqualFunc(base, left, bytes.Equal):
+		return right, false
+	case slices.EqualFunc(base, right, bytes.Equal), slices.EqualFunc(left, right, bytes.Equal):
+		return left, false
+	case len(base) == 0:
+		// both branches inserted elements at the same position
+		merged := append([][]byte{}, left...)
+		inLeft := make(map[string]struct{}, len(left))
+		for _, elem := range left {
+			inLeft[string(elem)] = struct{}{}
+		}
+		for _, elem := range right {
+			if _, ok := inLeft[string(elem)]; !ok {
+				merged = append(merged, elem)
+			}
+		}
+		return merged, false
+	default:
+		return nil, true
+	}
+}
+
+// matchJsonElements returns, for each element of |base|, the index of the element of |other| it is matched to in a
+// longest common subsequence of the two arrays, or -1 if it was removed or changed. The subsequence is found with
+// Myers' diff algorithm, and false is returned if the arrays differ by more than maxJsonArrayMergeEdits insertions
+// and removals.
+func matchJsonElements(base, other [][]byte) ([]int, bool) {
+	matches := make([]int, len(base))
+	for i := range matches {
+		matches[i] = -1
+	}
+
+	prefix := 0
+	for prefix < len(base) && prefix < len(other) && bytes.Equal(base[prefix], other[prefix]) {
+		matches[prefix] = prefix
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(base)-prefix && suffix < len(other)-prefix && bytes.Equal(base[len(base)-1-suffix], other[len(other)-1-suffix]) {
+		matches[len(base)-1-suffix] = len(other) - 1 - suffix
+		suffix++
+	}
+
+	b, o := base[prefix:len(base)-suffix], other[prefix:len(other)-suffix]
+	if len(b) == 0 || len(o) == 0 {
+		return matches, true
+	}
+
+	// furthest[k+offset] is the furthest index into |b| reached on diagonal k, where k is the index into |b| minus
+	// the index into |o|. trace[d] holds the diagonals -d through d of furthest after d edits.
+	maxEdits := min(len(b)+len(o), maxJsonArrayMergeEdits)
+	offset := maxEdits + 1
+	furthest := make([]int, 2*maxEdits+3)
+	var trace [][]int
+	for d := 0; ; d++ {
+		if d > maxEdits {
+			return nil, false
+		}
+		done := false
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && furthest[offset+k-1] < furthest[offset+k+1]) {
+				x = furthest[offset+k+1]
+			} else {
+				x = furthest[offset+k-1] + 1
+			}
+			y := x - k
+			for x < len(b) && y < len(o) && bytes.Equal(b[x], o[y]) {
+				x++
+				y++
+			}
+			furthest[offset+k] = x
+			if x >= len(b) && y >= len(o) {
+				done = true
+				break
+			}
+		}
+		trace = append(trace, slices.Clone(furthest[offset-d:offset+d+1]))
+		if done {
+			break
+		}
+	}
+
+	// walk back from the end of both arrays, matching the elements of each diagonal run
+	x, y := len(b), len(o)
+	for d := len(trace) - 1; d > 0; d-- {
+		prev := trace[d-1]
+		k := x - y
+		prevK := k - 1
+		if k == -d || (k != d && prev[k-1+d-1] < prev[k+1+d-1]) {
+			prevK = k + 1
+		}
+		prevX := prev[prevK+d-1]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			matches[prefix+x] = prefix + y
+		}
+		x, y = prevX, prevY
+	}
+	for x > 0 && y > 0 {
+		x--
+		y--
+		matches[prefix+x] = prefix + y
+	}
+	return matches, true
+}
+
+// allJsonArrays returns whether each of |docs| is a JSON array.
+func allJsonArrays(docs ...sql.JSONWrapper) (bool, error) {
+	for _, doc := range docs {
+		isArray, err := tree.IsJsonArray(doc)
+		if err != nil || !isArray {
+			return false, err
+		}
+	}
+	return true, nil
+}
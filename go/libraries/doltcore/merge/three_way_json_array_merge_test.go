@@ -0,0 +1,220 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+)
+
+func TestMergeJSONArrays(t *testing.T) {
+	tests := []struct {
+		name              string
+		base, left, right string
+		// expected is empty if the merge conflicts
+		expected string
+	}{
+		{
+			name:     "keyed elements modified on both sides",
+			base:     `{"rules": [{"id": 1, "allow": false}, {"id": 2, "allow": false}]}`,
+			left:     `{"rules": [{"id": 1, "allow": true}, {"id": 2, "allow": false}]}`,
+			right:    `{"rules": [{"id": 1, "allow": false}, {"id": 2, "allow": true}]}`,
+			expected: `{"rules": [{"id": 1, "allow": true}, {"id": 2, "allow": true}]}`,
+		},
+		{
+			name:     "keyed element fields modified on both sides",
+			base:     `{"rules": [{"id": "a", "x": 1, "y": 1}]}`,
+			left:     `{"rules": [{"id": "a", "x": 2, "y": 1}]}`,
+			right:    `{"rules": [{"id": "a", "x": 1, "y": 2}]}`,
+			expected: `{"rules": [{"id": "a", "x": 2, "y": 2}]}`,
+		},
+		{
+			name:     "keyed elements added and removed",
+			base:     `{"rules": [{"id": 1}, {"id": 2}, {"id": 3}]}`,
+			left:     `{"rules": [{"id": 1}, {"id": 4}, {"id": 3}]}`,
+			right:    `{"rules": [{"id": 1}, {"id": 2}, {"id": 5}, {"id": 3}]}`,
+			expected: `{"rules": [{"id": 1}, {"id": 5}, {"id": 4}, {"id": 3}]}`,
+		},
+		{
+			name:     "keyed elements reordered on one side",
+			base:     `[{"id": 1}, {"id": 2}, {"id": 3}]`,
+			left:     `[{"id": 1, "v": 1}, {"id": 2}, {"id": 3}]`,
+			right:    `[{"id": 3}, {"id": 2}, {"id": 1}]`,
+			expected: `[{"id": 3}, {"id": 2}, {"id": 1, "v": 1}]`,
+		},
+		{
+			name:  "keyed element removed and modified",
+			base:  `{"rules": [{"id": 1, "v": 1}, {"id": 2}]}`,
+			left:  `{"rules": [{"id": 2}]}`,
+			right: `{"rules": [{"id": 1, "v": 2}, {"id": 2}]}`,
+		},
+		{
+			name:  "keyed element modified to different values",
+			base:  `{"rules": [{"id": 1, "v": 1}]}`,
+			left:  `{"rules": [{"id": 1, "v": 2}]}`,
+			right: `{"rules": [{"id": 1, "v": 3}]}`,
+		},
+		{
+			name:     "keyed identity duplicated outside of the changed elements",
+			base:     `[{"id": 1}, {"id": 2}, {"id": 3}]`,
+			left:     `[{"id": 1}, {"id": 3}, {"id": 2}, {"id": 1, "v": 1}]`,
+			right:    `[{"id": 1}, {"id": 2}, {"id": 3}, {"id": 4}]`,
+			expected: `[{"id": 1}, {"id": 3}, {"id": 2}, {"id": 1, "v": 1}, {"id": 4}]`,
+		},
+		{
+			name:     "sequence changed in different places",
+			base:     `{"a": [1, 2, 3, 4, 5]}`,
+			left:     `{"a": [0, 1, 2, 3, 4, 5]}`,
+			right:    `{"a": [1, 2, 3, 4, 6]}`,
+			expected: `{"a": [0, 1, 2, 3, 4, 6]}`,
+		},
+		{
+			name:     "sequence inserts at the same position",
+			base:     `{"a": ["x", "z"]}`,
+			left:     `{"a": ["x", "y1", "z"]}`,
+			right:    `{"a": ["x", "y2", "y1", "z"]}`,
+			expected: `{"a": ["x", "y1", "y2", "z"]}`,
+		},
+		{
+			name:     "sequence removals",
+			base:     `{"a": [1, 2, 3, 4]}`,
+			left:     `{"a": [2, 3, 4]}`,
+			right:    `{"a": [1, 2, 3]}`,
+			expected: `{"a": [2, 3]}`,
+		},
+		{
+			name:  "sequence element modified to different values",
+			base:  `{"a": [1, 2, 3]}`,
+			left:  `{"a": [1, 4, 3]}`,
+			right: `{"a": [1, 5, 3]}`,
+		},
+		{
+			name:     "nested arrays",
+			base:     `{"a": [{"id": 1, "tags": ["x"]}]}`,
+			left:     `{"a": [{"id": 1, "tags": ["x", "y"]}]}`,
+			right:    `{"a": [{"id": 1, "tags": ["w", "x"]}]}`,
+			expected: `{"a": [{"id": 1, "tags": ["w", "x", "y"]}]}`,
+		},
+	}
+
+	ctx := sql.NewEmptyContext()
+	ns := tree.NewTestNodeStore()
+	arrays := &jsonArrayMerger{identityFields: []string{"id"}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			base, left, right := types.MustJSON(test.base), types.MustJSON(test.left), types.MustJSON(test.right)
+			t.Run("indexed", func(t *testing.T) {
+				merged, conflict, err := mergeJSON(ctx, ns, indexedJSON(t, ctx, ns, base), indexedJSON(t, ctx, ns, left), indexedJSON(t, ctx, ns, right), arrays)
+				assertMergedJSON(t, test.expected, merged, conflict, err)
+			})
+			t.Run("not indexed", func(t *testing.T) {
+				merged, conflict, err := mergeJSON(ctx, ns, base, left, right, arrays)
+				assertMergedJSON(t, test.expected, merged, conflict, err)
+			})
+		})
+	}
+}
+
+func TestMergeLargeJSONArrays(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	ns := tree.NewTestNodeStore()
+	arrays := &jsonArrayMerger{}
+
+	array := func(n int, edit func(types.JsonArray) types.JsonArray) sql.JSONWrapper {
+		arr := make(types.JsonArray, n)
+		for i := range arr {
+			arr[i] = fmt.Sprintf("element %d", i)
+		}
+		return types.JSONDocument{Val: edit(arr)}
+	}
+	unchanged := func(arr types.JsonArray) types.JsonArray { return arr }
+
+	base := array(10_000, unchanged)
+	t.Run("changed in different places", func(t *testing.T) {
+		left := array(10_000, func(arr types.JsonArray) types.JsonArray {
+			arr[100] = "left"
+			return arr
+		})
+		right := array(10_000, func(arr types.JsonArray) types.JsonArray {
+			return slices.Insert(arr, 9_000, "right")
+		})
+		expected := array(10_000, func(arr types.JsonArray) types.JsonArray {
+			arr[100] = "left"
+			return slices.Insert(arr, 9_000, "right")
+		})
+		merged, conflict, err := mergeJSON(ctx, ns, indexedJSON(t, ctx, ns, base), indexedJSON(t, ctx, ns, left), indexedJSON(t, ctx, ns, right), arrays)
+		require.NoError(t, err)
+		require.False(t, conflict)
+		cmp, err := types.CompareJSON(expected, merged)
+		require.NoError(t, err)
+		assert.Zero(t, cmp)
+	})
+	t.Run("too many edits", func(t *testing.T) {
+		left := array(10_000, func(arr types.JsonArray) types.JsonArray {
+			for i := 0; i < 2_000; i++ {
+				arr[i] = fmt.Sprintf("left %d", i)
+			}
+			return arr
+		})
+		right := array(10_000, func(arr types.JsonArray) types.JsonArray {
+			arr[5_000] = "right"
+			return arr
+		})
+		_, conflict, err := mergeJSON(ctx, ns, indexedJSON(t, ctx, ns, base), indexedJSON(t, ctx, ns, left), indexedJSON(t, ctx, ns, right), arrays)
+		require.NoError(t, err)
+		assert.True(t, conflict)
+	})
+}
+
+func TestMergeJSONArraysDisabled(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	ns := tree.NewTestNodeStore()
+	base := types.MustJSON(`{"a": [1, 2]}`)
+	left := types.MustJSON(`{"a": [0, 1, 2]}`)
+	right := types.MustJSON(`{"a": [1, 2, 3]}`)
+	_, conflict, err := mergeJSON(ctx, ns, indexedJSON(t, ctx, ns, base), indexedJSON(t, ctx, ns, left), indexedJSON(t, ctx, ns, right), nil)
+	require.NoError(t, err)
+	assert.True(t, conflict)
+}
+
+func indexedJSON(t *testing.T, ctx context.Context, ns tree.NodeStore, doc sql.JSONWrapper) tree.IndexedJsonDocument {
+	root, err := tree.SerializeJsonToAddr(ctx, ns, doc)
+	require.NoError(t, err)
+	return tree.NewIndexedJsonDocument(ctx, root, ns)
+}
+
+func assertMergedJSON(t *testing.T, expected string, merged sql.JSONWrapper, conflict bool, err error) {
+	require.NoError(t, err)
+	if expected == "" {
+		assert.True(t, conflict)
+		return
+	}
+	require.False(t, conflict)
+	cmp, err := types.CompareJSON(types.MustJSON(expected), merged)
+	require.NoError(t, err)
+	if cmp != 0 {
+		s, _ := types.StringifyJSON(merged)
+		assert.Fail(t, "unexpected merge result", "expected %s, got %s", expected, s)
+	}
+}
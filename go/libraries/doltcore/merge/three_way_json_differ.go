@@ -30,6 +30,11 @@ type ThreeWayJsonDiffer struct {
 	leftCurrentDiff, rightCurrentDiff *tree.JsonDiff
 	leftIsDone, rightIsDone           bool
 	ns                                tree.NodeStore
+	// arrays, if set, merges concurrent changes to the same array instead of reporting them as conflicts.
+	arrays *jsonArrayMerger
+	// base, left and right are the documents being merged. They are only set if all three documents are indexed.
+	base, left, right tree.IndexedJsonDocument
+	indexed           bool
 }
 
 type ThreeWayJsonDiff struct {
@@ -64,8 +69,13 @@ func (differ *ThreeWayJsonDiffer) Next(ctx context.Context) (ThreeWayJsonDiff, e
 		rightKey := rightDiff.Key
 
 		cmp := bytes.Compare(leftKey, rightKey)
-		// If both sides modify the same array to different values, we currently consider that to be a conflict.
-		// This may be relaxed in the future.
+		if differ.arrays != nil && differ.indexed {
+			if arrayKey, ok := tree.JsonKeysSharedArray(leftKey, rightKey); ok {
+				return differ.mergeArray(ctx, arrayKey)
+			}
+		}
+		// If both sides modify the same array to different values, we consider that to be a conflict,
+		// unless array merging is enabled.
 		if cmp != 0 && tree.JsonKeysModifySameArray(leftKey, rightKey) {
 			result := ThreeWayJsonDiff{
 				Op: tree.DiffOpDivergentModifyConflict,
@@ -123,12 +133,19 @@ func (differ *ThreeWayJsonDiffer) Next(ctx context.Context) (ThreeWayJsonDiff, e
 			// This shouldn't be necessary: if its an object on all three branches, the original diff is recursive.
 			mergedValue, conflict, err := mergeJSON(ctx, differ.ns, differ.leftCurrentDiff.From,
 				differ.leftCurrentDiff.To,
-				differ.rightCurrentDiff.To)
+				differ.rightCurrentDiff.To,
+				differ.arrays)
 			if err != nil {
 				return ThreeWayJsonDiff{}, err
 			}
 			if conflict {
 				return differ.processMergedDiff(tree.DiffOpDivergentModifyConflict, nil), nil
+			} else if differ.arrays != nil {
+				// arrays merged in the value combine changes from both sides, so the merged value replaces the left
+				// value, as the right value of a resolved diff does
+				result := differ.processMergedDiff(tree.DiffOpDivergentModifyResolved, mergedValue)
+				result.Right = mergedValue
+				return result, nil
 			} else {
 				return differ.processMergedDiff(tree.DiffOpDivergentModifyResolved, mergedValue), nil
 			}
@@ -136,6 +153,89 @@ func (differ *ThreeWayJsonDiffer) Next(ctx context.Context) (ThreeWayJsonDiff, e
 	}
 }
 
+// mergeArray merges the array at |arrayKey|, which was modified on both sides, and skips the remaining diffs inside
+// the array.
+func (differ *ThreeWayJsonDiffer) mergeArray(ctx context.Context, arrayKey []byte) (ThreeWayJsonDiff, error) {
+	for {
+		leftInArray := differ.leftCurrentDiff != nil && isInsideJsonKey(differ.leftCurrentDiff.Key, arrayKey)
+		rightInArray := differ.rightCurrentDiff != nil && isInsideJsonKey(differ.rightCurrentDiff.Key, arrayKey)
+		if !leftInArray && !rightInArray {
+			break
+		}
+		if leftInArray {
+			differ.leftCurrentDiff = nil
+		}
+		if rightInArray {
+			differ.rightCurrentDiff = nil
+		}
+		if err := differ.loadNextDiff(ctx); err != nil {
+			return ThreeWayJsonDiff{}, err
+		}
+	}
+
+	var elems [3][][]byte
+	for i, doc := range []tree.IndexedJsonDocument{differ.base, differ.left, differ.right} {
+		arr, isArray, err := doc.ArrayElementsWithKey(ctx, arrayKey)
+		if err != nil {
+			return ThreeWayJsonDiff{}, err
+		}
+		if !isArray {
+			return differ.mergeArrayValues(ctx, arrayKey)
+		}
+		elems[i] = arr
+	}
+
+	merged, conflict, err := differ.arrays.mergeElements(ctx, differ.ns, elems[0], elems[1], elems[2])
+	if err != nil {
+		return ThreeWayJsonDiff{}, err
+	}
+	if conflict {
+		return ThreeWayJsonDiff{Op: tree.DiffOpDivergentModifyConflict}, nil
+	}
+	// the merged array replaces the left array, as the right value of any resolved diff does
+	return ThreeWayJsonDiff{
+		Op:     tree.DiffOpDivergentModifyResolved,
+		Key:    arrayKey,
+		Right:  merged,
+		Merged: merged,
+	}, nil
+}
+
+// mergeArrayValues merges the values at |arrayKey| when they aren't an array on every side.
+func (differ *ThreeWayJsonDiffer) mergeArrayValues(ctx context.Context, arrayKey []byte) (ThreeWayJsonDiff, error) {
+	var values [3]sql.JSONWrapper
+	for i, doc := range []tree.IndexedJsonDocument{differ.base, differ.left, differ.right} {
+		val, err := doc.LookupWithKey(ctx, arrayKey)
+		if err != nil {
+			return ThreeWayJsonDiff{}, err
+		}
+		if val == nil {
+			return ThreeWayJsonDiff{Op: tree.DiffOpDivergentModifyConflict}, nil
+		}
+		values[i] = val
+	}
+
+	merged, conflict, err := differ.arrays.merge(ctx, differ.ns, values[0], values[1], values[2])
+	if err != nil {
+		return ThreeWayJsonDiff{}, err
+	}
+	if conflict {
+		return ThreeWayJsonDiff{Op: tree.DiffOpDivergentModifyConflict}, nil
+	}
+	return ThreeWayJsonDiff{
+		Op:     tree.DiffOpDivergentModifyResolved,
+		Key:    arrayKey,
+		Left:   values[1],
+		Right:  merged,
+		Merged: merged,
+	}, nil
+}
+
+// isInsideJsonKey returns whether |key| is the location of a value nested inside the value at |prefix|.
+func isInsideJsonKey(key, prefix []byte) bool {
+	return len(key) > len(prefix) && tree.IsJsonKeyPrefix(key, prefix)
+}
+
 func (differ *ThreeWayJsonDiffer) loadNextDiff(ctx context.Context) error {
 	if differ.leftCurrentDiff == nil && !differ.leftIsDone {
 		newLeftDiff, err := differ.leftDiffer.Next(ctx)
@@ -0,0 +1,139 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvdata
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/rowconv"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/json"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/untyped/csv"
+)
+
+const (
+	// BadRowLineCol is the column of a bad rows file holding the line or record number a rejected row was read from.
+	BadRowLineCol = "dolt_line"
+	// BadRowErrorCol is the column of a bad rows file holding the reason a row was rejected.
+	BadRowErrorCol = "dolt_error"
+)
+
+// BadRowWriter writes the rows rejected by an import to a file, along with the line they were read from and the error
+// which rejected them. The remaining columns of the file are the columns of the import's source, so that the rejected
+// rows can be fixed and imported again. Rejected rows are reported by both the reading and the writing side of an
+// import, so a BadRowWriter is safe for concurrent use.
+type BadRowWriter struct {
+	mu         sync.Mutex
+	wr         table.SqlRowWriter
+	colNames   []string
+	nameMapper rowconv.NameMapper
+}
+
+// NewBadRowWriter returns a BadRowWriter which writes to |wr| in |format|, which must be a csv, psv, or jsonl format.
+// |srcSch| is the schema of the import's source, and |nameMapper| maps its columns to the columns of the table.
+func NewBadRowWriter(wr io.WriteCloser, format DataFormat, srcSch schema.Schema, nameMapper rowconv.NameMapper) (*BadRowWriter, error) {
+	cols := srcSch.GetAllCols().GetColumns()
+	colNames := make([]string, len(cols))
+	outSch := sql.Schema{
+		{Name: BadRowLineCol, Type: types.Int64, Nullable: false},
+		{Name: BadRowErrorCol, Type: types.LongText, Nullable: false},
+	}
+	for i, col := range cols {
+		colNames[i] = col.Name
+		outSch = append(outSch, &sql.Column{Name: col.Name, Type: types.LongText, Nullable: true})
+	}
+
+	var rowWr table.SqlRowWriter
+	var err error
+	switch format {
+	case CsvFile:
+		rowWr, err = csv.NewCSVSqlWriter(wr, outSch, csv.NewCSVInfo())
+	case PsvFile:
+		rowWr, err = csv.NewCSVSqlWriter(wr, outSch, csv.NewCSVInfo().SetDelim("|"))
+	case JsonlFile:
+		rowWr, err = json.NewJSONLSqlWriter(wr, outSch)
+	default:
+		return nil, fmt.Errorf("unsupported format for bad rows file: %s", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &BadRowWriter{wr: rowWr, colNames: colNames, nameMapper: nameMapper}, nil
+}
+
+// WriteBadRow writes |row|, which was rejected with |rowErr| on |line|. |rowSch| is the schema of |row|, which is
+// either the schema of the import's source or of the table being imported to. |row| may be nil or hold fewer values
+// than |rowSch| when the source could not be parsed.
+func (bw *BadRowWriter) WriteBadRow(ctx context.Context, row sql.Row, rowSch sql.Schema, line int, rowErr error) error {
+	out := make(sql.Row, 2+len(bw.colNames))
+	out[0] = int64(line)
+	out[1] = badRowErrorString(rowErr)
+
+	for i, v := range row {
+		if v == nil || i >= len(rowSch) {
+			continue
+		}
+		idx := bw.colIndex(rowSch[i].Name)
+		if idx < 0 {
+			continue
+		}
+
+		str, err := sqlutil.SqlColToStr(rowSch[i].Type, v)
+		if err != nil {
+			str = fmt.Sprint(v)
+		}
+		out[2+idx] = str
+	}
+
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+	return bw.wr.WriteSqlRow(ctx, out)
+}
+
+// colIndex returns the index of the source column named |name|, which is either the name of a source column or the
+// name of the table column it is mapped to. Returns -1 if there is no such column.
+func (bw *BadRowWriter) colIndex(name string) int {
+	for _, n := range []string{name, bw.nameMapper.PreImage(name)} {
+		for i, colName := range bw.colNames {
+			if colName == n {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// Close flushes the rows written and closes the underlying file.
+func (bw *BadRowWriter) Close(ctx context.Context) error {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+	return bw.wr.Close(ctx)
+}
+
+func badRowErrorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvdata
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/rowconv"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestBadRowWriter(t *testing.T) {
+	srcSch := schema.MustSchemaFromCols(schema.NewColCollection(
+		schema.NewColumn("id", 0, types.StringKind, true),
+		schema.NewColumn("full name", 1, types.StringKind, false),
+	))
+	nameMapper := rowconv.NameMapper{"full name": "name"}
+	tableSch := sql.Schema{
+		{Name: "id", Type: gmstypes.Int64},
+		{Name: "name", Type: gmstypes.Text},
+	}
+
+	tests := []struct {
+		format   DataFormat
+		expected string
+	}{
+		{
+			format: CsvFile,
+			expected: "dolt_line,dolt_error,id,full name\n" +
+				"2,bad value,1,\n" +
+				"3,\"duplicate, key\",2,Jane Doe\n" +
+				"4,\"\",,\n",
+		},
+		{
+			format: JsonlFile,
+			expected: `{"dolt_error":"bad value","dolt_line":2,"id":"1"}` + "\n" +
+				`{"dolt_error":"duplicate, key","dolt_line":3,"full name":"Jane Doe","id":"2"}` + "\n" +
+				`{"dolt_error":"","dolt_line":4}` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.format), func(t *testing.T) {
+			ctx := context.Background()
+			var buf bytes.Buffer
+			wr, err := NewBadRowWriter(iohelp.NopWrCloser(&buf), test.format, srcSch, nameMapper)
+			require.NoError(t, err)
+
+			// a row rejected by the reader, in the columns of the source
+			srcSqlSch := sql.Schema{{Name: "id", Type: gmstypes.Text}, {Name: "full name", Type: gmstypes.Text}}
+			require.NoError(t, wr.WriteBadRow(ctx, sql.Row{"1"}, srcSqlSch, 2, errors.New("bad value")))
+			// a row rejected by the writer, in the columns of the table
+			require.NoError(t, wr.WriteBadRow(ctx, sql.Row{int64(2), "Jane Doe"}, tableSch, 3, errors.New("duplicate, key")))
+			// a row which could not be parsed
+			require.NoError(t, wr.WriteBadRow(ctx, nil, srcSqlSch, 4, nil))
+			require.NoError(t, wr.Close(ctx))
+
+			assert.Equal(t, test.expected, buf.String())
+		})
+	}
+
+	_, err := NewBadRowWriter(iohelp.NopWrCloser(&bytes.Buffer{}), JsonFile, srcSch, nameMapper)
+	assert.Error(t, err)
+}
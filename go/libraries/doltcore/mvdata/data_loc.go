@@ -52,11 +52,20 @@ const (
 	// JsonFile is the format of a data location that is a json file
 	JsonFile DataFormat = ".json"
 
+	// JsonlFile is the format of a data location that is a JSON Lines file, with one JSON object per line
+	JsonlFile DataFormat = ".jsonl"
+
 	// SqlFile is the format of a data location that is a .sql file
 	SqlFile DataFormat = ".sql"
 
 	// ParquetFile is the format of a data location that is a .paquet file
 	ParquetFile DataFormat = ".parquet"
+
+	// ArrowFile is the format of a data location that is an Arrow IPC file, also known as a Feather file
+	ArrowFile DataFormat = ".arrow"
+
+	// ArrowStreamFile is the format of a data location that is in the Arrow IPC streaming format
+	ArrowStreamFile DataFormat = ".arrows"
 )
 
 // ReadableStr returns a human readable string for a DataFormat
@@ -72,10 +81,16 @@ func (df DataFormat) ReadableStr() string {
 		return "xlsx file"
 	case JsonFile:
 		return "json file"
+	case JsonlFile:
+		return "jsonl file"
 	case SqlFile:
 		return "sql file"
 	case ParquetFile:
 		return "parquet file"
+	case ArrowFile:
+		return "arrow file"
+	case ArrowStreamFile:
+		return "arrow stream"
 	default:
 		return "invalid"
 	}
@@ -116,10 +131,16 @@ func NewDataLocation(path, fileFmtStr string) DataLocation {
 			dataFmt = XlsxFile
 		case string(JsonFile):
 			dataFmt = JsonFile
+		case string(JsonlFile), ".ndjson":
+			dataFmt = JsonlFile
 		case string(SqlFile):
 			dataFmt = SqlFile
 		case string(ParquetFile):
 			dataFmt = ParquetFile
+		case string(ArrowFile), ".feather":
+			dataFmt = ArrowFile
+		case string(ArrowStreamFile):
+			dataFmt = ArrowStreamFile
 		}
 	}
 
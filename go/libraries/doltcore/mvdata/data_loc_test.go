@@ -85,6 +85,8 @@ func TestBasics(t *testing.T) {
 		{NewDataLocation("file.csv", ""), CsvFile.ReadableStr() + ":file.csv", true},
 		{NewDataLocation("file.psv", ""), PsvFile.ReadableStr() + ":file.psv", true},
 		{NewDataLocation("file.json", ""), JsonFile.ReadableStr() + ":file.json", true},
+		{NewDataLocation("file.feather", ""), ArrowFile.ReadableStr() + ":file.feather", true},
+		{NewDataLocation("file.arrows", ""), ArrowStreamFile.ReadableStr() + ":file.arrows", true},
 		//{NewDataLocation("file.nbf", ""), NbfFile, "file.nbf", true},
 	}
 
@@ -48,6 +48,11 @@ type ParquetOptions struct {
 	SchFile   string
 }
 
+type ArrowOptions struct {
+	TableName string
+	SchFile   string
+}
+
 type MoverOptions struct {
 	ContinueOnErr  bool
 	Force          bool
@@ -132,8 +137,51 @@ func InferSchema(ctx context.Context, root doltdb.RootValue, rd table.ReadCloser
 		return nil, err
 	}
 
+	return newTableSchema(ctx, root, infCols, tableName, pks)
+}
+
+// SourceSchema returns the schema of a new table for rows read from a source which defines the types of its columns,
+// such as a table of a MySQL server. The primary key of the source is used unless |pks| is given, and the secondary
+// indexes of the source are kept.
+func SourceSchema(ctx context.Context, root doltdb.RootValue, srcSch schema.Schema, tableName string, pks []string) (schema.Schema, error) {
+	if len(pks) == 0 {
+		pks = srcSch.GetPKCols().GetColumnNames()
+	}
+	sch, err := newTableSchema(ctx, root, srcSch.GetAllCols(), tableName, pks)
+	if err != nil {
+		return nil, err
+	}
+
+	// the primary key's columns are ordered as they were given, rather than in the order of the table's columns
+	ordinals := make([]int, len(pks))
+	for i, pk := range pks {
+		ordinals[i] = sch.GetAllCols().IndexOf(pk)
+	}
+	if err = sch.SetPkOrdinals(ordinals); err != nil {
+		return nil, err
+	}
+
+	// the tags of the new table's columns differ from the source's, so indexes are added by the names of their columns
+	for _, index := range srcSch.Indexes().AllIndexes() {
+		_, err = sch.Indexes().AddIndexByColNames(index.Name(), index.ColumnNames(), index.PrefixLengths(), schema.IndexProperties{
+			IsUnique:      index.IsUnique(),
+			IsSpatial:     index.IsSpatial(),
+			IsUserDefined: index.IsUserDefined(),
+			Comment:       index.Comment(),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return sch, nil
+}
+
+// newTableSchema returns the schema of a new table with |cols| and the primary key |pks|, generating the tags of the
+// columns.
+func newTableSchema(ctx context.Context, root doltdb.RootValue, cols *schema.ColCollection, tableName string, pks []string) (schema.Schema, error) {
+	var err error
 	pkSet := set.NewStrSet(pks)
-	newCols := schema.MapColCollection(infCols, func(col schema.Column) schema.Column {
+	newCols := schema.MapColCollection(cols, func(col schema.Column) schema.Column {
 		col.IsPartOfPK = pkSet.Contains(col.Name)
 		if col.IsPartOfPK {
 			hasNotNull := false
@@ -179,4 +227,6 @@ const (
 	ReplaceOp TableImportOp = "replace"
 	UpdateOp  TableImportOp = "update"
 	AppendOp  TableImportOp = "append"
+	// SyncOp makes the rows of a table match the imported rows, inserting, updating and deleting rows as needed.
+	SyncOp TableImportOp = "sync"
 )
@@ -0,0 +1,234 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvdata
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/analyzer/analyzererrors"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/commands/engine"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/noms"
+)
+
+// SqlEngineImport imports the rows of several tables through one session of a sql engine, in a single transaction.
+// Foreign key checks are disabled for the session, so that the tables can be imported in any order. Nothing is written
+// to the working set until the import is committed, which also creates a Dolt commit of the imported tables.
+type SqlEngineImport struct {
+	se       *engine.SqlEngine
+	sqlCtx   *sql.Context
+	database string
+}
+
+// NewSqlEngineImport starts an import of several tables into the database of |dEnv|.
+func NewSqlEngineImport(ctx context.Context, dEnv *env.DoltEnv) (*SqlEngineImport, error) {
+	mrEnv, err := env.MultiEnvForDirectory(ctx, dEnv.Config.WriteableConfig(), dEnv.FS, dEnv.Version, dEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &engine.SqlEngineConfig{
+		ServerUser: "root",
+		Autocommit: false,
+		Bulk:       true,
+	}
+	se, err := engine.NewSqlEngine(ctx, mrEnv, config)
+	if err != nil {
+		return nil, err
+	}
+
+	dbName := mrEnv.GetFirstDatabase()
+	if se.GetUnderlyingEngine().IsReadOnly() {
+		se.Close()
+		return nil, analyzererrors.ErrReadOnlyDatabase.New(dbName)
+	}
+
+	sqlCtx, err := se.NewLocalContext(ctx)
+	if err != nil {
+		se.Close()
+		return nil, err
+	}
+	sqlCtx.SetCurrentDatabase(dbName)
+
+	imp := &SqlEngineImport{se: se, sqlCtx: sqlCtx, database: dbName}
+	for _, query := range []string{"START TRANSACTION", "SET FOREIGN_KEY_CHECKS = 0"} {
+		if err = imp.exec(query); err != nil {
+			se.Close()
+			return nil, err
+		}
+	}
+	return imp, nil
+}
+
+// NewTableWriter returns a writer of the rows of one table of the import. The writer creates, empties or drops its
+// table in the import's transaction, and doesn't commit it.
+func (imp *SqlEngineImport) NewTableWriter(ctx context.Context, createTableSchema, rowOperationSchema schema.Schema, options *MoverOptions, statsCB noms.StatsCB) (*SqlEngineTableWriter, error) {
+	doltCreateTableSchema, err := sqlutil.FromDoltSchema("", options.TableToWriteTo, createTableSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	doltRowOperationSchema, err := sqlutil.FromDoltSchema("", options.TableToWriteTo, rowOperationSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SqlEngineTableWriter{
+		se:        imp.se,
+		sqlCtx:    imp.sqlCtx,
+		contOnErr: options.ContinueOnErr,
+		force:     options.Force,
+		// foreign key checks are disabled for the whole import
+		disableFks: false,
+		imp:        imp,
+
+		database:  imp.database,
+		tableName: options.TableToWriteTo,
+
+		statsCB: statsCB,
+
+		importOption:       options.Operation,
+		tableSchema:        doltCreateTableSchema,
+		rowOperationSchema: doltRowOperationSchema,
+		indexes:            createTableSchema.Indexes().AllIndexes(),
+	}, nil
+}
+
+// WorkingRoot returns the working root of the import's transaction.
+func (imp *SqlEngineImport) WorkingRoot() (doltdb.RootValue, error) {
+	roots, ok := dsess.DSessFromSess(imp.sqlCtx.Session).GetRoots(imp.sqlCtx, imp.database)
+	if !ok {
+		return nil, fmt.Errorf("no root value found in session for database %s", imp.database)
+	}
+	return roots.Working, nil
+}
+
+// SetWorkingRoot sets the working root of the import's transaction. Tables are created and dropped this way, rather
+// than with DDL statements, which would commit the transaction.
+func (imp *SqlEngineImport) SetWorkingRoot(root doltdb.RootValue) error {
+	return dsess.DSessFromSess(imp.sqlCtx.Session).SetWorkingRoot(imp.sqlCtx, imp.database, root)
+}
+
+// Commit stages |tables|, commits them with |message|, and commits the import's transaction. No Dolt commit is
+// created if the tables are unchanged.
+func (imp *SqlEngineImport) Commit(tables []string, message string) error {
+	args := make([]string, len(tables))
+	for i, table := range tables {
+		args[i] = sqlfmt.QuoteString(table)
+	}
+	queries := []string{
+		fmt.Sprintf("CALL DOLT_ADD(%s)", strings.Join(args, ", ")),
+		fmt.Sprintf("CALL DOLT_COMMIT('--skip-empty', '-m', %s)", sqlfmt.QuoteString(message)),
+		"COMMIT",
+	}
+	for _, query := range queries {
+		if err := imp.exec(query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Rollback discards everything written by the import.
+func (imp *SqlEngineImport) Rollback() error {
+	return imp.exec("ROLLBACK")
+}
+
+// Close closes the import's engine.
+func (imp *SqlEngineImport) Close() {
+	imp.se.Close()
+}
+
+func (imp *SqlEngineImport) exec(query string) error {
+	_, iter, _, err := imp.se.Query(imp.sqlCtx, query)
+	if err != nil {
+		return err
+	}
+	for {
+		_, err = iter.Next(imp.sqlCtx)
+		if err == io.EOF {
+			return iter.Close(imp.sqlCtx)
+		} else if err != nil {
+			_ = iter.Close(imp.sqlCtx)
+			return err
+		}
+	}
+}
+
+// editRoot applies |edit| to the working root of the transaction of |s|, which must be a writer of an import.
+func (s *SqlEngineTableWriter) editRoot(edit func(root doltdb.RootValue) (doltdb.RootValue, error)) error {
+	root, err := s.imp.WorkingRoot()
+	if err != nil {
+		return err
+	}
+	root, err = edit(root)
+	if err != nil {
+		return err
+	}
+	return s.imp.SetWorkingRoot(root)
+}
+
+// createTableInTx creates the writer's table in the transaction of its import.
+func (s *SqlEngineTableWriter) createTableInTx() error {
+	return s.editRoot(func(root doltdb.RootValue) (doltdb.RootValue, error) {
+		tn := doltdb.TableName{Name: s.tableName}
+		if ok, err := root.HasTable(s.sqlCtx, tn); err != nil {
+			return nil, err
+		} else if ok {
+			return nil, sql.ErrTableAlreadyExists.New(s.tableName)
+		}
+		sch, err := sqlutil.ToDoltSchema(s.sqlCtx, root, tn, s.tableSchema, nil, sql.Collation_Default)
+		if err != nil {
+			return nil, err
+		}
+		for _, index := range s.indexes {
+			if index.IsFullText() || index.IsVector() {
+				// these indexes are backed by tables of their own, which only the engine creates
+				return nil, fmt.Errorf("index %s of table %s can't be created by an import of several tables", index.Name(), s.tableName)
+			}
+			_, err = sch.Indexes().AddIndexByColNames(index.Name(), index.ColumnNames(), index.PrefixLengths(), schema.IndexProperties{
+				IsUnique:      index.IsUnique(),
+				IsSpatial:     index.IsSpatial(),
+				IsUserDefined: index.IsUserDefined(),
+				Comment:       index.Comment(),
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		return doltdb.CreateEmptyTable(s.sqlCtx, root, tn, sch)
+	})
+}
+
+// dropTableInTx drops the writer's table, if it exists, in the transaction of its import.
+func (s *SqlEngineTableWriter) dropTableInTx() error {
+	return s.editRoot(func(root doltdb.RootValue) (doltdb.RootValue, error) {
+		tn := doltdb.TableName{Name: s.tableName}
+		if ok, err := root.HasTable(s.sqlCtx, tn); err != nil || !ok {
+			return root, err
+		}
+		return root.RemoveTables(s.sqlCtx, false, true, tn)
+	})
+}
@@ -17,6 +17,7 @@ package mvdata
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	sqle "github.com/dolthub/go-mysql-server"
 	"github.com/dolthub/go-mysql-server/sql"
@@ -30,6 +31,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	dsqle "github.com/dolthub/dolt/go/libraries/doltcore/sqle"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 )
 
@@ -41,7 +43,24 @@ type sqlEngineTableReader struct {
 	iter sql.RowIter
 }
 
+// TableReadOptions select the rows and columns of a table read by a SQL engine reader.
+type TableReadOptions struct {
+	// Columns are the names of the columns read, in order. When empty, every column is read.
+	Columns []string
+	// Where is a SQL expression the rows read must satisfy. When empty, every row is read.
+	Where string
+	// AsOf is the commit, branch or tag the table is read at. When empty, the working set is read.
+	AsOf string
+	// OrderBy are the names of the columns the rows are ordered by.
+	OrderBy []string
+}
+
 func NewSqlEngineReader(ctx context.Context, dEnv *env.DoltEnv, tableName string) (*sqlEngineTableReader, error) {
+	return NewSqlEngineReaderWithOptions(ctx, dEnv, tableName, TableReadOptions{})
+}
+
+// NewSqlEngineReaderWithOptions returns a reader of the rows and columns of |tableName| selected by |opts|.
+func NewSqlEngineReaderWithOptions(ctx context.Context, dEnv *env.DoltEnv, tableName string, opts TableReadOptions) (*sqlEngineTableReader, error) {
 	mrEnv, err := env.MultiEnvForDirectory(ctx, dEnv.Config.WriteableConfig(), dEnv.FS, dEnv.Version, dEnv)
 	if err != nil {
 		return nil, err
@@ -68,7 +87,11 @@ func NewSqlEngineReader(ctx context.Context, dEnv *env.DoltEnv, tableName string
 
 	sqlEngine := se.GetUnderlyingEngine()
 	binder := planbuilder.New(sqlCtx, sqlEngine.Analyzer.Catalog, sqlEngine.EventScheduler, sqlEngine.Parser)
-	ret, _, _, _, err := binder.Parse(fmt.Sprintf("show create table `%s`", tableName), nil, false)
+	asOf := ""
+	if opts.AsOf != "" {
+		asOf = fmt.Sprintf(" AS OF '%s'", strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(opts.AsOf))
+	}
+	ret, _, _, _, err := binder.Parse(fmt.Sprintf("show create table `%s`%s", tableName, asOf), nil, false)
 	if err != nil {
 		return nil, err
 	}
@@ -78,7 +101,33 @@ func NewSqlEngineReader(ctx context.Context, dEnv *env.DoltEnv, tableName string
 		return nil, fmt.Errorf("expected *plan.ShowCreate table, found %T", ret)
 	}
 
-	_, iter, _, err := se.Query(sqlCtx, fmt.Sprintf("SELECT * FROM `%s`", tableName))
+	pkSch := create.PrimaryKeySchema
+	cols := "*"
+	if len(opts.Columns) > 0 {
+		pkSch, err = selectColumns(pkSch, opts.Columns)
+		if err != nil {
+			return nil, err
+		}
+		quoted := make([]string, len(opts.Columns))
+		for i, col := range pkSch.Schema {
+			quoted[i] = sqlfmt.QuoteIdentifier(col.Name)
+		}
+		cols = strings.Join(quoted, ", ")
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM `%s`%s", cols, tableName, asOf)
+	if opts.Where != "" {
+		query += fmt.Sprintf(" WHERE (%s)", opts.Where)
+	}
+	if len(opts.OrderBy) > 0 {
+		quoted := make([]string, len(opts.OrderBy))
+		for i, col := range opts.OrderBy {
+			quoted[i] = sqlfmt.QuoteIdentifier(col)
+		}
+		query += " ORDER BY " + strings.Join(quoted, ", ")
+	}
+
+	_, iter, _, err := se.Query(sqlCtx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -90,7 +139,7 @@ func NewSqlEngineReader(ctx context.Context, dEnv *env.DoltEnv, tableName string
 
 	// NOTE: We don't support setting a schema name to qualify the table name here, so this code will not work
 	//       correctly with Doltgres yet.
-	doltSchema, err := sqlutil.ToDoltSchema(ctx, root, doltdb.TableName{Name: tableName}, create.PrimaryKeySchema, nil, sql.Collation_Default)
+	doltSchema, err := sqlutil.ToDoltSchema(ctx, root, doltdb.TableName{Name: tableName}, pkSch, nil, sql.Collation_Default)
 	if err != nil {
 		return nil, err
 	}
@@ -104,6 +153,22 @@ func NewSqlEngineReader(ctx context.Context, dEnv *env.DoltEnv, tableName string
 	}, nil
 }
 
+// selectColumns returns the schema of the columns of |sch| named |names|, in the order of |names|.
+func selectColumns(sch sql.PrimaryKeySchema, names []string) (sql.PrimaryKeySchema, error) {
+	selected := make(sql.Schema, 0, len(names))
+	for _, name := range names {
+		idx := sch.Schema.IndexOfColName(name)
+		if idx < 0 {
+			return sql.PrimaryKeySchema{}, fmt.Errorf("column %s not found", name)
+		}
+		if selected.IndexOfColName(name) >= 0 {
+			return sql.PrimaryKeySchema{}, fmt.Errorf("column %s is selected more than once", name)
+		}
+		selected = append(selected, sch.Schema[idx])
+	}
+	return sql.NewPrimaryKeySchema(selected), nil
+}
+
 // Used by Dolthub API
 func NewSqlEngineTableReaderWithEngine(sqlCtx *sql.Context, se *sqle.Engine, db dsqle.Database, root doltdb.RootValue, tableName string) (*sqlEngineTableReader, error) {
 	sch, iter, _, err := se.Query(sqlCtx, fmt.Sprintf("SELECT * FROM `%s`", tableName))
@@ -0,0 +1,382 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvdata
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
+	"github.com/dolthub/dolt/go/store/pool"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+var syncPool = pool.NewBuffPool()
+
+// syncBatchSize is the number of rows deleted by each statement of a sync import, and the number of changed rows read
+// from the diff at a time.
+const syncBatchSize = 256
+
+// syncRows prepares a sync import, which makes the rows of the table match the rows read from |inputChannel|. The
+// incoming rows are staged in a prolly map encoded like the table's primary index, and the staged map is diffed against
+// the table's rows, so that only the chunks holding changed rows are visited. Rows which are no longer present are
+// deleted in batches, and the rows which must be inserted or updated are streamed from the diff into the returned
+// channel until |ctx| is canceled. The returned function waits for the diff to be read, and returns its error.
+func (s *SqlEngineTableWriter) syncRows(ctx context.Context, inputChannel chan sql.Row, badRowCb func(row sql.Row, rowSchema sql.PrimaryKeySchema, tableName string, lineNumber int, err error) bool) (chan sql.Row, func() error, error) {
+	roots, ok := dsess.DSessFromSess(s.sqlCtx.Session).GetRoots(s.sqlCtx, s.database)
+	if !ok {
+		return nil, nil, fmt.Errorf("unable to get roots for database %s", s.database)
+	}
+	tbl, _, ok, err := doltdb.GetTableInsensitive(ctx, roots.Working, doltdb.TableName{Name: s.tableName})
+	if err != nil {
+		return nil, nil, err
+	} else if !ok {
+		return nil, nil, doltdb.ErrTableNotFound
+	}
+
+	if !types.IsFormat_DOLT(tbl.Format()) {
+		return nil, nil, fmt.Errorf("sync imports are not supported for the storage format of this database")
+	}
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if schema.IsKeyless(sch) {
+		return nil, nil, fmt.Errorf("sync imports require table %s to have a primary key", s.tableName)
+	}
+
+	// rows of the import are matched to the table's rows by every column, so every column must be present, except for
+	// generated columns whose values are computed from the others
+	var missing []string
+	for _, col := range sch.GetAllCols().GetColumns() {
+		if col.Generated == "" && s.rowOperationSchema.Schema.IndexOfColName(col.Name) < 0 {
+			missing = append(missing, col.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, nil, fmt.Errorf("sync imports require every column of table %s, missing columns: %s", s.tableName, strings.Join(missing, ", "))
+	}
+
+	rowData, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	from := durable.ProllyMapFromIndex(rowData)
+
+	staged, numRows, err := s.stageRows(ctx, from, sch, inputChannel, badRowCb)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stagedTbl, err := tbl.UpdateRows(ctx, durable.IndexFromProllyMap(staged))
+	if err != nil {
+		return nil, nil, err
+	}
+	tblName := doltdb.TableName{Name: s.tableName}
+	s.syncStat, err = syncDiffStat(ctx, diff.TableDelta{
+		FromName:  tblName,
+		ToName:    tblName,
+		FromTable: tbl,
+		ToTable:   stagedTbl,
+		FromSch:   sch,
+		ToSch:     sch,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	s.stats.Deletions = int64(s.syncStat.Removes)
+	s.stats.SameVal = numRows - int64(s.syncStat.Adds+s.syncStat.Changes)
+
+	// deletes are applied first so that a row which replaces a deleted row can't violate a unique key
+	var removed []kvPair
+	deleteRemoved := func() error {
+		if len(removed) == 0 {
+			return nil
+		}
+		rows, err := syncBatchRows(s.sqlCtx, sch, from, removed)
+		if err != nil {
+			return err
+		}
+		removed = removed[:0]
+		stmt, err := sqlfmt.SqlRowsAsDeleteStmt(rows, s.tableName, sch)
+		if err != nil {
+			return err
+		}
+		_, iter, _, err := s.se.Query(s.sqlCtx, stmt)
+		if err != nil {
+			return err
+		}
+		_, err = sql.RowIterToRows(s.sqlCtx, iter)
+		return err
+	}
+	err = prolly.DiffMaps(ctx, from, staged, false, func(ctx context.Context, d tree.Diff) error {
+		if d.Type != tree.RemovedDiff {
+			return nil
+		}
+		removed = append(removed, kvPair{val.Tuple(d.Key), val.Tuple(d.From)})
+		if len(removed) < syncBatchSize {
+			return nil
+		}
+		return deleteRemoved()
+	})
+	if err != nil && err != io.EOF {
+		return nil, nil, err
+	}
+	if err = deleteRemoved(); err != nil {
+		return nil, nil, err
+	}
+
+	// the changed rows are returned in the order of the row operation schema, which the insert node expects
+	ordinals := make([]int, len(s.rowOperationSchema.Schema))
+	for i, col := range s.rowOperationSchema.Schema {
+		ordinals[i] = sch.GetAllCols().IndexOf(col.Name)
+	}
+
+	upsertChannel := make(chan sql.Row, syncBatchSize)
+	eg, egCtx := errgroup.WithContext(ctx)
+	sqlCtx := s.sqlCtx.WithContext(egCtx)
+	eg.Go(func() error {
+		defer close(upsertChannel)
+		var upserts []kvPair
+		sendUpserts := func() error {
+			rows, err := syncBatchRows(sqlCtx, sch, staged, upserts)
+			if err != nil {
+				return err
+			}
+			upserts = upserts[:0]
+			for _, r := range rows {
+				opRow := make(sql.Row, len(ordinals))
+				for i, ord := range ordinals {
+					opRow[i] = r[ord]
+				}
+				select {
+				case upsertChannel <- opRow:
+				case <-egCtx.Done():
+					return egCtx.Err()
+				}
+			}
+			return nil
+		}
+		err := prolly.DiffMaps(egCtx, from, staged, false, func(ctx context.Context, d tree.Diff) error {
+			if d.Type != tree.AddedDiff && d.Type != tree.ModifiedDiff {
+				return nil
+			}
+			upserts = append(upserts, kvPair{val.Tuple(d.Key), val.Tuple(d.To)})
+			if len(upserts) < syncBatchSize {
+				return nil
+			}
+			return sendUpserts()
+		})
+		if err != nil && err != io.EOF {
+			return err
+		}
+		return sendUpserts()
+	})
+
+	return upsertChannel, eg.Wait, nil
+}
+
+// syncBatchRows returns the rows of |m| for the key value pairs |kvs|.
+func syncBatchRows(ctx *sql.Context, sch schema.Schema, m prolly.Map, kvs []kvPair) ([]sql.Row, error) {
+	iter := index.NewProllyRowIterForMap(sch, m, &kvPairIter{kvs: kvs}, nil)
+	rows := make([]sql.Row, 0, len(kvs))
+	for {
+		r, err := iter.Next(ctx)
+		if err == io.EOF {
+			return rows, nil
+		} else if err != nil {
+			return nil, err
+		}
+		rows = append(rows, r)
+	}
+}
+
+// stageRows builds a map of the rows read from |inputChannel|, encoded with the descriptors of |from|. Rows with the
+// same primary key replace one another, as they would in an update import. Stored generated columns which aren't
+// imported keep the values of the table's row with the same key, so that they don't make every row differ; a row whose
+// other columns change is still rewritten, which recomputes them.
+func (s *SqlEngineTableWriter) stageRows(ctx context.Context, from prolly.Map, sch schema.Schema, inputChannel chan sql.Row, badRowCb func(row sql.Row, rowSchema sql.PrimaryKeySchema, tableName string, lineNumber int, err error) bool) (prolly.Map, int64, error) {
+	kd, vd := from.Descriptors()
+	empty, err := prolly.NewMapFromTuples(ctx, from.NodeStore(), kd, vd)
+	if err != nil {
+		return prolly.Map{}, 0, err
+	}
+	mut := empty.Mutate()
+	ns := mut.NodeStore()
+
+	keyCols := sch.GetPKCols().GetColumns()
+	var valCols []schema.Column
+	for _, col := range sch.GetNonPKCols().GetColumns() {
+		if !col.Virtual {
+			valCols = append(valCols, col)
+		}
+	}
+
+	keyBld, valBld := val.NewTupleBuilder(kd), val.NewTupleBuilder(vd)
+	putFields := func(bld *val.TupleBuilder, cols []schema.Column, row sql.Row, isKey bool) error {
+		for i, col := range cols {
+			idx := s.rowOperationSchema.Schema.IndexOfColName(col.Name)
+			if idx < 0 {
+				// a generated column, copied from the table's row below
+				continue
+			}
+			v := row[idx]
+			if v == nil {
+				if isKey {
+					return fmt.Errorf("primary key column %s cannot be NULL", col.Name)
+				}
+			} else {
+				typ := s.rowOperationSchema.Schema[idx].Type
+				converted, inRange, err := typ.Convert(v)
+				if err != nil {
+					return err
+				} else if !inRange {
+					return sql.ErrValueOutOfRange.New(v, typ)
+				}
+				v = converted
+			}
+			if err := tree.PutField(ctx, ns, bld, i, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var generated []int
+	for i, col := range valCols {
+		if s.rowOperationSchema.Schema.IndexOfColName(col.Name) < 0 {
+			generated = append(generated, i)
+		}
+	}
+
+	var numRows int64
+	line := 0
+	for row := range inputChannel {
+		line++
+		err := putFields(keyBld, keyCols, row, true)
+		if err == nil {
+			err = putFields(valBld, valCols, row, false)
+		}
+		if err != nil {
+			keyBld.Recycle()
+			valBld.Recycle()
+			if badRowCb(row, s.rowOperationSchema, s.tableName, line, err) {
+				return prolly.Map{}, 0, err
+			}
+			continue
+		}
+
+		k := keyBld.BuildPermissive(syncPool)
+		if len(generated) > 0 {
+			err = from.Get(ctx, k, func(_, existing val.Tuple) error {
+				if existing == nil {
+					return nil
+				}
+				for _, i := range generated {
+					valBld.PutRaw(i, existing.GetField(i))
+				}
+				return nil
+			})
+			if err != nil {
+				return prolly.Map{}, 0, err
+			}
+		}
+		// NOT NULL constraints of non-key columns are checked when the row is written
+		v := valBld.BuildPermissive(syncPool)
+		if err = mut.Put(ctx, k, v); err != nil {
+			return prolly.Map{}, 0, err
+		}
+		numRows++
+	}
+
+	staged, err := mut.Map(ctx)
+	if err != nil {
+		return prolly.Map{}, 0, err
+	}
+	return staged, numRows, nil
+}
+
+// syncDiffStat returns the stat of |td|, the diff between the table and the table holding the rows being imported.
+func syncDiffStat(ctx context.Context, td diff.TableDelta) (diff.DiffStatProgress, error) {
+	ch := make(chan diff.DiffStatProgress)
+
+	grp, ctx2 := errgroup.WithContext(ctx)
+	grp.Go(func() error {
+		defer close(ch)
+		return diff.StatForTableDelta(ctx2, ch, td)
+	})
+
+	acc := diff.DiffStatProgress{}
+	grp.Go(func() error {
+		for {
+			select {
+			case p, ok := <-ch:
+				if !ok {
+					return nil
+				}
+				acc.Adds += p.Adds
+				acc.Removes += p.Removes
+				acc.Changes += p.Changes
+				acc.CellChanges += p.CellChanges
+				acc.NewRowSize += p.NewRowSize
+				acc.OldRowSize += p.OldRowSize
+				acc.NewCellSize += p.NewCellSize
+				acc.OldCellSize += p.OldCellSize
+			case <-ctx2.Done():
+				return ctx2.Err()
+			}
+		}
+	})
+
+	if err := grp.Wait(); err != nil {
+		return diff.DiffStatProgress{}, err
+	}
+	return acc, nil
+}
+
+type kvPair struct {
+	key, value val.Tuple
+}
+
+// kvPairIter is a prolly.MapIter over a slice of key value pairs.
+type kvPairIter struct {
+	kvs []kvPair
+	idx int
+}
+
+var _ prolly.MapIter = (*kvPairIter)(nil)
+
+func (it *kvPairIter) Next(ctx context.Context) (val.Tuple, val.Tuple, error) {
+	if it.idx >= len(it.kvs) {
+		return nil, nil, io.EOF
+	}
+	kv := it.kvs[it.idx]
+	it.idx++
+	return kv.key, kv.value, nil
+}
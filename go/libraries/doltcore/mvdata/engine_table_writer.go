@@ -16,6 +16,7 @@ package mvdata
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"sync/atomic"
@@ -28,6 +29,7 @@ import (
 	"github.com/dolthub/go-mysql-server/sql/transform"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/commands/engine"
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
@@ -51,6 +53,9 @@ type SqlEngineTableWriter struct {
 	force      bool
 	disableFks bool
 
+	// imp is the import whose transaction the rows are written in, if the writer is one of the tables of an import
+	imp *SqlEngineImport
+
 	statsCB noms.StatsCB
 	stats   types.AppliedEditStats
 	statOps int32
@@ -58,6 +63,11 @@ type SqlEngineTableWriter struct {
 	importOption       TableImportOp
 	tableSchema        sql.PrimaryKeySchema
 	rowOperationSchema sql.PrimaryKeySchema
+	// indexes are the secondary indexes of the table, if it's created
+	indexes []schema.Index
+
+	// syncStat is the stat of the diff applied by a sync import
+	syncStat diff.DiffStatProgress
 }
 
 func NewSqlEngineTableWriter(ctx context.Context, dEnv *env.DoltEnv, createTableSchema, rowOperationSchema schema.Schema, options *MoverOptions, statsCB noms.StatsCB) (*SqlEngineTableWriter, error) {
@@ -122,6 +132,7 @@ func NewSqlEngineTableWriter(ctx context.Context, dEnv *env.DoltEnv, createTable
 		importOption:       options.Operation,
 		tableSchema:        doltCreateTableSchema,
 		rowOperationSchema: doltRowOperationSchema,
+		indexes:            createTableSchema.Indexes().AllIndexes(),
 	}, nil
 }
 
@@ -131,9 +142,11 @@ func (s *SqlEngineTableWriter) WriteRows(ctx context.Context, inputChannel chan
 		return err
 	}
 
-	_, _, _, err = s.se.Query(s.sqlCtx, "START TRANSACTION")
-	if err != nil {
-		return err
+	if s.imp == nil {
+		_, _, _, err = s.se.Query(s.sqlCtx, "START TRANSACTION")
+		if err != nil {
+			return err
+		}
 	}
 
 	if s.disableFks {
@@ -170,6 +183,17 @@ func (s *SqlEngineTableWriter) WriteRows(ctx context.Context, inputChannel chan
 		}
 	}
 
+	// syncWait waits for the rows of a sync import to be read from its diff
+	syncWait := func() error { return nil }
+	if s.importOption == SyncOp {
+		syncCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		inputChannel, syncWait, err = s.syncRows(syncCtx, inputChannel, badRowCb)
+		if err != nil {
+			return err
+		}
+	}
+
 	insertOrUpdateOperation, err := s.getInsertNode(inputChannel, false)
 	if err != nil {
 		return err
@@ -188,7 +212,7 @@ func (s *SqlEngineTableWriter) WriteRows(ctx context.Context, inputChannel chan
 	}()
 
 	// If there were create table statements, they are automatically committed, so we need to start a new transaction
-	if s.importOption == CreateOp {
+	if s.importOption == CreateOp && s.imp == nil {
 		_, iter, _, err := s.se.Query(s.sqlCtx, "START TRANSACTION")
 		if err != nil {
 			return err
@@ -204,7 +228,7 @@ func (s *SqlEngineTableWriter) WriteRows(ctx context.Context, inputChannel chan
 		}
 	}
 
-	line := 1
+	line := 0
 	for {
 		if s.statsCB != nil && atomic.LoadInt32(&s.statOps) >= tableWriterStatUpdateRate {
 			atomic.StoreInt32(&s.statOps, 0)
@@ -219,6 +243,9 @@ func (s *SqlEngineTableWriter) WriteRows(ctx context.Context, inputChannel chan
 			_ = atomic.AddInt32(&s.statOps, 1)
 			updateStats(row)
 		} else if err == io.EOF {
+			if err := syncWait(); err != nil {
+				return err
+			}
 			atomic.LoadInt32(&s.statOps)
 			atomic.StoreInt32(&s.statOps, 0)
 			if s.statsCB != nil {
@@ -233,6 +260,12 @@ func (s *SqlEngineTableWriter) WriteRows(ctx context.Context, inputChannel chan
 				offendingRow = n.OffendingRow
 			case sql.IgnorableError:
 				offendingRow = n.OffendingRow
+				// the cause of an ignored error is recorded as a session warning, which is cleared so that they don't
+				// accumulate over the import
+				if warnings := s.sqlCtx.Session.Warnings(); len(warnings) > 0 {
+					err = errors.New(warnings[0].Message)
+				}
+				s.sqlCtx.Session.ClearWarnings()
 			}
 
 			quit := badRowCb(offendingRow, s.tableSchema, s.tableName, line, err)
@@ -244,6 +277,10 @@ func (s *SqlEngineTableWriter) WriteRows(ctx context.Context, inputChannel chan
 }
 
 func (s *SqlEngineTableWriter) Commit(ctx context.Context) error {
+	if s.imp != nil {
+		// the rows are committed with the rest of the import
+		return nil
+	}
 	_, iter, _, err := s.se.Query(s.sqlCtx, "COMMIT")
 	if err != nil {
 		return err
@@ -260,6 +297,11 @@ func (s *SqlEngineTableWriter) Commit(ctx context.Context) error {
 	return nil
 }
 
+// SyncStat returns the stat of the diff between the table's rows and the imported rows for a sync import.
+func (s *SqlEngineTableWriter) SyncStat() diff.DiffStatProgress {
+	return s.syncStat
+}
+
 func (s *SqlEngineTableWriter) RowOperationSchema() sql.PrimaryKeySchema {
 	return s.rowOperationSchema
 }
@@ -270,6 +312,10 @@ func (s *SqlEngineTableWriter) TableSchema() sql.PrimaryKeySchema {
 
 func (s *SqlEngineTableWriter) DropCreatedTable() error {
 	// quitting import that created table, should drop table
+	if s.imp != nil {
+		// the table is dropped when the import is rolled back
+		return nil
+	}
 	if s.importOption == CreateOp {
 		var err error
 		var iter sql.RowIter
@@ -306,7 +352,9 @@ func (s *SqlEngineTableWriter) DropCreatedTable() error {
 
 // forceDropTableIfNeeded drop the given table in case the -f parameter is passed.
 func (s *SqlEngineTableWriter) forceDropTableIfNeeded() error {
-	if s.force {
+	if s.force && s.imp != nil {
+		return s.dropTableInTx()
+	} else if s.force {
 		_, _, _, err := s.se.Query(s.sqlCtx, fmt.Sprintf("DROP TABLE IF EXISTS `%s`", s.tableName))
 		return err
 	}
@@ -318,9 +366,21 @@ func (s *SqlEngineTableWriter) forceDropTableIfNeeded() error {
 func (s *SqlEngineTableWriter) createOrEmptyTableIfNeeded() error {
 	switch s.importOption {
 	case CreateOp:
+		if s.imp != nil {
+			return s.createTableInTx()
+		}
 		return s.createTable()
 	case ReplaceOp:
-		_, _, _, err := s.se.Query(s.sqlCtx, fmt.Sprintf("TRUNCATE TABLE `%s`", s.tableName))
+		// TRUNCATE is DDL, which would commit the transaction of an import
+		query := "TRUNCATE TABLE `%s`"
+		if s.imp != nil {
+			query = "DELETE FROM `%s`"
+		}
+		_, iter, _, err := s.se.Query(s.sqlCtx, fmt.Sprintf(query, s.tableName))
+		if err != nil {
+			return err
+		}
+		_, err = sql.RowIterToRows(s.sqlCtx, iter)
 		return err
 	default:
 		return nil
@@ -335,6 +395,9 @@ func (s *SqlEngineTableWriter) createTable() error {
 	for i, c := range s.tableSchema.Schema {
 		sqlCols[i] = sql.GenerateCreateTableColumnDefinition(c, c.Default.String(), c.OnUpdate.String(), sql.Collation_Default)
 	}
+	for _, index := range s.indexes {
+		sqlCols = append(sqlCols, createTableIndexDefinition(index))
+	}
 	var pks string
 	var sep string
 	for _, i := range s.tableSchema.PkOrdinals {
@@ -354,10 +417,22 @@ func (s *SqlEngineTableWriter) createTable() error {
 	return err
 }
 
+// createTableIndexDefinition returns the definition of |index| in a CREATE TABLE statement, including the prefix
+// lengths of its columns.
+func createTableIndexDefinition(index schema.Index) string {
+	cols := sql.QuoteIdentifiers(index.ColumnNames())
+	for i, prefixLength := range index.PrefixLengths() {
+		if prefixLength > 0 {
+			cols[i] = fmt.Sprintf("%s(%d)", cols[i], prefixLength)
+		}
+	}
+	return sql.GenerateCreateTableIndexDefinition(index.IsUnique(), index.IsSpatial(), index.IsFullText(), index.IsVector(), index.Name(), cols, index.Comment())
+}
+
 // createInsertImportNode creates the relevant/analyzed insert node given the import option. This insert node is wrapped
 // with an error handler.
 func (s *SqlEngineTableWriter) getInsertNode(inputChannel chan sql.Row, replace bool) (sql.Node, error) {
-	update := s.importOption == UpdateOp
+	update := s.importOption == UpdateOp || s.importOption == SyncOp
 	colNames := ""
 	values := ""
 	duplicate := ""
@@ -28,6 +28,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/arrow"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/json"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/parquet"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/untyped/csv"
@@ -47,10 +48,16 @@ func DFFromString(dfStr string) DataFormat {
 		return XlsxFile
 	case "json", ".json":
 		return JsonFile
+	case "jsonl", ".jsonl", "ndjson", ".ndjson":
+		return JsonlFile
 	case "sql", ".sql":
 		return SqlFile
 	case "parquet", ".parquet":
 		return ParquetFile
+	case "arrow", ".arrow", "feather", ".feather":
+		return ArrowFile
+	case "arrows", ".arrows", "arrow-stream":
+		return ArrowStreamFile
 	default:
 		return InvalidDataFormat
 	}
@@ -118,37 +125,21 @@ func (dl FileDataLocation) NewReader(ctx context.Context, dEnv *env.DoltEnv, opt
 		return rd, false, err
 
 	case JsonFile:
-		var sch schema.Schema
-		jsonOpts, _ := opts.(JSONOptions)
-		if jsonOpts.SchFile != "" {
-			tn, s, err := SchAndTableNameFromFile(ctx, jsonOpts.SchFile, dEnv)
-			if err != nil {
-				return nil, false, err
-			}
-			if tn != jsonOpts.TableName {
-				return nil, false, fmt.Errorf("table name '%s' from schema file %s does not match table arg '%s'", tn, jsonOpts.SchFile, jsonOpts.TableName)
-			}
-			sch = s
-		} else {
-			if opts == nil {
-				return nil, false, errors.New("Unable to determine table name on JSON import")
-			}
-			tbl, exists, err := root.GetTable(context.TODO(), doltdb.TableName{Name: jsonOpts.TableName})
-			if !exists {
-				return nil, false, fmt.Errorf("The following table could not be found:\n%v", jsonOpts.TableName)
-			}
-			if err != nil {
-				return nil, false, fmt.Errorf("An error occurred attempting to read the table:\n%v", err.Error())
-			}
-			sch, err = tbl.GetSchema(context.TODO())
-			if err != nil {
-				return nil, false, fmt.Errorf("An error occurred attempting to read the table schema:\n%v", err.Error())
-			}
+		sch, err := jsonImportSchema(ctx, dEnv, root, opts)
+		if err != nil {
+			return nil, false, err
 		}
-
 		rd, err := json.OpenJSONReader(root.VRW(), dl.Path, fs, sch)
 		return rd, false, err
 
+	case JsonlFile:
+		sch, err := jsonImportSchema(ctx, dEnv, root, opts)
+		if err != nil {
+			return nil, false, err
+		}
+		rd, err := json.OpenJSONLReader(root.VRW(), dl.Path, fs, sch)
+		return rd, false, err
+
 	case ParquetFile:
 		var tableSch schema.Schema
 		parquetOpts, _ := opts.(ParquetOptions)
@@ -179,11 +170,74 @@ func (dl FileDataLocation) NewReader(ctx context.Context, dEnv *env.DoltEnv, opt
 		}
 		rd, rErr := parquet.OpenParquetReader(root.VRW(), dl.Path, tableSch)
 		return rd, false, rErr
+
+	case ArrowFile, ArrowStreamFile:
+		arrowOpts, ok := opts.(ArrowOptions)
+		if !ok {
+			return nil, false, errors.New("Unable to determine table name on Arrow import")
+		}
+		sch, err := importSchema(ctx, dEnv, root, arrowOpts.TableName, arrowOpts.SchFile)
+		if err != nil {
+			return nil, false, err
+		}
+		rd, err := arrow.OpenArrowReader(root.VRW(), dl.Path, fs, sch)
+		return rd, false, err
 	}
 
 	return nil, false, errors.New("unsupported format")
 }
 
+// jsonImportSchema returns the schema of the rows being imported from a JSON or JSON Lines file with |opts|.
+func jsonImportSchema(ctx context.Context, dEnv *env.DoltEnv, root doltdb.RootValue, opts interface{}) (schema.Schema, error) {
+	jsonOpts, ok := opts.(JSONOptions)
+	if !ok {
+		return nil, errors.New("Unable to determine table name on JSON import")
+	}
+	return importSchema(ctx, dEnv, root, jsonOpts.TableName, jsonOpts.SchFile)
+}
+
+// importSchema returns the schema of the rows being imported into |tableName| from a file which doesn't describe its
+// own schema. The schema is read from |schFile| if one is given, and otherwise from the table being imported into.
+func importSchema(ctx context.Context, dEnv *env.DoltEnv, root doltdb.RootValue, tableName, schFile string) (schema.Schema, error) {
+	if schFile != "" {
+		tn, s, err := SchAndTableNameFromFile(ctx, schFile, dEnv)
+		if err != nil {
+			return nil, err
+		}
+		if tn != tableName {
+			return nil, fmt.Errorf("table name '%s' from schema file %s does not match table arg '%s'", tn, schFile, tableName)
+		}
+		return s, nil
+	}
+
+	tbl, exists, err := root.GetTable(context.TODO(), doltdb.TableName{Name: tableName})
+	if !exists {
+		return nil, fmt.Errorf("The following table could not be found:\n%v", tableName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("An error occurred attempting to read the table:\n%v", err.Error())
+	}
+	sch, err := tbl.GetSchema(context.TODO())
+	if err != nil {
+		return nil, fmt.Errorf("An error occurred attempting to read the table schema:\n%v", err.Error())
+	}
+	return sch, nil
+}
+
+// ParquetDataMoverOptions are DataMoverOptions which set the options of the parquet files written.
+type ParquetDataMoverOptions interface {
+	DataMoverOptions
+	ParquetWriterOptions() parquet.WriterOptions
+}
+
+// parquetWriterOptions returns the options of the parquet files written for |mvOpts|.
+func parquetWriterOptions(mvOpts DataMoverOptions) parquet.WriterOptions {
+	if pqOpts, ok := mvOpts.(ParquetDataMoverOptions); ok {
+		return pqOpts.ParquetWriterOptions()
+	}
+	return parquet.WriterOptions{}
+}
+
 // NewCreatingWriter will create a TableWriteCloser for a DataLocation that will create a new table, or overwrite
 // an existing table.
 func (dl FileDataLocation) NewCreatingWriter(ctx context.Context, mvOpts DataMoverOptions, root doltdb.RootValue, outSch schema.Schema, opts editor.Options, wr io.WriteCloser) (table.SqlRowWriter, error) {
@@ -193,9 +247,11 @@ func (dl FileDataLocation) NewCreatingWriter(ctx context.Context, mvOpts DataMov
 	case PsvFile:
 		return csv.NewCSVWriter(wr, outSch, csv.NewCSVInfo().SetDelim("|"))
 	case XlsxFile:
-		panic("writing to xlsx files is not supported yet")
+		return xlsx.NewXLSXWriter(wr, outSch, mvOpts.SrcName())
 	case JsonFile:
 		return json.NewJSONWriter(wr, outSch)
+	case JsonlFile:
+		return json.NewJSONLWriter(wr, outSch)
 	case SqlFile:
 		if mvOpts.IsBatched() {
 			return sqlexport.OpenBatchedSQLExportWriter(ctx, wr, root, mvOpts.SrcName(), mvOpts.IsAutocommitOff(), outSch, opts)
@@ -203,7 +259,11 @@ func (dl FileDataLocation) NewCreatingWriter(ctx context.Context, mvOpts DataMov
 			return sqlexport.OpenSQLExportWriter(ctx, wr, root, mvOpts.SrcName(), mvOpts.IsAutocommitOff(), outSch, opts)
 		}
 	case ParquetFile:
-		return parquet.NewParquetRowWriterForFile(outSch, mvOpts.DestName())
+		return parquet.NewParquetRowWriterForFile(outSch, mvOpts.DestName(), parquetWriterOptions(mvOpts))
+	case ArrowFile:
+		return arrow.NewArrowRowWriter(outSch, wr, arrow.FileFormat)
+	case ArrowStreamFile:
+		return arrow.NewArrowRowWriter(outSch, wr, arrow.StreamFormat)
 	}
 
 	panic("Invalid Data Format." + string(dl.Format))
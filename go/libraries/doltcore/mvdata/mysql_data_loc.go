@@ -0,0 +1,68 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvdata
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/mysql"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+// MySQLDataLocation is a table or a query of a MySQL server which rows can be imported from. Rows are streamed from
+// the server, and the types of the columns are read from the server rather than inferred.
+type MySQLDataLocation struct {
+	// DSN is the data source name of the server, in the format of github.com/go-sql-driver/mysql
+	DSN string
+	// Table is the name of the table rows are read from, if rows aren't read from a Query
+	Table string
+	// Query is the query rows are read from, if rows aren't read from a Table
+	Query string
+}
+
+// String returns a string representation of the data location.
+func (dl MySQLDataLocation) String() string {
+	if dl.Table != "" {
+		return "mysql table " + dl.Table
+	}
+	return "mysql query"
+}
+
+// Exists returns true if the DataLocation already exists
+func (dl MySQLDataLocation) Exists(ctx context.Context, root doltdb.RootValue, fs filesys.ReadableFS) (bool, error) {
+	return true, nil
+}
+
+// NewReader creates a TableReadCloser for the DataLocation
+func (dl MySQLDataLocation) NewReader(ctx context.Context, dEnv *env.DoltEnv, opts interface{}) (rdCl table.SqlRowReader, sorted bool, err error) {
+	rd, err := mysql.OpenMySQLReader(ctx, dl.DSN, dl.Table, dl.Query)
+	if err != nil {
+		return nil, false, err
+	}
+	return rd, false, nil
+}
+
+// NewCreatingWriter will create a TableWriteCloser for a DataLocation that will create a new table, or overwrite
+// an existing table.
+func (dl MySQLDataLocation) NewCreatingWriter(ctx context.Context, mvOpts DataMoverOptions, root doltdb.RootValue, outSch schema.Schema, opts editor.Options, wr io.WriteCloser) (table.SqlRowWriter, error) {
+	return nil, errors.New("writing to a MySQL server is not supported")
+}
@@ -32,6 +32,8 @@ type RemoteSrvStore interface {
 
 	Path() (string, bool)
 	GetChunkLocationsWithPaths(ctx context.Context, hashes hash.HashSet) (map[string]map[hash.Hash]nbs.Range, error)
+	// OpenColdTableFile opens the table files whose location prefix is nbs.ColdGenPrefix, which aren't on local disk.
+	OpenColdTableFile(ctx context.Context, fileId string) (nbs.TableFileReader, int64, error)
 }
 
 var _ RemoteSrvStore = &nbs.NomsBlockStore{}
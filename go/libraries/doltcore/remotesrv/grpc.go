@@ -196,7 +196,7 @@ func (rs *RemoteChunkStore) GetDownloadLocations(ctx context.Context, req *remot
 			ranges = append(ranges, &remotesapi.RangeChunk{Hash: hCpy[:], Offset: r.Offset, Length: r.Length})
 		}
 
-		url := rs.getDownloadUrl(md, prefix+"/"+loc)
+		url := rs.getTableFileUrl(md, repoPath, prefix, loc)
 		preurl := url.String()
 		url, err = rs.sealer.Seal(url)
 		if err != nil {
@@ -304,7 +304,7 @@ func (rs *RemoteChunkStore) StreamDownloadLocations(stream remotesapi.ChunkStore
 					DictionaryLength: r.DictLength})
 			}
 
-			url := rs.getDownloadUrl(md, prefix+"/"+loc)
+			url := rs.getTableFileUrl(md, repoPath, prefix, loc)
 			preurl := url.String()
 			url, err = rs.sealer.Seal(url)
 			if err != nil {
@@ -362,6 +362,18 @@ func (rs *RemoteChunkStore) getDownloadUrl(md metadata.MD, path string) *url.URL
 	}
 }
 
+// getTableFileUrl returns the download URL of the table file at |loc|, relative to the store of the repository
+// |repoPath|, which is at |prefix|. The table files of the cold generation of a store aren't on local disk, so their
+// URLs name the repository, and the HTTP server reads them through the store.
+func (rs *RemoteChunkStore) getTableFileUrl(md metadata.MD, repoPath, prefix, loc string) *url.URL {
+	if fileId, ok := strings.CutPrefix(loc, nbs.ColdGenPrefix+"/"); ok {
+		u := rs.getDownloadUrl(md, repoPath+"/"+fileId)
+		u.RawQuery = url.Values{coldTierParam: []string{"true"}}.Encode()
+		return u
+	}
+	return rs.getDownloadUrl(md, prefix+"/"+loc)
+}
+
 func getTableFileDetails(req *remotesapi.GetUploadLocsRequest) ([]*remotesapi.TableFileDetails, error) {
 	tfd := req.GetTableFileDetails()
 	if len(tfd) == 0 {
@@ -612,7 +624,7 @@ func getTableFileInfo(
 	}
 	appendixTableFileInfo := make([]*remotesapi.TableFileInfo, 0)
 	for _, t := range tableList {
-		url := rs.getDownloadUrl(md, prefix+"/"+t.LocationPrefix()+t.FileID()+t.LocationSuffix())
+		url := rs.getTableFileUrl(md, getRepoPath(req), prefix, t.LocationPrefix()+t.FileID()+t.LocationSuffix())
 		url, err = rs.sealer.Seal(url)
 		if err != nil {
 			return nil, status.Error(codes.Internal, "failed to get seal download url for "+t.FileID())
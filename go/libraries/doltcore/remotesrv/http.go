@@ -39,6 +39,10 @@ import (
 	"github.com/dolthub/dolt/go/store/types"
 )
 
+// coldTierParam is the query parameter of the download URLs of the table files of the cold generation of a store,
+// whose paths are the path of the repository followed by the file id.
+const coldTierParam = "cold_tier"
+
 var (
 	ErrReadOutOfBounds = errors.New("cannot read file for given length and " +
 		"offset since the read would exceed the size of the file")
@@ -107,14 +111,25 @@ func (fh filehandler) ServeHTTP(respWr http.ResponseWriter, req *http.Request) {
 			return
 		}
 
-		abs, err := fh.fs.Abs(path)
-		if err != nil {
-			logger.WithError(err).Error("could not get absolute path")
-			respWr.WriteHeader(http.StatusInternalServerError)
-			return
+		var open func() (nbs.TableFileReader, int64, error)
+		if req.URL.Query().Get(coldTierParam) != "" {
+			repoPath, fileId := path[:i], path[i+1:]
+			open = func() (nbs.TableFileReader, int64, error) {
+				return fh.openColdTableFile(req.Context(), repoPath, fileId)
+			}
+		} else {
+			abs, err := fh.fs.Abs(path)
+			if err != nil {
+				logger.WithError(err).Error("could not get absolute path")
+				respWr.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			open = func() (nbs.TableFileReader, int64, error) {
+				return openFile(abs)
+			}
 		}
 		respWr.Header().Add("Accept-Ranges", "bytes")
-		logger, statusCode = readTableFile(logger, abs, respWr, req.Header.Get("Range"))
+		logger, statusCode = readTableFile(logger, open, respWr, req.Header.Get("Range"))
 
 	case http.MethodPost, http.MethodPut:
 		if fh.readOnly {
@@ -184,14 +199,14 @@ func (fh filehandler) ServeHTTP(respWr http.ResponseWriter, req *http.Request) {
 	}
 }
 
-func readTableFile(logger *logrus.Entry, path string, respWr http.ResponseWriter, rangeStr string) (*logrus.Entry, int) {
+func readTableFile(logger *logrus.Entry, open func() (nbs.TableFileReader, int64, error), respWr http.ResponseWriter, rangeStr string) (*logrus.Entry, int) {
 	var r io.ReadCloser
 	var readSize int64
 	var fileErr error
 	{
 		if rangeStr == "" {
 			logger = logger.WithField("whole_file", true)
-			r, readSize, fileErr = getFileReader(path)
+			r, readSize, fileErr = getFileReader(open)
 		} else {
 			offset, length, headerStr, err := offsetAndLenFromRange(rangeStr)
 			if err != nil {
@@ -204,7 +219,7 @@ func readTableFile(logger *logrus.Entry, path string, respWr http.ResponseWriter
 			})
 			readSize = length
 			var fSize int64
-			r, fSize, fileErr = getFileReaderAt(path, offset, length)
+			r, fSize, fileErr = getFileReaderAt(open, offset, length)
 			if fileErr == nil {
 				respWr.Header().Add("Content-Range", headerStr+strconv.Itoa(int(fSize)))
 			}
@@ -361,24 +376,39 @@ func offsetAndLenFromRange(rngStr string) (int64, int64, string, error) {
 	return int64(start), int64(end-start) + 1, "bytes " + tokens[0] + "-" + tokens[1] + "/", nil
 }
 
-// getFileReader opens a file at the given path and returns an io.ReadCloser,
+// getFileReader opens a file with |open| and returns an io.ReadCloser,
 // the corresponding file's filesize, and a http status.
-func getFileReader(path string) (io.ReadCloser, int64, error) {
-	return openFile(path)
+func getFileReader(open func() (nbs.TableFileReader, int64, error)) (io.ReadCloser, int64, error) {
+	f, fSize, err := open()
+	if err != nil {
+		return nil, 0, err
+	}
+	return closerReaderWrapper{io.NewSectionReader(f, 0, fSize), f}, fSize, nil
 }
 
-func openFile(path string) (*os.File, int64, error) {
-	info, err := os.Stat(path)
+// openFile opens the table file at |path|, which is decrypted as it's read if it's encrypted at rest, and returns
+// the size of its contents.
+func openFile(path string) (nbs.TableFileReader, int64, error) {
+	_, err := os.Stat(path)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get stats for file at path %s: %w", path, err)
 	}
 
-	f, err := os.Open(path)
+	f, fSize, err := nbs.OpenTableFile(path)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to open file at path %s: %w", path, err)
 	}
 
-	return f, info.Size(), nil
+	return f, fSize, nil
+}
+
+// openColdTableFile opens the table file |fileId| of the cold generation of the store of the repository |repoPath|.
+func (fh filehandler) openColdTableFile(ctx context.Context, repoPath, fileId string) (nbs.TableFileReader, int64, error) {
+	cs, err := fh.dbCache.Get(ctx, repoPath, types.Format_Default.VersionString())
+	if err != nil {
+		return nil, 0, err
+	}
+	return cs.OpenColdTableFile(ctx, fileId)
 }
 
 type closerReaderWrapper struct {
@@ -386,22 +416,18 @@ type closerReaderWrapper struct {
 	io.Closer
 }
 
-func getFileReaderAt(path string, offset int64, length int64) (io.ReadCloser, int64, error) {
-	f, fSize, err := openFile(path)
+func getFileReaderAt(open func() (nbs.TableFileReader, int64, error), offset int64, length int64) (io.ReadCloser, int64, error) {
+	f, fSize, err := open()
 	if err != nil {
 		return nil, 0, err
 	}
 
 	if fSize < int64(offset+length) {
-		return nil, 0, fmt.Errorf("failed to read file %s at offset %d, length %d: %w", path, offset, length, ErrReadOutOfBounds)
-	}
-
-	_, err = f.Seek(int64(offset), 0)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to seek file at path %s to offset %d: %w", path, offset, err)
+		f.Close()
+		return nil, 0, fmt.Errorf("failed to read file at offset %d, length %d: %w", offset, length, ErrReadOutOfBounds)
 	}
 
-	r := closerReaderWrapper{io.LimitReader(f, length), f}
+	r := closerReaderWrapper{io.NewSectionReader(f, offset, length), f}
 	return r, fSize, nil
 }
 
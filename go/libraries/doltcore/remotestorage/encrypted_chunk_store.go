@@ -0,0 +1,816 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotestorage
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/golang/snappy"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/datas/pull"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
+	"github.com/dolthub/dolt/go/store/util/tempfiles"
+)
+
+// The chunks of an encrypted remote are stored under keyed hashes of their addresses, so that the remote can neither
+// read their contents nor match their addresses against known data. Each stored chunk is
+//
+//	[magic:4][nonce:12][AES-256-GCM([kind:1][address:20][snappy compressed data])]
+//
+// authenticated along with the address it's stored under. The magic lets the remote tell that it's encrypted.
+//
+// Keyed hashes are only computed for chunks as they're first written. The mapping from the addresses of the chunks in
+// the remote to the addresses they're stored under is kept in the remote, in mapping chunks whose data is a sorted
+// list of [address:20][stored address:20] entries, and chunks are looked up through it. The remote's manifest can only
+// hold the stored address of its root, which can't be reversed, so its root is instead the stored address of a root
+// record, an encrypted chunk which holds the root's address and, as its data, the stored addresses of the mapping
+// chunks. Each commit adds a mapping chunk with the chunks written since the last one, and once there are
+// maxMappingChunks of them, they're compacted.
+const (
+	encryptedChunkKindData    byte = 0
+	encryptedChunkKindRoot    byte = 1
+	encryptedChunkKindMapping byte = 2
+
+	encryptionKeySize     = 32
+	encryptedChunkHdrSize = 1 + hash.ByteLen
+	mappingEntrySize      = 2 * hash.ByteLen
+
+	// maxMappingChunks is the number of mapping chunks a root record can reference before they're compacted.
+	maxMappingChunks = 64
+	// compactedMappingEntries is the number of entries in each of the mapping chunks written by a compaction.
+	compactedMappingEntries = 32 * 1024
+)
+
+// encryptedChunkMagic starts every chunk of an encrypted remote.
+var encryptedChunkMagic = []byte("DENC")
+
+var ErrRemoteEncryptionKey = errors.New("the remote could not be decrypted; it isn't encrypted or it's encrypted with a different key")
+
+var errCorruptEncryptedChunk = errors.New("encrypted remote chunk is corrupt")
+
+var _ chunks.TableFileStore = (*EncryptedChunkStore)(nil)
+var _ nbs.NBSCompressedChunkStore = (*EncryptedChunkStore)(nil)
+
+// IsEncryptedChunk returns whether |data| is a chunk of a remote which is encrypted on the client. The remote can't
+// walk the references of such chunks.
+func IsEncryptedChunk(data []byte) bool {
+	return bytes.HasPrefix(data, encryptedChunkMagic)
+}
+
+// remoteChunkStore is the interface of the chunk stores an EncryptedChunkStore can be built on, which include the
+// DoltChunkStore and the NomsBlockStores of blobstore backed remotes.
+type remoteChunkStore interface {
+	nbs.NBSCompressedChunkStore
+	chunks.TableFileStore
+}
+
+// EncryptedChunkStore is a chunks.ChunkStore for remotes which are encrypted on the client. Chunks are encrypted
+// before they're written to the remote and decrypted as they're read, and their addresses are mapped to keyed hashes,
+// so the remote never sees plaintext.
+type EncryptedChunkStore struct {
+	cs      remoteChunkStore
+	aead    cipher.AEAD
+	addrKey []byte
+
+	mu sync.Mutex
+	// root is the root of the remote, as of the last call to Root or Commit, storedRoot is the stored address of its
+	// root record, and mappingChunks are the stored addresses of the mapping chunks the root record references
+	root          hash.Hash
+	storedRoot    hash.Hash
+	loaded        bool
+	mappingChunks []hash.Hash
+	// tableFiles maps the ids of the table files written with WriteTableFile to those of their encrypted copies
+	tableFiles map[string]string
+
+	// mapMu guards the mapping, which is looked up by the GetAddrsCbs the remote calls while it's committing, so it's
+	// never held across calls to the remote.
+	mapMu sync.RWMutex
+	// addrs maps the addresses of the chunks in the mapping chunks of the remote to the addresses they're stored under,
+	// and pending does the same for the chunks written since the last commit
+	addrs   map[hash.Hash]hash.Hash
+	pending map[hash.Hash]hash.Hash
+}
+
+// ParseEncryptionKey parses the base64 encoded 32 byte key of an encrypted remote.
+func ParseEncryptionKey(s string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote encryption key: %w", err)
+	} else if len(key) != encryptionKeySize {
+		return nil, fmt.Errorf("invalid remote encryption key: expected %d bytes, found %d", encryptionKeySize, len(key))
+	}
+	return key, nil
+}
+
+// NewEncryptedChunkStore returns an EncryptedChunkStore which encrypts the chunks written to |cs| with |key|.
+func NewEncryptedChunkStore(cs chunks.ChunkStore, key []byte) (*EncryptedChunkStore, error) {
+	rcs, ok := cs.(remoteChunkStore)
+	if !ok {
+		return nil, fmt.Errorf("remotes of type %T can't be encrypted", cs)
+	}
+
+	encKey, err := hkdf.Key(sha256.New, key, nil, "dolt remote chunk encryption", encryptionKeySize)
+	if err != nil {
+		return nil, err
+	}
+	addrKey, err := hkdf.Key(sha256.New, key, nil, "dolt remote chunk addresses", encryptionKeySize)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(encKey)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptedChunkStore{
+		cs:         rcs,
+		aead:       aead,
+		addrKey:    addrKey,
+		tableFiles: make(map[string]string),
+		addrs:      make(map[hash.Hash]hash.Hash),
+		pending:    make(map[hash.Hash]hash.Hash),
+	}, nil
+}
+
+// keyedAddr returns the keyed hash of the chunk of |kind| with address |h| and |data|. Only root records hash their
+// data, since the address of every other chunk is a hash of its data, and a root can be committed with different
+// mapping chunks.
+func (ecs *EncryptedChunkStore) keyedAddr(kind byte, h hash.Hash, data []byte) hash.Hash {
+	mac := hmac.New(sha256.New, ecs.addrKey)
+	mac.Write([]byte{kind})
+	mac.Write(h[:])
+	if kind == encryptedChunkKindRoot {
+		mac.Write(data)
+	}
+	return hash.New(mac.Sum(nil)[:hash.ByteLen])
+}
+
+// lookup returns the address the chunk with address |h| is stored under, if it's in the remote or was written to it.
+func (ecs *EncryptedChunkStore) lookup(h hash.Hash) (hash.Hash, bool) {
+	ecs.mapMu.RLock()
+	defer ecs.mapMu.RUnlock()
+	if s, ok := ecs.addrs[h]; ok {
+		return s, true
+	}
+	s, ok := ecs.pending[h]
+	return s, ok
+}
+
+// refAddr returns the address the chunk with address |h| is stored under, or will be once it's written.
+func (ecs *EncryptedChunkStore) refAddr(h hash.Hash) hash.Hash {
+	if s, ok := ecs.lookup(h); ok {
+		return s
+	}
+	return ecs.keyedAddr(encryptedChunkKindData, h, nil)
+}
+
+// assign returns the address the chunk with address |h| is stored under, adding it to the mapping if it's new.
+func (ecs *EncryptedChunkStore) assign(h hash.Hash) hash.Hash {
+	if s, ok := ecs.lookup(h); ok {
+		return s
+	}
+	s := ecs.keyedAddr(encryptedChunkKindData, h, nil)
+	ecs.mapMu.Lock()
+	defer ecs.mapMu.Unlock()
+	ecs.pending[h] = s
+	return s
+}
+
+// seal returns the chunk stored under |stored| for the chunk of |kind| with address |h| and |data|.
+func (ecs *EncryptedChunkStore) seal(stored hash.Hash, kind byte, h hash.Hash, data []byte) (chunks.Chunk, error) {
+	pt := make([]byte, encryptedChunkHdrSize, encryptedChunkHdrSize+snappy.MaxEncodedLen(len(data)))
+	pt[0] = kind
+	copy(pt[1:], h[:])
+	pt = append(pt, snappy.Encode(nil, data)...)
+
+	hdrSize := len(encryptedChunkMagic) + ecs.aead.NonceSize()
+	b := make([]byte, hdrSize, hdrSize+len(pt)+ecs.aead.Overhead())
+	copy(b, encryptedChunkMagic)
+	nonce := b[len(encryptedChunkMagic):]
+	if _, err := rand.Read(nonce); err != nil {
+		return chunks.EmptyChunk, err
+	}
+	return chunks.NewChunkWithHash(stored, ecs.aead.Seal(b, nonce, pt, stored[:])), nil
+}
+
+// open returns the kind, address and data of the stored chunk |c|.
+func (ecs *EncryptedChunkStore) open(c chunks.Chunk) (byte, hash.Hash, []byte, error) {
+	stored := c.Hash()
+	sealed := c.Data()
+	if !IsEncryptedChunk(sealed) {
+		return 0, hash.Hash{}, nil, ErrRemoteEncryptionKey
+	}
+	sealed = sealed[len(encryptedChunkMagic):]
+	if len(sealed) < ecs.aead.NonceSize() {
+		return 0, hash.Hash{}, nil, errCorruptEncryptedChunk
+	}
+	pt, err := ecs.aead.Open(nil, sealed[:ecs.aead.NonceSize()], sealed[ecs.aead.NonceSize():], stored[:])
+	if err != nil {
+		return 0, hash.Hash{}, nil, ErrRemoteEncryptionKey
+	} else if len(pt) < encryptedChunkHdrSize {
+		return 0, hash.Hash{}, nil, errCorruptEncryptedChunk
+	}
+
+	kind := pt[0]
+	h := hash.New(pt[1:encryptedChunkHdrSize])
+	data, err := snappy.Decode(nil, pt[encryptedChunkHdrSize:])
+	if err != nil {
+		return 0, hash.Hash{}, nil, errCorruptEncryptedChunk
+	}
+	if kind != encryptedChunkKindRoot && hash.Of(data) != h {
+		return 0, hash.Hash{}, nil, errCorruptEncryptedChunk
+	}
+	return kind, h, data, nil
+}
+
+// openChunk returns the plaintext chunk of the stored chunk |c|, which must have the address |h|.
+func (ecs *EncryptedChunkStore) openChunk(c chunks.Chunk, h hash.Hash) (chunks.Chunk, error) {
+	kind, addr, data, err := ecs.open(c)
+	if err != nil {
+		return chunks.EmptyChunk, err
+	} else if kind != encryptedChunkKindData || addr != h {
+		return chunks.EmptyChunk, errCorruptEncryptedChunk
+	}
+	return chunks.NewChunkWithHash(h, data), nil
+}
+
+// storedAddrs returns the addresses the members of |hashes| which are in the remote are stored under, along with a
+// map back to them.
+func (ecs *EncryptedChunkStore) storedAddrs(hashes hash.HashSet) (hash.HashSet, map[hash.Hash]hash.Hash) {
+	stored := make(hash.HashSet, len(hashes))
+	addrs := make(map[hash.Hash]hash.Hash, len(hashes))
+	for h := range hashes {
+		if s, ok := ecs.lookup(h); ok {
+			stored.Insert(s)
+			addrs[s] = h
+		}
+	}
+	return stored, addrs
+}
+
+// storedGetAddrs returns a GetAddrsCb which adds the stored addresses of the addresses |getAddrs| adds.
+func (ecs *EncryptedChunkStore) storedGetAddrs(getAddrs chunks.GetAddrsCb) chunks.GetAddrsCb {
+	return func(ctx context.Context, addrs hash.HashSet, exists chunks.PendingRefExists) error {
+		refs := make(hash.HashSet)
+		err := getAddrs(ctx, refs, func(h hash.Hash) bool {
+			return exists(ecs.refAddr(h))
+		})
+		if err != nil {
+			return err
+		}
+		for h := range refs {
+			addrs.Insert(ecs.refAddr(h))
+		}
+		return nil
+	}
+}
+
+// rootRecordAddrs returns the GetAddrsCb of a root record, which references the stored address |storedRoot| of the
+// root and the mapping chunks |mappingChunks|.
+func rootRecordAddrs(storedRoot hash.Hash, mappingChunks []hash.Hash) chunks.GetAddrsCb {
+	return func(_ context.Context, addrs hash.HashSet, _ chunks.PendingRefExists) error {
+		addrs.Insert(storedRoot)
+		for _, h := range mappingChunks {
+			addrs.Insert(h)
+		}
+		return nil
+	}
+}
+
+// mappingChunkAddrs is the GetAddrsCb of a mapping chunk, which doesn't reference any chunks.
+func mappingChunkAddrs(context.Context, hash.HashSet, chunks.PendingRefExists) error {
+	return nil
+}
+
+// storedGetAddrsCurry returns a GetAddrsCurry for the stored chunks of the chunks whose addresses |getAddrs| returns.
+func (ecs *EncryptedChunkStore) storedGetAddrsCurry(getAddrs chunks.GetAddrsCurry) chunks.GetAddrsCurry {
+	return func(c chunks.Chunk) chunks.GetAddrsCb {
+		kind, h, data, err := ecs.open(c)
+		if err != nil {
+			return func(context.Context, hash.HashSet, chunks.PendingRefExists) error {
+				return err
+			}
+		}
+		switch kind {
+		case encryptedChunkKindRoot:
+			mappingChunks, err := parseMappingChunkAddrs(data)
+			if err != nil {
+				return func(context.Context, hash.HashSet, chunks.PendingRefExists) error {
+					return err
+				}
+			}
+			return rootRecordAddrs(ecs.refAddr(h), mappingChunks)
+		case encryptedChunkKindMapping:
+			return mappingChunkAddrs
+		}
+		return ecs.storedGetAddrs(getAddrs(chunks.NewChunkWithHash(h, data)))
+	}
+}
+
+// parseMappingChunkAddrs parses the stored addresses of the mapping chunks in the data of a root record.
+func parseMappingChunkAddrs(data []byte) ([]hash.Hash, error) {
+	if len(data)%hash.ByteLen != 0 {
+		return nil, errCorruptEncryptedChunk
+	}
+	addrs := make([]hash.Hash, 0, len(data)/hash.ByteLen)
+	for i := 0; i < len(data); i += hash.ByteLen {
+		addrs = append(addrs, hash.New(data[i:i+hash.ByteLen]))
+	}
+	return addrs, nil
+}
+
+// readRoot returns the root and the mapping chunks of the root record stored under |stored|.
+func (ecs *EncryptedChunkStore) readRoot(ctx context.Context, stored hash.Hash) (hash.Hash, []hash.Hash, error) {
+	c, err := ecs.cs.Get(ctx, stored)
+	if err != nil {
+		return hash.Hash{}, nil, err
+	} else if c.IsEmpty() {
+		return hash.Hash{}, nil, fmt.Errorf("the root record of the remote is missing: %s", stored.String())
+	}
+	kind, root, data, err := ecs.open(c)
+	if err != nil {
+		return hash.Hash{}, nil, err
+	} else if kind != encryptedChunkKindRoot {
+		return hash.Hash{}, nil, ErrRemoteEncryptionKey
+	}
+	mappingChunks, err := parseMappingChunkAddrs(data)
+	if err != nil {
+		return hash.Hash{}, nil, err
+	}
+	return root, mappingChunks, nil
+}
+
+// loadMapping reads the mapping chunks |mappingChunks| of the remote into the mapping. Only those which haven't been
+// read already are read, unless the mapping chunks were compacted.
+func (ecs *EncryptedChunkStore) loadMapping(ctx context.Context, mappingChunks []hash.Hash) error {
+	loaded := hash.NewHashSet(ecs.mappingChunks...)
+	toRead := make(hash.HashSet)
+	for _, h := range mappingChunks {
+		if !loaded.Has(h) {
+			toRead.Insert(h)
+		}
+	}
+	// A compaction replaces the mapping chunks which have been read.
+	replaced := len(mappingChunks)-len(toRead) != len(loaded)
+	if replaced {
+		toRead = hash.NewHashSet(mappingChunks...)
+	}
+
+	var mu sync.Mutex
+	addrs := make(map[hash.Hash]hash.Hash)
+	read := make(hash.HashSet, len(toRead))
+	var openErr error
+	err := ecs.cs.GetMany(ctx, toRead, func(ctx context.Context, c *chunks.Chunk) {
+		kind, _, data, err := ecs.open(*c)
+		if err == nil && (kind != encryptedChunkKindMapping || len(data)%mappingEntrySize != 0) {
+			err = errCorruptEncryptedChunk
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			openErr = err
+			return
+		}
+		read.Insert(c.Hash())
+		for i := 0; i < len(data); i += mappingEntrySize {
+			addrs[hash.New(data[i:i+hash.ByteLen])] = hash.New(data[i+hash.ByteLen : i+mappingEntrySize])
+		}
+	})
+	if err != nil {
+		return err
+	} else if openErr != nil {
+		return openErr
+	} else if len(read) != len(toRead) {
+		return fmt.Errorf("the remote is missing %d of the chunks which map its addresses", len(toRead)-len(read))
+	}
+
+	ecs.mapMu.Lock()
+	defer ecs.mapMu.Unlock()
+	if replaced {
+		ecs.addrs = addrs
+	} else {
+		for h, s := range addrs {
+			ecs.addrs[h] = s
+		}
+	}
+	ecs.mappingChunks = mappingChunks
+	return nil
+}
+
+// writeMapping writes the mapping chunks of |pending|, along with those of the mapping of the remote if it's due for
+// a compaction, and returns the mapping chunks the next root record references.
+func (ecs *EncryptedChunkStore) writeMapping(ctx context.Context, pending map[hash.Hash]hash.Hash) ([]hash.Hash, error) {
+	if len(pending) == 0 {
+		return ecs.mappingChunks, nil
+	}
+
+	var entries [][mappingEntrySize]byte
+	addEntries := func(m map[hash.Hash]hash.Hash) {
+		for h, s := range m {
+			var e [mappingEntrySize]byte
+			copy(e[:], h[:])
+			copy(e[hash.ByteLen:], s[:])
+			entries = append(entries, e)
+		}
+	}
+	addEntries(pending)
+	perChunk := len(entries)
+	mappingChunks := append([]hash.Hash(nil), ecs.mappingChunks...)
+	if len(mappingChunks) >= maxMappingChunks {
+		ecs.mapMu.RLock()
+		addEntries(ecs.addrs)
+		ecs.mapMu.RUnlock()
+		perChunk = compactedMappingEntries
+		mappingChunks = nil
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i][:], entries[j][:]) < 0
+	})
+
+	for len(entries) > 0 {
+		n := min(perChunk, len(entries))
+		data := make([]byte, 0, n*mappingEntrySize)
+		for _, e := range entries[:n] {
+			data = append(data, e[:]...)
+		}
+		entries = entries[n:]
+
+		h := hash.Of(data)
+		c, err := ecs.seal(ecs.keyedAddr(encryptedChunkKindMapping, h, nil), encryptedChunkKindMapping, h, data)
+		if err != nil {
+			return nil, err
+		}
+		err = ecs.cs.Put(ctx, c, func(chunks.Chunk) chunks.GetAddrsCb {
+			return mappingChunkAddrs
+		})
+		if err != nil {
+			return nil, err
+		}
+		mappingChunks = append(mappingChunks, c.Hash())
+	}
+	return mappingChunks, nil
+}
+
+// loadRoot reads the root record of the remote, if it hasn't been read yet.
+func (ecs *EncryptedChunkStore) loadRoot(ctx context.Context) error {
+	ecs.mu.Lock()
+	loaded := ecs.loaded
+	ecs.mu.Unlock()
+	if loaded {
+		return nil
+	}
+	_, err := ecs.Root(ctx)
+	return err
+}
+
+func (ecs *EncryptedChunkStore) Get(ctx context.Context, h hash.Hash) (chunks.Chunk, error) {
+	if err := ecs.loadRoot(ctx); err != nil {
+		return chunks.EmptyChunk, err
+	}
+	stored, ok := ecs.lookup(h)
+	if !ok {
+		return chunks.EmptyChunk, nil
+	}
+	c, err := ecs.cs.Get(ctx, stored)
+	if err != nil || c.IsEmpty() {
+		return chunks.EmptyChunk, err
+	}
+	return ecs.openChunk(c, h)
+}
+
+func (ecs *EncryptedChunkStore) GetMany(ctx context.Context, hashes hash.HashSet, found func(context.Context, *chunks.Chunk)) error {
+	if err := ecs.loadRoot(ctx); err != nil {
+		return err
+	}
+	stored, addrs := ecs.storedAddrs(hashes)
+	var mu sync.Mutex
+	var openErr error
+	err := ecs.cs.GetMany(ctx, stored, func(ctx context.Context, c *chunks.Chunk) {
+		plain, err := ecs.openChunk(*c, addrs[c.Hash()])
+		if err != nil {
+			mu.Lock()
+			openErr = err
+			mu.Unlock()
+			return
+		}
+		found(ctx, &plain)
+	})
+	if err != nil {
+		return err
+	}
+	return openErr
+}
+
+func (ecs *EncryptedChunkStore) GetManyCompressed(ctx context.Context, hashes hash.HashSet, found func(context.Context, nbs.ToChunker)) error {
+	if err := ecs.loadRoot(ctx); err != nil {
+		return err
+	}
+	stored, addrs := ecs.storedAddrs(hashes)
+	var mu sync.Mutex
+	var openErr error
+	err := ecs.cs.GetManyCompressed(ctx, stored, func(ctx context.Context, tc nbs.ToChunker) {
+		c, err := tc.ToChunk()
+		if err == nil {
+			c, err = ecs.openChunk(c, addrs[tc.Hash()])
+		}
+		if err != nil {
+			mu.Lock()
+			openErr = err
+			mu.Unlock()
+			return
+		}
+		found(ctx, nbs.ChunkToCompressedChunk(c))
+	})
+	if err != nil {
+		return err
+	}
+	return openErr
+}
+
+// Has is answered by the mapping of the remote, which holds the chunks in it along with those written to it.
+func (ecs *EncryptedChunkStore) Has(ctx context.Context, h hash.Hash) (bool, error) {
+	if err := ecs.loadRoot(ctx); err != nil {
+		return false, err
+	}
+	_, ok := ecs.lookup(h)
+	return ok, nil
+}
+
+// HasMany is answered by the mapping of the remote, which holds the chunks in it along with those written to it.
+func (ecs *EncryptedChunkStore) HasMany(ctx context.Context, hashes hash.HashSet) (hash.HashSet, error) {
+	if err := ecs.loadRoot(ctx); err != nil {
+		return nil, err
+	}
+	absent := make(hash.HashSet)
+	for h := range hashes {
+		if _, ok := ecs.lookup(h); !ok {
+			absent.Insert(h)
+		}
+	}
+	return absent, nil
+}
+
+func (ecs *EncryptedChunkStore) Put(ctx context.Context, c chunks.Chunk, getAddrs chunks.GetAddrsCurry) error {
+	stored, err := ecs.seal(ecs.assign(c.Hash()), encryptedChunkKindData, c.Hash(), c.Data())
+	if err != nil {
+		return err
+	}
+	return ecs.cs.Put(ctx, stored, func(chunks.Chunk) chunks.GetAddrsCb {
+		return ecs.storedGetAddrs(getAddrs(c))
+	})
+}
+
+func (ecs *EncryptedChunkStore) Version() string {
+	return ecs.cs.Version()
+}
+
+func (ecs *EncryptedChunkStore) AccessMode() chunks.ExclusiveAccessMode {
+	return ecs.cs.AccessMode()
+}
+
+func (ecs *EncryptedChunkStore) Rebase(ctx context.Context) error {
+	return ecs.cs.Rebase(ctx)
+}
+
+func (ecs *EncryptedChunkStore) Root(ctx context.Context) (hash.Hash, error) {
+	stored, err := ecs.cs.Root(ctx)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+
+	ecs.mu.Lock()
+	defer ecs.mu.Unlock()
+	if ecs.loaded && stored == ecs.storedRoot {
+		return ecs.root, nil
+	}
+
+	var root hash.Hash
+	var mappingChunks []hash.Hash
+	if !stored.IsEmpty() {
+		root, mappingChunks, err = ecs.readRoot(ctx, stored)
+		if err != nil {
+			return hash.Hash{}, err
+		}
+	}
+	if err = ecs.loadMapping(ctx, mappingChunks); err != nil {
+		return hash.Hash{}, err
+	}
+	ecs.root, ecs.storedRoot, ecs.loaded = root, stored, true
+	return root, nil
+}
+
+func (ecs *EncryptedChunkStore) Commit(ctx context.Context, current, last hash.Hash) (bool, error) {
+	if _, err := ecs.Root(ctx); err != nil {
+		return false, err
+	}
+
+	ecs.mu.Lock()
+	defer ecs.mu.Unlock()
+	if last != ecs.root {
+		return false, nil
+	}
+
+	ecs.mapMu.RLock()
+	pending := make(map[hash.Hash]hash.Hash, len(ecs.pending))
+	for h, s := range ecs.pending {
+		pending[h] = s
+	}
+	ecs.mapMu.RUnlock()
+
+	var stored hash.Hash
+	var mappingChunks []hash.Hash
+	if !current.IsEmpty() {
+		var err error
+		mappingChunks, err = ecs.writeMapping(ctx, pending)
+		if err != nil {
+			return false, err
+		}
+		data := make([]byte, 0, len(mappingChunks)*hash.ByteLen)
+		for _, h := range mappingChunks {
+			data = append(data, h[:]...)
+		}
+		rec, err := ecs.seal(ecs.keyedAddr(encryptedChunkKindRoot, current, data), encryptedChunkKindRoot, current, data)
+		if err != nil {
+			return false, err
+		}
+		err = ecs.cs.Put(ctx, rec, func(chunks.Chunk) chunks.GetAddrsCb {
+			return rootRecordAddrs(ecs.refAddr(current), mappingChunks)
+		})
+		if err != nil {
+			return false, err
+		}
+		stored = rec.Hash()
+	}
+
+	ok, err := ecs.cs.Commit(ctx, stored, ecs.storedRoot)
+	if err != nil || !ok {
+		return ok, err
+	}
+
+	ecs.mapMu.Lock()
+	defer ecs.mapMu.Unlock()
+	if current.IsEmpty() {
+		ecs.addrs = make(map[hash.Hash]hash.Hash)
+	}
+	for h, s := range pending {
+		if !current.IsEmpty() {
+			ecs.addrs[h] = s
+		}
+		delete(ecs.pending, h)
+	}
+	ecs.root, ecs.storedRoot, ecs.mappingChunks = current, stored, mappingChunks
+	return true, nil
+}
+
+func (ecs *EncryptedChunkStore) Stats() interface{} {
+	return ecs.cs.Stats()
+}
+
+func (ecs *EncryptedChunkStore) StatsSummary() string {
+	return ecs.cs.StatsSummary()
+}
+
+func (ecs *EncryptedChunkStore) PersistGhostHashes(ctx context.Context, refs hash.HashSet) error {
+	return ecs.cs.PersistGhostHashes(ctx, refs)
+}
+
+func (ecs *EncryptedChunkStore) PushConcurrencyControl() chunks.PushConcurrencyControl {
+	return chunks.GetPushConcurrencyControl(ecs.cs)
+}
+
+func (ecs *EncryptedChunkStore) Close() error {
+	return ecs.cs.Close()
+}
+
+// Sources isn't supported, since the table files of the remote can't be read as they are. Clones of encrypted remotes
+// fall back to pulling their chunks.
+func (ecs *EncryptedChunkStore) Sources(ctx context.Context) (hash.Hash, []chunks.TableFile, []chunks.TableFile, error) {
+	return hash.Hash{}, nil, nil, fmt.Errorf("%w: the remote is encrypted", pull.ErrCloneUnsupported)
+}
+
+func (ecs *EncryptedChunkStore) Size(ctx context.Context) (uint64, error) {
+	return ecs.cs.Size(ctx)
+}
+
+// WriteTableFile writes an encrypted copy of the table file given to the remote. The copy is added to the manifest
+// in place of the table file by AddTableFilesToManifest.
+func (ecs *EncryptedChunkStore) WriteTableFile(ctx context.Context, fileId string, numChunks int, contentHash []byte, getRd func() (io.ReadCloser, uint64, error)) error {
+	f, err := tempfiles.MovableTempFileProvider.NewFile("", "encrypted_remote_src_")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		f.Close()
+		os.Remove(f.Name())
+	}()
+
+	rd, _, err := getRd()
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, rd)
+	rd.Close()
+	if err != nil {
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+
+	wr, err := nbs.NewCmpChunkTableWriter("")
+	if err != nil {
+		return err
+	}
+	defer wr.Remove()
+
+	err = nbs.IterateTableFileChunks(ctx, f.Name(), func(c chunks.Chunk) error {
+		stored, err := ecs.seal(ecs.assign(c.Hash()), encryptedChunkKindData, c.Hash(), c.Data())
+		if err != nil {
+			return err
+		}
+		_, err = wr.AddChunk(nbs.ChunkToCompressedChunk(stored))
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if wr.ChunkCount() != numChunks {
+		return fmt.Errorf("table file %s has %d chunks, expected %d", fileId, wr.ChunkCount(), numChunks)
+	}
+
+	storedId, err := wr.Finish()
+	if err != nil {
+		return err
+	}
+	err = ecs.cs.WriteTableFile(ctx, storedId, numChunks, wr.GetMD5(), func() (io.ReadCloser, uint64, error) {
+		rd, err := wr.Reader()
+		return rd, wr.ContentLength(), err
+	})
+	if err != nil {
+		return err
+	}
+
+	ecs.mu.Lock()
+	defer ecs.mu.Unlock()
+	ecs.tableFiles[fileId] = storedId
+	return nil
+}
+
+func (ecs *EncryptedChunkStore) AddTableFilesToManifest(ctx context.Context, fileIdToNumChunks map[string]int, getAddrs chunks.GetAddrsCurry) error {
+	stored := make(map[string]int, len(fileIdToNumChunks))
+	ecs.mu.Lock()
+	for fileId, numChunks := range fileIdToNumChunks {
+		storedId, ok := ecs.tableFiles[fileId]
+		if !ok {
+			ecs.mu.Unlock()
+			return fmt.Errorf("table file %s wasn't written to the encrypted remote", fileId)
+		}
+		stored[storedId] = numChunks
+	}
+	ecs.mu.Unlock()
+
+	return ecs.cs.AddTableFilesToManifest(ctx, stored, ecs.storedGetAddrsCurry(getAddrs))
+}
+
+func (ecs *EncryptedChunkStore) PruneTableFiles(ctx context.Context) error {
+	return ecs.cs.PruneTableFiles(ctx)
+}
+
+func (ecs *EncryptedChunkStore) SupportedOperations() chunks.TableFileStoreOps {
+	ops := ecs.cs.SupportedOperations()
+	ops.CanGC = false
+	return ops
+}
@@ -0,0 +1,275 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotestorage
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/datas/pull"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func newTestEncryptionKey(t *testing.T) []byte {
+	key := make([]byte, encryptionKeySize)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	return key
+}
+
+// openEncryptedRemote opens the blobstore backed remote in |dir| as an encrypted remote with |key|.
+func openEncryptedRemote(t *testing.T, dir string, key []byte) *EncryptedChunkStore {
+	ctx := context.Background()
+	cs, err := nbs.NewBSStore(ctx, types.Format_Default.VersionString(), blobstore.NewLocalBlobstore(dir), 1<<20, nbs.NewUnlimitedMemQuotaProvider())
+	require.NoError(t, err)
+	ecs, err := NewEncryptedChunkStore(cs, key)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		ecs.Close()
+	})
+	return ecs
+}
+
+// requireNoPlaintext requires that none of the files in |dir| contain any of |plaintexts|.
+func requireNoPlaintext(t *testing.T, dir string, plaintexts ...[]byte) {
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, pt := range plaintexts {
+			require.False(t, bytes.Contains(b, pt), "%s contains plaintext", path)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func noAddrs(chunks.Chunk) chunks.GetAddrsCb {
+	return func(context.Context, hash.HashSet, chunks.PendingRefExists) error {
+		return nil
+	}
+}
+
+func TestParseEncryptionKey(t *testing.T) {
+	key := newTestEncryptionKey(t)
+	parsed, err := ParseEncryptionKey(base64.StdEncoding.EncodeToString(key) + "\n")
+	require.NoError(t, err)
+	assert.Equal(t, key, parsed)
+
+	_, err = ParseEncryptionKey(base64.StdEncoding.EncodeToString(key[:16]))
+	assert.Error(t, err)
+	_, err = ParseEncryptionKey("not base64!")
+	assert.Error(t, err)
+}
+
+func TestEncryptedChunkStore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	key := newTestEncryptionKey(t)
+
+	leaf := chunks.NewChunk([]byte("plaintext-canary-value"))
+	root := chunks.NewChunk([]byte("root-canary-value"))
+
+	ecs := openEncryptedRemote(t, dir, key)
+	require.NoError(t, ecs.Put(ctx, leaf, noAddrs))
+	require.NoError(t, ecs.Put(ctx, root, func(chunks.Chunk) chunks.GetAddrsCb {
+		return func(_ context.Context, addrs hash.HashSet, _ chunks.PendingRefExists) error {
+			addrs.Insert(leaf.Hash())
+			return nil
+		}
+	}))
+	ok, err := ecs.Commit(ctx, root.Hash(), hash.Hash{})
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// chunks which reference missing chunks can't be committed
+	dangling := chunks.NewChunk([]byte("dangling"))
+	require.NoError(t, ecs.Put(ctx, dangling, func(chunks.Chunk) chunks.GetAddrsCb {
+		return func(_ context.Context, addrs hash.HashSet, _ chunks.PendingRefExists) error {
+			addrs.Insert(hash.Of([]byte("missing")))
+			return nil
+		}
+	}))
+	_, err = ecs.Commit(ctx, dangling.Hash(), root.Hash())
+	require.Error(t, err)
+
+	leafAddr, rootAddr := leaf.Hash(), root.Hash()
+	requireNoPlaintext(t, dir, leaf.Data(), root.Data(), leafAddr[:], rootAddr[:])
+
+	t.Run("reopen", func(t *testing.T) {
+		ecs := openEncryptedRemote(t, dir, key)
+		r, err := ecs.Root(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, root.Hash(), r)
+
+		c, err := ecs.Get(ctx, leaf.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, leaf.Data(), c.Data())
+
+		missing := hash.Of([]byte("missing"))
+		absent, err := ecs.HasMany(ctx, hash.NewHashSet(leaf.Hash(), root.Hash(), missing))
+		require.NoError(t, err)
+		assert.Equal(t, hash.NewHashSet(missing), absent)
+
+		var mu sync.Mutex
+		found := make(map[hash.Hash][]byte)
+		err = ecs.GetManyCompressed(ctx, hash.NewHashSet(leaf.Hash(), root.Hash()), func(_ context.Context, tc nbs.ToChunker) {
+			c, err := tc.ToChunk()
+			require.NoError(t, err)
+			mu.Lock()
+			defer mu.Unlock()
+			found[c.Hash()] = c.Data()
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[hash.Hash][]byte{leaf.Hash(): leaf.Data(), root.Hash(): root.Data()}, found)
+
+		_, _, _, err = ecs.Sources(ctx)
+		assert.ErrorIs(t, err, pull.ErrCloneUnsupported)
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		ecs := openEncryptedRemote(t, dir, newTestEncryptionKey(t))
+		_, err := ecs.Root(ctx)
+		assert.ErrorIs(t, err, ErrRemoteEncryptionKey)
+	})
+
+	t.Run("stale commit", func(t *testing.T) {
+		ecs := openEncryptedRemote(t, dir, key)
+		next := chunks.NewChunk([]byte("next"))
+		require.NoError(t, ecs.Put(ctx, next, noAddrs))
+		ok, err := ecs.Commit(ctx, next.Hash(), hash.Of([]byte("stale")))
+		require.NoError(t, err)
+		assert.False(t, ok)
+		ok, err = ecs.Commit(ctx, next.Hash(), root.Hash())
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+}
+
+func TestEncryptedChunkStoreTableFiles(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	key := newTestEncryptionKey(t)
+
+	wr, err := nbs.NewCmpChunkTableWriter("")
+	require.NoError(t, err)
+	defer wr.Remove()
+	var written []chunks.Chunk
+	for i := 0; i < 16; i++ {
+		c := chunks.NewChunk([]byte("table-file-canary-" + string(rune('a'+i))))
+		_, err = wr.AddChunk(nbs.ChunkToCompressedChunk(c))
+		require.NoError(t, err)
+		written = append(written, c)
+	}
+	fileId, err := wr.Finish()
+	require.NoError(t, err)
+
+	ecs := openEncryptedRemote(t, dir, key)
+	err = ecs.WriteTableFile(ctx, fileId, len(written), wr.GetMD5(), func() (io.ReadCloser, uint64, error) {
+		rd, err := wr.Reader()
+		return rd, wr.ContentLength(), err
+	})
+	require.NoError(t, err)
+	require.NoError(t, ecs.AddTableFilesToManifest(ctx, map[string]int{fileId: len(written)}, noAddrs))
+	ok, err := ecs.Commit(ctx, written[0].Hash(), hash.Hash{})
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	var plaintexts [][]byte
+	for _, c := range written {
+		plaintexts = append(plaintexts, c.Data())
+	}
+	requireNoPlaintext(t, dir, plaintexts...)
+	_, err = os.Stat(filepath.Join(dir, fileId))
+	assert.True(t, os.IsNotExist(err))
+
+	ecs = openEncryptedRemote(t, dir, key)
+	for _, c := range written {
+		got, err := ecs.Get(ctx, c.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, c.Data(), got.Data())
+	}
+
+	// table files must be written before they're added
+	err = ecs.AddTableFilesToManifest(ctx, map[string]int{hash.Of([]byte("unwritten")).String(): 1}, noAddrs)
+	assert.Error(t, err)
+}
+
+func TestEncryptedChunkStoreMapping(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	key := newTestEncryptionKey(t)
+
+	ecs := openEncryptedRemote(t, dir, key)
+	var written []chunks.Chunk
+	last := hash.Hash{}
+	for i := 0; i < maxMappingChunks+2; i++ {
+		c := chunks.NewChunk([]byte("mapped-" + strconv.Itoa(i)))
+		require.NoError(t, ecs.Put(ctx, c, noAddrs))
+		ok, err := ecs.Commit(ctx, c.Hash(), last)
+		require.NoError(t, err)
+		require.True(t, ok)
+		written, last = append(written, c), c.Hash()
+	}
+	// the mapping chunks were compacted once there were too many of them
+	assert.Less(t, len(ecs.mappingChunks), maxMappingChunks)
+
+	// the mapping is read back from the remote, and chunks are looked up through it
+	reopened := openEncryptedRemote(t, dir, key)
+	r, err := reopened.Root(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, last, r)
+	assert.Equal(t, ecs.addrs, reopened.addrs)
+	for _, c := range written {
+		got, err := reopened.Get(ctx, c.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, c.Data(), got.Data())
+	}
+
+	// a chunk which is in the remote, but not in its mapping, isn't found
+	unmapped := chunks.NewChunk([]byte("unmapped"))
+	stored, err := reopened.seal(reopened.keyedAddr(encryptedChunkKindData, unmapped.Hash(), nil), encryptedChunkKindData, unmapped.Hash(), unmapped.Data())
+	require.NoError(t, err)
+	require.NoError(t, reopened.cs.Put(ctx, stored, noAddrs))
+	has, err := reopened.Has(ctx, unmapped.Hash())
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	// the remote can tell that it's encrypted
+	rawRoot, err := reopened.cs.Root(ctx)
+	require.NoError(t, err)
+	rec, err := reopened.cs.Get(ctx, rawRoot)
+	require.NoError(t, err)
+	assert.True(t, IsEncryptedChunk(rec.Data()))
+}
@@ -57,6 +57,7 @@ const (
 	DefaultLogFormat               = LogFormat_Text
 	DefaultAutoCommit              = true
 	DefaultAutoGCBehaviorEnable    = false
+	DefaultAutoGCArchiveLevel      = AutoGCArchiveLevelOff
 	DefaultDoltTransactionCommit   = false
 	DefaultMaxConnections          = 100
 	DefaultDataDir                 = "."
@@ -288,6 +289,9 @@ func ValidateConfig(config ServerConfig) error {
 	if config.RequireSecureTransport() && config.TLSCert() == "" && config.TLSKey() == "" {
 		return fmt.Errorf("require_secure_transport can only be `true` when a tls_key and tls_cert are provided.")
 	}
+	if gc := config.AutoGCBehavior(); gc != nil && gc.ArchiveLevel() != AutoGCArchiveLevelOff && gc.ArchiveLevel() != AutoGCArchiveLevelOldGen {
+		return fmt.Errorf("auto_gc_behavior.archive_level is invalid: %v\n", gc.ArchiveLevel())
+	}
 	return ValidateClusterConfig(config.ClusterConfig())
 }
 
@@ -470,4 +474,12 @@ func CheckForUnixSocket(config ServerConfig) (string, bool, error) {
 
 type AutoGCBehavior interface {
 	Enable() bool
+	// ArchiveLevel is AutoGCArchiveLevelOff, or AutoGCArchiveLevelOldGen to convert the old generation of a database
+	// to archives after each automatic GC.
+	ArchiveLevel() int
 }
+
+const (
+	AutoGCArchiveLevelOff    = 0
+	AutoGCArchiveLevelOldGen = 1
+)
@@ -953,7 +953,8 @@ func (cfg YAMLConfig) ValueSet(value string) bool {
 }
 
 type AutoGCBehaviorYAMLConfig struct {
-	Enable_ *bool `yaml:"enable,omitempty" minver:"1.50.0"`
+	Enable_       *bool `yaml:"enable,omitempty" minver:"1.50.0"`
+	ArchiveLevel_ *int  `yaml:"archive_level,omitempty" minver:"TBD"`
 }
 
 func (a *AutoGCBehaviorYAMLConfig) Enable() bool {
@@ -963,8 +964,16 @@ func (a *AutoGCBehaviorYAMLConfig) Enable() bool {
 	return *a.Enable_
 }
 
+func (a *AutoGCBehaviorYAMLConfig) ArchiveLevel() int {
+	if a.ArchiveLevel_ == nil {
+		return DefaultAutoGCArchiveLevel
+	}
+	return *a.ArchiveLevel_
+}
+
 func toAutoGCBehaviorYAML(a AutoGCBehavior) *AutoGCBehaviorYAMLConfig {
 	return &AutoGCBehaviorYAMLConfig{
-		Enable_: ptr(a.Enable()),
+		Enable_:       ptr(a.Enable()),
+		ArchiveLevel_: nillableIntPtr(a.ArchiveLevel()),
 	}
 }
@@ -26,10 +26,13 @@ import (
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dprocedures"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -44,10 +47,16 @@ import (
 // it is time to perform a GC for that particular database. If it is,
 // they forward a request the background thread to register the
 // database as wanting a GC.
+//
+// If |archiveOldGen| is set, the background thread also converts the
+// old generation of a database to archives after each GC of it, so
+// that it is compressed without the downtime of running `dolt
+// archive`.
 
 type AutoGCController struct {
-	workCh chan autoGCWork
-	lgr    *logrus.Logger
+	workCh        chan autoGCWork
+	lgr           *logrus.Logger
+	archiveOldGen bool
 
 	mu      sync.Mutex
 	hooks   map[string]*autoGCCommitHook
@@ -55,11 +64,12 @@ type AutoGCController struct {
 	threads *sql.BackgroundThreads
 }
 
-func NewAutoGCController(lgr *logrus.Logger) *AutoGCController {
+func NewAutoGCController(archiveOldGen bool, lgr *logrus.Logger) *AutoGCController {
 	return &AutoGCController{
-		workCh: make(chan autoGCWork),
-		lgr:    lgr,
-		hooks:  make(map[string]*autoGCCommitHook),
+		workCh:        make(chan autoGCWork),
+		lgr:           lgr,
+		archiveOldGen: archiveOldGen,
+		hooks:         make(map[string]*autoGCCommitHook),
 	}
 }
 
@@ -142,10 +152,23 @@ func (c *AutoGCController) gcBgThread(ctx context.Context) {
 
 func (c *AutoGCController) doWork(ctx context.Context, work autoGCWork, ctxF func(context.Context) (*sql.Context, error)) {
 	defer close(work.done)
+	if !c.gc(ctx, work, ctxF) {
+		return
+	}
+	// The archive is built outside of the GC's session. A dolt_gc
+	// run concurrently waits for every session to quiesce, and
+	// swapping in the archives waits for that GC to complete.
+	if c.archiveOldGen {
+		c.archive(ctx, work)
+	}
+}
+
+// gc runs an auto GC of |work.db|. Returns false if the GC failed.
+func (c *AutoGCController) gc(ctx context.Context, work autoGCWork, ctxF func(context.Context) (*sql.Context, error)) bool {
 	sqlCtx, err := ctxF(ctx)
 	if err != nil {
 		c.lgr.Warnf("sqle/auto_gc: Could not create session to GC %s: %v", work.name, err)
-		return
+		return false
 	}
 	c.lgr.Tracef("sqle/auto_gc: Beginning auto GC of database %s", work.name)
 	start := time.Now()
@@ -156,10 +179,48 @@ func (c *AutoGCController) doWork(ctx context.Context, work autoGCWork, ctxF fun
 	if err != nil {
 		if !errors.Is(err, chunks.ErrNothingToCollect) {
 			c.lgr.Warnf("sqle/auto_gc: Attempt to auto GC database %s failed with error: %v", work.name, err)
+			return false
+		}
+	} else {
+		c.lgr.Infof("sqle/auto_gc: Successfully completed auto GC of database %s in %v", work.name, time.Since(start))
+	}
+	return true
+}
+
+// archive converts the table files which GC moved into the old
+// generation of |work.db| to archives, with a dictionary trained for
+// each table.
+func (c *AutoGCController) archive(ctx context.Context, work autoGCWork) {
+	cs := datas.ChunkStoreFromDatabase(doltdb.HackDatasDatabaseFromDoltDB(work.db))
+	c.lgr.Tracef("sqle/auto_gc: Beginning archive of database %s", work.name)
+	start := time.Now()
+
+	progress := make(chan interface{}, 32)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for msg := range progress {
+			if s, ok := msg.(string); ok {
+				c.lgr.Debugf("sqle/auto_gc: %s", s)
+			}
 		}
+	}()
+	// The chunks are only related if there are table files to
+	// archive, and only those of the table files are walked.
+	relate := func(ctx context.Context, include func(hash.Hash) (bool, error)) (nbs.ChunkRelations, error) {
+		return actions.TableChunkRelations(ctx, work.db, include)
+	}
+	n, err := nbs.ArchiveOldGenTableFiles(ctx, cs, relate, progress)
+	close(progress)
+	wg.Wait()
+	if err != nil {
+		c.lgr.Warnf("sqle/auto_gc: Attempt to archive database %s failed with error: %v", work.name, err)
+		return
+	} else if n == 0 {
 		return
 	}
-	c.lgr.Infof("sqle/auto_gc: Successfully completed auto GC of database %s in %v", work.name, time.Since(start))
+	c.lgr.Infof("sqle/auto_gc: Successfully archived %d table files of database %s in %v", n, work.name, time.Since(start))
 }
 
 func (c *AutoGCController) newCommitHook(name string, db *doltdb.DoltDB) *autoGCCommitHook {
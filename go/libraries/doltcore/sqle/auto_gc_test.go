@@ -39,12 +39,12 @@ func TestAutoGCController(t *testing.T) {
 	}
 	t.Run("Hook", func(t *testing.T) {
 		t.Run("NeverStarted", func(t *testing.T) {
-			controller := NewAutoGCController(NewLogger())
+			controller := NewAutoGCController(false, NewLogger())
 			hook := controller.newCommitHook("some_database", nil)
 			hook.stop()
 		})
 		t.Run("StartedBeforeNewHook", func(t *testing.T) {
-			controller := NewAutoGCController(NewLogger())
+			controller := NewAutoGCController(false, NewLogger())
 			bg := sql.NewBackgroundThreads()
 			defer bg.Shutdown()
 			err := controller.RunBackgroundThread(bg, CtxFactory)
@@ -56,7 +56,7 @@ func TestAutoGCController(t *testing.T) {
 			hook.stop()
 		})
 		t.Run("StartedAfterNewHook", func(t *testing.T) {
-			controller := NewAutoGCController(NewLogger())
+			controller := NewAutoGCController(false, NewLogger())
 			bg := sql.NewBackgroundThreads()
 			defer bg.Shutdown()
 			ctx := context.Background()
@@ -68,7 +68,7 @@ func TestAutoGCController(t *testing.T) {
 			hook.stop()
 		})
 		t.Run("ExecuteOnCanceledCtx", func(t *testing.T) {
-			controller := NewAutoGCController(NewLogger())
+			controller := NewAutoGCController(false, NewLogger())
 			ctx, cancel := context.WithCancel(context.Background())
 			cancel()
 			dEnv := CreateTestEnvWithName("some_database")
@@ -78,7 +78,7 @@ func TestAutoGCController(t *testing.T) {
 		})
 	})
 	t.Run("gcBgThread", func(t *testing.T) {
-		controller := NewAutoGCController(NewLogger())
+		controller := NewAutoGCController(false, NewLogger())
 		ctx, cancel := context.WithCancel(context.Background())
 		var wg sync.WaitGroup
 		wg.Add(1)
@@ -92,7 +92,7 @@ func TestAutoGCController(t *testing.T) {
 	})
 	t.Run("DatabaseProviderHooks", func(t *testing.T) {
 		t.Run("Unstarted", func(t *testing.T) {
-			controller := NewAutoGCController(NewLogger())
+			controller := NewAutoGCController(false, NewLogger())
 			ctx, err := CtxFactory(context.Background())
 			require.NoError(t, err)
 			dEnv := CreateTestEnvWithName("some_database")
@@ -101,7 +101,7 @@ func TestAutoGCController(t *testing.T) {
 			controller.DropDatabaseHook()(nil, "some_database")
 		})
 		t.Run("Started", func(t *testing.T) {
-			controller := NewAutoGCController(NewLogger())
+			controller := NewAutoGCController(false, NewLogger())
 			bg := sql.NewBackgroundThreads()
 			defer bg.Shutdown()
 			err := controller.RunBackgroundThread(bg, CtxFactory)
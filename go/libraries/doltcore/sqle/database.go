@@ -654,6 +654,39 @@ func (db Database) getTableInsensitive(ctx *sql.Context, head *doltdb.Commit, ds
 			versionableTable := backingTable.(dtables.VersionableTable)
 			dt, found = dtables.NewIgnoreTable(ctx, versionableTable, db.schemaName), true
 		}
+	case doltdb.MergeStrategiesTableName:
+		backingTable, _, err := db.getTable(ctx, root, doltdb.MergeStrategiesTableName)
+		if err != nil {
+			return nil, false, err
+		}
+		if backingTable == nil {
+			dt, found = dtables.NewEmptyMergeStrategiesTable(ctx), true
+		} else {
+			versionableTable := backingTable.(dtables.VersionableTable)
+			dt, found = dtables.NewMergeStrategiesTable(ctx, versionableTable), true
+		}
+	case doltdb.ConflictResolversTableName:
+		backingTable, _, err := db.getTable(ctx, root, doltdb.ConflictResolversTableName)
+		if err != nil {
+			return nil, false, err
+		}
+		if backingTable == nil {
+			dt, found = dtables.NewEmptyConflictResolversTable(ctx), true
+		} else {
+			versionableTable := backingTable.(dtables.VersionableTable)
+			dt, found = dtables.NewConflictResolversTable(ctx, versionableTable), true
+		}
+	case doltdb.ExternalTablesTableName:
+		backingTable, _, err := db.getTable(ctx, root, doltdb.ExternalTablesTableName)
+		if err != nil {
+			return nil, false, err
+		}
+		if backingTable == nil {
+			dt, found = dtables.NewEmptyExternalTablesTable(ctx, db.RevisionQualifiedName()), true
+		} else {
+			versionableTable := backingTable.(dtables.VersionableTable)
+			dt, found = dtables.NewExternalTablesTable(ctx, db.RevisionQualifiedName(), versionableTable), true
+		}
 	case doltdb.GetDocTableName(), doltdb.DocTableName:
 		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
 		if err != nil {
@@ -695,6 +728,8 @@ func (db Database) getTableInsensitive(ctx *sql.Context, head *doltdb.Commit, ds
 			return nil, false, err
 		}
 		dt, found = dtables.NewStatisticsTable(ctx, db.Name(), db.schemaName, branch, tables), true
+	case doltdb.WorkflowRunsTableName:
+		dt, found = dtables.NewWorkflowRunsTable(ctx, db.ddb, db.Name(), lwrName), true
 	case doltdb.ProceduresTableName:
 		found = true
 		backingTable, _, err := db.getTable(ctx, root, doltdb.ProceduresTableName)
@@ -751,11 +786,52 @@ func (db Database) getTableInsensitive(ctx *sql.Context, head *doltdb.Commit, ds
 		return table, found, err
 	}
 
+	// Tables defined in dolt_external_tables are read from their files
+	def, found, err := db.getExternalTableDef(ctx, root, tblName)
+	if err != nil {
+		return nil, false, err
+	}
+	if found {
+		externalTable, err := dtables.NewExternalTable(ctx, def)
+		if err != nil {
+			return nil, false, err
+		}
+		return externalTable, true, nil
+	}
+
 	// If the table wasn't found in the specified data root, check if there is an overridden
 	// schema commit that contains it and return an empty table if so.
 	return resolveOverriddenNonexistentTable(ctx, tblName, db)
 }
 
+// getExternalTableDef returns the definition of the external table named |tblName| in |root|, matched
+// case-insensitively. The definitions are cached by the hash of the dolt_external_tables table, so that it isn't read
+// again for every table name which doesn't resolve to a table.
+func (db Database) getExternalTableDef(ctx *sql.Context, root doltdb.RootValue, tblName string) (doltdb.ExternalTableDef, bool, error) {
+	extTblHash, ok, err := root.GetTableHash(ctx, doltdb.TableName{Name: doltdb.ExternalTablesTableName})
+	if err != nil || !ok {
+		return doltdb.ExternalTableDef{}, false, err
+	}
+	key := doltdb.DataCacheKey{Hash: extTblHash}
+
+	ds := dsess.DSessFromSess(ctx.Session)
+	dbState, _, err := ds.LookupDbState(ctx, db.RevisionQualifiedName())
+	if err != nil {
+		return doltdb.ExternalTableDef{}, false, err
+	}
+
+	defs, ok := dbState.SessionCache().GetCachedExternalTables(key)
+	if !ok {
+		defs, err = doltdb.GetExternalTableDefs(ctx, root)
+		if err != nil {
+			return doltdb.ExternalTableDef{}, false, err
+		}
+		dbState.SessionCache().CacheExternalTables(key, defs)
+	}
+	def, ok := defs[strings.ToLower(tblName)]
+	return def, ok, nil
+}
+
 // workingSetStagedRoot returns the staged root for the current session in the database
 // named |dbName|. If a working set is not available (e.g. if a commit or tag is checked
 // out), this function returns an ErrOperationNotSupportedInDetachedHead error.
@@ -226,7 +226,9 @@ func performMerge(
 	}
 
 	if canFF {
-		if spec.NoFF {
+		// Like --no-ff, the ours strategy always records a merge commit. Fast-forwarding would take all of their
+		// changes.
+		if spec.NoFF || spec.Strategies.KeepsOurs() {
 			var commit *doltdb.Commit
 			ws, commit, err = executeNoFFMerge(ctx, sess, spec, msg, dbName, ws, noCommit)
 			if err == doltdb.ErrUnresolvedConflictsOrViolations {
@@ -271,7 +273,7 @@ func performMerge(
 		return ws, "", noConflictsOrViolations, threeWayMerge, "", sql.ErrDatabaseNotFound.New(dbName)
 	}
 
-	ws, err = executeMerge(ctx, sess, dbName, spec.Squash, spec.Force, spec.HeadC, spec.MergeC, spec.MergeCSpecStr, ws, dbState.EditOpts(), spec.WorkingDiffs)
+	ws, err = executeMerge(ctx, sess, dbName, spec.Squash, spec.Force, spec.HeadC, spec.MergeC, spec.MergeCSpecStr, ws, dbState.EditOpts(), spec.WorkingDiffs, spec.Strategies)
 	if err == doltdb.ErrUnresolvedConflictsOrViolations {
 		// if there are unresolved conflicts, write the resulting working set back to the session and return an
 		// error message
@@ -318,8 +320,9 @@ func executeMerge(
 	ws *doltdb.WorkingSet,
 	opts editor.Options,
 	workingDiffs map[doltdb.TableName]hash.Hash,
+	strategies merge.Strategies,
 ) (*doltdb.WorkingSet, error) {
-	result, err := merge.MergeCommits(ctx, head, cm, opts)
+	result, err := merge.MergeCommits(ctx, head, cm, opts, strategies)
 	if err != nil {
 		switch err {
 		case doltdb.ErrUpToDate:
@@ -392,7 +395,12 @@ func executeNoFFMerge(
 	ws *doltdb.WorkingSet,
 	noCommit bool,
 ) (*doltdb.WorkingSet, *doltdb.Commit, error) {
-	mergeRoot, err := spec.MergeC.GetRootValue(ctx)
+	mergeC := spec.MergeC
+	if spec.Strategies.KeepsOurs() {
+		// the ours strategy keeps our root, but still records the merge
+		mergeC = spec.HeadC
+	}
+	mergeRoot, err := mergeC.GetRootValue(ctx)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -478,6 +486,12 @@ func createMergeSpec(ctx *sql.Context, sess *dsess.DoltSession, dbName string, a
 	if apr.Contains(cli.NoCommitFlag) && apr.Contains(cli.CommitFlag) {
 		return nil, errors.New("cannot define both 'commit' and 'no-commit' flags at the same time")
 	}
+
+	strategies, err := merge.NewStrategies(ctx, roots.Working, apr.GetValueOrDefault(cli.StrategyParam, ""), apr.GetValueOrDefault(cli.StrategyOptionParam, ""))
+	if err != nil {
+		return nil, err
+	}
+
 	return merge.NewMergeSpec(
 		ctx,
 		dbData.Rsr,
@@ -492,6 +506,7 @@ func createMergeSpec(ctx *sql.Context, sess *dsess.DoltSession, dbName string, a
 		merge.WithForce(apr.Contains(cli.ForceFlag)),
 		merge.WithNoCommit(apr.Contains(cli.NoCommitFlag)),
 		merge.WithNoEdit(apr.Contains(cli.NoEditFlag)),
+		merge.WithStrategies(strategies),
 	)
 }
 
@@ -43,6 +43,8 @@ type SessionCache struct {
 	strictLookups map[doltdb.DataCacheKey][]index.LookupMeta
 	// checks is keyed by table schema hash
 	checks map[doltdb.DataCacheKey][]sql.CheckDefinition
+	// externalTables is keyed by the hash of the dolt_external_tables table
+	externalTables map[doltdb.DataCacheKey]map[string]doltdb.ExternalTableDef
 
 	mu sync.RWMutex
 }
@@ -261,6 +263,32 @@ func (c *SessionCache) CacheTableChecks(key doltdb.DataCacheKey, checks []sql.Ch
 	c.checks[key] = checks
 }
 
+// GetCachedExternalTables returns the cached definitions of the external tables, keyed by their lower-cased names, and
+// whether the cache was present
+func (c *SessionCache) GetCachedExternalTables(key doltdb.DataCacheKey) (map[string]doltdb.ExternalTableDef, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	defs, ok := c.externalTables[key]
+	return defs, ok
+}
+
+// CacheExternalTables caches the definitions of the external tables for the cache key given
+func (c *SessionCache) CacheExternalTables(key doltdb.DataCacheKey, defs map[string]doltdb.ExternalTableDef) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.externalTables == nil {
+		c.externalTables = make(map[doltdb.DataCacheKey]map[string]doltdb.ExternalTableDef)
+	}
+	if len(c.externalTables) > maxCachedKeys {
+		for k := range c.externalTables {
+			delete(c.externalTables, k)
+		}
+	}
+
+	c.externalTables[key] = defs
+}
+
 func (c *SessionCache) GetCachedTableMap(key uint64) (map[string]string, bool) {
 	tables, ok := c.tableMaps[key]
 	return tables, ok
@@ -0,0 +1,206 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtablefunctions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+)
+
+const (
+	readFileTableFunctionName = "dolt_read_file"
+	readCsvTableFunctionName  = "dolt_read_csv"
+)
+
+var _ sql.TableFunction = (*ReadFileTableFunction)(nil)
+var _ sql.ExecSourceRel = (*ReadFileTableFunction)(nil)
+var _ sql.AuthorizationCheckerNode = (*ReadFileTableFunction)(nil)
+
+// ReadFileTableFunction implements the dolt_read_file and dolt_read_csv table functions, which return the rows of a
+// csv, psv or parquet file as a read-only table. dolt_read_file takes the path of the file and, optionally, its
+// format, which is otherwise given by the file's extension. dolt_read_csv takes the path of a delimited file and,
+// optionally, its delimiter.
+type ReadFileTableFunction struct {
+	ctx      *sql.Context
+	database sql.Database
+	name     string
+	argExprs []sql.Expression
+	table    *dtables.ExternalTable
+}
+
+// NewReadFileTableFunction returns the dolt_read_file table function.
+func NewReadFileTableFunction() *ReadFileTableFunction {
+	return &ReadFileTableFunction{name: readFileTableFunctionName}
+}
+
+// NewReadCsvTableFunction returns the dolt_read_csv table function.
+func NewReadCsvTableFunction() *ReadFileTableFunction {
+	return &ReadFileTableFunction{name: readCsvTableFunctionName}
+}
+
+// NewInstance creates a new instance of TableFunction interface
+func (rftf *ReadFileTableFunction) NewInstance(ctx *sql.Context, database sql.Database, expressions []sql.Expression) (sql.Node, error) {
+	newInstance := &ReadFileTableFunction{
+		ctx:      ctx,
+		database: database,
+		name:     rftf.name,
+	}
+
+	node, err := newInstance.WithExpressions(expressions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// Name implements the sql.TableFunction interface
+func (rftf *ReadFileTableFunction) Name() string {
+	return rftf.name
+}
+
+// Database implements the sql.Databaser interface
+func (rftf *ReadFileTableFunction) Database() sql.Database {
+	return rftf.database
+}
+
+// WithDatabase implements the sql.Databaser interface
+func (rftf *ReadFileTableFunction) WithDatabase(database sql.Database) (sql.Node, error) {
+	nrftf := *rftf
+	nrftf.database = database
+	return &nrftf, nil
+}
+
+// Expressions implements the sql.Expressioner interface
+func (rftf *ReadFileTableFunction) Expressions() []sql.Expression {
+	return rftf.argExprs
+}
+
+// WithExpressions implements the sql.Expressioner interface. The file is read when the arguments are given to
+// resolve the schema of the function.
+func (rftf *ReadFileTableFunction) WithExpressions(expressions ...sql.Expression) (sql.Node, error) {
+	if len(expressions) < 1 || len(expressions) > 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New(rftf.Name(), "1 or 2", len(expressions))
+	}
+
+	// The schema of the function is the schema of the file, so only literal arguments are supported
+	for _, expr := range expressions {
+		if !expr.Resolved() {
+			return nil, ErrInvalidNonLiteralArgument.New(rftf.Name(), expr.String())
+		}
+		// prepared statements resolve functions beforehand, so above check fails
+		if _, ok := expr.(sql.FunctionExpression); ok {
+			return nil, ErrInvalidNonLiteralArgument.New(rftf.Name(), expr.String())
+		}
+	}
+
+	args := make([]string, len(expressions))
+	for i, expr := range expressions {
+		if !gmstypes.IsText(expr.Type()) {
+			return nil, sql.ErrInvalidArgumentDetails.New(rftf.Name(), expr.String())
+		}
+		val, err := expr.Eval(rftf.ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		arg, ok := val.(string)
+		if !ok {
+			return nil, sql.ErrInvalidArgumentDetails.New(rftf.Name(), expr.String())
+		}
+		args[i] = arg
+	}
+
+	def := doltdb.ExternalTableDef{Name: rftf.name, Path: args[0]}
+	if rftf.name == readCsvTableFunctionName {
+		def.Format = dtables.ExternalFormatCsv
+		if len(args) > 1 {
+			def.Delimiter = args[1]
+		}
+	} else if len(args) > 1 {
+		def.Format = args[1]
+	}
+
+	table, err := dtables.NewExternalTable(rftf.ctx, def)
+	if err != nil {
+		return nil, err
+	}
+
+	nrftf := *rftf
+	nrftf.argExprs = expressions
+	nrftf.table = table
+	return &nrftf, nil
+}
+
+// Children implements the sql.Node interface
+func (rftf *ReadFileTableFunction) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface
+func (rftf *ReadFileTableFunction) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, fmt.Errorf("unexpected children")
+	}
+	return rftf, nil
+}
+
+// CheckAuth implements the sql.AuthorizationCheckerNode interface. Like LOAD DATA, reading a file on the server
+// requires the FILE privilege.
+func (rftf *ReadFileTableFunction) CheckAuth(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	return opChecker.UserHasPrivileges(ctx, sql.NewPrivilegedOperation(sql.PrivilegeCheckSubject{}, sql.PrivilegeType_File))
+}
+
+// Schema implements the sql.Node interface
+func (rftf *ReadFileTableFunction) Schema() sql.Schema {
+	if rftf.table == nil {
+		return nil
+	}
+	return rftf.table.Schema()
+}
+
+// Resolved implements the sql.Resolvable interface
+func (rftf *ReadFileTableFunction) Resolved() bool {
+	for _, expr := range rftf.argExprs {
+		if !expr.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// IsReadOnly implements the sql.Node interface
+func (rftf *ReadFileTableFunction) IsReadOnly() bool {
+	return true
+}
+
+// String implements the Stringer interface
+func (rftf *ReadFileTableFunction) String() string {
+	var args []string
+	for _, expr := range rftf.argExprs {
+		args = append(args, expr.String())
+	}
+	return fmt.Sprintf("%s(%s)", strings.ToUpper(rftf.name), strings.Join(args, ", "))
+}
+
+// RowIter implements the sql.Node interface
+func (rftf *ReadFileTableFunction) RowIter(ctx *sql.Context, _ sql.Row) (sql.RowIter, error) {
+	return rftf.table.RowIter(ctx)
+}
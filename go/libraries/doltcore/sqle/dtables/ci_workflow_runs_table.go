@@ -0,0 +1,109 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+var _ sql.Table = (*WorkflowRunsTable)(nil)
+
+// WorkflowRunsTable is a sql.Table implementation that implements a system table which shows the dolt ci workflow
+// runs performed by sql-servers for a database. Runs are not stored on any branch, so the table has the same rows
+// on every branch and at every revision.
+type WorkflowRunsTable struct {
+	ddb       *doltdb.DoltDB
+	dbName    string
+	tableName string
+}
+
+// NewWorkflowRunsTable creates a WorkflowRunsTable
+func NewWorkflowRunsTable(_ *sql.Context, ddb *doltdb.DoltDB, dbName, tableName string) sql.Table {
+	return &WorkflowRunsTable{ddb: ddb, dbName: dbName, tableName: tableName}
+}
+
+// Name is a sql.Table interface function which returns the name of the table.
+func (wrt *WorkflowRunsTable) Name() string {
+	return wrt.tableName
+}
+
+// String is a sql.Table interface function which returns the name of the table.
+func (wrt *WorkflowRunsTable) String() string {
+	return wrt.tableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the workflow runs system table.
+func (wrt *WorkflowRunsTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: doltdb.WorkflowRunsIdPkColName, Type: types.Text, Source: wrt.tableName, PrimaryKey: true, DatabaseSource: wrt.dbName},
+		{Name: doltdb.WorkflowRunsRunIdColName, Type: types.Text, Source: wrt.tableName, PrimaryKey: false, DatabaseSource: wrt.dbName},
+		{Name: doltdb.WorkflowRunsWorkflowNameColName, Type: types.Text, Source: wrt.tableName, PrimaryKey: false, DatabaseSource: wrt.dbName},
+		{Name: doltdb.WorkflowRunsEventTypeColName, Type: types.Text, Source: wrt.tableName, PrimaryKey: false, DatabaseSource: wrt.dbName},
+		{Name: doltdb.WorkflowRunsBranchColName, Type: types.Text, Source: wrt.tableName, PrimaryKey: false, DatabaseSource: wrt.dbName},
+		{Name: doltdb.WorkflowRunsCommitHashColName, Type: types.Text, Source: wrt.tableName, PrimaryKey: false, DatabaseSource: wrt.dbName},
+		{Name: doltdb.WorkflowRunsJobNameColName, Type: types.Text, Source: wrt.tableName, PrimaryKey: false, DatabaseSource: wrt.dbName},
+		{Name: doltdb.WorkflowRunsStepNameColName, Type: types.Text, Source: wrt.tableName, PrimaryKey: false, DatabaseSource: wrt.dbName},
+		{Name: doltdb.WorkflowRunsStepOrderColName, Type: types.Int32, Source: wrt.tableName, PrimaryKey: false, DatabaseSource: wrt.dbName},
+		{Name: doltdb.WorkflowRunsStatusColName, Type: types.Text, Source: wrt.tableName, PrimaryKey: false, DatabaseSource: wrt.dbName},
+		{Name: doltdb.WorkflowRunsStartedAtColName, Type: types.DatetimeMaxPrecision, Source: wrt.tableName, PrimaryKey: false, DatabaseSource: wrt.dbName},
+		{Name: doltdb.WorkflowRunsEndedAtColName, Type: types.DatetimeMaxPrecision, Source: wrt.tableName, PrimaryKey: false, DatabaseSource: wrt.dbName},
+		{Name: doltdb.WorkflowRunsFailureDetailsColName, Type: types.LongText, Source: wrt.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: wrt.dbName},
+	}
+}
+
+// Collation implements the sql.Table interface.
+func (wrt *WorkflowRunsTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data. Currently the data is unpartitioned.
+func (wrt *WorkflowRunsTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition.
+func (wrt *WorkflowRunsTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	steps, err := wrt.ddb.GetWorkflowRunSteps(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]sql.Row, len(steps))
+	for i, s := range steps {
+		var failureDetails interface{}
+		if s.FailureDetails != "" {
+			failureDetails = s.FailureDetails
+		}
+		rows[i] = sql.NewRow(
+			s.Id,
+			s.RunId,
+			s.WorkflowName,
+			s.EventType,
+			s.Branch,
+			s.CommitHash,
+			s.JobName,
+			s.StepName,
+			int32(s.StepOrder),
+			s.Status,
+			s.StartedAt,
+			s.EndedAt,
+			failureDetails,
+		)
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
@@ -0,0 +1,261 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	sqlTypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/parquet"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/untyped/csv"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const (
+	// ExternalFormatCsv is the format of comma separated files read by external tables
+	ExternalFormatCsv = "csv"
+	// ExternalFormatPsv is the format of pipe separated files read by external tables
+	ExternalFormatPsv = "psv"
+	// ExternalFormatParquet is the format of parquet files read by external tables
+	ExternalFormatParquet = "parquet"
+)
+
+var _ sql.Table = (*ExternalTable)(nil)
+
+// ExternalTable is a read-only table whose rows are read from a file each time it's scanned. The columns of a csv or
+// psv file are read as text, and the columns of a parquet file are typed after their parquet type.
+type ExternalTable struct {
+	name   string
+	path   string
+	format string
+	delim  string
+	sch    schema.Schema
+	sqlSch sql.Schema
+}
+
+// NewExternalTable returns the ExternalTable defined by |def|, reading the schema of the table from its file. Like LOAD
+// DATA, this requires the FILE privilege, and the file must be in the directory given by the secure_file_priv system
+// variable. Files aren't read at all if secure_file_priv isn't set.
+func NewExternalTable(ctx *sql.Context, def doltdb.ExternalTableDef) (*ExternalTable, error) {
+	format := strings.ToLower(def.Format)
+	if format == "" {
+		format = strings.ToLower(strings.TrimPrefix(filepath.Ext(def.Path), "."))
+	}
+
+	delim := def.Delimiter
+	switch format {
+	case ExternalFormatCsv:
+		if delim == "" {
+			delim = ","
+		}
+	case ExternalFormatPsv:
+		if delim == "" {
+			delim = "|"
+		}
+	case ExternalFormatParquet:
+		if delim != "" {
+			return nil, fmt.Errorf("external table %s: a delimiter can't be given for a %s file", def.Name, format)
+		}
+	default:
+		return nil, fmt.Errorf("external table %s: unsupported file format '%s', expected one of: %s, %s, %s",
+			def.Name, format, ExternalFormatCsv, ExternalFormatPsv, ExternalFormatParquet)
+	}
+
+	if err := checkFilePrivilege(ctx); err != nil {
+		return nil, fmt.Errorf("external table %s: %w", def.Name, err)
+	}
+	if err := checkSecureFilePath(ctx, def.Path); err != nil {
+		return nil, fmt.Errorf("external table %s: %w", def.Name, err)
+	}
+
+	et := &ExternalTable{name: def.Name, path: def.Path, format: format, delim: delim}
+	var err error
+	if format == ExternalFormatParquet {
+		et.sch, err = parquet.SchemaFromFile(def.Path)
+	} else {
+		et.sch, err = et.csvSchema(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("external table %s: failed to read %s: %w", def.Name, def.Path, err)
+	}
+
+	err = et.sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		typ := col.TypeInfo.ToSqlType()
+		if format != ExternalFormatParquet {
+			typ = sqlTypes.LongText
+		}
+		et.sqlSch = append(et.sqlSch, &sql.Column{Name: col.Name, Type: typ, Source: def.Name, Nullable: true})
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return et, nil
+}
+
+// csvSchema returns the schema given by the header line of the table's delimited file.
+func (et *ExternalTable) csvSchema(ctx *sql.Context) (schema.Schema, error) {
+	rd, err := et.openCSVReader()
+	if err != nil {
+		return nil, err
+	}
+	defer rd.Close(ctx)
+	return rd.GetSchema(), nil
+}
+
+func (et *ExternalTable) openCSVReader() (*csv.CSVReader, error) {
+	return csv.OpenCSVReader(types.Format_Default, et.path, filesys.LocalFS, csv.NewCSVInfo().SetDelim(et.delim))
+}
+
+// checkFilePrivilege returns an error if the current user doesn't have the FILE privilege. The privilege set of a
+// client's session has been loaded by the time tables are resolved, since resolving their database checks it. Internal
+// sessions, such as those of background jobs, never load one, and aren't subject to privilege checks.
+func checkFilePrivilege(ctx *sql.Context) error {
+	privs, counter := ctx.GetPrivilegeSet()
+	if counter == 0 {
+		return nil
+	}
+	if !privs.Has(sql.PrivilegeType_File) && !privs.Has(sql.PrivilegeType_Super) {
+		return sql.ErrPrivilegeCheckFailed.New(ctx.Session.Client().User)
+	}
+	return nil
+}
+
+// checkSecureFilePath returns an error if |path| isn't in the directory given by the secure_file_priv system
+// variable, or if it isn't set. Symbolic links are resolved first, so that a link in the directory can't point to a
+// file outside of it.
+func checkSecureFilePath(ctx *sql.Context, path string) error {
+	dir, err := ctx.Session.GetSessionVariable(ctx, "secure_file_priv")
+	if err != nil {
+		return err
+	}
+	secureDir, _ := dir.(string)
+	if secureDir == "" {
+		return fmt.Errorf("files can't be read unless the secure_file_priv system variable is set")
+	}
+
+	absDir, err := resolvePath(secureDir)
+	if err != nil {
+		return err
+	}
+	absPath, err := resolvePath(path)
+	if err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(absDir, absPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("the file %s is not in the secure_file_priv directory", path)
+	}
+	return nil
+}
+
+// resolvePath returns the absolute path of |path|, with its symbolic links resolved if it exists. A path which doesn't
+// exist fails to be read later on.
+func resolvePath(path string) (string, error) {
+	resolved, err := filepath.EvalSymlinks(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		resolved = path
+	} else if err != nil {
+		return "", err
+	}
+	return filepath.Abs(resolved)
+}
+
+// Name is a sql.Table interface function which returns the name of the table.
+func (et *ExternalTable) Name() string {
+	return et.name
+}
+
+// String is a sql.Table interface function which returns the name of the table.
+func (et *ExternalTable) String() string {
+	return et.name
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the table.
+func (et *ExternalTable) Schema() sql.Schema {
+	return et.sqlSch
+}
+
+// Collation is a sql.Table interface function that returns the collation of the table.
+func (et *ExternalTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a single partition of the data.
+func (et *ExternalTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition.
+func (et *ExternalTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	return et.RowIter(ctx)
+}
+
+// RowIter returns an iterator over the rows of the table's file.
+func (et *ExternalTable) RowIter(ctx *sql.Context) (sql.RowIter, error) {
+	var rd table.SqlRowReader
+	var err error
+	if et.format == ExternalFormatParquet {
+		rd, err = parquet.OpenParquetReader(nil, et.path, et.sch)
+	} else {
+		rd, err = et.openCSVReader()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("external table %s: failed to read %s: %w", et.name, et.path, err)
+	}
+	return &externalTableRowIter{rd: rd, sch: et.sqlSch}, nil
+}
+
+type externalTableRowIter struct {
+	rd  table.SqlRowReader
+	sch sql.Schema
+}
+
+var _ sql.RowIter = (*externalTableRowIter)(nil)
+
+// Next implements sql.RowIter, converting the values read from the file to the types of the table's columns.
+func (itr *externalTableRowIter) Next(ctx *sql.Context) (sql.Row, error) {
+	r, err := itr.rd.ReadSqlRow(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, v := range r {
+		if v == nil {
+			continue
+		}
+		r[i], _, err = itr.sch[i].Type.Convert(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Close implements sql.RowIter.
+func (itr *externalTableRowIter) Close(ctx *sql.Context) error {
+	return itr.rd.Close(ctx)
+}
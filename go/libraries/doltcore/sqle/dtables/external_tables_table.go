@@ -0,0 +1,290 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	sqlTypes "github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/sqltypes"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+var _ sql.Table = (*ExternalTablesTable)(nil)
+var _ sql.UpdatableTable = (*ExternalTablesTable)(nil)
+var _ sql.DeletableTable = (*ExternalTablesTable)(nil)
+var _ sql.InsertableTable = (*ExternalTablesTable)(nil)
+var _ sql.ReplaceableTable = (*ExternalTablesTable)(nil)
+var _ sql.IndexAddressableTable = (*ExternalTablesTable)(nil)
+
+// ExternalTablesTable is the system table that stores the definitions of external tables, read-only tables whose rows
+// are read from a file.
+type ExternalTablesTable struct {
+	dbName       string
+	backingTable VersionableTable
+}
+
+// NewExternalTablesTable creates an ExternalTablesTable for the database with the revision qualified name |dbName|
+func NewExternalTablesTable(_ *sql.Context, dbName string, backingTable VersionableTable) sql.Table {
+	return &ExternalTablesTable{dbName: dbName, backingTable: backingTable}
+}
+
+// NewEmptyExternalTablesTable creates an ExternalTablesTable for the database with the revision qualified name |dbName|
+func NewEmptyExternalTablesTable(_ *sql.Context, dbName string) sql.Table {
+	return &ExternalTablesTable{dbName: dbName}
+}
+
+func (ct *ExternalTablesTable) Name() string {
+	return doltdb.ExternalTablesTableName
+}
+
+func (ct *ExternalTablesTable) String() string {
+	return doltdb.ExternalTablesTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the dolt_external_tables system table.
+func (ct *ExternalTablesTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: doltdb.ExternalTablesTableNameColName, Type: sqlTypes.MustCreateString(sqltypes.VarChar, defaultStringsLen, sql.Collation_Default), Source: doltdb.ExternalTablesTableName, PrimaryKey: true, Nullable: false},
+		{Name: doltdb.ExternalTablesPathColName, Type: sqlTypes.MustCreateString(sqltypes.VarChar, defaultStringsLen, sql.Collation_Default), Source: doltdb.ExternalTablesTableName, PrimaryKey: false, Nullable: false},
+		{Name: doltdb.ExternalTablesFormatColName, Type: sqlTypes.MustCreateString(sqltypes.VarChar, defaultStringsLen, sql.Collation_Default), Source: doltdb.ExternalTablesTableName, PrimaryKey: false, Nullable: true},
+		{Name: doltdb.ExternalTablesDelimiterColName, Type: sqlTypes.MustCreateString(sqltypes.VarChar, defaultStringsLen, sql.Collation_Default), Source: doltdb.ExternalTablesTableName, PrimaryKey: false, Nullable: true},
+	}
+}
+
+func (ct *ExternalTablesTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data.
+func (ct *ExternalTablesTable) Partitions(context *sql.Context) (sql.PartitionIter, error) {
+	if ct.backingTable == nil {
+		// no backing table; return an empty iter.
+		return index.SinglePartitionIterFromNomsMap(nil), nil
+	}
+	return ct.backingTable.Partitions(context)
+}
+
+func (ct *ExternalTablesTable) PartitionRows(context *sql.Context, partition sql.Partition) (sql.RowIter, error) {
+	if ct.backingTable == nil {
+		// no backing table; return an empty iter.
+		return sql.RowsToRowIter(), nil
+	}
+
+	return ct.backingTable.PartitionRows(context, partition)
+}
+
+// Replacer returns a RowReplacer for this table. The RowReplacer will have Insert and optionally Delete called once
+// for each row, followed by a call to Close() when all rows have been processed.
+func (ct *ExternalTablesTable) Replacer(ctx *sql.Context) sql.RowReplacer {
+	return newExternalTablesWriter(ct)
+}
+
+// Updater returns a RowUpdater for this table. The RowUpdater will have Update called once for each row to be
+// updated, followed by a call to Close() when all rows have been processed.
+func (ct *ExternalTablesTable) Updater(ctx *sql.Context) sql.RowUpdater {
+	return newExternalTablesWriter(ct)
+}
+
+// Inserter returns an Inserter for this table. The Inserter will get one call to Insert() for each row to be
+// inserted, and will end with a call to Close() to finalize the insert operation.
+func (ct *ExternalTablesTable) Inserter(*sql.Context) sql.RowInserter {
+	return newExternalTablesWriter(ct)
+}
+
+// Deleter returns a RowDeleter for this table. The RowDeleter will get one call to Delete for each row to be deleted,
+// and will end with a call to Close() to finalize the delete operation.
+func (ct *ExternalTablesTable) Deleter(*sql.Context) sql.RowDeleter {
+	return newExternalTablesWriter(ct)
+}
+
+func (ct *ExternalTablesTable) LockedToRoot(ctx *sql.Context, root doltdb.RootValue) (sql.IndexAddressableTable, error) {
+	if ct.backingTable == nil {
+		return ct, nil
+	}
+	return ct.backingTable.LockedToRoot(ctx, root)
+}
+
+// IndexedAccess implements IndexAddressableTable, but ExternalTablesTable has no indexes.
+// Thus, this should never be called.
+func (ct *ExternalTablesTable) IndexedAccess(lookup sql.IndexLookup) sql.IndexedTable {
+	panic("Unreachable")
+}
+
+// GetIndexes implements IndexAddressableTable, but ExternalTablesTable has no indexes.
+func (ct *ExternalTablesTable) GetIndexes(ctx *sql.Context) ([]sql.Index, error) {
+	return nil, nil
+}
+
+func (ct *ExternalTablesTable) PreciseMatch() bool {
+	return true
+}
+
+var _ sql.RowReplacer = (*externalTablesWriter)(nil)
+var _ sql.RowUpdater = (*externalTablesWriter)(nil)
+var _ sql.RowInserter = (*externalTablesWriter)(nil)
+var _ sql.RowDeleter = (*externalTablesWriter)(nil)
+
+type externalTablesWriter struct {
+	ct                      *ExternalTablesTable
+	errDuringStatementBegin error
+	prevHash                *hash.Hash
+	tableWriter             dsess.TableWriter
+}
+
+func newExternalTablesWriter(ct *ExternalTablesTable) *externalTablesWriter {
+	return &externalTablesWriter{ct, nil, nil, nil}
+}
+
+// Insert inserts the row given, returning an error if it cannot. Insert will be called once for each row to process
+// for the insert operation, which may involve many rows. After all rows in an operation have been processed, Close
+// is called.
+func (mw *externalTablesWriter) Insert(ctx *sql.Context, r sql.Row) error {
+	if err := mw.errDuringStatementBegin; err != nil {
+		return err
+	}
+	return mw.tableWriter.Insert(ctx, r)
+}
+
+// Update the given row. Provides both the old and new rows.
+func (mw *externalTablesWriter) Update(ctx *sql.Context, old sql.Row, new sql.Row) error {
+	if err := mw.errDuringStatementBegin; err != nil {
+		return err
+	}
+	return mw.tableWriter.Update(ctx, old, new)
+}
+
+// Delete deletes the given row. Returns ErrDeleteRowNotFound if the row was not found. Delete will be called once for
+// each row to process for the delete operation, which may involve many rows. After all rows have been processed,
+// Close is called.
+func (mw *externalTablesWriter) Delete(ctx *sql.Context, r sql.Row) error {
+	if err := mw.errDuringStatementBegin; err != nil {
+		return err
+	}
+	return mw.tableWriter.Delete(ctx, r)
+}
+
+// StatementBegin is called before the first operation of a statement. Integrators should mark the state of the data
+// in some way that it may be returned to in the case of an error.
+func (mw *externalTablesWriter) StatementBegin(ctx *sql.Context) {
+	dbName := mw.ct.dbName
+	dSess := dsess.DSessFromSess(ctx.Session)
+
+	roots, _ := dSess.GetRoots(ctx, dbName)
+	dbState, ok, err := dSess.LookupDbState(ctx, dbName)
+	if err != nil {
+		mw.errDuringStatementBegin = err
+		return
+	}
+	if !ok {
+		mw.errDuringStatementBegin = fmt.Errorf("no root value found in session")
+		return
+	}
+
+	prevHash, err := roots.Working.HashOf()
+	if err != nil {
+		mw.errDuringStatementBegin = err
+		return
+	}
+
+	mw.prevHash = &prevHash
+
+	tname := doltdb.TableName{Name: doltdb.ExternalTablesTableName}
+	found, err := roots.Working.HasTable(ctx, tname)
+	if err != nil {
+		mw.errDuringStatementBegin = err
+		return
+	}
+
+	if !found {
+		sch := sql.NewPrimaryKeySchema(mw.ct.Schema())
+		doltSch, err := sqlutil.ToDoltSchema(ctx, roots.Working, tname, sch, roots.Head, sql.Collation_Default)
+		if err != nil {
+			mw.errDuringStatementBegin = err
+			return
+		}
+
+		// underlying table doesn't exist. Record this, then create the table.
+		newRootValue, err := doltdb.CreateEmptyTable(ctx, roots.Working, tname, doltSch)
+		if err != nil {
+			mw.errDuringStatementBegin = err
+			return
+		}
+
+		if dbState.WorkingSet() == nil {
+			mw.errDuringStatementBegin = doltdb.ErrOperationNotSupportedInDetachedHead
+			return
+		}
+
+		// We use WriteSession.SetWorkingSet instead of DoltSession.SetWorkingRoot because we want to avoid modifying the root
+		// until the end of the transaction, but we still want the WriteSession to be able to find the newly
+		// created table.
+		if ws := dbState.WriteSession(); ws != nil {
+			err = ws.SetWorkingSet(ctx, dbState.WorkingSet().WithWorkingRoot(newRootValue))
+			if err != nil {
+				mw.errDuringStatementBegin = err
+				return
+			}
+		}
+
+		err = dSess.SetWorkingRoot(ctx, dbName, newRootValue)
+		if err != nil {
+			mw.errDuringStatementBegin = err
+			return
+		}
+	}
+
+	if ws := dbState.WriteSession(); ws != nil {
+		tableWriter, err := ws.GetTableWriter(ctx, tname, dbName, dSess.SetWorkingRoot, false)
+		if err != nil {
+			mw.errDuringStatementBegin = err
+			return
+		}
+		mw.tableWriter = tableWriter
+		tableWriter.StatementBegin(ctx)
+	}
+}
+
+// DiscardChanges is called if a statement encounters an error, and all current changes since the statement beginning
+// should be discarded.
+func (mw *externalTablesWriter) DiscardChanges(ctx *sql.Context, errorEncountered error) error {
+	if mw.tableWriter != nil {
+		return mw.tableWriter.DiscardChanges(ctx, errorEncountered)
+	}
+	return nil
+}
+
+// StatementComplete is called after the last operation of the statement, indicating that it has successfully completed.
+// The mark set in StatementBegin may be removed, and a new one should be created on the next StatementBegin.
+func (mw *externalTablesWriter) StatementComplete(ctx *sql.Context) error {
+	if mw.tableWriter != nil {
+		return mw.tableWriter.StatementComplete(ctx)
+	}
+	return nil
+}
+
+// Close finalizes the delete operation, persisting the result.
+func (mw externalTablesWriter) Close(ctx *sql.Context) error {
+	if mw.tableWriter != nil {
+		return mw.tableWriter.Close(ctx)
+	}
+	return nil
+}
@@ -2582,6 +2582,81 @@ var SchemaChangeTestsForJsonConflicts = []MergeScriptTest{
 			},
 		},
 	},
+	{
+		Name: "json array merge fails without @@dolt_merge_json_arrays",
+		AncSetUpScript: []string{
+			"set autocommit = 0;",
+			"CREATE table t (pk int primary key, j json);",
+			`INSERT into t values (1, '{"rules": [{"id": 1, "allow": false}, {"id": 2, "allow": false}]}');`,
+		},
+		RightSetUpScript: []string{
+			`update t set j = '{"rules": [{"id": 1, "allow": false}, {"id": 2, "allow": true}]}';`,
+		},
+		LeftSetUpScript: []string{
+			`update t set j = '{"rules": [{"id": 1, "allow": true}, {"id": 2, "allow": false}, {"id": 3}]}';`,
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('right');",
+				Expected: []sql.Row{{"", 0, 1, "conflicts found"}},
+			},
+		},
+	},
+	{
+		Name: "json array merge succeeds with @@dolt_merge_json_arrays",
+		AncSetUpScript: []string{
+			"set autocommit = 0;",
+			"set @@dolt_merge_json_arrays = 1;",
+			"CREATE table t (pk int primary key, j json);",
+			`INSERT into t values (1, '{"rules": [{"id": 1, "allow": false}, {"id": 2, "allow": false}], "tags": ["a", "b"]}');`,
+		},
+		RightSetUpScript: []string{
+			`update t set j = '{"rules": [{"id": 1, "allow": false}, {"id": 2, "allow": true}], "tags": ["a", "b", "c"]}';`,
+		},
+		LeftSetUpScript: []string{
+			`update t set j = '{"rules": [{"id": 1, "allow": true}, {"id": 2, "allow": false}, {"id": 3}], "tags": ["z", "a", "b"]}';`,
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('right');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query: "select * from t;",
+				Expected: []sql.Row{
+					{
+						1, `{"tags": ["z", "a", "b", "c"], "rules": [{"id": 1, "allow": true}, {"id": 2, "allow": true}, {"id": 3}]}`,
+					},
+				},
+			},
+		},
+	},
+	{
+		Name: "json array merge with custom identity fields",
+		AncSetUpScript: []string{
+			"set autocommit = 0;",
+			"set @@dolt_merge_json_arrays = 1;",
+			"set @@dolt_json_array_identity_fields = 'name';",
+			"CREATE table t (pk int primary key, j json);",
+			`INSERT into t values (1, '[{"name": "a", "v": 1}, {"name": "b", "v": 1}]');`,
+		},
+		RightSetUpScript: []string{
+			`update t set j = '[{"name": "a", "v": 1}, {"name": "b", "v": 2}]';`,
+		},
+		LeftSetUpScript: []string{
+			`update t set j = '[{"name": "a", "v": 2}, {"name": "b", "v": 1}]';`,
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('right');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{1, `[{"v": 2, "name": "a"}, {"v": 2, "name": "b"}]`}},
+			},
+		},
+	},
 }
 
 // These tests are not run because they cause panics during set-up.
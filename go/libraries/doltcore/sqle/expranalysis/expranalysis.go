@@ -71,6 +71,38 @@ func ResolveCheckExpression(ctx *sql.Context, tableName string, sch schema.Schem
 	return nil, fmt.Errorf("unable to find check expression")
 }
 
+// ResolveConflictResolverExpression returns a sql.Expression for |resolver|, a conflict resolver expression over the
+// columns base, ours and theirs, each of type |typ|. The returned expression is evaluated against a row holding the
+// base, ours and theirs values, in that order. |fns| are made available to the expression in addition to the
+// built-in functions.
+func ResolveConflictResolverExpression(ctx *sql.Context, typ sql.Type, resolver string, fns ...sql.Function) (sql.Expression, error) {
+	query := fmt.Sprintf("CREATE TABLE resolver (base %[1]s, ours %[1]s, theirs %[1]s, resolved %[1]s AS (%[2]s))", typ.String(), resolver)
+
+	mockDatabase := memory.NewDatabase("mydb")
+	mockProvider := memory.NewDBProvider(mockDatabase)
+	catalog := analyzer.NewCatalog(mockProvider)
+	parseCtx := sql.NewEmptyContext()
+	parseCtx.SetCurrentDatabase("mydb")
+	catalog.RegisterFunction(parseCtx, fns...)
+
+	b := planbuilder.New(parseCtx, catalog, nil, nil)
+	pseudoAnalyzedQuery, _, _, _, err := b.Parse(query, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	ct, ok := pseudoAnalyzedQuery.(*plan.CreateTable)
+	if !ok {
+		return nil, fmt.Errorf("expected a *plan.CreateTable node, but got %T", pseudoAnalyzedQuery)
+	}
+
+	resolved := ct.PkSchema().Schema[3].Generated
+	if resolved == nil || resolved.Expr == nil {
+		return nil, fmt.Errorf("unable to find conflict resolver expression")
+	}
+	return resolved.Expr, nil
+}
+
 func stripTableNamesFromExpression(expr sql.Expression) sql.Expression {
 	e, _, _ := transform.Expr(expr, func(e sql.Expression) (sql.Expression, transform.TreeIdentity, error) {
 		if col, ok := e.(*expression.GetField); ok {
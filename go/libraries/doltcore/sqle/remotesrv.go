@@ -26,11 +26,13 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/remotesrv"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
 )
 
 type remotesrvStore struct {
-	ctxFactory func(context.Context) (*sql.Context, error)
-	createDBs  bool
+	ctxFactory  func(context.Context) (*sql.Context, error)
+	createDBs   bool
+	commitHooks bool
 }
 
 var _ remotesrv.DBCache = remotesrvStore{}
@@ -67,9 +69,46 @@ func (s remotesrvStore) Get(ctx context.Context, path, _ string) (remotesrv.Remo
 	if !ok {
 		return nil, remotesrv.ErrUnimplemented
 	}
+	if s.commitHooks {
+		return commitHooksStore{rss, sdb.DbData().Ddb}, nil
+	}
 	return rss, nil
 }
 
+// commitHooksStore is a remotesrv.RemoteSrvStore which runs the commit
+// hooks of |ddb| for every branch moved by a push.
+type commitHooksStore struct {
+	remotesrv.RemoteSrvStore
+	ddb *doltdb.DoltDB
+}
+
+func (s commitHooksStore) Commit(ctx context.Context, current, last hash.Hash) (bool, error) {
+	ok, err := s.RemoteSrvStore.Commit(ctx, current, last)
+	if err != nil || !ok {
+		return ok, err
+	}
+	// The push has succeeded whether or not the hooks can be run.
+	before, err := s.ddb.GetBranchesByRootHash(ctx, last)
+	if err != nil {
+		return true, nil
+	}
+	after, err := s.ddb.GetBranchesByRootHash(ctx, current)
+	if err != nil {
+		return true, nil
+	}
+	heads := make(map[string]hash.Hash, len(before))
+	for _, b := range before {
+		heads[b.Ref.String()] = b.Hash
+	}
+	for _, b := range after {
+		if h, ok := heads[b.Ref.String()]; ok && h == b.Hash {
+			continue
+		}
+		_ = s.ddb.ExecuteCommitHooks(ctx, b.Ref.String())
+	}
+	return true, nil
+}
+
 // In the SQL context, the database provider that we use to expose the
 // remotesapi interface can choose to either create a newly accessed database
 // on first access or to return NotFound. Currently we allow creation in the
@@ -83,11 +122,22 @@ type CreateUnknownDatabasesSetting bool
 const CreateUnknownDatabases CreateUnknownDatabasesSetting = true
 const DoNotCreateUnknownDatabases CreateUnknownDatabasesSetting = false
 
+// In the exposed-as-a-remotesapi-endpoint use case, a push moves the
+// branches of a database just like a commit or a dolt_push does, so the
+// commit hooks of the database are run for every branch it moves. In the
+// cluster replication context they are not, since the commits being
+// replicated already ran the hooks of the primary.
+
+type CommitHooksSetting bool
+
+const RunCommitHooks CommitHooksSetting = true
+const DoNotRunCommitHooks CommitHooksSetting = false
+
 // Returns a remotesrv.DBCache instance which will use the *sql.Context
 // returned from |ctxFactory| to access a database in the session
 // DatabaseProvider.
-func RemoteSrvDBCache(ctxFactory func(context.Context) (*sql.Context, error), createSetting CreateUnknownDatabasesSetting) (remotesrv.DBCache, error) {
-	dbcache := remotesrvStore{ctxFactory, bool(createSetting)}
+func RemoteSrvDBCache(ctxFactory func(context.Context) (*sql.Context, error), createSetting CreateUnknownDatabasesSetting, hooksSetting CommitHooksSetting) (remotesrv.DBCache, error) {
+	dbcache := remotesrvStore{ctxFactory, bool(createSetting), bool(hooksSetting)}
 	return dbcache, nil
 }
 
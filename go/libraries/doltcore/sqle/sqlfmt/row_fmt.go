@@ -52,6 +52,11 @@ func QuoteComment(s string) string {
 	return `'` + strings.ReplaceAll(s, `'`, `\'`) + `'`
 }
 
+// QuoteString returns the given string as a SQL string literal, with any special characters escaped.
+func QuoteString(s string) string {
+	return quoteAndEscapeString(s)
+}
+
 func RowAsInsertStmt(r row.Row, tableName string, tableSch schema.Schema) (string, error) {
 	var b strings.Builder
 	b.WriteString("INSERT INTO ")
@@ -425,6 +430,65 @@ func SqlRowAsDeleteStmt(r sql.Row, tableName string, tableSch schema.Schema, lim
 	return b.String(), nil
 }
 
+// SqlRowsAsDeleteStmt returns a statement deleting the rows |rows| of the table |tableName|, which has primary key
+// columns. Rows are matched by their primary key, with an IN list if the primary key has a single column.
+func SqlRowsAsDeleteStmt(rows []sql.Row, tableName string, tableSch schema.Schema) (string, error) {
+	var pkCols []schema.Column
+	var pkIdxs []int
+	for i, col := range tableSch.GetAllCols().GetColumns() {
+		if col.IsPartOfPK {
+			pkCols = append(pkCols, col)
+			pkIdxs = append(pkIdxs, i)
+		}
+	}
+	if len(pkCols) == 0 {
+		return "", fmt.Errorf("table %s has no primary key", tableName)
+	}
+
+	var b strings.Builder
+	b.WriteString("DELETE FROM ")
+	b.WriteString(QuoteIdentifier(tableName))
+	b.WriteString(" WHERE ")
+	if len(pkCols) == 1 {
+		b.WriteString(QuoteIdentifier(pkCols[0].Name))
+		b.WriteString(" IN (")
+	}
+	for i, r := range rows {
+		if i > 0 {
+			if len(pkCols) == 1 {
+				b.WriteRune(',')
+			} else {
+				b.WriteString(" OR ")
+			}
+		}
+		if len(pkCols) > 1 {
+			b.WriteRune('(')
+		}
+		for j, col := range pkCols {
+			sqlString, err := interfaceValueAsSqlString(col.TypeInfo, r[pkIdxs[j]])
+			if err != nil {
+				return "", err
+			}
+			if len(pkCols) > 1 {
+				if j > 0 {
+					b.WriteString(" AND ")
+				}
+				b.WriteString(QuoteIdentifier(col.Name))
+				b.WriteRune('=')
+			}
+			b.WriteString(sqlString)
+		}
+		if len(pkCols) > 1 {
+			b.WriteRune(')')
+		}
+	}
+	if len(pkCols) == 1 {
+		b.WriteRune(')')
+	}
+	b.WriteString(";")
+	return b.String(), nil
+}
+
 func SqlRowAsUpdateStmt(r sql.Row, tableName string, tableSch schema.Schema, colsToUpdate *set.StrSet) (string, error) {
 	var b strings.Builder
 	b.WriteString("UPDATE ")
@@ -17,6 +17,7 @@ package sqlfmt_test
 import (
 	"testing"
 
+	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -191,6 +192,26 @@ func TestRowAsDeleteStmt(t *testing.T) {
 	}
 }
 
+func TestSqlRowsAsDeleteStmt(t *testing.T) {
+	singlePkSch := dtestutils.CreateSchema(
+		schema.NewColumn("id", 0, types.IntKind, true),
+		schema.NewColumn("name", 1, types.StringKind, false),
+	)
+	compositePkSch := dtestutils.CreateSchema(
+		schema.NewColumn("name", 0, types.StringKind, false),
+		schema.NewColumn("a", 1, types.IntKind, true),
+		schema.NewColumn("b", 2, types.StringKind, true),
+	)
+
+	stmt, err := sqlfmt.SqlRowsAsDeleteStmt([]sql.Row{{int64(1), "x"}, {int64(-2), "y"}}, "t", singlePkSch)
+	require.NoError(t, err)
+	assert.Equal(t, "DELETE FROM `t` WHERE `id` IN (1,-2);", stmt)
+
+	stmt, err = sqlfmt.SqlRowsAsDeleteStmt([]sql.Row{{"x", int64(1), "it's"}, {"y", int64(2), "b"}}, "t", compositePkSch)
+	require.NoError(t, err)
+	assert.Equal(t, "DELETE FROM `t` WHERE (`a`=1 AND `b`='it\\'s') OR (`a`=2 AND `b`='b');", stmt)
+}
+
 func TestRowAsUpdateStmt(t *testing.T) {
 	id := uuid.MustParse("00000000-0000-0000-0000-000000000000")
 	tableName := "people"
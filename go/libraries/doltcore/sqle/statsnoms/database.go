@@ -36,6 +36,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
 	"github.com/dolthub/dolt/go/store/prolly"
 	"github.com/dolthub/dolt/go/store/types"
 )
@@ -87,7 +88,16 @@ func (sf NomsStatsFactory) Init(ctx *sql.Context, sourceDb dsess.SqlDatabase, pr
 		dEnv = env.LoadWithoutDB(ctx, hdp, statsFs, "", "")
 	}
 
-	dEnv.LoadDoltDBWithParams(ctx, types.Format_Default, urlPath, statsFs, params)
+	err = dEnv.LoadDoltDBWithParams(ctx, types.Format_Default, urlPath, statsFs, params)
+	if errors.Is(err, nbs.ErrEncryptionKeyUnknown) {
+		// statistics encrypted with a key which has since been rotated out can't be read, so they're rebuilt
+		if err = statsFs.Delete("", true); err != nil {
+			return nil, err
+		}
+		return sf.Init(ctx, sourceDb, prov, fs, hdp)
+	} else if err != nil {
+		return nil, err
+	}
 
 	deaf := dEnv.DbEaFactory(ctx)
 
@@ -211,6 +211,20 @@ var DoltSystemVariables = []sql.SystemVariable{
 		Type:    types.NewSystemBoolType("dolt_dont_merge_json"),
 		Default: int8(0),
 	},
+	&sql.MysqlSystemVariable{
+		Name:    "dolt_merge_json_arrays",
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Both),
+		Type:    types.NewSystemBoolType("dolt_merge_json_arrays"),
+		Default: int8(0),
+	},
+	&sql.MysqlSystemVariable{
+		Name:    "dolt_json_array_identity_fields",
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Both),
+		Type:    types.NewSystemStringType("dolt_json_array_identity_fields"),
+		Default: "id",
+	},
 	&sql.MysqlSystemVariable{
 		Name:    "dolt_optimize_json",
 		Dynamic: true,
@@ -445,6 +459,20 @@ func AddDoltSystemVariables() {
 			Type:    types.NewSystemBoolType("dolt_dont_merge_json"),
 			Default: int8(0),
 		},
+		&sql.MysqlSystemVariable{
+			Name:    "dolt_merge_json_arrays",
+			Dynamic: true,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Both),
+			Type:    types.NewSystemBoolType("dolt_merge_json_arrays"),
+			Default: int8(0),
+		},
+		&sql.MysqlSystemVariable{
+			Name:    "dolt_json_array_identity_fields",
+			Dynamic: true,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Both),
+			Type:    types.NewSystemStringType("dolt_json_array_identity_fields"),
+			Default: "id",
+		},
 		&sql.MysqlSystemVariable{
 			Name:    dsess.DoltStatsAutoRefreshEnabled,
 			Dynamic: true,
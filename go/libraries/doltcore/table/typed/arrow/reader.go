@@ -0,0 +1,243 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrow
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/dolthub/go-mysql-server/sql"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/shopspring/decimal"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+type recordReader interface {
+	Read() (array.Record, error)
+}
+
+type readAtSeeker interface {
+	io.Reader
+	io.ReaderAt
+	io.Seeker
+}
+
+// ArrowReader implements TableReader. It reads files in either of the Arrow IPC formats and returns rows. Columns are
+// matched to the fields of the Arrow schema by name, and fields which aren't in the table's schema are ignored.
+type ArrowReader struct {
+	vrw     types.ValueReadWriter
+	sch     schema.Schema
+	closer  io.Closer
+	release func()
+	rd      recordReader
+	// fieldIdx holds the index of the Arrow field read for each column of |sch|
+	fieldIdx []int
+	rec      array.Record
+	recRow   int
+}
+
+var _ table.SqlTableReader = (*ArrowReader)(nil)
+
+// OpenArrowReader opens a reader at a given path within the filesystem.
+func OpenArrowReader(vrw types.ValueReadWriter, path string, fs filesys.ReadableFS, sch schema.Schema) (*ArrowReader, error) {
+	r, err := fs.OpenForRead(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewArrowReader(vrw, r, sch)
+}
+
+// NewArrowReader creates an ArrowReader reading from |r|. The IPC format is detected from the first bytes read. Files
+// in the file format are read from their footer, so when |r| can't seek they are read into memory first.
+func NewArrowReader(vrw types.ValueReadWriter, r io.ReadCloser, sch schema.Schema) (*ArrowReader, error) {
+	ar := &ArrowReader{vrw: vrw, sch: sch, closer: r}
+
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(len(ipc.Magic))
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	if bytes.Equal(magic, ipc.Magic) {
+		ras, ok := r.(readAtSeeker)
+		if !ok {
+			data, err := io.ReadAll(br)
+			if err != nil {
+				return nil, err
+			}
+			ras = bytes.NewReader(data)
+		}
+		fr, err := ipc.NewFileReader(ras)
+		if err != nil {
+			return nil, err
+		}
+		ar.rd = fr
+		ar.release = func() { fr.Close() }
+	} else {
+		sr, err := ipc.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		ar.rd = sr
+		ar.release = sr.Release
+	}
+
+	var arrowSch *arrow.Schema
+	switch rd := ar.rd.(type) {
+	case *ipc.FileReader:
+		arrowSch = rd.Schema()
+	case *ipc.Reader:
+		arrowSch = rd.Schema()
+	}
+
+	cols := sch.GetAllCols().GetColumns()
+	ar.fieldIdx = make([]int, len(cols))
+	for i, col := range cols {
+		indices := arrowSch.FieldIndices(col.Name)
+		if len(indices) == 0 {
+			ar.release()
+			return nil, fmt.Errorf("cannot read column: %s Column not found", col.Name)
+		} else if len(indices) > 1 {
+			ar.release()
+			return nil, fmt.Errorf("cannot read column: %s is ambiguous", col.Name)
+		}
+		ar.fieldIdx[i] = indices[0]
+	}
+
+	return ar, nil
+}
+
+func (ar *ArrowReader) ReadRow(ctx context.Context) (row.Row, error) {
+	panic("deprecated")
+}
+
+func (ar *ArrowReader) ReadSqlRow(ctx context.Context) (sql.Row, error) {
+	for ar.rec == nil || ar.recRow >= int(ar.rec.NumRows()) {
+		rec, err := ar.rd.Read()
+		if err != nil {
+			return nil, err
+		}
+		ar.rec = rec
+		ar.recRow = 0
+	}
+
+	cols := ar.sch.GetAllCols().GetColumns()
+	r := make(sql.Row, len(cols))
+	for i, col := range cols {
+		val, err := arrowValue(ar.rec.Column(ar.fieldIdx[i]), ar.recRow)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read column: %s %w", col.Name, err)
+		}
+		if val != nil {
+			val, _, err = col.TypeInfo.ToSqlType().Convert(val)
+			if err != nil {
+				return nil, err
+			}
+		}
+		r[i] = val
+	}
+	ar.recRow++
+
+	return r, nil
+}
+
+// arrowValue returns the value at index |i| of |arr|.
+func arrowValue(arr array.Interface, i int) (interface{}, error) {
+	if arr.IsNull(i) {
+		return nil, nil
+	}
+
+	switch arr := arr.(type) {
+	case *array.Boolean:
+		return arr.Value(i), nil
+	case *array.Int8:
+		return arr.Value(i), nil
+	case *array.Int16:
+		return arr.Value(i), nil
+	case *array.Int32:
+		return arr.Value(i), nil
+	case *array.Int64:
+		return arr.Value(i), nil
+	case *array.Uint8:
+		return arr.Value(i), nil
+	case *array.Uint16:
+		return arr.Value(i), nil
+	case *array.Uint32:
+		return arr.Value(i), nil
+	case *array.Uint64:
+		return arr.Value(i), nil
+	case *array.Float32:
+		return arr.Value(i), nil
+	case *array.Float64:
+		return arr.Value(i), nil
+	case *array.String:
+		return arr.Value(i), nil
+	case *array.Binary:
+		// the bytes of the array are only valid until the next record is read
+		return append([]byte(nil), arr.Value(i)...), nil
+	case *array.Decimal128:
+		scale := arr.DataType().(*arrow.Decimal128Type).Scale
+		return decimal.NewFromBigInt(arr.Value(i).BigInt(), -scale), nil
+	case *array.Date32:
+		return time.Unix(int64(arr.Value(i))*24*60*60, 0).UTC(), nil
+	case *array.Date64:
+		return time.UnixMilli(int64(arr.Value(i))).UTC(), nil
+	case *array.Timestamp:
+		unit := arr.DataType().(*arrow.TimestampType).Unit
+		return time.Unix(0, int64(arr.Value(i))*int64(unit.Multiplier())).UTC(), nil
+	case *array.Duration:
+		unit := arr.DataType().(*arrow.DurationType).Unit
+		return gmstypes.Timespan(time.Duration(int64(arr.Value(i)) * int64(unit.Multiplier())).Microseconds()), nil
+	case *array.Time32:
+		unit := arr.DataType().(*arrow.Time32Type).Unit
+		return gmstypes.Timespan(time.Duration(int64(arr.Value(i)) * int64(unit.Multiplier())).Microseconds()), nil
+	case *array.Time64:
+		unit := arr.DataType().(*arrow.Time64Type).Unit
+		return gmstypes.Timespan(time.Duration(int64(arr.Value(i)) * int64(unit.Multiplier())).Microseconds()), nil
+	default:
+		return nil, fmt.Errorf("has unsupported arrow type %s", arr.DataType())
+	}
+}
+
+func (ar *ArrowReader) GetSchema() schema.Schema {
+	return ar.sch
+}
+
+// Close should release resources being held
+func (ar *ArrowReader) Close(ctx context.Context) error {
+	if ar.closer == nil {
+		return errors.New("already closed")
+	}
+
+	ar.release()
+	err := ar.closer.Close()
+	ar.closer = nil
+
+	return err
+}
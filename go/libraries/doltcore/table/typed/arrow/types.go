@@ -0,0 +1,95 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrow
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/vt/proto/query"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+)
+
+// maxDecimal128Precision is the largest precision of an Arrow decimal128 value. Decimal columns with a larger precision
+// are written as strings.
+const maxDecimal128Precision = 38
+
+// ArrowSchema returns the Arrow schema of the rows of |sch|, with each column mapped to an Arrow type by
+// mapTypeToArrowType.
+func ArrowSchema(sch schema.Schema) (*arrow.Schema, error) {
+	cols := sch.GetAllCols().GetColumns()
+	fields := make([]arrow.Field, len(cols))
+	for i, col := range cols {
+		dt, err := mapTypeToArrowType(col.TypeInfo.ToSqlType())
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", col.Name, err)
+		}
+		fields[i] = arrow.Field{Name: col.Name, Type: dt, Nullable: col.IsNullable()}
+	}
+	return arrow.NewSchema(fields, nil), nil
+}
+
+// mapTypeToArrowType maps |t| to the Arrow type used to write its values. Types without an Arrow equivalent, such as
+// JSON, enums and sets, are written as strings.
+func mapTypeToArrowType(t sql.Type) (arrow.DataType, error) {
+	switch t.Type() {
+	case query.Type_INT8:
+		return arrow.PrimitiveTypes.Int8, nil
+	case query.Type_INT16, query.Type_YEAR:
+		return arrow.PrimitiveTypes.Int16, nil
+	case query.Type_INT24, query.Type_INT32:
+		return arrow.PrimitiveTypes.Int32, nil
+	case query.Type_INT64:
+		return arrow.PrimitiveTypes.Int64, nil
+	case query.Type_UINT8:
+		return arrow.PrimitiveTypes.Uint8, nil
+	case query.Type_UINT16:
+		return arrow.PrimitiveTypes.Uint16, nil
+	case query.Type_UINT24, query.Type_UINT32:
+		return arrow.PrimitiveTypes.Uint32, nil
+	case query.Type_UINT64, query.Type_BIT:
+		return arrow.PrimitiveTypes.Uint64, nil
+	case query.Type_FLOAT32:
+		return arrow.PrimitiveTypes.Float32, nil
+	case query.Type_FLOAT64:
+		return arrow.PrimitiveTypes.Float64, nil
+	case query.Type_DECIMAL:
+		dt := t.(types.DecimalType_)
+		if dt.Precision() > maxDecimal128Precision {
+			return arrow.BinaryTypes.String, nil
+		}
+		return &arrow.Decimal128Type{Precision: int32(dt.Precision()), Scale: int32(dt.Scale())}, nil
+	case query.Type_DATE:
+		return arrow.FixedWidthTypes.Date32, nil
+	case query.Type_DATETIME:
+		return &arrow.TimestampType{Unit: arrow.Microsecond}, nil
+	case query.Type_TIMESTAMP:
+		// timestamps are stored in UTC, whereas datetimes have no time zone
+		return &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}, nil
+	case query.Type_TIME:
+		// times can be negative or longer than a day, so they are written as durations rather than times of day
+		return &arrow.DurationType{Unit: arrow.Microsecond}, nil
+	case query.Type_BINARY, query.Type_VARBINARY, query.Type_BLOB:
+		return arrow.BinaryTypes.Binary, nil
+	case query.Type_CHAR, query.Type_VARCHAR, query.Type_TEXT, query.Type_JSON, query.Type_ENUM, query.Type_SET,
+		query.Type_GEOMETRY:
+		return arrow.BinaryTypes.String, nil
+	default:
+		return nil, fmt.Errorf("unsupported type: %v", t.Type())
+	}
+}
@@ -0,0 +1,298 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/shopspring/decimal"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+)
+
+// IPCFormat is one of the two formats of Arrow's inter-process communication (IPC) encoding.
+type IPCFormat int
+
+const (
+	// FileFormat is the Arrow IPC file format, which is also known as Feather version 2. Files in this format end with
+	// a footer that allows record batches to be read in any order.
+	FileFormat IPCFormat = iota
+	// StreamFormat is the Arrow IPC streaming format, which can be written and read without seeking.
+	StreamFormat
+)
+
+// RecordBatchSize is the number of rows written in each Arrow record batch.
+var RecordBatchSize = 64 * 1024
+
+type recordWriter interface {
+	Write(rec array.Record) error
+	Close() error
+}
+
+// ArrowRowWriter writes rows in an Arrow IPC format. Rows are buffered in memory until a record batch is full.
+type ArrowRowWriter struct {
+	sch      schema.Schema
+	sqlTypes []sql.Type
+	builder  *array.RecordBuilder
+	wr       recordWriter
+	closer   io.Closer
+	numRows  int
+}
+
+var _ table.SqlRowWriter = (*ArrowRowWriter)(nil)
+
+// NewArrowRowWriter creates a new ArrowRowWriter for the specified schema that writes to |w| in the IPC format given.
+func NewArrowRowWriter(outSch schema.Schema, w io.WriteCloser, format IPCFormat) (*ArrowRowWriter, error) {
+	arrowSch, err := ArrowSchema(outSch)
+	if err != nil {
+		return nil, err
+	}
+
+	var wr recordWriter
+	switch format {
+	case FileFormat:
+		// the file writer only seeks to find its current position, so it can write to streams as well as files
+		wr, err = ipc.NewFileWriter(&positionWriter{w: w}, ipc.WithSchema(arrowSch))
+		if err != nil {
+			return nil, err
+		}
+	case StreamFormat:
+		wr = ipc.NewWriter(w, ipc.WithSchema(arrowSch))
+	default:
+		return nil, fmt.Errorf("unknown arrow ipc format: %d", format)
+	}
+
+	cols := outSch.GetAllCols().GetColumns()
+	sqlTypes := make([]sql.Type, len(cols))
+	for i, col := range cols {
+		sqlTypes[i] = col.TypeInfo.ToSqlType()
+	}
+
+	return &ArrowRowWriter{
+		sch:      outSch,
+		sqlTypes: sqlTypes,
+		builder:  array.NewRecordBuilder(memory.NewGoAllocator(), arrowSch),
+		wr:       wr,
+		closer:   w,
+	}, nil
+}
+
+func (aw *ArrowRowWriter) WriteSqlRow(_ context.Context, r sql.Row) error {
+	for i, val := range r {
+		field := aw.builder.Schema().Field(i)
+		if err := appendValue(aw.builder.Field(i), field.Type, aw.sqlTypes[i], val); err != nil {
+			return fmt.Errorf("column %s: %w", field.Name, err)
+		}
+	}
+
+	aw.numRows++
+	if aw.numRows >= RecordBatchSize {
+		return aw.flush()
+	}
+	return nil
+}
+
+// appendValue appends |val|, a value of |sqlType|, to |b|, which builds an array of |dt|.
+func appendValue(b array.Builder, dt arrow.DataType, sqlType sql.Type, val interface{}) error {
+	if val == nil {
+		b.AppendNull()
+		return nil
+	}
+
+	switch b := b.(type) {
+	case *array.Int8Builder:
+		v, err := convertTo[int64](types.Int64, val)
+		b.Append(int8(v))
+		return err
+	case *array.Int16Builder:
+		v, err := convertTo[int64](types.Int64, val)
+		b.Append(int16(v))
+		return err
+	case *array.Int32Builder:
+		v, err := convertTo[int64](types.Int64, val)
+		b.Append(int32(v))
+		return err
+	case *array.Int64Builder:
+		v, err := convertTo[int64](types.Int64, val)
+		b.Append(v)
+		return err
+	case *array.Uint8Builder:
+		v, err := convertTo[uint64](types.Uint64, val)
+		b.Append(uint8(v))
+		return err
+	case *array.Uint16Builder:
+		v, err := convertTo[uint64](types.Uint64, val)
+		b.Append(uint16(v))
+		return err
+	case *array.Uint32Builder:
+		v, err := convertTo[uint64](types.Uint64, val)
+		b.Append(uint32(v))
+		return err
+	case *array.Uint64Builder:
+		v, err := convertTo[uint64](types.Uint64, val)
+		b.Append(v)
+		return err
+	case *array.Float32Builder:
+		v, err := convertTo[float64](types.Float64, val)
+		b.Append(float32(v))
+		return err
+	case *array.Float64Builder:
+		v, err := convertTo[float64](types.Float64, val)
+		b.Append(v)
+		return err
+	case *array.Decimal128Builder:
+		d, err := convertTo[decimal.Decimal](sqlType, val)
+		if err != nil {
+			return err
+		}
+		scale := dt.(*arrow.Decimal128Type).Scale
+		b.Append(decimal128.FromBigInt(d.Shift(scale).BigInt()))
+		return nil
+	case *array.Date32Builder:
+		t, err := convertTo[time.Time](sqlType, val)
+		if err != nil {
+			return err
+		}
+		b.Append(arrow.Date32(daysSinceEpoch(t)))
+		return nil
+	case *array.TimestampBuilder:
+		t, err := convertTo[time.Time](sqlType, val)
+		if err != nil {
+			return err
+		}
+		b.Append(arrow.Timestamp(t.UnixMicro()))
+		return nil
+	case *array.DurationBuilder:
+		ts, err := convertTo[types.Timespan](sqlType, val)
+		if err != nil {
+			return err
+		}
+		b.Append(arrow.Duration(ts.AsMicroseconds()))
+		return nil
+	case *array.BinaryBuilder:
+		switch v := val.(type) {
+		case []byte:
+			b.Append(v)
+		case string:
+			b.AppendString(v)
+		default:
+			str, err := sqlutil.SqlColToStr(sqlType, val)
+			if err != nil {
+				return err
+			}
+			b.AppendString(str)
+		}
+		return nil
+	case *array.StringBuilder:
+		str, err := sqlutil.SqlColToStr(sqlType, val)
+		if err != nil {
+			return err
+		}
+		b.Append(str)
+		return nil
+	default:
+		return fmt.Errorf("unsupported arrow type: %s", dt)
+	}
+}
+
+// convertTo converts |val| with |sqlType| and asserts that the result is a T.
+func convertTo[T any](sqlType sql.Type, val interface{}) (T, error) {
+	var zero T
+	converted, _, err := sqlType.Convert(val)
+	if err != nil {
+		return zero, err
+	}
+	v, ok := converted.(T)
+	if !ok {
+		return zero, fmt.Errorf("unexpected value %v of type %T", converted, converted)
+	}
+	return v, nil
+}
+
+// daysSinceEpoch returns the number of whole days between the unix epoch and the date of |t|.
+func daysSinceEpoch(t time.Time) int32 {
+	t = t.UTC()
+	date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return int32(date.Unix() / (24 * 60 * 60))
+}
+
+// flush writes the buffered rows as a record batch.
+func (aw *ArrowRowWriter) flush() error {
+	if aw.numRows == 0 {
+		return nil
+	}
+
+	rec := aw.builder.NewRecord()
+	defer rec.Release()
+	aw.numRows = 0
+
+	return aw.wr.Write(rec)
+}
+
+// Close should flush all writes, release resources being held
+func (aw *ArrowRowWriter) Close(_ context.Context) error {
+	if aw.wr == nil {
+		return errors.New("already closed")
+	}
+
+	err := aw.flush()
+	if err == nil {
+		err = aw.wr.Close()
+	}
+	aw.wr = nil
+	aw.builder.Release()
+
+	if aw.closer != nil {
+		errCl := aw.closer.Close()
+		if err == nil {
+			err = errCl
+		}
+	}
+
+	return err
+}
+
+// positionWriter is an io.WriteSeeker which tracks how many bytes have been written to it, so that it can report its
+// current position. It can't seek to any other position.
+type positionWriter struct {
+	w   io.Writer
+	pos int64
+}
+
+func (pw *positionWriter) Write(p []byte) (int, error) {
+	n, err := pw.w.Write(p)
+	pw.pos += int64(n)
+	return n, err
+}
+
+func (pw *positionWriter) Seek(offset int64, whence int) (int64, error) {
+	if offset != 0 || whence != io.SeekCurrent {
+		return 0, errors.New("positionWriter can only report its current position")
+	}
+	return pw.pos, nil
+}
@@ -0,0 +1,216 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrow
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/dolthub/go-mysql-server/sql"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/vt/proto/query"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func arrowTestSchema(t *testing.T) schema.Schema {
+	sqlTypes := []struct {
+		name string
+		typ  sql.Type
+	}{
+		{"id", gmstypes.Int64},
+		{"price", gmstypes.MustCreateDecimalType(10, 2)},
+		{"created", gmstypes.DatetimeMaxPrecision},
+		{"updated", gmstypes.TimestampMaxPrecision},
+		{"day", gmstypes.Date},
+		{"elapsed", gmstypes.Time},
+		{"attrs", gmstypes.JSON},
+		{"data", gmstypes.MustCreateBinary(query.Type_VARBINARY, 16)},
+		{"name", gmstypes.Text},
+	}
+
+	cols := make([]schema.Column, len(sqlTypes))
+	for i, st := range sqlTypes {
+		ti, err := typeinfo.FromSqlType(st.typ)
+		require.NoError(t, err)
+		col, err := schema.NewColumnWithTypeInfo(st.name, uint64(i), ti, i == 0, "", false, "")
+		require.NoError(t, err)
+		cols[i] = col
+	}
+
+	sch, err := schema.SchemaFromCols(schema.NewColCollection(cols...))
+	require.NoError(t, err)
+	return sch
+}
+
+func getSampleRows(t *testing.T) []sql.Row {
+	attrs, _, err := gmstypes.JSON.Convert(`{"a": [1, 2]}`)
+	require.NoError(t, err)
+
+	return []sql.Row{
+		{
+			int64(1),
+			decimal.RequireFromString("12.34"),
+			time.Date(2021, 3, 4, 5, 6, 7, 123456000, time.UTC),
+			time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC),
+			time.Date(1969, 12, 31, 0, 0, 0, 0, time.UTC),
+			gmstypes.Timespan(-(26*time.Hour + 5*time.Second).Microseconds()),
+			attrs,
+			[]byte{0, 1, 2, 255},
+			"first",
+		},
+		{int64(2), nil, nil, nil, nil, nil, nil, nil, nil},
+		{
+			int64(3),
+			decimal.RequireFromString("-0.05"),
+			time.Date(1999, 12, 31, 23, 59, 59, 0, time.UTC),
+			time.Date(2038, 1, 19, 3, 14, 7, 0, time.UTC),
+			time.Date(2000, 2, 29, 0, 0, 0, 0, time.UTC),
+			gmstypes.Timespan((time.Hour).Microseconds()),
+			nil,
+			[]byte{},
+			"",
+		},
+	}
+}
+
+func readAllArrow(t *testing.T, reader *ArrowReader) []sql.Row {
+	var rows []sql.Row
+	for {
+		r, err := reader.ReadSqlRow(context.Background())
+		if err == io.EOF {
+			return rows
+		}
+		require.NoError(t, err)
+		rows = append(rows, r)
+	}
+}
+
+func TestArrowSchema(t *testing.T) {
+	arrowSch, err := ArrowSchema(arrowTestSchema(t))
+	require.NoError(t, err)
+
+	expected := []arrow.DataType{
+		arrow.PrimitiveTypes.Int64,
+		&arrow.Decimal128Type{Precision: 10, Scale: 2},
+		&arrow.TimestampType{Unit: arrow.Microsecond},
+		&arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"},
+		arrow.FixedWidthTypes.Date32,
+		&arrow.DurationType{Unit: arrow.Microsecond},
+		arrow.BinaryTypes.String,
+		arrow.BinaryTypes.Binary,
+		arrow.BinaryTypes.String,
+	}
+	require.Len(t, arrowSch.Fields(), len(expected))
+	for i, dt := range expected {
+		assert.True(t, arrow.TypeEqual(dt, arrowSch.Field(i).Type), "field %d: expected %s, got %s", i, dt, arrowSch.Field(i).Type)
+	}
+	assert.False(t, arrowSch.Field(0).Nullable)
+	assert.True(t, arrowSch.Field(1).Nullable)
+}
+
+func TestArrowWriter(t *testing.T) {
+	// write more than one record batch
+	oldBatchSize := RecordBatchSize
+	RecordBatchSize = 2
+	defer func() { RecordBatchSize = oldBatchSize }()
+
+	for _, format := range []IPCFormat{FileFormat, StreamFormat} {
+		sch := arrowTestSchema(t)
+		rows := getSampleRows(t)
+
+		var buf bytes.Buffer
+		wr, err := NewArrowRowWriter(sch, nopWriteCloser{&buf}, format)
+		require.NoError(t, err)
+		for _, r := range rows {
+			require.NoError(t, wr.WriteSqlRow(context.Background(), r))
+		}
+		require.NoError(t, wr.Close(context.Background()))
+		assert.Error(t, wr.Close(context.Background()))
+
+		if format == FileFormat {
+			assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("ARROW1")))
+		}
+
+		// read back from both a seekable reader and a stream
+		for _, r := range []io.ReadCloser{readSeekNopCloser{bytes.NewReader(buf.Bytes())}, io.NopCloser(bytes.NewReader(buf.Bytes()))} {
+			reader, err := NewArrowReader(types.NewMemoryValueStore(), r, sch)
+			require.NoError(t, err)
+			actual := readAllArrow(t, reader)
+			require.NoError(t, reader.Close(context.Background()))
+
+			require.Len(t, actual, len(rows))
+			for i := range rows {
+				for j := range rows[i] {
+					expected := rows[i][j]
+					if expected != nil {
+						expected, _, err = sch.GetAllCols().GetByIndex(j).TypeInfo.ToSqlType().Convert(expected)
+						require.NoError(t, err)
+					}
+					if d, ok := expected.(decimal.Decimal); ok {
+						assert.True(t, d.Equal(actual[i][j].(decimal.Decimal)), "row %d col %d", i, j)
+					} else {
+						assert.Equal(t, expected, actual[i][j], "row %d col %d", i, j)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestArrowReaderMissingColumn(t *testing.T) {
+	sch := arrowTestSchema(t)
+
+	ti, err := typeinfo.FromSqlType(gmstypes.Int32)
+	require.NoError(t, err)
+	col, err := schema.NewColumnWithTypeInfo("other", 0, ti, true, "", false, "")
+	require.NoError(t, err)
+	otherSch, err := schema.SchemaFromCols(schema.NewColCollection(col))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	wr, err := NewArrowRowWriter(sch, nopWriteCloser{&buf}, StreamFormat)
+	require.NoError(t, err)
+	require.NoError(t, wr.Close(context.Background()))
+
+	_, err = NewArrowReader(types.NewMemoryValueStore(), io.NopCloser(&buf), otherSch)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot read column: other Column not found")
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+type readSeekNopCloser struct {
+	*bytes.Reader
+}
+
+func (readSeekNopCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,216 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// JSONLReader reads rows from JSON Lines (also known as newline delimited JSON) input, where each line holds a single
+// JSON object. Unlike JSONReader, rows are read one line at a time, so inputs of any size can be streamed.
+type JSONLReader struct {
+	vrw       types.ValueReadWriter
+	closer    io.Closer
+	sch       schema.Schema
+	rd        *bufio.Reader
+	line      int
+	sampleRow sql.Row
+}
+
+var _ table.SqlTableReader = (*JSONLReader)(nil)
+
+func OpenJSONLReader(vrw types.ValueReadWriter, path string, fs filesys.ReadableFS, sch schema.Schema) (*JSONLReader, error) {
+	r, err := fs.OpenForRead(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewJSONLReader(vrw, r, sch)
+}
+
+// NewJSONLReader returns a reader for the JSON Lines read from |r|. Byte order marks are handled as they are by
+// NewJSONReader.
+func NewJSONLReader(vrw types.ValueReadWriter, r io.ReadCloser, sch schema.Schema) (*JSONLReader, error) {
+	if sch == nil {
+		return nil, errors.New("schema must be provided to JsonlReader")
+	}
+
+	textReader := transform.NewReader(r, unicode.BOMOverride(unicode.UTF8.NewDecoder()))
+
+	return &JSONLReader{vrw: vrw, closer: r, sch: sch, rd: bufio.NewReaderSize(textReader, ReadBufSize)}, nil
+}
+
+// Close should release resources being held
+func (r *JSONLReader) Close(ctx context.Context) error {
+	if r.closer != nil {
+		err := r.closer.Close()
+		r.closer = nil
+
+		return err
+	}
+	return errors.New("already closed")
+}
+
+// GetSchema gets the schema of the rows that this reader will return
+func (r *JSONLReader) GetSchema() schema.Schema {
+	return r.sch
+}
+
+// VerifySchema checks that the incoming schema matches the schema from the existing table
+func (r *JSONLReader) VerifySchema(sch schema.Schema) (bool, error) {
+	if r.sampleRow == nil {
+		var err error
+		r.sampleRow, err = r.ReadSqlRow(context.Background())
+		return err == nil, nil
+	}
+	return true, nil
+}
+
+func (r *JSONLReader) ReadRow(ctx context.Context) (row.Row, error) {
+	panic("deprecated")
+}
+
+func (r *JSONLReader) ReadSqlRow(ctx context.Context) (sql.Row, error) {
+	if r.sampleRow != nil {
+		ret := r.sampleRow
+		r.sampleRow = nil
+		return ret, nil
+	}
+
+	for {
+		line, err := r.rd.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		if len(line) == 0 && err == io.EOF {
+			return nil, io.EOF
+		}
+		r.line++
+
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		rowMap, decErr := decodeJSONLine(line)
+		if decErr != nil {
+			return nil, fmt.Errorf("error reading line %d: %w", r.line, decErr)
+		}
+
+		ret, convErr := r.convToSqlRow(rowMap)
+		if convErr != nil {
+			return nil, fmt.Errorf("error reading line %d: %w", r.line, convErr)
+		}
+		return ret, nil
+	}
+}
+
+// decodeJSONLine decodes a single line of JSON Lines input, which must hold exactly one JSON object. Numbers are
+// decoded as json.Number so that integers too large to be represented exactly as a float64 keep their precision.
+func decodeJSONLine(line []byte) (map[string]interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(line))
+	dec.UseNumber()
+
+	var val interface{}
+	if err := dec.Decode(&val); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, errors.New("unexpected data after JSON object")
+	}
+
+	rowMap, ok := val.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("unexpected JSON format received, expected one JSON object per line")
+	}
+	return rowMap, nil
+}
+
+func (r *JSONLReader) convToSqlRow(rowMap map[string]interface{}) (sql.Row, error) {
+	allCols := r.sch.GetAllCols()
+
+	ret := make(sql.Row, allCols.Size())
+	for k, v := range rowMap {
+		col, ok := allCols.GetByName(k)
+		if !ok {
+			return nil, fmt.Errorf("column %s not found in schema", k)
+		}
+
+		if n, ok := v.(json.Number); ok && col.TypeInfo.GetTypeIdentifier() == typeinfo.DecimalTypeIdentifier {
+			// decimals are converted from their text so that no precision is lost
+			v = n.String()
+		} else {
+			v = jsonNumbersToGo(v)
+		}
+
+		v, _, err := col.TypeInfo.ToSqlType().Convert(v)
+		if err != nil {
+			return nil, err
+		}
+
+		idx := allCols.TagToIdx[col.Tag]
+		ret[idx] = v
+	}
+
+	return ret, nil
+}
+
+// jsonNumbersToGo replaces the json.Number values within |v| with an int64, uint64 or float64, depending on which can
+// represent them.
+func jsonNumbersToGo(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		if u, err := strconv.ParseUint(v.String(), 10, 64); err == nil {
+			return u
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	case map[string]interface{}:
+		for k, elem := range v {
+			v[k] = jsonNumbersToGo(elem)
+		}
+		return v
+	case []interface{}:
+		for i, elem := range v {
+			v[i] = jsonNumbersToGo(elem)
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func jsonlTestSchema(t *testing.T) schema.Schema {
+	colColl := schema.NewColCollection(
+		schema.Column{
+			Name:       "id",
+			Tag:        0,
+			Kind:       types.IntKind,
+			IsPartOfPK: true,
+			TypeInfo:   typeinfo.Int64Type,
+		},
+		schema.Column{
+			Name:     "name",
+			Tag:      1,
+			Kind:     types.StringKind,
+			TypeInfo: typeinfo.StringDefaultType,
+		},
+		schema.Column{
+			Name:     "attrs",
+			Tag:      2,
+			Kind:     types.JSONKind,
+			TypeInfo: typeinfo.JSONType,
+		},
+	)
+
+	sch, err := schema.SchemaFromCols(colColl)
+	require.NoError(t, err)
+	return sch
+}
+
+func readAllJSONL(t *testing.T, reader *JSONLReader) ([]sql.Row, error) {
+	var rows []sql.Row
+	for {
+		r, err := reader.ReadSqlRow(context.Background())
+		if err == io.EOF {
+			return rows, nil
+		} else if err != nil {
+			return rows, err
+		}
+		rows = append(rows, r)
+	}
+}
+
+func TestJSONLReader(t *testing.T) {
+	testJSONL := `{"id": 9007199254740993, "name": "tim", "attrs": {"tags": ["a", "b"], "n": 1}}
+
+{"id": 1, "name": "brian"}
+{"id": 2, "attrs": [1.5, null]}`
+
+	fs := filesys.EmptyInMemFS("/")
+	require.NoError(t, fs.WriteFile("file.jsonl", []byte(testJSONL), os.ModePerm))
+
+	sch := jsonlTestSchema(t)
+	reader, err := OpenJSONLReader(types.NewMemoryValueStore(), "file.jsonl", fs, sch)
+	require.NoError(t, err)
+
+	verifySchema, err := reader.VerifySchema(sch)
+	require.NoError(t, err)
+	assert.True(t, verifySchema)
+
+	rows, err := readAllJSONL(t, reader)
+	require.NoError(t, err)
+	require.NoError(t, reader.Close(context.Background()))
+	require.Len(t, rows, 3)
+
+	assert.Equal(t, int64(9007199254740993), rows[0][0])
+	assert.Equal(t, "tim", rows[0][1])
+	attrs, err := rows[0][2].(sql.JSONWrapper).ToInterface()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"tags": []interface{}{"a", "b"}, "n": float64(1)}, attrs)
+
+	assert.Equal(t, int64(1), rows[1][0])
+	assert.Equal(t, "brian", rows[1][1])
+	assert.Nil(t, rows[1][2])
+
+	assert.Equal(t, int64(2), rows[2][0])
+	assert.Nil(t, rows[2][1])
+	attrs, err = rows[2][2].(sql.JSONWrapper).ToInterface()
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{1.5, nil}, attrs)
+}
+
+func TestJSONLReaderErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		jsonl string
+		err   string
+	}{
+		{
+			name:  "invalid json",
+			jsonl: "{\"id\": 0}\n{\"id\": 1,\n",
+			err:   "error reading line 2",
+		},
+		{
+			name:  "not an object",
+			jsonl: "{\"id\": 0}\n\n[1, 2]\n",
+			err:   "error reading line 3: unexpected JSON format received",
+		},
+		{
+			name:  "two objects on a line",
+			jsonl: "{\"id\": 0} {\"id\": 1}\n",
+			err:   "error reading line 1: unexpected data after JSON object",
+		},
+		{
+			name:  "unknown column",
+			jsonl: "{\"id\": 0, \"other\": 1}\n",
+			err:   "error reading line 1: column other not found in schema",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reader, err := NewJSONLReader(types.NewMemoryValueStore(), io.NopCloser(strings.NewReader(test.jsonl)), jsonlTestSchema(t))
+			require.NoError(t, err)
+			_, err = readAllJSONL(t, reader)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.err)
+		})
+	}
+}
+
+func TestJSONLWriter(t *testing.T) {
+	sch := jsonlTestSchema(t)
+	sqlSch, err := sqlutil.FromDoltSchema("", "", sch)
+	require.NoError(t, err)
+
+	var sb strings.Builder
+	wr, err := NewJSONLSqlWriter(nopWriteCloser{&sb}, sqlSch.Schema)
+	require.NoError(t, err)
+	require.NoError(t, wr.WriteSqlRow(context.Background(), sql.Row{int64(1), "a\nb", nil}))
+	require.NoError(t, wr.WriteSqlRow(context.Background(), sql.Row{int64(2), nil, nil}))
+	require.NoError(t, wr.Close(context.Background()))
+
+	assert.Equal(t, "{\"id\":1,\"name\":\"a\\nb\"}\n{\"id\":2}\n", sb.String())
+
+	// the written rows can be read back
+	reader, err := NewJSONLReader(types.NewMemoryValueStore(), io.NopCloser(strings.NewReader(sb.String())), sch)
+	require.NoError(t, err)
+	rows, err := readAllJSONL(t, reader)
+	require.NoError(t, err)
+	assert.Equal(t, []sql.Row{{int64(1), "a\nb", nil}, {int64(2), nil, nil}}, rows)
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
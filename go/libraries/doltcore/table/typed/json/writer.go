@@ -71,6 +71,24 @@ func NewJSONSqlWriter(wr io.WriteCloser, sch sql.Schema) (*RowWriter, error) {
 	return w, nil
 }
 
+// NewJSONLWriter returns a new writer that encodes rows as JSON Lines, with each row written as a JSON object on its
+// own line.
+func NewJSONLWriter(wr io.WriteCloser, outSch schema.Schema) (*RowWriter, error) {
+	return NewJSONWriterWithHeader(wr, outSch, "", "\n", "\n")
+}
+
+// NewJSONLSqlWriter returns a new writer that encodes rows as JSON Lines, with each row written as a JSON object on its
+// own line.
+func NewJSONLSqlWriter(wr io.WriteCloser, sch sql.Schema) (*RowWriter, error) {
+	w, err := NewJSONLWriter(wr, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	w.sqlSch = sch
+	return w, nil
+}
+
 func NewJSONWriterWithHeader(wr io.WriteCloser, outSch schema.Schema, header, footer, separator string) (*RowWriter, error) {
 	bwr := bufio.NewWriterSize(wr, WriteBufSize)
 	return &RowWriter{
@@ -0,0 +1,197 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	gosql "database/sql"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	gomysql "github.com/go-sql-driver/mysql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+)
+
+// MySQLReader reads the rows of a table or of a query from a MySQL server, or any server which speaks the MySQL
+// protocol. Rows are streamed from the server as they are read, and their values are returned as strings, which are
+// converted to the types of the table being written to.
+type MySQLReader struct {
+	db    *gosql.DB
+	query string
+	sch   schema.Schema
+	// bitCols holds whether each column is a BIT column, whose values are sent as big-endian bytes.
+	bitCols []bool
+
+	rows *gosql.Rows
+	vals []gosql.RawBytes
+	ptrs []interface{}
+}
+
+var _ table.SqlTableReader = (*MySQLReader)(nil)
+
+// OpenMySQLReader connects to the server at |dsn|, which is a data source name in the format of
+// github.com/go-sql-driver/mysql and must name a database, and returns a reader for either |tableName|, whose schema is
+// read from the server's information_schema, or |query|, whose schema is taken from its result set.
+func OpenMySQLReader(ctx context.Context, dsn, tableName, query string) (*MySQLReader, error) {
+	if (tableName == "") == (query == "") {
+		return nil, errors.New("exactly one of a table or a query must be given to read from a MySQL server")
+	}
+
+	if lower := strings.ToLower(dsn); strings.HasPrefix(lower, "postgres://") || strings.HasPrefix(lower, "postgresql://") {
+		return nil, errors.New("PostgreSQL servers are not supported, rows can only be read from servers which speak the MySQL protocol")
+	}
+
+	cfg, err := gomysql.ParseDSN(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MySQL data source name: %w", err)
+	}
+	if cfg.DBName == "" {
+		return nil, fmt.Errorf("the MySQL data source name must name a database, as in user:password@tcp(host:port)/database")
+	}
+
+	connector, err := gomysql.NewConnector(cfg)
+	if err != nil {
+		return nil, err
+	}
+	db := gosql.OpenDB(connector)
+	if err = db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("unable to connect to MySQL server: %w", err)
+	}
+
+	var sch schema.Schema
+	if tableName != "" {
+		sch, err = SchemaForTable(ctx, db, cfg.DBName, tableName)
+		if err == nil {
+			query = selectTableQuery(sch, tableName)
+		}
+	} else {
+		sch, err = schemaForQuery(ctx, db, query)
+	}
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	cols := sch.GetAllCols().GetColumns()
+	bitCols := make([]bool, len(cols))
+	for i, col := range cols {
+		bitCols[i] = col.TypeInfo.GetTypeIdentifier() == typeinfo.BitTypeIdentifier
+	}
+
+	return &MySQLReader{db: db, query: query, sch: sch, bitCols: bitCols}, nil
+}
+
+// selectTableQuery returns a query selecting the columns of |sch| from |tableName|.
+func selectTableQuery(sch schema.Schema, tableName string) string {
+	cols := sch.GetAllCols().GetColumns()
+	names := make([]string, len(cols))
+	for i, col := range cols {
+		names[i] = sql.QuoteIdentifier(col.Name)
+	}
+	return fmt.Sprintf("SELECT %s FROM %s", strings.Join(names, ", "), sql.QuoteIdentifier(tableName))
+}
+
+// ReadRow reads a row from a table. If there is a bad row the returned error will be non nil, and calling
+// IsBadRow(err) will be return true. This is a potentially non-fatal error and callers can decide if they want to
+// continue on a bad row, or fail.
+func (r *MySQLReader) ReadRow(ctx context.Context) (row.Row, error) {
+	panic("deprecated")
+}
+
+func (r *MySQLReader) ReadSqlRow(ctx context.Context) (sql.Row, error) {
+	if r.rows == nil {
+		// the query is run when the first row is read, so that a reader which is only used for its schema doesn't
+		// read the rows
+		rows, err := r.db.QueryContext(ctx, r.query)
+		if err != nil {
+			return nil, err
+		}
+		cols, err := rows.Columns()
+		if err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		if len(cols) != len(r.bitCols) {
+			_ = rows.Close()
+			return nil, fmt.Errorf("expected %d columns from MySQL server, but received %d", len(r.bitCols), len(cols))
+		}
+
+		r.rows = rows
+		r.vals = make([]gosql.RawBytes, len(cols))
+		r.ptrs = make([]interface{}, len(cols))
+		for i := range r.vals {
+			r.ptrs[i] = &r.vals[i]
+		}
+	}
+
+	if !r.rows.Next() {
+		if err := r.rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+	if err := r.rows.Scan(r.ptrs...); err != nil {
+		return nil, err
+	}
+
+	out := make(sql.Row, len(r.vals))
+	for i, v := range r.vals {
+		if v == nil {
+			continue
+		}
+		if r.bitCols[i] {
+			// bits are read as strings of their value, as they are from other sources
+			var buf [8]byte
+			copy(buf[8-len(v):], v)
+			out[i] = strconv.FormatUint(binary.BigEndian.Uint64(buf[:]), 10)
+		} else {
+			out[i] = string(v)
+		}
+	}
+	return out, nil
+}
+
+// GetSchema gets the schema of the rows that this reader will return
+func (r *MySQLReader) GetSchema() schema.Schema {
+	return r.sch
+}
+
+// Close should release resources being held
+func (r *MySQLReader) Close(ctx context.Context) error {
+	if r.db == nil {
+		return errors.New("already closed")
+	}
+
+	var err error
+	if r.rows != nil {
+		err = r.rows.Close()
+		r.rows = nil
+	}
+	if cerr := r.db.Close(); err == nil {
+		err = cerr
+	}
+	r.db = nil
+	return err
+}
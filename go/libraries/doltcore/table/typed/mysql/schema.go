@@ -0,0 +1,323 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	gosql "database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql/planbuilder"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
+)
+
+const columnsQuery = `SELECT COLUMN_NAME, COLUMN_TYPE, IS_NULLABLE, COLUMN_DEFAULT, COLLATION_NAME, EXTRA, COLUMN_COMMENT
+FROM information_schema.COLUMNS
+WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?
+ORDER BY ORDINAL_POSITION`
+
+const primaryKeyQuery = `SELECT COLUMN_NAME
+FROM information_schema.KEY_COLUMN_USAGE
+WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? AND CONSTRAINT_NAME = 'PRIMARY'
+ORDER BY ORDINAL_POSITION`
+
+const indexesQuery = `SELECT INDEX_NAME, NON_UNIQUE, COLUMN_NAME, SUB_PART, INDEX_TYPE, INDEX_COMMENT
+FROM information_schema.STATISTICS
+WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? AND INDEX_NAME <> 'PRIMARY'
+ORDER BY INDEX_NAME, SEQ_IN_INDEX`
+
+// SchemaForTable returns the schema of |tableName| in |dbName|, read from the information_schema of the server |db| is
+// connected to. Column types, including their lengths, precisions, collations and enum and set values, column defaults
+// and secondary indexes are taken from the server's definition of the table. Generated columns are not included, and
+// neither are fulltext indexes and indexes on expressions or generated columns.
+func SchemaForTable(ctx context.Context, db *gosql.DB, dbName, tableName string) (schema.Schema, error) {
+	var pkNames []string
+	pks := make(map[string]bool)
+	pkRows, err := db.QueryContext(ctx, primaryKeyQuery, dbName, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer pkRows.Close()
+	for pkRows.Next() {
+		var name string
+		if err = pkRows.Scan(&name); err != nil {
+			return nil, err
+		}
+		pkNames = append(pkNames, name)
+		pks[name] = true
+	}
+	if err = pkRows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, columnsQuery, dbName, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cols []schema.Column
+	for rows.Next() {
+		var name, colType, nullable, extra, comment string
+		var deflt, collation gosql.NullString
+		if err = rows.Scan(&name, &colType, &nullable, &deflt, &collation, &extra, &comment); err != nil {
+			return nil, err
+		}
+
+		extra = strings.ToLower(extra)
+		if isGeneratedColumn(extra) {
+			continue
+		}
+
+		if collation.Valid {
+			colType += " COLLATE " + collation.String
+		}
+		sqlType, err := planbuilder.ParseColumnTypeString(colType)
+		if err != nil {
+			return nil, fmt.Errorf("unable to map type %s of column %s: %w", colType, name, err)
+		}
+		ti, err := typeinfo.FromSqlType(sqlType)
+		if err != nil {
+			return nil, fmt.Errorf("unable to map type %s of column %s: %w", colType, name, err)
+		}
+
+		var constraints []schema.ColConstraint
+		if nullable == "NO" {
+			constraints = append(constraints, schema.NotNullConstraint{})
+		}
+		col, err := schema.NewColumnWithTypeInfo(name, uint64(len(cols)), ti, pks[name], columnDefault(deflt, extra, ti), strings.Contains(extra, "auto_increment"), comment, constraints...)
+		if err != nil {
+			return nil, err
+		}
+		cols = append(cols, col)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(cols) == 0 {
+		return nil, fmt.Errorf("table %s was not found in database %s of the MySQL server", tableName, dbName)
+	}
+
+	sch, err := schema.SchemaFromCols(schema.NewColCollection(cols...))
+	if err != nil {
+		return nil, err
+	}
+
+	// the primary key's columns may be in a different order than the table's columns
+	ordinals := make([]int, len(pkNames))
+	for i, name := range pkNames {
+		ordinals[i] = sch.GetAllCols().IndexOf(name)
+		if ordinals[i] < 0 {
+			return nil, fmt.Errorf("primary key column %s of table %s is a generated column, which can't be imported", name, tableName)
+		}
+	}
+	if err = sch.SetPkOrdinals(ordinals); err != nil {
+		return nil, err
+	}
+
+	if err = addIndexes(ctx, db, dbName, tableName, sch); err != nil {
+		return nil, err
+	}
+	return sch, nil
+}
+
+// columnDefault returns the default of a column as an expression, given its COLUMN_DEFAULT and lower cased EXTRA in
+// information_schema.COLUMNS, which report literal defaults as their values and expression defaults, marked
+// DEFAULT_GENERATED, as their expressions.
+func columnDefault(deflt gosql.NullString, extra string, ti typeinfo.TypeInfo) string {
+	if !deflt.Valid {
+		return ""
+	}
+	// older servers don't mark CURRENT_TIMESTAMP defaults as DEFAULT_GENERATED
+	upper := strings.ToUpper(deflt.String)
+	if strings.Contains(extra, "default_generated") || strings.HasPrefix(upper, "CURRENT_TIMESTAMP") {
+		return deflt.String
+	}
+
+	switch ti.GetTypeIdentifier() {
+	case typeinfo.IntTypeIdentifier, typeinfo.UintTypeIdentifier, typeinfo.FloatTypeIdentifier,
+		typeinfo.DecimalTypeIdentifier, typeinfo.YearTypeIdentifier:
+		return deflt.String
+	case typeinfo.BitTypeIdentifier:
+		// bits are reported as b'101'
+		if strings.HasPrefix(deflt.String, "b'") {
+			return deflt.String
+		}
+	case typeinfo.VarBinaryTypeIdentifier, typeinfo.InlineBlobTypeIdentifier:
+		// binary strings are reported as 0x616263
+		if strings.HasPrefix(deflt.String, "0x") {
+			return deflt.String
+		}
+	}
+	return sqlfmt.QuoteString(deflt.String)
+}
+
+// addIndexes adds the secondary indexes of |tableName| in |dbName|, read from information_schema.STATISTICS, to |sch|.
+func addIndexes(ctx context.Context, db *gosql.DB, dbName, tableName string, sch schema.Schema) error {
+	type indexDef struct {
+		name          string
+		cols          []string
+		prefixLengths []uint16
+		props         schema.IndexProperties
+		skip          bool
+	}
+	var defs []*indexDef
+
+	rows, err := db.QueryContext(ctx, indexesQuery, dbName, tableName)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name, indexType, comment string
+		var nonUnique int
+		var colName gosql.NullString
+		var subPart gosql.NullInt64
+		if err = rows.Scan(&name, &nonUnique, &colName, &subPart, &indexType, &comment); err != nil {
+			return err
+		}
+
+		if len(defs) == 0 || defs[len(defs)-1].name != name {
+			defs = append(defs, &indexDef{
+				name: name,
+				props: schema.IndexProperties{
+					IsUnique:      nonUnique == 0,
+					IsSpatial:     strings.EqualFold(indexType, "SPATIAL"),
+					IsUserDefined: true,
+					Comment:       comment,
+				},
+			})
+		}
+		def := defs[len(defs)-1]
+
+		switch strings.ToUpper(indexType) {
+		case "BTREE", "HASH", "SPATIAL":
+		default:
+			def.skip = true
+		}
+		// expressions have no column name, and generated columns aren't imported
+		if !colName.Valid || sch.GetAllCols().IndexOf(colName.String) < 0 {
+			def.skip = true
+			continue
+		}
+		def.cols = append(def.cols, colName.String)
+		def.prefixLengths = append(def.prefixLengths, uint16(subPart.Int64))
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	for _, def := range defs {
+		if def.skip {
+			continue
+		}
+		prefixLengths := def.prefixLengths
+		if !hasPrefixLength(prefixLengths) {
+			prefixLengths = nil
+		}
+		if _, err = sch.Indexes().AddIndexByColNames(def.name, def.cols, prefixLengths, def.props); err != nil {
+			return fmt.Errorf("unable to import index %s of table %s: %w", def.name, tableName, err)
+		}
+	}
+	return nil
+}
+
+// hasPrefixLength returns whether any column of an index has a prefix length.
+func hasPrefixLength(prefixLengths []uint16) bool {
+	for _, l := range prefixLengths {
+		if l > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// isGeneratedColumn returns whether the EXTRA of a column in information_schema.COLUMNS, lower cased, is that of a
+// generated column. Columns with an expression default, like DEFAULT CURRENT_TIMESTAMP, are reported as
+// DEFAULT_GENERATED, and aren't generated columns.
+func isGeneratedColumn(extra string) bool {
+	return strings.Contains(extra, "virtual generated") || strings.Contains(extra, "stored generated")
+}
+
+// schemaForQuery returns the schema of the rows of |query|, using the column types of an empty result set of the query.
+// Result sets don't include the length of string columns, so they are mapped to default length varchars.
+func schemaForQuery(ctx context.Context, db *gosql.DB, query string) (schema.Schema, error) {
+	query = strings.TrimRight(strings.TrimSpace(query), ";")
+	rows, err := db.QueryContext(ctx, fmt.Sprintf("SELECT * FROM (%s) AS query LIMIT 0", query))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	colTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	cols := make([]schema.Column, len(colTypes))
+	for i, ct := range colTypes {
+		ti, err := typeInfoForColumnType(ct)
+		if err != nil {
+			return nil, fmt.Errorf("unable to map type %s of column %s: %w", ct.DatabaseTypeName(), ct.Name(), err)
+		}
+
+		var constraints []schema.ColConstraint
+		if nullable, ok := ct.Nullable(); ok && !nullable {
+			constraints = append(constraints, schema.NotNullConstraint{})
+		}
+		cols[i], err = schema.NewColumnWithTypeInfo(ct.Name(), uint64(i), ti, false, "", false, "", constraints...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return schema.SchemaFromCols(schema.NewColCollection(cols...))
+}
+
+// typeInfoForColumnType maps the type of a column of a result set to a TypeInfo.
+func typeInfoForColumnType(ct *gosql.ColumnType) (typeinfo.TypeInfo, error) {
+	name := ct.DatabaseTypeName()
+	switch name {
+	case "CHAR", "VARCHAR", "ENUM", "SET", "NULL":
+		return typeinfo.StringDefaultType, nil
+	case "BINARY", "VARBINARY":
+		return typeinfo.VarbinaryDefaultType, nil
+	case "BIT":
+		name = "BIT(64)"
+	case "DECIMAL":
+		if precision, scale, ok := ct.DecimalSize(); ok {
+			name = fmt.Sprintf("DECIMAL(%d, %d)", precision, scale)
+		}
+	case "DATETIME", "TIMESTAMP", "TIME":
+		if precision, _, ok := ct.DecimalSize(); ok && precision > 0 {
+			name = fmt.Sprintf("%s(%d)", name, precision)
+		}
+	default:
+		if unsigned, ok := strings.CutPrefix(name, "UNSIGNED "); ok {
+			name = unsigned + " UNSIGNED"
+		}
+	}
+
+	sqlType, err := planbuilder.ParseColumnTypeString(name)
+	if err != nil {
+		return nil, err
+	}
+	return typeinfo.FromSqlType(sqlType)
+}
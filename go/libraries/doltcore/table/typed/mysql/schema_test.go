@@ -0,0 +1,71 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	gosql "database/sql"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+)
+
+func TestIsGeneratedColumn(t *testing.T) {
+	tests := []struct {
+		extra     string
+		generated bool
+	}{
+		{"", false},
+		{"auto_increment", false},
+		// DEFAULT CURRENT_TIMESTAMP
+		{"DEFAULT_GENERATED", false},
+		// DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+		{"DEFAULT_GENERATED on update CURRENT_TIMESTAMP", false},
+		{"VIRTUAL GENERATED", true},
+		{"STORED GENERATED", true},
+	}
+	for _, test := range tests {
+		t.Run(test.extra, func(t *testing.T) {
+			assert.Equal(t, test.generated, isGeneratedColumn(strings.ToLower(test.extra)))
+		})
+	}
+}
+
+func TestColumnDefault(t *testing.T) {
+	tests := []struct {
+		name     string
+		deflt    gosql.NullString
+		extra    string
+		ti       typeinfo.TypeInfo
+		expected string
+	}{
+		{"no default", gosql.NullString{}, "", typeinfo.Int32Type, ""},
+		{"int", gosql.NullString{String: "18", Valid: true}, "", typeinfo.Int32Type, "18"},
+		{"float", gosql.NullString{String: "1.5", Valid: true}, "", typeinfo.Float64Type, "1.5"},
+		{"string", gosql.NullString{String: "it's", Valid: true}, "", typeinfo.StringDefaultType, "'it\\'s'"},
+		{"empty string", gosql.NullString{String: "", Valid: true}, "", typeinfo.StringDefaultType, "''"},
+		{"binary", gosql.NullString{String: "0x7879", Valid: true}, "", typeinfo.VarbinaryDefaultType, "0x7879"},
+		{"expression", gosql.NullString{String: "(5 * 2)", Valid: true}, "default_generated", typeinfo.Int32Type, "(5 * 2)"},
+		{"current timestamp", gosql.NullString{String: "CURRENT_TIMESTAMP", Valid: true}, "default_generated", typeinfo.TimestampType, "CURRENT_TIMESTAMP"},
+		{"unmarked current timestamp", gosql.NullString{String: "CURRENT_TIMESTAMP", Valid: true}, "", typeinfo.TimestampType, "CURRENT_TIMESTAMP"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, columnDefault(test.deflt, test.extra, test.ti))
+		})
+	}
+}
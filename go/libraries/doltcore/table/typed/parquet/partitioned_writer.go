@@ -0,0 +1,181 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/xitongsys/parquet-go-source/local"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+)
+
+// HiveDefaultPartition is the name of the partition of the rows whose partition column is NULL.
+const HiveDefaultPartition = "__HIVE_DEFAULT_PARTITION__"
+
+// PartitionedParquetWriter writes rows to a Hive-style layout of parquet files under a directory. The rows with each
+// value of the partition column are written, without the partition column, to |dir|/<column>=<value>/part-<n>.parquet.
+// Rows are expected to be ordered by the partition column; a partition whose rows aren't contiguous is split across
+// several files. Values whose partition directories differ only in case are rejected, since they would share a
+// directory on case-insensitive file systems.
+type PartitionedParquetWriter struct {
+	dir      string
+	partIdx  int
+	partCol  *sql.Column
+	outSch   sql.Schema
+	opts     WriterOptions
+	parts    map[string]int
+	folded   map[string]string
+	files    []string
+	curr     *ParquetRowWriter
+	currPart string
+}
+
+var _ table.SqlRowWriter = (*PartitionedParquetWriter)(nil)
+
+// NewPartitionedParquetWriter creates a PartitionedParquetWriter writing the rows of |sch| under |dir|, partitioned
+// by the column |partCol|.
+func NewPartitionedParquetWriter(sch sql.Schema, dir, partCol string, opts WriterOptions) (*PartitionedParquetWriter, error) {
+	partIdx := sch.IndexOfColName(partCol)
+	if partIdx < 0 {
+		return nil, fmt.Errorf("partition column %s not found", partCol)
+	}
+	if len(sch) == 1 {
+		return nil, fmt.Errorf("no columns are left to write after partitioning by %s", partCol)
+	}
+	if _, err := opts.compressionCodec(); err != nil {
+		return nil, err
+	}
+
+	outSch := make(sql.Schema, 0, len(sch)-1)
+	outSch = append(outSch, sch[:partIdx]...)
+	outSch = append(outSch, sch[partIdx+1:]...)
+
+	return &PartitionedParquetWriter{
+		dir:     dir,
+		partIdx: partIdx,
+		partCol: sch[partIdx],
+		outSch:  outSch,
+		opts:    opts,
+		parts:   make(map[string]int),
+		folded:  make(map[string]string),
+	}, nil
+}
+
+// WriteSqlRow writes the row given to the file of its partition.
+func (pw *PartitionedParquetWriter) WriteSqlRow(ctx context.Context, r sql.Row) error {
+	part, err := pw.partitionDir(r[pw.partIdx])
+	if err != nil {
+		return err
+	}
+
+	if pw.curr == nil || part != pw.currPart {
+		if err = pw.closeCurrent(ctx); err != nil {
+			return err
+		}
+		if err = pw.openPartition(part); err != nil {
+			return err
+		}
+	}
+
+	out := make(sql.Row, 0, len(r)-1)
+	out = append(out, r[:pw.partIdx]...)
+	out = append(out, r[pw.partIdx+1:]...)
+	return pw.curr.WriteSqlRow(ctx, out)
+}
+
+// Files returns the paths of the files written.
+func (pw *PartitionedParquetWriter) Files() []string {
+	return pw.files
+}
+
+// Close finishes the file being written.
+func (pw *PartitionedParquetWriter) Close(ctx context.Context) error {
+	return pw.closeCurrent(ctx)
+}
+
+func (pw *PartitionedParquetWriter) openPartition(part string) error {
+	folded := strings.ToLower(part)
+	if other, ok := pw.folded[folded]; ok && other != part {
+		return fmt.Errorf("partitions %s and %s of column %s differ only in case, and can't be written to separate directories", other, part, pw.partCol.Name)
+	}
+	pw.folded[folded] = part
+
+	partDir := filepath.Join(pw.dir, part)
+	if err := os.MkdirAll(partDir, os.ModePerm); err != nil {
+		return err
+	}
+
+	path := filepath.Join(partDir, fmt.Sprintf("part-%d.parquet", pw.parts[part]))
+	fw, err := local.NewLocalFileWriter(path)
+	if err != nil {
+		return err
+	}
+	wr, err := NewParquetRowWriterWithOptions(pw.outSch, fw, pw.opts)
+	if err != nil {
+		fw.Close()
+		return err
+	}
+
+	pw.parts[part]++
+	pw.files = append(pw.files, path)
+	pw.curr = wr
+	pw.currPart = part
+	return nil
+}
+
+func (pw *PartitionedParquetWriter) closeCurrent(ctx context.Context) error {
+	if pw.curr == nil {
+		return nil
+	}
+	err := pw.curr.Close(ctx)
+	pw.curr = nil
+	return err
+}
+
+// partitionDir returns the name of the directory of the partition of the rows whose partition column is |val|.
+func (pw *PartitionedParquetWriter) partitionDir(val interface{}) (string, error) {
+	if val == nil {
+		return escapePartitionPath(pw.partCol.Name) + "=" + HiveDefaultPartition, nil
+	}
+	str, err := sqlutil.SqlColToStr(pw.partCol.Type, val)
+	if err != nil {
+		return "", err
+	}
+	if str == "" {
+		return escapePartitionPath(pw.partCol.Name) + "=" + HiveDefaultPartition, nil
+	}
+	return escapePartitionPath(pw.partCol.Name) + "=" + escapePartitionPath(str), nil
+}
+
+// escapePartitionPath escapes the characters of |s| which Hive escapes in the names of partition directories.
+func escapePartitionPath(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c < 0x20 || c == 0x7f || strings.IndexByte("\"#%'*/:=?\\{[]^", c) >= 0 {
+			fmt.Fprintf(&sb, "%%%02X", c)
+		} else {
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+)
+
+func TestPartitionedParquetWriter(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	sqlSch, err := sqlutil.FromDoltSchema("", "people", rowSch)
+	require.NoError(t, err)
+
+	pw, err := NewPartitionedParquetWriter(sqlSch.Schema, dir, titleColName, WriterOptions{Compression: "zstd", RowGroupSize: 1024})
+	require.NoError(t, err)
+
+	rows := []sql.Row{
+		{"Andy Anderson", uint64(27), nil},
+		{"John Johnson", uint64(21), ""},
+		{"Rob Robertson", uint64(25), "Dufus"},
+		{"Bill Billerson", uint64(32), "Senior/Dufus"},
+		{"Ted Tedson", uint64(40), "Dufus"},
+	}
+	for _, r := range rows {
+		require.NoError(t, pw.WriteSqlRow(ctx, r))
+	}
+	require.NoError(t, pw.Close(ctx))
+
+	assert.Equal(t, []string{
+		filepath.Join(dir, "title="+HiveDefaultPartition, "part-0.parquet"),
+		filepath.Join(dir, "title=Dufus", "part-0.parquet"),
+		filepath.Join(dir, "title=Senior%2FDufus", "part-0.parquet"),
+		filepath.Join(dir, "title=Dufus", "part-1.parquet"),
+	}, pw.Files())
+
+	sch, err := SchemaFromFile(pw.Files()[0])
+	require.NoError(t, err)
+	assert.Equal(t, 2, sch.GetAllCols().Size())
+
+	pRd, err := OpenParquetReader(nil, pw.Files()[0], sch)
+	require.NoError(t, err)
+	defer pRd.Close(ctx)
+
+	var read []sql.Row
+	for {
+		r, err := pRd.ReadSqlRow(ctx)
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		read = append(read, r)
+	}
+	assert.Equal(t, []sql.Row{{"Andy Anderson", int64(27)}, {"John Johnson", int64(21)}}, read)
+}
+
+func TestPartitionedParquetWriterErrors(t *testing.T) {
+	sqlSch, err := sqlutil.FromDoltSchema("", "people", rowSch)
+	require.NoError(t, err)
+
+	_, err = NewPartitionedParquetWriter(sqlSch.Schema, t.TempDir(), "missing", WriterOptions{})
+	assert.Error(t, err)
+
+	_, err = NewPartitionedParquetWriter(sqlSch.Schema, t.TempDir(), titleColName, WriterOptions{Compression: "brotli"})
+	assert.Error(t, err)
+
+	_, err = NewPartitionedParquetWriter(sqlSch.Schema[2:], t.TempDir(), titleColName, WriterOptions{})
+	assert.Error(t, err)
+
+	ctx := context.Background()
+	pw, err := NewPartitionedParquetWriter(sqlSch.Schema, t.TempDir(), titleColName, WriterOptions{})
+	require.NoError(t, err)
+	require.NoError(t, pw.WriteSqlRow(ctx, sql.Row{"Rob Robertson", uint64(25), "Dufus"}))
+	err = pw.WriteSqlRow(ctx, sql.Row{"Ted Tedson", uint64(40), "dufus"})
+	assert.ErrorContains(t, err, "differ only in case")
+	require.NoError(t, pw.Close(ctx))
+}
+
+func TestEscapePartitionPath(t *testing.T) {
+	assert.Equal(t, "us-east", escapePartitionPath("us-east"))
+	assert.Equal(t, "a%2Fb%3Dc", escapePartitionPath("a/b=c"))
+	assert.Equal(t, "100%25 %3A%0A", escapePartitionPath("100% :\n"))
+}
@@ -221,13 +221,19 @@ func (pr *ParquetReader) ReadSqlRow(ctx context.Context) (sql.Row, error) {
 			if val != nil {
 				switch col.TypeInfo.GetTypeIdentifier() {
 				case typeinfo.DatetimeTypeIdentifier:
-					val = time.UnixMicro(val.(int64))
+					switch v := val.(type) {
+					case int32:
+						// dates are stored as days since the epoch
+						val = time.Unix(int64(v)*24*60*60, 0).UTC()
+					default:
+						val = time.UnixMicro(val.(int64))
+					}
 				case typeinfo.TimeTypeIdentifier:
 					val = gmstypes.Timespan(time.Duration(val.(int64)).Microseconds())
 				}
 			}
 
-			if col.Kind == types.DecimalKind {
+			if col.Kind == types.DecimalKind && val != nil {
 				prec, scale := col.TypeInfo.ToSqlType().(gmstypes.DecimalType_).Precision(), col.TypeInfo.ToSqlType().(gmstypes.DecimalType_).Scale()
 				val = DecimalByteArrayToString([]byte(val.(string)), int(prec), int(scale))
 			}
@@ -0,0 +1,139 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+)
+
+// SchemaFromFile returns a keyless schema with a column for each top-level field of the parquet file at |path|, typed
+// after the field's parquet type.
+func SchemaFromFile(path string) (schema.Schema, error) {
+	fr, err := local.NewLocalFileReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fr.Close()
+
+	pr, err := reader.NewParquetColumnReader(fr, 1)
+	if err != nil {
+		return nil, err
+	}
+	defer pr.ReadStop()
+
+	elements := pr.SchemaHandler.SchemaElements
+	if len(elements) == 0 {
+		return nil, fmt.Errorf("parquet file %s has no schema", path)
+	}
+
+	var cols []schema.Column
+	// elements holds the schema tree in depth-first order, starting with the root
+	for i := 1; i < len(elements); i = skipParquetElement(elements, i) {
+		// the reader renames the schema's elements, so the names from the file are taken from the schema's infos
+		name := pr.SchemaHandler.Infos[i].ExName
+		sqlType, err := sqlTypeForParquetElement(elements, name, i)
+		if err != nil {
+			return nil, err
+		}
+		ti, err := typeinfo.FromSqlType(sqlType)
+		if err != nil {
+			return nil, err
+		}
+		col, err := schema.NewColumnWithTypeInfo(name, uint64(len(cols)), ti, false, "", false, "")
+		if err != nil {
+			return nil, err
+		}
+		cols = append(cols, col)
+	}
+
+	return schema.UnkeyedSchemaFromCols(schema.NewColCollection(cols...)), nil
+}
+
+// skipParquetElement returns the index of the element following the element at |i| and all of its descendants.
+func skipParquetElement(elements []*parquet.SchemaElement, i int) int {
+	children := int(elements[i].GetNumChildren())
+	i++
+	for c := 0; c < children; c++ {
+		i = skipParquetElement(elements, i)
+	}
+	return i
+}
+
+// sqlTypeForParquetElement returns the type of the column |name| read from the top-level field at |i|. Nested and repeated
+// fields are read as JSON.
+func sqlTypeForParquetElement(elements []*parquet.SchemaElement, name string, i int) (sql.Type, error) {
+	el := elements[i]
+	if el.GetNumChildren() > 0 || el.GetRepetitionType() == parquet.FieldRepetitionType_REPEATED {
+		return gmstypes.JSON, nil
+	}
+
+	switch el.GetType() {
+	case parquet.Type_BOOLEAN:
+		return gmstypes.Boolean, nil
+	case parquet.Type_INT32:
+		switch {
+		case !el.IsSetConvertedType():
+			return gmstypes.Int32, nil
+		case el.GetConvertedType() == parquet.ConvertedType_DATE:
+			return gmstypes.Date, nil
+		case el.GetConvertedType() == parquet.ConvertedType_INT_8 || el.GetConvertedType() == parquet.ConvertedType_INT_16 ||
+			el.GetConvertedType() == parquet.ConvertedType_INT_32:
+			return gmstypes.Int32, nil
+		case el.GetConvertedType() == parquet.ConvertedType_UINT_8 || el.GetConvertedType() == parquet.ConvertedType_UINT_16 ||
+			el.GetConvertedType() == parquet.ConvertedType_UINT_32:
+			return gmstypes.Uint32, nil
+		}
+	case parquet.Type_INT64:
+		switch {
+		case !el.IsSetConvertedType():
+			return gmstypes.Int64, nil
+		case el.GetConvertedType() == parquet.ConvertedType_TIMESTAMP_MICROS:
+			return gmstypes.DatetimeMaxPrecision, nil
+		case el.GetConvertedType() == parquet.ConvertedType_INT_64:
+			return gmstypes.Int64, nil
+		case el.GetConvertedType() == parquet.ConvertedType_UINT_64:
+			return gmstypes.Uint64, nil
+		}
+	case parquet.Type_FLOAT:
+		return gmstypes.Float32, nil
+	case parquet.Type_DOUBLE:
+		return gmstypes.Float64, nil
+	case parquet.Type_BYTE_ARRAY, parquet.Type_FIXED_LEN_BYTE_ARRAY:
+		switch {
+		case !el.IsSetConvertedType():
+			return gmstypes.LongBlob, nil
+		case el.GetConvertedType() == parquet.ConvertedType_DECIMAL:
+			return gmstypes.CreateColumnDecimalType(uint8(el.GetPrecision()), uint8(el.GetScale()))
+		case el.GetConvertedType() == parquet.ConvertedType_UTF8 || el.GetConvertedType() == parquet.ConvertedType_ENUM:
+			return gmstypes.LongText, nil
+		case el.GetConvertedType() == parquet.ConvertedType_JSON:
+			return gmstypes.JSON, nil
+		}
+	}
+
+	if el.IsSetConvertedType() {
+		return nil, fmt.Errorf("column %s has unsupported parquet type %s (%s)", name, el.GetType(), el.GetConvertedType())
+	}
+	return nil, fmt.Errorf("column %s has unsupported parquet type %s", name, el.GetType())
+}
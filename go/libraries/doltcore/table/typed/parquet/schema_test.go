@@ -0,0 +1,72 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"context"
+	"io"
+	"path"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+)
+
+func TestSchemaFromFile(t *testing.T) {
+	path := path.Join(t.TempDir(), "parquet")
+
+	pWr, err := NewParquetRowWriterForFile(rowSch, path, WriterOptions{})
+	require.NoError(t, err)
+	writeToParquet(pWr, getSampleRows(), t)
+
+	sch, err := SchemaFromFile(path)
+	require.NoError(t, err)
+	assert.True(t, schema.IsKeyless(sch))
+
+	var names []string
+	var types []sql.Type
+	for _, col := range sch.GetAllCols().GetColumns() {
+		names = append(names, col.Name)
+		types = append(types, col.TypeInfo.ToSqlType())
+	}
+	assert.Equal(t, []string{nameColName, ageColName, titleColName}, names)
+	assert.Equal(t, []sql.Type{gmstypes.LongText, gmstypes.Uint64, gmstypes.LongText}, types)
+
+	// the schema reads the file's rows
+	pRd, err := OpenParquetReader(nil, path, sch)
+	require.NoError(t, err)
+	defer pRd.Close(context.Background())
+
+	var rows []sql.Row
+	for {
+		r, err := pRd.ReadSqlRow(context.Background())
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		rows = append(rows, r)
+	}
+	require.Len(t, rows, 4)
+	assert.Equal(t, sql.Row{"Andy Anderson", int64(27), nil}, rows[3])
+}
+
+func TestSchemaFromFileNotFound(t *testing.T) {
+	_, err := SchemaFromFile(path.Join(t.TempDir(), "missing.parquet"))
+	assert.Error(t, err)
+}
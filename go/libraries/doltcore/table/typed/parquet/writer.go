@@ -18,12 +18,14 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/types"
 	"github.com/dolthub/vitess/go/vt/proto/query"
 	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/parquet"
 	"github.com/xitongsys/parquet-go/writer"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
@@ -39,9 +41,47 @@ type ParquetRowWriter struct {
 
 var _ table.SqlRowWriter = (*ParquetRowWriter)(nil)
 
+// Compressions are the names of the codecs that can compress the pages of a parquet file.
+var Compressions = []string{"uncompressed", "snappy", "gzip", "lz4", "zstd"}
+
+// WriterOptions are the options of the files written by a ParquetRowWriter.
+type WriterOptions struct {
+	// RowGroupSize is the size in bytes of the row groups of the file. Zero is the default of 128MB.
+	RowGroupSize int64
+	// Compression is the name of the codec that compresses the file's pages. Empty is snappy.
+	Compression string
+}
+
+// compressionCodec returns the codec named by |o.Compression|.
+func (o WriterOptions) compressionCodec() (parquet.CompressionCodec, error) {
+	if o.Compression == "" {
+		return parquet.CompressionCodec_SNAPPY, nil
+	}
+	for _, name := range Compressions {
+		if strings.EqualFold(name, o.Compression) {
+			return parquet.CompressionCodecFromString(strings.ToUpper(name))
+		}
+	}
+	return 0, fmt.Errorf("unknown parquet compression '%s', expected one of: %s", o.Compression, strings.Join(Compressions, ", "))
+}
+
 // NewParquetRowWriter creates a new ParquetRowWriter instance for the specified schema and
 // writing to the specified WriteCloser.
 func NewParquetRowWriter(outSch sql.Schema, w io.WriteCloser) (*ParquetRowWriter, error) {
+	return NewParquetRowWriterWithOptions(outSch, w, WriterOptions{})
+}
+
+// NewParquetRowWriterWithOptions creates a new ParquetRowWriter instance for the specified schema, writing to the
+// specified WriteCloser a file with the given options.
+func NewParquetRowWriterWithOptions(outSch sql.Schema, w io.WriteCloser, opts WriterOptions) (*ParquetRowWriter, error) {
+	codec, err := opts.compressionCodec()
+	if err != nil {
+		return nil, err
+	}
+	if opts.RowGroupSize < 0 {
+		return nil, fmt.Errorf("invalid parquet row group size %d", opts.RowGroupSize)
+	}
+
 	var csvSchema []string
 	var repetitionType string
 	// creates csv schema for handling parquet format using NewCSVWriter
@@ -63,13 +103,16 @@ func NewParquetRowWriter(outSch sql.Schema, w io.WriteCloser) (*ParquetRowWriter
 		return nil, err
 	}
 
-	// pw.CompressionType defaults to parquet.CompressionCodec_SNAPPY
+	pw.CompressionType = codec
+	if opts.RowGroupSize > 0 {
+		pw.RowGroupSize = opts.RowGroupSize
+	}
 	return &ParquetRowWriter{pwriter: pw, sch: outSch, closer: w}, nil
 }
 
 // NewParquetRowWriterForFile creates a new ParquetRowWriter instance for the specified schema and
-// writing to the specified file name.
-func NewParquetRowWriterForFile(outSch schema.Schema, destName string) (*ParquetRowWriter, error) {
+// writing to the specified file name a file with the given options.
+func NewParquetRowWriterForFile(outSch schema.Schema, destName string, opts WriterOptions) (*ParquetRowWriter, error) {
 	primaryKeySchema, err := sqlutil.FromDoltSchema("", "", outSch)
 	if err != nil {
 		return nil, err
@@ -80,7 +123,12 @@ func NewParquetRowWriterForFile(outSch schema.Schema, destName string) (*Parquet
 		return nil, err
 	}
 
-	return NewParquetRowWriter(primaryKeySchema.Schema, fw)
+	pwr, err := NewParquetRowWriterWithOptions(primaryKeySchema.Schema, fw, opts)
+	if err != nil {
+		fw.Close()
+		return nil, err
+	}
+	return pwr, nil
 }
 
 func (pwr *ParquetRowWriter) WriteSqlRow(_ context.Context, r sql.Row) error {
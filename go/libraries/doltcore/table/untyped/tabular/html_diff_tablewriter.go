@@ -0,0 +1,78 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tabular
+
+import (
+	"html"
+	"io"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
+)
+
+// NewHTMLDiffTableWriter returns a writer for diff rows as an HTML table. Rows have the class added, removed or
+// modified, and changed values of modified rows are written as a <del> element holding the old value followed by an
+// <ins> element holding the new value.
+func NewHTMLDiffTableWriter(schema sql.Schema, wr io.WriteCloser) *MarkupDiffTableWriter {
+	return newMarkupDiffTableWriter(schema, wr, htmlFormat{})
+}
+
+// HTMLEscape escapes |s| for use as HTML text, preserving line breaks.
+func HTMLEscape(s string) string {
+	return strings.ReplaceAll(html.EscapeString(s), "\n", "<br>")
+}
+
+type htmlFormat struct{}
+
+func (f htmlFormat) writeHeader(wr io.Writer, cols []string) error {
+	var sb strings.Builder
+	sb.WriteString("<table class=\"dolt-diff\">\n<thead>\n<tr><th></th>")
+	for _, col := range cols {
+		sb.WriteString("<th>" + HTMLEscape(col) + "</th>")
+	}
+	sb.WriteString("</tr>\n</thead>\n<tbody>\n")
+	return iohelp.WriteAll(wr, []byte(sb.String()))
+}
+
+func (f htmlFormat) writeRow(wr io.Writer, rowDiffType diff.ChangeType, cells []markupCell) error {
+	var class string
+	switch rowDiffType {
+	case diff.Added:
+		class = "added"
+	case diff.Removed:
+		class = "removed"
+	default:
+		class = "modified"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<tr class=\"" + class + "\"><td>" + HTMLEscape(diffMarker(rowDiffType)) + "</td>")
+	for _, cell := range cells {
+		if cell.changed {
+			sb.WriteString("<td class=\"changed\"><del>" + HTMLEscape(cell.old) + "</del><ins>" + HTMLEscape(cell.value) + "</ins></td>")
+		} else {
+			sb.WriteString("<td>" + HTMLEscape(cell.value) + "</td>")
+		}
+	}
+	sb.WriteString("</tr>\n")
+	return iohelp.WriteAll(wr, []byte(sb.String()))
+}
+
+func (f htmlFormat) writeFooter(wr io.Writer) error {
+	return iohelp.WriteAll(wr, []byte("</tbody>\n</table>\n"))
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tabular
+
+import (
+	"io"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
+)
+
+// NewMarkdownDiffTableWriter returns a writer for diff rows as a GitHub flavored Markdown table. Changed values of
+// modified rows are written as the old value struck through followed by the new value in bold.
+func NewMarkdownDiffTableWriter(schema sql.Schema, wr io.WriteCloser) *MarkupDiffTableWriter {
+	return newMarkupDiffTableWriter(schema, wr, markdownFormat{})
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	`*`, `\*`,
+	`_`, `\_`,
+	`~`, `\~`,
+	`|`, `\|`,
+	`[`, `\[`,
+	`]`, `\]`,
+	`&`, `&amp;`,
+	`<`, `&lt;`,
+	`>`, `&gt;`,
+	"\r\n", `<br>`,
+	"\n", `<br>`,
+)
+
+// MarkdownEscape escapes |s| for use as inline Markdown text.
+func MarkdownEscape(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+type markdownFormat struct{}
+
+func (f markdownFormat) writeHeader(wr io.Writer, cols []string) error {
+	var sb strings.Builder
+	sb.WriteString("|   |")
+	for _, col := range cols {
+		sb.WriteString(" ")
+		sb.WriteString(MarkdownEscape(col))
+		sb.WriteString(" |")
+	}
+	sb.WriteString("\n| --- |")
+	for range cols {
+		sb.WriteString(" --- |")
+	}
+	sb.WriteString("\n")
+	return iohelp.WriteAll(wr, []byte(sb.String()))
+}
+
+func (f markdownFormat) writeRow(wr io.Writer, rowDiffType diff.ChangeType, cells []markupCell) error {
+	var sb strings.Builder
+	sb.WriteString("| ")
+	sb.WriteString(MarkdownEscape(diffMarker(rowDiffType)))
+	sb.WriteString(" |")
+	for _, cell := range cells {
+		sb.WriteString(" ")
+		if cell.changed {
+			if cell.old != "" {
+				sb.WriteString("~~" + MarkdownEscape(cell.old) + "~~ ")
+			}
+			if cell.value != "" {
+				sb.WriteString("**" + MarkdownEscape(cell.value) + "**")
+			}
+		} else {
+			sb.WriteString(MarkdownEscape(cell.value))
+		}
+		sb.WriteString(" |")
+	}
+	sb.WriteString("\n")
+	return iohelp.WriteAll(wr, []byte(sb.String()))
+}
+
+func (f markdownFormat) writeFooter(wr io.Writer) error {
+	return iohelp.WriteAll(wr, []byte("\n"))
+}
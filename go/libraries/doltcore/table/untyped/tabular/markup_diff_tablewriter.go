@@ -0,0 +1,176 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tabular
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+)
+
+// markupFormat renders the parts of a diff table in a markup language.
+type markupFormat interface {
+	writeHeader(wr io.Writer, cols []string) error
+	writeRow(wr io.Writer, rowDiffType diff.ChangeType, cells []markupCell) error
+	writeFooter(wr io.Writer) error
+}
+
+// markupCell is a cell of a markup diff table. For modified rows, |old| holds the previous value of cells whose value
+// changed.
+type markupCell struct {
+	value   string
+	old     string
+	changed bool
+}
+
+// MarkupDiffTableWriter writes diff rows as a Markdown or HTML table. Rather than writing the old and new versions of a
+// modified row separately, it writes a single row in which each changed value shows both its old and new value.
+type MarkupDiffTableWriter struct {
+	schema      sql.Schema
+	wr          io.WriteCloser
+	format      markupFormat
+	oldRow      []string
+	rowsWritten int
+}
+
+var _ diff.SqlRowDiffWriter = (*MarkupDiffTableWriter)(nil)
+
+func newMarkupDiffTableWriter(schema sql.Schema, wr io.WriteCloser, format markupFormat) *MarkupDiffTableWriter {
+	return &MarkupDiffTableWriter{
+		schema: schema,
+		wr:     wr,
+		format: format,
+	}
+}
+
+func (w *MarkupDiffTableWriter) WriteRow(ctx context.Context, row sql.Row, rowDiffType diff.ChangeType, colDiffTypes []diff.ChangeType) error {
+	if len(row) != len(colDiffTypes) {
+		return fmt.Errorf("expected the same size for columns and diff types, got %d and %d", len(row), len(colDiffTypes))
+	}
+
+	values, err := w.stringValues(row)
+	if err != nil {
+		return err
+	}
+
+	switch rowDiffType {
+	case diff.ModifiedOld:
+		// the new version of the row is written next
+		w.oldRow = values
+		return nil
+	case diff.ModifiedNew:
+		if w.oldRow != nil {
+			oldRow := w.oldRow
+			w.oldRow = nil
+			return w.writeModifiedRow(oldRow, values)
+		}
+	}
+
+	cells := make([]markupCell, len(values))
+	for i, v := range values {
+		cells[i] = markupCell{value: v}
+	}
+	return w.writeRow(rowDiffType, cells)
+}
+
+func (w *MarkupDiffTableWriter) WriteCombinedRow(ctx context.Context, oldRow, newRow sql.Row, mode diff.Mode) error {
+	oldValues, err := w.stringValues(oldRow)
+	if err != nil {
+		return err
+	}
+	newValues, err := w.stringValues(newRow)
+	if err != nil {
+		return err
+	}
+	return w.writeModifiedRow(oldValues, newValues)
+}
+
+func (w *MarkupDiffTableWriter) writeModifiedRow(oldValues, newValues []string) error {
+	cells := make([]markupCell, len(newValues))
+	for i := range newValues {
+		cells[i] = markupCell{value: newValues[i]}
+		if i < len(oldValues) && oldValues[i] != newValues[i] {
+			cells[i].old = oldValues[i]
+			cells[i].changed = true
+		}
+	}
+	return w.writeRow(diff.ModifiedNew, cells)
+}
+
+func (w *MarkupDiffTableWriter) writeRow(rowDiffType diff.ChangeType, cells []markupCell) error {
+	if w.rowsWritten == 0 {
+		cols := make([]string, len(w.schema))
+		for i, col := range w.schema {
+			cols[i] = col.Name
+		}
+		if err := w.format.writeHeader(w.wr, cols); err != nil {
+			return err
+		}
+	}
+	w.rowsWritten++
+	return w.format.writeRow(w.wr, rowDiffType, cells)
+}
+
+func (w *MarkupDiffTableWriter) stringValues(row sql.Row) ([]string, error) {
+	values := make([]string, len(row))
+	for i, v := range row {
+		if v == nil {
+			values[i] = "NULL"
+			continue
+		}
+		if b, ok := v.([]byte); ok && types.IsBinaryType(w.schema[i].Type) {
+			// binary values are written as hex, as their bytes are unlikely to be printable
+			values[i] = "0x" + strings.ToUpper(hex.EncodeToString(b))
+			continue
+		}
+		var err error
+		values[i], err = sqlutil.SqlColToStr(w.schema[i].Type, v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+func (w *MarkupDiffTableWriter) Close(ctx context.Context) error {
+	if w.rowsWritten > 0 {
+		if err := w.format.writeFooter(w.wr); err != nil {
+			return err
+		}
+	}
+	return w.wr.Close()
+}
+
+// diffMarker returns the marker written in the leading column of a markup diff table row.
+func diffMarker(rowDiffType diff.ChangeType) string {
+	switch rowDiffType {
+	case diff.Added:
+		return "+"
+	case diff.Removed:
+		return "-"
+	case diff.ModifiedOld, diff.ModifiedNew:
+		return "*"
+	default:
+		return ""
+	}
+}
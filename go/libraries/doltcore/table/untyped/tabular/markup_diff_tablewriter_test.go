@@ -0,0 +1,77 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tabular
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+)
+
+func TestMarkupDiffTableWriters(t *testing.T) {
+	sch := sql.Schema{
+		{Name: "id", Type: types.Int64},
+		{Name: "name", Type: types.Text},
+		{Name: "data", Type: types.MustCreateBinary(sqltypes.VarBinary, 2)},
+	}
+
+	writeRows := func(t *testing.T, wr *MarkupDiffTableWriter) {
+		ctx := context.Background()
+		cols := func(ct diff.ChangeType) []diff.ChangeType {
+			return []diff.ChangeType{ct, ct, ct}
+		}
+		require.NoError(t, wr.WriteRow(ctx, sql.Row{int64(1), "a|b", []byte{0, 1}}, diff.Added, cols(diff.Added)))
+		require.NoError(t, wr.WriteRow(ctx, sql.Row{int64(2), "<old>", nil}, diff.ModifiedOld, cols(diff.ModifiedOld)))
+		require.NoError(t, wr.WriteRow(ctx, sql.Row{int64(2), "new\nline", nil}, diff.ModifiedNew, cols(diff.ModifiedNew)))
+		require.NoError(t, wr.WriteRow(ctx, sql.Row{int64(3), "*c*", nil}, diff.Removed, cols(diff.Removed)))
+		require.NoError(t, wr.Close(ctx))
+	}
+
+	t.Run("markdown", func(t *testing.T) {
+		var stringWr StringBuilderCloser
+		writeRows(t, NewMarkdownDiffTableWriter(sch, &stringWr))
+		expected := "|   | id | name | data |\n" +
+			"| --- | --- | --- | --- |\n" +
+			"| + | 1 | a\\|b | 0x0001 |\n" +
+			"| \\* | 2 | ~~&lt;old&gt;~~ **new<br>line** | NULL |\n" +
+			"| - | 3 | \\*c\\* | NULL |\n" +
+			"\n"
+		assert.Equal(t, expected, stringWr.String())
+	})
+
+	t.Run("html", func(t *testing.T) {
+		var stringWr StringBuilderCloser
+		writeRows(t, NewHTMLDiffTableWriter(sch, &stringWr))
+		expected := "<table class=\"dolt-diff\">\n<thead>\n<tr><th></th><th>id</th><th>name</th><th>data</th></tr>\n</thead>\n<tbody>\n" +
+			"<tr class=\"added\"><td>+</td><td>1</td><td>a|b</td><td>0x0001</td></tr>\n" +
+			"<tr class=\"modified\"><td>*</td><td>2</td><td class=\"changed\"><del>&lt;old&gt;</del><ins>new<br>line</ins></td><td>NULL</td></tr>\n" +
+			"<tr class=\"removed\"><td>-</td><td>3</td><td>*c*</td><td>NULL</td></tr>\n" +
+			"</tbody>\n</table>\n"
+		assert.Equal(t, expected, stringWr.String())
+	})
+
+	t.Run("no rows", func(t *testing.T) {
+		var stringWr StringBuilderCloser
+		require.NoError(t, NewMarkdownDiffTableWriter(sch, &stringWr).Close(context.Background()))
+		assert.Empty(t, stringWr.String())
+	})
+}
@@ -42,6 +42,20 @@ func UnmarshalFromXLSX(path string) ([][][]string, error) {
 	return dataSlice, nil
 }
 
+// SheetNames returns the names of the sheets of the workbook at |path|, in order.
+func SheetNames(path string) ([]string, error) {
+	data, err := openFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(data.Sheets))
+	for i, sheet := range data.Sheets {
+		names[i] = sheet.Name
+	}
+	return names, nil
+}
+
 func openFile(path string) (*xlsx.File, error) {
 	data, err := xlsx.OpenFile(path)
 
@@ -79,7 +93,11 @@ func decodeXLSXRows(xlData [][][]string, sch schema.Schema) ([]sql.Row, error) {
 				if _, found := sch.GetAllCols().NameToCol[v]; !found {
 					return nil, errors.New(v + " is not a valid column")
 				}
-				valString := dataVals[i+1][k]
+				// cells after the last non-empty cell of a row aren't stored
+				valString := ""
+				if k < len(dataVals[i+1]) {
+					valString = dataVals[i+1][k]
+				}
 				row = append(row, valString)
 			}
 			rows = append(rows, row)
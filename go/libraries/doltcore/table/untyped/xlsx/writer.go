@@ -0,0 +1,245 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xlsx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/tealeg/xlsx"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+)
+
+const (
+	// maxSheetNameLen is the most characters Excel allows in the name of a sheet.
+	maxSheetNameLen = 31
+	// maxSheetRows is the most rows Excel allows in a sheet, including its header row.
+	maxSheetRows = 1048576
+)
+
+// XLSXWorkbookWriter writes tables to the sheets of an xlsx workbook. Workbooks are written as a whole, so nothing is
+// written until the workbook is closed.
+type XLSXWorkbookWriter struct {
+	closer io.WriteCloser
+	file   *xlsx.File
+	// sheetNames holds the lowercased names of the sheets added, as Excel compares sheet names case-insensitively.
+	sheetNames map[string]struct{}
+}
+
+// NewXLSXWorkbookWriter returns a writer of a workbook to |wr|.
+func NewXLSXWorkbookWriter(wr io.WriteCloser) *XLSXWorkbookWriter {
+	return &XLSXWorkbookWriter{closer: wr, file: xlsx.NewFile(), sheetNames: make(map[string]struct{})}
+}
+
+// NewSheetWriter adds a sheet for the table |tableName| to the workbook and returns a writer of the rows of |sch| to it.
+// The sheet is named after the table, with the characters Excel doesn't allow in sheet names replaced with '_', and
+// truncated to 31 characters. A name already taken by another sheet is made unique with a numbered suffix. The first
+// row of the sheet holds the names of the columns.
+func (wbw *XLSXWorkbookWriter) NewSheetWriter(tableName string, sch schema.Schema) (*XLSXWriter, error) {
+	if wbw.file == nil {
+		return nil, errors.New("Already closed.")
+	}
+
+	sheetName := wbw.uniqueSheetName(tableName)
+	sqlSch, err := sqlutil.FromDoltSchema("", tableName, sch)
+	if err != nil {
+		return nil, err
+	}
+
+	sheet, err := wbw.file.AddSheet(sheetName)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sheet name '%s': %w", sheetName, err)
+	}
+	wbw.sheetNames[strings.ToLower(sheetName)] = struct{}{}
+
+	header := sheet.AddRow()
+	for _, col := range sqlSch.Schema {
+		header.AddCell().SetString(col.Name)
+	}
+
+	return &XLSXWriter{sheet: sheet, sheetName: sheetName, tableName: tableName, sch: sqlSch.Schema}, nil
+}
+
+// uniqueSheetName returns a valid sheet name for the table |tableName| which no sheet of the workbook has.
+func (wbw *XLSXWorkbookWriter) uniqueSheetName(tableName string) string {
+	name := strings.Map(func(r rune) rune {
+		switch r {
+		case ':', '\\', '/', '?', '*', '[', ']':
+			return '_'
+		}
+		return r
+	}, tableName)
+	if name == "" {
+		name = "_"
+	}
+
+	for i := 1; ; i++ {
+		suffix := ""
+		if i > 1 {
+			suffix = fmt.Sprintf("_%d", i)
+		}
+		candidate := truncateRunes(name, maxSheetNameLen-len(suffix)) + suffix
+		if _, ok := wbw.sheetNames[strings.ToLower(candidate)]; !ok {
+			return candidate
+		}
+	}
+}
+
+// truncateRunes returns the first |n| characters of |s|.
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
+
+// Close writes the workbook and closes the underlying writer.
+func (wbw *XLSXWorkbookWriter) Close() error {
+	if wbw.file == nil {
+		return errors.New("Already closed.")
+	}
+
+	err := wbw.file.Write(wbw.closer)
+	wbw.file = nil
+	if closeErr := wbw.closer.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// XLSXWriter implements table.SqlRowWriter, writing rows to a sheet of an xlsx workbook. Integers and floats are
+// written as numbers, NULL values as empty cells, and all other values as text.
+type XLSXWriter struct {
+	sheet     *xlsx.Sheet
+	sheetName string
+	tableName string
+	sch       sql.Schema
+	// wbw is the workbook written when the writer is closed, if the writer is the only writer of its workbook.
+	wbw *XLSXWorkbookWriter
+}
+
+var _ table.SqlRowWriter = (*XLSXWriter)(nil)
+
+// NewXLSXWriter returns a writer of the rows of |sch| to a workbook written to |wr| with a single sheet for the table
+// |tableName|. The workbook is written when the writer is closed.
+func NewXLSXWriter(wr io.WriteCloser, sch schema.Schema, tableName string) (*XLSXWriter, error) {
+	wbw := NewXLSXWorkbookWriter(wr)
+	xlsxw, err := wbw.NewSheetWriter(tableName, sch)
+	if err != nil {
+		wr.Close()
+		return nil, err
+	}
+	xlsxw.wbw = wbw
+	return xlsxw, nil
+}
+
+// SheetName returns the name of the sheet written, which may differ from the name of its table.
+func (xlsxw *XLSXWriter) SheetName() string {
+	return xlsxw.sheetName
+}
+
+// WriteSqlRow adds a row to the sheet. It returns an error once the sheet holds as many rows as Excel allows.
+func (xlsxw *XLSXWriter) WriteSqlRow(ctx context.Context, r sql.Row) error {
+	if xlsxw.sheet == nil {
+		return errors.New("Already closed.")
+	}
+	if len(xlsxw.sheet.Rows) >= maxSheetRows {
+		return fmt.Errorf("table '%s' has too many rows for an xlsx sheet, which holds at most %d rows besides its header row",
+			xlsxw.tableName, maxSheetRows-1)
+	}
+
+	xlRow := xlsxw.sheet.AddRow()
+	for i, val := range r {
+		cell := xlRow.AddCell()
+		if val == nil {
+			continue
+		}
+		if err := setCellValue(cell, xlsxw.sch[i].Type, val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setCellValue sets |cell| to |val|, a value of the column type |colType|.
+func setCellValue(cell *xlsx.Cell, colType sql.Type, val interface{}) error {
+	_, isBit := colType.(types.BitType)
+	if isBit || types.IsInteger(colType) || types.IsFloat(colType) {
+		switch v := val.(type) {
+		case int8:
+			cell.SetInt64(int64(v))
+			return nil
+		case int16:
+			cell.SetInt64(int64(v))
+			return nil
+		case int32:
+			cell.SetInt64(int64(v))
+			return nil
+		case int64:
+			cell.SetInt64(v)
+			return nil
+		case uint8:
+			cell.SetInt64(int64(v))
+			return nil
+		case uint16:
+			cell.SetInt64(int64(v))
+			return nil
+		case uint32:
+			cell.SetInt64(int64(v))
+			return nil
+		case uint64:
+			if v <= math.MaxInt64 {
+				cell.SetInt64(int64(v))
+				return nil
+			}
+		case float32:
+			cell.SetFloat(float64(v))
+			return nil
+		case float64:
+			cell.SetFloat(v)
+			return nil
+		}
+	}
+
+	str, err := sqlutil.SqlColToStr(colType, val)
+	if err != nil {
+		return err
+	}
+	cell.SetString(str)
+	return nil
+}
+
+// Close finishes the sheet, and writes the workbook if the writer is the only writer of its workbook.
+func (xlsxw *XLSXWriter) Close(ctx context.Context) error {
+	if xlsxw.sheet == nil {
+		return errors.New("Already closed.")
+	}
+
+	xlsxw.sheet = nil
+	if xlsxw.wbw != nil {
+		return xlsxw.wbw.Close()
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xlsx
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tealeg/xlsx"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestXLSXWorkbookWriter(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "workbook.xlsx")
+
+	peopleSch := schema.MustSchemaFromCols(schema.NewColCollection(
+		schema.NewColumn("id", 0, types.IntKind, true),
+		schema.NewColumn("name", 1, types.StringKind, false),
+		schema.NewColumn("score", 2, types.FloatKind, false),
+	))
+	petsSch := schema.MustSchemaFromCols(schema.NewColCollection(
+		schema.NewColumn("kind", 0, types.StringKind, true),
+	))
+
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	wbw := NewXLSXWorkbookWriter(f)
+
+	people, err := wbw.NewSheetWriter("people", peopleSch)
+	require.NoError(t, err)
+	require.NoError(t, people.WriteSqlRow(ctx, sql.Row{int64(1), "alice", 1.5}))
+	require.NoError(t, people.WriteSqlRow(ctx, sql.Row{int64(2), nil, nil}))
+	require.NoError(t, people.Close(ctx))
+
+	pets, err := wbw.NewSheetWriter("pets", petsSch)
+	require.NoError(t, err)
+	require.NoError(t, pets.WriteSqlRow(ctx, sql.Row{"cat"}))
+	require.NoError(t, pets.Close(ctx))
+
+	// sheet names are made valid and unique
+	for _, tableName := range []string{"PETS", "a/b", "a_b", "a_very_long_table_name_of_many_characters", "a_very_long_table_name_of_many_things"} {
+		wr, err := wbw.NewSheetWriter(tableName, petsSch)
+		require.NoError(t, err)
+		require.NoError(t, wr.Close(ctx))
+	}
+
+	require.NoError(t, wbw.Close())
+
+	sheets, err := SheetNames(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"people", "pets", "PETS_2", "a_b", "a_b_2", "a_very_long_table_name_of_many_", "a_very_long_table_name_of_man_2"}, sheets)
+
+	assert.Equal(t, []sql.Row{{"1", "alice", "1.5"}, {"2", "", ""}}, readSheet(t, path, "people"))
+	assert.Equal(t, []sql.Row{{"cat"}}, readSheet(t, path, "pets"))
+}
+
+func TestXLSXWriter(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "table.xlsx")
+
+	sch := schema.MustSchemaFromCols(schema.NewColCollection(
+		schema.NewColumn("id", 0, types.UintKind, true),
+		schema.NewColumn("title", 1, types.StringKind, false),
+	))
+
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	wr, err := NewXLSXWriter(f, sch, "employees")
+	require.NoError(t, err)
+	require.NoError(t, wr.WriteSqlRow(ctx, sql.Row{uint64(7), "boss"}))
+	require.NoError(t, wr.Close(ctx))
+	assert.Error(t, wr.Close(ctx))
+
+	assert.Equal(t, []sql.Row{{"7", "boss"}}, readSheet(t, path, "employees"))
+}
+
+func TestXLSXWriterRowLimit(t *testing.T) {
+	ctx := context.Background()
+	sch := schema.MustSchemaFromCols(schema.NewColCollection(
+		schema.NewColumn("id", 0, types.IntKind, true),
+	))
+
+	wbw := NewXLSXWorkbookWriter(nopWriteCloser{io.Discard})
+	wr, err := wbw.NewSheetWriter("numbers", sch)
+	require.NoError(t, err)
+	assert.Equal(t, "numbers", wr.SheetName())
+
+	wr.sheet.Rows = make([]*xlsx.Row, maxSheetRows-1)
+	require.NoError(t, wr.WriteSqlRow(ctx, sql.Row{int64(1)}))
+	err = wr.WriteSqlRow(ctx, sql.Row{int64(2)})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "table 'numbers' has too many rows for an xlsx sheet")
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+func readSheet(t *testing.T, path, sheetName string) []sql.Row {
+	ctx := context.Background()
+	rd, err := OpenXLSXReader(ctx, nil, path, filesys.LocalFS, NewXLSXInfo(sheetName))
+	require.NoError(t, err)
+	defer rd.Close(ctx)
+
+	var rows []sql.Row
+	for {
+		r, err := rd.ReadSqlRow(ctx)
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		rows = append(rows, r)
+	}
+	return rows
+}
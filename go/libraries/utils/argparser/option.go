@@ -25,8 +25,14 @@ const (
 	OptionalFlag OptionType = iota
 	OptionalValue
 	OptionalEmptyValue
+	// OptionalRepeatedValue is a value which may be given more than once.
+	OptionalRepeatedValue
 )
 
+// repeatedValueSep separates the values of an OptionalRepeatedValue option. Command line arguments can't hold NUL, so
+// it can't appear in a value.
+const repeatedValueSep = "\x00"
+
 type ValidationFunc func(string) error
 
 // Convenience validation function that asserts that an arg is an integer
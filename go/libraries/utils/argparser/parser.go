@@ -149,6 +149,15 @@ func (ap *ArgParser) SupportsStringList(name, abbrev, valDesc, desc string) *Arg
 	return ap
 }
 
+// SupportsRepeatedString adds support for a new string argument which may be given more than once, with the
+// description given. See SupportOpt for details on params.
+func (ap *ArgParser) SupportsRepeatedString(name, abbrev, valDesc, desc string) *ArgParser {
+	opt := &Option{name, abbrev, valDesc, OptionalRepeatedValue, desc, nil, false}
+	ap.SupportOption(opt)
+
+	return ap
+}
+
 // SupportsOptionalString adds support for a new string argument with the description given and optional empty value.
 func (ap *ArgParser) SupportsOptionalString(name, abbrev, valDesc, desc string) *ArgParser {
 	opt := &Option{name, abbrev, valDesc, OptionalEmptyValue, desc, nil, false}
@@ -240,7 +249,7 @@ func (ap *ArgParser) matchModalOptions(arg string) (matches []*Option, rest stri
 func (ap *ArgParser) sortedValueOptions() []string {
 	vos := make([]string, 0, len(ap.Supported))
 	for s, opt := range ap.nameOrAbbrevToOpt {
-		if (opt.OptType == OptionalValue || opt.OptType == OptionalEmptyValue) && s != "" {
+		if (opt.OptType == OptionalValue || opt.OptType == OptionalEmptyValue || opt.OptType == OptionalRepeatedValue) && s != "" {
 			vos = append(vos, s)
 		}
 	}
@@ -398,7 +407,7 @@ func (ap *ArgParser) parseToken(args []string, index int, positionalArgs []strin
 		return 0, nil, nil, UnknownArgumentParam{name: arg}
 	}
 
-	if _, exists := namedArgs[opt.Name]; exists {
+	if _, exists := namedArgs[opt.Name]; exists && opt.OptType != OptionalRepeatedValue {
 		//already provided
 		return 0, nil, nil, errors.New("error: multiple values provided for `" + opt.Name + "'")
 	}
@@ -443,6 +452,9 @@ func (ap *ArgParser) parseToken(args []string, index int, positionalArgs []strin
 		value = new(string)
 	}
 
+	if prev, exists := namedArgs[opt.Name]; exists {
+		*value = prev + repeatedValueSep + *value
+	}
 	namedArgs[opt.Name] = *value
 	return index, positionalArgs, namedArgs, nil
 }
@@ -15,6 +15,7 @@
 package argparser
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -150,6 +151,20 @@ func TestArgParser(t *testing.T) {
 			map[string]string{"param": "value", "optional": ""},
 			[]string{},
 		},
+		{
+			NewArgParserWithVariableArgs("test").SupportsString("param", "p", "", ""),
+			[]string{"--param", "value", "--param", "other"},
+			errors.New("error: multiple values provided for `param'"),
+			map[string]string{},
+			[]string{},
+		},
+		{
+			NewArgParserWithVariableArgs("test").SupportsRepeatedString("where", "w", "", ""),
+			[]string{"--where", "a, b", "arg1", "-w", "c;d", "--where=e"},
+			nil,
+			map[string]string{"where": "a, b" + repeatedValueSep + "c;d" + repeatedValueSep + "e"},
+			[]string{"arg1"},
+		},
 		{
 			NewArgParserWithVariableArgs("test").SupportsString("param", "p", "", ""),
 			[]string{"--param", "value", "arg1", "--", "table1", "table2"},
@@ -182,3 +197,17 @@ func TestArgParserSet(t *testing.T) {
 	apr, err = apr.SetArgument("garbage", "garbage value")
 	require.Error(t, err)
 }
+
+func TestGetRepeatedValue(t *testing.T) {
+	ap := NewArgParserWithVariableArgs("test").SupportsRepeatedString("where", "", "", "")
+	apr, err := ap.Parse([]string{"--where", "a, b", "--where", "c;d"})
+	require.NoError(t, err)
+	vals, ok := apr.GetRepeatedValue("where")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"a, b", "c;d"}, vals)
+
+	apr, err = ap.Parse([]string{})
+	require.NoError(t, err)
+	_, ok = apr.GetRepeatedValue("where")
+	assert.False(t, ok)
+}
@@ -115,6 +115,15 @@ func (res *ArgParseResults) GetValueList(name string) ([]string, bool) {
 	return strings.Split(val, ","), ok
 }
 
+// GetRepeatedValue returns the values given for an option which may be repeated, in the order they were given.
+func (res *ArgParseResults) GetRepeatedValue(name string) ([]string, bool) {
+	val, ok := res.options[name]
+	if !ok {
+		return nil, false
+	}
+	return strings.Split(val, repeatedValueSep), true
+}
+
 func (res *ArgParseResults) GetValues(names ...string) map[string]string {
 	vals := make(map[string]string)
 
@@ -31,6 +31,9 @@ var ConfigOptions = map[string]struct{}{
 	PushAutoSetupRemote:   {},
 	ProfileKey:            {},
 	VersionCheckDisabled:  {},
+	ColdTierURLKey:        {},
+	ColdTierAfterKey:      {},
+	ColdTierCacheSizeKey:  {},
 }
 
 const UserEmailKey = "user.email"
@@ -68,3 +71,9 @@ const VersionCheckDisabled = "versioncheck.disabled"
 const SignCommitsKey = "commit.gpgsign"
 
 const GPGSigningKeyKey = "user.signingkey"
+
+const ColdTierURLKey = "storage.cold_tier.url"
+
+const ColdTierAfterKey = "storage.cold_tier.after"
+
+const ColdTierCacheSizeKey = "storage.cold_tier.cache_size"
@@ -41,6 +41,12 @@ type Blobstore interface {
 	Concatenate(ctx context.Context, key string, sources []string) (version string, err error)
 }
 
+// Deleter is implemented by the Blobstores which can delete blobs.
+type Deleter interface {
+	// Delete removes the blob keyed by |key|. Deleting a blob which doesn't exist is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
 // GetBytes is a utility method calls bs.Get and handles reading the data from the returned
 // io.ReadCloser and closing it.
 func GetBytes(ctx context.Context, bs Blobstore, key string, br BlobRange) ([]byte, string, error) {
@@ -29,6 +29,8 @@ import (
 	"testing"
 
 	"cloud.google.com/go/storage"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/google/uuid"
 	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/oracle/oci-go-sdk/v65/objectstorage"
@@ -48,10 +50,13 @@ var (
 	osProvider    common.ConfigurationProvider
 	osClient      objectstorage.ObjectStorageClient
 	testOCIBucket string
+	s3Client      *s3.Client
+	testS3Bucket  string
 )
 
 const envTestGSBucket = "TEST_GCS_BUCKET"
 const envTestOCIBucket = "TEST_OCI_BUCKET"
+const envTestS3Bucket = "TEST_S3_BUCKET"
 
 func init() {
 	testGCSBucket = os.Getenv(envTestGSBucket)
@@ -76,6 +81,15 @@ func init() {
 
 		osClient = client
 	}
+	testS3Bucket = os.Getenv(envTestS3Bucket)
+	if testS3Bucket != "" {
+		cfg, err := config.LoadDefaultConfig(context.Background())
+		if err != nil {
+			panic("Could not create S3Blobstore")
+		}
+
+		s3Client = s3.NewFromConfig(cfg)
+	}
 }
 
 type BlobstoreTest struct {
@@ -103,6 +117,15 @@ func appendGCSTest(tests []BlobstoreTest) []BlobstoreTest {
 	return tests
 }
 
+func appendS3Test(tests []BlobstoreTest) []BlobstoreTest {
+	if testS3Bucket != "" {
+		s3Test := BlobstoreTest{"s3", NewS3Blobstore(s3Client, testS3Bucket, uuid.New().String()+"/"), 4, 4}
+		tests = append(tests, s3Test)
+	}
+
+	return tests
+}
+
 func appendLocalTest(tests []BlobstoreTest) []BlobstoreTest {
 	dir, err := os.MkdirTemp("", uuid.New().String())
 
@@ -119,6 +142,7 @@ func newBlobStoreTests() []BlobstoreTest {
 	tests = appendLocalTest(tests)
 	tests = appendGCSTest(tests)
 	tests = appendOCITest(tests)
+	tests = appendS3Test(tests)
 
 	return tests
 }
@@ -177,6 +201,34 @@ func TestGetMissing(t *testing.T) {
 	}
 }
 
+func testDelete(t *testing.T, bs Blobstore) {
+	d, ok := bs.(Deleter)
+	if !ok {
+		t.Skip("blobstore does not support deleting blobs")
+	}
+	ctx := context.Background()
+	_, err := PutBytes(ctx, bs, key, randBytes(32))
+	require.NoError(t, err)
+
+	require.NoError(t, d.Delete(ctx, key))
+	exists, err := bs.Exists(ctx, key)
+	require.NoError(t, err)
+	assert.False(t, exists)
+	_, _, err = GetBytes(ctx, bs, key, BlobRange{})
+	assert.True(t, IsNotFoundError(err))
+
+	// deleting a missing blob is not an error
+	require.NoError(t, d.Delete(ctx, key))
+}
+
+func TestDelete(t *testing.T) {
+	for _, bsTest := range newBlobStoreTests() {
+		t.Run(bsTest.bsType, func(t *testing.T) {
+			testDelete(t, bsTest.bs)
+		})
+	}
+}
+
 // CheckAndPutBytes is a utility method calls bs.CheckAndPut by wrapping the supplied []byte
 // in an io.Reader
 func CheckAndPutBytes(ctx context.Context, bs Blobstore, expectedVersion, key string, data []byte) (string, error) {
@@ -413,7 +465,7 @@ func TestPanicOnNegativeRangeLength(t *testing.T) {
 func TestConcatenate(t *testing.T) {
 	tests := newBlobStoreTests()
 	for _, test := range tests {
-		if test.bsType != "oci" {
+		if test.bsType != "oci" && test.bsType != "s3" {
 			t.Run(test.bsType, func(t *testing.T) {
 				testConcatenate(t, test.bs, 1)
 				testConcatenate(t, test.bs, 4)
@@ -41,6 +41,7 @@ type GCSBlobstore struct {
 }
 
 var _ Blobstore = &GCSBlobstore{}
+var _ Deleter = &GCSBlobstore{}
 
 // NewGCSBlobstore creates a new instance of a GCSBlobstore
 func NewGCSBlobstore(gcs *storage.Client, bucketName, prefix string) *GCSBlobstore {
@@ -166,6 +167,16 @@ func (bs *GCSBlobstore) CheckAndPut(ctx context.Context, expectedVersion, key st
 	return ver, err
 }
 
+// Delete removes the blob keyed by |key|.
+func (bs *GCSBlobstore) Delete(ctx context.Context, key string) error {
+	absKey := path.Join(bs.prefix, key)
+	err := bs.bucket.Object(absKey).Delete(ctx)
+	if err == storage.ErrObjectNotExist {
+		return nil
+	}
+	return err
+}
+
 func (bs *GCSBlobstore) Concatenate(ctx context.Context, key string, sources []string) (string, error) {
 	// GCS compose has a batch size limit,
 	// recursively compose sources
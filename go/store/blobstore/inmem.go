@@ -45,6 +45,7 @@ type InMemoryBlobstore struct {
 }
 
 var _ Blobstore = &InMemoryBlobstore{}
+var _ Deleter = &InMemoryBlobstore{}
 
 // NewInMemoryBlobstore creates an instance of an InMemoryBlobstore
 func NewInMemoryBlobstore(path string) *InMemoryBlobstore {
@@ -118,6 +119,15 @@ func (bs *InMemoryBlobstore) Exists(ctx context.Context, key string) (bool, erro
 	return ok, nil
 }
 
+// Delete removes the blob keyed by |key|.
+func (bs *InMemoryBlobstore) Delete(ctx context.Context, key string) error {
+	bs.mutex.Lock()
+	defer bs.mutex.Unlock()
+	delete(bs.blobs, key)
+	delete(bs.versions, key)
+	return nil
+}
+
 func (bs *InMemoryBlobstore) Concatenate(ctx context.Context, key string, sources []string) (string, error) {
 	// recursively compose sources (mirrors GCS impl)
 	for len(sources) > composeBatch {
@@ -69,6 +69,7 @@ type LocalBlobstore struct {
 }
 
 var _ Blobstore = &LocalBlobstore{}
+var _ Deleter = &LocalBlobstore{}
 
 // NewLocalBlobstore returns a new LocalBlobstore instance
 func NewLocalBlobstore(dir string) *LocalBlobstore {
@@ -218,6 +219,16 @@ func (bs *LocalBlobstore) Exists(ctx context.Context, key string) (bool, error)
 	return err == nil, err
 }
 
+// Delete removes the blob keyed by |key|.
+func (bs *LocalBlobstore) Delete(ctx context.Context, key string) error {
+	path := filepath.Join(bs.RootDir, key) + bsExt
+	err := os.Remove(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
 func (bs *LocalBlobstore) Concatenate(ctx context.Context, key string, sources []string) (ver string, err error) {
 	totalSize := int64(0)
 	readers := make([]io.Reader, len(sources))
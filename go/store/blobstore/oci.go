@@ -72,6 +72,7 @@ type OCIBlobstore struct {
 }
 
 var _ Blobstore = &OCIBlobstore{}
+var _ Deleter = &OCIBlobstore{}
 
 // NewOCIBlobstore creates a new instance of a OCIBlobstore
 func NewOCIBlobstore(ctx context.Context, provider common.ConfigurationProvider, client objectstorage.ObjectStorageClient, bucketName, prefix string) (*OCIBlobstore, error) {
@@ -164,6 +165,20 @@ func (bs *OCIBlobstore) CheckAndPut(ctx context.Context, expectedVersion, key st
 
 // At the time of this implementation, Oracle Cloud does not provide a way to create composite objects
 // via their APIs/SDKs.
+// Delete removes the blob keyed by |key|.
+func (bs *OCIBlobstore) Delete(ctx context.Context, key string) error {
+	absKey := path.Join(bs.prefix, key)
+	_, err := bs.client.DeleteObject(ctx, objectstorage.DeleteObjectRequest{
+		NamespaceName: &bs.namespace,
+		BucketName:    &bs.bucketName,
+		ObjectName:    &absKey,
+	})
+	if serr, ok := common.IsServiceError(err); ok && serr.GetHTTPStatusCode() == 404 {
+		return nil
+	}
+	return err
+}
+
 func (bs *OCIBlobstore) Concatenate(ctx context.Context, key string, sources []string) (string, error) {
 	return "", fmt.Errorf("concatenate is unimplemented on the oci blobstore")
 }
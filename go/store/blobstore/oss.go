@@ -38,6 +38,7 @@ type OSSBlobstore struct {
 }
 
 var _ Blobstore = &OSSBlobstore{}
+var _ Deleter = &OSSBlobstore{}
 
 // NewOSSBlobstore creates a new instance of a OSSBlobstore
 func NewOSSBlobstore(ossClient *oss.Client, bucketName, prefix string) (*OSSBlobstore, error) {
@@ -126,6 +127,11 @@ func (ob *OSSBlobstore) Concatenate(ctx context.Context, key string, sources []s
 	return "", fmt.Errorf("Conjoin is not implemented for OSSBlobstore")
 }
 
+// Delete removes the blob keyed by |key|.
+func (ob *OSSBlobstore) Delete(_ context.Context, key string) error {
+	return ob.bucket.DeleteObject(ob.absKey(key))
+}
+
 func (ob *OSSBlobstore) absKey(key string) string {
 	return path.Join(ob.prefix, key)
 }
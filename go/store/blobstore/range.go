@@ -59,6 +59,12 @@ func (br BlobRange) positiveRange(size int64) BlobRange {
 	return BlobRange{offset, length}
 }
 
+// Bounds returns the offset and length of the range within a blob of |size| bytes.
+func (br BlobRange) Bounds(size int64) (offset, length int64) {
+	pr := br.positiveRange(size)
+	return pr.offset, pr.length
+}
+
 func (br BlobRange) asHttpRangeHeader() string {
 	if br.isAllRange() {
 		return ""
@@ -71,3 +77,13 @@ func (br BlobRange) asHttpRangeHeader() string {
 
 // AllRange is a BlobRange instance covering all values
 var AllRange = NewBlobRange(0, 0)
+
+// Offset returns the offset of the range, which is negative when it's relative to the end of the blob.
+func (br BlobRange) Offset() int64 {
+	return br.offset
+}
+
+// Length returns the length of the range, or 0 if it extends to the end of the blob.
+func (br BlobRange) Length() int64 {
+	return br.length
+}
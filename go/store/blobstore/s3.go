@@ -0,0 +1,167 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	s3manager "github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// S3Client is the subset of the S3 API used by S3Blobstore.
+type S3Client interface {
+	s3manager.UploadAPIClient
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	HeadObject(ctx context.Context, params *s3.HeadObjectInput, optFns ...func(*s3.Options)) (*s3.HeadObjectOutput, error)
+	DeleteObject(ctx context.Context, params *s3.DeleteObjectInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectOutput, error)
+}
+
+// S3Blobstore provides an AWS S3 implementation of the Blobstore interface. The versions of its blobs are their ETags,
+// and CheckAndPut relies on S3's conditional writes.
+type S3Blobstore struct {
+	client S3Client
+	bucket string
+	prefix string
+}
+
+var _ Blobstore = &S3Blobstore{}
+var _ Deleter = &S3Blobstore{}
+
+// NewS3Blobstore creates a new instance of a S3Blobstore
+func NewS3Blobstore(client S3Client, bucket, prefix string) *S3Blobstore {
+	return &S3Blobstore{client: client, bucket: bucket, prefix: normalizePrefix(prefix)}
+}
+
+func (bs *S3Blobstore) Path() string {
+	return path.Join(bs.bucket, bs.prefix)
+}
+
+func (bs *S3Blobstore) Exists(ctx context.Context, key string) (bool, error) {
+	_, err := bs.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bs.bucket),
+		Key:    aws.String(bs.absKey(key)),
+	})
+	if s3StatusCode(err) == http.StatusNotFound {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (bs *S3Blobstore) Get(ctx context.Context, key string, br BlobRange) (io.ReadCloser, string, error) {
+	absKey := bs.absKey(key)
+	in := &s3.GetObjectInput{
+		Bucket: aws.String(bs.bucket),
+		Key:    aws.String(absKey),
+	}
+	if !br.isAllRange() {
+		in.Range = aws.String(br.asHttpRangeHeader())
+	}
+	out, err := bs.client.GetObject(ctx, in)
+	if s3StatusCode(err) == http.StatusNotFound {
+		return nil, "", NotFound{"s3://" + path.Join(bs.bucket, absKey)}
+	} else if err != nil {
+		return nil, "", err
+	}
+
+	if br.offset < 0 && br.length > 0 {
+		// a suffix range reads to the end of the blob
+		return readCloser{io.LimitReader(out.Body, br.length), out.Body}, aws.ToString(out.ETag), nil
+	}
+	return out.Body, aws.ToString(out.ETag), nil
+}
+
+func (bs *S3Blobstore) Put(ctx context.Context, key string, totalSize int64, reader io.Reader) (string, error) {
+	uploader := s3manager.NewUploader(bs.client)
+	out, err := uploader.Upload(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(bs.bucket),
+		Key:    aws.String(bs.absKey(key)),
+		Body:   reader,
+	})
+	if err != nil {
+		return "", err
+	}
+	return aws.ToString(out.ETag), nil
+}
+
+func (bs *S3Blobstore) CheckAndPut(ctx context.Context, expectedVersion, key string, totalSize int64, reader io.Reader) (string, error) {
+	// Conditional writes need a body which can be retried, and the blobs written this way, like manifests, are small.
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+	in := &s3.PutObjectInput{
+		Bucket: aws.String(bs.bucket),
+		Key:    aws.String(bs.absKey(key)),
+		Body:   bytes.NewReader(data),
+	}
+	if expectedVersion == "" {
+		in.IfNoneMatch = aws.String("*")
+	} else {
+		in.IfMatch = aws.String(expectedVersion)
+	}
+	out, err := bs.client.PutObject(ctx, in)
+	if code := s3StatusCode(err); code == http.StatusPreconditionFailed || code == http.StatusConflict {
+		return "", CheckAndPutError{
+			Key:             key,
+			ExpectedVersion: expectedVersion,
+			ActualVersion:   "unknown",
+		}
+	} else if err != nil {
+		return "", err
+	}
+	return aws.ToString(out.ETag), nil
+}
+
+func (bs *S3Blobstore) Concatenate(ctx context.Context, key string, sources []string) (string, error) {
+	return "", fmt.Errorf("Conjoin is not implemented for S3Blobstore")
+}
+
+// Delete removes the blob keyed by |key|. S3 doesn't report whether the object existed.
+func (bs *S3Blobstore) Delete(ctx context.Context, key string) error {
+	_, err := bs.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(bs.bucket),
+		Key:    aws.String(bs.absKey(key)),
+	})
+	return err
+}
+
+func (bs *S3Blobstore) absKey(key string) string {
+	return path.Join(bs.prefix, key)
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// s3StatusCode returns the HTTP status code of the S3 response which failed with |err|, or 0.
+func s3StatusCode(err error) int {
+	var respErr *awshttp.ResponseError
+	if errors.As(err, &respErr) {
+		return respErr.HTTPStatusCode()
+	}
+	return 0
+}
@@ -25,6 +25,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"time"
 
 	"github.com/dolthub/dolt/go/store/hash"
 )
@@ -261,10 +262,29 @@ type GenerationalCS interface {
 	// Has the same return values as OldGen().HasMany, but should be used by a
 	// generational GC process as the filter function instead of
 	// OldGen().HasMany. This function never takes read dependencies on the
-	// chunks that it queries.
+	// chunks that it queries. Chunks in the cold generation of a
+	// ColdTieredCS are filtered out as well.
 	OldGenGCFilter() HasManyFunc
 }
 
+// ColdTieredCS is a GenerationalCS which can keep its oldest history in a third, cold generation. A full GC decides
+// which chunks belong in the cold generation, and collects it along with the old generation.
+type ColdTieredCS interface {
+	GenerationalCS
+
+	// ColdGen returns the cold generation, or nil if the store doesn't
+	// have one.
+	ColdGen() ChunkStoreGarbageCollector
+
+	// ColdCutoff returns the time before which commits, along with the
+	// history behind them, belong in the cold generation.
+	ColdCutoff() time.Time
+
+	// ResealColdTableFiles seals the files of the cold generation which
+	// aren't sealed with the current encryption key with it.
+	ResealColdTableFiles(ctx context.Context) error
+}
+
 var ErrUnsupportedOperation = errors.New("operation not supported")
 
 var ErrGCGenerationExpired = errors.New("garbage collection generation expired")
@@ -0,0 +1,181 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/dolt/go/libraries/utils/file"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// CheckpointsDir is the directory, within the temp table files directory given to a pull, in which the pull keeps
+// its checkpoint.
+const CheckpointsDir = "checkpoints"
+
+const checkpointExt = ".json"
+
+// A ResumableTableFileStore is a DestTableFileStore which can read back the table files written to it before they are
+// added to its manifest. Pulls into one are checkpointed, so that an interrupted pull resumes from the table files it
+// already wrote.
+type ResumableTableFileStore interface {
+	DestTableFileStore
+	IterateTableFileChunks(ctx context.Context, fileId string, numChunks int, cb func(chunks.Chunk) error) error
+}
+
+// A checkpoint records the table files which a clone or pull has written to its destination, but not yet added to
+// the destination's manifest. It is keyed by the root being pulled, so that only the same pull resumes from it, and is
+// removed once the pull completes.
+type checkpoint struct {
+	path string
+
+	mu    sync.Mutex
+	state checkpointState
+}
+
+type checkpointState struct {
+	Root       string         `json:"root"`
+	TableFiles map[string]int `json:"table_files"`
+}
+
+// loadCheckpoint returns the checkpoint for a pull of |root| into |tempDir|, which is empty if there is none. Any
+// checkpoints left by pulls of other roots are removed, since they can't be resumed.
+func loadCheckpoint(tempDir string, root hash.Hash) (*checkpoint, error) {
+	dir := filepath.Join(tempDir, CheckpointsDir)
+	c := &checkpoint{
+		path:  filepath.Join(dir, root.String()+checkpointExt),
+		state: checkpointState{Root: root.String(), TableFiles: make(map[string]int)},
+	}
+
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		path := filepath.Join(dir, e.Name())
+		if path == c.path || !strings.HasSuffix(path, checkpointExt) {
+			continue
+		}
+		if err = os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	data, err := os.ReadFile(c.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var state checkpointState
+	if err = json.Unmarshal(data, &state); err != nil || state.Root != root.String() || state.TableFiles == nil {
+		// A checkpoint which can't be read is started over.
+		return c, nil
+	}
+	c.state = state
+	return c, nil
+}
+
+// HasCheckpoint returns true if a clone or pull which used |tempDir| was interrupted, and can be resumed.
+func HasCheckpoint(tempDir string) (bool, error) {
+	entries, err := os.ReadDir(filepath.Join(tempDir, CheckpointsDir))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	for _, e := range entries {
+		if strings.HasSuffix(e.Name(), checkpointExt) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// checkpointKey returns the root which a pull of |hashes| is checkpointed under.
+func checkpointKey(hashes []hash.Hash) hash.Hash {
+	if len(hashes) == 1 {
+		return hashes[0]
+	}
+	sorted := append([]hash.Hash(nil), hashes...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Less(sorted[j])
+	})
+	var buf bytes.Buffer
+	for _, h := range sorted {
+		buf.Write(h[:])
+	}
+	return hash.Of(buf.Bytes())
+}
+
+// tableFiles returns the table files in the checkpoint, and their chunk counts.
+func (c *checkpoint) tableFiles() map[string]int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ret := make(map[string]int, len(c.state.TableFiles))
+	for id, numChunks := range c.state.TableFiles {
+		ret[id] = numChunks
+	}
+	return ret
+}
+
+// add records that the table file |id| was written to the destination, and persists the checkpoint.
+func (c *checkpoint) add(id string, numChunks int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.state.TableFiles[id] = numChunks
+	return c.persist()
+}
+
+// drop forgets the table file |id|, which could not be read back from the destination.
+func (c *checkpoint) drop(id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.state.TableFiles, id)
+	return c.persist()
+}
+
+func (c *checkpoint) persist() error {
+	data, err := json.Marshal(c.state)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(c.path), os.ModePerm); err != nil {
+		return err
+	}
+	return file.WriteFileAtomically(c.path, bytes.NewReader(data), os.ModePerm)
+}
+
+// remove deletes the checkpoint, once the pull it was kept for completes.
+func (c *checkpoint) remove() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	err := os.Remove(c.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,200 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/util/clienttest"
+)
+
+var errInterrupted = errors.New("interrupted")
+
+// interruptedStore fails to add table files to its manifest, as if the pull writing them was interrupted once it had
+// written them all.
+type interruptedStore struct {
+	*nbs.GenerationalNBS
+}
+
+func (s interruptedStore) AddTableFilesToManifest(context.Context, map[string]int, chunks.GetAddrsCurry) error {
+	return errInterrupted
+}
+
+func TestCheckpoint(t *testing.T) {
+	t.Run("LoadRemovesOtherRoots", func(t *testing.T) {
+		dir := t.TempDir()
+		a, b := hash.Of([]byte("a")), hash.Of([]byte("b"))
+
+		c, err := loadCheckpoint(dir, a)
+		require.NoError(t, err)
+		require.NoError(t, c.add("file", 3))
+		ok, err := HasCheckpoint(dir)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		c, err = loadCheckpoint(dir, a)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"file": 3}, c.tableFiles())
+
+		c, err = loadCheckpoint(dir, b)
+		require.NoError(t, err)
+		assert.Empty(t, c.tableFiles())
+		ok, err = HasCheckpoint(dir)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+	t.Run("Unreadable", func(t *testing.T) {
+		dir := t.TempDir()
+		root := hash.Of([]byte("root"))
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, CheckpointsDir), os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, CheckpointsDir, root.String()+checkpointExt), []byte("{"), os.ModePerm))
+
+		c, err := loadCheckpoint(dir, root)
+		require.NoError(t, err)
+		assert.Empty(t, c.tableFiles())
+		require.NoError(t, c.remove())
+		require.NoError(t, c.remove())
+	})
+	t.Run("Key", func(t *testing.T) {
+		a, b := hash.Of([]byte("a")), hash.Of([]byte("b"))
+		assert.Equal(t, a, checkpointKey([]hash.Hash{a}))
+		assert.Equal(t, checkpointKey([]hash.Hash{a, b}), checkpointKey([]hash.Hash{b, a}))
+		assert.NotEqual(t, a, checkpointKey([]hash.Hash{a, b}))
+	})
+}
+
+func TestResumePull(t *testing.T) {
+	ctx := context.Background()
+	srcCS, rootAddr := makeCheckpointTestSource(t, ctx)
+	sinkCS := makeCheckpointTestSink(t, ctx)
+	tmpDir := t.TempDir()
+	waf, err := types.WalkAddrsForChunkStore(srcCS)
+	require.NoError(t, err)
+
+	plr, err := NewPuller(ctx, tmpDir, 128, srcCS, interruptedStore{sinkCS}, waf, []hash.Hash{rootAddr}, nil)
+	require.NoError(t, err)
+	require.ErrorIs(t, plr.Pull(ctx), errInterrupted)
+
+	ckpt, err := loadCheckpoint(tmpDir, rootAddr)
+	require.NoError(t, err)
+	require.NotEmpty(t, ckpt.tableFiles())
+	// A table file which is gone from the sink is dropped from the checkpoint, and its chunks are pulled again.
+	require.NoError(t, ckpt.add(hash.Of([]byte("missing")).String(), 1))
+
+	plr, err = NewPuller(ctx, tmpDir, 128, srcCS, sinkCS, waf, []hash.Hash{rootAddr}, nil)
+	require.NoError(t, err)
+	require.NoError(t, plr.Pull(ctx))
+	assert.Zero(t, plr.stats.fetchedSourceChunks)
+
+	ok, err := HasCheckpoint(tmpDir)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	ok, err = sinkCS.Has(ctx, rootAddr)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestResumeClone(t *testing.T) {
+	ctx := context.Background()
+	srcCS, rootAddr := makeCheckpointTestSource(t, ctx)
+	sinkCS := makeCheckpointTestSink(t, ctx)
+	tmpDir := t.TempDir()
+	getAddrs := func(c chunks.Chunk) chunks.GetAddrsCb {
+		return func(ctx context.Context, addrs hash.HashSet, _ chunks.PendingRefExists) error {
+			return types.AddrsFromNomsValue(c, types.Format_Default, addrs)
+		}
+	}
+
+	err := Clone(ctx, tmpDir, srcCS, interruptedStore{sinkCS}, getAddrs, nil)
+	require.ErrorIs(t, err, errInterrupted)
+	ok, err := HasCheckpoint(tmpDir)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	eventCh := make(chan TableFileEvent, 1024)
+	require.NoError(t, Clone(ctx, tmpDir, srcCS, sinkCS, getAddrs, eventCh))
+	close(eventCh)
+	var resumed int
+	for e := range eventCh {
+		assert.NotEqual(t, DownloadStart, e.EventType)
+		if e.EventType == Resumed {
+			resumed += len(e.TableFiles)
+		}
+	}
+	assert.NotZero(t, resumed)
+
+	ok, err = HasCheckpoint(tmpDir)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	sinkRoot, err := sinkCS.Root(ctx)
+	require.NoError(t, err)
+	srcRoot, err := srcCS.Root(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, srcRoot, sinkRoot)
+	ok, err = sinkCS.Has(ctx, rootAddr)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func makeCheckpointTestSource(t *testing.T, ctx context.Context) (*nbs.NomsBlockStore, hash.Hash) {
+	nbf := types.Format_Default.VersionString()
+	st, err := nbs.NewLocalStore(ctx, nbf, t.TempDir(), clienttest.DefaultMemTableSize, nbs.NewUnlimitedMemQuotaProvider())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		st.Close()
+	})
+
+	vs := types.NewValueStore(st)
+	db := datas.NewTypesDatabase(vs, tree.NewNodeStore(st))
+	tbl, err := makeABigTable(ctx, vs)
+	require.NoError(t, err)
+	ds, err := db.GetDataset(ctx, "ds")
+	require.NoError(t, err)
+	ds, err = db.Commit(ctx, ds, tbl, datas.CommitOptions{})
+	require.NoError(t, err)
+	addr, ok := ds.MaybeHeadAddr()
+	require.True(t, ok)
+	return st, addr
+}
+
+func makeCheckpointTestSink(t *testing.T, ctx context.Context) *nbs.GenerationalNBS {
+	nbf := types.Format_Default.VersionString()
+	q := nbs.NewUnlimitedMemQuotaProvider()
+	dir := t.TempDir()
+	oldGenDir := filepath.Join(dir, "oldgen")
+	require.NoError(t, os.MkdirAll(oldGenDir, os.ModePerm))
+	newGen, err := nbs.NewLocalStore(ctx, nbf, dir, clienttest.DefaultMemTableSize, q)
+	require.NoError(t, err)
+	oldGen, err := nbs.NewLocalStore(ctx, nbf, oldGenDir, clienttest.DefaultMemTableSize, q)
+	require.NoError(t, err)
+	gs := nbs.NewGenerationalCS(oldGen, newGen, nil)
+	t.Cleanup(func() {
+		gs.Close()
+	})
+	return gs
+}
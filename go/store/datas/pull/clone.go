@@ -32,7 +32,9 @@ import (
 var ErrNoData = errors.New("no data")
 var ErrCloneUnsupported = errors.New("clone unsupported")
 
-func Clone(ctx context.Context, srcCS, sinkCS chunks.ChunkStore, getAddrs chunks.GetAddrsCurry, eventCh chan<- TableFileEvent) error {
+// Clone copies the table files of |srcCS| into |sinkCS|. If |tempDir| is not empty, the table files copied so far are
+// checkpointed within it, and a Clone of the same source root which was interrupted resumes from the checkpoint.
+func Clone(ctx context.Context, tempDir string, srcCS, sinkCS chunks.ChunkStore, getAddrs chunks.GetAddrsCurry, eventCh chan<- TableFileEvent) error {
 	srcTS, srcOK := srcCS.(chunks.TableFileStore)
 
 	if !srcOK {
@@ -55,7 +57,7 @@ func Clone(ctx context.Context, srcCS, sinkCS chunks.ChunkStore, getAddrs chunks
 		return fmt.Errorf("%w: sink db is not a Table File Store", ErrCloneUnsupported)
 	}
 
-	return clone(ctx, srcTS, sinkTS, sinkCS, getAddrs, eventCh)
+	return clone(ctx, tempDir, srcTS, sinkTS, sinkCS, getAddrs, eventCh)
 }
 
 type CloneTableFileEvent int
@@ -66,6 +68,7 @@ const (
 	DownloadStats
 	DownloadSuccess
 	DownloadFailed
+	Resumed
 )
 
 type TableFileEvent struct {
@@ -93,12 +96,20 @@ func mapTableFiles(tblFiles []chunks.TableFile) ([]string, map[string]chunks.Tab
 
 const concurrentTableFileDownloads = 3
 
-func clone(ctx context.Context, srcTS, sinkTS chunks.TableFileStore, sinkCS chunks.ChunkStore, getAddrs chunks.GetAddrsCurry, eventCh chan<- TableFileEvent) error {
+func clone(ctx context.Context, tempDir string, srcTS, sinkTS chunks.TableFileStore, sinkCS chunks.ChunkStore, getAddrs chunks.GetAddrsCurry, eventCh chan<- TableFileEvent) error {
 	root, sourceFiles, appendixFiles, err := srcTS.Sources(ctx)
 	if err != nil {
 		return err
 	}
 
+	var ckpt *checkpoint
+	if tempDir != "" {
+		ckpt, err = loadCheckpoint(tempDir, root)
+		if err != nil {
+			return err
+		}
+	}
+
 	tblFiles := filterAppendicesFromSourceFiles(appendixFiles, sourceFiles)
 	report := func(e TableFileEvent) {
 		if eventCh != nil {
@@ -114,6 +125,20 @@ func clone(ctx context.Context, srcTS, sinkTS chunks.TableFileStore, sinkCS chun
 
 	report(TableFileEvent{EventType: Listed, TableFiles: tblFiles})
 
+	if ckpt != nil {
+		downloaded := ckpt.tableFiles()
+		var resumed []chunks.TableFile
+		for i, fileID := range desiredFiles {
+			if _, ok := downloaded[fileID]; ok {
+				completed[i] = true
+				resumed = append(resumed, fileIDToTF[fileID])
+			}
+		}
+		if len(resumed) > 0 {
+			report(TableFileEvent{EventType: Resumed, TableFiles: resumed})
+		}
+	}
+
 	download := func(ctx context.Context) error {
 		sem := semaphore.NewWeighted(concurrentTableFileDownloads)
 		eg, ctx := errgroup.WithContext(ctx)
@@ -160,6 +185,12 @@ func clone(ctx context.Context, srcTS, sinkTS chunks.TableFileStore, sinkCS chun
 					return err
 				}
 
+				if ckpt != nil {
+					if err = ckpt.add(fileID, tblFile.NumChunks()); err != nil {
+						return err
+					}
+				}
+
 				report(TableFileEvent{EventType: DownloadSuccess, TableFiles: []chunks.TableFile{tblFile}})
 				completed[idx] = true
 				return nil
@@ -230,18 +261,23 @@ func clone(ctx context.Context, srcTS, sinkTS chunks.TableFileStore, sinkCS chun
 	if err != nil {
 		return err
 	}
-	if !sinkRoot.IsEmpty() {
-		return nil
+	if sinkRoot.IsEmpty() {
+		success, err := sinkTS.Commit(ctx, root, hash.Hash{})
+		if !success && err == nil {
+			return errors.New("root update failure. optimistic lock failed")
+		}
+		if success && err != nil {
+			panic(fmt.Sprintf("runtime error: successful root update with error: %v", err))
+		}
+		if err != nil {
+			return err
+		}
 	}
 
-	success, err := sinkTS.Commit(ctx, root, hash.Hash{})
-	if !success && err == nil {
-		return errors.New("root update failure. optimistic lock failed")
-	}
-	if success && err != nil {
-		panic(fmt.Sprintf("runtime error: successful root update with error: %v", err))
+	if ckpt != nil {
+		return ckpt.remove()
 	}
-	return err
+	return nil
 }
 
 func filterAppendicesFromSourceFiles(appendixFiles []chunks.TableFile, sourceFiles []chunks.TableFile) []chunks.TableFile {
@@ -79,6 +79,15 @@ type PullTableFileWriterConfig struct {
 	DestStore DestTableFileStore
 
 	GetAddrs chunks.GetAddrsCurry
+
+	// Table files which an earlier, interrupted, pull already wrote
+	// to DestStore. They are added to its manifest along with the
+	// table files written by this writer.
+	TableFiles map[string]int
+
+	// If set, called with each table file once it has been written to
+	// DestStore, so that the pull can be resumed from it.
+	Checkpoint func(id string, numChunks int) error
 }
 
 type DestTableFileStore interface {
@@ -166,17 +175,15 @@ func (w *PullTableFileWriter) uploadAndFinalizeThread() (err error) {
 	// to always be closed after uploadEg is done and we are going to check
 	// for errors later.
 	manifestUpdates := make(map[string]int)
+	for id, numChunks := range w.cfg.TableFiles {
+		manifestUpdates[id] = numChunks
+	}
 	var manifestWg sync.WaitGroup
 	manifestWg.Add(1)
 	go func() {
 		defer manifestWg.Done()
 		for ttf := range respCh {
-			id := ttf.id
-			if strings.HasSuffix(id, nbs.ArchiveFileSuffix) {
-				id = strings.TrimSuffix(id, nbs.ArchiveFileSuffix)
-			}
-
-			manifestUpdates[id] = ttf.numChunks
+			manifestUpdates[manifestFileId(ttf.id)] = ttf.numChunks
 		}
 	}()
 
@@ -318,6 +325,13 @@ func (w *PullTableFileWriter) uploadThread(ctx context.Context, reqCh chan nbs.G
 				return err
 			}
 
+			if w.cfg.Checkpoint != nil {
+				err = w.cfg.Checkpoint(manifestFileId(ttf.id), ttf.numChunks)
+				if err != nil {
+					return err
+				}
+			}
+
 			select {
 			case respCh <- ttf:
 			case <-ctx.Done():
@@ -329,6 +343,12 @@ func (w *PullTableFileWriter) uploadThread(ctx context.Context, reqCh chan nbs.G
 	}
 }
 
+// manifestFileId returns the id which the uploaded table file |id| is added
+// to the manifest with.
+func manifestFileId(id string) string {
+	return strings.TrimSuffix(id, nbs.ArchiveFileSuffix)
+}
+
 func (w *PullTableFileWriter) uploadTempTableFile(ctx context.Context, tmpTblFile tempTblFile) error {
 	fileSize := tmpTblFile.contentLen
 
@@ -53,6 +53,12 @@ type Puller struct {
 	sinkDBCS      chunks.ChunkStore
 	hashes        hash.HashSet
 
+	// The checkpoint of this pull, and the chunks which an earlier,
+	// interrupted, run of it already wrote to the sink. Nil and empty
+	// if the sink isn't a ResumableTableFileStore.
+	ckpt    *checkpoint
+	resumed hash.HashSet
+
 	wr *PullTableFileWriter
 	rd nbs.ChunkFetcher
 
@@ -113,14 +119,34 @@ func NewPuller(
 		}
 	}
 
-	wr := NewPullTableFileWriter(ctx, PullTableFileWriterConfig{
+	targets := hash.NewHashSet(hashes...)
+	resumed := hash.NewHashSet()
+	wrCfg := PullTableFileWriterConfig{
 		ConcurrentUploads:    2,
 		ChunksPerFile:        chunksPerTF,
 		MaximumBufferedFiles: 8,
 		TempDir:              tempDir,
 		DestStore:            sinkCS.(chunks.TableFileStore),
 		GetAddrs:             getAddrs,
-	})
+	}
+
+	var ckpt *checkpoint
+	if rs, ok := sinkCS.(ResumableTableFileStore); ok {
+		ckpt, err = loadCheckpoint(tempDir, checkpointKey(hashes))
+		if err != nil {
+			return nil, err
+		}
+		var frontier hash.HashSet
+		resumed, frontier, err = resumeFromCheckpoint(ctx, rs, ckpt, walkAddrs)
+		if err != nil {
+			return nil, err
+		}
+		targets.InsertAll(frontier)
+		wrCfg.TableFiles = ckpt.tableFiles()
+		wrCfg.Checkpoint = ckpt.add
+	}
+
+	wr := NewPullTableFileWriter(ctx, wrCfg)
 
 	rd := GetChunkFetcher(ctx, srcChunkStore)
 
@@ -138,7 +164,9 @@ func NewPuller(
 		waf:           walkAddrs,
 		srcChunkStore: srcChunkStore,
 		sinkDBCS:      sinkCS,
-		hashes:        hash.NewHashSet(hashes...),
+		hashes:        targets,
+		ckpt:          ckpt,
+		resumed:       resumed,
 		wr:            wr,
 		rd:            rd,
 		pushLog:       pushLogger,
@@ -155,6 +183,65 @@ func NewPuller(
 	return p, nil
 }
 
+// resumeFromCheckpoint reads back the table files in |ckpt|, which an
+// interrupted pull wrote to |sink|. It returns the chunks they hold, and the
+// chunks they reference but don't hold, which the pull still has to visit.
+// Table files which can no longer be read are dropped from the checkpoint.
+func resumeFromCheckpoint(ctx context.Context, sink ResumableTableFileStore, ckpt *checkpoint, walkAddrs WalkAddrs) (hash.HashSet, hash.HashSet, error) {
+	resumed := hash.NewHashSet()
+	refs := hash.NewHashSet()
+	for id, numChunks := range ckpt.tableFiles() {
+		fileChunks := hash.NewHashSet()
+		fileRefs := hash.NewHashSet()
+		err := sink.IterateTableFileChunks(ctx, id, numChunks, func(c chunks.Chunk) error {
+			fileChunks.Insert(c.Hash())
+			return walkAddrs(c, func(h hash.Hash, _ bool) error {
+				fileRefs.Insert(h)
+				return nil
+			})
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, nil, ctx.Err()
+			}
+			if err = ckpt.drop(id); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+		resumed.InsertAll(fileChunks)
+		refs.InsertAll(fileRefs)
+	}
+
+	frontier := hash.NewHashSet()
+	for h := range refs {
+		if !resumed.Has(h) {
+			frontier.Insert(h)
+		}
+	}
+	return resumed, frontier, nil
+}
+
+// resumedHasManyer reports the chunks written by an interrupted run of a
+// pull as present in the sink, although they are not in its manifest yet.
+type resumedHasManyer struct {
+	HasManyer
+	resumed hash.HashSet
+}
+
+func (r resumedHasManyer) HasMany(ctx context.Context, hashes hash.HashSet) (hash.HashSet, error) {
+	absent, err := r.HasManyer.HasMany(ctx, hashes)
+	if err != nil {
+		return nil, err
+	}
+	for h := range absent {
+		if r.resumed.Has(h) {
+			absent.Remove(h)
+		}
+	}
+	return absent, nil
+}
+
 func (p *Puller) Logf(fmt string, args ...interface{}) {
 	if p.pushLog != nil {
 		p.pushLog.Printf(fmt, args...)
@@ -314,9 +401,13 @@ func (p *Puller) Pull(ctx context.Context) error {
 	eg, ctx := errgroup.WithContext(ctx)
 
 	const batchSize = 64 * 1024
+	var hasManyer HasManyer = p.sinkDBCS
+	if p.resumed.Size() > 0 {
+		hasManyer = resumedHasManyer{HasManyer: p.sinkDBCS, resumed: p.resumed}
+	}
 	tracker := NewPullChunkTracker(ctx, p.hashes, TrackerConfig{
 		BatchSize: batchSize,
-		HasManyer: p.sinkDBCS,
+		HasManyer: hasManyer,
 	})
 
 	// One thread calls ChunkFetcher.Get on each batch.
@@ -395,5 +486,11 @@ func (p *Puller) Pull(ctx context.Context) error {
 	// errgroup will report the error.
 	wErr := eg.Wait()
 	rErr := p.rd.Close()
-	return errors.Join(wErr, rErr)
+	if err := errors.Join(wErr, rErr); err != nil {
+		return err
+	}
+	if p.ckpt != nil {
+		return p.ckpt.remove()
+	}
+	return nil
 }
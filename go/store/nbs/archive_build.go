@@ -114,57 +114,41 @@ func UnArchive(ctx context.Context, cs chunks.ChunkStore, smd StorageMetadata, p
 	return nil
 }
 
-func BuildArchive(ctx context.Context, cs chunks.ChunkStore, dagGroups *ChunkRelations, progress chan interface{}) (err error) {
+// ChunkRelater returns the relations of the chunks to archive, those for which |include| returns true. Other chunks
+// needn't be related, nor walked through.
+type ChunkRelater func(ctx context.Context, include func(hash.Hash) (bool, error)) (ChunkRelations, error)
+
+func BuildArchive(ctx context.Context, cs chunks.ChunkStore, relate ChunkRelater, progress chan interface{}) (err error) {
 	// Currently, we don't have any stats to report. Required for calls to the lower layers tho.
 	var stats Stats
 
 	if gs, ok := cs.(*GenerationalNBS); ok {
 		outPath, _ := gs.oldGen.Path()
-		oldgen := gs.oldGen.tables.upstream
-
-		swapMap := make(map[hash.Hash]hash.Hash)
-
-		for tf, ogcs := range oldgen {
-			if _, ok := ogcs.(archiveChunkSource); ok {
-				continue
-			}
-
-			idx, err := ogcs.index()
-			if err != nil {
-				return err
-			}
-
-			originalSize := idx.tableFileSize()
 
-			archivePath := ""
-			archiveName := hash.Hash{}
-			archivePath, archiveName, err = convertTableFileToArchive(ctx, ogcs, idx, dagGroups, outPath, progress, &stats)
-			if err != nil {
-				return err
+		sources := make(map[hash.Hash]chunkSource)
+		for tf, ogcs := range gs.oldGen.tables.upstream {
+			if _, ok := ogcs.(archiveChunkSource); !ok {
+				sources[tf] = ogcs
 			}
+		}
+		if len(sources) == 0 {
+			return fmt.Errorf("No tables found to archive. Run 'dolt gc' first")
+		}
 
-			fileInfo, err := os.Stat(archivePath)
-			if err != nil {
-				progress <- "Failed to stat archive file"
-				return err
-			}
-			archiveSize := fileInfo.Size()
+		dagGroups, err := relate(ctx, sourcesHave(sources))
+		if err != nil {
+			return err
+		}
 
-			err = verifyAllChunks(ctx, idx, archivePath, progress, &stats)
+		swapMap := make(map[hash.Hash]hash.Hash)
+		for tf, ogcs := range sources {
+			archiveName, err := archiveTableFile(ctx, ogcs, &dagGroups, outPath, progress, &stats)
 			if err != nil {
 				return err
 			}
-
-			percentReduction := -100.0 * (float64(archiveSize)/float64(originalSize) - 1.0)
-			progress <- fmt.Sprintf("Archived %s (%d -> %d bytes, %.2f%% reduction)", archiveName, originalSize, archiveSize, percentReduction)
-
 			swapMap[tf] = archiveName
 		}
 
-		if len(swapMap) == 0 {
-			return fmt.Errorf("No tables found to archive. Run 'dolt gc' first")
-		}
-
 		//NM4 TODO: This code path must only be run on an offline database. We should add a check for that.
 		specs, err := gs.oldGen.tables.toSpecs()
 		newSpecs := make([]tableSpec, 0, len(specs))
@@ -185,6 +169,169 @@ func BuildArchive(ctx context.Context, cs chunks.ChunkStore, dagGroups *ChunkRel
 	return nil
 }
 
+// ArchiveOldGenTableFiles converts the table files of the old generation of |cs| into archives, like BuildArchive, but
+// is safe to run while the database is in use. Table files with too few chunks to train a dictionary are left as they
+// are, and the table files which are archived are removed. |relate| is only called if there are table files to archive.
+// Returns the number of table files which were archived.
+func ArchiveOldGenTableFiles(ctx context.Context, cs chunks.ChunkStore, relate ChunkRelater, progress chan interface{}) (int, error) {
+	gs, ok := cs.(*GenerationalNBS)
+	if !ok {
+		return 0, errors.New("Modern DB Expected")
+	}
+	outPath, ok := gs.oldGen.Path()
+	if !ok {
+		return 0, nil
+	}
+
+	// The sources are cloned, so that they stay open if a GC swaps them out of the store while they're archived.
+	sources, err := gs.unarchivedOldGenSources()
+	if err != nil {
+		return 0, err
+	}
+	swapMap, err := relateAndArchiveSources(ctx, sources, relate, outPath, progress)
+	for _, src := range sources {
+		src.close()
+	}
+	if err != nil || len(swapMap) == 0 {
+		return 0, err
+	}
+
+	replaced, err := gs.oldGen.replaceTableFiles(ctx, swapMap)
+	if err != nil {
+		return 0, err
+	}
+	// Only the replaced table files are removed. Pruning the old generation could remove a file which a concurrent GC
+	// has written, but not yet added to the manifest.
+	if err = removeTableFiles(outPath, replaced); err != nil {
+		return 0, err
+	}
+	for tf, archiveName := range swapMap {
+		if _, ok := replaced[tf]; !ok {
+			err = os.Remove(filepath.Join(outPath, archiveName.String()+ArchiveFileSuffix))
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return 0, err
+			}
+		}
+	}
+	return len(replaced), nil
+}
+
+// removeTableFiles removes the table files named by the keys of |files| from |dir|.
+func removeTableFiles[V any](dir string, files map[hash.Hash]V) error {
+	for name := range files {
+		err := os.Remove(filepath.Join(dir, name.String()))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// relateAndArchiveSources relates the chunks of |sources| with |relate|, converts each of |sources| into an archive in
+// |outPath|, and returns the name of the archive of each.
+func relateAndArchiveSources(ctx context.Context, sources map[hash.Hash]chunkSource, relate ChunkRelater, outPath string, progress chan interface{}) (map[hash.Hash]hash.Hash, error) {
+	swapMap := make(map[hash.Hash]hash.Hash)
+	if len(sources) == 0 {
+		return swapMap, nil
+	}
+	dagGroups, err := relate(ctx, sourcesHave(sources))
+	if err != nil {
+		return nil, err
+	}
+
+	var stats Stats
+	for tf, src := range sources {
+		archiveName, err := archiveTableFile(ctx, src, &dagGroups, outPath, progress, &stats)
+		if err != nil {
+			return nil, err
+		}
+		swapMap[tf] = archiveName
+	}
+	return swapMap, nil
+}
+
+// sourcesHave returns a function which returns true if any of |sources| holds a chunk.
+func sourcesHave(sources map[hash.Hash]chunkSource) func(hash.Hash) (bool, error) {
+	return func(h hash.Hash) (bool, error) {
+		for _, src := range sources {
+			has, _, err := src.has(h, nil)
+			if err != nil || has {
+				return has, err
+			}
+		}
+		return false, nil
+	}
+}
+
+// unarchivedOldGenSources returns clones of the old generation's table files which aren't archives, and which have
+// enough chunks to train a dictionary. The caller must close them.
+func (gcs *GenerationalNBS) unarchivedOldGenSources() (map[hash.Hash]chunkSource, error) {
+	gcs.oldGen.mu.RLock()
+	defer gcs.oldGen.mu.RUnlock()
+
+	sources := make(map[hash.Hash]chunkSource)
+	for tf, src := range gcs.oldGen.tables.upstream {
+		if _, ok := src.(archiveChunkSource); ok {
+			continue
+		}
+		cnt, err := src.count()
+		if err != nil {
+			return nil, err
+		}
+		if cnt < minSamples {
+			continue
+		}
+		clone, err := src.clone()
+		if err != nil {
+			for _, src := range sources {
+				src.close()
+			}
+			return nil, err
+		}
+		sources[tf] = clone
+	}
+	return sources, nil
+}
+
+// archiveTableFile converts |cs| into an archive in |outPath|, verifies it and returns its name.
+func archiveTableFile(
+	ctx context.Context,
+	cs chunkSource,
+	dagGroups *ChunkRelations,
+	outPath string,
+	progress chan interface{},
+	stats *Stats,
+) (hash.Hash, error) {
+	idx, err := cs.index()
+	if err != nil {
+		return hash.Hash{}, err
+	}
+
+	originalSize := idx.tableFileSize()
+
+	archivePath, archiveName, err := convertTableFileToArchive(ctx, cs, idx, dagGroups, outPath, progress, stats)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+
+	fileInfo, err := os.Stat(archivePath)
+	if err != nil {
+		progress <- "Failed to stat archive file"
+		return hash.Hash{}, err
+	}
+	archiveSize := fileInfo.Size()
+
+	err = verifyAllChunks(ctx, idx, archivePath, progress, stats)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+
+	percentReduction := -100.0 * (float64(archiveSize)/float64(originalSize) - 1.0)
+	progress <- fmt.Sprintf("Archived %s (%d -> %d bytes, %.2f%% reduction)", archiveName, originalSize, archiveSize, percentReduction)
+
+	return archiveName, nil
+}
+
 func convertTableFileToArchive(
 	ctx context.Context,
 	cs chunkSource,
@@ -579,6 +726,19 @@ func padSamples(chks []*chunks.Chunk) []*chunks.Chunk {
 	return samples
 }
 
+// sampleChunks returns at most |maxSamples| of |chks|, evenly spaced, to train a dictionary with. Groups made of whole
+// tables can be far larger than is useful, or affordable, to train on.
+func sampleChunks(chks []*chunks.Chunk) []*chunks.Chunk {
+	if len(chks) <= maxSamples {
+		return chks
+	}
+	samples := make([]*chunks.Chunk, maxSamples)
+	for i := range samples {
+		samples[i] = chks[i*len(chks)/maxSamples]
+	}
+	return samples
+}
+
 // Add this chunk into the group. It does not attempt to determine if this is a good addition. The caller should
 // use testChunk to determine if this chunk should be added.
 //
@@ -648,7 +808,7 @@ func (cg *chunkGroup) rebuild(ctx context.Context, chunkCache *simpleChunkSource
 		totalBytes += len(c.Data())
 	}
 
-	dct := buildDictionary(padSamples(chks))
+	dct := buildDictionary(padSamples(sampleChunks(chks)))
 
 	var cDict *gozstd.CDict
 	cDict, err := gozstd.NewCDict(dct)
@@ -790,9 +950,20 @@ func (cr *ChunkRelations) convertToChunkGroups(
 			})
 		}
 
-		// Send groups to process
+		// Send groups to process. Groups may relate chunks from across the database, so only those in this table file
+		// are kept.
 		for _, v := range groups {
-			groupChannel <- v
+			present := hash.NewHashSet()
+			for h := range v {
+				has, err := chks.has(h)
+				if err != nil {
+					return nil, err
+				}
+				if has {
+					present.Insert(h)
+				}
+			}
+			groupChannel <- present
 		}
 		close(groupChannel)
 
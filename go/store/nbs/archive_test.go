@@ -22,6 +22,8 @@ import (
 	"io"
 	"math"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/dolthub/gozstd"
@@ -774,6 +776,65 @@ func TestArchiveChunkGroup(t *testing.T) {
 	assertIntBetween(t, cg.avgRawChunkSize, 990, 1010)
 }
 
+func TestArchiveOldGenTableFiles(t *testing.T) {
+	ctx := context.Background()
+	oldGen, oldGenDir, _ := makeTestLocalStore(t, 64)
+	newGen, _, _ := makeTestLocalStore(t, 64)
+	gcs := NewGenerationalCS(oldGen, newGen, nil)
+
+	similar, _, _ := generateSimilarChunks(42, 40)
+	chnks := make([]chunks.Chunk, len(similar))
+	for i, c := range similar {
+		chnks[i] = *c
+	}
+	archivedFile := putTableFile(t, ctx, oldGen, chnks[:36]...)
+	smallFile := putTableFile(t, ctx, oldGen, chnks[36:]...)
+
+	related := 0
+	relate := func(ctx context.Context, include func(hash.Hash) (bool, error)) (ChunkRelations, error) {
+		related++
+		// only the chunks of the table file archived are included
+		for i, c := range chnks {
+			has, err := include(c.Hash())
+			require.NoError(t, err)
+			assert.Equal(t, i < 36, has, "index %d", i)
+		}
+
+		relations := NewChunkRelations()
+		for _, c := range chnks[1:20] {
+			relations.Add(chnks[0].Hash(), c.Hash())
+		}
+		return relations, nil
+	}
+	progress := make(chan interface{})
+	go func() {
+		for range progress {
+		}
+	}()
+	defer close(progress)
+	n, err := ArchiveOldGenTableFiles(ctx, gcs, relate, progress)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, 1, related)
+
+	_, err = os.Stat(filepath.Join(oldGenDir, archivedFile.String()))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(oldGenDir, smallFile.String()))
+	assert.NoError(t, err)
+
+	for i, c := range chnks {
+		got, err := gcs.Get(ctx, c.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, c.Data(), got.Data(), "index %d", i)
+	}
+
+	// the small table file is too small to archive, and the chunks aren't related when nothing is archived
+	n, err = ArchiveOldGenTableFiles(ctx, gcs, relate, progress)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+	assert.Equal(t, 1, related)
+}
+
 func assertFloatBetween(t *testing.T, actual, min, max float64) {
 	if actual < min || actual > max {
 		t.Errorf("Expected %f to be between %f and %f", actual, min, max)
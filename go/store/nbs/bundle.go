@@ -0,0 +1,345 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// A bundle packs the manifest and table files of a blobstore backed NomsBlockStore into a single file, so that the
+// store can be copied as a file and read without being unpacked. A bundle may hold only part of the chunks reachable
+// from its root, when the chunks reachable from its prerequisite commits are left out.
+//
+//	+-------+-----------+-----+-----------+-------+--------+
+//	| Magic | Section 0 | ... | Section N | Index | Footer |
+//	+-------+-----------+-----+-----------+-------+--------+
+//
+// Sections hold the manifest, each table file, and the list of prerequisite commits.
+//
+// Index:
+//
+//	+-----------------------+---------+-----+---------+
+//	| (uint32) SectionCount | Entry 0 | ... | Entry N |
+//	+-----------------------+---------+-----+---------+
+//
+// Entry:
+//
+//	+-------------------+------+-----------------+-----------------+
+//	| (uint16) NameSize | Name | (uint64) Offset | (uint64) Length |
+//	+-------------------+------+-----------------+-----------------+
+//
+// Footer:
+//
+//	+----------------------+------------------------+-------+
+//	| (uint64) IndexOffset | (uint32) FormatVersion | Magic |
+//	+----------------------+------------------------+-------+
+const (
+	bundleMagic         = "DOLTBNDL"
+	bundleFormatVersion = uint32(1)
+	bundleFooterSize    = 8 + 4 + len(bundleMagic)
+
+	bundlePrerequisites = "prerequisites"
+)
+
+// ErrNotABundle is returned when opening a file which isn't a bundle as a bundle.
+var ErrNotABundle = errors.New("not a bundle file")
+
+// ErrBundleReadOnly is returned when writing to a bundle.
+var ErrBundleReadOnly = errors.New("bundles are read-only")
+
+type bundleSection struct {
+	offset int64
+	length int64
+}
+
+// Bundle is a bundle file opened for reading.
+type Bundle struct {
+	path     string
+	sections map[string]bundleSection
+	prereqs  []hash.Hash
+}
+
+// WriteBundle writes the manifest and table files of the store in |bs| to |wr| as a bundle whose prerequisite commits
+// are |prereqs|. The store must not be written to while it's bundled.
+func WriteBundle(ctx context.Context, bs blobstore.Blobstore, prereqs []hash.Hash, wr io.Writer) error {
+	_, contents, err := manifestVersionAndContents(ctx, bs)
+	if err != nil {
+		return err
+	}
+
+	keys := []string{manifestFile}
+	for _, spec := range contents.specs {
+		keys = append(keys, spec.name.String())
+	}
+
+	var prereqData bytes.Buffer
+	for _, h := range prereqs {
+		prereqData.WriteString(h.String())
+		prereqData.WriteByte('\n')
+	}
+
+	bw := &countingWriter{wr: wr}
+	if _, err = bw.Write([]byte(bundleMagic)); err != nil {
+		return err
+	}
+
+	sections := make([]string, 0, len(keys)+1)
+	offsets := make(map[string]bundleSection, len(keys)+1)
+	for _, key := range keys {
+		rc, _, err := bs.Get(ctx, key, blobstore.AllRange)
+		if err != nil {
+			return err
+		}
+		start := bw.n
+		_, err = io.Copy(bw, rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+		sections = append(sections, key)
+		offsets[key] = bundleSection{offset: start, length: bw.n - start}
+	}
+
+	start := bw.n
+	if _, err = bw.Write(prereqData.Bytes()); err != nil {
+		return err
+	}
+	sections = append(sections, bundlePrerequisites)
+	offsets[bundlePrerequisites] = bundleSection{offset: start, length: bw.n - start}
+
+	indexOffset := bw.n
+	var index bytes.Buffer
+	_ = binary.Write(&index, binary.BigEndian, uint32(len(sections)))
+	for _, name := range sections {
+		_ = binary.Write(&index, binary.BigEndian, uint16(len(name)))
+		index.WriteString(name)
+		_ = binary.Write(&index, binary.BigEndian, uint64(offsets[name].offset))
+		_ = binary.Write(&index, binary.BigEndian, uint64(offsets[name].length))
+	}
+	_ = binary.Write(&index, binary.BigEndian, uint64(indexOffset))
+	_ = binary.Write(&index, binary.BigEndian, bundleFormatVersion)
+	index.WriteString(bundleMagic)
+
+	_, err = bw.Write(index.Bytes())
+	return err
+}
+
+type countingWriter struct {
+	wr io.Writer
+	n  int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.wr.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+// IsBundle returns whether the file at |path| is a bundle.
+func IsBundle(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	magic := make([]byte, len(bundleMagic))
+	_, err = io.ReadFull(f, magic)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return string(magic) == bundleMagic, nil
+}
+
+// OpenBundle reads the index of the bundle at |path|.
+func OpenBundle(path string) (*Bundle, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := info.Size()
+	if size < int64(len(bundleMagic)+bundleFooterSize) {
+		return nil, ErrNotABundle
+	}
+
+	magic := make([]byte, len(bundleMagic))
+	if _, err = f.ReadAt(magic, 0); err != nil {
+		return nil, err
+	}
+	footer := make([]byte, bundleFooterSize)
+	if _, err = f.ReadAt(footer, size-int64(bundleFooterSize)); err != nil {
+		return nil, err
+	}
+	if string(magic) != bundleMagic || string(footer[12:]) != bundleMagic {
+		return nil, ErrNotABundle
+	}
+
+	indexOffset := int64(binary.BigEndian.Uint64(footer[:8]))
+	if vers := binary.BigEndian.Uint32(footer[8:12]); vers != bundleFormatVersion {
+		return nil, fmt.Errorf("unsupported bundle format version %d", vers)
+	}
+	indexEnd := size - int64(bundleFooterSize)
+	if indexOffset < int64(len(bundleMagic)) || indexOffset > indexEnd {
+		return nil, fmt.Errorf("corrupt bundle: invalid index offset %d", indexOffset)
+	}
+
+	index := make([]byte, indexEnd-indexOffset)
+	if _, err = f.ReadAt(index, indexOffset); err != nil {
+		return nil, err
+	}
+	sections, err := parseBundleIndex(index, indexOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Bundle{path: path, sections: sections}
+	if _, ok := sections[manifestFile]; !ok {
+		return nil, errors.New("corrupt bundle: missing manifest")
+	}
+	if sec, ok := sections[bundlePrerequisites]; ok {
+		data := make([]byte, sec.length)
+		if _, err = f.ReadAt(data, sec.offset); err != nil {
+			return nil, err
+		}
+		for _, line := range strings.Fields(string(data)) {
+			h, ok := hash.MaybeParse(line)
+			if !ok {
+				return nil, fmt.Errorf("corrupt bundle: invalid prerequisite commit %s", line)
+			}
+			b.prereqs = append(b.prereqs, h)
+		}
+	}
+	return b, nil
+}
+
+func parseBundleIndex(index []byte, indexOffset int64) (map[string]bundleSection, error) {
+	errCorrupt := errors.New("corrupt bundle: invalid index")
+	if len(index) < 4 {
+		return nil, errCorrupt
+	}
+	count := binary.BigEndian.Uint32(index)
+	index = index[4:]
+
+	sections := make(map[string]bundleSection, count)
+	for i := uint32(0); i < count; i++ {
+		if len(index) < 2 {
+			return nil, errCorrupt
+		}
+		nameLen := int(binary.BigEndian.Uint16(index))
+		index = index[2:]
+		if len(index) < nameLen+16 {
+			return nil, errCorrupt
+		}
+		name := string(index[:nameLen])
+		sec := bundleSection{
+			offset: int64(binary.BigEndian.Uint64(index[nameLen:])),
+			length: int64(binary.BigEndian.Uint64(index[nameLen+8:])),
+		}
+		index = index[nameLen+16:]
+		if sec.offset < int64(len(bundleMagic)) || sec.length < 0 || sec.offset+sec.length > indexOffset {
+			return nil, errCorrupt
+		}
+		sections[name] = sec
+	}
+	return sections, nil
+}
+
+// Path returns the path of the bundle file.
+func (b *Bundle) Path() string {
+	return b.path
+}
+
+// Prerequisites returns the commits whose chunks were left out of the bundle. The bundle can only be read into a
+// store which has these commits.
+func (b *Bundle) Prerequisites() []hash.Hash {
+	return b.prereqs
+}
+
+// Blobstore returns a read-only Blobstore of the manifest and table files of the bundle.
+func (b *Bundle) Blobstore() blobstore.Blobstore {
+	return bundleBlobstore{b}
+}
+
+// NewBundleStore returns a read-only NomsBlockStore of the chunks of the bundle |b|.
+func NewBundleStore(ctx context.Context, nbfVerStr string, b *Bundle, q MemoryQuotaProvider) (*NomsBlockStore, error) {
+	return NewUnsealedNoConjoinBSStore(ctx, nbfVerStr, b.Blobstore(), defaultMemTableSize, q)
+}
+
+// bundleBlobstore is a read-only blobstore.Blobstore of the sections of a bundle.
+type bundleBlobstore struct {
+	b *Bundle
+}
+
+var _ blobstore.Blobstore = bundleBlobstore{}
+
+func (bbs bundleBlobstore) Path() string {
+	return bbs.b.path
+}
+
+func (bbs bundleBlobstore) Exists(ctx context.Context, key string) (bool, error) {
+	_, ok := bbs.b.sections[key]
+	return ok, nil
+}
+
+func (bbs bundleBlobstore) Get(ctx context.Context, key string, br blobstore.BlobRange) (io.ReadCloser, string, error) {
+	sec, ok := bbs.b.sections[key]
+	if !ok {
+		return nil, "", blobstore.NotFound{Key: key}
+	}
+
+	offset, length := br.Bounds(sec.length)
+	f, err := os.Open(bbs.b.path)
+	if err != nil {
+		return nil, "", err
+	}
+	rd := io.NewSectionReader(f, sec.offset+offset, length)
+	// bundles are never modified, so every blob has the same version
+	return readCloser{Reader: rd, Closer: f}, bundleMagic, nil
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+func (bbs bundleBlobstore) Put(ctx context.Context, key string, totalSize int64, reader io.Reader) (string, error) {
+	return "", ErrBundleReadOnly
+}
+
+func (bbs bundleBlobstore) CheckAndPut(ctx context.Context, expectedVersion, key string, totalSize int64, reader io.Reader) (string, error) {
+	return "", ErrBundleReadOnly
+}
+
+func (bbs bundleBlobstore) Concatenate(ctx context.Context, key string, sources []string) (string, error) {
+	return "", ErrBundleReadOnly
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/constants"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+func TestBundleRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	bs := blobstore.NewInMemoryBlobstore("")
+	store, err := NewNoConjoinBSStore(ctx, constants.FormatDefaultString, bs, defaultMemTableSize, &UnlimitedQuotaProvider{})
+	require.NoError(t, err)
+
+	// commit the chunks in two table files
+	var inputs []chunks.Chunk
+	for _, batch := range [][]string{{"abc", "def"}, {"ghi"}} {
+		for _, data := range batch {
+			c := chunks.NewChunk([]byte(data))
+			require.NoError(t, store.Put(ctx, c, noopGetAddrs))
+			inputs = append(inputs, c)
+		}
+		last, err := store.Root(ctx)
+		require.NoError(t, err)
+		ok, err := store.Commit(ctx, inputs[len(inputs)-1].Hash(), last)
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+	require.NoError(t, store.Close())
+
+	prereq := hash.Of([]byte("prerequisite"))
+	path := filepath.Join(t.TempDir(), "test.bundle")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	require.NoError(t, WriteBundle(ctx, bs, []hash.Hash{prereq}, f))
+	require.NoError(t, f.Close())
+
+	isBundle, err := IsBundle(path)
+	require.NoError(t, err)
+	assert.True(t, isBundle)
+
+	b, err := OpenBundle(path)
+	require.NoError(t, err)
+	assert.Equal(t, []hash.Hash{prereq}, b.Prerequisites())
+
+	bundled, err := NewBundleStore(ctx, constants.FormatDefaultString, b, &UnlimitedQuotaProvider{})
+	require.NoError(t, err)
+	defer bundled.Close()
+
+	root, err := bundled.Root(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, inputs[len(inputs)-1].Hash(), root)
+	for _, c := range inputs {
+		assertInputInStore(c.Data(), c.Hash(), bundled, assert.New(t))
+	}
+
+	_, err = bundled.Commit(ctx, inputs[0].Hash(), root)
+	assert.Error(t, err)
+}
+
+func TestOpenBundleRejectsOtherFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	empty := filepath.Join(dir, "empty")
+	require.NoError(t, os.WriteFile(empty, nil, 0644))
+	isBundle, err := IsBundle(empty)
+	require.NoError(t, err)
+	assert.False(t, isBundle)
+
+	other := filepath.Join(dir, "other")
+	require.NoError(t, os.WriteFile(other, []byte("this is not a bundle, but it is long enough to be one"), 0644))
+	isBundle, err = IsBundle(other)
+	require.NoError(t, err)
+	assert.False(t, isBundle)
+	_, err = OpenBundle(other)
+	assert.ErrorIs(t, err, ErrNotABundle)
+
+	truncated := filepath.Join(dir, "truncated")
+	require.NoError(t, os.WriteFile(truncated, []byte(bundleMagic+"data"), 0644))
+	_, err = OpenBundle(truncated)
+	assert.ErrorIs(t, err, ErrNotABundle)
+}
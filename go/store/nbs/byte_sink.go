@@ -30,7 +30,16 @@ import (
 	"github.com/dolthub/dolt/go/store/util/tempfiles"
 )
 
-func flushSinkToFile(sink ByteSink, path string) (err error) {
+func flushSinkToFile(sink ByteSink, path string) error {
+	kr, err := encryptionKeyring()
+	if err != nil {
+		return err
+	}
+	return writeSinkToFile(sink, path, kr)
+}
+
+// writeSinkToFile writes the data of |sink| to a file at |path|, which is sealed with |kr| unless it's nil.
+func writeSinkToFile(sink ByteSink, path string, kr *keyring) (err error) {
 	var f *os.File
 	f, err = os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.ModePerm)
 
@@ -46,8 +55,14 @@ func flushSinkToFile(sink ByteSink, path string) (err error) {
 		}
 	}()
 
-	err = sink.Flush(f)
-	return err
+	wr, err := newTableFileWriter(f, kr)
+	if err != nil {
+		return err
+	}
+	if err = sink.Flush(wr); err != nil {
+		return err
+	}
+	return wr.Close()
 }
 
 // A ByteSink is an interface for writing bytes which can later be flushed to a writer
@@ -299,12 +314,29 @@ func (sink *BufferedFileByteSink) Flush(wr io.Writer) (err error) {
 }
 
 // FlushToFile writes all the data that was written to the ByteSink to a file at the given path
-func (sink *BufferedFileByteSink) FlushToFile(path string) (err error) {
+func (sink *BufferedFileByteSink) FlushToFile(path string) error {
+	kr, err := encryptionKeyring()
+	if err != nil {
+		return err
+	}
+	return sink.flushToFile(path, kr)
+}
+
+// flushToFile is FlushToFile, but seals the file with |kr| unless it's nil.
+func (sink *BufferedFileByteSink) flushToFile(path string, kr *keyring) (err error) {
 	err = sink.finish()
 	if err != nil {
 		return err
 	}
 
+	if kr != nil {
+		// the buffered data is plaintext, so it's sealed into a new file rather than moved
+		if err = writeSinkToFile(sink, path, kr); err != nil {
+			return err
+		}
+		return os.Remove(sink.path)
+	}
+
 	return file.Rename(sink.path, path)
 }
 
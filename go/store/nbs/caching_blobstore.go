@@ -0,0 +1,335 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+const (
+	// cacheBlockSize is the size of the aligned blocks of table files which are cached by a cachingBlobstore.
+	cacheBlockSize = 1 << 20
+
+	// cacheMaxRead is the largest read served through the cache. Larger reads, like those of whole table files,
+	// go straight to the underlying blobstore.
+	cacheMaxRead = 16 * cacheBlockSize
+
+	cacheTempPrefix = "tmp-"
+)
+
+// cachingBlobstore is a blobstore.Blobstore which keeps the ranges of the table files read from it in a bounded,
+// least recently used cache on local disk. Table files are immutable, so cached ranges never go stale. Reads of the
+// manifest and all writes go straight to the underlying blobstore.
+//
+// Ranges from the start of a blob are cached in aligned blocks of |cacheBlockSize|. Ranges relative to the end of a
+// blob, like table file indexes, are cached as they are read.
+type cachingBlobstore struct {
+	bs       blobstore.Blobstore
+	dir      string
+	capacity int64
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	// lru holds the cached entries, most recently used first
+	lru  *list.List
+	size int64
+}
+
+type cacheEntry struct {
+	name string
+	size int64
+}
+
+var _ blobstore.Blobstore = &cachingBlobstore{}
+var _ blobstore.Deleter = &cachingBlobstore{}
+
+// errBlobstoreCannotDelete is returned when deleting from a blobstore which doesn't implement blobstore.Deleter.
+var errBlobstoreCannotDelete = errors.New("blobstore does not support deleting blobs")
+
+// newCachingBlobstore returns a cachingBlobstore of |bs| which caches up to |capacity| bytes in |dir|. Entries
+// already in |dir| are kept, in the order in which they were last used.
+func newCachingBlobstore(bs blobstore.Blobstore, dir string, capacity int64) (*cachingBlobstore, error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, err
+	}
+	des, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	type existing struct {
+		cacheEntry
+		mtime time.Time
+	}
+	var found []existing
+	for _, de := range des {
+		if de.IsDir() {
+			continue
+		}
+		if strings.HasPrefix(de.Name(), cacheTempPrefix) {
+			// left behind by an interrupted write
+			_ = os.Remove(filepath.Join(dir, de.Name()))
+			continue
+		}
+		info, err := de.Info()
+		if err != nil {
+			return nil, err
+		}
+		found = append(found, existing{cacheEntry{de.Name(), info.Size()}, info.ModTime()})
+	}
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].mtime.After(found[j].mtime)
+	})
+
+	cbs := &cachingBlobstore{
+		bs:       bs,
+		dir:      dir,
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+	}
+	cbs.mu.Lock()
+	defer cbs.mu.Unlock()
+	for _, e := range found {
+		cbs.entries[e.name] = cbs.lru.PushBack(e.cacheEntry)
+		cbs.size += e.size
+	}
+	cbs.evict()
+	return cbs, nil
+}
+
+func (cbs *cachingBlobstore) Path() string {
+	return cbs.bs.Path()
+}
+
+func (cbs *cachingBlobstore) Exists(ctx context.Context, key string) (bool, error) {
+	return cbs.bs.Exists(ctx, key)
+}
+
+func (cbs *cachingBlobstore) Put(ctx context.Context, key string, totalSize int64, reader io.Reader) (string, error) {
+	return cbs.bs.Put(ctx, key, totalSize, reader)
+}
+
+func (cbs *cachingBlobstore) CheckAndPut(ctx context.Context, expectedVersion, key string, totalSize int64, reader io.Reader) (string, error) {
+	return cbs.bs.CheckAndPut(ctx, expectedVersion, key, totalSize, reader)
+}
+
+func (cbs *cachingBlobstore) Concatenate(ctx context.Context, key string, sources []string) (string, error) {
+	return cbs.bs.Concatenate(ctx, key, sources)
+}
+
+// Delete deletes the blob |key| from the underlying blobstore, if it can delete blobs, and drops its cached ranges.
+func (cbs *cachingBlobstore) Delete(ctx context.Context, key string) error {
+	d, ok := cbs.bs.(blobstore.Deleter)
+	if !ok {
+		return fmt.Errorf("cannot delete %s: %w", key, errBlobstoreCannotDelete)
+	}
+	if err := d.Delete(ctx, key); err != nil {
+		return err
+	}
+
+	cbs.mu.Lock()
+	defer cbs.mu.Unlock()
+	for name, elem := range cbs.entries {
+		if strings.HasPrefix(name, key+"-") {
+			_ = os.Remove(filepath.Join(cbs.dir, name))
+			cbs.remove(elem)
+		}
+	}
+	return nil
+}
+
+func (cbs *cachingBlobstore) Get(ctx context.Context, key string, br blobstore.BlobRange) (io.ReadCloser, string, error) {
+	// only table files are immutable
+	if _, ok := hash.MaybeParse(key); !ok {
+		return cbs.bs.Get(ctx, key, br)
+	}
+
+	off, length := br.Offset(), br.Length()
+	var data []byte
+	var err error
+	switch {
+	case off < 0:
+		data, err = cbs.getTail(ctx, key, br)
+	case length > 0 && length <= cacheMaxRead:
+		data, err = cbs.getBlocks(ctx, key, off, length)
+	default:
+		return cbs.bs.Get(ctx, key, br)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return io.NopCloser(bytes.NewReader(data)), "", nil
+}
+
+// getTail returns the range |br| of the blob |key|, which is relative to its end.
+func (cbs *cachingBlobstore) getTail(ctx context.Context, key string, br blobstore.BlobRange) ([]byte, error) {
+	name := fmt.Sprintf("%s-tail-%d-%d", key, -br.Offset(), br.Length())
+	if data, ok := cbs.get(name); ok {
+		return data, nil
+	}
+	data, _, err := blobstore.GetBytes(ctx, cbs.bs, key, br)
+	if err != nil {
+		return nil, err
+	}
+	cbs.put(name, data)
+	return data, nil
+}
+
+// getBlocks returns |length| bytes at |off| of the blob |key|, or fewer if the blob ends first. Missing blocks are
+// fetched with a single read.
+func (cbs *cachingBlobstore) getBlocks(ctx context.Context, key string, off, length int64) ([]byte, error) {
+	first, last := off/cacheBlockSize, (off+length-1)/cacheBlockSize
+	blocks := make([][]byte, last-first+1)
+	missingFirst, missingLast := int64(-1), int64(-1)
+	for i := first; i <= last; i++ {
+		if data, ok := cbs.get(blockName(key, i)); ok {
+			blocks[i-first] = data
+			continue
+		}
+		if missingFirst < 0 {
+			missingFirst = i
+		}
+		missingLast = i
+	}
+
+	if missingFirst >= 0 {
+		br := blobstore.NewBlobRange(missingFirst*cacheBlockSize, (missingLast-missingFirst+1)*cacheBlockSize)
+		fetched, _, err := blobstore.GetBytes(ctx, cbs.bs, key, br)
+		if err != nil {
+			return nil, err
+		}
+		for i := missingFirst; i <= missingLast && len(fetched) > 0; i++ {
+			n := min(int64(len(fetched)), cacheBlockSize)
+			block := fetched[:n:n]
+			fetched = fetched[n:]
+			if blocks[i-first] == nil {
+				blocks[i-first] = block
+				cbs.put(blockName(key, i), block)
+			}
+		}
+	}
+
+	buf := make([]byte, 0, length)
+	for _, block := range blocks {
+		buf = append(buf, block...)
+		if len(block) < cacheBlockSize {
+			// the blob ends in this block
+			break
+		}
+	}
+	start := off - first*cacheBlockSize
+	if start >= int64(len(buf)) {
+		return nil, nil
+	}
+	return buf[start:min(int64(len(buf)), start+length)], nil
+}
+
+func blockName(key string, block int64) string {
+	return fmt.Sprintf("%s-%d", key, block)
+}
+
+// get returns the data of the cache entry |name|, if it's cached.
+func (cbs *cachingBlobstore) get(name string) ([]byte, bool) {
+	cbs.mu.Lock()
+	elem, ok := cbs.entries[name]
+	if ok {
+		cbs.lru.MoveToFront(elem)
+	}
+	cbs.mu.Unlock()
+	if !ok {
+		return nil, false
+	}
+
+	path := filepath.Join(cbs.dir, name)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		// evicted concurrently, or removed from under us
+		cbs.mu.Lock()
+		if cbs.entries[name] == elem {
+			cbs.remove(elem)
+		}
+		cbs.mu.Unlock()
+		return nil, false
+	}
+	// the modification time orders the entries when the cache is reopened
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+	return data, true
+}
+
+// put caches |data| as the entry |name|. Failures to cache are ignored, since the data can always be read again.
+func (cbs *cachingBlobstore) put(name string, data []byte) {
+	if int64(len(data)) > cbs.capacity {
+		return
+	}
+
+	f, err := os.CreateTemp(cbs.dir, cacheTempPrefix)
+	if err != nil {
+		return
+	}
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), filepath.Join(cbs.dir, name))
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return
+	}
+
+	cbs.mu.Lock()
+	defer cbs.mu.Unlock()
+	if elem, ok := cbs.entries[name]; ok {
+		cbs.size -= elem.Value.(cacheEntry).size
+		cbs.lru.Remove(elem)
+	}
+	cbs.entries[name] = cbs.lru.PushFront(cacheEntry{name, int64(len(data))})
+	cbs.size += int64(len(data))
+	cbs.evict()
+}
+
+// evict removes the least recently used entries until the cache fits in its capacity. Callers must hold |mu|.
+func (cbs *cachingBlobstore) evict() {
+	for cbs.size > cbs.capacity && cbs.lru.Len() > 0 {
+		elem := cbs.lru.Back()
+		_ = os.Remove(filepath.Join(cbs.dir, elem.Value.(cacheEntry).name))
+		cbs.remove(elem)
+	}
+}
+
+// remove drops |elem| from the cache's bookkeeping. Callers must hold |mu|.
+func (cbs *cachingBlobstore) remove(elem *list.Element) {
+	e := elem.Value.(cacheEntry)
+	delete(cbs.entries, e.name)
+	cbs.lru.Remove(elem)
+	cbs.size -= e.size
+}
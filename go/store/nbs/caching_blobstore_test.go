@@ -0,0 +1,122 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"crypto/rand"
+	"io"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// countingBlobstore counts the reads made of the blobstore it wraps.
+type countingBlobstore struct {
+	blobstore.Blobstore
+	gets atomic.Int64
+}
+
+func (cbs *countingBlobstore) Get(ctx context.Context, key string, br blobstore.BlobRange) (io.ReadCloser, string, error) {
+	cbs.gets.Add(1)
+	return cbs.Blobstore.Get(ctx, key, br)
+}
+
+func readRange(t *testing.T, bs blobstore.Blobstore, key string, br blobstore.BlobRange) []byte {
+	data, _, err := blobstore.GetBytes(context.Background(), bs, key, br)
+	require.NoError(t, err)
+	return data
+}
+
+func TestCachingBlobstore(t *testing.T) {
+	ctx := context.Background()
+	inner := &countingBlobstore{Blobstore: blobstore.NewInMemoryBlobstore("")}
+	key := hash.Of([]byte("table")).String()
+	blob := make([]byte, 3*cacheBlockSize+cacheBlockSize/2)
+	_, err := rand.Read(blob)
+	require.NoError(t, err)
+	_, err = blobstore.PutBytes(ctx, inner, key, blob)
+	require.NoError(t, err)
+	_, err = blobstore.PutBytes(ctx, inner, manifestFile, []byte("manifest"))
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	cbs, err := newCachingBlobstore(inner, dir, 3*cacheBlockSize)
+	require.NoError(t, err)
+
+	t.Run("blocks", func(t *testing.T) {
+		off := int64(cacheBlockSize - 10)
+		br := blobstore.NewBlobRange(off, 20)
+		assert.Equal(t, blob[off:off+20], readRange(t, cbs, key, br))
+		assert.Equal(t, int64(1), inner.gets.Load())
+		assert.Equal(t, blob[off:off+20], readRange(t, cbs, key, br))
+		assert.Equal(t, int64(1), inner.gets.Load())
+
+		// reads past the end of the blob are truncated
+		off = int64(3 * cacheBlockSize)
+		assert.Equal(t, blob[off:], readRange(t, cbs, key, blobstore.NewBlobRange(off, cacheBlockSize)))
+		assert.Equal(t, blob[off:], readRange(t, cbs, key, blobstore.NewBlobRange(off, cacheBlockSize)))
+		assert.Equal(t, int64(2), inner.gets.Load())
+	})
+
+	t.Run("tails", func(t *testing.T) {
+		inner.gets.Store(0)
+		br := blobstore.NewBlobRange(-100, 0)
+		assert.Equal(t, blob[len(blob)-100:], readRange(t, cbs, key, br))
+		assert.Equal(t, blob[len(blob)-100:], readRange(t, cbs, key, br))
+		assert.Equal(t, int64(1), inner.gets.Load())
+	})
+
+	t.Run("uncached", func(t *testing.T) {
+		inner.gets.Store(0)
+		assert.Equal(t, blob, readRange(t, cbs, key, blobstore.AllRange))
+		assert.Equal(t, []byte("manifest"), readRange(t, cbs, manifestFile, blobstore.AllRange))
+		assert.Equal(t, []byte("manifest"), readRange(t, cbs, manifestFile, blobstore.AllRange))
+		assert.Equal(t, int64(3), inner.gets.Load())
+	})
+
+	t.Run("eviction", func(t *testing.T) {
+		inner.gets.Store(0)
+		readRange(t, cbs, key, blobstore.NewBlobRange(2*cacheBlockSize, 10))
+		assert.Equal(t, int64(1), inner.gets.Load())
+		assert.LessOrEqual(t, cbs.size, cbs.capacity)
+
+		// block 0 was the least recently used
+		readRange(t, cbs, key, blobstore.NewBlobRange(0, 10))
+		assert.Equal(t, int64(2), inner.gets.Load())
+		readRange(t, cbs, key, blobstore.NewBlobRange(2*cacheBlockSize, 10))
+		assert.Equal(t, int64(2), inner.gets.Load())
+	})
+
+	t.Run("reopen", func(t *testing.T) {
+		reopened, err := newCachingBlobstore(inner, dir, 3*cacheBlockSize)
+		require.NoError(t, err)
+		assert.Equal(t, cbs.size, reopened.size)
+
+		inner.gets.Store(0)
+		assert.Equal(t, blob[:10], readRange(t, reopened, key, blobstore.NewBlobRange(0, 10)))
+		assert.Equal(t, int64(0), inner.gets.Load())
+
+		// a smaller cache is trimmed when it's opened
+		trimmed, err := newCachingBlobstore(inner, dir, cacheBlockSize)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, trimmed.size, int64(cacheBlockSize))
+	})
+}
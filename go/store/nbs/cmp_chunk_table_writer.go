@@ -186,6 +186,18 @@ func (tw *CmpChunkTableWriter) FlushToFile(path string) error {
 	return tw.sink.FlushToFile(path)
 }
 
+// flushToPlainFile is FlushToFile, but writes the data in plaintext even when encryption at rest is configured.
+func (tw *CmpChunkTableWriter) flushToPlainFile(path string) error {
+	if tw.blockAddr == nil {
+		return ErrNotFinished
+	}
+
+	if bs, ok := tw.sink.backingSink.(*BufferedFileByteSink); ok {
+		return bs.flushToFile(path, nil)
+	}
+	return writeSinkToFile(tw.sink, path, nil)
+}
+
 // Flush can be called after Finish in order to write the data out to the writer provided.
 func (tw *CmpChunkTableWriter) Flush(wr io.Writer) error {
 	if tw.blockAddr == nil {
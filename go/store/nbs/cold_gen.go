@@ -0,0 +1,204 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+var _ chunks.ColdTieredCS = (*GenerationalNBS)(nil)
+
+// NewColdStore returns a store for the cold generation of a GenerationalNBS. Its table files are kept in |bs|, and up
+// to |cacheSize| bytes of the ranges read from them are cached in |cacheDir|.
+func NewColdStore(ctx context.Context, nbfVerStr string, bs blobstore.Blobstore, cacheDir string, cacheSize int64, q MemoryQuotaProvider) (*NomsBlockStore, error) {
+	cbs, err := newCachingBlobstore(bs, cacheDir, cacheSize)
+	if err != nil {
+		return nil, err
+	}
+	// Conjoining would rewrite table files which are already cached.
+	return NewNoConjoinBSStore(ctx, nbfVerStr, cbs, defaultMemTableSize, q)
+}
+
+// ColdGen returns the cold generation, or nil if there isn't one.
+func (gcs *GenerationalNBS) ColdGen() chunks.ChunkStoreGarbageCollector {
+	if gcs.coldGen == nil {
+		return nil
+	}
+	return gcs.coldGen
+}
+
+// ColdCutoff returns the time before which commits, along with the history behind them, belong in the cold
+// generation.
+func (gcs *GenerationalNBS) ColdCutoff() time.Time {
+	return time.Now().Add(-gcs.coldAfter)
+}
+
+// pruneColdTableFiles deletes the table files which were in the cold generation when the running full GC began, but
+// which it has replaced. Table files which other processes have added since aren't known, so they're never deleted.
+// Nothing is deleted if the blobstore of the cold generation can't delete blobs.
+func (gcs *GenerationalNBS) pruneColdTableFiles(ctx context.Context) error {
+	gcs.coldGen.mu.Lock()
+	dropped := gcs.coldGCSpecs
+	gcs.coldGCSpecs = nil
+	for name := range gcs.coldGen.tables.upstream {
+		delete(dropped, name)
+	}
+	gcs.coldGen.mu.Unlock()
+
+	p, ok := gcs.coldGen.p.(*noConjoinBlobstorePersister)
+	if !ok {
+		return nil
+	}
+	d, ok := p.bs.(blobstore.Deleter)
+	if !ok {
+		return nil
+	}
+	for name := range dropped {
+		err := d.Delete(ctx, name.String())
+		if errors.Is(err, errBlobstoreCannotDelete) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OpenColdTableFile returns a reader of the table file |fileId| of the cold generation, along with its size. The cold
+// generation isn't on local disk, so the servers of remotes read its table files through its blobstore.
+func (gcs *GenerationalNBS) OpenColdTableFile(ctx context.Context, fileId string) (TableFileReader, int64, error) {
+	var cs chunkSource
+	name, ok := hash.MaybeParse(fileId)
+	if ok && gcs.coldGen != nil {
+		gcs.coldGen.mu.RLock()
+		cs, ok = gcs.coldGen.tables.upstream[name]
+		gcs.coldGen.mu.RUnlock()
+	}
+	if !ok || gcs.coldGen == nil {
+		return nil, 0, fmt.Errorf("table file %s isn't in the cold generation: %w", fileId, os.ErrNotExist)
+	}
+	p, ok := gcs.coldGen.p.(*noConjoinBlobstorePersister)
+	if !ok {
+		return nil, 0, fmt.Errorf("the cold generation isn't stored in a blobstore")
+	}
+	return coldTableFileReader{ctx: ctx, r: &bsTableReaderAt{key: fileId, bs: p.bs}}, int64(cs.currentSize()), nil
+}
+
+// OpenColdTableFile returns an error, since a NomsBlockStore has no cold generation.
+func (nbs *NomsBlockStore) OpenColdTableFile(ctx context.Context, fileId string) (TableFileReader, int64, error) {
+	return nil, 0, fmt.Errorf("table file %s isn't in a cold generation: %w", fileId, os.ErrNotExist)
+}
+
+// coldTableFileReader is the TableFileReader of a table file of the cold generation.
+type coldTableFileReader struct {
+	ctx context.Context
+	r   *bsTableReaderAt
+}
+
+func (r coldTableFileReader) ReadAt(p []byte, off int64) (int, error) {
+	n, err := r.r.ReadAtWithStats(r.ctx, p, off, NewStats())
+	if err == nil && n < len(p) {
+		err = io.EOF
+	}
+	return n, err
+}
+
+func (r coldTableFileReader) Close() error {
+	return nil
+}
+
+// ResealColdTableFiles seals the table files of the cold generation which aren't sealed with the current encryption
+// key with it, so that keys which were rotated out can be removed from the keyring once it has run. A full GC writes
+// the cold generation with the current key, except for the table files which it would write unchanged. Files are
+// re-sealed under new names and swapped into the manifest, since the cache of the cold generation expects blobs never
+// to change. The blobs of the replaced files are deleted when the full GC prunes the cold generation.
+func (gcs *GenerationalNBS) ResealColdTableFiles(ctx context.Context) error {
+	if gcs.coldGen == nil {
+		return nil
+	}
+	p, ok := gcs.coldGen.p.(*noConjoinBlobstorePersister)
+	if !ok {
+		return nil
+	}
+	sbs, ok := p.bs.(*sealingBlobstore)
+	if !ok {
+		return nil
+	}
+
+	gcs.coldGen.mu.RLock()
+	specs, err := gcs.coldGen.tables.toSpecs()
+	gcs.coldGen.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	resealed := make([]tableSpec, len(specs))
+	extra := []byte("reseal")
+	for i, spec := range specs {
+		resealed[i] = spec
+		current, err := sbs.sealedWithCurrentKey(ctx, spec.name.String())
+		if err != nil {
+			return err
+		} else if current {
+			continue
+		}
+		// The name is derived from the key, so that a re-seal which is interrupted and run again writes the same blob.
+		name := hash.Of(append(spec.name[:], sbs.kr.keys[0].id[:]...))
+		err = resealBlob(ctx, sbs, spec.name.String(), name.String())
+		if err != nil {
+			return err
+		}
+		resealed[i].name = name
+		extra = append(extra, name[:]...)
+	}
+	if len(extra) == len("reseal") {
+		return nil
+	}
+	return gcs.coldGen.swapTablesWithGCGenExtra(ctx, resealed, extra)
+}
+
+// resealBlob writes the contents of the blob |from| of |sbs| to the blob |to|, which is sealed with the current key.
+func resealBlob(ctx context.Context, sbs *sealingBlobstore, from, to string) error {
+	rc, _, err := sbs.Get(ctx, from, blobstore.AllRange)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	// The size of the contents is only known once they're read.
+	f, err := os.CreateTemp("", "reseal-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+	sz, err := io.Copy(f, rc)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	_, err = sbs.Put(ctx, to, sz, f)
+	return err
+}
@@ -0,0 +1,165 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// putTableFile puts |chnks| to |st| and commits them as a new table file, which is returned.
+func putTableFile(t *testing.T, ctx context.Context, st *NomsBlockStore, chnks ...chunks.Chunk) hash.Hash {
+	before := make(map[hash.Hash]struct{})
+	for h := range st.tables.upstream {
+		before[h] = struct{}{}
+	}
+	for _, c := range chnks {
+		require.NoError(t, st.Put(ctx, c, noopGetAddrs))
+	}
+	last, err := st.Root(ctx)
+	require.NoError(t, err)
+	ok, err := st.Commit(ctx, chnks[0].Hash(), last)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	for h := range st.tables.upstream {
+		if _, ok := before[h]; !ok {
+			return h
+		}
+	}
+	require.Fail(t, "no table file was written")
+	return hash.Hash{}
+}
+
+// sweepInto copies |hashes| and the chunks they reference, other than those |filter| removes, from |gcs| into a new
+// table file of |dest|, which is added to its manifest. The table files of |dest| are replaced by it once the returned
+// finalizer is swapped in.
+func sweepInto(t *testing.T, ctx context.Context, gcs *GenerationalNBS, dest chunks.ChunkStoreGarbageCollector, getAddrs chunks.GetAddrsCurry, filter chunks.HasManyFunc, hashes ...hash.Hash) (chunks.GCFinalizer, chunks.HasManyFunc) {
+	sweeper, err := gcs.MarkAndSweepChunks(ctx, getAddrs, filter, dest, chunks.GCMode_Full)
+	require.NoError(t, err)
+	require.NoError(t, sweeper.SaveHashes(ctx, hashes))
+	finalizer, err := sweeper.Finalize(ctx)
+	require.NoError(t, err)
+	require.NoError(t, sweeper.Close(ctx))
+	has, err := finalizer.AddChunksToStore(ctx)
+	require.NoError(t, err)
+	return finalizer, has
+}
+
+func TestColdGenGC(t *testing.T) {
+	ctx := context.Background()
+	oldGen, _, q := makeTestLocalStore(t, 64)
+	newGen, _, _ := makeTestLocalStore(t, 64)
+	coldDir, cacheDir := t.TempDir(), t.TempDir()
+	openCold := func() *NomsBlockStore {
+		cold, err := NewColdStore(ctx, types.Format_Default.VersionString(), blobstore.NewLocalBlobstore(coldDir), cacheDir, 1<<20, q)
+		require.NoError(t, err)
+		return cold
+	}
+	gcs := NewTieredGenerationalCS(oldGen, newGen, openCold(), nil, 24*time.Hour)
+	assert.NotNil(t, gcs.ColdGen())
+	assert.Nil(t, NewGenerationalCS(oldGen, newGen, nil).ColdGen())
+
+	chnks := genChunks(t, 12, 1000)
+	putTableFile(t, ctx, oldGen, chnks[0:4]...)
+	putTableFile(t, ctx, oldGen, chnks[4:8]...)
+	// chnks[8:10] are still reachable, chnks[10:12] are garbage
+	oldColdFile := putTableFile(t, ctx, gcs.coldGen, chnks[8:12]...)
+
+	// chnks[1] references chnks[9], which stays cold
+	refs := map[hash.Hash]hash.Hash{
+		chnks[1].Hash(): chnks[9].Hash(),
+	}
+	getAddrs := func(c chunks.Chunk) chunks.GetAddrsCb {
+		return func(_ context.Context, addrs hash.HashSet, _ chunks.PendingRefExists) error {
+			if r, ok := refs[c.Hash()]; ok {
+				addrs.Insert(r)
+			}
+			return nil
+		}
+	}
+	hashes := func(idxs ...int) []hash.Hash {
+		ret := make([]hash.Hash, len(idxs))
+		for i, idx := range idxs {
+			ret[i] = chnks[idx].Hash()
+		}
+		return ret
+	}
+	cold := hashesForChunks(chnks, map[int]bool{0: true, 1: true, 2: true, 3: true, 8: true, 9: true})
+
+	// A full GC of the old generation keeps chnks[4:8] there. chnks[0:4], and what they reference, are cold.
+	require.NoError(t, gcs.BeginGC(func(hash.Hash) bool { return false }, chunks.GCMode_Full))
+	oldFinalizer, oldHas := sweepInto(t, ctx, gcs, gcs.OldGen(), getAddrs, func(_ context.Context, hs hash.HashSet) (hash.HashSet, error) {
+		return hs, nil
+	}, hashes(4, 5, 6, 7)...)
+	coldFinalizer, _ := sweepInto(t, ctx, gcs, gcs.ColdGen(), getAddrs, oldHas, hashes(0, 1, 2, 3, 8)...)
+	require.NoError(t, oldFinalizer.SwapChunksInStore(ctx))
+	require.NoError(t, coldFinalizer.SwapChunksInStore(ctx))
+	gcs.EndGC(chunks.GCMode_Full)
+	require.NoError(t, gcs.PruneTableFiles(ctx))
+
+	requireCollected := func(gcs *GenerationalNBS) {
+		for i, c := range chnks {
+			has, err := gcs.oldGen.Has(ctx, c.Hash())
+			require.NoError(t, err)
+			assert.Equal(t, i >= 4 && i < 8, has, "index %d", i)
+			has, err = gcs.coldGen.Has(ctx, c.Hash())
+			require.NoError(t, err)
+			assert.Equal(t, cold.Has(c.Hash()), has, "index %d", i)
+
+			got, err := gcs.Get(ctx, c.Hash())
+			require.NoError(t, err)
+			if i >= 10 {
+				assert.True(t, got.IsEmpty(), "index %d", i)
+			} else {
+				assert.Equal(t, c.Data(), got.Data(), "index %d", i)
+			}
+		}
+	}
+	requireCollected(gcs)
+	// the cold generation is read back from its blobstore
+	requireCollected(NewTieredGenerationalCS(oldGen, newGen, openCold(), nil, 24*time.Hour))
+
+	// the replaced table file of the cold generation is deleted
+	exists, err := blobstore.NewLocalBlobstore(coldDir).Exists(ctx, oldColdFile.String())
+	require.NoError(t, err)
+	assert.False(t, exists)
+	_, _, err = gcs.OpenColdTableFile(ctx, oldColdFile.String())
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	// remotes serve the table files of the cold generation from its blobstore
+	_, sources, _, err := gcs.coldGen.Sources(ctx)
+	require.NoError(t, err)
+	require.Len(t, sources, 1)
+	rd, sz, err := gcs.OpenColdTableFile(ctx, sources[0].FileID())
+	require.NoError(t, err)
+	got := make([]byte, sz)
+	_, err = rd.ReadAt(got, 0)
+	require.NoError(t, err)
+	require.NoError(t, rd.Close())
+	want, _, err := blobstore.GetBytes(ctx, blobstore.NewLocalBlobstore(coldDir), sources[0].FileID(), blobstore.AllRange)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
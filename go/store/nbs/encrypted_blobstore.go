@@ -0,0 +1,249 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/util/tempfiles"
+)
+
+// sealingBlobstore is a blobstore.Blobstore which seals the table files put to it, and unseals them as they're read.
+// Blobs which aren't sealed are read as they are. The manifest isn't sealed.
+type sealingBlobstore struct {
+	bs blobstore.Blobstore
+	kr *keyring
+
+	mu sync.Mutex
+	// blobs caches the sealed files of the blobs which have been read, or nil for those which aren't sealed
+	blobs map[string]*sealedFile
+}
+
+var _ blobstore.Blobstore = &sealingBlobstore{}
+var _ blobstore.Deleter = &sealingBlobstore{}
+
+// wrapBlobstore returns |bs|, wrapped to seal table files when encryption at rest is configured.
+func wrapBlobstore(bs blobstore.Blobstore) (blobstore.Blobstore, error) {
+	kr, err := encryptionKeyring()
+	if err != nil {
+		return nil, err
+	} else if kr == nil {
+		return bs, nil
+	}
+	return newSealingBlobstore(bs, kr), nil
+}
+
+func newSealingBlobstore(bs blobstore.Blobstore, kr *keyring) *sealingBlobstore {
+	return &sealingBlobstore{bs: bs, kr: kr, blobs: make(map[string]*sealedFile)}
+}
+
+func (sbs *sealingBlobstore) Path() string {
+	return sbs.bs.Path()
+}
+
+func (sbs *sealingBlobstore) Exists(ctx context.Context, key string) (bool, error) {
+	return sbs.bs.Exists(ctx, key)
+}
+
+// sealedBlob returns the sealed file of the blob |key|, or nil if the blob isn't sealed.
+func (sbs *sealingBlobstore) sealedBlob(ctx context.Context, key string) (*sealedFile, error) {
+	sbs.mu.Lock()
+	sf, ok := sbs.blobs[key]
+	sbs.mu.Unlock()
+	if ok {
+		return sf, nil
+	}
+
+	b, _, err := blobstore.GetBytes(ctx, sbs.bs, key, blobstore.NewBlobRange(0, int64(sealHeaderSize)))
+	if err != nil {
+		return nil, err
+	}
+	if isSealed(b) {
+		h, err := parseSealHeader(b)
+		if err != nil {
+			return nil, err
+		}
+		trailer, _, err := blobstore.GetBytes(ctx, sbs.bs, key, blobstore.NewBlobRange(-int64(sealTrailerSize), 0))
+		if err != nil {
+			return nil, err
+		}
+		size, err := parseSealTrailer(trailer)
+		if err != nil {
+			return nil, err
+		}
+		if sf, err = newSealedFile(nil, h, size, sbs.kr); err != nil {
+			return nil, err
+		}
+	}
+
+	sbs.mu.Lock()
+	defer sbs.mu.Unlock()
+	sbs.blobs[key] = sf
+	return sf, nil
+}
+
+// sealedWithCurrentKey returns whether the blob |key| is sealed with the first key of the keyring, which is the key
+// new blobs are sealed with.
+func (sbs *sealingBlobstore) sealedWithCurrentKey(ctx context.Context, key string) (bool, error) {
+	b, _, err := blobstore.GetBytes(ctx, sbs.bs, key, blobstore.NewBlobRange(0, int64(sealHeaderSize)))
+	if err != nil {
+		return false, err
+	}
+	if !isSealed(b) {
+		return false, nil
+	}
+	h, err := parseSealHeader(b)
+	if err != nil {
+		return false, err
+	}
+	return h.keyID == sbs.kr.keys[0].id, nil
+}
+
+func (sbs *sealingBlobstore) forget(key string) {
+	sbs.mu.Lock()
+	defer sbs.mu.Unlock()
+	delete(sbs.blobs, key)
+}
+
+func (sbs *sealingBlobstore) Delete(ctx context.Context, key string) error {
+	d, ok := sbs.bs.(blobstore.Deleter)
+	if !ok {
+		return fmt.Errorf("cannot delete %s: %w", key, errBlobstoreCannotDelete)
+	}
+	sbs.forget(key)
+	return d.Delete(ctx, key)
+}
+
+func (sbs *sealingBlobstore) Get(ctx context.Context, key string, br blobstore.BlobRange) (io.ReadCloser, string, error) {
+	if key == manifestFile {
+		return sbs.bs.Get(ctx, key, br)
+	}
+	sf, err := sbs.sealedBlob(ctx, key)
+	if err != nil {
+		return nil, "", err
+	} else if sf == nil {
+		return sbs.bs.Get(ctx, key, br)
+	}
+
+	off, length := br.Bounds(sf.size)
+	if off < 0 {
+		length += off
+		off = 0
+	}
+	if off > sf.size {
+		off = sf.size
+	}
+	if length < 0 {
+		length = 0
+	}
+
+	first, sealedOff, sealedLen := sf.segmentRange(off, length)
+	rc, ver, err := sbs.bs.Get(ctx, key, blobstore.NewBlobRange(sealedOff, sealedLen))
+	if err != nil {
+		return nil, "", err
+	}
+	return sf.stream(rc, first, off, length), ver, nil
+}
+
+// seal calls |put| with a reader of the sealed |totalSize| bytes of |reader|, along with its size.
+func (sbs *sealingBlobstore) seal(totalSize int64, reader io.Reader, put func(sealedSz int64, sealed io.Reader) (string, error)) (string, error) {
+	pr, pw := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		sw, err := newSealWriter(pw, sbs.kr)
+		if err == nil {
+			_, err = io.Copy(sw, reader)
+		}
+		if err == nil {
+			err = sw.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+
+	ver, err := put(sealedSize(totalSize), pr)
+	// unblock the sealing goroutine if |put| returned before reading everything
+	pr.CloseWithError(io.ErrClosedPipe)
+	<-done
+	return ver, err
+}
+
+func (sbs *sealingBlobstore) Put(ctx context.Context, key string, totalSize int64, reader io.Reader) (string, error) {
+	if key == manifestFile {
+		return sbs.bs.Put(ctx, key, totalSize, reader)
+	}
+	defer sbs.forget(key)
+	return sbs.seal(totalSize, reader, func(sealedSz int64, sealed io.Reader) (string, error) {
+		return sbs.bs.Put(ctx, key, sealedSz, sealed)
+	})
+}
+
+func (sbs *sealingBlobstore) CheckAndPut(ctx context.Context, expectedVersion, key string, totalSize int64, reader io.Reader) (string, error) {
+	if key == manifestFile {
+		return sbs.bs.CheckAndPut(ctx, expectedVersion, key, totalSize, reader)
+	}
+	defer sbs.forget(key)
+	return sbs.seal(totalSize, reader, func(sealedSz int64, sealed io.Reader) (string, error) {
+		return sbs.bs.CheckAndPut(ctx, expectedVersion, key, sealedSz, sealed)
+	})
+}
+
+// Concatenate unseals |sources| and seals their concatenation into a new blob, since sealed blobs can't be
+// concatenated as they are. The new blob is sealed into a temporary file before it's put, since blobstores may not
+// be read from while they're being put to.
+func (sbs *sealingBlobstore) Concatenate(ctx context.Context, key string, sources []string) (version string, err error) {
+	f, err := tempfiles.MovableTempFileProvider.NewFile("", "sealed_concat_")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		f.Close()
+		os.Remove(f.Name())
+	}()
+
+	sw, err := newSealWriter(f, sbs.kr)
+	if err != nil {
+		return "", err
+	}
+	for _, src := range sources {
+		rc, _, err := sbs.Get(ctx, src, blobstore.AllRange)
+		if err != nil {
+			return "", err
+		}
+		_, err = io.Copy(sw, rc)
+		rc.Close()
+		if err != nil {
+			return "", err
+		}
+	}
+	if err = sw.Close(); err != nil {
+		return "", err
+	}
+
+	sz, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return "", err
+	}
+	if _, err = f.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	defer sbs.forget(key)
+	return sbs.bs.Put(ctx, key, sz, f)
+}
@@ -0,0 +1,1093 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
+	"github.com/dolthub/dolt/go/libraries/utils/file"
+)
+
+// Table files, archives and the chunk journal are encrypted at rest when an encryption key is configured with
+// DOLT_ENCRYPTION_KEY or DOLT_ENCRYPTION_KEY_FILE. Each file is sealed with its own random data key, which is wrapped
+// by the master key and kept in the header of the file. Files are read the same way whether or not they're sealed,
+// so a store can hold both until it's garbage collected. Manifests and the journal index hold only addresses and
+// offsets, and aren't sealed.
+//
+// Keys are base64 encoded 32 byte AES-256 keys, separated by whitespace or commas. The first key seals new files, and
+// any further keys are only used to unseal files sealed with them. Keys are rotated by putting a new key first and
+// running `dolt gc --full`, which rewrites every file with the new key and re-seals the files of the cold generation,
+// after which the old key can be removed.
+//
+// Header:
+//
+//	+-------+-----------------+--------------+----------------------+---------------+----------------------+
+//	| Magic | (uint8) Version | (uint8) Kind | (uint32) SegmentSize | KeyID [8]byte | WrappedKey [60]byte  |
+//	+-------+-----------------+--------------+----------------------+---------------+----------------------+
+//
+// Table files and archives are immutable. Their plaintext is split into segments of SegmentSize bytes, each sealed
+// with AES-GCM under the data key, and is followed by a trailer holding the size of the plaintext:
+//
+//	+--------+-----------+-----+-----------+---------------------+-------+
+//	| Header | Segment 0 | ... | Segment N | (uint64) PlainSize  | Magic |
+//	+--------+-----------+-----+-----------+---------------------+-------+
+//
+//	+----------------+------------+--------------+
+//	| Nonce [12]byte | Ciphertext | Tag [16]byte |
+//	+----------------+------------+--------------+
+//
+// The chunk journal is appended to, so it's sealed as a log of slots, each of which holds the plaintext at
+// PlainOffset. A slot whose PlainOffset is before the end of the slots which precede it replaces their plaintext from
+// PlainOffset onward, so a torn tail is dropped without overwriting slots which were synced. Each slot is
+// authenticated along with its offset in the file and the tag of the slot before it, or the tag of the wrapped key for
+// the first slot, so slots can't be reordered, or replayed from elsewhere in the journal.
+//
+//	+----------------------+-----------------+----------------+------------+--------------+
+//	| (uint64) PlainOffset | (uint32) Length | Nonce [12]byte | Ciphertext | Tag [16]byte |
+//	+----------------------+-----------------+----------------+------------+--------------+
+const (
+	sealMagic         = "DOLTSEAL"
+	sealFormatVersion = uint8(1)
+
+	sealKindFile    = uint8(1)
+	sealKindJournal = uint8(2)
+
+	sealSegmentSize    = 16 * 1024
+	maxSealSegmentSize = 16 * 1024 * 1024
+
+	encryptionKeySize   = 32
+	encryptionKeyIDSize = 8
+	gcmNonceSize        = 12
+	gcmTagSize          = 16
+	wrappedKeySize      = gcmNonceSize + encryptionKeySize + gcmTagSize
+
+	// the wrapped key is authenticated along with the fields of the header which precede it
+	sealHeaderAADSize     = len(sealMagic) + 1 + 1 + 4 + encryptionKeyIDSize
+	sealHeaderSize        = sealHeaderAADSize + wrappedKeySize
+	sealTrailerSize       = uint64Size + len(sealMagic)
+	sealSegmentOverhead   = gcmNonceSize + gcmTagSize
+	journalSlotHeaderSize = uint64Size + uint32Size + gcmNonceSize
+)
+
+// ErrEncryptionKeyNotConfigured is returned when reading a sealed file without an encryption key configured.
+var ErrEncryptionKeyNotConfigured = errors.New("file is encrypted, but no encryption key is configured")
+
+// ErrEncryptionKeyUnknown is returned when reading a sealed file whose key isn't configured.
+var ErrEncryptionKeyUnknown = errors.New("file is encrypted with a key which isn't configured")
+
+var errCorruptSealedFile = errors.New("corrupt encrypted file")
+
+type encryptionKey struct {
+	id   [encryptionKeyIDSize]byte
+	aead cipher.AEAD
+}
+
+// keyring holds the master keys which seal and unseal data keys. The first key seals new files.
+type keyring struct {
+	keys []encryptionKey
+}
+
+// parseKeyring parses base64 encoded keys separated by whitespace or commas.
+func parseKeyring(s string) (*keyring, error) {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+	if len(fields) == 0 {
+		return nil, errors.New("no keys found")
+	}
+
+	kr := &keyring{}
+	for i, field := range fields {
+		key, err := base64.StdEncoding.DecodeString(field)
+		if err != nil {
+			return nil, fmt.Errorf("key %d is not base64 encoded: %w", i+1, err)
+		} else if len(key) != encryptionKeySize {
+			return nil, fmt.Errorf("key %d is %d bytes long, keys must be %d bytes long", i+1, len(key), encryptionKeySize)
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(key)
+		ek := encryptionKey{aead: aead}
+		copy(ek.id[:], sum[:])
+		kr.keys = append(kr.keys, ek)
+	}
+	return kr, nil
+}
+
+func (kr *keyring) find(id [encryptionKeyIDSize]byte) (encryptionKey, bool) {
+	for _, k := range kr.keys {
+		if k.id == id {
+			return k, true
+		}
+	}
+	return encryptionKey{}, false
+}
+
+var (
+	keyringOnce    sync.Once
+	loadedKeyring  *keyring
+	loadKeyringErr error
+)
+
+// encryptionKeyring returns the keyring configured with DOLT_ENCRYPTION_KEY or DOLT_ENCRYPTION_KEY_FILE, or nil when
+// encryption at rest isn't configured. The keyring is loaded once per process.
+func encryptionKeyring() (*keyring, error) {
+	keyringOnce.Do(func() {
+		loadedKeyring, loadKeyringErr = loadKeyring()
+	})
+	return loadedKeyring, loadKeyringErr
+}
+
+func loadKeyring() (*keyring, error) {
+	if keys := os.Getenv(dconfig.EnvEncryptionKey); keys != "" {
+		kr, err := parseKeyring(keys)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", dconfig.EnvEncryptionKey, err)
+		}
+		return kr, nil
+	}
+
+	if path := os.Getenv(dconfig.EnvEncryptionKeyFile); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", dconfig.EnvEncryptionKeyFile, err)
+		}
+		kr, err := parseKeyring(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("invalid key file %s: %w", path, err)
+		}
+		return kr, nil
+	}
+
+	return nil, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+type sealHeader struct {
+	kind    uint8
+	segSize uint32
+	keyID   [encryptionKeyIDSize]byte
+	wrapped [wrappedKeySize]byte
+}
+
+func (h sealHeader) encode() []byte {
+	b := make([]byte, sealHeaderSize)
+	copy(b, sealMagic)
+	b[8] = sealFormatVersion
+	b[9] = h.kind
+	binary.BigEndian.PutUint32(b[10:], h.segSize)
+	copy(b[14:], h.keyID[:])
+	copy(b[sealHeaderAADSize:], h.wrapped[:])
+	return b
+}
+
+func parseSealHeader(b []byte) (sealHeader, error) {
+	if len(b) < sealHeaderSize || !isSealed(b) {
+		return sealHeader{}, errCorruptSealedFile
+	}
+	if b[8] != sealFormatVersion {
+		return sealHeader{}, fmt.Errorf("unsupported encrypted file format version %d", b[8])
+	}
+
+	h := sealHeader{kind: b[9], segSize: binary.BigEndian.Uint32(b[10:])}
+	if h.kind != sealKindFile && h.kind != sealKindJournal {
+		return sealHeader{}, fmt.Errorf("%w: unknown kind %d", errCorruptSealedFile, h.kind)
+	} else if h.segSize == 0 || h.segSize > maxSealSegmentSize {
+		return sealHeader{}, fmt.Errorf("%w: invalid segment size %d", errCorruptSealedFile, h.segSize)
+	}
+	copy(h.keyID[:], b[14:])
+	copy(h.wrapped[:], b[sealHeaderAADSize:])
+	return h, nil
+}
+
+// isSealed returns whether |prefix|, the start of a file, is the start of a sealed file.
+func isSealed(prefix []byte) bool {
+	return len(prefix) >= len(sealMagic) && string(prefix[:len(sealMagic)]) == sealMagic
+}
+
+// newDataKey returns the header of a new sealed file of kind |kind|, and the cipher of its data key.
+func newDataKey(kr *keyring, kind uint8) (sealHeader, cipher.AEAD, error) {
+	key := kr.keys[0]
+	dataKey := make([]byte, encryptionKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return sealHeader{}, nil, err
+	}
+
+	h := sealHeader{kind: kind, segSize: sealSegmentSize, keyID: key.id}
+	nonce := make([]byte, gcmNonceSize, wrappedKeySize)
+	if _, err := rand.Read(nonce); err != nil {
+		return sealHeader{}, nil, err
+	}
+	wrapped := key.aead.Seal(nonce, nonce, dataKey, h.encode()[:sealHeaderAADSize])
+	copy(h.wrapped[:], wrapped)
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return sealHeader{}, nil, err
+	}
+	return h, aead, nil
+}
+
+// openDataKey returns the cipher of the data key of the sealed file whose header is |h|.
+func openDataKey(kr *keyring, h sealHeader) (cipher.AEAD, error) {
+	if kr == nil {
+		return nil, ErrEncryptionKeyNotConfigured
+	}
+	key, ok := kr.find(h.keyID)
+	if !ok {
+		return nil, fmt.Errorf("%w: key id %x", ErrEncryptionKeyUnknown, h.keyID)
+	}
+
+	dataKey, err := key.aead.Open(nil, h.wrapped[:gcmNonceSize], h.wrapped[gcmNonceSize:], h.encode()[:sealHeaderAADSize])
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to unwrap data key: %s", errCorruptSealedFile, err.Error())
+	}
+	return newAEAD(dataKey)
+}
+
+func segmentAAD(i int64, final bool) []byte {
+	aad := make([]byte, uint64Size+1)
+	binary.BigEndian.PutUint64(aad, uint64(i))
+	if final {
+		aad[uint64Size] = 1
+	}
+	return aad
+}
+
+// sealedSize returns the size of a sealed file whose plaintext is |plainSz| bytes long.
+func sealedSize(plainSz int64) int64 {
+	segments := (plainSz + sealSegmentSize - 1) / sealSegmentSize
+	if segments == 0 {
+		segments = 1
+	}
+	return int64(sealHeaderSize) + plainSz + segments*sealSegmentOverhead + int64(sealTrailerSize)
+}
+
+// sealWriter seals the plaintext written to it into a sealed file written to |wr|. Close must be called to finish
+// the file.
+type sealWriter struct {
+	wr   io.Writer
+	aead cipher.AEAD
+	buf  []byte
+	out  []byte
+	seg  int64
+	size int64
+}
+
+func newSealWriter(wr io.Writer, kr *keyring) (*sealWriter, error) {
+	h, aead, err := newDataKey(kr, sealKindFile)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = wr.Write(h.encode()); err != nil {
+		return nil, err
+	}
+	return &sealWriter{
+		wr:   wr,
+		aead: aead,
+		buf:  make([]byte, 0, sealSegmentSize),
+		out:  make([]byte, 0, sealSegmentSize+sealSegmentOverhead),
+	}, nil
+}
+
+func (sw *sealWriter) Write(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		// a full segment is only written once more plaintext follows it, so that the final segment is never empty
+		if len(sw.buf) == cap(sw.buf) {
+			if err := sw.writeSegment(false); err != nil {
+				return n, err
+			}
+		}
+		k := copy(sw.buf[len(sw.buf):cap(sw.buf)], p[n:])
+		sw.buf = sw.buf[:len(sw.buf)+k]
+		n += k
+	}
+	sw.size += int64(n)
+	return n, nil
+}
+
+func (sw *sealWriter) writeSegment(final bool) error {
+	nonce := sw.out[:gcmNonceSize]
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	sw.out = sw.aead.Seal(nonce, nonce, sw.buf, segmentAAD(sw.seg, final))
+	if _, err := sw.wr.Write(sw.out); err != nil {
+		return err
+	}
+	sw.seg++
+	sw.buf = sw.buf[:0]
+	return nil
+}
+
+// Close writes the final segment and the trailer of the file. It doesn't close the underlying writer.
+func (sw *sealWriter) Close() error {
+	if err := sw.writeSegment(true); err != nil {
+		return err
+	}
+	trailer := make([]byte, sealTrailerSize)
+	binary.BigEndian.PutUint64(trailer, uint64(sw.size))
+	copy(trailer[uint64Size:], sealMagic)
+	_, err := sw.wr.Write(trailer)
+	return err
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// newTableFileWriter returns a writer of a table file or archive to |wr|, which seals the file with |kr| unless it's
+// nil. The returned writer must be closed to finish the file; closing it doesn't close |wr|.
+func newTableFileWriter(wr io.Writer, kr *keyring) (io.WriteCloser, error) {
+	if kr == nil {
+		return nopWriteCloser{wr}, nil
+	}
+	return newSealWriter(wr, kr)
+}
+
+// newJournalFileWriter returns a writer of a chunk journal to the empty file |f|, which seals the journal with |kr|
+// unless it's nil. The returned writer must be closed to finish the file; closing it doesn't close |f|.
+func newJournalFileWriter(f *os.File, kr *keyring) (io.WriteCloser, error) {
+	if kr == nil {
+		return nopWriteCloser{f}, nil
+	}
+	sj, err := createSealedJournal(f, kr)
+	if err != nil {
+		return nil, err
+	}
+	return &sealedJournalAppender{sj: sj}, nil
+}
+
+// sealJournalFile seals the chunk journal at |path| if encryption at rest is configured and the journal isn't
+// sealed, so that records aren't appended to it in plaintext. Offsets into the journal are offsets into its
+// plaintext, so the journal index remains valid.
+func sealJournalFile(path string) (err error) {
+	kr, err := encryptionKeyring()
+	if err != nil || kr == nil {
+		return err
+	}
+
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	magic := make([]byte, len(sealMagic))
+	if _, err = src.ReadAt(magic, 0); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	} else if isSealed(magic) {
+		return nil
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".sealing")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+	wr, err := newJournalFileWriter(f, kr)
+	if err != nil {
+		return err
+	}
+	if _, err = io.CopyBuffer(wr, src, make([]byte, sealSegmentSize)); err != nil {
+		return err
+	}
+	if err = wr.Close(); err != nil {
+		return err
+	}
+	info, err := src.Stat()
+	if err != nil {
+		return err
+	}
+	if err = f.Chmod(info.Mode().Perm()); err != nil {
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	src.Close()
+	return file.Rename(f.Name(), path)
+}
+
+type sealedJournalAppender struct {
+	sj *sealedJournal
+}
+
+func (a *sealedJournalAppender) Write(p []byte) (int, error) {
+	return a.sj.WriteAt(p, a.sj.plaintextSize())
+}
+
+func (a *sealedJournalAppender) Close() error {
+	return nil
+}
+
+// sealedFile reads the plaintext of an immutable sealed file.
+type sealedFile struct {
+	r       io.ReaderAt
+	aead    cipher.AEAD
+	segSize int64
+	size    int64
+}
+
+var _ io.ReaderAt = &sealedFile{}
+
+// openSealedFile opens the sealed file in |r|, which is |sz| bytes long.
+func openSealedFile(r io.ReaderAt, sz int64, kr *keyring) (*sealedFile, error) {
+	if sz < int64(sealHeaderSize+sealTrailerSize) {
+		return nil, errCorruptSealedFile
+	}
+	b := make([]byte, sealHeaderSize)
+	if _, err := r.ReadAt(b, 0); err != nil {
+		return nil, err
+	}
+	h, err := parseSealHeader(b)
+	if err != nil {
+		return nil, err
+	}
+
+	trailer := make([]byte, sealTrailerSize)
+	if _, err = r.ReadAt(trailer, sz-int64(sealTrailerSize)); err != nil {
+		return nil, err
+	}
+	size, err := parseSealTrailer(trailer)
+	if err != nil {
+		return nil, err
+	}
+
+	sf, err := newSealedFile(r, h, size, kr)
+	if err != nil {
+		return nil, err
+	} else if sf.sealedSize() != sz {
+		return nil, fmt.Errorf("%w: size %d does not match plaintext size %d", errCorruptSealedFile, sz, size)
+	}
+	return sf, nil
+}
+
+func newSealedFile(r io.ReaderAt, h sealHeader, size int64, kr *keyring) (*sealedFile, error) {
+	if h.kind != sealKindFile {
+		return nil, fmt.Errorf("%w: expected a sealed file, found kind %d", errCorruptSealedFile, h.kind)
+	}
+	aead, err := openDataKey(kr, h)
+	if err != nil {
+		return nil, err
+	}
+	return &sealedFile{r: r, aead: aead, segSize: int64(h.segSize), size: size}, nil
+}
+
+func parseSealTrailer(b []byte) (int64, error) {
+	if len(b) != sealTrailerSize || string(b[uint64Size:]) != sealMagic {
+		return 0, fmt.Errorf("%w: invalid trailer", errCorruptSealedFile)
+	}
+	size := int64(binary.BigEndian.Uint64(b))
+	if size < 0 {
+		return 0, fmt.Errorf("%w: invalid plaintext size %d", errCorruptSealedFile, size)
+	}
+	return size, nil
+}
+
+func (sf *sealedFile) segments() int64 {
+	n := (sf.size + sf.segSize - 1) / sf.segSize
+	if n == 0 {
+		n = 1
+	}
+	return n
+}
+
+func (sf *sealedFile) sealedSize() int64 {
+	return int64(sealHeaderSize) + sf.size + sf.segments()*sealSegmentOverhead + int64(sealTrailerSize)
+}
+
+// segmentOffset returns the offset of segment |i| in the file.
+func (sf *sealedFile) segmentOffset(i int64) int64 {
+	return int64(sealHeaderSize) + i*(sf.segSize+sealSegmentOverhead)
+}
+
+// segmentLen returns the length of the plaintext of segment |i|.
+func (sf *sealedFile) segmentLen(i int64) int64 {
+	if i == sf.segments()-1 {
+		return sf.size - i*sf.segSize
+	}
+	return sf.segSize
+}
+
+// openSegment unseals |sealed|, the sealed segment |i|, into |dst|.
+func (sf *sealedFile) openSegment(i int64, sealed, dst []byte) ([]byte, error) {
+	pt, err := sf.aead.Open(dst[:0], sealed[:gcmNonceSize], sealed[gcmNonceSize:], segmentAAD(i, i == sf.segments()-1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: segment %d failed authentication", errCorruptSealedFile, i)
+	}
+	return pt, nil
+}
+
+func (sf *sealedFile) ReadAt(p []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, errors.New("negative offset")
+	} else if len(p) == 0 {
+		return 0, nil
+	} else if off >= sf.size {
+		return 0, io.EOF
+	}
+
+	end := off + int64(len(p))
+	if end > sf.size {
+		end = sf.size
+	}
+	sealed := make([]byte, sf.segSize+sealSegmentOverhead)
+	pt := make([]byte, sf.segSize)
+	for off < end {
+		i := off / sf.segSize
+		sl := sf.segmentLen(i) + sealSegmentOverhead
+		if _, err = sf.r.ReadAt(sealed[:sl], sf.segmentOffset(i)); err != nil {
+			return n, err
+		}
+		seg, err := sf.openSegment(i, sealed[:sl], pt)
+		if err != nil {
+			return n, err
+		}
+		start := off - i*sf.segSize
+		k := copy(p[n:end-off+int64(n)], seg[start:])
+		n += k
+		off += int64(k)
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// segmentRange returns the offset and length of the sealed segments which hold the |length| bytes of plaintext at
+// |off|, along with the index of the first of them.
+func (sf *sealedFile) segmentRange(off, length int64) (first, sealedOff, sealedLen int64) {
+	first = off / sf.segSize
+	last := first
+	if length > 0 {
+		last = (off + length - 1) / sf.segSize
+	}
+	if last >= sf.segments() {
+		last = sf.segments() - 1
+	}
+	if first > last {
+		first = last
+	}
+	sealedOff = sf.segmentOffset(first)
+	sealedLen = sf.segmentOffset(last) + sf.segmentLen(last) + sealSegmentOverhead - sealedOff
+	return first, sealedOff, sealedLen
+}
+
+// stream returns a reader of the |length| bytes of plaintext at |off| from |rd|, which reads the sealed segments
+// starting with segment |first|.
+func (sf *sealedFile) stream(rd io.ReadCloser, first, off, length int64) io.ReadCloser {
+	return &sealedStream{
+		sf:        sf,
+		rd:        bufio.NewReaderSize(rd, int(sf.segSize+sealSegmentOverhead)),
+		closer:    rd,
+		seg:       first,
+		skip:      off - first*sf.segSize,
+		remaining: length,
+	}
+}
+
+type sealedStream struct {
+	sf        *sealedFile
+	rd        io.Reader
+	closer    io.Closer
+	seg       int64
+	skip      int64
+	remaining int64
+	sealed    []byte
+	buf       []byte
+	pt        []byte
+}
+
+func (ss *sealedStream) Read(p []byte) (int, error) {
+	for len(ss.pt) == 0 {
+		if ss.remaining <= 0 {
+			return 0, io.EOF
+		}
+		if ss.sealed == nil {
+			ss.sealed = make([]byte, ss.sf.segSize+sealSegmentOverhead)
+			ss.buf = make([]byte, ss.sf.segSize)
+		}
+		sl := ss.sf.segmentLen(ss.seg) + sealSegmentOverhead
+		if _, err := io.ReadFull(ss.rd, ss.sealed[:sl]); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return 0, err
+		}
+		pt, err := ss.sf.openSegment(ss.seg, ss.sealed[:sl], ss.buf)
+		if err != nil {
+			return 0, err
+		}
+		ss.seg++
+		pt = pt[ss.skip:]
+		ss.skip = 0
+		if int64(len(pt)) > ss.remaining {
+			pt = pt[:ss.remaining]
+		}
+		ss.remaining -= int64(len(pt))
+		ss.pt = pt
+	}
+	n := copy(p, ss.pt)
+	ss.pt = ss.pt[n:]
+	return n, nil
+}
+
+func (ss *sealedStream) Close() error {
+	return ss.closer.Close()
+}
+
+type journalSlot struct {
+	// plainOff is the offset of the plaintext of the slot
+	plainOff int64
+	// fileOff is the offset of the slot in the file
+	fileOff int64
+	// length is the length of the plaintext of the slot
+	length int64
+	// used is the length of the plaintext of the slot which isn't replaced by later slots
+	used int64
+	// prev is the tag of the slot before this one in the file, which the slot is authenticated with
+	prev [gcmTagSize]byte
+}
+
+// journalSlotAAD returns the additional authenticated data of a slot, whose header is |hdr|.
+func journalSlotAAD(hdr []byte, fileOff int64, prev [gcmTagSize]byte) []byte {
+	aad := make([]byte, 0, uint64Size+uint32Size+uint64Size+gcmTagSize)
+	aad = append(aad, hdr[:uint64Size+uint32Size]...)
+	aad = binary.BigEndian.AppendUint64(aad, uint64(fileOff))
+	return append(aad, prev[:]...)
+}
+
+// wrappedKeyTag returns the tag of the wrapped key of a sealed journal, which its first slot is authenticated with.
+func wrappedKeyTag(h sealHeader) (tag [gcmTagSize]byte) {
+	copy(tag[:], h.wrapped[wrappedKeySize-gcmTagSize:])
+	return tag
+}
+
+// sealedJournal is a chunk journal file sealed as a log of slots. It implements journalFile.
+type sealedJournal struct {
+	f    *os.File
+	aead cipher.AEAD
+
+	mu    sync.RWMutex
+	slots []journalSlot
+	// synced is the number of slots which were synced by this process
+	synced  int
+	size    int64
+	fileEnd int64
+	// lastTag is the tag of the last slot in the file, which the next slot written is authenticated with
+	lastTag [gcmTagSize]byte
+	trimmed bool
+	pos     int64
+}
+
+var _ journalFile = &sealedJournal{}
+
+// createSealedJournal writes the header of a new sealed journal to the empty file |f|.
+func createSealedJournal(f *os.File, kr *keyring) (*sealedJournal, error) {
+	h, aead, err := newDataKey(kr, sealKindJournal)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = f.WriteAt(h.encode(), 0); err != nil {
+		return nil, err
+	}
+	if err = f.Sync(); err != nil {
+		return nil, err
+	}
+	return &sealedJournal{f: f, aead: aead, fileEnd: int64(sealHeaderSize), lastTag: wrappedKeyTag(h), trimmed: true}, nil
+}
+
+// openSealedJournal reads the slots of the sealed journal in |f|.
+func openSealedJournal(f *os.File, kr *keyring) (*sealedJournal, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	sz := info.Size()
+
+	b := make([]byte, sealHeaderSize)
+	if _, err = f.ReadAt(b, 0); err != nil {
+		return nil, err
+	}
+	h, err := parseSealHeader(b)
+	if err != nil {
+		return nil, err
+	} else if h.kind != sealKindJournal {
+		return nil, fmt.Errorf("%w: expected a sealed journal, found kind %d", errCorruptSealedFile, h.kind)
+	}
+	aead, err := openDataKey(kr, h)
+	if err != nil {
+		return nil, err
+	}
+
+	// slots are read up to anything which can't be a slot, which is the torn tail of the journal
+	var parsed []journalSlot
+	var tags [][gcmTagSize]byte
+	prev := wrappedKeyTag(h)
+	off := int64(sealHeaderSize)
+	rd := bufio.NewReaderSize(io.NewSectionReader(f, off, sz-off), journalWriterBuffSize)
+	hdr := make([]byte, journalSlotHeaderSize)
+	for {
+		if _, err = io.ReadFull(rd, hdr); err != nil {
+			break
+		}
+		plainOff := int64(binary.BigEndian.Uint64(hdr))
+		length := int64(binary.BigEndian.Uint32(hdr[uint64Size:]))
+		slotSz := int64(journalSlotHeaderSize) + length + gcmTagSize
+		if length == 0 || length > int64(h.segSize) || plainOff < 0 || off+slotSz > sz {
+			break
+		}
+		if _, err = rd.Discard(int(length)); err != nil {
+			break
+		}
+		var tag [gcmTagSize]byte
+		if _, err = io.ReadFull(rd, tag[:]); err != nil {
+			break
+		}
+		parsed = append(parsed, journalSlot{plainOff: plainOff, fileOff: off, length: length, used: length, prev: prev})
+		tags = append(tags, tag)
+		prev = tag
+		off += slotSz
+	}
+
+	// a slot which leaves a gap in the plaintext, or which replaces plaintext but doesn't authenticate, is out of
+	// place. It begins the torn tail of the journal, unless a slot after it authenticates.
+	sj := &sealedJournal{f: f, aead: aead, fileEnd: int64(sealHeaderSize), lastTag: wrappedKeyTag(h)}
+	for i, s := range parsed {
+		if s.plainOff > sj.size || (s.plainOff < sj.size && !sj.authenticates(parsed[i:i+1])) {
+			if sj.authenticates(parsed[i+1:]) {
+				return nil, fmt.Errorf("%w: journal slot at offset %d is out of place", errCorruptSealedFile, s.fileOff)
+			}
+			break
+		}
+		sj.truncate(s.plainOff)
+		sj.slots = append(sj.slots, s)
+		sj.size = s.plainOff + s.length
+		sj.fileEnd = s.fileOff + int64(journalSlotHeaderSize) + s.length + gcmTagSize
+		sj.lastTag = tags[i]
+	}
+	return sj, nil
+}
+
+// authenticates returns whether any of |slots| authenticates.
+func (sj *sealedJournal) authenticates(slots []journalSlot) bool {
+	var sealed, buf []byte
+	for _, s := range slots {
+		if cap(sealed) < int(s.length)+journalSlotHeaderSize+gcmTagSize {
+			sealed = make([]byte, int(s.length)+journalSlotHeaderSize+gcmTagSize)
+			buf = make([]byte, s.length)
+		}
+		if _, err := sj.openSlot(s, sealed, buf); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// truncate drops the plaintext after |off|.
+func (sj *sealedJournal) truncate(off int64) {
+	for len(sj.slots) > 0 {
+		last := &sj.slots[len(sj.slots)-1]
+		if last.plainOff >= off {
+			sj.slots = sj.slots[:len(sj.slots)-1]
+			continue
+		}
+		if last.plainOff+last.used > off {
+			last.used = off - last.plainOff
+		}
+		break
+	}
+	if sj.synced > len(sj.slots) {
+		sj.synced = len(sj.slots)
+	}
+	if sj.size > off {
+		sj.size = off
+	}
+}
+
+// openSlot reads and unseals |s|.
+func (sj *sealedJournal) openSlot(s journalSlot, sealed, dst []byte) ([]byte, error) {
+	sealed = sealed[:int64(journalSlotHeaderSize)+s.length+gcmTagSize]
+	if _, err := sj.f.ReadAt(sealed, s.fileOff); err != nil {
+		return nil, err
+	}
+	hdr := sealed[:journalSlotHeaderSize]
+	if int64(binary.BigEndian.Uint64(hdr)) != s.plainOff || int64(binary.BigEndian.Uint32(hdr[uint64Size:])) != s.length {
+		return nil, fmt.Errorf("%w: journal slot at %d has changed", errCorruptSealedFile, s.fileOff)
+	}
+	nonce := hdr[uint64Size+uint32Size:]
+	return sj.aead.Open(dst[:0], nonce, sealed[journalSlotHeaderSize:], journalSlotAAD(hdr, s.fileOff, s.prev))
+}
+
+// ReadAt reads the plaintext of the journal at |off|. Slots which fail authentication after the last slot which was
+// synced, or which authenticates, are the torn tail of the journal, where the journal ends. Any other slot which fails
+// authentication has been corrupted.
+func (sj *sealedJournal) ReadAt(p []byte, off int64) (n int, err error) {
+	sj.mu.RLock()
+	defer sj.mu.RUnlock()
+	if off < 0 {
+		return 0, errors.New("negative offset")
+	} else if len(p) == 0 {
+		return 0, nil
+	} else if off >= sj.size {
+		return 0, io.EOF
+	}
+
+	i := sort.Search(len(sj.slots), func(i int) bool {
+		return sj.slots[i].plainOff+sj.slots[i].used > off
+	})
+	var sealed, buf []byte
+	for n < len(p) && i < len(sj.slots) {
+		s := sj.slots[i]
+		if cap(sealed) < int(s.length)+journalSlotHeaderSize+gcmTagSize {
+			sealed = make([]byte, int(s.length)+journalSlotHeaderSize+gcmTagSize)
+			buf = make([]byte, s.length)
+		}
+		pt, err := sj.openSlot(s, sealed, buf)
+		if err != nil {
+			if !sj.isTornTail(i) {
+				return n, fmt.Errorf("%w: journal slot at offset %d: %s", errCorruptSealedFile, s.fileOff, err.Error())
+			}
+			logrus.Errorf("Error reading encrypted journal slot at offset %d; "+
+				"skipping remaining journal records: %s", s.fileOff, err.Error())
+			break
+		}
+		k := copy(p[n:], pt[off-s.plainOff:s.used])
+		n += k
+		off += int64(k)
+		i++
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// isTornTail returns whether the slot at |i|, which failed authentication, can be part of the torn tail of the journal.
+// Slots which were synced aren't, and neither is a slot followed by one which authenticates.
+func (sj *sealedJournal) isTornTail(i int) bool {
+	return i >= sj.synced && !sj.authenticates(sj.slots[i+1:])
+}
+
+// WriteAt seals |p| into new slots at the end of the file. Writing before the end of the plaintext replaces the
+// plaintext from |off| onward.
+func (sj *sealedJournal) WriteAt(p []byte, off int64) (int, error) {
+	sj.mu.Lock()
+	defer sj.mu.Unlock()
+	if off < 0 || off > sj.size {
+		return 0, fmt.Errorf("cannot write journal at offset %d, journal size is %d", off, sj.size)
+	}
+
+	if !sj.trimmed {
+		// drop any torn tail which couldn't be read as slots, so that the slots written next are read back
+		if err := sj.f.Truncate(sj.fileEnd); err != nil {
+			return 0, err
+		}
+		sj.trimmed = true
+	}
+
+	var out []byte
+	var slots []journalSlot
+	fileOff := sj.fileEnd
+	prev := sj.lastTag
+	for n := 0; n < len(p); {
+		k := len(p) - n
+		if k > sealSegmentSize {
+			k = sealSegmentSize
+		}
+		start := len(out)
+		hdr := make([]byte, journalSlotHeaderSize)
+		binary.BigEndian.PutUint64(hdr, uint64(off+int64(n)))
+		binary.BigEndian.PutUint32(hdr[uint64Size:], uint32(k))
+		if _, err := rand.Read(hdr[uint64Size+uint32Size:]); err != nil {
+			return 0, err
+		}
+		slotOff := fileOff + int64(start)
+		out = append(out, hdr...)
+		out = sj.aead.Seal(out, hdr[uint64Size+uint32Size:], p[n:n+k], journalSlotAAD(hdr, slotOff, prev))
+		slots = append(slots, journalSlot{plainOff: off + int64(n), fileOff: slotOff, length: int64(k), used: int64(k), prev: prev})
+		copy(prev[:], out[len(out)-gcmTagSize:])
+		n += k
+	}
+	if _, err := sj.f.WriteAt(out, fileOff); err != nil {
+		return 0, err
+	}
+
+	sj.truncate(off)
+	sj.slots = append(sj.slots, slots...)
+	sj.size = off + int64(len(p))
+	sj.fileEnd = fileOff + int64(len(out))
+	sj.lastTag = prev
+	return len(p), nil
+}
+
+func (sj *sealedJournal) Read(p []byte) (int, error) {
+	n, err := sj.ReadAt(p, sj.pos)
+	sj.pos += int64(n)
+	if n > 0 && err == io.EOF {
+		err = nil
+	}
+	return n, err
+}
+
+func (sj *sealedJournal) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += sj.pos
+	case io.SeekEnd:
+		sj.mu.RLock()
+		offset += sj.size
+		sj.mu.RUnlock()
+	default:
+		return 0, errors.New("invalid whence")
+	}
+	if offset < 0 {
+		return 0, errors.New("negative position")
+	}
+	sj.pos = offset
+	return offset, nil
+}
+
+func (sj *sealedJournal) Sync() error {
+	sj.mu.Lock()
+	defer sj.mu.Unlock()
+	if err := sj.f.Sync(); err != nil {
+		return err
+	}
+	sj.synced = len(sj.slots)
+	return nil
+}
+
+func (sj *sealedJournal) Close() error {
+	return sj.f.Close()
+}
+
+// plaintextSize returns the size of the plaintext of the journal.
+func (sj *sealedJournal) plaintextSize() int64 {
+	sj.mu.RLock()
+	defer sj.mu.RUnlock()
+	return sj.size
+}
+
+// openPlaintext returns a reader of the plaintext of |f|, which is |sz| bytes long, along with the size of the
+// plaintext. Files which aren't sealed are read as they are.
+func openPlaintext(f *os.File, sz int64) (io.ReaderAt, int64, error) {
+	if sz < int64(len(sealMagic)) {
+		return f, sz, nil
+	}
+	magic := make([]byte, len(sealMagic))
+	if _, err := f.ReadAt(magic, 0); err != nil {
+		return nil, 0, err
+	} else if !isSealed(magic) {
+		return f, sz, nil
+	}
+
+	kr, err := encryptionKeyring()
+	if err != nil {
+		return nil, 0, err
+	}
+	b := make([]byte, sealHeaderSize)
+	if _, err = f.ReadAt(b, 0); err != nil {
+		return nil, 0, err
+	}
+	h, err := parseSealHeader(b)
+	if err != nil {
+		return nil, 0, err
+	}
+	if h.kind == sealKindJournal {
+		sj, err := openSealedJournal(f, kr)
+		if err != nil {
+			return nil, 0, err
+		}
+		return sj, sj.plaintextSize(), nil
+	}
+	sf, err := openSealedFile(f, sz, kr)
+	if err != nil {
+		return nil, 0, err
+	}
+	return sf, sf.size, nil
+}
+
+// TableFileReader reads the contents of a table file, archive or chunk journal.
+type TableFileReader interface {
+	io.ReaderAt
+	io.Closer
+}
+
+type readerAtCloser struct {
+	io.ReaderAt
+	io.Closer
+}
+
+// OpenTableFile opens the table file, archive or chunk journal at |path|, which is decrypted as it's read if it's
+// encrypted at rest. It returns the size of the contents of the file.
+func OpenTableFile(path string) (TableFileReader, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, 0, err
+	}
+	r, sz, err := openPlaintext(f, info.Size())
+	if err != nil {
+		f.Close()
+		return nil, 0, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	return readerAtCloser{r, f}, sz, nil
+}
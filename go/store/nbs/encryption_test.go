@@ -0,0 +1,613 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/constants"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+func makeTestKeyring(t *testing.T, n int) (*keyring, string) {
+	keys := make([]string, n)
+	for i := range keys {
+		key := make([]byte, encryptionKeySize)
+		_, err := rand.Read(key)
+		require.NoError(t, err)
+		keys[i] = base64.StdEncoding.EncodeToString(key)
+	}
+	s := strings.Join(keys, "\n")
+	kr, err := parseKeyring(s)
+	require.NoError(t, err)
+	return kr, s
+}
+
+// useTestKeyring configures |kr| as the keyring of the process for the duration of the test.
+func useTestKeyring(t *testing.T, kr *keyring) {
+	keyringOnce.Do(func() {})
+	prev, prevErr := loadedKeyring, loadKeyringErr
+	loadedKeyring, loadKeyringErr = kr, nil
+	t.Cleanup(func() {
+		loadedKeyring, loadKeyringErr = prev, prevErr
+	})
+}
+
+func sealBytes(t *testing.T, kr *keyring, data []byte) []byte {
+	buf := &bytes.Buffer{}
+	sw, err := newSealWriter(buf, kr)
+	require.NoError(t, err)
+	_, err = sw.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, sw.Close())
+	return buf.Bytes()
+}
+
+func TestParseKeyring(t *testing.T) {
+	_, keys := makeTestKeyring(t, 3)
+	fields := strings.Split(keys, "\n")
+
+	kr, err := parseKeyring(" " + fields[0] + ",\n" + fields[1] + "\t" + fields[2] + "\n")
+	require.NoError(t, err)
+	assert.Len(t, kr.keys, 3)
+	again, err := parseKeyring(keys)
+	require.NoError(t, err)
+	for i := range kr.keys {
+		assert.Equal(t, kr.keys[i].id, again.keys[i].id)
+	}
+
+	_, err = parseKeyring(" \n")
+	assert.Error(t, err)
+	_, err = parseKeyring("not-base64!")
+	assert.Error(t, err)
+	_, err = parseKeyring(base64.StdEncoding.EncodeToString([]byte("too short")))
+	assert.Error(t, err)
+}
+
+func TestSealedFileRoundTrip(t *testing.T) {
+	kr, _ := makeTestKeyring(t, 1)
+	for _, sz := range []int{0, 1, sealSegmentSize - 1, sealSegmentSize, sealSegmentSize + 1, 3*sealSegmentSize + 5} {
+		data := make([]byte, sz)
+		_, err := rand.Read(data)
+		require.NoError(t, err)
+
+		sealed := sealBytes(t, kr, data)
+		assert.Equal(t, sealedSize(int64(sz)), int64(len(sealed)))
+		if sz >= 64 {
+			assert.False(t, bytes.Contains(sealed, data[:64]))
+		}
+
+		sf, err := openSealedFile(bytes.NewReader(sealed), int64(len(sealed)), kr)
+		require.NoError(t, err)
+		assert.Equal(t, int64(sz), sf.size)
+
+		all := make([]byte, sz)
+		n, err := sf.ReadAt(all, 0)
+		require.NoError(t, err)
+		assert.Equal(t, sz, n)
+		assert.Equal(t, data, all)
+
+		for _, rng := range [][2]int{{0, 1}, {sz / 2, sz / 3}, {sealSegmentSize - 3, 7}, {sz - 1, 1}} {
+			off, l := rng[0], rng[1]
+			if off < 0 || off+l > sz {
+				continue
+			}
+			p := make([]byte, l)
+			_, err = sf.ReadAt(p, int64(off))
+			require.NoError(t, err)
+			assert.Equal(t, data[off:off+l], p)
+		}
+
+		_, err = sf.ReadAt(make([]byte, 2), int64(sz))
+		assert.Equal(t, io.EOF, err)
+	}
+}
+
+func TestSealedFileRejectsTampering(t *testing.T) {
+	kr, _ := makeTestKeyring(t, 1)
+	data := make([]byte, 2*sealSegmentSize+100)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+	sealed := sealBytes(t, kr, data)
+
+	t.Run("flipped bit", func(t *testing.T) {
+		tampered := bytes.Clone(sealed)
+		tampered[sealHeaderSize+sealSegmentSize/2] ^= 1
+		sf, err := openSealedFile(bytes.NewReader(tampered), int64(len(tampered)), kr)
+		require.NoError(t, err)
+		_, err = sf.ReadAt(make([]byte, 10), 0)
+		assert.ErrorIs(t, err, errCorruptSealedFile)
+	})
+	t.Run("reordered segments", func(t *testing.T) {
+		stride := sealSegmentSize + sealSegmentOverhead
+		tampered := bytes.Clone(sealed)
+		first := bytes.Clone(tampered[sealHeaderSize : sealHeaderSize+stride])
+		copy(tampered[sealHeaderSize:], tampered[sealHeaderSize+stride:sealHeaderSize+2*stride])
+		copy(tampered[sealHeaderSize+stride:], first)
+		sf, err := openSealedFile(bytes.NewReader(tampered), int64(len(tampered)), kr)
+		require.NoError(t, err)
+		_, err = sf.ReadAt(make([]byte, 10), 0)
+		assert.ErrorIs(t, err, errCorruptSealedFile)
+	})
+	t.Run("truncated", func(t *testing.T) {
+		truncated := sealed[:len(sealed)-sealTrailerSize-10]
+		_, err := openSealedFile(bytes.NewReader(truncated), int64(len(truncated)), kr)
+		assert.ErrorIs(t, err, errCorruptSealedFile)
+	})
+	t.Run("wrong key", func(t *testing.T) {
+		other, _ := makeTestKeyring(t, 1)
+		_, err := openSealedFile(bytes.NewReader(sealed), int64(len(sealed)), other)
+		assert.ErrorIs(t, err, ErrEncryptionKeyUnknown)
+		_, err = openSealedFile(bytes.NewReader(sealed), int64(len(sealed)), nil)
+		assert.ErrorIs(t, err, ErrEncryptionKeyNotConfigured)
+	})
+	t.Run("rotated key", func(t *testing.T) {
+		rotated := &keyring{keys: append([]encryptionKey{}, kr.keys...)}
+		next, _ := makeTestKeyring(t, 1)
+		rotated.keys = append(next.keys, rotated.keys...)
+		sf, err := openSealedFile(bytes.NewReader(sealed), int64(len(sealed)), rotated)
+		require.NoError(t, err)
+		p := make([]byte, len(data))
+		_, err = sf.ReadAt(p, 0)
+		require.NoError(t, err)
+		assert.Equal(t, data, p)
+	})
+}
+
+func readJournal(t *testing.T, sj *sealedJournal) []byte {
+	p := make([]byte, sj.plaintextSize())
+	n, err := sj.ReadAt(p, 0)
+	if err != io.EOF {
+		require.NoError(t, err)
+	}
+	return p[:n]
+}
+
+func TestSealedJournal(t *testing.T) {
+	kr, _ := makeTestKeyring(t, 1)
+	path := filepath.Join(t.TempDir(), chunkJournalName)
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0666)
+	require.NoError(t, err)
+	sj, err := createSealedJournal(f, kr)
+	require.NoError(t, err)
+
+	first := bytes.Repeat([]byte("first record;"), 2000)
+	_, err = sj.WriteAt(first, 0)
+	require.NoError(t, err)
+	second := []byte("second record")
+	_, err = sj.WriteAt(second, int64(len(first)))
+	require.NoError(t, err)
+	// a write before the end of the journal replaces what follows it
+	replaced := []byte("replaced")
+	_, err = sj.WriteAt(replaced, int64(len(first))+6)
+	require.NoError(t, err)
+	expected := append(append(bytes.Clone(first), second[:6]...), replaced...)
+	assert.Equal(t, expected, readJournal(t, sj))
+	require.NoError(t, sj.Close())
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.False(t, bytes.Contains(raw, []byte("first record")))
+
+	// a torn slot at the end of the journal is dropped on open, and then truncated away by the next write
+	f, err = os.OpenFile(path, os.O_RDWR|os.O_APPEND, 0666)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 100, 1, 2, 3})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	f, err = os.OpenFile(path, os.O_RDWR, 0666)
+	require.NoError(t, err)
+	sj, err = openSealedJournal(f, kr)
+	require.NoError(t, err)
+	assert.Equal(t, expected, readJournal(t, sj))
+	_, err = sj.WriteAt([]byte("appended"), int64(len(expected)))
+	require.NoError(t, err)
+	expected = append(expected, "appended"...)
+	require.NoError(t, sj.Close())
+
+	f, err = os.OpenFile(path, os.O_RDWR, 0666)
+	require.NoError(t, err)
+	sj, err = openSealedJournal(f, kr)
+	require.NoError(t, err)
+	assert.Equal(t, expected, readJournal(t, sj))
+
+	// the journal can be read sequentially, as it is when it's bootstrapped
+	_, err = sj.Seek(int64(len(first)), io.SeekStart)
+	require.NoError(t, err)
+	rest, err := io.ReadAll(sj)
+	require.NoError(t, err)
+	assert.Equal(t, expected[len(first):], rest)
+	require.NoError(t, sj.Close())
+
+	// a slot at the end of the journal which fails authentication is its torn tail, and ends the journal
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	f, err = os.OpenFile(path, os.O_RDWR, 0666)
+	require.NoError(t, err)
+	_, err = f.WriteAt([]byte{0xff}, info.Size()-1)
+	require.NoError(t, err)
+	sj, err = openSealedJournal(f, kr)
+	require.NoError(t, err)
+	assert.Equal(t, expected[:len(expected)-len("appended")], readJournal(t, sj))
+	require.NoError(t, sj.Close())
+
+	// but a slot before one which authenticates has been corrupted
+	f, err = os.OpenFile(path, os.O_RDWR, 0666)
+	require.NoError(t, err)
+	_, err = f.WriteAt([]byte{0xff}, int64(sealHeaderSize+journalSlotHeaderSize))
+	require.NoError(t, err)
+	sj, err = openSealedJournal(f, kr)
+	require.NoError(t, err)
+	_, err = sj.ReadAt(make([]byte, 10), 0)
+	assert.ErrorIs(t, err, errCorruptSealedFile)
+	require.NoError(t, sj.Close())
+
+	// as is a slot which was synced
+	f, err = os.OpenFile(filepath.Join(t.TempDir(), chunkJournalName), os.O_RDWR|os.O_CREATE, 0666)
+	require.NoError(t, err)
+	sj, err = createSealedJournal(f, kr)
+	require.NoError(t, err)
+	defer sj.Close()
+	_, err = sj.WriteAt(second, 0)
+	require.NoError(t, err)
+	require.NoError(t, sj.Sync())
+	_, err = f.WriteAt([]byte{0xff}, int64(sealHeaderSize+journalSlotHeaderSize))
+	require.NoError(t, err)
+	_, err = sj.ReadAt(make([]byte, len(second)), 0)
+	assert.ErrorIs(t, err, errCorruptSealedFile)
+}
+
+func TestSealedJournalRejectsMovedSlots(t *testing.T) {
+	kr, _ := makeTestKeyring(t, 1)
+	path := filepath.Join(t.TempDir(), chunkJournalName)
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0666)
+	require.NoError(t, err)
+	sj, err := createSealedJournal(f, kr)
+	require.NoError(t, err)
+	var expected []byte
+	for _, rec := range []string{"record 0", "record 1", "record 2", "record 3"} {
+		_, err = sj.WriteAt([]byte(rec), int64(len(expected)))
+		require.NoError(t, err)
+		expected = append(expected, rec...)
+	}
+	require.NoError(t, sj.Close())
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	slotSz := journalSlotHeaderSize + len("record 0") + gcmTagSize
+	slot := func(i int) []byte {
+		off := sealHeaderSize + i*slotSz
+		return bytes.Clone(raw[off : off+slotSz])
+	}
+
+	open := func(data []byte) (*sealedJournal, error) {
+		require.NoError(t, os.WriteFile(path, data, 0666))
+		f, err := os.OpenFile(path, os.O_RDWR, 0666)
+		require.NoError(t, err)
+		t.Cleanup(func() { f.Close() })
+		return openSealedJournal(f, kr)
+	}
+
+	// a replayed slot would replace the plaintext which follows its offset, but it fails authentication at the end of
+	// the journal, so it's dropped as a torn tail
+	sj, err = open(append(bytes.Clone(raw), slot(0)...))
+	require.NoError(t, err)
+	assert.Equal(t, expected, readJournal(t, sj))
+
+	// reordered slots fail authentication
+	reordered := append(bytes.Clone(raw[:sealHeaderSize]), slot(1)...)
+	reordered = append(reordered, slot(0)...)
+	reordered = append(reordered, slot(2)...)
+	reordered = append(reordered, slot(3)...)
+	_, err = open(reordered)
+	assert.ErrorIs(t, err, errCorruptSealedFile)
+
+	// as does a slot replayed in place of another
+	replayed := bytes.Clone(raw)
+	copy(replayed[sealHeaderSize+slotSz:], slot(0))
+	_, err = open(replayed)
+	assert.ErrorIs(t, err, errCorruptSealedFile)
+}
+
+func TestSealingBlobstore(t *testing.T) {
+	ctx := context.Background()
+	kr, _ := makeTestKeyring(t, 1)
+	inner := blobstore.NewInMemoryBlobstore("")
+	bs := newSealingBlobstore(inner, kr)
+
+	data := make([]byte, 3*sealSegmentSize+17)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+	_, err = blobstore.PutBytes(ctx, bs, "table", data)
+	require.NoError(t, err)
+
+	raw, _, err := blobstore.GetBytes(ctx, inner, "table", blobstore.AllRange)
+	require.NoError(t, err)
+	assert.True(t, isSealed(raw))
+	assert.Equal(t, sealedSize(int64(len(data))), int64(len(raw)))
+
+	sz := int64(len(data))
+	tests := []struct {
+		rng      blobstore.BlobRange
+		from, to int64
+	}{
+		{blobstore.AllRange, 0, sz},
+		{blobstore.NewBlobRange(0, 10), 0, 10},
+		{blobstore.NewBlobRange(sealSegmentSize-5, 10), sealSegmentSize - 5, sealSegmentSize + 5},
+		{blobstore.NewBlobRange(100, 0), 100, sz},
+		{blobstore.NewBlobRange(-40, 0), sz - 40, sz},
+		{blobstore.NewBlobRange(-40, 10), sz - 40, sz - 30},
+		{blobstore.NewBlobRange(sz-5, 100), sz - 5, sz},
+		{blobstore.NewBlobRange(sz, 0), sz, sz},
+	}
+	for _, test := range tests {
+		got, _, err := blobstore.GetBytes(ctx, bs, "table", test.rng)
+		require.NoError(t, err)
+		assert.Equal(t, data[test.from:test.to], got)
+	}
+
+	// blobs which aren't sealed are read as they are
+	_, err = blobstore.PutBytes(ctx, inner, "plain", []byte("plaintext"))
+	require.NoError(t, err)
+	got, _, err := blobstore.GetBytes(ctx, bs, "plain", blobstore.NewBlobRange(-4, 0))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("text"), got)
+
+	_, err = bs.Concatenate(ctx, "concat", []string{"plain", "table"})
+	require.NoError(t, err)
+	got, _, err = blobstore.GetBytes(ctx, bs, "concat", blobstore.AllRange)
+	require.NoError(t, err)
+	assert.Equal(t, append([]byte("plaintext"), data...), got)
+
+	// the manifest isn't sealed
+	_, err = blobstore.PutBytes(ctx, bs, manifestFile, []byte("manifest"))
+	require.NoError(t, err)
+	got, _, err = blobstore.GetBytes(ctx, inner, manifestFile, blobstore.AllRange)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("manifest"), got)
+}
+
+func TestEncryptedStores(t *testing.T) {
+	ctx := context.Background()
+	kr, _ := makeTestKeyring(t, 1)
+	useTestKeyring(t, kr)
+
+	data := []byte(strings.Repeat("this chunk must not be stored in plaintext;", 20))
+	c := chunks.NewChunk(data)
+
+	commit := func(t *testing.T, store *NomsBlockStore) {
+		require.NoError(t, store.Put(ctx, c, noopGetAddrs))
+		root, err := store.Root(ctx)
+		require.NoError(t, err)
+		ok, err := store.Commit(ctx, c.Hash(), root)
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+	hasPlaintext := func(t *testing.T, dir string) bool {
+		found := false
+		err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			require.NoError(t, err)
+			if info.IsDir() {
+				return nil
+			}
+			b, err := os.ReadFile(path)
+			require.NoError(t, err)
+			if bytes.Contains(b, data[:40]) {
+				t.Logf("plaintext found in %s", path)
+				found = true
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		return found
+	}
+	assertNoPlaintext := func(t *testing.T, dir string) {
+		assert.False(t, hasPlaintext(t, dir))
+	}
+
+	t.Run("table files", func(t *testing.T) {
+		dir := t.TempDir()
+		store, err := NewLocalStore(ctx, constants.FormatDefaultString, dir, defaultMemTableSize, &UnlimitedQuotaProvider{})
+		require.NoError(t, err)
+		commit(t, store)
+		require.NoError(t, store.Close())
+		assertNoPlaintext(t, dir)
+
+		store, err = NewLocalStore(ctx, constants.FormatDefaultString, dir, defaultMemTableSize, &UnlimitedQuotaProvider{})
+		require.NoError(t, err)
+		defer store.Close()
+		assertInputInStore(data, c.Hash(), store, assert.New(t))
+	})
+	t.Run("chunk journal", func(t *testing.T) {
+		dir := t.TempDir()
+		store, err := NewLocalJournalingStore(ctx, constants.FormatDefaultString, dir, &UnlimitedQuotaProvider{})
+		require.NoError(t, err)
+		commit(t, store)
+		require.NoError(t, store.Close())
+		assertNoPlaintext(t, dir)
+
+		store, err = NewLocalJournalingStore(ctx, constants.FormatDefaultString, dir, &UnlimitedQuotaProvider{})
+		require.NoError(t, err)
+		defer store.Close()
+		assertInputInStore(data, c.Hash(), store, assert.New(t))
+	})
+	t.Run("cold generation", func(t *testing.T) {
+		dir, cacheDir := t.TempDir(), t.TempDir()
+		store, err := NewColdStore(ctx, constants.FormatDefaultString, blobstore.NewLocalBlobstore(dir), cacheDir, 1<<20, &UnlimitedQuotaProvider{})
+		require.NoError(t, err)
+		commit(t, store)
+		require.NoError(t, store.Close())
+		assertNoPlaintext(t, dir)
+
+		store, err = NewColdStore(ctx, constants.FormatDefaultString, blobstore.NewLocalBlobstore(dir), cacheDir, 1<<20, &UnlimitedQuotaProvider{})
+		require.NoError(t, err)
+		defer store.Close()
+		assertInputInStore(data, c.Hash(), store, assert.New(t))
+		assertNoPlaintext(t, cacheDir)
+	})
+	t.Run("cold generation gc", func(t *testing.T) {
+		oldGenDir, newGenDir, coldDir, cacheDir := t.TempDir(), t.TempDir(), t.TempDir(), t.TempDir()
+		oldGen, err := NewLocalStore(ctx, constants.FormatDefaultString, oldGenDir, defaultMemTableSize, &UnlimitedQuotaProvider{})
+		require.NoError(t, err)
+		commit(t, oldGen)
+		newGen, err := NewLocalStore(ctx, constants.FormatDefaultString, newGenDir, defaultMemTableSize, &UnlimitedQuotaProvider{})
+		require.NoError(t, err)
+		cold, err := NewColdStore(ctx, constants.FormatDefaultString, blobstore.NewLocalBlobstore(coldDir), cacheDir, 1<<20, &UnlimitedQuotaProvider{})
+		require.NoError(t, err)
+
+		gcs := NewTieredGenerationalCS(oldGen, newGen, cold, nil, 0)
+		defer gcs.Close()
+		require.NoError(t, gcs.BeginGC(func(hash.Hash) bool { return false }, chunks.GCMode_Full))
+		finalizer, _ := sweepInto(t, ctx, gcs, gcs.ColdGen(), noopGetAddrs, func(_ context.Context, hs hash.HashSet) (hash.HashSet, error) {
+			return hs, nil
+		}, c.Hash())
+		require.NoError(t, finalizer.SwapChunksInStore(ctx))
+		gcs.EndGC(chunks.GCMode_Full)
+		has, err := gcs.coldGen.Has(ctx, c.Hash())
+		require.NoError(t, err)
+		require.True(t, has)
+		assertNoPlaintext(t, coldDir)
+
+		got, err := gcs.Get(ctx, c.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, data, got.Data())
+	})
+	t.Run("blobstore", func(t *testing.T) {
+		dir := t.TempDir()
+		store, err := NewBSStore(ctx, constants.FormatDefaultString, blobstore.NewLocalBlobstore(dir), defaultMemTableSize, &UnlimitedQuotaProvider{})
+		require.NoError(t, err)
+		commit(t, store)
+		require.NoError(t, store.Close())
+		assertNoPlaintext(t, dir)
+
+		store, err = NewBSStore(ctx, constants.FormatDefaultString, blobstore.NewLocalBlobstore(dir), defaultMemTableSize, &UnlimitedQuotaProvider{})
+		require.NoError(t, err)
+		defer store.Close()
+		assertInputInStore(data, c.Hash(), store, assert.New(t))
+	})
+	t.Run("unsealed stores", func(t *testing.T) {
+		// remotes are read by other machines, which don't have the key
+		dir := t.TempDir()
+		store, err := NewUnsealedLocalStore(ctx, constants.FormatDefaultString, dir, defaultMemTableSize, &UnlimitedQuotaProvider{})
+		require.NoError(t, err)
+		commit(t, store)
+		require.NoError(t, store.Close())
+		assert.True(t, hasPlaintext(t, dir))
+
+		dir = t.TempDir()
+		store, err = NewUnsealedBSStore(ctx, constants.FormatDefaultString, blobstore.NewLocalBlobstore(dir), defaultMemTableSize, &UnlimitedQuotaProvider{})
+		require.NoError(t, err)
+		commit(t, store)
+		require.NoError(t, store.Close())
+		assert.True(t, hasPlaintext(t, dir))
+	})
+}
+
+func TestSealExistingJournal(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	plain := chunks.NewChunk([]byte("written before encryption was configured"))
+	sealed := chunks.NewChunk([]byte("written after encryption was configured"))
+
+	store, err := NewLocalJournalingStore(ctx, constants.FormatDefaultString, dir, &UnlimitedQuotaProvider{})
+	require.NoError(t, err)
+	require.NoError(t, store.Put(ctx, plain, noopGetAddrs))
+	root, err := store.Root(ctx)
+	require.NoError(t, err)
+	ok, err := store.Commit(ctx, plain.Hash(), root)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NoError(t, store.Close())
+
+	kr, _ := makeTestKeyring(t, 1)
+	useTestKeyring(t, kr)
+
+	store, err = NewLocalJournalingStore(ctx, constants.FormatDefaultString, dir, &UnlimitedQuotaProvider{})
+	require.NoError(t, err)
+	require.NoError(t, store.Put(ctx, sealed, noopGetAddrs))
+	ok, err = store.Commit(ctx, sealed.Hash(), plain.Hash())
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NoError(t, store.Close())
+
+	raw, err := os.ReadFile(filepath.Join(dir, chunkJournalName))
+	require.NoError(t, err)
+	assert.True(t, isSealed(raw))
+
+	store, err = NewLocalJournalingStore(ctx, constants.FormatDefaultString, dir, &UnlimitedQuotaProvider{})
+	require.NoError(t, err)
+	defer store.Close()
+	root, err = store.Root(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, sealed.Hash(), root)
+	assertInputInStore(plain.Data(), plain.Hash(), store, assert.New(t))
+	assertInputInStore(sealed.Data(), sealed.Hash(), store, assert.New(t))
+}
+
+func TestResealColdTableFiles(t *testing.T) {
+	ctx := context.Background()
+	oldKr, _ := makeTestKeyring(t, 1)
+	newKr, _ := makeTestKeyring(t, 1)
+	rotating := &keyring{keys: []encryptionKey{newKr.keys[0], oldKr.keys[0]}}
+
+	coldDir, cacheDir := t.TempDir(), t.TempDir()
+	open := func(kr *keyring) *GenerationalNBS {
+		useTestKeyring(t, kr)
+		oldGen, err := NewLocalStore(ctx, constants.FormatDefaultString, t.TempDir(), defaultMemTableSize, &UnlimitedQuotaProvider{})
+		require.NoError(t, err)
+		newGen, err := NewLocalStore(ctx, constants.FormatDefaultString, t.TempDir(), defaultMemTableSize, &UnlimitedQuotaProvider{})
+		require.NoError(t, err)
+		cold, err := NewColdStore(ctx, constants.FormatDefaultString, blobstore.NewLocalBlobstore(coldDir), cacheDir, 1<<20, &UnlimitedQuotaProvider{})
+		require.NoError(t, err)
+		return NewTieredGenerationalCS(oldGen, newGen, cold, nil, 0)
+	}
+
+	c := chunks.NewChunk([]byte("sealed with a key which is rotated out"))
+	gcs := open(oldKr)
+	putTableFile(t, ctx, gcs.coldGen, c)
+	require.NoError(t, gcs.Close())
+
+	gcs = open(rotating)
+	require.NoError(t, gcs.ResealColdTableFiles(ctx))
+	// nothing is left to re-seal
+	gcs.coldGen.mu.RLock()
+	specs, err := gcs.coldGen.tables.toSpecs()
+	gcs.coldGen.mu.RUnlock()
+	require.NoError(t, err)
+	require.NoError(t, gcs.ResealColdTableFiles(ctx))
+	after, err := gcs.coldGen.tables.toSpecs()
+	require.NoError(t, err)
+	assert.Equal(t, specs, after)
+	require.NoError(t, gcs.Close())
+
+	gcs = open(newKr)
+	defer gcs.Close()
+	got, err := gcs.Get(ctx, c.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, c.Data(), got.Data())
+}
@@ -43,12 +43,20 @@ import (
 const tempTablePrefix = "nbs_table_"
 
 func newFSTablePersister(dir string, q MemoryQuotaProvider) tablePersister {
-	return &fsTablePersister{dir, q, sync.Mutex{}, nil, make(map[string]struct{})}
+	return &fsTablePersister{dir: dir, q: q, curTmps: make(map[string]struct{})}
+}
+
+// newUnsealedFSTablePersister returns a persister which writes table files in plaintext, even when encryption at rest
+// is configured.
+func newUnsealedFSTablePersister(dir string, q MemoryQuotaProvider) tablePersister {
+	return &fsTablePersister{dir: dir, q: q, unsealed: true, curTmps: make(map[string]struct{})}
 }
 
 type fsTablePersister struct {
 	dir string
 	q   MemoryQuotaProvider
+	// unsealed is true if table files are written in plaintext, as they're read by other machines
+	unsealed bool
 
 	// Protects the following two maps.
 	removeMu sync.Mutex
@@ -113,6 +121,14 @@ func (ftp *fsTablePersister) Path() string {
 	return ftp.dir
 }
 
+// keyring returns the keyring which seals the files written by |ftp|, or nil if they're written in plaintext.
+func (ftp *fsTablePersister) keyring() (*keyring, error) {
+	if ftp.unsealed {
+		return nil, nil
+	}
+	return encryptionKeyring()
+}
+
 func (ftp *fsTablePersister) CopyTableFile(ctx context.Context, r io.Reader, fileId string, fileSz uint64, chunkCount uint32) error {
 	tn, f, err := func() (n string, cleanup func(), err error) {
 		ftp.removeMu.Lock()
@@ -138,7 +154,25 @@ func (ftp *fsTablePersister) CopyTableFile(ctx context.Context, r io.Reader, fil
 			}
 		}()
 
-		_, err = io.Copy(temp, r)
+		var kr *keyring
+		kr, err = ftp.keyring()
+		if err != nil {
+			return "", cleanup, err
+		}
+		var wr io.WriteCloser
+		if fileId == chunkJournalAddr {
+			wr, err = newJournalFileWriter(temp, kr)
+		} else {
+			wr, err = newTableFileWriter(temp, kr)
+		}
+		if err != nil {
+			return "", cleanup, err
+		}
+		_, err = io.Copy(wr, r)
+		if err != nil {
+			return "", cleanup, err
+		}
+		err = wr.Close()
 		if err != nil {
 			return "", cleanup, err
 		}
@@ -171,6 +205,9 @@ func (ftp *fsTablePersister) TryMoveCmpChunkTableWriter(ctx context.Context, fil
 		ftp.toKeep[filepath.Clean(path)] = struct{}{}
 	}
 	defer ftp.removeMu.Unlock()
+	if ftp.unsealed {
+		return w.flushToPlainFile(path)
+	}
 	return w.FlushToFile(path)
 }
 
@@ -203,7 +240,21 @@ func (ftp *fsTablePersister) persistTable(ctx context.Context, name hash.Hash, d
 			}
 		}()
 
-		_, ferr = io.Copy(temp, bytes.NewReader(data))
+		var kr *keyring
+		kr, ferr = ftp.keyring()
+		if ferr != nil {
+			return "", cleanup, ferr
+		}
+		var wr io.WriteCloser
+		wr, ferr = newTableFileWriter(temp, kr)
+		if ferr != nil {
+			return "", cleanup, ferr
+		}
+		_, ferr = io.Copy(wr, bytes.NewReader(data))
+		if ferr != nil {
+			return "", cleanup, ferr
+		}
+		ferr = wr.Close()
 		if ferr != nil {
 			return "", cleanup, ferr
 		}
@@ -269,6 +320,17 @@ func (ftp *fsTablePersister) ConjoinAll(ctx context.Context, sources chunkSource
 			}
 		}()
 
+		var kr *keyring
+		kr, ferr = ftp.keyring()
+		if ferr != nil {
+			return "", cleanup, ferr
+		}
+		var wr io.WriteCloser
+		wr, ferr = newTableFileWriter(temp, kr)
+		if ferr != nil {
+			return "", cleanup, ferr
+		}
+
 		for _, sws := range plan.sources.sws {
 			var r io.ReadCloser
 			r, _, ferr = sws.source.reader(ctx)
@@ -276,7 +338,7 @@ func (ftp *fsTablePersister) ConjoinAll(ctx context.Context, sources chunkSource
 				return "", cleanup, ferr
 			}
 
-			n, ferr := io.CopyN(temp, r, int64(sws.dataLen))
+			n, ferr := io.CopyN(wr, r, int64(sws.dataLen))
 			if ferr != nil {
 				r.Close()
 				return "", cleanup, ferr
@@ -293,8 +355,13 @@ func (ftp *fsTablePersister) ConjoinAll(ctx context.Context, sources chunkSource
 			}
 		}
 
-		_, ferr = temp.Write(plan.mergedIndex)
+		_, ferr = wr.Write(plan.mergedIndex)
+
+		if ferr != nil {
+			return "", cleanup, ferr
+		}
 
+		ferr = wr.Close()
 		if ferr != nil {
 			return "", cleanup, ferr
 		}
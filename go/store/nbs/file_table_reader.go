@@ -31,6 +31,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/hash"
 )
 
@@ -107,7 +108,12 @@ func newFileReaderAt(path string) (*fileReaderAt, error) {
 		// Size returns the number of bytes for regular files and is system dependent for others (Some of which can be negative).
 		return nil, fmt.Errorf("%s has invalid size: %d", path, fi.Size())
 	}
-	return &fileReaderAt{f, path, fi.Size()}, nil
+	rd, sz, err := openPlaintext(f, fi.Size())
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	return &fileReaderAt{f, rd, path, sz}, nil
 }
 
 func nomsFileTableReader(ctx context.Context, path string, h hash.Hash, chunkCount uint32, q MemoryQuotaProvider) (cs chunkSource, err error) {
@@ -118,7 +124,7 @@ func nomsFileTableReader(ctx context.Context, path string, h hash.Hash, chunkCou
 
 	idxSz := int64(indexSize(chunkCount) + footerSize)
 	indexOffset := fra.sz - idxSz
-	r := io.NewSectionReader(fra.f, indexOffset, idxSz)
+	r := io.NewSectionReader(fra.rd, indexOffset, idxSz)
 	if int64(int(idxSz)) != idxSz {
 		err = fmt.Errorf("table file %s is too large to read on this platform. index size %d > max int.", path, idxSz)
 		return
@@ -163,6 +169,37 @@ func nomsFileTableReader(ctx context.Context, path string, h hash.Hash, chunkCou
 	}, nil
 }
 
+// IterateTableFileChunks calls |cb| with each of the chunks in the table file at |path|, stopping at the first error
+// it returns.
+func IterateTableFileChunks(ctx context.Context, path string, cb func(chunks.Chunk) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	chunkCount, _, err := ReadTableFooter(f)
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	cs, err := nomsFileTableReader(ctx, path, hash.Hash{}, chunkCount, NewUnlimitedMemQuotaProvider())
+	if err != nil {
+		return err
+	}
+	defer cs.close()
+
+	var cbErr error
+	err = cs.iterateAllChunks(ctx, func(c chunks.Chunk) {
+		if cbErr == nil {
+			cbErr = cb(c)
+		}
+	}, NewStats())
+	if err != nil {
+		return err
+	}
+	return cbErr
+}
+
 func (ftr *fileTableReader) hash() hash.Hash {
 	return ftr.h
 }
@@ -184,21 +221,15 @@ func (ftr *fileTableReader) clone() (chunkSource, error) {
 }
 
 type fileReaderAt struct {
-	f    *os.File
+	f *os.File
+	// rd reads the plaintext of |f|, which is |f| itself unless it's encrypted at rest
+	rd   io.ReaderAt
 	path string
 	sz   int64
 }
 
 func (fra *fileReaderAt) clone() (tableReaderAt, error) {
-	f, err := os.Open(fra.path)
-	if err != nil {
-		return nil, err
-	}
-	return &fileReaderAt{
-		f,
-		fra.path,
-		fra.sz,
-	}, nil
+	return newFileReaderAt(fra.path)
 }
 
 func (fra *fileReaderAt) Close() error {
@@ -206,7 +237,14 @@ func (fra *fileReaderAt) Close() error {
 }
 
 func (fra *fileReaderAt) Reader(ctx context.Context) (io.ReadCloser, error) {
-	return os.Open(fra.path)
+	if fra.rd == io.ReaderAt(fra.f) {
+		return os.Open(fra.path)
+	}
+	r, err := newFileReaderAt(fra.path)
+	if err != nil {
+		return nil, err
+	}
+	return readCloser{io.NewSectionReader(r.rd, 0, r.sz), r}, nil
 }
 
 func (fra *fileReaderAt) ReadAtWithStats(ctx context.Context, p []byte, off int64, stats *Stats) (n int, err error) {
@@ -215,5 +253,5 @@ func (fra *fileReaderAt) ReadAtWithStats(ctx context.Context, p []byte, off int6
 		stats.FileBytesPerRead.Sample(uint64(len(p)))
 		stats.FileReadLatency.SampleTimeSince(t1)
 	}()
-	return fra.f.ReadAt(p, off)
+	return fra.rd.ReadAt(p, off)
 }
@@ -22,6 +22,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/hash"
@@ -38,6 +39,14 @@ type GenerationalNBS struct {
 	oldGen   *NomsBlockStore
 	newGen   *NomsBlockStore
 	ghostGen *GhostBlockStore
+
+	// coldGen, when it isn't nil, holds the history behind the commits which are more than |coldAfter| old. A full
+	// GC decides which chunks go in it.
+	coldGen   *NomsBlockStore
+	coldAfter time.Duration
+	// coldGCSpecs are the table files of the cold generation when the running full GC began. It's guarded by
+	// coldGen.mu.
+	coldGCSpecs hash.HashSet
 }
 
 var ErrGhostChunkRequested = errors.New("requested chunk which is expected to be a ghost chunk")
@@ -54,14 +63,23 @@ func (gcs *GenerationalNBS) GhostGen() chunks.ChunkStore {
 }
 
 func NewGenerationalCS(oldGen, newGen *NomsBlockStore, ghostGen *GhostBlockStore) *GenerationalNBS {
+	return NewTieredGenerationalCS(oldGen, newGen, nil, ghostGen, 0)
+}
+
+// NewTieredGenerationalCS returns a GenerationalNBS with a cold generation, |coldGen|, which a full GC moves the
+// history behind the commits which are |coldAfter| old to. Reads fall through to the cold generation after the old and
+// new ones.
+func NewTieredGenerationalCS(oldGen, newGen, coldGen *NomsBlockStore, ghostGen *GhostBlockStore, coldAfter time.Duration) *GenerationalNBS {
 	if oldGen.Version() != "" && oldGen.Version() != newGen.Version() {
 		panic("oldgen and newgen chunkstore versions vary")
 	}
 
 	return &GenerationalNBS{
-		oldGen:   oldGen,
-		newGen:   newGen,
-		ghostGen: ghostGen,
+		oldGen:    oldGen,
+		newGen:    newGen,
+		ghostGen:  ghostGen,
+		coldGen:   coldGen,
+		coldAfter: coldAfter,
 	}
 }
 
@@ -88,6 +106,13 @@ func (gcs *GenerationalNBS) Get(ctx context.Context, h hash.Hash) (chunks.Chunk,
 		return chunks.EmptyChunk, err
 	}
 
+	if c.IsEmpty() && gcs.coldGen != nil {
+		c, err = gcs.coldGen.Get(ctx, h)
+		if err != nil {
+			return chunks.EmptyChunk, err
+		}
+	}
+
 	if c.IsEmpty() && gcs.ghostGen != nil {
 		c, err = gcs.ghostGen.Get(ctx, h)
 		if err != nil {
@@ -137,6 +162,26 @@ func (gcs *GenerationalNBS) GetMany(ctx context.Context, hashes hash.HashSet, fo
 		return nil
 	}
 
+	if gcs.coldGen != nil {
+		hashes = notFound
+		notFound = hashes.Copy()
+		err = gcs.coldGen.GetMany(ctx, hashes, func(ctx context.Context, chunk *chunks.Chunk) {
+			func() {
+				mu.Lock()
+				defer mu.Unlock()
+				delete(notFound, chunk.Hash())
+			}()
+
+			found(ctx, chunk)
+		})
+		if err != nil {
+			return err
+		}
+		if len(notFound) == 0 {
+			return nil
+		}
+	}
+
 	// Last ditch effort to see if the requested objects are commits we've decided to ignore. Note the function spec
 	// considers non-present chunks to be silently ignored, so we don't need to return an error here
 	if gcs.ghostGen == nil {
@@ -179,6 +224,23 @@ func (gcs *GenerationalNBS) getManyCompressed(ctx context.Context, hashes hash.H
 		return nil
 	}
 
+	if gcs.coldGen != nil {
+		notInNewGen := notFound
+		notFound = notInNewGen.Copy()
+		err = gcs.coldGen.getManyCompressed(ctx, notInNewGen, func(ctx context.Context, chunk ToChunker) {
+			mu.Lock()
+			delete(notFound, chunk.Hash())
+			mu.Unlock()
+			found(ctx, chunk)
+		}, gcDepMode)
+		if err != nil {
+			return err
+		}
+		if len(notFound) == 0 {
+			return nil
+		}
+	}
+
 	// The missing chunks may be ghost chunks.
 	if gcs.ghostGen != nil {
 		return gcs.ghostGen.getManyCompressed(ctx, notFound, found, gcDepMode)
@@ -198,6 +260,13 @@ func (gcs *GenerationalNBS) Has(ctx context.Context, h hash.Hash) (bool, error)
 		return has, err
 	}
 
+	if gcs.coldGen != nil {
+		has, err = gcs.coldGen.Has(ctx, h)
+		if err != nil || has {
+			return has, err
+		}
+	}
+
 	// Possibly a truncated commit.
 	if gcs.ghostGen != nil {
 		has, err = gcs.ghostGen.Has(ctx, h)
@@ -222,10 +291,23 @@ func (gcs *GenerationalNBS) HasMany(ctx context.Context, hashes hash.HashSet) (h
 	if err != nil {
 		return nil, err
 	}
-	if len(absent) == 0 || gcs.ghostGen == nil {
+	if len(absent) == 0 {
 		return nil, err
 	}
 
+	if gcs.coldGen != nil {
+		absent, err = gcs.coldGen.HasMany(ctx, absent)
+		if err != nil {
+			return nil, err
+		}
+		if len(absent) == 0 {
+			return nil, err
+		}
+	}
+
+	if gcs.ghostGen == nil {
+		return absent, nil
+	}
 	return gcs.ghostGen.HasMany(ctx, absent)
 }
 
@@ -247,10 +329,27 @@ func (gcs *GenerationalNBS) refCheck(recs []hasRecord) (hash.HashSet, error) {
 	if err != nil {
 		return nil, err
 	}
-	if len(absent) == 0 || gcs.ghostGen == nil {
+	if len(absent) == 0 {
 		return absent, nil
 	}
 
+	if gcs.coldGen != nil {
+		absent, err = func() (hash.HashSet, error) {
+			gcs.coldGen.mu.RLock()
+			defer gcs.coldGen.mu.RUnlock()
+			return gcs.coldGen.refCheck(recs)
+		}()
+		if err != nil {
+			return nil, err
+		}
+		if len(absent) == 0 {
+			return absent, nil
+		}
+	}
+
+	if gcs.ghostGen == nil {
+		return absent, nil
+	}
 	return gcs.ghostGen.refCheck(recs)
 }
 
@@ -285,8 +384,14 @@ func (gcs *GenerationalNBS) Rebase(ctx context.Context) error {
 	if oErr != nil {
 		return oErr
 	}
+	if nErr != nil {
+		return nErr
+	}
 
-	return nErr
+	if gcs.coldGen != nil {
+		return gcs.coldGen.Rebase(ctx)
+	}
+	return nil
 }
 
 // Root returns the root of the database as of the time the ChunkStore
@@ -318,6 +423,10 @@ func (gcs *GenerationalNBS) StatsSummary() string {
 	sb.WriteString(gcs.newGen.StatsSummary())
 	sb.WriteString("\nOld Gen: \n\t")
 	sb.WriteString(gcs.oldGen.StatsSummary())
+	if gcs.coldGen != nil {
+		sb.WriteString("\nCold Gen: \n\t")
+		sb.WriteString(gcs.coldGen.StatsSummary())
+	}
 	return sb.String()
 }
 
@@ -329,11 +438,19 @@ func (gcs *GenerationalNBS) Close() error {
 	oErr := gcs.oldGen.Close()
 	nErr := gcs.newGen.Close()
 
+	var cErr error
+	if gcs.coldGen != nil {
+		cErr = gcs.coldGen.Close()
+	}
+
 	if oErr != nil {
 		return oErr
 	}
+	if nErr != nil {
+		return nErr
+	}
 
-	return nErr
+	return cErr
 }
 
 func (gcs *GenerationalNBS) copyToOldGen(ctx context.Context, hashes hash.HashSet) error {
@@ -359,6 +476,9 @@ func (gcs *GenerationalNBS) copyToOldGen(ctx context.Context, hashes hash.HashSe
 	return err
 }
 
+// ColdGenPrefix is the location prefix of the table files of the cold generation, which aren't on local disk.
+const ColdGenPrefix = "cold"
+
 type prefixedTableFile struct {
 	chunks.TableFile
 	prefix string
@@ -369,7 +489,7 @@ func (p prefixedTableFile) LocationPrefix() string {
 }
 
 // Sources retrieves the current root hash, a list of all the table files (which may include appendix table files),
-// and a second list containing only appendix table files for both the old gen and new gen stores.
+// and a second list containing only appendix table files for the old gen, new gen and cold gen stores.
 func (gcs *GenerationalNBS) Sources(ctx context.Context) (hash.Hash, []chunks.TableFile, []chunks.TableFile, error) {
 	root, tFiles, appFiles, err := gcs.newGen.Sources(ctx)
 	if err != nil {
@@ -390,10 +510,22 @@ func (gcs *GenerationalNBS) Sources(ctx context.Context) (hash.Hash, []chunks.Ta
 		appFiles = append(appFiles, prefixedTableFile{tf, prefix})
 	}
 
+	if gcs.coldGen != nil {
+		// cold gen table files aren't on local disk, but they can still be opened
+		_, coldTFiles, _, err := gcs.coldGen.Sources(ctx)
+		if err != nil {
+			return hash.Hash{}, nil, nil, err
+		}
+		for _, tf := range coldTFiles {
+			tFiles = append(tFiles, prefixedTableFile{tf, ColdGenPrefix})
+		}
+	}
+
 	return root, tFiles, appFiles, nil
 }
 
-// Size  returns the total size, in bytes, of the table files in the new and old gen stores combined
+// Size  returns the total size, in bytes, of the table files in the new and old gen stores combined. The cold gen
+// isn't on local disk, so it isn't counted.
 func (gcs *GenerationalNBS) Size(ctx context.Context) (uint64, error) {
 	oldSize, err := gcs.oldGen.Size(ctx)
 
@@ -415,6 +547,12 @@ func (gcs *GenerationalNBS) WriteTableFile(ctx context.Context, fileId string, n
 	return gcs.newGen.WriteTableFile(ctx, fileId, numChunks, contentHash, getRd)
 }
 
+// IterateTableFileChunks calls |cb| with each of the chunks in the table file |fileId|, which was written with
+// WriteTableFile but need not have been added to the manifest of the newgen cs yet.
+func (gcs *GenerationalNBS) IterateTableFileChunks(ctx context.Context, fileId string, numChunks int, cb func(chunks.Chunk) error) error {
+	return gcs.newGen.iterateTableFileChunks(ctx, fileId, numChunks, cb)
+}
+
 // AddTableFilesToManifest adds table files to the manifest of the newgen cs
 func (gcs *GenerationalNBS) AddTableFilesToManifest(ctx context.Context, fileIdToNumChunks map[string]int, getAddrs chunks.GetAddrsCurry) error {
 	return gcs.newGen.addTableFilesToManifest(ctx, fileIdToNumChunks, getAddrs, gcs.refCheck)
@@ -428,6 +566,13 @@ func (gcs *GenerationalNBS) PruneTableFiles(ctx context.Context) error {
 		return err
 	}
 
+	if gcs.coldGen != nil {
+		err = gcs.pruneColdTableFiles(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
 	return gcs.newGen.pruneTableFiles(ctx)
 }
 
@@ -451,6 +596,16 @@ func (gcs *GenerationalNBS) GetChunkLocationsWithPaths(ctx context.Context, hash
 			res[filepath.ToSlash(filepath.Join(prefix, k))] = v
 		}
 	}
+	if len(hashes) > 0 && gcs.coldGen != nil {
+		// cold gen table files are served with OpenColdTableFile
+		toadd, err := gcs.coldGen.GetChunkLocationsWithPaths(ctx, hashes)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range toadd {
+			res[ColdGenPrefix+"/"+k] = v
+		}
+	}
 	return res, nil
 }
 
@@ -492,7 +647,11 @@ func (gcs *GenerationalNBS) UpdateManifest(ctx context.Context, updates map[hash
 
 func (gcs *GenerationalNBS) OldGenGCFilter() chunks.HasManyFunc {
 	return func(ctx context.Context, hashes hash.HashSet) (hash.HashSet, error) {
-		return gcs.oldGen.hasManyDep(ctx, hashes, gcDependencyMode_NoDependency)
+		absent, err := gcs.oldGen.hasManyDep(ctx, hashes, gcDependencyMode_NoDependency)
+		if err != nil || len(absent) == 0 || gcs.coldGen == nil {
+			return absent, err
+		}
+		return gcs.coldGen.hasManyDep(ctx, absent, gcDependencyMode_NoDependency)
 	}
 }
 
@@ -513,10 +672,28 @@ func (gcs *GenerationalNBS) BeginGC(keeper func(hash.Hash) bool, mode chunks.GCM
 			return err
 		}
 	}
+	// A full GC collects the cold generation as well.
+	if mode == chunks.GCMode_Full && gcs.coldGen != nil {
+		err = gcs.coldGen.BeginGC(keeper, mode)
+		if err != nil {
+			gcs.oldGen.EndGC(mode)
+			gcs.newGen.EndGC(mode)
+			return err
+		}
+		gcs.coldGen.mu.Lock()
+		gcs.coldGCSpecs = make(hash.HashSet, len(gcs.coldGen.tables.upstream))
+		for name := range gcs.coldGen.tables.upstream {
+			gcs.coldGCSpecs.Insert(name)
+		}
+		gcs.coldGen.mu.Unlock()
+	}
 	return nil
 }
 
 func (gcs *GenerationalNBS) EndGC(mode chunks.GCMode) {
+	if mode == chunks.GCMode_Full && gcs.coldGen != nil {
+		gcs.coldGen.EndGC(mode)
+	}
 	if mode == chunks.GCMode_Full {
 		gcs.oldGen.EndGC(mode)
 	}
@@ -536,6 +713,9 @@ func (gcs *GenerationalNBS) IterateAllChunks(ctx context.Context, cb func(chunk
 	if err != nil {
 		return err
 	}
+	if gcs.coldGen != nil {
+		return gcs.coldGen.IterateAllChunks(ctx, cb)
+	}
 	return nil
 }
 
@@ -548,5 +728,12 @@ func (gcs *GenerationalNBS) Count() (uint32, error) {
 	if err != nil {
 		return 0, err
 	}
+	if gcs.coldGen != nil {
+		coldGenCnt, err := gcs.coldGen.Count()
+		if err != nil {
+			return 0, err
+		}
+		return newGenCnt + oldGenCnt + coldGenCnt, nil
+	}
 	return newGenCnt + oldGenCnt, nil
 }
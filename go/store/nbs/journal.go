@@ -168,6 +168,12 @@ func (j *ChunkJournal) bootstrapJournalWriter(ctx context.Context) (err error) {
 		return
 	}
 
+	if !j.backing.readOnly() {
+		if err = sealJournalFile(j.path); err != nil {
+			return err
+		}
+	}
+
 	j.wr, ok, err = openJournalWriter(ctx, j.path)
 	if err != nil {
 		return err
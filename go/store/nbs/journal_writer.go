@@ -85,6 +85,7 @@ func fileExists(path string) (bool, error) {
 
 func openJournalWriter(ctx context.Context, path string) (wr *journalWriter, exists bool, err error) {
 	var f *os.File
+	var journal journalFile
 	if path, err = filepath.Abs(path); err != nil {
 		return nil, false, err
 	}
@@ -100,14 +101,33 @@ func openJournalWriter(ctx context.Context, path string) (wr *journalWriter, exi
 	if f, err = os.OpenFile(path, os.O_RDWR, 0666); err != nil {
 		return nil, true, err
 	}
+	if journal, err = openJournalFile(f); err != nil {
+		f.Close()
+		return nil, true, err
+	}
 
 	return &journalWriter{
 		buf:     make([]byte, 0, journalWriterBuffSize),
-		journal: f,
+		journal: journal,
 		path:    path,
 	}, true, nil
 }
 
+// openJournalFile returns |f|, or the sealed journal in |f| if it's encrypted at rest.
+func openJournalFile(f *os.File) (journalFile, error) {
+	magic := make([]byte, len(sealMagic))
+	if _, err := f.ReadAt(magic, 0); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	} else if !isSealed(magic) {
+		return f, nil
+	}
+	kr, err := encryptionKeyring()
+	if err != nil {
+		return nil, err
+	}
+	return openSealedJournal(f, kr)
+}
+
 func createJournalWriter(ctx context.Context, path string) (wr *journalWriter, err error) {
 	var f *os.File
 	if path, err = filepath.Abs(path); err != nil {
@@ -127,6 +147,21 @@ func createJournalWriter(ctx context.Context, path string) (wr *journalWriter, e
 	if f, err = os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0666); err != nil {
 		return nil, err
 	}
+	kr, err := encryptionKeyring()
+	if err != nil {
+		return nil, err
+	} else if kr != nil {
+		// sealed journals are a log of slots which is only ever appended to, so there's no space to allocate
+		sj, err := createSealedJournal(f, kr)
+		if err != nil {
+			return nil, err
+		}
+		return &journalWriter{
+			buf:     make([]byte, 0, journalWriterBuffSize),
+			journal: sj,
+			path:    path,
+		}, nil
+	}
 	const batch = 1024 * 1024
 	b := make([]byte, batch)
 	for i := 0; i < chunkJournalFileSize; i += batch {
@@ -158,10 +193,19 @@ func deleteJournalAndIndexFiles(ctx context.Context, path string) (err error) {
 	return os.Remove(idxPath)
 }
 
+// journalFile is the file of a chunk journal, which is either an *os.File or a *sealedJournal.
+type journalFile interface {
+	io.ReadSeeker
+	io.ReaderAt
+	io.WriterAt
+	io.Closer
+	Sync() error
+}
+
 type journalWriter struct {
 	buf []byte
 
-	journal *os.File
+	journal journalFile
 	// off indicates the last position that has been written to the journal buffer
 	off     int64
 	indexed int64
@@ -607,12 +651,12 @@ func (wr *journalWriter) snapshot(ctx context.Context) (io.ReadCloser, int64, er
 	}
 	// open a new file descriptor with an
 	// independent lifecycle from |wr.file|
-	f, err := os.Open(wr.path)
+	f, _, err := OpenTableFile(wr.path)
 	if err != nil {
 		return nil, 0, err
 	}
 	return journalWriterSnapshot{
-		io.LimitReader(f, wr.off),
+		io.NewSectionReader(f, 0, wr.off),
 		func() error {
 			return f.Close()
 		},
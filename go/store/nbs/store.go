@@ -538,8 +538,18 @@ func NewOCISStore(ctx context.Context, nbfVerStr string, bucketName, path string
 	return NewNoConjoinBSStore(ctx, nbfVerStr, bs, memTableSize, q)
 }
 
-// NewBSStore returns an nbs implementation backed by a Blobstore
+// NewBSStore returns an nbs implementation backed by a Blobstore. Its table files are sealed when encryption at rest
+// is configured.
 func NewBSStore(ctx context.Context, nbfVerStr string, bs blobstore.Blobstore, memTableSize uint64, q MemoryQuotaProvider) (*NomsBlockStore, error) {
+	bs, err := wrapBlobstore(bs)
+	if err != nil {
+		return nil, err
+	}
+	return NewUnsealedBSStore(ctx, nbfVerStr, bs, memTableSize, q)
+}
+
+// NewUnsealedBSStore returns an nbs implementation backed by a Blobstore, whose table files are written in plaintext.
+func NewUnsealedBSStore(ctx context.Context, nbfVerStr string, bs blobstore.Blobstore, memTableSize uint64, q MemoryQuotaProvider) (*NomsBlockStore, error) {
 	cacheOnce.Do(makeGlobalCaches)
 
 	mm := makeManifestManager(blobstoreManifest{bs})
@@ -548,8 +558,19 @@ func NewBSStore(ctx context.Context, nbfVerStr string, bs blobstore.Blobstore, m
 	return newNomsBlockStore(ctx, nbfVerStr, mm, p, q, inlineConjoiner{defaultMaxTables}, memTableSize)
 }
 
-// NewNoConjoinBSStore returns a nbs implementation backed by a Blobstore
+// NewNoConjoinBSStore returns a nbs implementation backed by a Blobstore. Its table files are sealed when encryption
+// at rest is configured.
 func NewNoConjoinBSStore(ctx context.Context, nbfVerStr string, bs blobstore.Blobstore, memTableSize uint64, q MemoryQuotaProvider) (*NomsBlockStore, error) {
+	bs, err := wrapBlobstore(bs)
+	if err != nil {
+		return nil, err
+	}
+	return NewUnsealedNoConjoinBSStore(ctx, nbfVerStr, bs, memTableSize, q)
+}
+
+// NewUnsealedNoConjoinBSStore returns a nbs implementation backed by a Blobstore, whose table files are written in
+// plaintext.
+func NewUnsealedNoConjoinBSStore(ctx context.Context, nbfVerStr string, bs blobstore.Blobstore, memTableSize uint64, q MemoryQuotaProvider) (*NomsBlockStore, error) {
 	cacheOnce.Do(makeGlobalCaches)
 
 	mm := makeManifestManager(blobstoreManifest{bs})
@@ -562,7 +583,17 @@ func NewLocalStore(ctx context.Context, nbfVerStr string, dir string, memTableSi
 	return newLocalStore(ctx, nbfVerStr, dir, memTableSize, defaultMaxTables, q)
 }
 
+// NewUnsealedLocalStore returns a local store whose table files are written in plaintext, even when encryption at
+// rest is configured. Table files which are already sealed are still read.
+func NewUnsealedLocalStore(ctx context.Context, nbfVerStr string, dir string, memTableSize uint64, q MemoryQuotaProvider) (*NomsBlockStore, error) {
+	return openLocalStore(ctx, nbfVerStr, dir, memTableSize, defaultMaxTables, q, newUnsealedFSTablePersister(dir, q))
+}
+
 func newLocalStore(ctx context.Context, nbfVerStr string, dir string, memTableSize uint64, maxTables int, q MemoryQuotaProvider) (*NomsBlockStore, error) {
+	return openLocalStore(ctx, nbfVerStr, dir, memTableSize, maxTables, q, newFSTablePersister(dir, q))
+}
+
+func openLocalStore(ctx context.Context, nbfVerStr string, dir string, memTableSize uint64, maxTables int, q MemoryQuotaProvider, p tablePersister) (*NomsBlockStore, error) {
 	cacheOnce.Do(makeGlobalCaches)
 	if err := checkDir(dir); err != nil {
 		return nil, err
@@ -578,7 +609,6 @@ func newLocalStore(ctx context.Context, nbfVerStr string, dir string, memTableSi
 	if err != nil {
 		return nil, err
 	}
-	p := newFSTablePersister(dir, q)
 	c := conjoinStrategy(inlineConjoiner{maxTables})
 
 	return newNomsBlockStore(ctx, nbfVerStr, makeManifestManager(m), p, q, c, memTableSize)
@@ -1645,6 +1675,33 @@ func (nbs *NomsBlockStore) AddTableFilesToManifest(ctx context.Context, fileIdTo
 	return nbs.addTableFilesToManifest(ctx, fileIdToNumChunks, getAddrs, nbs.refCheck)
 }
 
+// iterateTableFileChunks calls |cb| with each of the chunks in the table file |fileId|, which was written to the store
+// with WriteTableFile but need not have been added to the manifest, stopping at the first error it returns.
+func (nbs *NomsBlockStore) iterateTableFileChunks(ctx context.Context, fileId string, numChunks int, cb func(chunks.Chunk) error) error {
+	h, ok := hash.MaybeParse(fileId)
+	if !ok {
+		return errors.New("invalid base32 encoded hash: " + fileId)
+	}
+	sources, _, err := nbs.openChunkSourcesForAddTableFiles(ctx, map[hash.Hash]uint32{h: uint32(numChunks)})
+	if err != nil {
+		return err
+	}
+	defer sources.close()
+
+	var cbErr error
+	for _, source := range sources {
+		err = source.iterateAllChunks(ctx, func(c chunks.Chunk) {
+			if cbErr == nil {
+				cbErr = cb(c)
+			}
+		}, nbs.stats)
+		if err != nil {
+			return err
+		}
+	}
+	return cbErr
+}
+
 // A small helper which returns a composite refCheck function combining |base| and a |hasMany| call against each source in |css|.
 func getRefCheck(nbs *NomsBlockStore, base func([]hasRecord) (hash.HashSet, error), css chunkSourceSet) func([]hasRecord) (hash.HashSet, error) {
 	if base == nil {
@@ -2093,6 +2150,16 @@ func (nbs *NomsBlockStore) IterateAllChunks(ctx context.Context, cb func(chunk c
 }
 
 func (nbs *NomsBlockStore) swapTables(ctx context.Context, specs []tableSpec, mode chunks.GCMode) (err error) {
+	var extra []byte
+	if mode == chunks.GCMode_Full {
+		extra = []byte("full")
+	}
+	return nbs.swapTablesWithGCGenExtra(ctx, specs, extra)
+}
+
+// swapTablesWithGCGenExtra replaces the table files of the store with |specs|. |extra| is mixed into the new gc
+// generation, so that swaps which leave the same specs behind still update it.
+func (nbs *NomsBlockStore) swapTablesWithGCGenExtra(ctx context.Context, specs []tableSpec, extra []byte) (err error) {
 	nbs.mu.Lock()
 	defer nbs.mu.Unlock()
 
@@ -2105,10 +2172,6 @@ func (nbs *NomsBlockStore) swapTables(ctx context.Context, specs []tableSpec, mo
 	}()
 
 	newLock := generateLockHash(nbs.upstream.root, specs, []tableSpec{}, nil)
-	var extra []byte
-	if mode == chunks.GCMode_Full {
-		extra = []byte("full")
-	}
 	newGCGen := generateLockHash(nbs.upstream.root, specs, []tableSpec{}, extra)
 
 	newContents := manifestContents{
@@ -2172,6 +2235,76 @@ func (nbs *NomsBlockStore) swapTables(ctx context.Context, specs []tableSpec, mo
 	return nil
 }
 
+// replaceTableFiles swaps each of the table files of the store which is a key of |replacements| for the file it maps
+// to, which must hold the same chunks. Like a conjoin, the swap waits for any GC in progress and is otherwise safe
+// while the store is in use. Returns the table files which were replaced; any no longer in the store are skipped.
+func (nbs *NomsBlockStore) replaceTableFiles(ctx context.Context, replacements map[hash.Hash]hash.Hash) (replaced map[hash.Hash]hash.Hash, err error) {
+	nbs.mu.Lock()
+	defer nbs.mu.Unlock()
+	if err = nbs.waitForGC(ctx); err != nil {
+		return nil, err
+	}
+
+	nbs.mm.LockForUpdate()
+	defer func() {
+		unlockErr := nbs.mm.UnlockForUpdate()
+		if err == nil {
+			err = unlockErr
+		}
+	}()
+
+	// Pick up any changes made by other processes before deciding what to replace.
+	if err = nbs.rebase(ctx); err != nil {
+		return nil, err
+	}
+
+	// Appendix table files are left alone, as they are by conjoin.
+	appendix := make(map[hash.Hash]struct{}, len(nbs.upstream.appendix))
+	for _, spec := range nbs.upstream.appendix {
+		appendix[spec.name] = struct{}{}
+	}
+	replaced = make(map[hash.Hash]hash.Hash)
+	specs := make([]tableSpec, 0, len(nbs.upstream.specs))
+	for _, spec := range nbs.upstream.specs {
+		if _, ok := appendix[spec.name]; ok {
+			specs = append(specs, spec)
+			continue
+		}
+		if r, ok := replacements[spec.name]; ok {
+			replaced[spec.name] = r
+			spec = tableSpec{r, spec.chunkCount}
+		}
+		specs = append(specs, spec)
+	}
+	if len(replaced) == 0 {
+		return replaced, nil
+	}
+
+	newContents := manifestContents{
+		nbfVers:  nbs.upstream.nbfVers,
+		root:     nbs.upstream.root,
+		lock:     generateLockHash(nbs.upstream.root, specs, nbs.upstream.appendix, nil),
+		gcGen:    nbs.upstream.gcGen,
+		specs:    specs,
+		appendix: nbs.upstream.appendix,
+	}
+	upstream, err := nbs.mm.Update(ctx, nbs.upstream.lock, newContents, nbs.stats, nil)
+	if err != nil {
+		return nil, err
+	}
+	if upstream.lock != newContents.lock {
+		return nil, errors.New("concurrent manifest edit while replacing table files")
+	}
+
+	newTables, err := nbs.tables.rebase(ctx, upstream.specs, nil, nbs.stats)
+	if err != nil {
+		return nil, err
+	}
+	oldTables := nbs.tables
+	nbs.tables, nbs.upstream = newTables, upstream
+	return replaced, oldTables.close()
+}
+
 // CalcReads computes the number of IO operations necessary to fetch |hashes|.
 func CalcReads(nbs *NomsBlockStore, hashes hash.HashSet, blockSize uint64, keeper keeperF) (int, bool, gcBehavior, error) {
 	reqs := toGetRecords(hashes)
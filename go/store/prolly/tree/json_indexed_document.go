@@ -154,6 +154,47 @@ func (i IndexedJsonDocument) tryLookup(ctx context.Context, pathString string) (
 	return i.lookupByLocation(ctx, path)
 }
 
+// LookupWithKey returns the value at the location encoded by |key|, or nil if the document has no value there.
+func (i IndexedJsonDocument) LookupWithKey(ctx context.Context, key []byte) (sql.JSONWrapper, error) {
+	return i.lookupByLocation(ctx, jsonPathFromKey(key))
+}
+
+// ArrayElementsWithKey returns the serialized elements of the array at the location encoded by |key|, in order, or false
+// if the document has no array there. A nil |key| is the location of the whole document. Only the chunks of the document
+// which hold the array are read.
+func (i IndexedJsonDocument) ArrayElementsWithKey(ctx context.Context, key []byte) ([][]byte, bool, error) {
+	location := newRootLocation()
+	if key != nil {
+		location = jsonPathFromKey(key)
+		location.setScannerState(startOfValue)
+	}
+	jCur, found, err := newJsonCursor(ctx, i.m.NodeStore, i.m.Root, location, false)
+	if err != nil || !found {
+		return nil, false, err
+	}
+	if c, err := jCur.nextCharacter(ctx); err != nil || c != '[' {
+		return nil, false, err
+	}
+
+	var elems [][]byte
+	for idx := uint64(0); ; idx++ {
+		elemLocation := location.Clone()
+		elemLocation.appendArrayIndex(idx)
+		found, err = jCur.AdvanceToLocation(ctx, elemLocation, false)
+		if err != nil {
+			return nil, false, err
+		}
+		if !found {
+			return elems, true, nil
+		}
+		elem, err := jCur.NextValue(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		elems = append(elems, elem)
+	}
+}
+
 func (i IndexedJsonDocument) lookupByLocation(ctx context.Context, path jsonLocation) (sql.JSONWrapper, error) {
 	jCur, found, err := newJsonCursor(ctx, i.m.NodeStore, i.m.Root, path, false)
 	if err != nil {
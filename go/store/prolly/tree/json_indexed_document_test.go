@@ -292,6 +292,56 @@ func TestIndexedJsonDocument_Value(t *testing.T) {
 	jsontests.RunJsonValueTests(t, convertToIndexedJsonDocument)
 }
 
+func TestIndexedJsonDocument_ArrayElementsWithKey(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	ns := NewTestNodeStore()
+	largeDoc := createLargeDocumentForTesting(t, ctx, ns)
+
+	requireElements := func(t *testing.T, doc IndexedJsonDocument, path string) {
+		var key []byte
+		if path != "$" {
+			key = jsonLocationFromMySqlPath(t, path, startOfValue).key
+		}
+		elems, ok, err := doc.ArrayElementsWithKey(ctx, key)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		arr, err := doc.Lookup(ctx, path)
+		require.NoError(t, err)
+		expected, err := arr.ToInterface()
+		require.NoError(t, err)
+		require.Len(t, elems, len(expected.(types.JsonArray)))
+		for i, elem := range elems {
+			cmp, err := types.CompareJSON(types.JSONDocument{Val: expected.(types.JsonArray)[i]}, types.NewLazyJSONDocument(elem))
+			require.NoError(t, err)
+			require.Zero(t, cmp, "element %d of %s", i, path)
+		}
+	}
+
+	t.Run("elements span chunks", func(t *testing.T) {
+		requireElements(t, largeDoc, "$")
+		requireElements(t, largeDoc, "$[8].children")
+		requireElements(t, largeDoc, "$[8].children[7].children")
+	})
+	t.Run("not an array", func(t *testing.T) {
+		_, ok, err := largeDoc.ArrayElementsWithKey(ctx, jsonLocationFromMySqlPath(t, "$[8]", startOfValue).key)
+		require.NoError(t, err)
+		require.False(t, ok)
+		_, ok, err = largeDoc.ArrayElementsWithKey(ctx, jsonLocationFromMySqlPath(t, "$[100]", startOfValue).key)
+		require.NoError(t, err)
+		require.False(t, ok)
+		_, ok, err = newIndexedJsonDocumentFromValue(t, ctx, ns, `{"a": [1]}`).ArrayElementsWithKey(ctx, nil)
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+	t.Run("empty array", func(t *testing.T) {
+		elems, ok, err := newIndexedJsonDocumentFromValue(t, ctx, ns, `{"a": []}`).ArrayElementsWithKey(ctx, jsonLocationFromMySqlPath(t, "$.a", startOfValue).key)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Empty(t, elems)
+	})
+}
+
 func TestIndexedJsonDocument_ContainsPath(t *testing.T) {
 	ctx := context.Background()
 	ns := NewTestNodeStore()
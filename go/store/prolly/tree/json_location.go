@@ -233,6 +233,28 @@ func JsonKeysModifySameArray(leftKey, rightKey []byte) bool {
 	return false
 }
 
+// JsonKeysSharedArray returns the key of the outermost array containing the locations of both |leftKey| and
+// |rightKey|, if both locations are inside the same array.
+func JsonKeysSharedArray(leftKey, rightKey []byte) ([]byte, bool) {
+	if len(leftKey) == 0 || len(rightKey) == 0 || leftKey[0] != rightKey[0] {
+		return nil, false
+	}
+	left, right := jsonPathFromKey(leftKey), jsonPathFromKey(rightKey)
+	for i := 0; i+1 < len(left.offsets) && i+1 < len(right.offsets); i++ {
+		start := left.offsets[i]
+		if start != right.offsets[i] {
+			return nil, false
+		}
+		if leftKey[start] == beginArrayKey && rightKey[start] == beginArrayKey {
+			return leftKey[:start], true
+		}
+		if !bytes.Equal(leftKey[start:left.offsets[i+1]], rightKey[start:right.offsets[i+1]]) {
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
 func jsonPathElementsFromMySQLJsonPath(pathBytes []byte) (jsonLocation, error) {
 	location := newRootLocation()
 	state := lexStatePath
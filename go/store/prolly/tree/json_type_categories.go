@@ -76,3 +76,11 @@ func IsJsonObject(json sql.JSONWrapper) (bool, error) {
 	}
 	return valType == jsonTypeObject, nil
 }
+
+func IsJsonArray(json sql.JSONWrapper) (bool, error) {
+	valType, err := GetTypeCategory(json)
+	if err != nil {
+		return false, err
+	}
+	return valType == jsonTypeArray, nil
+}
@@ -26,17 +26,43 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
+	"github.com/dolthub/dolt/go/gen/fb/serial"
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/d"
 	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/util/sizecache"
 )
 
+// excludeHashes returns a HasManyFunc which returns the members of |hs| which aren't in |excluded|.
+func excludeHashes(excluded hash.HashSet) chunks.HasManyFunc {
+	return func(_ context.Context, hs hash.HashSet) (hash.HashSet, error) {
+		ret := make(hash.HashSet, len(hs))
+		for h := range hs {
+			if !excluded.Has(h) {
+				ret.Insert(h)
+			}
+		}
+		return ret, nil
+	}
+}
+
 func unfilteredHashFunc(_ context.Context, hs hash.HashSet) (hash.HashSet, error) {
 	return hs, nil
 }
 
+// chainHasManyFuncs returns a HasManyFunc which returns the members of |hs| which are absent from both |a| and |b|.
+func chainHasManyFuncs(a, b chunks.HasManyFunc) chunks.HasManyFunc {
+	return func(ctx context.Context, hs hash.HashSet) (hash.HashSet, error) {
+		absent, err := a(ctx, hs)
+		if err != nil || len(absent) == 0 {
+			return absent, err
+		}
+		return b(ctx, absent)
+	}
+}
+
 // ValueReader is an interface that knows how to read Noms Values, e.g.
 // datas/Database. Required to avoid import cycle between this package and the
 // package that implements Value reading.
@@ -574,6 +600,14 @@ func (lvs *ValueStore) GC(ctx context.Context, mode GCMode, oldGenRefs, newGenRe
 			return fmt.Errorf("unsupported GCMode %v", mode)
 		}
 
+		// A full GC of a store with a cold generation decides which chunks belong in it, and collects it as well.
+		var coldGen chunks.ChunkStoreGarbageCollector
+		var coldCutoff time.Time
+		if tcs, ok := lvs.cs.(chunks.ColdTieredCS); ok && mode == GCModeFull {
+			coldGen = tcs.ColdGen()
+			coldCutoff = tcs.ColdCutoff()
+		}
+
 		err := func() error {
 			err := collector.BeginGC(lvs.gcAddChunk, chksMode)
 			if err != nil {
@@ -604,20 +638,52 @@ func (lvs *ValueStore) GC(ctx context.Context, mode GCMode, oldGenRefs, newGenRe
 			}
 			newGenRefs.Insert(root)
 
-			var oldGenFinalizer, newGenFinalizer chunks.GCFinalizer
-			oldGenFinalizer, err = lvs.gc(ctx, oldGenRefs, oldGenHasMany, chksMode, collector, oldGen, nil, func() hash.HashSet {
+			toNewGen := func() hash.HashSet {
 				n := lvs.transitionToNewGenGC()
 				newGenRefs.InsertAll(n)
 				return make(hash.HashSet)
-			})
-			if err != nil {
-				return err
 			}
 
+			var oldGenFinalizer, coldGenFinalizer, newGenFinalizer chunks.GCFinalizer
 			var newFileHasMany chunks.HasManyFunc
-			newFileHasMany, err = oldGenFinalizer.AddChunksToStore(ctx)
-			if err != nil {
-				return err
+			if coldGen != nil {
+				// The old generation gets the chunks of the history since the cutoff. The cold generation gets
+				// the rest of the history, behind the first commits older than the cutoff.
+				var coldRefs hash.HashSet
+				coldRefs, err = lvs.coldHistory(ctx, oldGenRefs, coldCutoff)
+				if err != nil {
+					return err
+				}
+				oldGenFinalizer, err = lvs.gc(ctx, oldGenRefs, excludeHashes(coldRefs), chksMode, collector, oldGen, nil, func() hash.HashSet {
+					return make(hash.HashSet)
+				})
+				if err != nil {
+					return err
+				}
+				var oldFileHasMany, coldFileHasMany chunks.HasManyFunc
+				oldFileHasMany, err = oldGenFinalizer.AddChunksToStore(ctx)
+				if err != nil {
+					return err
+				}
+
+				coldGenFinalizer, err = lvs.gc(ctx, coldRefs, oldFileHasMany, chksMode, collector, coldGen, nil, toNewGen)
+				if err != nil {
+					return err
+				}
+				coldFileHasMany, err = coldGenFinalizer.AddChunksToStore(ctx)
+				if err != nil {
+					return err
+				}
+				newFileHasMany = chainHasManyFuncs(oldFileHasMany, coldFileHasMany)
+			} else {
+				oldGenFinalizer, err = lvs.gc(ctx, oldGenRefs, oldGenHasMany, chksMode, collector, oldGen, nil, toNewGen)
+				if err != nil {
+					return err
+				}
+				newFileHasMany, err = oldGenFinalizer.AddChunksToStore(ctx)
+				if err != nil {
+					return err
+				}
 			}
 
 			if mode == GCModeDefault {
@@ -644,6 +710,20 @@ func (lvs *ValueStore) GC(ctx context.Context, mode GCMode, oldGenRefs, newGenRe
 				}
 			}
 
+			// Chunks which moved between the old and cold generations are in the new files of both by now.
+			if coldGenFinalizer != nil {
+				err = coldGenFinalizer.SwapChunksInStore(ctx)
+				if err != nil {
+					return err
+				}
+				// The cold generation is written with the current encryption key, unless its files
+				// were left as they were.
+				err = lvs.cs.(chunks.ColdTieredCS).ResealColdTableFiles(ctx)
+				if err != nil {
+					return err
+				}
+			}
+
 			return nil
 		}()
 
@@ -774,6 +854,61 @@ func (lvs *ValueStore) gc(ctx context.Context,
 	return finalizer, sweeper.Close(ctx)
 }
 
+// coldHistory returns the commits which are the first ones older than |cutoff| on each path back through the history
+// of the commits |refs|. They, and everything they reference which the history since |cutoff| doesn't, belong in the
+// cold generation of a store.
+func (lvs *ValueStore) coldHistory(ctx context.Context, refs hash.HashSet, cutoff time.Time) (hash.HashSet, error) {
+	cold := make(hash.HashSet)
+	if !lvs.nbf.UsesFlatbuffers() {
+		return cold, nil
+	}
+
+	visited := make(hash.HashSet)
+	toVisit := refs.Copy()
+	for len(toVisit) > 0 {
+		visited.InsertAll(toVisit)
+		next := make(hash.HashSet)
+		var mu sync.Mutex
+		var cbErr error
+		err := lvs.cs.GetMany(ctx, toVisit, func(ctx context.Context, c *chunks.Chunk) {
+			// Only commits are followed. Anything else a ref points to is recent.
+			if serial.GetFileID(c.Data()) != serial.CommitFileID {
+				return
+			}
+			var msg serial.Commit
+			err := serial.InitCommitRoot(&msg, c.Data(), serial.MessagePrefixSz)
+			if err == nil && time.UnixMilli(int64(msg.TimestampMillis())).Before(cutoff) {
+				mu.Lock()
+				defer mu.Unlock()
+				cold.Insert(c.Hash())
+				return
+			}
+			var parents []hash.Hash
+			if err == nil {
+				parents, err = SerialCommitParentAddrs(lvs.nbf, SerialMessage(c.Data()))
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				cbErr = err
+				return
+			}
+			for _, p := range parents {
+				if !visited.Has(p) {
+					next.Insert(p)
+				}
+			}
+		})
+		if err != nil {
+			return nil, err
+		} else if cbErr != nil {
+			return nil, cbErr
+		}
+		toVisit = next
+	}
+	return cold, nil
+}
+
 func (lvs *ValueStore) PurgeCaches() {
 	lvs.decodedChunks.Purge()
 }
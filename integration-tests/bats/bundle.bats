@@ -0,0 +1,126 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    TMPDIRS=$(pwd)/tmpdirs
+    mkdir -p $TMPDIRS/repo1
+
+    cd $TMPDIRS/repo1
+    dolt init
+    dolt sql -q "create table t1 (pk int primary key, c1 varchar(20))"
+    dolt sql -q "insert into t1 values (1, 'one')"
+    dolt commit -Am "cm1"
+    dolt tag v1
+    dolt branch feature
+    cd $TMPDIRS
+}
+
+teardown() {
+    teardown_common
+    rm -rf $TMPDIRS
+    cd $BATS_TMPDIR
+}
+
+@test "bundle: clone from a bundle of branches and tags" {
+    cd repo1
+    run dolt bundle create ../all.bundle main feature v1
+    [ "$status" -eq 0 ]
+    [ -f ../all.bundle ]
+
+    cd ..
+    run dolt clone file://./all.bundle repo2
+    [ "$status" -eq 0 ]
+
+    cd repo2
+    run dolt branch -a
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "remotes/origin/main" ]] || false
+    [[ "$output" =~ "remotes/origin/feature" ]] || false
+
+    run dolt tag
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "v1" ]] || false
+
+    run dolt sql -q "select c1 from t1 where pk = 1" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "one" ]] || false
+}
+
+@test "bundle: fetch from a bundle created since a commit" {
+    cd repo1
+    dolt bundle create ../base.bundle main
+    dolt sql -q "insert into t1 values (2, 'two')"
+    dolt commit -am "cm2"
+    run dolt bundle create --since v1 ../inc.bundle main
+    [ "$status" -eq 0 ]
+
+    # the chunks reachable from v1 are left out
+    dolt bundle create ../full.bundle main
+    [ "$(wc -c < ../inc.bundle)" -lt "$(wc -c < ../full.bundle)" ]
+
+    cd ..
+    dolt clone file://./base.bundle repo2
+    cd repo2
+    dolt remote add inc file://../inc.bundle
+    run dolt fetch inc
+    [ "$status" -eq 0 ]
+
+    run dolt merge inc/main
+    [ "$status" -eq 0 ]
+    run dolt sql -q "select count(*) from t1" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+
+    run dolt log --oneline
+    [[ "$output" =~ "cm2" ]] || false
+}
+
+@test "bundle: unbundle reads commits without changing refs" {
+    cd repo1
+    dolt bundle create ../base.bundle main
+    dolt sql -q "insert into t1 values (2, 'two')"
+    dolt commit -am "cm2"
+    dolt bundle create --since v1 ../inc.bundle main
+    head=$(dolt sql -q "select hashof('main')" -r csv | tail -n 1)
+
+    cd ..
+    dolt clone file://./base.bundle repo2
+    cd repo2
+    run dolt bundle unbundle ../inc.bundle
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "$head refs/heads/main" ]] || false
+
+    run dolt log --oneline
+    [[ ! "$output" =~ "cm2" ]] || false
+
+    run dolt log --oneline "$head"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "cm2" ]] || false
+}
+
+@test "bundle: unbundle fails without the prerequisite commit" {
+    cd repo1
+    dolt sql -q "insert into t1 values (2, 'two')"
+    dolt commit -am "cm2"
+    dolt bundle create --since v1 ../inc.bundle main
+
+    mkdir ../repo2 && cd ../repo2
+    dolt init
+    run dolt bundle unbundle ../inc.bundle
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "lacks the prerequisite commit" ]] || false
+}
+
+@test "bundle: create fails for unknown refs and non-bundle files" {
+    cd repo1
+    run dolt bundle create ../all.bundle main nosuchbranch
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "'nosuchbranch' is not a branch or tag" ]] || false
+    [ ! -f ../all.bundle ]
+
+    echo "not a bundle" > ../file.txt
+    run dolt bundle unbundle ../file.txt
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "is not a bundle" ]] || false
+}
@@ -38,6 +38,11 @@ get_commit_hash() {
     dolt sql -q "select * from dolt_ci_workflow_steps;"
     dolt sql -q "select * from dolt_ci_workflow_saved_query_steps;"
     dolt sql -q "select * from dolt_ci_workflow_saved_query_step_expected_row_column_results;"
+    dolt sql -q "select * from dolt_ci_workflow_sql_assertion_steps;"
+    dolt sql -q "select * from dolt_ci_workflow_verify_constraints_steps;"
+    dolt sql -q "select * from dolt_ci_workflow_schema_unchanged_steps;"
+    dolt sql -q "select * from dolt_ci_workflow_diff_budget_steps;"
+    dolt sql -q "select * from dolt_ci_workflow_runs;"
 }
 
 @test "ci: destroy should destroy dolt ci workflow tables" {
@@ -373,3 +378,164 @@ EOF
     [ "$status" -eq 0 ]
     [[ "$output" =~ "workflow_2" ]] || false
 }
+
+@test "ci: run executes saved query steps and reports failures" {
+    skip_remote_engine
+    dolt sql -q "create table t1(pk int primary key);"
+    dolt sql -q "insert into t1 values (1), (2);"
+    dolt sql -q "select * from t1;" -s "select t1"
+    dolt add .
+    dolt commit -m "add t1"
+    cat > workflow.yaml <<EOF
+name: workflow
+on:
+  push:
+    branches:
+      - main
+jobs:
+  - name: check t1
+    steps:
+      - name: t1 has two rows
+        saved_query_name: select t1
+        expected_rows: "== 2"
+      - name: t1 has one column
+        saved_query_name: select t1
+        expected_columns: "1"
+EOF
+    dolt ci init
+    dolt ci import ./workflow.yaml
+    run dolt ci run workflow
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "PASS t1 has two rows" ]] || false
+    [[ "$output" =~ "PASS t1 has one column" ]] || false
+
+    dolt checkout -b other
+    dolt sql -q "insert into t1 values (3);"
+    dolt commit -am "add row"
+    dolt checkout main
+
+    run dolt ci run workflow --ref other
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "FAIL t1 has two rows" ]] || false
+    [[ "$output" =~ "expected row count == 2, got 3" ]] || false
+    [[ "$output" =~ "PASS t1 has one column" ]] || false
+    [[ "$output" =~ "1 of 2 steps failed" ]] || false
+}
+
+@test "ci: run fails steps whose saved query does not exist" {
+    skip_remote_engine
+    cat > workflow.yaml <<EOF
+name: workflow
+on:
+  push:
+    branches:
+      - main
+jobs:
+  - name: check tables
+    steps:
+      - name: missing query
+        saved_query_name: does not exist
+EOF
+    dolt ci init
+    dolt ci import ./workflow.yaml
+    run dolt ci run workflow
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "FAIL missing query" ]] || false
+    [[ "$output" =~ "saved query not found: does not exist" ]] || false
+
+    run dolt ci run not_a_workflow
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "workflow not found" ]] || false
+}
+
+@test "ci: run executes sql assertion, constraint, schema and diff budget steps" {
+    skip_remote_engine
+    dolt sql -q "create table parent (id int primary key);"
+    dolt sql -q "create table child (id int primary key, parent_id int, foreign key (parent_id) references parent(id));"
+    dolt sql -q "insert into parent values (1), (2);"
+    dolt sql -q "insert into child values (1, 1);"
+    dolt add .
+    dolt commit -m "add tables"
+    cat > workflow.yaml <<EOF
+name: workflow
+on:
+  push:
+    branches:
+      - main
+jobs:
+  - name: check tables
+    steps:
+      - name: parent rows
+        sql_assertion:
+          query: select id, null from parent
+          expected_results:
+            - [2, null]
+            - [1, null]
+      - name: child constraints
+        verify_constraints:
+          tables:
+            - child
+      - name: parent schema
+        schema_unchanged:
+          table: parent
+          compare_to: main
+      - name: few changes
+        diff_budget:
+          compare_to: main
+          max_rows_added: 1
+          max_rows_deleted: 0
+EOF
+    dolt ci init
+    dolt ci import ./workflow.yaml
+    run dolt ci run workflow
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "4 of 4 steps passed" ]] || false
+
+    run dolt ci export workflow
+    [ "$status" -eq 0 ]
+    run cat workflow.yaml
+    [[ "$output" =~ "sql_assertion:" ]] || false
+    [[ "$output" =~ "max_rows_deleted: \"0\"" ]] || false
+    run dolt ci import ./workflow.yaml
+    [ "$status" -eq 0 ]
+
+    dolt checkout -b other
+    dolt sql -q "alter table parent add column v int;"
+    dolt sql -q "insert into parent values (3, 3), (4, 4);"
+    dolt sql -q "set foreign_key_checks = 0; insert into child values (2, 5);"
+    dolt commit -am "break things"
+    dolt checkout main
+
+    run dolt ci run workflow --ref other
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "FAIL parent rows" ]] || false
+    [[ "$output" =~ "expected 2 rows, got 4" ]] || false
+    [[ "$output" =~ 'unexpected row: ["3",null]' ]] || false
+    [[ "$output" =~ "FAIL child constraints" ]] || false
+    [[ "$output" =~ "constraint violations found in table child" ]] || false
+    [[ "$output" =~ "FAIL parent schema" ]] || false
+    [[ "$output" =~ "schema of table parent changed since main" ]] || false
+    [[ "$output" =~ "FAIL few changes" ]] || false
+    [[ "$output" =~ "3 rows added since main, more than the budget of 1" ]] || false
+    [[ "$output" =~ "4 of 4 steps failed" ]] || false
+}
+
+@test "ci: import command will error on an invalid step" {
+    cat > workflow.yaml <<EOF
+name: workflow
+on:
+  push:
+    branches:
+      - main
+jobs:
+  - name: check tables
+    steps:
+      - name: no budget
+        diff_budget:
+          table: t1
+EOF
+    dolt ci init
+    run dolt ci import ./workflow.yaml
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "diff_budget requires at least one of max_rows_added, max_rows_deleted or max_rows_modified" ]] || false
+}
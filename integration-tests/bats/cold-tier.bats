@@ -0,0 +1,131 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql -q "create table t (pk int primary key, c1 varchar(40))"
+    dolt sql -q "insert into t values (1, 'first')"
+    DOLT_COMMITTER_DATE='2020-01-01T00:00:00' dolt commit -Am "cm1"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+    rm -rf "$BATS_TMPDIR/cold-$$" "$BATS_TMPDIR/backup-$$" "$BATS_TMPDIR/restored-$$"
+}
+
+# table_files lists the table files in |dir|
+table_files() {
+    ls "$1" | grep -E '^[0-9a-v]{32}(\.bs)?$' || true
+}
+
+@test "cold-tier: full gc moves old history to the cold tier" {
+    dolt config --local --add storage.cold_tier.url "file://$BATS_TMPDIR/cold-$$"
+    dolt config --local --add storage.cold_tier.after 0s
+    dolt gc --full
+
+    [ -n "$(table_files "$BATS_TMPDIR/cold-$$")" ]
+
+    dolt sql -q "insert into t values (2, 'second')"
+    dolt commit -Am "cm2"
+    dolt gc --full
+
+    run dolt sql -q "select c1 from t order by pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "first" ]] || false
+    [[ "$output" =~ "second" ]] || false
+    [ -n "$(ls .dolt/noms/coldcache)" ]
+
+    run dolt log --oneline
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "cm1" ]] || false
+    [[ "$output" =~ "cm2" ]] || false
+}
+
+@test "cold-tier: recent history stays local" {
+    dolt config --local --add storage.cold_tier.url "file://$BATS_TMPDIR/cold-$$"
+    dolt sql -q "insert into t values (2, 'second')"
+    dolt commit -Am "cm2"
+    dolt gc --full
+
+    [ -n "$(table_files .dolt/noms/oldgen)" ]
+    [ -n "$(table_files "$BATS_TMPDIR/cold-$$")" ]
+
+    run dolt log --oneline
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "cm1" ]] || false
+    [[ "$output" =~ "cm2" ]] || false
+}
+
+@test "cold-tier: gc without --full leaves the cold tier alone" {
+    dolt config --local --add storage.cold_tier.url "file://$BATS_TMPDIR/cold-$$"
+    dolt gc
+
+    [ -n "$(table_files .dolt/noms/oldgen)" ]
+    [ -z "$(table_files "$BATS_TMPDIR/cold-$$")" ]
+}
+
+@test "cold-tier: full gc collects the cold tier" {
+    dolt config --local --add storage.cold_tier.url "file://$BATS_TMPDIR/cold-$$"
+    dolt branch feature
+    dolt checkout feature
+    dolt sql -q "insert into t values (3, 'feature')"
+    DOLT_COMMITTER_DATE='2020-01-02T00:00:00' dolt commit -Am "feature"
+    dolt checkout main
+    dolt gc --full
+    before="$(table_files "$BATS_TMPDIR/cold-$$")"
+    [ -n "$before" ]
+
+    dolt branch -D feature
+    dolt gc --full
+    after="$(table_files "$BATS_TMPDIR/cold-$$")"
+    [ -n "$after" ]
+    [ "$before" != "$after" ]
+    [ -z "$(ls "$BATS_TMPDIR/cold-$$" | grep -F "$before")" ]
+
+    run dolt sql -q "select c1 from t order by pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "first" ]] || false
+}
+
+@test "cold-tier: full gc and backups include the cold tier" {
+    dolt config --local --add storage.cold_tier.url "file://$BATS_TMPDIR/cold-$$"
+    dolt config --local --add storage.cold_tier.after 0s
+    dolt gc --full
+    dolt sql -q "insert into t values (2, 'second')"
+    dolt commit -Am "cm2"
+    dolt gc --full
+
+    run dolt sql -q "select count(*) from t" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+
+    dolt backup add bk "file://$BATS_TMPDIR/backup-$$"
+    dolt backup sync bk
+    cd "$BATS_TMPDIR"
+    dolt backup restore "file://$BATS_TMPDIR/backup-$$" "restored-$$"
+    cd "restored-$$"
+    run dolt sql -q "select c1 from t order by pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "first" ]] || false
+    [[ "$output" =~ "second" ]] || false
+}
+
+@test "cold-tier: invalid settings are rejected" {
+    dolt config --local --add storage.cold_tier.url "file://$BATS_TMPDIR/cold-$$"
+    dolt config --local --add storage.cold_tier.after soon
+    run dolt status
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "invalid value for cold-tier-after" ]] || false
+
+    dolt config --local --unset storage.cold_tier.after
+    dolt config --local --add storage.cold_tier.url "ftp://host/path"
+    run dolt status
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "unsupported cold tier url scheme" ]] || false
+
+    dolt config --local --add storage.cold_tier.url "aws://[table:bucket]/path"
+    run dolt status
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "use s3://bucket/prefix instead" ]] || false
+}
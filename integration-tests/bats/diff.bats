@@ -2181,4 +2181,56 @@ EOF
     [[ "$output" =~ "$EXPECTED" ]] || false
     # Count the line numbers to make sure there are no schema changes output
     [ "${#lines[@]}" -eq 3 ]
-}
\ No newline at end of file
+}
+@test "diff: markdown output" {
+    dolt sql -q "INSERT INTO test VALUES (0, 0, 0, 0, 0, 0), (1, 1, 1, 1, 1, 1)"
+    dolt add -A
+    dolt commit -m "rows"
+    dolt sql -q "UPDATE test SET c1 = 10 WHERE pk = 0"
+    dolt sql -q "DELETE FROM test WHERE pk = 1"
+    dolt sql -q "INSERT INTO test VALUES (2, 2, 2, 2, 2, 2)"
+    dolt sql -q "ALTER TABLE test ADD COLUMN c6 varchar(10)"
+
+    run dolt diff -r markdown
+    [ $status -eq 0 ]
+    [ "${lines[0]}" = "## test" ]
+    [[ "$output" =~ "**Schema changes**" ]] || false
+    [[ "$output" =~ "| + | c6 | varchar(10) |" ]] || false
+    [[ "$output" =~ "+  \`c6\` varchar(10)," ]] || false
+    [[ "$output" =~ "|   | pk | c1 | c2 | c3 | c4 | c5 | c6 |" ]] || false
+    [[ "$output" =~ "| \\* | 0 | ~~0~~ **10** | 0 | 0 | 0 | 0 | NULL |" ]] || false
+    [[ "$output" =~ "| - | 1 | 1 | 1 | 1 | 1 | 1 | NULL |" ]] || false
+    [[ "$output" =~ "| + | 2 | 2 | 2 | 2 | 2 | 2 | NULL |" ]] || false
+
+    run dolt diff -r markdown --data --where "to_pk = 0"
+    [ $status -eq 0 ]
+    [[ "$output" =~ "|   | pk | c1 |" ]] || false
+    [[ "$output" =~ "| \\* | 0 | ~~0~~ **10** |" ]] || false
+    [[ ! "$output" =~ "c2" ]] || false
+
+    run dolt diff -r markdown --stat
+    [ $status -eq 0 ]
+    [[ "$output" =~ "- 1 Row Modified (50.00%)" ]] || false
+}
+
+@test "diff: html output" {
+    dolt sql -q "INSERT INTO test VALUES (0, 0, 0, 0, 0, 0)"
+    dolt add -A
+    dolt commit -m "rows"
+    dolt sql -q "UPDATE test SET c1 = 10 WHERE pk = 0"
+    dolt sql -q "INSERT INTO test VALUES (2, 2, 2, 2, 2, 2)"
+    dolt sql -q "CREATE VIEW v AS SELECT pk FROM test"
+
+    run dolt diff -r html
+    [ $status -eq 0 ]
+    [ "${lines[0]}" = "<style>" ]
+    [[ "$output" =~ "<h2>test</h2>" ]] || false
+    [[ "$output" =~ '<tr class="modified"><td>*</td><td>0</td><td class="changed"><del>0</del><ins>10</ins></td><td>0</td>' ]] || false
+    [[ "$output" =~ '<tr class="added"><td>+</td><td>2</td><td>2</td><td>2</td><td>2</td><td>2</td><td>2</td></tr>' ]] || false
+    [[ "$output" =~ "<h2>view v</h2>" ]] || false
+    [[ "$output" =~ "<ins>+CREATE VIEW v AS SELECT pk FROM test;</ins>" ]] || false
+
+    run dolt diff -r htm
+    [ $status -ne 0 ]
+    [[ "$output" =~ "invalid output format: htm" ]] || false
+}
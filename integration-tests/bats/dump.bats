@@ -761,6 +761,43 @@ SQL
     [ ! -f dumps/warehouse.json ]
 }
 
+@test "dump: JSONL type - compare tables in database with tables imported from corresponding files" {
+    create_tables
+
+    dolt add .
+    dolt commit -m "create tables"
+
+    dolt branch new_branch
+
+    insert_data_into_tables
+    dolt sql -q "CREATE TABLE events(id int primary key, payload json);"
+    dolt sql -q "INSERT INTO events VALUES (1, '{\"a\": [1, 2], \"b\": {\"c\": null}}'), (2, NULL);"
+
+    dolt add .
+    dolt commit -m "insert to tables"
+
+    run dolt dump -r jsonl
+    [ "$status" -eq 0 ]
+    check_for_files "jsonl"
+    [ -f doltdump/events.jsonl ]
+
+    run cat doltdump/warehouse.jsonl
+    [ "${#lines[@]}" -eq 3 ]
+    [ "${lines[0]}" = '{"warehouse_id":1,"warehouse_name":"UPS"}' ]
+
+    dolt checkout new_branch
+    dolt sql -q "CREATE TABLE events(id int primary key, payload json);"
+
+    import_tables "jsonl"
+    dolt table import -r events doltdump/events.jsonl
+    dolt add .
+    dolt commit --allow-empty -m "create tables from doltdump"
+
+    run dolt diff --stat main new_branch
+    [ "$status" -eq 0 ]
+    [[ "$output" = "" ]] || false
+}
+
 @test "dump: dump with schema-only flag" {
     dolt sql -q "CREATE TABLE new_table(pk int primary key);"
     dolt sql -q "INSERT INTO new_table VALUES (1), (2);"
@@ -1019,3 +1056,206 @@ function import_tables() {
     dolt table import -r keyless "doltdump/keyless.$1"
   fi
 }
+
+@test "dump: xlsx dump writes a sheet per table and imports back with --sheet all" {
+    dolt sql <<SQL
+CREATE TABLE people (id int PRIMARY KEY, name varchar(20));
+CREATE TABLE pets (id int PRIMARY KEY, owner int, kind varchar(20));
+INSERT INTO people VALUES (1, 'alice'), (2, 'bob');
+INSERT INTO pets VALUES (10, 1, 'cat'), (11, 2, NULL);
+SQL
+    run dolt dump -r xlsx
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Successfully exported data." ]] || false
+    [ -f doltdump.xlsx ]
+
+    run dolt dump -r xlsx
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "doltdump.xlsx already exists" ]] || false
+
+    run dolt dump -r xlsx -fn workbook
+    [ "$status" -eq 0 ]
+    [ -f workbook.xlsx ]
+
+    run dolt dump -r xlsx -d out
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "directory is not supported for xlsx exports" ]] || false
+
+    mkdir imported && cd imported
+    dolt init
+    dolt sql <<SQL
+CREATE TABLE people (id int PRIMARY KEY, name varchar(20));
+CREATE TABLE pets (id int PRIMARY KEY, owner int, kind varchar(20));
+SQL
+    run dolt table import -u --sheet all ../doltdump.xlsx
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Import completed successfully." ]] || false
+    run dolt sql -r csv -q "SELECT p.name, q.kind FROM people p JOIN pets q ON p.id = q.owner ORDER BY p.id"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "alice,cat" ]
+    [ "${lines[2]}" = 'bob,""' ]
+}
+
+@test "dump: xlsx dump names sheets of long table names within Excel's limit" {
+    dolt sql <<SQL
+CREATE TABLE a_very_long_table_name_of_many_characters (id int PRIMARY KEY);
+CREATE TABLE a_very_long_table_name_of_many_things (id int PRIMARY KEY);
+INSERT INTO a_very_long_table_name_of_many_things VALUES (1);
+SQL
+    run dolt dump -r xlsx
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Dumping table a_very_long_table_name_of_many_characters to sheet a_very_long_table_name_of_many_" ]] || false
+    [[ "$output" =~ "Dumping table a_very_long_table_name_of_many_things to sheet a_very_long_table_name_of_man_2" ]] || false
+
+    dolt sql -q "CREATE TABLE a_very_long_table_name_of_man_2 (id int PRIMARY KEY)"
+    run dolt table import -u --sheet a_very_long_table_name_of_man_2 a_very_long_table_name_of_man_2 doltdump.xlsx
+    [ "$status" -eq 0 ]
+    run dolt sql -r csv -q "SELECT id FROM a_very_long_table_name_of_man_2"
+    [ "${lines[1]}" = "1" ]
+}
+
+@test "dump: dump the tables, views and triggers of a tag with --as-of" {
+    dolt sql <<SQL
+CREATE TABLE people (id int PRIMARY KEY, age int);
+CREATE TABLE pets (id int PRIMARY KEY, kind varchar(20));
+INSERT INTO people VALUES (1, 10), (2, 30);
+INSERT INTO pets VALUES (1, 'cat');
+CREATE VIEW adults AS SELECT * FROM people WHERE age > 21;
+CREATE TRIGGER lower_kind BEFORE INSERT ON pets FOR EACH ROW SET new.kind = lower(new.kind);
+SQL
+    dolt commit -Am "release"
+    dolt tag v1
+    dolt sql -q "INSERT INTO people VALUES (3, 50); DROP VIEW adults; CREATE TABLE later (id int PRIMARY KEY)"
+
+    run dolt dump --as-of v1 --no-create-db
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Successfully exported data." ]] || false
+    run grep "CREATE TABLE \`later\`" doltdump.sql
+    [ "$status" -eq 1 ]
+
+    mkdir imported && cd imported
+    dolt init
+    dolt sql < ../doltdump.sql
+    run dolt sql -r csv -q "SELECT count(*) FROM adults"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "1" ]
+    run dolt sql -r csv -q "SELECT name FROM dolt_schemas WHERE type = 'trigger'"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "lower_kind" ]
+    cd ..
+
+    run dolt dump --as-of nope -f
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "'nope' is not a commit, branch or tag" ]] || false
+}
+
+@test "dump: dump the tables and rows selected by --tables, --exclude-tables and --where" {
+    dolt sql <<SQL
+CREATE TABLE people (id int PRIMARY KEY, age int);
+CREATE TABLE pets (id int PRIMARY KEY, kind varchar(20));
+CREATE TABLE private_notes (id int PRIMARY KEY);
+INSERT INTO people VALUES (1, 10), (2, 30), (3, 50);
+INSERT INTO pets VALUES (1, 'cat'), (2, 'dog');
+CREATE VIEW adults AS SELECT * FROM people WHERE age > 21;
+CREATE VIEW cats AS SELECT * FROM pets WHERE kind = 'cat';
+CREATE TRIGGER lower_kind BEFORE INSERT ON pets FOR EACH ROW SET new.kind = lower(new.kind);
+SQL
+
+    run dolt dump --tables 'p*' --exclude-tables 'pets,private_*' --where "people:age > 21"
+    [ "$status" -eq 0 ]
+    run grep -c "CREATE TABLE" doltdump.sql
+    [ "$output" = "1" ]
+    run grep "INSERT INTO \`people\` (\`id\`,\`age\`) VALUES (2,30), (3,50);" doltdump.sql
+    [ "$status" -eq 0 ]
+    run grep -i "create view adults" doltdump.sql
+    [ "$status" -eq 0 ]
+    run grep -i "cats\|lower_kind" doltdump.sql
+    [ "$status" -eq 1 ]
+
+    run dolt dump -f -r csv --where "pets:kind = 'dog'"
+    [ "$status" -eq 0 ]
+    run cat doltdump/pets.csv
+    [ "${#lines[@]}" -eq 2 ]
+    [ "${lines[1]}" = "2,dog" ]
+
+    run dolt dump -f --where "people:age > 21" --where "pets:kind = 'dog'"
+    [ "$status" -eq 0 ]
+    run grep "INSERT INTO \`people\` (\`id\`,\`age\`) VALUES (2,30), (3,50);" doltdump.sql
+    [ "$status" -eq 0 ]
+    run grep "INSERT INTO \`pets\` (\`id\`,\`kind\`) VALUES (2,'dog');" doltdump.sql
+    [ "$status" -eq 0 ]
+
+    run dolt dump -f --tables people --where "pets:kind = 'dog'"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "--where is given for tables which aren't dumped: pets" ]] || false
+
+    run dolt dump -f --where "people"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "invalid --where filter 'people'" ]] || false
+}
+
+@test "dump: dump every branch with --all-branches and rebuild the branches on import" {
+    dolt sql <<SQL
+CREATE TABLE people (id int PRIMARY KEY, age int);
+INSERT INTO people VALUES (1, 10), (2, 30);
+CREATE VIEW adults AS SELECT * FROM people WHERE age > 21;
+SQL
+    dolt commit -Am "people"
+    dolt checkout -b feature/pets
+    dolt sql <<SQL
+CREATE TABLE pets (id int PRIMARY KEY, kind varchar(20));
+INSERT INTO pets VALUES (1, 'cat');
+DROP VIEW adults;
+CREATE PROCEDURE count_pets() SELECT count(*) FROM pets;
+SQL
+    dolt commit -Am "it's the pets" --author "Pet Owner <pets@example.com>"
+    dolt checkout main
+    dolt sql -q "INSERT INTO people VALUES (3, 50)"
+    dolt commit -Am "more people"
+
+    run dolt dump --all-branches --no-create-db
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Successfully exported data." ]] || false
+    [ -f doltdump/main.sql ]
+    [ -f doltdump/feature/pets.sql ]
+
+    run dolt dump --all-branches
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "already exists" ]] || false
+
+    run dolt dump --all-branches -r csv
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "all-branches is only supported for sql exports" ]] || false
+
+    run dolt dump --all-branches --as-of main
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "as-of and all-branches are mutually exclusive" ]] || false
+
+    mkdir imported && cd imported
+    dolt init
+    dolt sql < ../doltdump/main.sql
+    dolt sql < ../doltdump/feature/pets.sql
+
+    run dolt sql -r csv -q "SELECT message, committer FROM dolt_log AS OF 'feature/pets' LIMIT 1"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "it's the pets,Pet Owner" ]
+    run dolt sql -r csv -q "SELECT message FROM dolt_log AS OF 'feature/pets' LIMIT 1 OFFSET 1"
+    [ "${lines[1]}" = "people" ]
+    run dolt sql -r csv -q "SELECT message FROM dolt_log WHERE commit_hash = dolt_merge_base('main', 'feature/pets')"
+    [ "${lines[1]}" = "people" ]
+    run dolt sql -r csv -q "SELECT count(*) FROM people AS OF 'feature/pets'"
+    [ "${lines[1]}" = "2" ]
+    run dolt sql -r csv -q "SELECT count(*) FROM dolt_procedures AS OF 'feature/pets'"
+    [ "${lines[1]}" = "1" ]
+    run dolt sql -r csv -q "SELECT count(*) FROM dolt_schemas AS OF 'feature/pets'"
+    [ "${lines[1]}" = "0" ]
+
+    run dolt sql -r csv -q "SELECT message FROM dolt_log AS OF 'main' LIMIT 1"
+    [ "${lines[1]}" = "more people" ]
+    run dolt sql -r csv -q "SELECT count(*) FROM adults AS OF 'main'"
+    [ "${lines[1]}" = "2" ]
+    run dolt sql -q "SHOW TABLES AS OF 'main'"
+    [[ ! "$output" =~ "pets" ]] || false
+    run dolt sql -r csv -q "SELECT count(*) FROM dolt_procedures AS OF 'main'"
+    [ "${lines[1]}" = "0" ]
+}
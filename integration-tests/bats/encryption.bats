@@ -0,0 +1,125 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    export DOLT_ENCRYPTION_KEY=$(head -c 32 /dev/urandom | base64)
+    dolt sql -q "create table t (pk int primary key, c1 varchar(40))"
+    dolt sql -q "insert into t values (1, 'plaintext-canary-value')"
+    dolt commit -Am "cm1"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "encryption: data is not stored in plaintext" {
+    run grep -r "plaintext-canary-value" .dolt
+    [ "$status" -eq 1 ]
+
+    dolt gc
+    run grep -r "plaintext-canary-value" .dolt
+    [ "$status" -eq 1 ]
+
+    run dolt sql -q "select c1 from t where pk = 1" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "plaintext-canary-value" ]] || false
+}
+
+@test "encryption: databases can't be read without their key" {
+    dolt gc
+
+    run env -u DOLT_ENCRYPTION_KEY dolt status
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "no encryption key is configured" ]] || false
+
+    run env DOLT_ENCRYPTION_KEY=$(head -c 32 /dev/urandom | base64) dolt status
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "encrypted with a key which isn't configured" ]] || false
+}
+
+@test "encryption: keys can be read from a key file" {
+    echo "$DOLT_ENCRYPTION_KEY" > "$BATS_TMPDIR/dolt-key-$$"
+    run env -u DOLT_ENCRYPTION_KEY DOLT_ENCRYPTION_KEY_FILE="$BATS_TMPDIR/dolt-key-$$" dolt sql -q "select c1 from t" -r csv
+    rm "$BATS_TMPDIR/dolt-key-$$"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "plaintext-canary-value" ]] || false
+}
+
+@test "encryption: gc --full rotates keys" {
+    dolt gc
+    dolt sql -q "insert into t values (2, 'second-canary-value')"
+    dolt commit -am "cm2"
+
+    old_key=$DOLT_ENCRYPTION_KEY
+    new_key=$(head -c 32 /dev/urandom | base64)
+    DOLT_ENCRYPTION_KEY="$new_key,$old_key" dolt gc --full
+
+    export DOLT_ENCRYPTION_KEY=$new_key
+    run dolt sql -q "select c1 from t order by pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "plaintext-canary-value" ]] || false
+    [[ "$output" =~ "second-canary-value" ]] || false
+
+    run dolt log
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "cm2" ]] || false
+}
+
+@test "encryption: file remotes can be cloned without the key" {
+    mkdir -p "$BATS_TMPDIR/remote-$$"
+    dolt remote add origin "file://$BATS_TMPDIR/remote-$$"
+    dolt push origin main
+
+    # remotes are read by other machines, so only the local database is encrypted
+    run grep -r "plaintext-canary-value" .dolt
+    [ "$status" -eq 1 ]
+
+    cd "$BATS_TMPDIR"
+    run env -u DOLT_ENCRYPTION_KEY dolt clone "file://$BATS_TMPDIR/remote-$$" "clone-$$"
+    [ "$status" -eq 0 ]
+
+    cd "clone-$$"
+    run env -u DOLT_ENCRYPTION_KEY dolt sql -q "select c1 from t" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "plaintext-canary-value" ]] || false
+
+    cd ..
+    rm -rf "remote-$$" "clone-$$"
+}
+
+@test "encryption: clones of file remotes are encrypted" {
+    mkdir -p "$BATS_TMPDIR/remote-$$"
+    dolt remote add origin "file://$BATS_TMPDIR/remote-$$"
+    dolt push origin main
+
+    cd "$BATS_TMPDIR"
+    dolt clone "file://$BATS_TMPDIR/remote-$$" "clone-$$"
+    run grep -r "plaintext-canary-value" "clone-$$/.dolt"
+    [ "$status" -eq 1 ]
+
+    cd "clone-$$"
+    run dolt sql -q "select c1 from t" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "plaintext-canary-value" ]] || false
+
+    cd ..
+    rm -rf "remote-$$" "clone-$$"
+}
+
+@test "encryption: bundles can be read without the key" {
+    dolt bundle create "$BATS_TMPDIR/bundle-$$.bundle" main
+
+    cd "$BATS_TMPDIR"
+    run env -u DOLT_ENCRYPTION_KEY dolt clone "file://$BATS_TMPDIR/bundle-$$.bundle" "bundle-clone-$$"
+    [ "$status" -eq 0 ]
+
+    cd "bundle-clone-$$"
+    run env -u DOLT_ENCRYPTION_KEY dolt sql -q "select c1 from t" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "plaintext-canary-value" ]] || false
+
+    cd ..
+    rm -rf "bundle-$$.bundle" "bundle-clone-$$"
+}
@@ -105,6 +105,30 @@ if rows[2] != "9,8,7,6,5,4".split(","):
     [[ "$output" =~ "2 export.csv" ]] || false
 }
 
+@test "export-tables: dolt table jsonl export" {
+    dolt sql -q "CREATE TABLE events (id int primary key, name varchar(20), payload json)"
+    dolt sql -q "INSERT INTO events VALUES (1, 'one', '{\"a\": [1, {\"b\": null}]}'), (2, NULL, NULL)"
+
+    run dolt table export events events.jsonl
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Successfully exported data." ]] || false
+    run cat events.jsonl
+    [ "${#lines[@]}" -eq 2 ]
+    [ "${lines[0]}" = '{"id":1,"name":"one","payload":{"a":[1,{"b":null}]}}' ]
+    [ "${lines[1]}" = '{"id":2}' ]
+
+    run dolt table export --file-type ndjson events
+    [ "$status" -eq 0 ]
+    [ "${lines[0]}" = '{"id":1,"name":"one","payload":{"a":[1,{"b":null}]}}' ]
+
+    dolt sql -q "DELETE FROM events"
+    dolt table import -u events events.jsonl
+    run dolt sql -r csv -q "SELECT id, name, payload FROM events ORDER BY id"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = '1,one,"{""a"":[1,{""b"":null}]}"' ]
+    [ "${lines[2]}" = "2,," ]
+}
+
 @test "export-tables: dolt table SQL export" {
     dolt sql -q "insert into test_int values (0, 1, 2, 3, 4, 5)"
     run dolt table export test_int export.sql
@@ -479,6 +503,74 @@ SQL
     [[ "$output" =~ "5235.66789" ]] || false
 }
 
+@test "export-tables: round trip arrow file and stream formats" {
+    dolt sql <<SQL
+CREATE TABLE t (
+  id int primary key,
+  d decimal(9,5),
+  dt datetime(6),
+  ts timestamp,
+  tm time,
+  j json,
+  b varbinary(10),
+  s text
+);
+INSERT INTO t VALUES
+  (1, 1234.56789, '2021-03-04 05:06:07.123456', '2022-01-02 03:04:05', '-26:00:05', '{"a": [1, 2]}', 0x68ff, 'one'),
+  (2, NULL, NULL, NULL, NULL, NULL, NULL, NULL);
+SQL
+    run dolt sql -r csv -q "SELECT * FROM t ORDER BY id"
+    [ "$status" -eq 0 ]
+    expected=$output
+
+    for file in t.arrow t.feather t.arrows; do
+        run dolt table export -f t $file
+        [ "$status" -eq 0 ]
+        [[ "$output" =~ "Successfully exported data." ]] || false
+        [ -f $file ]
+
+        dolt sql -q "DELETE FROM t"
+        dolt table import -u t $file
+        run dolt sql -r csv -q "SELECT * FROM t ORDER BY id"
+        [ "$status" -eq 0 ]
+        [ "$output" = "$expected" ]
+    done
+
+    # the stream format can be written to stdout and read from stdin
+    dolt table export --file-type arrows t > stdout.arrows
+    dolt sql -q "DELETE FROM t"
+    dolt table import -r --file-type arrows t < stdout.arrows
+    run dolt sql -r csv -q "SELECT * FROM t ORDER BY id"
+    [ "$status" -eq 0 ]
+    [ "$output" = "$expected" ]
+
+    run dolt table import -c t2 t.arrow
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "Please specify schema file for .arrow tables." ]] || false
+}
+
+@test "export-tables: arrow file export read with pyarrow" {
+    skiponwindows "Missing dependencies"
+    if ! python3 -c "import pyarrow" 2> /dev/null; then
+        skip "pyarrow not installed"
+    fi
+    dolt sql -q "CREATE TABLE t (pk int primary key, d decimal(10,2), dt datetime, j json, b varbinary(10));"
+    dolt sql -q "INSERT INTO t VALUES (1, 12.34, '2021-03-04 05:06:07', '{\"a\": 1}', 0x6869), (2, NULL, NULL, NULL, NULL);"
+
+    dolt table export t t.arrow
+    echo "import pyarrow.feather as feather
+table = feather.read_table('t.arrow')
+print(table.schema)
+print(table.to_pylist())
+" > arrow.py
+    run python3 arrow.py
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "d: decimal128(10, 2)" ]] || false
+    [[ "$output" =~ "dt: timestamp[us]" ]] || false
+    [[ "$output" =~ "b: binary" ]] || false
+    [[ "$output" =~ "'j': '{\"a\": 1}'" ]] || false
+}
+
 @test "export-tables: table export to sql with null values in different sql types" {
     dolt sql <<SQL
 CREATE TABLE s (stringVal VARCHAR(6));
@@ -513,3 +605,119 @@ SQL
     run dolt sql -q "SELECT * FROM i"
     [ "$output" = "$int_output" ]
 }
+
+@test "export-tables: export the rows and columns selected by --where, --columns and --as-of" {
+    dolt sql -q "INSERT INTO test_int VALUES (1, 1, 2, 3, 4, 5), (2, 2, 3, 4, 5, 6), (3, 3, 4, 5, 6, 7)"
+    dolt commit -Am "add rows"
+    dolt sql -q "UPDATE test_int SET c1 = 10 WHERE pk = 1"
+
+    run dolt table export --where "c1 < 3" --columns "c2,pk" test_int sel.csv
+    [ "$status" -eq 0 ]
+    run cat sel.csv
+    [ "${lines[0]}" = "c2,pk" ]
+    [ "${lines[1]}" = "3,2" ]
+    [ "${#lines[@]}" -eq 2 ]
+
+    run dolt table export --as-of HEAD --where "c1 < 3" --columns "pk,c1" test_int asof.csv
+    [ "$status" -eq 0 ]
+    run cat asof.csv
+    [ "${lines[0]}" = "pk,c1" ]
+    [ "${lines[1]}" = "1,1" ]
+    [ "${lines[2]}" = "2,2" ]
+    [ "${#lines[@]}" -eq 3 ]
+
+    run dolt table export --columns "pk,nope" test_int bad.csv
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "column nope not found" ]] || false
+
+    run dolt table export --as-of nope test_int bad.csv
+    [ "$status" -eq 1 ]
+
+    run dolt table export --compression zstd test_int bad.csv
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "--compression is only supported when exporting to parquet" ]] || false
+}
+
+@test "export-tables: export parquet partitioned by a column" {
+    dolt sql <<SQL
+CREATE TABLE events (id int PRIMARY KEY, region varchar(20), amount int);
+INSERT INTO events VALUES (1, 'us', 10), (2, 'eu', 20), (3, 'us', 30), (4, NULL, 40), (5, 'a/b', 50);
+SQL
+    dolt commit -Am "add events"
+    dolt sql -q "INSERT INTO events VALUES (6, 'asia', 60)"
+
+    run dolt table export --partition-by region --compression zstd --row-group-size 1024 --as-of HEAD --where "id > 2" --columns id events out
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Wrote 3 files to out" ]] || false
+    [ -f out/region=us/part-0.parquet ]
+    [ -f out/region=__HIVE_DEFAULT_PARTITION__/part-0.parquet ]
+    [ -f out/region=a%2Fb/part-0.parquet ]
+    [ ! -d out/region=eu ]
+    [ ! -d out/region=asia ]
+
+    run dolt sql -r csv -q "SELECT * FROM dolt_read_file('out/region=us/part-0.parquet')"
+    [ "$status" -eq 0 ]
+    [ "${lines[0]}" = "id" ]
+    [ "${lines[1]}" = "3" ]
+    [ "${#lines[@]}" -eq 2 ]
+
+    run dolt sql -r csv -q "SELECT * FROM dolt_read_file('out/region=__HIVE_DEFAULT_PARTITION__/part-0.parquet')"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "4" ]
+
+    run dolt table export --partition-by region events out
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "out already exists. Use -f to overwrite." ]] || false
+
+    run dolt table export -f --partition-by region events out
+    [ "$status" -eq 0 ]
+    [ -f out/region=asia/part-0.parquet ]
+    run dolt sql -r csv -q "SELECT * FROM dolt_read_file('out/region=us/part-0.parquet') ORDER BY id"
+    [ "$status" -eq 0 ]
+    [ "${lines[0]}" = "id,amount" ]
+    [ "${lines[1]}" = "1,10" ]
+    [ "${lines[2]}" = "3,30" ]
+
+    run dolt table export --partition-by region --file-type csv events out2
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "--partition-by is only supported when exporting to parquet" ]] || false
+
+    run dolt table export --partition-by nope events out3
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ 'column "nope" could not be found' ]] || false
+
+    run dolt table export --compression brotli events out.parquet
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "invalid compression 'brotli'" ]] || false
+
+    run dolt table export --row-group-size 0 events out.parquet
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "--row-group-size must be a positive number of bytes" ]] || false
+}
+
+@test "export-tables: export a table to xlsx and import it back" {
+    dolt sql <<SQL
+CREATE TABLE people (id int PRIMARY KEY, name varchar(20), score double, born date);
+INSERT INTO people VALUES (1, 'alice', 1.5, '2000-01-02'), (2, NULL, NULL, NULL);
+SQL
+    run dolt table export people people.xlsx
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Successfully exported data." ]] || false
+    [ -f people.xlsx ]
+
+    run dolt table export people people.xlsx
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "people.xlsx already exists. Use -f to overwrite." ]] || false
+
+    dolt sql -q "CREATE TABLE people2 LIKE people"
+    run dolt table import -u --sheet people people2 people.xlsx
+    [ "$status" -eq 0 ]
+    run dolt sql -r csv -q "SELECT * FROM people2 ORDER BY id"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "1,alice,1.5,2000-01-02" ]
+    # empty cells are read as empty strings
+    [ "${lines[2]}" = '2,"",,' ]
+
+    run dolt table export people - --file-type xlsx
+    [ "$status" -eq 1 ]
+}
@@ -136,6 +136,52 @@ teardown() {
     [[ "$output" =~ "employees-sch-bad.sql" ]] || false
 }
 
+@test "import-create-tables: create a table with jsonl import" {
+    cat <<SQL > events-sch.sql
+CREATE TABLE events (
+    id bigint primary key,
+    name varchar(20),
+    amount decimal(20,2),
+    payload json
+);
+SQL
+    cat <<JSONL > events.ndjson
+{"id": 9007199254740993, "name": "first", "amount": 12345678901234567.89, "payload": {"tags": ["a", "b"], "n": {"m": 1}}}
+
+{"id": 2, "payload": [1, "two", null]}
+JSONL
+
+    run dolt table import -c -s events-sch.sql events events.ndjson
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Rows Processed: 2, Additions: 2, Modifications: 0, Had No Effect: 0" ]] || false
+    [[ "$output" =~ "Import completed successfully." ]] || false
+
+    run dolt sql -r csv -q "select id, name, amount, payload from events order by id"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = '2,,,"[1,""two"",null]"' ]
+    [ "${lines[2]}" = '9007199254740993,first,12345678901234567.89,"{""n"":{""m"":1},""tags"":[""a"",""b""]}"' ]
+
+    # jsonl can be read from stdin
+    echo '{"id": 3, "name": "third"}' | dolt table import -u --file-type jsonl events
+    run dolt sql -r csv -q "select name from events where id = 3"
+    [ "${lines[1]}" = "third" ]
+}
+
+@test "import-create-tables: create a table with jsonl import. no schema." {
+    echo '{"id": 1}' > events.jsonl
+    run dolt table import -c events events.jsonl
+    [ "$status" -ne 0 ]
+    [ "$output" = "Please specify schema file for .jsonl tables." ]
+}
+
+@test "import-create-tables: create a table with jsonl import. bad jsonl data." {
+    dolt sql -q "CREATE TABLE events (id int primary key, name varchar(20))"
+    printf '{"id": 1, "name": "one"}\n{"id": 2, "name": "two"\n' > events.jsonl
+    run dolt table import -u events events.jsonl
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "error reading line 2" ]] || false
+}
+
 @test "import-create-tables: import data from csv and create the table" {
     run dolt table import -c --pk=pk test 1pk5col-ints.csv
     [ "$status" -eq 0 ]
@@ -416,6 +462,41 @@ DELIM
     [[ ! "$output" =~ "bad-sheet-name" ]] || false
 }
 
+@test "import-create-tables: import the excel sheet given by --sheet" {
+    run dolt table import -c --pk=id staff --sheet employees `batshelper employees.xlsx`
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Import completed successfully." ]] || false
+    run dolt sql -r csv -q "select count(*) from staff"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "3" ]
+
+    run dolt table import -c --pk=id t --sheet employees `batshelper 1pk5col-ints.csv`
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "--sheet is only supported when importing an xlsx file" ]] || false
+}
+
+@test "import-create-tables: import every excel sheet with --sheet all" {
+    # the basketball sheet has no id column, so no table is imported
+    run dolt table import -c --pk=id --sheet all `batshelper employees.xlsx`
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "into table basketball" ]] || false
+    run dolt ls
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "employees" ]] || false
+
+    run dolt table import -c --sheet all `batshelper employees.xlsx`
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Import completed successfully." ]] || false
+    run dolt ls
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "employees" ]] || false
+    [[ "$output" =~ "basketball" ]] || false
+
+    run dolt table import -c --pk=id --sheet all t `batshelper employees.xlsx`
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "only the file is given with --sheet all" ]] || false
+}
+
 @test "import-create-tables: import an .xlsx file that is not a valid excel spreadsheet" {
     run dolt table import -c --pk=id test `batshelper bad.xlsx`
     [ "$status" -eq 1 ]
@@ -0,0 +1,138 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    mkdir -p data
+    cat <<CSV > data/people.csv
+id,name,age
+1,alice,30
+2,bob,25
+CSV
+    cat <<PSV > data/pets.psv
+id|owner|kind
+10|1|cat
+11|2|dog
+PSV
+    echo "not imported" > data/README.txt
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "import-dir: create a table from each file of a directory" {
+    run dolt table import -c --pk id --dir data
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Skipping data/README.txt, which isn't a supported file type." ]] || false
+    [[ "$output" =~ "people: Rows Processed: 2, Additions: 2" ]] || false
+    [[ "$output" =~ "pets: Rows Processed: 2, Additions: 2" ]] || false
+    [[ "$output" =~ "Import completed successfully." ]] || false
+
+    run dolt sql -r csv -q "SELECT p.name, q.kind FROM people p JOIN pets q ON p.id = q.owner ORDER BY p.id"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "alice,cat" ]
+    [ "${lines[2]}" = "bob,dog" ]
+
+    # the tables are imported in one commit
+    run dolt log -n 1 --oneline
+    [[ "$output" =~ "Import data" ]] || false
+    run dolt sql -r csv -q "SELECT count(*) FROM dolt_diff WHERE commit_hash = hashof('HEAD')"
+    [ "${lines[1]}" = "2" ]
+    run dolt status
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+
+    run dolt table import -c --pk id --dir data
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "people already exists. Use -f to overwrite." ]] || false
+
+    run dolt table import -c -f --pk id --parallel 2 --dir data
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Import completed successfully." ]] || false
+}
+
+@test "import-dir: a csv dump round trips with foreign keys checked at the end" {
+    dolt sql <<SQL
+CREATE TABLE parent (id int PRIMARY KEY, name varchar(20));
+CREATE TABLE a_child (id int PRIMARY KEY, pid int, FOREIGN KEY (pid) REFERENCES parent(id));
+INSERT INTO parent VALUES (1, 'a'), (2, 'b');
+INSERT INTO a_child VALUES (10, 1), (11, 2);
+SQL
+    dolt dump -r csv
+    dolt dump --schema-only --no-create-db -fn schema.sql
+    dolt sql -q "DROP TABLE a_child; DROP TABLE parent"
+    dolt sql < schema.sql
+
+    # a_child is imported before the parent rows it references
+    run dolt table import -u --dir doltdump
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "a_child: Rows Processed: 2, Additions: 2" ]] || false
+    [[ "$output" =~ "parent: Rows Processed: 2, Additions: 2" ]] || false
+
+    run dolt sql -r csv -q "SELECT a_child.id, name FROM a_child JOIN parent ON pid = parent.id ORDER BY a_child.id"
+    [ "${lines[1]}" = "10,a" ]
+    [ "${lines[2]}" = "11,b" ]
+
+    echo "12,3" >> doltdump/a_child.csv
+    run dolt table import -r --dir doltdump
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "the imported rows violate foreign keys of the tables a_child" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+
+    run dolt table import -r --disable-fk-checks --dir doltdump
+    [ "$status" -eq 0 ]
+    run dolt sql -r csv -q "SELECT count(*) FROM a_child"
+    [ "${lines[1]}" = "3" ]
+}
+
+@test "import-dir: no table is imported if one fails" {
+    dolt table import -c --pk id --dir data
+    cat <<CSV > data/people.csv
+id,name,age
+1,carol,31
+CSV
+    echo "12|3|fish|extra" >> data/pets.psv
+
+    run dolt table import -u --dir data
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "failed to import data/pets.psv into table pets" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+    run dolt sql -r csv -q "SELECT name FROM people"
+    [ "${lines[1]}" = "alice" ]
+}
+
+@test "import-dir: invalid arguments" {
+    run dolt table import -c --dir data people data/people.csv
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "a table and file can't be given with --dir" ]] || false
+
+    run dolt table import -c --dir data --schema schema.sql
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "--schema can't be used with --dir" ]] || false
+
+    run dolt table import -c --dir data --parallel 0
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "--parallel must be a positive number of tables" ]] || false
+
+    run dolt table import -c --parallel 2 people data/people.csv
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "--parallel is only supported with --dir" ]] || false
+
+    run dolt table import -u --dir data
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "The following table could not be found: people" ]] || false
+
+    run dolt table import -c --dir missing
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "directory 'missing' not found" ]] || false
+
+    echo '{"rows": []}' > data/things.json
+    run dolt table import -c --dir data
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "table things can't be created from data/things.json, which needs a schema file" ]] || false
+}
@@ -0,0 +1,170 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+load $BATS_TEST_DIRNAME/helper/query-server-common.bash
+
+setup() {
+    skiponwindows "tests are flaky on Windows"
+    setup_common
+
+    # a dolt sql-server acts as the MySQL server rows are imported from. Its database is kept outside of the
+    # repo being imported to, which would otherwise load it as a database of its own.
+    SRC_DIR="$BATS_TMPDIR/import-mysql-src-$$"
+    rm -rf "$SRC_DIR"
+    mkdir -p "$SRC_DIR/srcdb"
+    cd "$SRC_DIR/srcdb"
+    dolt init
+    dolt sql <<SQL
+CREATE TABLE items (
+  name varchar(40) COLLATE utf8mb4_0900_ai_ci NOT NULL,
+  id int NOT NULL,
+  price decimal(12,4),
+  kind enum('small','large'),
+  tags set('a','b','c'),
+  created datetime(3),
+  flags bit(5),
+  notes text COMMENT 'free text',
+  PRIMARY KEY (id, name)
+);
+INSERT INTO items VALUES
+  ('widget', 1, 12.3456, 'large', 'a,c', '2024-01-02 03:04:05.678', b'10101', 'hello, "world"'),
+  ('gadget', 2, NULL, NULL, NULL, NULL, NULL, NULL);
+CREATE TABLE stamped (
+  id int PRIMARY KEY,
+  created timestamp DEFAULT CURRENT_TIMESTAMP
+);
+INSERT INTO stamped (id, created) VALUES (1, '2024-01-02 03:04:05');
+CREATE TABLE people (
+  id int PRIMARY KEY,
+  email varchar(100) NOT NULL DEFAULT '',
+  name varchar(100) DEFAULT 'it''s',
+  age int DEFAULT 18,
+  size enum('s','m') DEFAULT 'm',
+  UNIQUE KEY email_idx (email),
+  KEY name_age_idx (name, age),
+  KEY name_prefix_idx (name(10))
+);
+INSERT INTO people (id, email) VALUES (1, 'a@example.com');
+SQL
+    dolt commit -Am "add items"
+    start_sql_server srcdb
+    cd "$BATS_TMPDIR/dolt-repo-$$"
+
+    DSN="root@tcp(127.0.0.1:$PORT)/srcdb"
+}
+
+teardown() {
+    stop_sql_server 1
+    rm -rf "$SRC_DIR"
+    teardown_common
+}
+
+@test "import-mysql-tables: create a table from a mysql table keeps its types" {
+    run dolt table import -c --from-mysql "$DSN" --table items items
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Rows Processed: 2, Additions: 2" ]] || false
+    [[ "$output" =~ "Import completed successfully." ]] || false
+
+    run dolt schema show items
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "\`name\` varchar(40) COLLATE utf8mb4_0900_ai_ci NOT NULL" ]] || false
+    [[ "$output" =~ "\`price\` decimal(12,4)" ]] || false
+    [[ "$output" =~ "\`kind\` enum('small','large')" ]] || false
+    [[ "$output" =~ "\`tags\` set('a','b','c')" ]] || false
+    [[ "$output" =~ "\`created\` datetime(3)" ]] || false
+    [[ "$output" =~ "\`flags\` bit(5)" ]] || false
+    [[ "$output" =~ "\`notes\` text COMMENT 'free text'" ]] || false
+    [[ "$output" =~ "PRIMARY KEY (\`id\`,\`name\`)" ]] || false
+
+    run dolt sql -r csv -q "SELECT id, name, price, kind, tags, created, flags + 0, notes FROM items ORDER BY id"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = '1,widget,12.3456,large,"a,c",2024-01-02 03:04:05.678,21,"hello, ""world"""' ]
+    [ "${lines[2]}" = "2,gadget,,,,,," ]
+}
+
+@test "import-mysql-tables: columns with an expression default are imported" {
+    run dolt table import -c --from-mysql "$DSN" --table stamped stamped
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Rows Processed: 1, Additions: 1" ]] || false
+
+    run dolt schema show stamped
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "\`created\` timestamp DEFAULT CURRENT_TIMESTAMP" ]] || false
+
+    run dolt sql -r csv -q "SELECT id, created FROM stamped"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "1,2024-01-02 03:04:05" ]
+}
+
+@test "import-mysql-tables: column defaults and secondary indexes are imported" {
+    run dolt table import -c --from-mysql "$DSN" --table people people
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Rows Processed: 1, Additions: 1" ]] || false
+
+    run dolt schema show people
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "\`email\` varchar(100) NOT NULL DEFAULT ('')" ]] || false
+    [[ "$output" =~ "\`name\` varchar(100) DEFAULT ('it''s')" ]] || false
+    [[ "$output" =~ "\`age\` int DEFAULT (18)" ]] || false
+    [[ "$output" =~ "\`size\` enum('s','m') DEFAULT ('m')" ]] || false
+    [[ "$output" =~ "UNIQUE KEY \`email_idx\` (\`email\`)" ]] || false
+    [[ "$output" =~ "KEY \`name_age_idx\` (\`name\`,\`age\`)" ]] || false
+    [[ "$output" =~ "KEY \`name_prefix_idx\` (\`name\`(10))" ]] || false
+
+    run dolt sql -r csv -q "SELECT id, email, name, age, size FROM people"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "1,a@example.com,it's,18,m" ]
+}
+
+@test "import-mysql-tables: update a table from a mysql table" {
+    dolt table import -c --from-mysql "$DSN" --table items items
+    dolt sql -q "UPDATE items SET price = 1 WHERE id = 1"
+
+    run dolt table import -u --from-mysql "$DSN" --table items items
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Rows Processed: 2, Additions: 0, Modifications: 1, Had No Effect: 1" ]] || false
+
+    run dolt sql -r csv -q "SELECT price FROM items WHERE id = 1"
+    [ "${lines[1]}" = "12.3456" ]
+}
+
+@test "import-mysql-tables: create a table from a mysql query" {
+    run dolt table import -c --pk id --from-mysql "$DSN" --query "SELECT id, price, created, kind FROM items WHERE id > 1;" items
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Rows Processed: 1, Additions: 1" ]] || false
+
+    run dolt schema show items
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "\`price\` decimal(12,4)" ]] || false
+    [[ "$output" =~ "\`created\` datetime(3)" ]] || false
+    [[ "$output" =~ "\`kind\` varchar(1023)" ]] || false
+    [[ "$output" =~ "PRIMARY KEY (\`id\`)" ]] || false
+
+    run dolt sql -r csv -q "SELECT id FROM items"
+    [ "${lines[1]}" = "2" ]
+}
+
+@test "import-mysql-tables: invalid arguments and sources" {
+    run dolt table import -c --from-mysql "$DSN" items
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "--from-mysql requires exactly one of --table or --query" ]] || false
+
+    run dolt table import -c --from-mysql "$DSN" --table items items items.csv
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "a file can't be given with --from-mysql" ]] || false
+
+    run dolt table import -c --table items items items.csv
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "--table and --query are only supported with --from-mysql" ]] || false
+
+    run dolt table import -c --from-mysql "$DSN" --table missing missing
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "table missing was not found in database srcdb of the MySQL server" ]] || false
+
+    run dolt table import -c --from-mysql "root@tcp(127.0.0.1:$PORT)/" --table items items
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "the MySQL data source name must name a database" ]] || false
+
+    run dolt table import -c --from-mysql "postgres://root@127.0.0.1:5432/srcdb" --table items items
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "PostgreSQL servers are not supported" ]] || false
+}
@@ -0,0 +1,213 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk int NOT NULL,
+  name varchar(20) NOT NULL,
+  payload json,
+  PRIMARY KEY (pk),
+  UNIQUE KEY (name)
+);
+INSERT INTO test VALUES (1, 'one', '{"a": 1}'), (2, 'two', NULL), (3, 'three', '[1, 2]');
+SQL
+    dolt commit -Am "initial rows"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "import-sync-tables: sync inserts, updates and deletes rows" {
+    cat <<CSV > sync.csv
+pk,name,payload
+1,one,"{""a"": 1}"
+2,deux,
+4,three,"[3]"
+CSV
+
+    run dolt table import --sync test sync.csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Rows Processed: 3, Additions: 1, Modifications: 1, Had No Effect: 1, Deletions: 1" ]] || false
+    [[ "$output" =~ "1 Row Unmodified (33.33%)" ]] || false
+    [[ "$output" =~ "1 Row Added (33.33%)" ]] || false
+    [[ "$output" =~ "1 Row Deleted (33.33%)" ]] || false
+    [[ "$output" =~ "1 Row Modified (33.33%)" ]] || false
+    [[ "$output" =~ "Import completed successfully." ]] || false
+
+    run dolt sql -r csv -q "SELECT * FROM test ORDER BY pk"
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 4 ]
+    [ "${lines[1]}" = '1,one,"{""a"":1}"' ]
+    [ "${lines[2]}" = "2,deux," ]
+    [ "${lines[3]}" = '4,three,[3]' ]
+
+    # the summary matches the diff
+    run dolt diff --stat
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1 Row Unmodified (33.33%)" ]] || false
+    [[ "$output" =~ "1 Row Deleted (33.33%)" ]] || false
+
+    run dolt diff -r sql
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "one" ]] || false
+}
+
+@test "import-sync-tables: sync with no changes leaves the table untouched" {
+    dolt table export test sync.csv
+
+    run dolt table import --sync test sync.csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Rows Processed: 3, Additions: 0, Modifications: 0, Had No Effect: 3" ]] || false
+    [[ "$output" =~ "No data changes." ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+}
+
+@test "import-sync-tables: sync from an empty file deletes every row" {
+    echo "pk,name,payload" > sync.csv
+
+    run dolt table import --sync test sync.csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "3 Rows Deleted (100.00%)" ]] || false
+
+    run dolt sql -r csv -q "SELECT count(*) FROM test"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "0" ]
+}
+
+@test "import-sync-tables: sync deletes and inserts more rows than a batch" {
+    dolt sql <<SQL
+CREATE TABLE composite (a int, b varchar(10), v int, PRIMARY KEY (a, b));
+INSERT INTO composite SELECT seq, 'x', seq FROM (WITH RECURSIVE s(seq) AS (SELECT 1 UNION ALL SELECT seq + 1 FROM s WHERE seq < 1000) SELECT seq FROM s) t;
+SQL
+    echo "a,b,v" > sync.csv
+    for i in $(seq 501 1500); do echo "$i,x,$i"; done >> sync.csv
+
+    run dolt table import --sync composite sync.csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Rows Processed: 1,000, Additions: 500, Modifications: 0, Had No Effect: 500, Deletions: 500" ]] || false
+
+    run dolt sql -r csv -q "SELECT count(*), min(a), max(a) FROM composite"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "1000,501,1500" ]
+}
+
+@test "import-sync-tables: sync requires every column" {
+    cat <<CSV > sync.csv
+pk,name
+1,one
+CSV
+
+    run dolt table import --sync test sync.csv
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "sync imports require every column of table test, missing columns: payload" ]] || false
+
+    run dolt sql -r csv -q "SELECT count(*) FROM test"
+    [ "${lines[1]}" = "3" ]
+}
+
+@test "import-sync-tables: sync doesn't require generated columns" {
+    dolt sql -q "CREATE TABLE gen (pk int primary key, a int, doubled int as (a * 2) stored)"
+    dolt sql -q "INSERT INTO gen (pk, a) VALUES (1, 1), (2, 2)"
+    dolt commit -Am "generated rows"
+    cat <<CSV > sync.csv
+pk,a
+1,1
+2,2
+3,3
+CSV
+
+    run dolt table import --sync gen sync.csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2 Rows Unmodified" ]] || false
+    [[ "$output" =~ "1 Row Added" ]] || false
+
+    run dolt sql -r csv -q "SELECT * FROM gen ORDER BY pk"
+    [ "${lines[3]}" = "3,3,6" ]
+}
+
+@test "import-sync-tables: sync reports the lines of bad rows" {
+    cat <<CSV > sync.csv
+pk,name,payload
+1,one,
+,nokey,
+CSV
+    run dolt table import --sync test sync.csv
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "(on line 3)" ]] || false
+
+    echo '{"rows": [{"pk": 1, "name": "one"}, {"pk": null, "name": "nokey"}]}' > sync.json
+    run dolt table import --sync test sync.json
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "(on line 2)" ]] || false
+}
+
+@test "import-sync-tables: sync rejects values out of range" {
+    dolt sql -q "CREATE TABLE small (pk int primary key, v tinyint)"
+    cat <<CSV > sync.csv
+pk,v
+1,1000
+CSV
+
+    run dolt table import --sync small sync.csv
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "1000 out of range for tinyint" ]] || false
+}
+
+@test "import-sync-tables: sync skips bad rows with --continue" {
+    cat <<CSV > sync.csv
+pk,name,payload
+1,one,"{""a"": 1}"
+,nokey,
+CSV
+
+    run dolt table import --sync test sync.csv
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "primary key column pk cannot be NULL" ]] || false
+
+    run dolt table import --sync --continue test sync.csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Lines skipped: 1" ]] || false
+
+    run dolt sql -r csv -q "SELECT pk FROM test"
+    [ "${#lines[@]}" -eq 2 ]
+    [ "${lines[1]}" = "1" ]
+}
+
+@test "import-sync-tables: sync doesn't support --bad-rows" {
+    echo "pk,name,payload" > sync.csv
+
+    run dolt table import --sync --continue --bad-rows bad.csv test sync.csv
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "--bad-rows is not supported with --sync" ]] || false
+    [ ! -f bad.csv ]
+
+    run dolt sql -r csv -q "SELECT count(*) FROM test"
+    [ "${lines[1]}" = "3" ]
+}
+
+@test "import-sync-tables: sync respects foreign keys" {
+    dolt sql -q "CREATE TABLE child (id int primary key, parent int, FOREIGN KEY (parent) REFERENCES test (pk))"
+    dolt sql -q "INSERT INTO child VALUES (1, 3)"
+    echo "pk,name,payload" > sync.csv
+
+    run dolt table import --sync test sync.csv
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "cannot delete or update a parent row" ]] || false
+
+    run dolt sql -r csv -q "SELECT count(*) FROM test"
+    [ "${lines[1]}" = "3" ]
+}
+
+@test "import-sync-tables: sync is exclusive with other operations" {
+    run dolt table import --sync -u test sync.csv
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "Must specify exactly one of -c, -u, -a, -r, or --sync." ]] || false
+}
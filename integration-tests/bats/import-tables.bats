@@ -14,13 +14,13 @@ teardown() {
     run dolt table import t test.csv
 
     [ "$status" -eq 1 ]
-    [[ "$output" =~ "Must specify exactly one of -c, -u, -a, or -r." ]] || false
+    [[ "$output" =~ "Must specify exactly one of -c, -u, -a, -r, or --sync." ]] || false
 }
 
 @test "import-tables: error if multiple operations are provided" {
     run dolt table import -c -u -r t test.csv
     [ "$status" -eq 1 ]
-    [[ "$output" =~ "Must specify exactly one of -c, -u, -a, or -r." ]] || false
+    [[ "$output" =~ "Must specify exactly one of -c, -u, -a, -r, or --sync." ]] || false
 }
 
 @test "import-tables: import tables where field names need to be escaped" {
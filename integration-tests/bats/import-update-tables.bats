@@ -1486,3 +1486,94 @@ DELIM
     [ "$status" -eq 1 ]
     [[ "$output" =~ "fatal: --all-text is only supported for create operations" ]] || false
 }
+
+@test "import-update-tables: --bad-rows writes skipped rows with their lines and errors" {
+    dolt sql <<SQL
+CREATE TABLE parent (id int primary key);
+INSERT INTO parent VALUES (1);
+CREATE TABLE t (
+    pk int primary key,
+    doc json,
+    parent_id int,
+    FOREIGN KEY (parent_id) REFERENCES parent (id)
+);
+SQL
+
+    cat <<DELIM > updates.csv
+pk,doc,parent_id
+1,"{""a"": 1}",1
+4,
+2,not json,1
+3,,9
+5,[],
+DELIM
+
+    run dolt table import -u --continue --bad-rows bad.csv t updates.csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Lines skipped: 3" ]] || false
+    [[ "$output" =~ "Skipped rows written to bad.csv" ]] || false
+
+    run cat bad.csv
+    [ "${lines[0]}" = "dolt_line,dolt_error,pk,doc,parent_id" ]
+    [[ "$output" =~ "3,\"CSV reader expected 3 values, but saw 2." ]] || false
+    [[ "$output" =~ "4,Invalid JSON text: invalid character 'o' in literal null (expecting 'u'),2,not json,1" ]] || false
+    [[ "$output" =~ '5,"cannot add or update a child row - Foreign key violation' ]] || false
+    [[ "$output" =~ ",3,,9" ]] || false
+
+    run dolt sql -r csv -q "SELECT pk FROM t ORDER BY pk"
+    [ "${#lines[@]}" -eq 3 ]
+
+    # the skipped rows can be fixed and imported again
+    dolt sql -q "INSERT INTO parent VALUES (9)"
+    cat <<DELIM > fixed.csv
+pk,doc,parent_id
+2,"""not json""",1
+3,,9
+4,,
+DELIM
+    run dolt table import -u --continue --bad-rows bad.csv t fixed.csv
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "Lines skipped" ]] || false
+
+    run cat bad.csv
+    [ "${#lines[@]}" -eq 1 ]
+
+    run dolt sql -r csv -q "SELECT pk FROM t ORDER BY pk"
+    [ "${#lines[@]}" -eq 6 ]
+}
+
+@test "import-update-tables: --bad-rows writes jsonl files" {
+    dolt sql -q "CREATE TABLE t (pk int primary key, c1 varchar(20))"
+    dolt sql -q "INSERT INTO t VALUES (1, 'one')"
+
+    cat <<DELIM > append.csv
+pk,c1
+1,uno
+2,two
+DELIM
+
+    run dolt table import -a --continue --quiet --bad-rows bad.jsonl t append.csv
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "The following rows were skipped:" ]] || false
+    [[ "$output" =~ "Lines skipped: 1" ]] || false
+
+    run cat bad.jsonl
+    [ "${#lines[@]}" -eq 1 ]
+    [[ "$output" =~ '"dolt_line":2' ]] || false
+    [[ "$output" =~ '"c1":"uno"' ]] || false
+    [[ "$output" =~ '"pk":"1"' ]] || false
+    [[ "$output" =~ "duplicate primary key" ]] || false
+}
+
+@test "import-update-tables: --bad-rows requires --continue and a supported format" {
+    dolt sql -q "CREATE TABLE t (pk int primary key)"
+    echo "pk" > in.csv
+
+    run dolt table import -u --bad-rows bad.csv t in.csv
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "--bad-rows requires --continue" ]] || false
+
+    run dolt table import -u --continue --bad-rows bad.json t in.csv
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "the --bad-rows file must have a .csv, .psv, or .jsonl extension" ]] || false
+}
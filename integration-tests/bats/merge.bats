@@ -1360,3 +1360,212 @@ SQL
     run dolt merge b1
     log_status_eq 0
 }
+
+@test "merge: -X theirs and -X ours resolve conflicting rows" {
+    dolt sql -q "insert into test1 values (1, 1, 1)"
+    dolt commit -am "base row"
+    dolt branch other
+
+    dolt sql -q "update test1 set c1 = 10 where pk = 1"
+    dolt commit -am "ours"
+
+    dolt checkout other
+    dolt sql -q "update test1 set c1 = 20 where pk = 1; insert into test1 values (2, 2, 2)"
+    dolt commit -am "theirs"
+    dolt checkout main
+    dolt branch before_merge
+
+    run dolt merge -X theirs other -m "merge theirs"
+    log_status_eq 0
+    run dolt sql -q "select * from test1 order by pk" -r csv
+    [[ "$output" =~ "1,20,1" ]] || false
+    [[ "$output" =~ "2,2,2" ]] || false
+    run dolt sql -q "select count(*) from dolt_conflicts"
+    [[ "$output" =~ "| 0 " ]] || false
+
+    dolt reset --hard before_merge
+    run dolt merge -X ours other -m "merge ours"
+    log_status_eq 0
+    run dolt sql -q "select * from test1 order by pk" -r csv
+    [[ "$output" =~ "1,10,1" ]] || false
+    [[ "$output" =~ "2,2,2" ]] || false
+
+    dolt reset --hard before_merge
+    run dolt sql -q "call dolt_merge('-X', 'theirs', 'other')" -r csv
+    log_status_eq 0
+    run dolt sql -q "select c1 from test1 where pk = 1" -r csv
+    [[ "$output" =~ "20" ]] || false
+}
+
+@test "merge: -s ours keeps every table from the current branch" {
+    dolt sql -q "insert into test1 values (1, 1, 1)"
+    dolt commit -am "base row"
+    dolt branch other
+
+    dolt sql -q "update test1 set c1 = 10 where pk = 1"
+    dolt commit -am "ours"
+
+    dolt checkout other
+    dolt sql -q "update test1 set c1 = 20 where pk = 1; insert into test2 values (1, 1, 1)"
+    dolt commit -am "theirs"
+    dolt checkout main
+
+    run dolt merge -s ours other -m "merge with ours"
+    log_status_eq 0
+
+    run dolt sql -q "select * from test1" -r csv
+    [[ "$output" =~ "1,10,1" ]] || false
+    run dolt sql -q "select count(*) from test2" -r csv
+    [[ "$output" =~ "0" ]] || false
+
+    run dolt log -n 1
+    [[ "$output" =~ "Merge:" ]] || false
+    [[ "$output" =~ "merge with ours" ]] || false
+
+    run dolt merge other
+    log_status_eq 0
+    [[ "$output" =~ "Everything up-to-date" ]] || false
+}
+
+@test "merge: -s ours does not fast-forward" {
+    dolt sql -q "insert into test1 values (1, 1, 1)"
+    dolt commit -am "base row"
+    dolt branch other
+
+    dolt checkout other
+    dolt sql -q "update test1 set c1 = 20 where pk = 1; insert into test2 values (1, 1, 1)"
+    dolt commit -am "theirs"
+    dolt checkout main
+
+    run dolt merge -s ours other -m "merge with ours"
+    log_status_eq 0
+    [[ ! "$output" =~ "Fast-forward" ]] || false
+
+    run dolt sql -q "select * from test1" -r csv
+    [[ "$output" =~ "1,1,1" ]] || false
+    run dolt sql -q "select count(*) from test2" -r csv
+    [[ "$output" =~ "0" ]] || false
+    run dolt diff HEAD~1 HEAD
+    log_status_eq 0
+    [ "$output" = "" ]
+
+    run dolt log -n 1
+    [[ "$output" =~ "Merge:" ]] || false
+    [[ "$output" =~ "merge with ours" ]] || false
+
+    dolt reset --hard HEAD~1
+    run dolt sql -q "call dolt_merge('-s', 'ours', 'other')" -r csv
+    log_status_eq 0
+    run dolt sql -q "select c1 from test1 where pk = 1" -r csv
+    [[ "$output" =~ "1" ]] || false
+    [[ ! "$output" =~ "20" ]] || false
+}
+
+@test "merge: invalid merge strategies" {
+    dolt branch other
+
+    run dolt merge -s octopus other
+    log_status_eq 1
+    [[ "$output" =~ "unknown merge strategy 'octopus'" ]] || false
+
+    run dolt merge -X patience other
+    log_status_eq 1
+    [[ "$output" =~ "unknown merge strategy option 'patience'" ]] || false
+
+    run dolt merge -s ours -X theirs other
+    log_status_eq 1
+    [[ "$output" =~ "cannot be used with merge strategy 'ours'" ]] || false
+
+    run dolt sql -q "insert into dolt_merge_strategies values ('test1', 'octopus')"
+    log_status_eq 1
+}
+
+@test "merge: dolt_merge_strategies sets the strategy for each table" {
+    dolt sql -q "insert into test1 values (1, 1, 1), (2, 2, 2); insert into test2 values (1, 1, 1)"
+    dolt sql -q "insert into dolt_merge_strategies values ('test1', 'theirs'), ('TEST2', 'merge-ours')"
+    run dolt sql -q "select * from dolt_merge_strategies order by table_name" -r csv
+    [[ "$output" =~ "TEST2,merge-ours" ]] || false
+    [[ "$output" =~ "test1,theirs" ]] || false
+    dolt commit -Am "base rows and strategies"
+    dolt branch other
+
+    dolt sql -q "update test1 set c1 = 10 where pk = 1; insert into test1 values (3, 3, 3); update test2 set c1 = 10 where pk = 1"
+    dolt commit -am "ours"
+
+    dolt checkout other
+    dolt sql -q "update test1 set c1 = 20 where pk = 1; delete from test1 where pk = 2; update test2 set c1 = 20, c2 = 20 where pk = 1"
+    dolt commit -am "theirs"
+    dolt checkout main
+
+    run dolt merge other -m "merge"
+    log_status_eq 0
+
+    # test1 is taken from other as is
+    run dolt sql -q "select * from test1 order by pk" -r csv
+    [ "${lines[1]}" = "1,20,1" ]
+    [ "${#lines[@]}" -eq 2 ]
+
+    # test2 is merged, keeping our version of the conflicting row
+    run dolt sql -q "select * from test2" -r csv
+    [[ "$output" =~ "1,10,1" ]] || false
+    run dolt sql -q "select count(*) from dolt_conflicts" -r csv
+    [[ "$output" =~ "0" ]] || false
+}
+
+@test "merge: dolt_conflict_resolvers resolves concurrent column changes" {
+    dolt sql <<SQL
+create table telemetry (id int primary key, hits int, last_seen datetime, tags json, note varchar(20));
+insert into telemetry values (1, 10, '2025-01-01 00:00:00', '["a"]', 'base');
+insert into dolt_conflict_resolvers values
+    ('telemetry', 'hits', 'ours + theirs - base'),
+    ('telemetry', 'last_seen', 'greatest(ours, theirs)'),
+    ('TELEMETRY', 'Tags', 'json_array_union(ours, theirs)');
+SQL
+    dolt commit -Am "telemetry and resolvers"
+    dolt branch other
+
+    dolt sql -q "update telemetry set hits = 15, last_seen = '2025-03-01', tags = '[\"a\", \"b\"]'"
+    dolt commit -am "ours"
+
+    dolt checkout other
+    dolt sql -q "update telemetry set hits = 12, last_seen = '2025-02-01', tags = '[\"a\", \"c\"]'"
+    dolt commit -am "theirs"
+    dolt checkout main
+
+    run dolt merge other -m "merge"
+    log_status_eq 0
+    run dolt sql -q "select hits, last_seen, tags from telemetry" -r csv
+    [[ "$output" =~ '17,2025-03-01 00:00:00,"[""a"",""b"",""c""]"' ]] || false
+
+    # columns without a resolver still conflict
+    dolt reset --hard HEAD~1
+    dolt sql -q "update telemetry set note = 'ours'"
+    dolt commit -am "ours note"
+    dolt checkout other
+    dolt sql -q "update telemetry set note = 'theirs'"
+    dolt commit -am "theirs note"
+    dolt checkout main
+
+    run dolt merge other -m "merge"
+    log_status_eq 1
+    [[ "$output" =~ "CONFLICT (content)" ]] || false
+    run dolt sql -q "select our_hits, their_hits, our_note, their_note from dolt_conflicts_telemetry" -r csv
+    [[ "$output" =~ "15,12,ours,theirs" ]] || false
+}
+
+@test "merge: invalid conflict resolver" {
+    dolt sql -q "insert into test1 values (1, 1, 1)"
+    dolt sql -q "insert into dolt_conflict_resolvers values ('test1', 'c1', 'greatest(ours,')"
+    dolt commit -Am "bad resolver"
+    dolt branch other
+    dolt sql -q "update test1 set c1 = 10"
+    dolt commit -am "ours"
+    dolt checkout other
+    dolt sql -q "update test1 set c1 = 20"
+    dolt commit -am "theirs"
+    dolt checkout main
+
+    run dolt merge other -m "merge"
+    log_status_eq 1
+    [[ "$output" =~ "invalid conflict resolver 'greatest(ours,' for column 'c1' of table 'test1'" ]] || false
+}
@@ -0,0 +1,195 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql <<SQL
+CREATE TABLE test (pk int PRIMARY KEY, c1 varchar(20), j json, b varbinary(10));
+INSERT INTO test VALUES (1, 'one', '{"a": 1}', 0x01), (2, 'two', NULL, NULL), (3, 'three', '[]', 0xff);
+CREATE TABLE keyless (v int);
+INSERT INTO keyless VALUES (1), (1), (2);
+SQL
+    dolt add -A
+    dolt commit -m "initial tables"
+    dolt branch base
+
+    dolt checkout -b changes
+    dolt sql <<SQL
+UPDATE test SET c1 = 'uno', j = '{"a": 2}' WHERE pk = 1;
+DELETE FROM test WHERE pk = 2;
+INSERT INTO test VALUES (4, 'four', '"s"', 0x0a0b);
+ALTER TABLE test ADD COLUMN c2 int;
+UPDATE test SET c2 = 7 WHERE pk = 3;
+DELETE FROM keyless WHERE v = 1 LIMIT 1;
+INSERT INTO keyless VALUES (3);
+CREATE TABLE added (id int PRIMARY KEY);
+INSERT INTO added VALUES (1), (2);
+CREATE VIEW test_view AS SELECT pk FROM test;
+SQL
+    dolt add -A
+    dolt commit -m "changes"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "patch: diff -r patch output" {
+    run dolt diff -r patch base changes
+    [ "$status" -eq 0 ]
+    [ "${lines[0]}" = 'dolt-patch {"version":1}' ]
+    [[ "$output" =~ 'table {"from":"test","to":"test","diff_type":"modified"}' ]] || false
+    [[ "$output" =~ '"statements":["ALTER TABLE `test` ADD `c2` int;"]' ]] || false
+    [[ "$output" =~ 'update {"key":["1"],"old":["1","one","{\"a\":1}","01",null],"new":["1","uno","{\"a\":2}","01",null]}' ]] || false
+    [[ "$output" =~ 'delete {"key":["2"],"old":["2","two",null,null,null]}' ]] || false
+    [[ "$output" =~ 'insert {"key":["4"],"new":["4","four","\"s\"","0a0b",null]}' ]] || false
+    [[ "$output" =~ 'table {"from":"","to":"added","diff_type":"added"}' ]] || false
+    [[ "$output" =~ 'view {"name":"test_view","from":"","to":' ]] || false
+    [[ "${lines[-1]}" =~ ^end\ \{\"records\":[0-9]+,\"checksum\":\"[0-9a-f]{64}\"\}$ ]] || false
+}
+
+@test "patch: apply a patch" {
+    dolt diff -r patch base changes > changes.patch
+    dolt checkout base
+
+    run dolt apply --check changes.patch
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Patch applies cleanly." ]] || false
+    run dolt status
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+
+    run dolt apply changes.patch
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Applied patch: 3 schema changes, 4 rows inserted, 2 rows updated, 2 rows deleted" ]] || false
+
+    dolt add -A
+    run dolt diff --stat changes
+    [ "$status" -eq 0 ]
+    [ "$output" = "" ]
+
+    # applying the patch again skips the changes which are already applied
+    run dolt apply --check changes.patch
+    [ "$status" -eq 0 ]
+}
+
+@test "patch: apply a patch from stdin" {
+    dolt diff -r patch base changes -- test > changes.patch
+    dolt checkout base
+
+    run dolt apply < changes.patch
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1 schema change, 1 row inserted, 2 rows updated, 1 row deleted" ]] || false
+
+    run dolt sql -q "SELECT pk, c1, c2 FROM test ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "1,uno," ]
+    [ "${lines[2]}" = "3,three,7" ]
+    [ "${lines[3]}" = "4,four," ]
+}
+
+@test "patch: apply keeps changes to other columns" {
+    dolt diff -r patch base changes -- test > changes.patch
+    dolt checkout base
+    dolt sql -q "UPDATE test SET j = '[1]' WHERE pk = 3"
+    dolt commit -am "change j"
+
+    run dolt apply changes.patch
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q "SELECT j, c2 FROM test WHERE pk = 3" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "[1],7" ]
+}
+
+@test "patch: apply reports conflicts and changes nothing" {
+    dolt diff -r patch base changes > changes.patch
+    dolt checkout base
+    dolt sql -q "UPDATE test SET c1 = 'eins' WHERE pk = 1"
+    dolt sql -q "UPDATE test SET c1 = 'deux' WHERE pk = 2"
+    dolt sql -q "INSERT INTO test VALUES (4, 'vier', NULL, NULL)"
+    dolt commit -am "conflicting changes"
+
+    run dolt apply changes.patch
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "conflict: table test: row ('1'): column c1 was changed from 'one' to 'uno', but it is 'eins'" ]] || false
+    [[ "$output" =~ "conflict: table test: row ('2') was deleted, but it has been modified" ]] || false
+    [[ "$output" =~ "conflict: table test: row ('4') was inserted, but a different row with the same key exists" ]] || false
+    [[ "$output" =~ "error: patch does not apply, 3 conflicts" ]] || false
+
+    run dolt sql -q "SHOW TABLES"
+    [[ ! "$output" =~ "added" ]] || false
+    run dolt sql -q "SELECT c1 FROM test WHERE pk = 1" -r csv
+    [ "${lines[1]}" = "eins" ]
+    run dolt status
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+}
+
+@test "patch: apply reports schema conflicts" {
+    dolt diff -r patch base changes -- test > changes.patch
+    dolt checkout base
+    dolt sql -q "ALTER TABLE test ADD COLUMN c3 int"
+    dolt commit -am "add c3"
+
+    run dolt apply changes.patch
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "conflict: schema of table test has changed" ]] || false
+    [[ "$output" =~ "1 conflict" ]] || false
+}
+
+@test "patch: apply rejects invalid patches" {
+    echo "not a patch" > bad.patch
+    run dolt apply bad.patch
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "not a dolt patch" ]] || false
+
+    run dolt apply missing.patch
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "could not open patch 'missing.patch'" ]] || false
+}
+
+@test "patch: apply rejects truncated patches and changes nothing" {
+    dolt diff -r patch base changes > changes.patch
+    dolt checkout base
+    head -n 5 changes.patch > truncated.patch
+
+    run dolt apply truncated.patch
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "patch is truncated: missing 'end' record" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+    run dolt sql -q "SHOW CREATE TABLE test"
+    [[ ! "$output" =~ "c2" ]] || false
+    run dolt branch
+    [[ ! "$output" =~ "dolt_apply" ]] || false
+
+    # a changed record doesn't match the checksum
+    sed 's/"uno"/"une"/' changes.patch > corrupted.patch
+    run dolt apply corrupted.patch
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "patch checksum does not match" ]] || false
+}
+
+@test "patch: apply requires the changed tables to be committed" {
+    dolt diff -r patch base changes -- test > changes.patch
+    dolt checkout base
+    dolt sql -q "INSERT INTO test VALUES (5, 'five', NULL, NULL)"
+
+    run dolt apply changes.patch
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "table test has uncommitted changes" ]] || false
+    run dolt sql -q "SHOW CREATE TABLE test"
+    [[ ! "$output" =~ "c2" ]] || false
+
+    # uncommitted changes to other tables are kept
+    dolt checkout test
+    dolt sql -q "INSERT INTO keyless VALUES (5)"
+    run dolt apply changes.patch
+    [ "$status" -eq 0 ]
+    run dolt sql -q "SELECT count(*) FROM keyless WHERE v = 5" -r csv
+    [ "${lines[1]}" = "1" ]
+    run dolt status
+    [[ "$output" =~ "Changes not staged for commit" ]] || false
+    [[ ! "$output" =~ "Changes to be committed" ]] || false
+}
@@ -0,0 +1,186 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    cat <<CSV > people.csv
+id,name,age
+1,alice,30
+2,bob,
+CSV
+    cat <<PSV > pets.psv
+id|owner|kind
+10|1|cat
+11|2|dog
+PSV
+}
+
+teardown() {
+    stop_sql_server
+    teardown_common
+}
+
+@test "read-file: dolt_read_file and dolt_read_csv read delimited files" {
+    run dolt sql -r csv -q "SELECT * FROM dolt_read_file('people.csv') ORDER BY id"
+    [ "$status" -eq 0 ]
+    [ "${lines[0]}" = "id,name,age" ]
+    [ "${lines[1]}" = "1,alice,30" ]
+    [ "${lines[2]}" = "2,bob," ]
+
+    run dolt sql -r csv -q "SELECT p.name, q.kind FROM dolt_read_file('people.csv') p JOIN dolt_read_csv('pets.psv', '|') q ON p.id = q.owner ORDER BY p.id"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "alice,cat" ]
+    [ "${lines[2]}" = "bob,dog" ]
+
+    run dolt sql -r csv -q "SELECT kind FROM dolt_read_file('pets.psv') WHERE id = 11"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "dog" ]
+}
+
+@test "read-file: insert the transformed rows of a file" {
+    dolt sql <<SQL
+CREATE TABLE people (id int PRIMARY KEY, name varchar(20), age int);
+INSERT INTO people SELECT id, upper(name), nullif(age, '') FROM dolt_read_file('people.csv');
+SQL
+    run dolt sql -r csv -q "SELECT * FROM people ORDER BY id"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "1,ALICE,30" ]
+    [ "${lines[2]}" = "2,BOB," ]
+}
+
+@test "read-file: dolt_read_file reads typed columns from a parquet file" {
+    dolt sql <<SQL
+CREATE TABLE t (id int PRIMARY KEY, d date, f double, c decimal(10,2), s varchar(20));
+INSERT INTO t VALUES (1, '2020-01-02', 1.5, 12.34, 'hi'), (2, NULL, NULL, NULL, NULL);
+SQL
+    dolt table export t t.parquet
+
+    run dolt sql -r csv -q "SELECT id, d, f, c, s FROM dolt_read_file('t.parquet') ORDER BY id"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "1,2020-01-02 00:00:00,1.5,12.34,hi" ]
+    [ "${lines[2]}" = "2,,,," ]
+
+    dolt sql -q "CREATE TABLE t2 LIKE t; INSERT INTO t2 SELECT * FROM dolt_read_file('t.parquet', 'parquet')"
+    run dolt sql -r csv -q "SELECT * FROM t2 ORDER BY id"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "1,2020-01-02,1.5,12.34,hi" ]
+}
+
+@test "read-file: external tables are read from the files in dolt_external_tables" {
+    dolt sql -q "INSERT INTO dolt_external_tables VALUES ('ext_people', 'people.csv', NULL, NULL), ('ext_pets', 'pets.psv', 'csv', '|')"
+    dolt add dolt_external_tables
+    dolt commit -m "add external tables"
+
+    run dolt sql -r csv -q "SELECT p.name, q.kind FROM ext_people p JOIN ext_pets q ON p.id = q.owner ORDER BY p.id"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "alice,cat" ]
+    [ "${lines[2]}" = "bob,dog" ]
+
+    # rows are read from the file each time the table is queried
+    echo "3,carol,41" >> people.csv
+    run dolt sql -r csv -q "SELECT count(*) FROM ext_people"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "3" ]
+
+    run dolt sql -q "INSERT INTO ext_people VALUES (4, 'dan', 20)"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "doesn't support INSERT INTO" ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+}
+
+@test "read-file: dolt_external_tables is written on the branch it's qualified with" {
+    dolt branch other
+    dolt sql -q "INSERT INTO \`$(basename $PWD)/other\`.dolt_external_tables VALUES ('ext_people', 'people.csv', NULL, NULL)"
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+
+    dolt checkout other
+    run dolt sql -r csv -q "SELECT count(*) FROM ext_people"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "2" ]
+}
+
+@test "read-file: reading files requires the FILE privilege" {
+    dolt sql -q "INSERT INTO dolt_external_tables VALUES ('ext_people', 'people.csv', NULL, NULL)"
+    dolt sql -q "CREATE USER reader@'localhost'; GRANT SELECT ON *.* TO reader@'localhost'"
+
+    run dolt --user=reader sql -q "SELECT * FROM dolt_read_file('people.csv')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "command denied to user" ]] || false
+
+    run dolt --user=reader sql -q "SELECT * FROM ext_people"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "external table ext_people: command denied to user" ]] || false
+
+    dolt sql -q "GRANT FILE ON *.* TO reader@'localhost'"
+    run dolt --user=reader sql -r csv -q "SELECT count(*) FROM ext_people"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "2" ]
+}
+
+@test "read-file: sql-server: files aren't read unless secure_file_priv is set" {
+    dolt sql -q "INSERT INTO dolt_external_tables VALUES ('ext_people', 'people.csv', NULL, NULL)"
+    start_sql_server
+
+    run dolt sql -q "SELECT * FROM dolt_read_file('people.csv')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "secure_file_priv system variable is set" ]] || false
+
+    run dolt sql -q "SELECT * FROM ext_people"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "secure_file_priv system variable is set" ]] || false
+}
+
+@test "read-file: files linked to from outside of secure_file_priv aren't read" {
+    outside=$(mktemp -d)
+    echo "secret" > "$outside/secret.csv"
+    ln -s "$outside/secret.csv" link.csv
+
+    run dolt sql -q "SELECT * FROM dolt_read_file('link.csv')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "the file link.csv is not in the secure_file_priv directory" ]] || false
+    rm -rf "$outside"
+}
+
+@test "read-file: external tables defined in the same session are resolved" {
+    run dolt sql -r csv <<SQL
+INSERT INTO dolt_external_tables VALUES ('ext_people', 'people.csv', NULL, NULL);
+SELECT count(*) FROM ext_people;
+INSERT INTO dolt_external_tables VALUES ('ext_pets', 'pets.psv', NULL, NULL);
+SELECT count(*) FROM ext_pets;
+DELETE FROM dolt_external_tables WHERE table_name = 'ext_people';
+SELECT count(*) FROM ext_people;
+SQL
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "table not found: ext_people" ]] || false
+    [ "$(echo "$output" | grep -c '^2$')" -eq 2 ]
+}
+
+@test "read-file: errors" {
+    run dolt sql -q "SELECT * FROM dolt_read_file('missing.csv')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "failed to read missing.csv" ]] || false
+
+    run dolt sql -q "SELECT * FROM dolt_read_file('people.csv', 'xlsx')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "unsupported file format 'xlsx'" ]] || false
+
+    run dolt sql -q "SELECT * FROM dolt_read_file(concat('people', '.csv'))"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "only literal values supported" ]] || false
+
+    run dolt sql -q "SELECT * FROM dolt_read_csv('people.csv', ',', 'x')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "expected 1 or 2 arguments" ]] || false
+
+    dolt sql -q "INSERT INTO dolt_external_tables VALUES ('ext', 'missing.parquet', NULL, NULL)"
+    run dolt sql -q "SELECT * FROM ext"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "external table ext: failed to read missing.parquet" ]] || false
+}
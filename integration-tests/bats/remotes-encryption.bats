@@ -0,0 +1,76 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    head -c 32 /dev/urandom | base64 > "$BATS_TMPDIR/remote-key-$$"
+    dolt sql -q "create table t (pk int primary key, c1 varchar(40))"
+    dolt sql -q "insert into t values (1, 'plaintext-canary-value')"
+    dolt commit -Am "cm1"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+    rm -rf "$BATS_TMPDIR/remote-key-$$" "$BATS_TMPDIR/remote-$$" "$BATS_TMPDIR/clone-$$"
+}
+
+@test "remotes-encryption: pushed data is encrypted" {
+    mkdir "$BATS_TMPDIR/remote-$$"
+    dolt remote add --encryption-key-file "$BATS_TMPDIR/remote-key-$$" origin "file://$BATS_TMPDIR/remote-$$"
+    dolt push origin main
+
+    run grep -r "plaintext-canary-value" "$BATS_TMPDIR/remote-$$"
+    [ "$status" -eq 1 ]
+
+    run dolt remote -v
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "encryption-key-file" ]] || false
+}
+
+@test "remotes-encryption: clone, push and pull an encrypted remote" {
+    repo=$(pwd)
+    mkdir "$BATS_TMPDIR/remote-$$"
+    dolt remote add --encryption-key-file "$BATS_TMPDIR/remote-key-$$" origin "file://$BATS_TMPDIR/remote-$$"
+    dolt push origin main
+
+    cd "$BATS_TMPDIR"
+    dolt clone --encryption-key-file "$BATS_TMPDIR/remote-key-$$" "file://$BATS_TMPDIR/remote-$$" "clone-$$"
+    cd "clone-$$"
+    run dolt sql -q "select c1 from t" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "plaintext-canary-value" ]] || false
+
+    dolt sql -q "insert into t values (2, 'second-canary-value')"
+    dolt commit -am "cm2"
+    dolt push origin main
+    run grep -r "second-canary-value" "$BATS_TMPDIR/remote-$$"
+    [ "$status" -eq 1 ]
+
+    cd "$repo"
+    dolt pull origin main
+    run dolt sql -q "select c1 from t order by pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "second-canary-value" ]] || false
+}
+
+@test "remotes-encryption: encrypted remotes can't be cloned with the wrong key" {
+    mkdir "$BATS_TMPDIR/remote-$$"
+    dolt remote add --encryption-key-file "$BATS_TMPDIR/remote-key-$$" origin "file://$BATS_TMPDIR/remote-$$"
+    dolt push origin main
+
+    cd "$BATS_TMPDIR"
+    head -c 32 /dev/urandom | base64 > "$BATS_TMPDIR/remote-key-$$"
+    run dolt clone --encryption-key-file "$BATS_TMPDIR/remote-key-$$" "file://$BATS_TMPDIR/remote-$$" "clone-$$"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "encrypted with a different key" ]] || false
+}
+
+@test "remotes-encryption: invalid keys are rejected" {
+    mkdir "$BATS_TMPDIR/remote-$$"
+    echo "not a key" > "$BATS_TMPDIR/remote-key-$$"
+    dolt remote add --encryption-key-file "$BATS_TMPDIR/remote-key-$$" origin "file://$BATS_TMPDIR/remote-$$"
+    run dolt push origin main
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "invalid remote encryption key" ]] || false
+}
@@ -410,3 +410,57 @@ CSV
     [[ "$output" =~ "name" ]] || false
     [[ "$output" =~ "invalid schema" ]] || false
 }
+
+@test "schema-import: --infer-precision chooses decimal and datetime precision" {
+    cat <<CSV > import.csv
+pk,price,created,ratio
+1,12.5,2024-01-02 03:04:05.12,1.5e3
+2,-1234.125,2024-01-02 03:04:05,2.5
+3,,2024-01-03 00:00:01.1,0.5
+CSV
+    run dolt schema import --dry-run -c --pks=pk test import.csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "\`price\` float" ]] || false
+    [[ "$output" =~ "\`created\` datetime(6)" ]] || false
+
+    run dolt schema import --dry-run -c --pks=pk --infer-precision test import.csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "\`price\` decimal(7,3)" ]] || false
+    [[ "$output" =~ "\`created\` datetime(2)" ]] || false
+    [[ "$output" =~ "\`ratio\` float" ]] || false
+}
+
+@test "schema-import: --infer-report prints how each type was chosen" {
+    cat <<CSV > import.csv
+pk,price,name
+1,12.5,abc
+2,-1234.125,
+3,7,x
+CSV
+    run dolt schema import --dry-run -c --pks=pk --infer-precision --infer-report test import.csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "| column | candidate_types | sampled | nulls | max_length | type" ]] || false
+    [[ "$output" =~ "| price  | float, int      | 3       | 0     | 9          | decimal(7,3)  | sampled numbers have at most 4 digits before and 3 digits after the decimal point |" ]] || false
+    [[ "$output" =~ "| name   | varchar(1023)   | 3       | 1     | 3          | varchar(1023) | all sampled values are varchar(1023)" ]] || false
+    [[ "$output" =~ "CREATE TABLE \`test\`" ]] || false
+}
+
+@test "schema-import: --sample infers types from the first rows" {
+    cat <<CSV > import.csv
+pk,val
+1,1
+2,2
+3,abc
+CSV
+    run dolt schema import --dry-run -c --pks=pk test import.csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "\`val\` varchar(1023)" ]] || false
+
+    run dolt schema import --dry-run -c --pks=pk --sample 2 test import.csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "\`val\` int" ]] || false
+
+    run dolt schema import --dry-run -c --pks=pk --sample 0 test import.csv
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "--sample must be a positive number of rows" ]] || false
+}
@@ -122,6 +122,26 @@ assert_has_key_value() {
     [[ "$output" =~ "| + | 4  |" ]] || false
 }
 
+@test "show: markdown diff" {
+    dolt sql -q "create table testtable (pk int PRIMARY KEY, c varchar(10))"
+    dolt sql -q "insert into testtable values (1, 'a'), (2, 'b')"
+    dolt add .
+    dolt commit -m "commit: add table"
+    dolt sql -q "update testtable set c = 'z' where pk = 2"
+    dolt add .
+    dolt commit -m "commit: update row"
+
+    run dolt show -r markdown
+    [ $status -eq 0 ]
+    [[ "$output" =~ "commit: update row" ]] || false
+    [[ "$output" =~ "## testtable" ]] || false
+    [[ "$output" =~ "| \\* | 2 | ~~b~~ **z** |" ]] || false
+
+    run dolt show -r html
+    [ $status -eq 0 ]
+    [[ "$output" =~ '<td class="changed"><del>b</del><ins>z</ins></td>' ]] || false
+}
+
 @test "show: --no-pretty" {
     dolt commit --allow-empty -m "commit: initialize table1"
     run dolt show --no-pretty
@@ -56,6 +56,43 @@ call dolt_commit('-am', 'add some vals');
     [[ "$output" =~ "10" ]] || false
 }
 
+@test "sql-server-remotesrv: can clone and pull a database with a cold tier from sql-server with --remotesapi-port" {
+    mkdir -p db/remote
+    cd db/remote
+    dolt init
+    dolt sql -q 'create table vals (i int);'
+    dolt sql -q 'insert into vals (i) values (1), (2), (3), (4), (5);'
+    dolt add vals
+    dolt commit -m 'initial vals.'
+    dolt config --local --add storage.cold_tier.url "file://$BATS_TMPDIR/cold-$$"
+    dolt config --local --add storage.cold_tier.after 0s
+    dolt gc --full
+    [ -n "$(ls "$BATS_TMPDIR/cold-$$" | grep -E '^[0-9a-v]{32}$')" ]
+
+    dolt sql-server --remotesapi-port 50051 &
+    srv_pid=$!
+
+    cd ../../
+    dolt clone http://localhost:50051/remote repo1
+    cd repo1
+    run dolt sql -q 'select count(*) from vals'
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "5" ]] || false
+    run dolt log --oneline
+    [[ "$output" =~ "initial vals." ]] || false
+
+    dolt -u root --port 3306 --host localhost --no-tls sql -q "
+use remote;
+insert into vals (i) values (6), (7), (8), (9), (10);
+call dolt_commit('-am', 'add some vals');
+"
+
+    dolt pull
+
+    run dolt sql -q 'select count(*) from vals;'
+    [[ "$output" =~ "10" ]] || false
+}
+
 @test "sql-server-remotesrv: can access a created database from sql-server with --remotesapi-port" {
     mkdir -p db/remote
     cd db/remote
@@ -696,3 +733,56 @@ GRANT CLONE_ADMIN ON *.* TO clone_admin_user@'localhost';
     [[ "$output" =~ "main" ]] || false
 }
 
+
+@test "sql-server-remotesrv: push to remotesapi port runs ci workflows" {
+    mkdir remote
+    cd remote
+    dolt init
+    dolt sql -q 'create table names (name varchar(10) primary key);'
+    dolt sql -q 'insert into names (name) values ("abe"), ("betsy"), ("calvin");'
+    dolt sql -q "select * from names;" -s "select names"
+    dolt add .
+    dolt commit -m 'initial names.'
+    cat > workflow.yaml <<YAML
+name: workflow
+on:
+  push:
+    branches:
+      - main
+jobs:
+  - name: check names
+    steps:
+      - name: names has three rows
+        saved_query_name: select names
+        expected_rows: "== 3"
+YAML
+    dolt ci init
+    dolt ci import ./workflow.yaml
+
+    APIPORT=$( definePORT )
+    dolt sql -q "CREATE USER root@'%' identified by 'rootpass'; GRANT ALL ON *.* to root@'%';"
+    export DOLT_REMOTE_PASSWORD="rootpass"
+    export SQL_USER="root"
+    start_sql_server_with_args --remotesapi-port $APIPORT
+
+    cd ../
+    dolt clone http://localhost:$APIPORT/remote cloned_db -u $SQL_USER
+    cd cloned_db
+
+    dolt sql -q 'insert into names values ("dave");'
+    dolt commit -am 'add dave'
+    run dolt push origin --user $SQL_USER main:main
+    [[ "$status" -eq 0 ]] || false
+
+    cd ../remote
+    for i in $(seq 1 20); do
+        run dolt sql -r csv -q "select count(*) from dolt_ci_workflow_runs"
+        [[ "$output" =~ "1" ]] && break
+        sleep 0.5
+    done
+
+    run dolt sql -r csv -q "select workflow_name, event_type, branch, job_name, step_name, status, failure_details from dolt_ci_workflow_runs"
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [[ "$output" =~ "workflow,push,main,check names,names has three rows,failed,\"expected row count == 3, got 4\"" ]] || false
+}
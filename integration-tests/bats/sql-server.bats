@@ -2115,3 +2115,53 @@ EOF
     [[ "$output" =~ "br3  | true" ]] || false
     [[ "$output" =~ "main | false" ]] || false
 }
+
+@test "sql-server: ci workflows run when a matching branch is updated" {
+    cd repo1
+    dolt sql -q "create table t1(pk int primary key);"
+    dolt sql -q "insert into t1 values (1), (2);"
+    dolt sql -q "select * from t1;" -s "select t1"
+    dolt add .
+    dolt commit -m "add t1"
+    cat > workflow.yaml <<EOF
+name: workflow
+on:
+  push:
+    branches:
+      - main
+jobs:
+  - name: check t1
+    steps:
+      - name: t1 has two rows
+        saved_query_name: select t1
+        expected_rows: "== 2"
+EOF
+    dolt ci init
+    dolt ci import ./workflow.yaml
+    dolt branch other
+    start_sql_server repo1
+
+    dolt --use-db "repo1" --branch other sql -q "insert into t1 values (3); call dolt_commit('-am', 'add row on other');"
+    dolt --use-db "repo1" --branch main sql -q "insert into t1 values (4); call dolt_commit('-am', 'add row on main');"
+
+    for i in $(seq 1 20); do
+        run dolt --use-db "repo1" sql -r csv -q "select count(*) from dolt_ci_workflow_runs"
+        [[ "$output" =~ "1" ]] && break
+        sleep 0.5
+    done
+
+    run dolt --use-db "repo1" sql -r csv -q "select workflow_name, event_type, branch, job_name, step_name, status, failure_details from dolt_ci_workflow_runs"
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [[ "$output" =~ "workflow,push,main,check t1,t1 has two rows,failed,\"expected row count == 2, got 3\"" ]] || false
+
+    # runs are not recorded in the working set of the branch, and are kept across restarts
+    run dolt --use-db "repo1" sql -q "select * from dolt_status"
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "dolt_ci_workflow_runs" ]] || false
+    stop_sql_server 1 && sleep 0.5
+    start_sql_server repo1
+    run dolt --use-db "repo1" sql -r csv -q "select count(*) from dolt_ci_workflow_runs"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "1" ]
+}
@@ -2314,6 +2314,25 @@ SQL
     [[ "$output" =~ "2020-02-17 00:00:00" ]] || false
 }
 
+@test "sql: select with jsonl output" {
+    dolt sql -q "create table jsonl_out (a int primary key, b text, j json)"
+    dolt sql -q "insert into jsonl_out values (1, 'x\ny', '{\"k\": [1]}'), (2, null, null)"
+    run dolt sql -r jsonl -q "select * from jsonl_out order by a"
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [ "${lines[0]}" = '{"a":1,"b":"x\ny","j":{"k":[1]}}' ]
+    [ "${lines[1]}" = '{"a":2}' ]
+
+    run dolt sql -r ndjson -q "select * from has_datetimes"
+    [ $status -eq 0 ]
+    [[ "$output" =~ "2020-02-17 00:00:00" ]] || false
+
+    # empty results produce no output
+    run dolt sql -r jsonl -q "select * from has_datetimes where 1 = 0"
+    [ $status -eq 0 ]
+    [ "$output" = "" ]
+}
+
 @test "sql: dolt_version() func" {
     SQL=$(dolt sql -q 'select dolt_version() from dual;' -r csv | tail -n 1)
     CLI=$(dolt version | sed '1p;d' | cut -d " " -f 3)
@@ -42,6 +42,16 @@ func TestAutoGC(t *testing.T) {
 			t.Logf("repo size before final gc: %v", enabled_16)
 			t.Logf("repo size after final gc: %v", final_16)
 		})
+		t.Run("ArchiveOldGen", func(t *testing.T) {
+			var s AutoGCTest
+			s.Enable = true
+			s.ArchiveLevel = 1
+			runAutoGCTest(t, &s, 64, 16)
+			assert.Contains(t, string(s.PrimaryServer.Output.Bytes()), "Successfully archived")
+			archives, err := filepath.Glob(filepath.Join(s.PrimaryDir, ".dolt/noms/oldgen/*.darc"))
+			require.NoError(t, err)
+			assert.NotEmpty(t, archives)
+		})
 		t.Run("ClusterReplication", func(t *testing.T) {
 			var s AutoGCTest
 			s.Enable = true
@@ -72,6 +82,7 @@ func TestAutoGC(t *testing.T) {
 
 type AutoGCTest struct {
 	Enable        bool
+	ArchiveLevel  int
 	PrimaryDir    string
 	PrimaryServer *driver.SqlServer
 	PrimaryDB     *sql.DB
@@ -114,7 +125,8 @@ func (s *AutoGCTest) CreatePrimaryServer(ctx context.Context, t *testing.T, u dr
 behavior:
   auto_gc_behavior:
     enable: %v
-`, s.Enable)
+    archive_level: %v
+`, s.Enable, s.ArchiveLevel)
 
 	var clusterFragment string
 	if s.Replicate {